@@ -13,11 +13,19 @@ import (
 )
 
 func main() {
-	port := flag.Int("port", 19443, "Port to listen on")
+	port := flag.Int("port", incapsula.MockServerDefaultPort, "Port to listen on")
+	seed := flag.String("seed", "", "JSON file with the state to start with, such as a snapshot")
+	snapshot := flag.String("snapshot", "", "JSON file to write the state to on shutdown")
 	flag.Parse()
 
 	// Create the mock server
 	mock := incapsula.NewMockImpervaServer()
+	if *seed != "" {
+		if err := mock.LoadStateFile(*seed); err != nil {
+			log.Fatalf("Failed to load seed: %v", err)
+		}
+		log.Printf("Loaded state from %s", *seed)
+	}
 
 	// Create a new server on the specified port instead of using the httptest server
 	mock.Server.Close() // Close the auto-started httptest server
@@ -50,6 +58,10 @@ func main() {
 	fmt.Printf("export INCAPSULA_BASE_URL_REV_3=http://localhost%s\n", addr)
 	fmt.Printf("export INCAPSULA_BASE_URL_API=http://localhost%s\n", addr)
 	fmt.Printf("export INCAPSULA_CUSTOM_TEST_DOMAIN=.mock.incaptest.com\n")
+	if *port == incapsula.MockServerDefaultPort {
+		log.Println("")
+		log.Println("Or set environment = \"mock\" in the provider block (or export INCAPSULA_ENVIRONMENT=mock) instead of the base URLs.")
+	}
 	log.Println("")
 	log.Println("Press Ctrl+C to stop the server")
 
@@ -60,5 +72,11 @@ func main() {
 	}()
 
 	<-done
+	if *snapshot != "" {
+		if err := mock.SaveStateFile(*snapshot); err != nil {
+			log.Fatalf("Failed to save snapshot: %v", err)
+		}
+		log.Printf("Saved state to %s", *snapshot)
+	}
 	log.Println("Server stopped")
 }
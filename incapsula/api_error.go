@@ -0,0 +1,200 @@
+package incapsula
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Imperva API v1 result codes (the "res" field of the response body)
+const (
+	apiV1ResObjectNotFound       = 2002
+	apiV1ResUnknownAccountID     = 9403
+	apiV1ResAuthenticationFailed = 9411
+	apiV1ResUnknownSiteID        = 9413
+	apiV1ResFeatureNotPermitted  = 9414
+	apiV1ResOperationNotAllowed  = 9415
+)
+
+// apiRoleErrNotFound is the errorCode of the user management API for an unknown role
+const apiRoleErrNotFound = 1047
+
+// APIError is returned by the Client when the Imperva API rejects a request, either with a
+// non successful HTTP status code (API v2/v3) or with a non zero "res" code in the body (API v1).
+// Use errors.As or the IsNotFound / IsPermissionDenied / IsFeatureUnavailable helpers to branch
+// on the kind of failure instead of matching the error text.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+	// Code is the Imperva error code: the v1 "res" value or the code of the first v3 error
+	Code int
+	// Operation is the x-tf-operation value of the failed request, e.g. "read_site"
+	Operation string
+	// ResourceIDs holds the identifiers of the resources the request was made for
+	ResourceIDs []string
+	// Message is the error message returned by the service (res_message or error detail)
+	Message string
+	// Errors holds the errors[] array of API v3 responses
+	Errors []APIErrors
+
+	msg string
+}
+
+func (e *APIError) Error() string {
+	return e.msg
+}
+
+// IsNotFound reports whether the API answered that the requested object does not exist
+func (e *APIError) IsNotFound() bool {
+	if e.StatusCode == http.StatusNotFound {
+		return true
+	}
+	switch e.Code {
+	case apiV1ResObjectNotFound, apiV1ResUnknownSiteID, apiRoleErrNotFound:
+		return true
+	}
+	for _, apiErr := range e.Errors {
+		if apiErr.Status == http.StatusNotFound {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPermissionDenied reports whether the API credentials are not allowed to perform the request
+func (e *APIError) IsPermissionDenied() bool {
+	if e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden {
+		return true
+	}
+	switch e.Code {
+	case apiV1ResAuthenticationFailed, apiV1ResUnknownAccountID, apiV1ResOperationNotAllowed:
+		return true
+	}
+	return false
+}
+
+// IsFeatureUnavailable reports whether the request failed because the feature is not part of the account plan
+func (e *APIError) IsFeatureUnavailable() bool {
+	if e.StatusCode == http.StatusPaymentRequired {
+		return true
+	}
+	return e.Code == apiV1ResFeatureNotPermitted
+}
+
+// IsNotFound reports whether err is an APIError for an object that does not exist
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.IsNotFound()
+}
+
+// IsPermissionDenied reports whether err is an APIError for a request the credentials are not allowed to make
+func IsPermissionDenied(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.IsPermissionDenied()
+}
+
+// IsFeatureUnavailable reports whether err is an APIError for a feature missing from the account plan
+func IsFeatureUnavailable(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.IsFeatureUnavailable()
+}
+
+// newAPIError builds an APIError from a failed response. The error text is built from format and args,
+// so it stays the same as the message previously returned by the calling client method. The status,
+// Imperva error code and message are taken from the response and its body (v1, v2 and v3 formats).
+func newAPIError(resp *http.Response, body []byte, resourceIDs []string, format string, args ...interface{}) *APIError {
+	apiErr := &APIError{
+		ResourceIDs: resourceIDs,
+		msg:         fmt.Sprintf(format, args...),
+	}
+	if resp != nil {
+		apiErr.StatusCode = resp.StatusCode
+		if resp.Request != nil {
+			apiErr.Operation = resp.Request.Header.Get("x-tf-operation")
+		}
+	}
+	apiErr.parseBody(body)
+
+	return apiErr
+}
+
+// parseBody fills the error code and message from a v1 (res/res_message), v2 (message), user management
+// (errorCode) or v3 (errors[]) response body. Bodies in an unknown format are ignored.
+func (e *APIError) parseBody(body []byte) {
+	var parsed struct {
+		Res        interface{}     `json:"res"`
+		ResMessage string          `json:"res_message"`
+		Message    string          `json:"message"`
+		ErrorCode  json.RawMessage `json:"errorCode"`
+		Errors     json.RawMessage `json:"errors"`
+	}
+	if len(body) == 0 || json.Unmarshal(body, &parsed) != nil {
+		return
+	}
+
+	switch res := parsed.Res.(type) {
+	case float64:
+		e.Code = int(res)
+	case string:
+		e.Code, _ = strconv.Atoi(res)
+	}
+	if e.Code == 0 {
+		e.Code = jsonInt(parsed.ErrorCode)
+	}
+	e.Message = parsed.ResMessage
+	if e.Message == "" {
+		e.Message = parsed.Message
+	}
+
+	// Some APIs send the status and code of each error as strings, so they are decoded leniently
+	var errs []struct {
+		Status  json.RawMessage `json:"status"`
+		Id      string          `json:"id"`
+		Code    json.RawMessage `json:"code"`
+		Source  json.RawMessage `json:"source"`
+		Title   string          `json:"title"`
+		Detail  string          `json:"detail"`
+		Message string          `json:"message"`
+	}
+	if len(parsed.Errors) == 0 || json.Unmarshal(parsed.Errors, &errs) != nil {
+		return
+	}
+	for _, apiErr := range errs {
+		detail := apiErr.Detail
+		if detail == "" {
+			detail = apiErr.Message
+		}
+		var source map[string]string
+		json.Unmarshal(apiErr.Source, &source)
+		e.Errors = append(e.Errors, APIErrors{Status: jsonInt(apiErr.Status), Id: apiErr.Id, Code: jsonInt(apiErr.Code), Source: source, Title: apiErr.Title, Detail: detail})
+	}
+	if len(e.Errors) > 0 {
+		if e.Code == 0 {
+			e.Code = e.Errors[0].Code
+		}
+		if e.Message == "" {
+			e.Message = e.Errors[0].Detail
+		}
+		if e.Message == "" {
+			e.Message = e.Errors[0].Title
+		}
+	}
+}
+
+// jsonInt returns the value of a JSON number or numeric string, or 0 for anything else
+func jsonInt(raw json.RawMessage) int {
+	value, _ := strconv.Atoi(strings.Trim(string(raw), `"`))
+	return value
+}
+
+// apiResourceIDs formats the identifiers of the resources a request was made for
+func apiResourceIDs(ids ...interface{}) []string {
+	resourceIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		resourceIDs = append(resourceIDs, fmt.Sprint(id))
+	}
+	return resourceIDs
+}
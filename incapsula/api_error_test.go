@@ -0,0 +1,162 @@
+package incapsula
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAPIErrorV1Body(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusOK}
+	body := []byte(`{"res":9413,"res_message":"Unknown/unauthorized site_id","debug_info":{"id-info":"13008","site_id":"1234"}}`)
+
+	apiErr := newAPIError(resp, body, apiResourceIDs(1234), "Error from Incapsula service for site id %d: %s", 1234, string(body))
+
+	if apiErr.StatusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %d", apiErr.StatusCode)
+	}
+	if apiErr.Code != 9413 {
+		t.Errorf("Expected code 9413, got %d", apiErr.Code)
+	}
+	if apiErr.Message != "Unknown/unauthorized site_id" {
+		t.Errorf("Unexpected message: %s", apiErr.Message)
+	}
+	if len(apiErr.ResourceIDs) != 1 || apiErr.ResourceIDs[0] != "1234" {
+		t.Errorf("Unexpected resource IDs: %v", apiErr.ResourceIDs)
+	}
+	if !strings.HasPrefix(apiErr.Error(), "Error from Incapsula service for site id 1234: ") {
+		t.Errorf("Unexpected error text: %s", apiErr.Error())
+	}
+	if !apiErr.IsNotFound() || apiErr.IsPermissionDenied() || apiErr.IsFeatureUnavailable() {
+		t.Errorf("Expected a not found error only")
+	}
+}
+
+func TestAPIErrorV1StringRes(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusOK}
+	body := []byte(`{"res":"9414","res_message":"Feature not permitted"}`)
+
+	apiErr := newAPIError(resp, body, nil, "Error from Incapsula service: %s", string(body))
+
+	if apiErr.Code != 9414 {
+		t.Errorf("Expected code 9414, got %d", apiErr.Code)
+	}
+	if !apiErr.IsFeatureUnavailable() {
+		t.Errorf("Expected a feature unavailable error")
+	}
+}
+
+func TestAPIErrorV3Body(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusForbidden}
+	body := []byte(`{"errors":[{"status":403,"id":"abc","code":1003,"title":"Forbidden","detail":"Not allowed to access site 42"}]}`)
+
+	apiErr := newAPIError(resp, body, apiResourceIDs("42"), "Error status code %d: %s", resp.StatusCode, string(body))
+
+	if apiErr.Code != 1003 {
+		t.Errorf("Expected code 1003, got %d", apiErr.Code)
+	}
+	if apiErr.Message != "Not allowed to access site 42" {
+		t.Errorf("Unexpected message: %s", apiErr.Message)
+	}
+	if len(apiErr.Errors) != 1 || apiErr.Errors[0].Title != "Forbidden" {
+		t.Errorf("Unexpected errors: %v", apiErr.Errors)
+	}
+	if !apiErr.IsPermissionDenied() || apiErr.IsNotFound() {
+		t.Errorf("Expected a permission denied error only")
+	}
+}
+
+func TestAPIErrorNonJSONBody(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusNotFound}
+	body := []byte(`<html>Not Found</html>`)
+
+	apiErr := newAPIError(resp, body, nil, "Error status code %d: %s", resp.StatusCode, string(body))
+
+	if apiErr.Code != 0 || apiErr.Message != "" {
+		t.Errorf("Expected no code and message for a non JSON body, got %d %q", apiErr.Code, apiErr.Message)
+	}
+	if !apiErr.IsNotFound() {
+		t.Errorf("Expected a not found error")
+	}
+}
+
+func TestAPIErrorHelpersUnwrap(t *testing.T) {
+	apiErr := newAPIError(&http.Response{StatusCode: http.StatusNotFound}, nil, nil, "not found")
+	wrapped := fmt.Errorf("reading resource: %w", apiErr)
+
+	if !IsNotFound(wrapped) {
+		t.Errorf("Expected IsNotFound to match a wrapped APIError")
+	}
+	if IsNotFound(errors.New("404")) {
+		t.Errorf("Expected IsNotFound not to match a plain error")
+	}
+	if IsPermissionDenied(wrapped) || IsFeatureUnavailable(wrapped) {
+		t.Errorf("Expected only IsNotFound to match")
+	}
+}
+
+func TestClientReadIncapRuleReturnsAPIError(t *testing.T) {
+	siteID := "42"
+	ruleID := 7
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+		rw.Write([]byte(`{"errors":[{"status":404,"code":2002,"title":"Not Found","detail":"Rule not found"}]}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	_, _, err := client.ReadIncapRule(context.Background(), siteID, ruleID)
+	if err == nil {
+		t.Fatalf("Should have received an error")
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected an *APIError, got %T: %s", err, err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Code != 2002 || apiErr.Message != "Rule not found" {
+		t.Errorf("Unexpected API error fields: %+v", apiErr)
+	}
+	if apiErr.Operation != ReadIncapRule {
+		t.Errorf("Expected operation %s, got %s", ReadIncapRule, apiErr.Operation)
+	}
+	if strings.Join(apiErr.ResourceIDs, ",") != "7,42" {
+		t.Errorf("Unexpected resource IDs: %v", apiErr.ResourceIDs)
+	}
+	if !strings.HasPrefix(err.Error(), "Error status code 404 from Incapsula service when reading Incap Rule 7 for Site ID 42") {
+		t.Errorf("Unexpected error text: %s", err)
+	}
+	if !IsNotFound(err) {
+		t.Errorf("Expected IsNotFound to be true")
+	}
+}
+
+func TestClientTransportErrorsAreWrapped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		t.Errorf("Unexpected request %s %s", req.Method, req.URL)
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	// A canceled context is still recognizable once the client method wrapped it
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := client.ReadIncapRule(ctx, "42", 7); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the context cancellation to be wrapped, got: %v", err)
+	}
+
+	// So is a request refused in read-only mode
+	config.ReadOnly = true
+	if err := client.DeleteIncapRule(context.Background(), "42", 7); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly to be wrapped, got: %v", err)
+	}
+}
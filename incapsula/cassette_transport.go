@@ -0,0 +1,273 @@
+package incapsula
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+)
+
+// Environment variables enabling the record/replay mode of the client. Both take the directory of the cassette files.
+const (
+	recordCassetteEnv = "INCAPSULA_RECORD"
+	replayCassetteEnv = "INCAPSULA_REPLAY"
+)
+
+// defaultCassetteName is the cassette used outside of the acceptance tests, which use one cassette per test
+const defaultCassetteName = "incapsula"
+
+// Cassette holds the API interactions recorded for one acceptance test (or one Terraform run)
+type Cassette struct {
+	Interactions []CassetteInteraction `json:"interactions"`
+}
+
+// CassetteInteraction is one recorded API call. Secret headers are not recorded and secret body fields are redacted.
+type CassetteInteraction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+type CassetteRequest struct {
+	Method    string `json:"method"`
+	URL       string `json:"url"`
+	Operation string `json:"operation"`
+	Body      string `json:"body,omitempty"`
+}
+
+type CassetteResponse struct {
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body"`
+}
+
+var (
+	cassetteMu        sync.Mutex
+	cassetteName      = defaultCassetteName
+	cassettes         = map[string]*cassetteFile{}
+	cassetteNameChars = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+)
+
+// SetCassetteName selects the cassette used by the clients created from now on, e.g. the name of the
+// acceptance test being run. It has no effect unless INCAPSULA_RECORD or INCAPSULA_REPLAY is set.
+func SetCassetteName(name string) {
+	cassetteMu.Lock()
+	defer cassetteMu.Unlock()
+	cassetteName = cassetteNameChars.ReplaceAllString(name, "_")
+}
+
+// IsReplayMode reports whether the clients serve the API interactions from cassettes instead of the API
+func IsReplayMode() bool {
+	return os.Getenv(replayCassetteEnv) != ""
+}
+
+// cassetteFile is a cassette being recorded or replayed. It is shared by all the clients using the
+// same file, so the interactions of every provider configuration of a test end up in the same cassette.
+type cassetteFile struct {
+	mu       sync.Mutex
+	path     string
+	cassette Cassette
+	// index of the next interaction to replay, by request key
+	replayed map[string]int
+}
+
+// cassetteTransportFromEnv wraps next in a recording or replaying transport when INCAPSULA_RECORD or
+// INCAPSULA_REPLAY is set, and returns next unchanged otherwise
+func cassetteTransportFromEnv(next http.RoundTripper) (http.RoundTripper, error) {
+	recordDir, replayDir := os.Getenv(recordCassetteEnv), os.Getenv(replayCassetteEnv)
+	switch {
+	case recordDir != "" && replayDir != "":
+		return nil, fmt.Errorf("%s and %s can't be set at the same time", recordCassetteEnv, replayCassetteEnv)
+	case recordDir != "":
+		cassette, err := openCassette(recordDir, true)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("[INFO] Recording the API interactions to %s\n", cassette.path)
+		return &recordingTransport{next: next, cassette: cassette}, nil
+	case replayDir != "":
+		cassette, err := openCassette(replayDir, false)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("[INFO] Replaying the API interactions from %s\n", cassette.path)
+		return &replayingTransport{cassette: cassette}, nil
+	}
+	return next, nil
+}
+
+func openCassette(dir string, record bool) (*cassetteFile, error) {
+	cassetteMu.Lock()
+	defer cassetteMu.Unlock()
+
+	path := filepath.Join(dir, cassetteName+".json")
+	if cassette, ok := cassettes[path]; ok {
+		return cassette, nil
+	}
+
+	cassette := &cassetteFile{path: path, replayed: map[string]int{}}
+	if record {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("Error creating cassette directory %s: %w", dir, err)
+		}
+	} else {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading cassette %s: %w", path, err)
+		}
+		if err := json.Unmarshal(content, &cassette.cassette); err != nil {
+			return nil, fmt.Errorf("Error parsing cassette %s: %w", path, err)
+		}
+	}
+	cassettes[path] = cassette
+	return cassette, nil
+}
+
+// cassetteRequestKey identifies the interactions replayed for a request. The host is left out, so a
+// cassette recorded against one Imperva environment can be replayed with another base URL. The body is the
+// normalized body of cassetteRequestBody, so the API v1 form posts made to the same path for different
+// sites (e.g. /sites/status) are told apart.
+func cassetteRequestKey(method string, requestURL string, operation string, body string) string {
+	return fmt.Sprintf("%s %s %s %s", method, requestURL, operation, body)
+}
+
+// cassetteRequestBody returns the request body as it is recorded: form values sorted by name, JSON
+// re-encoded with sorted keys, and the values of secret fields redacted
+func cassetteRequestBody(req *http.Request, body []byte) string {
+	return redactedBody(req.Header.Get("Content-Type"), body)
+}
+
+func cassetteRequestURL(req *http.Request) string {
+	requestURL := *req.URL
+	requestURL.Scheme, requestURL.Host, requestURL.User = "", "", nil
+	return redactedURL(&requestURL)
+}
+
+// recordingTransport sends the requests to the API and saves every interaction to the cassette
+type recordingTransport struct {
+	next     http.RoundTripper
+	cassette *cassetteFile
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			requestBody, _ = io.ReadAll(body)
+			body.Close()
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	responseBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+	if err != nil {
+		return resp, nil
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	interaction := CassetteInteraction{
+		Request: CassetteRequest{
+			Method:    req.Method,
+			URL:       cassetteRequestURL(req),
+			Operation: req.Header.Get("x-tf-operation"),
+			Body:      cassetteRequestBody(req, requestBody),
+		},
+		Response: CassetteResponse{
+			StatusCode:  resp.StatusCode,
+			ContentType: contentType,
+			Body:        redactedBody(contentType, responseBody),
+		},
+	}
+	if err := t.cassette.record(interaction); err != nil {
+		log.Printf("[WARN] %s\n", err)
+	}
+
+	return resp, nil
+}
+
+func (f *cassetteFile) record(interaction CassetteInteraction) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.cassette.Interactions = append(f.cassette.Interactions, interaction)
+	content, err := json.MarshalIndent(f.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Error encoding cassette %s: %w", f.path, err)
+	}
+	// Save after every interaction, so that the cassette is complete even if the run is interrupted
+	if err := os.WriteFile(f.path, content, 0644); err != nil {
+		return fmt.Errorf("Error writing cassette %s: %w", f.path, err)
+	}
+	return nil
+}
+
+// replayingTransport serves the requests from the cassette, without calling the API. The interactions
+// recorded for the same method, URL, operation and body are served in the recorded order; when they are
+// all used up, the last one is served again.
+type replayingTransport struct {
+	cassette *cassetteFile
+}
+
+func (t *replayingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.Body != nil {
+		requestBody, _ = io.ReadAll(req.Body)
+		req.Body.Close()
+	}
+
+	interaction, ok := t.cassette.next(req.Method, cassetteRequestURL(req), req.Header.Get("x-tf-operation"), cassetteRequestBody(req, requestBody))
+	if !ok {
+		return nil, fmt.Errorf("No interaction recorded in cassette %s for %s %s (operation %s)",
+			t.cassette.path, req.Method, cassetteRequestURL(req), req.Header.Get("x-tf-operation"))
+	}
+
+	header := http.Header{}
+	if interaction.Response.ContentType != "" {
+		header.Set("Content-Type", interaction.Response.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(interaction.Response.Body))),
+		ContentLength: int64(len(interaction.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+func (f *cassetteFile) next(method string, requestURL string, operation string, body string) (*CassetteInteraction, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := cassetteRequestKey(method, requestURL, operation, body)
+	var matches []int
+	for i, interaction := range f.cassette.Interactions {
+		if cassetteRequestKey(interaction.Request.Method, interaction.Request.URL, interaction.Request.Operation, interaction.Request.Body) == key {
+			matches = append(matches, i)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, false
+	}
+
+	index := f.replayed[key]
+	if index >= len(matches) {
+		index = len(matches) - 1
+	}
+	f.replayed[key] = index + 1
+	return &f.cassette.Interactions[matches[index]], true
+}
@@ -0,0 +1,169 @@
+package incapsula
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// resetCassettes forgets the cassettes opened by the previous clients, as a new test process would
+func resetCassettes(t *testing.T, name string) {
+	cassetteMu.Lock()
+	cassettes = map[string]*cassetteFile{}
+	cassetteMu.Unlock()
+	SetCassetteName(name)
+	t.Cleanup(func() {
+		cassetteMu.Lock()
+		cassettes = map[string]*cassetteFile{}
+		cassetteName = defaultCassetteName
+		cassetteMu.Unlock()
+	})
+}
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	resetCassettes(t, "TestAccIncapsulaSite/basic")
+
+	reads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", contentTypeApplicationJson)
+		if strings.HasSuffix(req.URL.Path, "/account/verify") {
+			rw.Write([]byte(testVerifyResponse))
+			return
+		}
+		reads++
+		rw.Write([]byte(`{"data":[{"read":` + strconv.Itoa(reads) + `,"secretKey":"recorded-secret"}]}`))
+	}))
+
+	t.Setenv(recordCassetteEnv, dir)
+	config := testConfigForURL(server.URL)
+	config.APIKey = "recorded-api-key"
+	recordingClient, err := config.Client(context.Background())
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	for i := 0; i < 2; i++ {
+		resp, err := recordingClient.(*Client).DoJsonRequestWithHeaders(context.Background(), http.MethodGet, server.URL+"/sites/42?caid=7", nil, ReadIncapRule)
+		if err != nil {
+			t.Fatalf("Should not have received an error, got: %s", err)
+		}
+		resp.Body.Close()
+	}
+	server.Close()
+
+	cassettePath := filepath.Join(dir, "TestAccIncapsulaSite_basic.json")
+	content, err := os.ReadFile(cassettePath)
+	if err != nil {
+		t.Fatalf("Expected the cassette to be saved: %s", err)
+	}
+	if strings.Contains(string(content), "recorded-api-key") || strings.Contains(string(content), "recorded-secret") {
+		t.Errorf("Secrets should not be recorded in the cassette:\n%s", content)
+	}
+
+	// Replay without any server listening
+	resetCassettes(t, "TestAccIncapsulaSite/basic")
+	t.Setenv(recordCassetteEnv, "")
+	t.Setenv(replayCassetteEnv, dir)
+	replayingClient, err := config.Client(context.Background())
+	if err != nil {
+		t.Fatalf("Should not have received an error when replaying, got: %s", err)
+	}
+	client := replayingClient.(*Client)
+
+	// The interactions are replayed in order and the last one is repeated when they are used up
+	for _, expected := range []string{`"read":1`, `"read":2`, `"read":2`} {
+		resp, err := client.DoJsonRequestWithHeaders(context.Background(), http.MethodGet, "https://api.imperva.test/sites/42?caid=7", nil, ReadIncapRule)
+		if err != nil {
+			t.Fatalf("Should not have received an error when replaying, got: %s", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), expected) {
+			t.Errorf("Expected a replayed response with %s, got %d: %s", expected, resp.StatusCode, body)
+		}
+	}
+
+	_, err = client.DoJsonRequestWithHeaders(context.Background(), http.MethodDelete, "https://api.imperva.test/sites/42", nil, DeleteIncapRule)
+	if err == nil || !strings.Contains(err.Error(), "No interaction recorded in cassette") {
+		t.Errorf("Should have received a missing interaction error, got: %v", err)
+	}
+}
+
+func TestCassetteReplayMatchesRequestBody(t *testing.T) {
+	dir := t.TempDir()
+	resetCassettes(t, "TestAccIncapsulaSite/two_sites")
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", contentTypeApplicationJson)
+		if strings.HasSuffix(req.URL.Path, "/account/verify") {
+			rw.Write([]byte(testVerifyResponse))
+			return
+		}
+		req.ParseForm()
+		rw.Write([]byte(`{"res":0,"site_id":` + req.PostForm.Get("site_id") + `}`))
+	}))
+
+	siteStatus := func(client *Client, baseURL string, siteID string) string {
+		values := url.Values{"site_id": {siteID}, "tests": {"domain_validation"}}
+		resp, err := client.PostFormWithHeaders(context.Background(), baseURL+"/sites/status", values, ReadSite)
+		if err != nil {
+			t.Fatalf("Should not have received an error, got: %s", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	t.Setenv(recordCassetteEnv, dir)
+	config := testConfigForURL(server.URL)
+	recordingClient, err := config.Client(context.Background())
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	siteStatus(recordingClient.(*Client), server.URL, "1")
+	siteStatus(recordingClient.(*Client), server.URL, "2")
+	server.Close()
+
+	resetCassettes(t, "TestAccIncapsulaSite/two_sites")
+	t.Setenv(recordCassetteEnv, "")
+	t.Setenv(replayCassetteEnv, dir)
+	replayingClient, err := config.Client(context.Background())
+	if err != nil {
+		t.Fatalf("Should not have received an error when replaying, got: %s", err)
+	}
+
+	// The same path and operation are told apart by the form values, whatever the order of the calls
+	for _, siteID := range []string{"2", "1", "2"} {
+		if body := siteStatus(replayingClient.(*Client), "https://api.imperva.test", siteID); body != `{"res":0,"site_id":`+siteID+`}` {
+			t.Errorf("Expected the response recorded for site %s, got %s", siteID, body)
+		}
+	}
+}
+
+func TestCassetteMissingFile(t *testing.T) {
+	resetCassettes(t, "TestAccMissing")
+	t.Setenv(replayCassetteEnv, t.TempDir())
+
+	config := testConfigForURL("https://api.imperva.test")
+	_, err := config.Client(context.Background())
+	if err == nil || !strings.HasPrefix(err.Error(), "Error reading cassette") {
+		t.Errorf("Should have received a missing cassette error, got: %v", err)
+	}
+}
+
+func TestCassetteRecordAndReplayExclusive(t *testing.T) {
+	resetCassettes(t, "TestAccExclusive")
+	t.Setenv(recordCassetteEnv, t.TempDir())
+	t.Setenv(replayCassetteEnv, t.TempDir())
+
+	if _, err := cassetteTransportFromEnv(http.DefaultTransport); err == nil {
+		t.Errorf("Should have received an error when both record and replay are set")
+	}
+}
@@ -2,6 +2,7 @@ package incapsula
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -16,6 +17,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 )
@@ -23,25 +25,73 @@ import (
 const contentTypeApplicationUrlEncoded = "application/x-www-form-urlencoded"
 const contentTypeApplicationJson = "application/json"
 
-// Retry defaults for transient API failures (502, 503, 504, 429, HTML error pages).
-// These are vars (not consts) so tests can override them for fast execution.
-var maxRetries = 4
-var retryWaitMinSeconds = 1
-var retryWaitMaxSeconds = 30
+// RetryPolicy controls how the client retries transient API failures (502, 503, 504, 429, HTML error pages)
+type RetryPolicy struct {
+	// Total number of attempts per request, including the first one
+	MaxAttempts int
+
+	// Backoff before the first retry, doubled on every further retry
+	MinBackoff time.Duration
+
+	// Upper bound of the backoff between two attempts
+	MaxBackoff time.Duration
+
+	// Status codes retried in addition to 429 and 5xx
+	RetryableStatusCodes []int
+
+	// Also retry non-read (non-idempotent) requests on 5xx and RetryableStatusCodes.
+	// By default they are only retried when the error page shows the request never reached the API.
+	RetryNonIdempotent bool
+}
+
+// defaultRetryPolicy is used by clients whose Config has no retry policy.
+// This is a var (not a const) so tests can override it for fast execution.
+var defaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	MinBackoff:  1 * time.Second,
+	MaxBackoff:  30 * time.Second,
+}
+
+func (p *RetryPolicy) isRetryableStatusCode(statusCode int) bool {
+	if statusCode >= 500 {
+		return true
+	}
+	for _, code := range p.RetryableStatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
 
 // Client represents an internal client that brokers calls to the Incapsula API
 type Client struct {
 	config          *Config
 	httpClient      *http.Client
 	providerVersion string
+	limiter         *requestLimiter
+	readCache       *readCache
+
+	// accountStatus is the account of the API key, set by the credentials check
+	accountStatusMu sync.Mutex
 	accountStatus   *AccountStatusResponse
 }
 
 // NewClient creates a new client with the provided configuration
 func NewClient(config *Config) *Client {
-	client := &http.Client{}
+	client := &http.Client{Transport: newLoggingTransport(http.DefaultTransport, nil)}
 
-	return &Client{config: config, httpClient: client, providerVersion: "3.39.0"}
+	limiter := newRequestLimiter(config.MaxRequestsPerSecond, config.MaxConcurrentRequests)
+
+	return &Client{config: config, httpClient: client, providerVersion: "3.39.0", limiter: limiter, readCache: newReadCache(config.ReadCacheTTL)}
+}
+
+// retryPolicy returns the retry policy configured for this client, or the default one
+func (c *Client) retryPolicy() *RetryPolicy {
+	if c.config != nil && c.config.Retry != nil {
+		return c.config.Retry
+	}
+	return &defaultRetryPolicy
 }
 
 func (c *Client) CreateFormDataBody(bodyMap map[string]interface{}) ([]byte, string) {
@@ -74,15 +124,15 @@ func (c *Client) CreateFormDataBody(bodyMap map[string]interface{}) ([]byte, str
 }
 
 // Verify the API credentials using the lightweight verify endpoint
-func (c *Client) Verify() (*AccountStatusResponse, error) {
+func (c *Client) Verify(ctx context.Context) (*AccountStatusResponse, error) {
 	log.Println("[INFO] Checking API credentials against Incapsula API")
 
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointAccountVerify)
 	data := url.Values{}
 
-	resp, err := c.PostFormWithHeaders(reqURL, data, VerifyAccount)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, data, VerifyAccount)
 	if err != nil {
-		return nil, fmt.Errorf("Error checking account: %s", err)
+		return nil, fmt.Errorf("Error checking account: %w", err)
 	}
 
 	// Read the body
@@ -96,7 +146,7 @@ func (c *Client) Verify() (*AccountStatusResponse, error) {
 	var accountVerifyResponse AccountVerifyResponse
 	err = json.Unmarshal([]byte(responseBody), &accountVerifyResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing account JSON response: %s", err)
+		return nil, fmt.Errorf("Error parsing account JSON response: %w", err)
 	}
 
 	var resString string
@@ -109,7 +159,7 @@ func (c *Client) Verify() (*AccountStatusResponse, error) {
 
 	// Look at the response status code from Incapsula
 	if resString != "0" {
-		return nil, fmt.Errorf("Error from Incapsula service when checking account: %s", string(responseBody))
+		return nil, newAPIError(resp, responseBody, nil, "Error from Incapsula service when checking account: %s", string(responseBody))
 	}
 
 	// Convert the lightweight verify response to AccountStatusResponse for backward compatibility
@@ -128,35 +178,51 @@ func (c *Client) Verify() (*AccountStatusResponse, error) {
 	return accountStatusResponse, nil
 }
 
-func (c *Client) PostFormWithHeaders(url string, data url.Values, operation string) (*http.Response, error) {
+// currentAccountStatus returns the account of the API key. The credentials are checked on first use when
+// they were not checked when the provider was configured (skip_credentials_validation).
+func (c *Client) currentAccountStatus(ctx context.Context) (*AccountStatusResponse, error) {
+	c.accountStatusMu.Lock()
+	defer c.accountStatusMu.Unlock()
+
+	if c.accountStatus == nil {
+		accountStatus, err := c.Verify(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.accountStatus = accountStatus
+	}
+	return c.accountStatus, nil
+}
+
+func (c *Client) PostFormWithHeaders(ctx context.Context, url string, data url.Values, operation string) (*http.Response, error) {
 	encoded := []byte(data.Encode())
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(encoded))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(encoded))
 	if err != nil {
-		return nil, fmt.Errorf("Error preparing request: %s", err)
+		return nil, fmt.Errorf("Error preparing request: %w", err)
 	}
 
 	SetHeaders(c, req, contentTypeApplicationUrlEncoded, operation, nil)
 	return c.executeRequest(req)
 }
 
-func (c *Client) GetWithHeaders(url string, queryParams url.Values, operation string) (*http.Response, error) {
+func (c *Client) GetWithHeaders(ctx context.Context, url string, queryParams url.Values, operation string) (*http.Response, error) {
 	reqURL := url
 	if len(queryParams) > 0 {
 		reqURL = fmt.Sprintf("%s?%s", url, queryParams.Encode())
 	}
-	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("Error preparing request: %s", err)
+		return nil, fmt.Errorf("Error preparing request: %w", err)
 	}
 
 	SetHeaders(c, req, contentTypeApplicationJson, operation, nil)
 	return c.executeRequest(req)
 }
 
-func (c *Client) DoJsonRequestWithCustomHeaders(method string, url string, data []byte, headers map[string]string, operation string) (*http.Response, error) {
-	req, err := PrepareJsonRequest(method, url, data)
+func (c *Client) DoJsonRequestWithCustomHeaders(ctx context.Context, method string, url string, data []byte, headers map[string]string, operation string) (*http.Response, error) {
+	req, err := PrepareJsonRequest(ctx, method, url, data)
 	if err != nil {
-		return nil, fmt.Errorf("Error preparing request: %s", err)
+		return nil, fmt.Errorf("Error preparing request: %w", err)
 	}
 
 	SetHeaders(c, req, contentTypeApplicationJson, operation, headers)
@@ -164,14 +230,14 @@ func (c *Client) DoJsonRequestWithCustomHeaders(method string, url string, data
 	return c.executeRequest(req)
 }
 
-func (c *Client) DoJsonRequestWithHeaders(method string, url string, data []byte, operation string) (*http.Response, error) {
-	return c.DoJsonRequestWithCustomHeaders(method, url, data, nil, operation)
+func (c *Client) DoJsonRequestWithHeaders(ctx context.Context, method string, url string, data []byte, operation string) (*http.Response, error) {
+	return c.DoJsonRequestWithCustomHeaders(ctx, method, url, data, nil, operation)
 }
 
-func (c *Client) DoJsonAndQueryParamsRequestWithHeaders(method string, url string, data []byte, params map[string]string, operation string) (*http.Response, error) {
-	req, err := PrepareJsonRequest(method, url, data)
+func (c *Client) DoJsonAndQueryParamsRequestWithHeaders(ctx context.Context, method string, url string, data []byte, params map[string]string, operation string) (*http.Response, error) {
+	req, err := PrepareJsonRequest(ctx, method, url, data)
 	if err != nil {
-		return nil, fmt.Errorf("Error preparing request: %s", err)
+		return nil, fmt.Errorf("Error preparing request: %w", err)
 	}
 	q := req.URL.Query()
 	for name, value := range params {
@@ -195,22 +261,22 @@ func GetRequestParamsWithCaid(accountId int) map[string]string {
 	return params
 }
 
-func (c *Client) DoFormDataRequestWithHeaders(method string, url string, data []byte, contentType string, operation string) (*http.Response, error) {
-	req, err := PrepareJsonRequest(method, url, data)
+func (c *Client) DoFormDataRequestWithHeaders(ctx context.Context, method string, url string, data []byte, contentType string, operation string) (*http.Response, error) {
+	req, err := PrepareJsonRequest(ctx, method, url, data)
 	if err != nil {
-		return nil, fmt.Errorf("Error preparing request: %s", err)
+		return nil, fmt.Errorf("Error preparing request: %w", err)
 	}
 
 	SetHeaders(c, req, contentType, operation, nil)
 	return c.executeRequest(req)
 }
 
-func PrepareJsonRequest(method string, url string, data []byte) (*http.Request, error) {
+func PrepareJsonRequest(ctx context.Context, method string, url string, data []byte) (*http.Request, error) {
 	if data == nil {
-		return http.NewRequest(method, url, nil)
+		return http.NewRequestWithContext(ctx, method, url, nil)
 	}
 
-	return http.NewRequest(method, url, bytes.NewReader(data))
+	return http.NewRequestWithContext(ctx, method, url, bytes.NewReader(data))
 }
 
 func SetHeaders(c *Client, req *http.Request, contentType string, operation string, customHeaders map[string]string) {
@@ -229,22 +295,78 @@ func SetHeaders(c *Client, req *http.Request, contentType string, operation stri
 	}
 }
 
+// ErrReadOnly is returned for the requests that would change the configuration of a provider in read-only mode
+var ErrReadOnly = errors.New("the provider is in read-only mode (read_only = true)")
+
+// isReadOnlyRequest reports whether a request can be sent in read-only mode: GET, HEAD and OPTIONS requests,
+// the API v1 POST requests that only read data (their operation starts with "read") and the credentials check
+func isReadOnlyRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		operation := strings.ToLower(req.Header.Get("x-tf-operation"))
+		return strings.HasPrefix(operation, "read") || operation == VerifyAccount
+	}
+	return false
+}
+
 func (c *Client) executeRequest(req *http.Request) (*http.Response, error) {
+	if c.config.ReadOnly && !isReadOnlyRequest(req) {
+		log.Printf("[WARN] Refused %s %s (operation %s) in read-only mode\n", req.Method, req.URL.Path, req.Header.Get("x-tf-operation"))
+		return nil, fmt.Errorf("Refused %s %s (operation %s): %w", req.Method, req.URL.Path, req.Header.Get("x-tf-operation"), ErrReadOnly)
+	}
+	// Deferred credentials check, before the first API call
+	if c.config.SkipCredentialsValidation && req.Header.Get("x-tf-operation") != VerifyAccount {
+		if _, err := c.currentAccountStatus(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+	c.addDefaultAccountID(req)
+	if c.readCache != nil {
+		return c.readCache.do(req, c.sendRequest)
+	}
+	return c.sendRequest(req)
+}
+
+// sendRequest sends the request to the API, traced as one span including its retries
+func (c *Client) sendRequest(req *http.Request) (*http.Response, error) {
+	ctx, span := startRequestSpan(req)
+	retries := 0
+	resp, err := c.executeRequestWithRetries(req.WithContext(ctx), &retries)
+	endRequestSpan(span, resp, err, retries)
+
+	return resp, err
+}
+
+// executeRequestWithRetries sends the request, retrying transient failures according to the retry policy.
+// retries is set to the number of retries made.
+func (c *Client) executeRequestWithRetries(req *http.Request, retries *int) (*http.Response, error) {
 	var resp *http.Response
 	var err error
+	var retryAfter time.Duration
+
+	policy := c.retryPolicy()
+	maxRetries := policy.MaxAttempts - 1
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
 
 	for attempt := 0; attempt <= maxRetries; attempt++ {
+		*retries = attempt
 		if attempt > 0 {
 			if req.GetBody != nil {
 				req.Body, _ = req.GetBody()
 			}
-			delay := time.Duration(retryWaitMinSeconds) * time.Second * (1 << (attempt - 1))
-			maxDelay := time.Duration(retryWaitMaxSeconds) * time.Second
-			if delay > maxDelay {
-				delay = maxDelay
+			delay := policy.MinBackoff * (1 << (attempt - 1))
+			if delay > policy.MaxBackoff {
+				delay = policy.MaxBackoff
 			}
 			var jitter time.Duration
-			if delay > 0 {
+			if retryAfter > 0 {
+				// The API told us how long to back off, so use that instead of our own schedule
+				delay = retryAfter
+			} else if delay > 0 {
 				jitter = time.Duration(rand.Int63n(int64(delay) / 4))
 			}
 			if err != nil {
@@ -254,21 +376,36 @@ func (c *Client) executeRequest(req *http.Request) (*http.Response, error) {
 				log.Printf("[WARN] Transient error (status %d), retry %d/%d for %s %s (backoff %s)",
 					resp.StatusCode, attempt, maxRetries, req.Method, req.URL.Path, delay+jitter)
 			}
-			time.Sleep(delay + jitter)
+			if err := sleepWithContext(req.Context(), delay+jitter); err != nil {
+				return nil, err
+			}
 		}
 
-		resp, err = c.httpClient.Do(req)
+		release, limitErr := c.limiter.acquire(req.Context())
+		if limitErr != nil {
+			return nil, limitErr
+		}
+		resp, err = c.httpClient.Do(req.WithContext(withRequestAttempt(req.Context(), attempt+1)))
+		release()
 		if err != nil {
+			// A cancelled or expired context is not transient, so don't retry it
+			if req.Context().Err() != nil {
+				return nil, err
+			}
 			if isTransientNetError(err) && attempt < maxRetries {
 				continue
 			}
 			return nil, err
 		}
 
-		if !c.isRetryableResponse(req, resp) {
+		if !c.isRetryableResponse(req, resp, policy) {
 			return resp, nil
 		}
 
+		// Capped by the policy, so that a large Retry-After can't stall the run (or, through the limiter, every request)
+		retryAfter = min(parseRetryAfter(resp), policy.MaxBackoff)
+		c.limiter.pause(retryAfter)
+
 		if attempt == maxRetries {
 			log.Printf("[WARN] Retries exhausted (status %d) for %s %s, returning last response",
 				resp.StatusCode, req.Method, req.URL.Path)
@@ -282,16 +419,27 @@ func (c *Client) executeRequest(req *http.Request) (*http.Response, error) {
 	return nil, fmt.Errorf("request to %s %s failed after %d retries: last status %d", req.Method, req.URL.Path, maxRetries, resp.StatusCode)
 }
 
-func (c *Client) isRetryableResponse(req *http.Request, resp *http.Response) bool {
+// sleepWithContext waits for the given duration, returning early with the context's
+// error if it is cancelled or its deadline passes first.
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (c *Client) isRetryableResponse(req *http.Request, resp *http.Response, policy *RetryPolicy) bool {
 	if resp.StatusCode == 429 {
 		return true
 	}
 
-	isRead := req.Method == http.MethodGet ||
-		strings.HasPrefix(strings.ToLower(req.Header.Get("x-tf-operation")), "read")
-
-	if resp.StatusCode >= 500 {
-		if isRead {
+	if policy.isRetryableStatusCode(resp.StatusCode) {
+		if isReadRequest(req) || policy.RetryNonIdempotent {
 			return true
 		}
 		return c.responseBodyIsHTML(resp)
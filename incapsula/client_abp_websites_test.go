@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -15,12 +16,12 @@ func TestClientAbpWebsitesReadBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	accountId := 1234
-	abpWebsitesResponse, diags := client.ReadAbpWebsites(accountId)
-	if len(diags) == 0 {
+	abpWebsitesResponse, err := client.ReadAbpWebsites(context.Background(), accountId)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error from Incapsula service when reading ABP Websites for Account ID %d", accountId)) {
-		t.Errorf("Should have received a client error, got: %+v", diags)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error from Incapsula service when reading ABP Websites for Account ID %d", accountId)) {
+		t.Errorf("Should have received a client error, got: %+v", err)
 	}
 	if abpWebsitesResponse != nil {
 		t.Errorf("Should have received a nil abpWebsitesResponse instance")
@@ -36,12 +37,12 @@ func TestClientAbpWebsitesReadBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountId := 1234
-	abpWebsitesResponse, diags := client.ReadAbpWebsites(accountId)
-	if len(diags) == 0 {
+	abpWebsitesResponse, err := client.ReadAbpWebsites(context.Background(), accountId)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error parsing ABP Websites JSON response for Account ID %d", accountId)) {
-		t.Errorf("Should have received a client error, got: %+v", diags)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error parsing ABP Websites JSON response for Account ID %d", accountId)) {
+		t.Errorf("Should have received a client error, got: %+v", err)
 	}
 	if abpWebsitesResponse != nil {
 		t.Errorf("Should have received a nil abpWebsitesResponse instance")
@@ -58,12 +59,12 @@ func TestClientAbpWebsitesReadBadRequest(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountId := 1234
-	abpWebsitesResponse, diags := client.ReadAbpWebsites(accountId)
-	if len(diags) == 0 {
+	abpWebsitesResponse, err := client.ReadAbpWebsites(context.Background(), accountId)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error status code 400 from Incapsula service when reading ABP Websites for Account ID %d: some error", accountId)) {
-		t.Errorf("Should have received a client error, got: %+v", diags)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error status code 400 from Incapsula service when reading ABP Websites for Account ID %d: some error", accountId)) {
+		t.Errorf("Should have received a client error, got: %+v", err)
 	}
 	if abpWebsitesResponse != nil {
 		t.Errorf("Should have received a nil abpWebsitesResponse instance")
@@ -105,9 +106,9 @@ func TestClientAbpWebsitesReadOk(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountId := 1234
-	abpWebsitesResponse, diags := client.ReadAbpWebsites(accountId)
-	if len(diags) != 0 {
-		t.Errorf("Should not have received an error %+v", diags)
+	abpWebsitesResponse, err := client.ReadAbpWebsites(context.Background(), accountId)
+	if err != nil {
+		t.Errorf("Should not have received an error %+v", err)
 	}
 
 	if !reflect.DeepEqual(*abpWebsitesResponse, abpWebsites) {
@@ -119,12 +120,12 @@ func TestClientAbpWebsitesCreateBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	accountId := 1234
-	abpWebsitesResponse, diags := client.CreateAbpWebsites(accountId, AbpTerraformAccount{})
-	if len(diags) == 0 {
+	abpWebsitesResponse, err := client.CreateAbpWebsites(context.Background(), accountId, AbpTerraformAccount{})
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error from Incapsula service when creating ABP Websites for Account ID %d", accountId)) {
-		t.Errorf("Should have received a client error, got: %+v", diags)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error from Incapsula service when creating ABP Websites for Account ID %d", accountId)) {
+		t.Errorf("Should have received a client error, got: %+v", err)
 	}
 	if abpWebsitesResponse != nil {
 		t.Errorf("Should have received a nil abpWebsitesResponse instance")
@@ -141,12 +142,12 @@ func TestClientAbpWebsitesCreateBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountId := 1234
-	abpWebsitesResponse, diags := client.CreateAbpWebsites(accountId, AbpTerraformAccount{})
-	if len(diags) == 0 {
+	abpWebsitesResponse, err := client.CreateAbpWebsites(context.Background(), accountId, AbpTerraformAccount{})
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error parsing ABP Websites JSON response for Account ID %d", accountId)) {
-		t.Errorf("Should have received a client error, got: %+v", diags)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error parsing ABP Websites JSON response for Account ID %d", accountId)) {
+		t.Errorf("Should have received a client error, got: %+v", err)
 	}
 	if abpWebsitesResponse != nil {
 		t.Errorf("Should have received a nil abpWebsitesResponse instance")
@@ -163,12 +164,12 @@ func TestClientAbpWebsitesCreateBadRequest(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountId := 1234
-	abpWebsitesResponse, diags := client.CreateAbpWebsites(accountId, AbpTerraformAccount{})
-	if len(diags) == 0 {
+	abpWebsitesResponse, err := client.CreateAbpWebsites(context.Background(), accountId, AbpTerraformAccount{})
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error status code 400 from Incapsula service when creating ABP Websites for Account ID %d: some error", accountId)) {
-		t.Errorf("Should have received a client error, got: %+v", diags)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error status code 400 from Incapsula service when creating ABP Websites for Account ID %d: some error", accountId)) {
+		t.Errorf("Should have received a client error, got: %+v", err)
 	}
 	if abpWebsitesResponse != nil {
 		t.Errorf("Should have received a nil abpWebsitesResponse instance")
@@ -211,9 +212,9 @@ func TestClientAbpWebsitesCreateOk(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountId := 1234
-	abpWebsitesResponse, diags := client.CreateAbpWebsites(accountId, abpWebsites)
-	if len(diags) != 0 {
-		t.Errorf("Should not have received an error %+v", diags)
+	abpWebsitesResponse, err := client.CreateAbpWebsites(context.Background(), accountId, abpWebsites)
+	if err != nil {
+		t.Errorf("Should not have received an error %+v", err)
 		return
 	}
 
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -108,7 +109,7 @@ type AccountStatusResponse struct {
 }
 
 // AddAccount adds an account to be managed by Incapsula
-func (c *Client) AddAccount(email, refID, userName, planID, accountName, logLevel string, logsAccountID int, parentID int) (*AccountAddResponse, error) {
+func (c *Client) AddAccount(ctx context.Context, email, refID, userName, planID, accountName, logLevel string, logsAccountID int, parentID int) (*AccountAddResponse, error) {
 	log.Printf("[INFO] Adding Incapsula account for email: %s (account ID %d)\n", email, parentID)
 
 	values := url.Values{
@@ -130,9 +131,9 @@ func (c *Client) AddAccount(email, refID, userName, planID, accountName, logLeve
 	}
 
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointAccountAdd)
-	resp, err := c.PostFormWithHeaders(reqURL, values, CreateAccount)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, CreateAccount)
 	if err != nil {
-		return nil, fmt.Errorf("Error adding account for email %s: %s", email, err)
+		return nil, fmt.Errorf("Error adding account for email %s: %w", email, err)
 	}
 
 	// Read the body
@@ -146,27 +147,27 @@ func (c *Client) AddAccount(email, refID, userName, planID, accountName, logLeve
 	var accountAddResponse AccountAddResponse
 	err = json.Unmarshal([]byte(responseBody), &accountAddResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing add account JSON response for email %s: %s", email, err)
+		return nil, fmt.Errorf("Error parsing add account JSON response for email %s: %w", email, err)
 	}
 
 	// Look at the response status code from Incapsula
 	if accountAddResponse.Res != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when adding account for email %s: %s", email, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(email), "Error from Incapsula service when adding account for email %s: %s", email, string(responseBody))
 	}
 
 	return &accountAddResponse, nil
 }
 
 // AccountStatus gets the Incapsula managed account's status
-func (c *Client) AccountStatus(accountID int, operation string) (*AccountStatusResponse, error) {
+func (c *Client) AccountStatus(ctx context.Context, accountID int, operation string) (*AccountStatusResponse, error) {
 	log.Printf("[INFO] Getting Incapsula account status for account id: %d\n", accountID)
 
 	// Post form to Incapsula
 	values := url.Values{"account_id": {strconv.Itoa(accountID)}}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointAccountStatus)
-	resp, err := c.PostFormWithHeaders(reqURL, values, operation)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, operation)
 	if err != nil {
-		return nil, fmt.Errorf("Error getting account status for account id %d: %s", accountID, err)
+		return nil, fmt.Errorf("Error getting account status for account id %d: %w", accountID, err)
 	}
 
 	// Read the body
@@ -180,7 +181,7 @@ func (c *Client) AccountStatus(accountID int, operation string) (*AccountStatusR
 	var accountStatusResponse AccountStatusResponse
 	err = json.Unmarshal([]byte(responseBody), &accountStatusResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing account status JSON response for account id %d: %s", accountID, err)
+		return nil, fmt.Errorf("Error parsing account status JSON response for account id %d: %w", accountID, err)
 	}
 
 	var resString string
@@ -193,7 +194,7 @@ func (c *Client) AccountStatus(accountID int, operation string) (*AccountStatusR
 
 	// Look at the response status code from Incapsula
 	if resString != "0" {
-		return &accountStatusResponse, fmt.Errorf("Error from Incapsula service when getting account status for account id %d: %s", accountID, string(responseBody))
+		return &accountStatusResponse, newAPIError(resp, responseBody, apiResourceIDs(accountID), "Error from Incapsula service when getting account status for account id %d: %s", accountID, string(responseBody))
 	}
 
 	// Convert inactivity timeout from millis to minutes
@@ -206,14 +207,14 @@ func (c *Client) AccountStatus(accountID int, operation string) (*AccountStatusR
 }
 
 // UpdateAccount will update the specific param/value on the account resource
-func (c *Client) UpdateAccount(accountID, param, value string) (*AccountUpdateResponse, error) {
+func (c *Client) UpdateAccount(ctx context.Context, accountID, param, value string) (*AccountUpdateResponse, error) {
 	log.Printf("[INFO] Updating Incapsula account for accountID: %s. Param: %s. Value: %s\n", accountID, param, value)
 
 	// Convert inactivity timeout from minutes to millis
 	if param == "inactivity_timeout" {
 		millis, err := convertMinutesToMilliseconds(value)
 		if err != nil {
-			return nil, fmt.Errorf("Error converting inactivity timeout from minutes to millis: %s", err)
+			return nil, fmt.Errorf("Error converting inactivity timeout from minutes to millis: %w", err)
 		}
 		value = strconv.Itoa(millis)
 	}
@@ -224,9 +225,9 @@ func (c *Client) UpdateAccount(accountID, param, value string) (*AccountUpdateRe
 		"value":      {value},
 	}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointAccountUpdate)
-	resp, err := c.PostFormWithHeaders(reqURL, values, UpdateAccount)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, UpdateAccount)
 	if err != nil {
-		return nil, fmt.Errorf("Error updating param (%s) with value (%s) on account_id: %s: %s", param, value, accountID, err)
+		return nil, fmt.Errorf("Error updating param (%s) with value (%s) on account_id: %s: %w", param, value, accountID, err)
 	}
 
 	// Read the body
@@ -240,19 +241,19 @@ func (c *Client) UpdateAccount(accountID, param, value string) (*AccountUpdateRe
 	var accountUpdateResponse AccountUpdateResponse
 	err = json.Unmarshal([]byte(responseBody), &accountUpdateResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing update account JSON response for accountID %s: %s", accountID, err)
+		return nil, fmt.Errorf("Error parsing update account JSON response for accountID %s: %w", accountID, err)
 	}
 
 	// Look at the response status code from Incapsula
 	if accountUpdateResponse.Res != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when updating account for accountID %s: %s", accountID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(accountID), "Error from Incapsula service when updating account for accountID %s: %s", accountID, string(responseBody))
 	}
 
 	return &accountUpdateResponse, nil
 }
 
 // DeleteAccount deletes a account currently managed by Incapsula
-func (c *Client) DeleteAccount(accountID int) error {
+func (c *Client) DeleteAccount(ctx context.Context, accountID int) error {
 	// Specifically shaded this struct, no need to share across funcs or export
 	// We only care about the response code and possibly the message
 	type AccountDeleteResponse struct {
@@ -265,9 +266,9 @@ func (c *Client) DeleteAccount(accountID int) error {
 	// Post form to Incapsula
 	values := url.Values{"account_id": {strconv.Itoa(accountID)}}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointAccountDelete)
-	resp, err := c.PostFormWithHeaders(reqURL, values, DeleteAccount)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, DeleteAccount)
 	if err != nil {
-		return fmt.Errorf("Error deleting account id: %d: %s", accountID, err)
+		return fmt.Errorf("Error deleting account id: %d: %w", accountID, err)
 	}
 
 	// Read the body
@@ -281,12 +282,12 @@ func (c *Client) DeleteAccount(accountID int) error {
 	var accountDeleteResponse AccountDeleteResponse
 	err = json.Unmarshal([]byte(responseBody), &accountDeleteResponse)
 	if err != nil {
-		return fmt.Errorf("Error parsing delete account JSON response for account id: %d: %s", accountID, err)
+		return fmt.Errorf("Error parsing delete account JSON response for account id: %d: %w", accountID, err)
 	}
 
 	// Look at the response status code from Incapsula
 	if accountDeleteResponse.Res != 0 {
-		return fmt.Errorf("Error from Incapsula service when deleting account id: %d: %s", accountID, string(responseBody))
+		return newAPIError(resp, responseBody, apiResourceIDs(accountID), "Error from Incapsula service when deleting account id: %d: %s", accountID, string(responseBody))
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -17,7 +18,7 @@ func TestClientGetAccountDataStorageRegionBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	accountID := "123"
-	dataStorageRegionResponse, err := client.GetAccountDataStorageRegion(accountID)
+	dataStorageRegionResponse, err := client.GetAccountDataStorageRegion(context.Background(), accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -41,7 +42,7 @@ func TestClientGetAccountDataStorageRegionBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountID := "123"
-	dataStorageRegionResponse, err := client.GetAccountDataStorageRegion(accountID)
+	dataStorageRegionResponse, err := client.GetAccountDataStorageRegion(context.Background(), accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -65,7 +66,7 @@ func TestClientGetAccountDataStorageRegionInvalidSite(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountID := "7289383"
-	dataStorageRegionResponse, err := client.GetAccountDataStorageRegion(accountID)
+	dataStorageRegionResponse, err := client.GetAccountDataStorageRegion(context.Background(), accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -89,7 +90,7 @@ func TestClientGetAccountDataStorageRegionValidSite(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountID := "123"
-	dataStorageRegionResponse, err := client.GetAccountDataStorageRegion(accountID)
+	dataStorageRegionResponse, err := client.GetAccountDataStorageRegion(context.Background(), accountID)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -113,7 +114,7 @@ func TestClientUpdateAccountDataStorageRegionBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	accountID := "42"
 	region := "US"
-	dataStorageRegionResponse, err := client.UpdateAccountDataStorageRegion(accountID, region)
+	dataStorageRegionResponse, err := client.UpdateAccountDataStorageRegion(context.Background(), accountID, region)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -138,7 +139,7 @@ func TestClientUpdateAccountDataStorageRegionBadJSON(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountID := "42"
 	region := "US"
-	dataStorageRegionResponse, err := client.UpdateAccountDataStorageRegion(accountID, region)
+	dataStorageRegionResponse, err := client.UpdateAccountDataStorageRegion(context.Background(), accountID, region)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -163,7 +164,7 @@ func TestClientUpdateAccountDataStorageRegionInvalidSite(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountID := "7293873"
 	region := "US"
-	dataStorageRegionResponse, err := client.UpdateAccountDataStorageRegion(accountID, region)
+	dataStorageRegionResponse, err := client.UpdateAccountDataStorageRegion(context.Background(), accountID, region)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -188,7 +189,7 @@ func TestClientUpdateAccountDataStorageRegionValidSite(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountID := "7293873"
 	region := "US"
-	dataStorageRegionResponse, err := client.UpdateAccountDataStorageRegion(accountID, region)
+	dataStorageRegionResponse, err := client.UpdateAccountDataStorageRegion(context.Background(), accountID, region)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -55,7 +56,7 @@ type RoleDetailsDTO struct {
 }
 
 // AddAccountRole Adds an Account Role to be managed by Incapsula
-func (c *Client) AddAccountRole(requestDTO RoleDetailsCreateDTO) (*RoleDetailsDTO, error) {
+func (c *Client) AddAccountRole(ctx context.Context, requestDTO RoleDetailsCreateDTO) (*RoleDetailsDTO, error) {
 	log.Printf("[INFO] Adding Incapsula account role %s (account ID %d)\n", requestDTO.RoleName, requestDTO.AccountId)
 
 	roleJSON, err := json.Marshal(requestDTO)
@@ -64,9 +65,9 @@ func (c *Client) AddAccountRole(requestDTO RoleDetailsCreateDTO) (*RoleDetailsDT
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURLAPI, endpointRoleAdd)
 	log.Printf("[INFO]  reqURL: %v\n", reqURL)
 
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, roleJSON, CreateAccountRole)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, roleJSON, CreateAccountRole)
 	if err != nil {
-		return nil, fmt.Errorf("Error adding account role %s: %s", requestDTO.RoleName, err)
+		return nil, fmt.Errorf("Error adding account role %s: %w", requestDTO.RoleName, err)
 	}
 
 	// Read the body
@@ -81,26 +82,26 @@ func (c *Client) AddAccountRole(requestDTO RoleDetailsCreateDTO) (*RoleDetailsDT
 	err = json.Unmarshal(responseBody, &roleResponse)
 
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing add account role JSON response: %s", err)
+		return nil, fmt.Errorf("Error parsing add account role JSON response: %w", err)
 	}
 
 	// Look at the response status code from Incapsula
 	if roleResponse.ErrorCode != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when adding account role: %s", string(responseBody))
+		return nil, newAPIError(resp, responseBody, nil, "Error from Incapsula service when adding account role: %s", string(responseBody))
 	}
 
 	return &roleResponse, nil
 }
 
 // GetAccountRole - Retrieve the Account Role for a given role ID
-func (c *Client) GetAccountRole(roleId int) (*RoleDetailsDTO, error) {
+func (c *Client) GetAccountRole(ctx context.Context, roleId int) (*RoleDetailsDTO, error) {
 	log.Printf("[INFO] Getting Account Role (Id: %d)\n", roleId)
 
 	// Get request to Incapsula
 	reqURL := fmt.Sprintf("%s/%s/%d", c.config.BaseURLAPI, endpointRoleGet, roleId)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadAccountRole)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadAccountRole)
 	if err != nil {
-		return nil, fmt.Errorf("Error executing get Account Role request for role with id %d: %s", roleId, err)
+		return nil, fmt.Errorf("Error executing get Account Role request for role with id %d: %w", roleId, err)
 	}
 
 	// Read the body
@@ -114,14 +115,18 @@ func (c *Client) GetAccountRole(roleId int) (*RoleDetailsDTO, error) {
 	var responseDTO RoleDetailsDTO
 	err = json.Unmarshal(responseBody, &responseDTO)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Account Role JSON response for role with Id: %d %s\nresponse: %s", roleId, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Account Role JSON response for role with Id: %d %w\nresponse: %s", roleId, err, string(responseBody))
+	}
+
+	if resp.StatusCode != 200 || responseDTO.ErrorCode != 0 {
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(roleId), "Error from Incapsula service when reading account role with Id %d: %s", roleId, string(responseBody))
 	}
 
 	return &responseDTO, nil
 }
 
 // UpdateAccountRole - Update the Account Role for a given role ID
-func (c *Client) UpdateAccountRole(roleId int, accountId int, requestDTO RoleDetailsBasicDTO) (*RoleDetailsDTO, error) {
+func (c *Client) UpdateAccountRole(ctx context.Context, roleId int, accountId int, requestDTO RoleDetailsBasicDTO) (*RoleDetailsDTO, error) {
 	log.Printf("[INFO] Updating Incapsula account role (Id: %d, Account Id: %d)\n", roleId, accountId)
 
 	log.Printf("[INFO]  requestDTO: %+v\n", requestDTO)
@@ -133,9 +138,9 @@ func (c *Client) UpdateAccountRole(roleId int, accountId int, requestDTO RoleDet
 	log.Printf("[INFO]  reqURL: %v\n", reqURL)
 
 	params := GetRequestParamsWithCaid(accountId)
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodPost, reqURL, roleJSON, params, UpdateAccountRole)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodPost, reqURL, roleJSON, params, UpdateAccountRole)
 	if err != nil {
-		return nil, fmt.Errorf("Error updating account role with Id %d: %s", roleId, err)
+		return nil, fmt.Errorf("Error updating account role with Id %d: %w", roleId, err)
 	}
 
 	// Read the body
@@ -150,27 +155,27 @@ func (c *Client) UpdateAccountRole(roleId int, accountId int, requestDTO RoleDet
 	err = json.Unmarshal(responseBody, &roleResponse)
 
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing update account role JSON response: %s", err)
+		return nil, fmt.Errorf("Error parsing update account role JSON response: %w", err)
 	}
 
 	// Look at the response status code from Incapsula
 	if roleResponse.ErrorCode != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when updating account role: %s", string(responseBody))
+		return nil, newAPIError(resp, responseBody, nil, "Error from Incapsula service when updating account role: %s", string(responseBody))
 	}
 
 	return &roleResponse, nil
 }
 
 // DeleteAccountRole - Delete the Account Role for a given role ID
-func (c *Client) DeleteAccountRole(roleId int, accountId int) error {
+func (c *Client) DeleteAccountRole(ctx context.Context, roleId int, accountId int) error {
 	log.Printf("[INFO] Delete Account Role (Id: %d, Account Id: %d))\n", roleId, accountId)
 
 	// Get request to Incapsula
 	reqURL := fmt.Sprintf("%s/%s/%d", c.config.BaseURLAPI, endpointRoleDelete, roleId)
 	params := GetRequestParamsWithCaid(accountId)
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodDelete, reqURL, nil, params, DeleteAccountRole)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, params, DeleteAccountRole)
 	if err != nil {
-		return fmt.Errorf("Error executing delete Account Role request for role with id %d: %s", roleId, err)
+		return fmt.Errorf("Error executing delete Account Role request for role with id %d: %w", roleId, err)
 	}
 
 	// Read the body
@@ -184,21 +189,21 @@ func (c *Client) DeleteAccountRole(roleId int, accountId int) error {
 	var responseDTO RoleDetailsDTO
 	err = json.Unmarshal([]byte(responseBody), &responseDTO)
 	if err != nil {
-		return fmt.Errorf("Error parsing Account Role JSON response for role with Id: %d %s\nresponse: %s", roleId, err, string(responseBody))
+		return fmt.Errorf("Error parsing Account Role JSON response for role with Id: %d %w\nresponse: %s", roleId, err, string(responseBody))
 	}
 
 	return nil
 }
 
 // GetAccountAbilities - Retrieve the Account Abilities for a given account ID
-func (c *Client) GetAccountAbilities(accountId int) (*[]RoleAbility, error) {
+func (c *Client) GetAccountAbilities(ctx context.Context, accountId int) (*[]RoleAbility, error) {
 	log.Printf("[INFO] Getting Account Abilities for account Id: %d\n", accountId)
 
 	// Get request to Incapsula
 	reqURL := fmt.Sprintf("%s/%s/%d", c.config.BaseURLAPI, endpointAbilitiesGet, accountId)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadAccountAbilities)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadAccountAbilities)
 	if err != nil {
-		return nil, fmt.Errorf("Error executing get Account Abilities request for account with id %d: %s", accountId, err)
+		return nil, fmt.Errorf("Error executing get Account Abilities request for account with id %d: %w", accountId, err)
 	}
 
 	// Read the body
@@ -212,21 +217,21 @@ func (c *Client) GetAccountAbilities(accountId int) (*[]RoleAbility, error) {
 	var roleAbility []RoleAbility
 	err = json.Unmarshal(responseBody, &roleAbility)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Account Abilities JSON response for account with id: %d %s\nresponse: %s", accountId, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Account Abilities JSON response for account with id: %d %w\nresponse: %s", accountId, err, string(responseBody))
 	}
 
 	return &roleAbility, nil
 }
 
 // GetAccountRoles - Retrieve all the Roles for a given Account ID
-func (c *Client) GetAccountRoles(accountId int) (*[]RoleDetailsDTO, error) {
+func (c *Client) GetAccountRoles(ctx context.Context, accountId int) (*[]RoleDetailsDTO, error) {
 	log.Printf("[INFO] Getting Account Roles (Account Id: %d)\n", accountId)
 
 	// Get request to Incapsula
 	reqURL := fmt.Sprintf("%s/%s?accountId=%d", c.config.BaseURLAPI, endpointAccountRolesGet, accountId)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadAccountRoles)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadAccountRoles)
 	if err != nil {
-		return nil, fmt.Errorf("Error executing get Account Roles request for account with id %d: %s", accountId, err)
+		return nil, fmt.Errorf("Error executing get Account Roles request for account with id %d: %w", accountId, err)
 	}
 
 	// Read the body
@@ -240,7 +245,7 @@ func (c *Client) GetAccountRoles(accountId int) (*[]RoleDetailsDTO, error) {
 	var responseDTO []RoleDetailsDTO
 	err = json.Unmarshal(responseBody, &responseDTO)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Account Roles JSON response for account with Id: %d %s\nresponse: %s", accountId, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Account Roles JSON response for account with Id: %d %w\nresponse: %s", accountId, err, string(responseBody))
 	}
 
 	return &responseDTO, nil
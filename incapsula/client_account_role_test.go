@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -17,7 +18,7 @@ func TestClientAddRoleBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	requestDTO := RoleDetailsCreateDTO{}
-	RoleAddResponse, err := client.AddAccountRole(requestDTO)
+	RoleAddResponse, err := client.AddAccountRole(context.Background(), requestDTO)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -41,7 +42,7 @@ func TestClientAddRoleBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	requestDTO := RoleDetailsCreateDTO{}
-	RoleAddResponse, err := client.AddAccountRole(requestDTO)
+	RoleAddResponse, err := client.AddAccountRole(context.Background(), requestDTO)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -61,7 +62,7 @@ func TestClientGetRoleBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	roleID := 123
-	RoleStatusResponse, err := client.GetAccountRole(roleID)
+	RoleStatusResponse, err := client.GetAccountRole(context.Background(), roleID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -85,7 +86,7 @@ func TestClientGetRoleBadJSON(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	GetRoleResponse, err := client.GetAccountRole(roleID)
+	GetRoleResponse, err := client.GetAccountRole(context.Background(), roleID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -106,7 +107,7 @@ func TestClientDeleteRoleBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	roleID := 123
 	accountID := 456
-	err := client.DeleteAccountRole(roleID, accountID)
+	err := client.DeleteAccountRole(context.Background(), roleID, accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -129,7 +130,7 @@ func TestClientDeleteRoleBadJSON(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	err := client.DeleteAccountRole(roleID, accountID)
+	err := client.DeleteAccountRole(context.Background(), roleID, accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -148,7 +149,7 @@ func TestClientUpdateRoleBadConnection(t *testing.T) {
 	roleID := 123
 	accountID := 456
 	requestDTO := RoleDetailsBasicDTO{}
-	updateRoleResponse, err := client.UpdateAccountRole(roleID, accountID, requestDTO)
+	updateRoleResponse, err := client.UpdateAccountRole(context.Background(), roleID, accountID, requestDTO)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -175,7 +176,7 @@ func TestClientUpdateRoleBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	requestDTO := RoleDetailsBasicDTO{}
-	updateRoleResponse, err := client.UpdateAccountRole(roleID, accountID, requestDTO)
+	updateRoleResponse, err := client.UpdateAccountRole(context.Background(), roleID, accountID, requestDTO)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
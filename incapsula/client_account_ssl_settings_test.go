@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -13,8 +14,8 @@ func TestClientUpdateAccountSSlSettingsBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: "http://badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	dto := AccountSSLSettingsDTO{}
-	updateAccountSSLSettingsResponse, diag := client.UpdateAccountSSLSettings(&dto, "")
-	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "Timeout exceeded while awaiting") {
+	updateAccountSSLSettingsResponse, err := client.UpdateAccountSSLSettings(context.Background(), &dto, "")
+	if err == nil || !strings.Contains(err.Error(), "Timeout exceeded while awaiting") {
 		t.Errorf("Should have received an time out error")
 	}
 	if updateAccountSSLSettingsResponse != nil {
@@ -40,8 +41,8 @@ func TestClientUpdateAccountSSlSettingsInternalError(t *testing.T) {
 	dto := AccountSSLSettingsDTO{
 		ImpervaCertificate: &imp,
 	}
-	_, diag := client.UpdateAccountSSLSettings(&dto, "")
-	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "got response status 500, error") {
+	_, err := client.UpdateAccountSSLSettings(context.Background(), &dto, "")
+	if err == nil || !strings.Contains(err.Error(), "got response status 500, error") {
 		t.Errorf("Should have received an error")
 	}
 }
@@ -64,8 +65,8 @@ func TestClientUpdateAccountSSlSettingsErrorsInBody(t *testing.T) {
 	dto := AccountSSLSettingsDTO{
 		ImpervaCertificate: &imp,
 	}
-	accountSSLSettingsResponse, diag := client.UpdateAccountSSLSettings(&dto, "")
-	if diag != nil {
+	accountSSLSettingsResponse, err := client.UpdateAccountSSLSettings(context.Background(), &dto, "")
+	if err != nil {
 		t.Errorf("Should not received an error")
 	}
 	if accountSSLSettingsResponse.Errors == nil || accountSSLSettingsResponse.Errors[0].Status != 400 || accountSSLSettingsResponse.Data != nil {
@@ -91,8 +92,8 @@ func TestClientUpdateAccountSSlSettingsDataInBody(t *testing.T) {
 	dto := AccountSSLSettingsDTO{
 		ImpervaCertificate: &imp,
 	}
-	accountSSLSettingsResponse, diag := client.UpdateAccountSSLSettings(&dto, "")
-	if diag != nil {
+	accountSSLSettingsResponse, err := client.UpdateAccountSSLSettings(context.Background(), &dto, "")
+	if err != nil {
 		t.Errorf("Should not received an error")
 	}
 	if accountSSLSettingsResponse.Errors != nil || accountSSLSettingsResponse.Data == nil || !*accountSSLSettingsResponse.Data[0].ImpervaCertificate.UseWildCardSanInsteadOfFQDN {
@@ -111,8 +112,8 @@ func TestClientGetAccountSSlSettingsDataInBody(t *testing.T) {
 	defer server.Close()
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	accountSSLSettingsResponse, diag := client.GetAccountSSLSettings("")
-	if diag != nil {
+	accountSSLSettingsResponse, err := client.GetAccountSSLSettings(context.Background(), "")
+	if err != nil {
 		t.Errorf("Should not received an error")
 	}
 	if accountSSLSettingsResponse.Errors != nil || accountSSLSettingsResponse.Data == nil || !*accountSSLSettingsResponse.Data[0].ImpervaCertificate.UseWildCardSanInsteadOfFQDN {
@@ -132,8 +133,8 @@ func TestClientGetAccountSSlSettingsErrorsInBody(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	accountSSLSettingsResponse, diag := client.GetAccountSSLSettings("")
-	if diag != nil {
+	accountSSLSettingsResponse, err := client.GetAccountSSLSettings(context.Background(), "")
+	if err != nil {
 		t.Errorf("Should not received an error")
 	}
 	if accountSSLSettingsResponse.Errors == nil || accountSSLSettingsResponse.Errors[0].Status != 400 || accountSSLSettingsResponse.Data != nil {
@@ -155,8 +156,8 @@ func TestClientGetAccountSSlSettingsErrorFromMY(t *testing.T) {
 	defer server.Close()
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	_, diag := client.GetAccountSSLSettings("")
-	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "got response status 500, error") {
+	_, err := client.GetAccountSSLSettings(context.Background(), "")
+	if err == nil || !strings.Contains(err.Error(), "got response status 500, error") {
 		t.Errorf("Should have received an error")
 	}
 }
@@ -164,8 +165,8 @@ func TestClientGetAccountSSlSettingsErrorFromMY(t *testing.T) {
 func TestClientGetAccountSSlSettingsBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: "http://badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
-	updateAccountSSLSettingsResponse, diag := client.GetAccountSSLSettings("")
-	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "Timeout exceeded while awaiting") {
+	updateAccountSSLSettingsResponse, err := client.GetAccountSSLSettings(context.Background(), "")
+	if err == nil || !strings.Contains(err.Error(), "Timeout exceeded while awaiting") {
 		t.Errorf("Should have received an time out error")
 	}
 	if updateAccountSSLSettingsResponse != nil {
@@ -176,9 +177,9 @@ func TestClientGetAccountSSlSettingsBadConnection(t *testing.T) {
 func TestClientDeleteAccountSSlSettingsBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: "http://invalid.invalid"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
-	diag := client.DeleteAccountSSLSettings("")
-	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "error from Imperva service when deleting Account SSL certificate") {
-		t.Errorf("Should have received an error, got: %v", diag)
+	err := client.DeleteAccountSSLSettings(context.Background(), "")
+	if err == nil || !strings.Contains(err.Error(), "error from Imperva service when deleting Account SSL certificate") {
+		t.Errorf("Should have received an error, got: %v", err)
 	}
 }
 
@@ -193,8 +194,8 @@ func TestClientDeleteAccountSSlSettingsErrorFromMY(t *testing.T) {
 	defer server.Close()
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	diag := client.DeleteAccountSSLSettings("")
-	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "got response status 500") {
+	err := client.DeleteAccountSSLSettings(context.Background(), "")
+	if err == nil || !strings.Contains(err.Error(), "got response status 500") {
 		t.Errorf("Should have received an error")
 	}
 }
@@ -209,8 +210,8 @@ func TestClientDeleteAccountSSlSettings200FromMY(t *testing.T) {
 	defer server.Close()
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	diag := client.DeleteAccountSSLSettings("")
-	if diag != nil || diag.HasError() {
+	err := client.DeleteAccountSSLSettings(context.Background(), "")
+	if err != nil {
 		t.Errorf("Should not received an error")
 	}
 }
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -57,7 +58,7 @@ type UserUpdateReq struct {
 }
 
 // AddAccountUser adds a user to Incapsula Account
-func (c *Client) AddAccountUser(accountID int, email, firstName, lastName string, roleIds []interface{}, approvedIps []interface{}) (*UserApisResponse, error) {
+func (c *Client) AddAccountUser(ctx context.Context, accountID int, email, firstName, lastName string, roleIds []interface{}, approvedIps []interface{}) (*UserApisResponse, error) {
 	log.Printf("[INFO] Adding Incapsula account user for email: %s (account ID %d)\n", email, accountID)
 
 	listRoles := make([]int, len(roleIds))
@@ -74,25 +75,24 @@ func (c *Client) AddAccountUser(accountID int, email, firstName, lastName string
 
 	userJSON, err := json.Marshal(userAddReq)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to JSON marshal IncapRule: %s", err)
+		return nil, fmt.Errorf("Failed to JSON marshal IncapRule: %w", err)
 	}
 
 	endpointUserAdd := endpointUserOperationNew
 	operation := CreateAccountUser
-	accountStatusResponse, err := c.AccountStatus(accountID, ReadAccount)
+	accountStatusResponse, err := c.AccountStatus(ctx, accountID, ReadAccount)
 	if accountStatusResponse != nil && accountStatusResponse.AccountType == "Sub Account" {
 		endpointUserAdd = endpointUserOperationNew + "/" + email
 		operation = CreateSubAccountUser
 	}
 
 	reqURL := fmt.Sprintf("%s/%s?caid=%d", c.config.BaseURLAPI, endpointUserAdd, accountID)
-	log.Printf("[INFO] Values: %s\n", userJSON)
 	log.Printf("[INFO] Req: %s\n", reqURL)
-	log.Printf("[INFO] json: %s\n", userJSON)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, userJSON, operation)
+	log.Printf("[INFO] json: %s\n", redactedBody(contentTypeApplicationJson, userJSON))
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, userJSON, operation)
 
 	if err != nil {
-		return nil, fmt.Errorf("Error adding user email %s: %s", email, err)
+		return nil, fmt.Errorf("Error adding user email %s: %w", email, err)
 	}
 
 	// Read the body
@@ -100,34 +100,33 @@ func (c *Client) AddAccountUser(accountID int, email, firstName, lastName string
 	responseBody, err := ioutil.ReadAll(resp.Body)
 
 	// Dump JSON
-	log.Printf("[DEBUG] Incapsula add user JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Incapsula add user JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 
 	// Look at the response status code from Incapsula
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when adding User %s: %s", resp.StatusCode, email, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(email), "Error status code %d from Incapsula service when adding User %s: %s", resp.StatusCode, email, string(responseBody))
 	}
 
 	// Parse the JSON
 	var userAddResponse UserApisResponse
 	err = json.Unmarshal(responseBody, &userAddResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing add user JSON response for email %s: %s", email, err)
+		return nil, fmt.Errorf("Error parsing add user JSON response for email %s: %w", email, err)
 	}
 
-	log.Printf("[INFO] ResponseStruct : %+v\n", userAddResponse)
 	return &userAddResponse, nil
 }
 
 // GetAccountUser gets the Incapsula user status
-func (c *Client) GetAccountUser(accountID int, email string) (*UserApisResponse, error) {
+func (c *Client) GetAccountUser(ctx context.Context, accountID int, email string) (*UserApisResponse, error) {
 	log.Printf("[INFO] Getting Incapsula user status for email id: %s\n", email)
 
 	// Get to Incapsula
 	reqURL := fmt.Sprintf("%s/%s/%s?caid=%d", c.config.BaseURLAPI, endpointUserOperationNew, email, accountID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadAccountUser)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadAccountUser)
 
 	if err != nil {
-		return nil, fmt.Errorf("Error getting user %s: %s", email, err)
+		return nil, fmt.Errorf("Error getting user %s: %w", email, err)
 	}
 
 	// Read the body
@@ -135,26 +134,25 @@ func (c *Client) GetAccountUser(accountID int, email string) (*UserApisResponse,
 	responseBody, err := ioutil.ReadAll(resp.Body)
 
 	// Dump JSON
-	log.Printf("[DEBUG] Incapsula user status JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Incapsula user status JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when getting User %s: %s", resp.StatusCode, email, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(email), "Error status code %d from Incapsula service when getting User %s: %s", resp.StatusCode, email, string(responseBody))
 	}
 
 	// Parse the JSON
 	var userStatusResponse UserApisResponse
 	err = json.Unmarshal(responseBody, &userStatusResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing user status JSON response for user id %s: %s", email, err)
+		return nil, fmt.Errorf("Error parsing user status JSON response for user id %s: %w", email, err)
 	}
 
-	log.Printf("[INFO] ResponseStruct : %+v\n", userStatusResponse)
 	return &userStatusResponse, nil
 }
 
 // UpdateAccountUser User Roles
 // Pass nil for roleIds or approvedIps to leave them unchanged (for PATCH semantics)
-func (c *Client) UpdateAccountUser(accountID int, email string, roleIds []interface{}, approvedIps []interface{}) (*UserApisUpdateResponse, error) {
+func (c *Client) UpdateAccountUser(ctx context.Context, accountID int, email string, roleIds []interface{}, approvedIps []interface{}) (*UserApisUpdateResponse, error) {
 	log.Printf("[INFO] Update Incapsula User for email: %s (account ID %d)\n", email, accountID)
 	log.Printf("[DEBUG] UpdateAccountUser called with roleIds=%v (nil: %v), approvedIps=%v (nil: %v)\n",
 		roleIds, roleIds == nil, approvedIps, approvedIps == nil)
@@ -186,19 +184,19 @@ func (c *Client) UpdateAccountUser(accountID int, email string, roleIds []interf
 	}
 
 	userJSON, err := json.Marshal(userUpdateReq)
-	log.Printf("[DEBUG] Final JSON payload: %s\n", string(userJSON))
+	log.Printf("[DEBUG] Final JSON payload: %s\n", redactedBody(contentTypeApplicationJson, userJSON))
 	if err != nil {
-		return nil, fmt.Errorf("Failed to JSON marshal IncapRule: %s", err)
+		return nil, fmt.Errorf("Failed to JSON marshal IncapRule: %w", err)
 	}
 
 	reqURL := fmt.Sprintf("%s/%s/%s?caid=%d", c.config.BaseURLAPI, endpointUserOperationNew, email, accountID)
 
 	log.Printf("[INFO] Req: %s\n", reqURL)
 	log.Printf("[INFO] json: %s\n", userJSON)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPatch, reqURL, userJSON, UpdateAccountUser)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPatch, reqURL, userJSON, UpdateAccountUser)
 
 	if err != nil {
-		return nil, fmt.Errorf("Error updating user email %s: %s", email, err)
+		return nil, fmt.Errorf("Error updating user email %s: %w", email, err)
 	}
 
 	// Read the body
@@ -206,26 +204,25 @@ func (c *Client) UpdateAccountUser(accountID int, email string, roleIds []interf
 	responseBody, err := ioutil.ReadAll(resp.Body)
 
 	// Dump JSON
-	log.Printf("[DEBUG] Incapsula update user JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Incapsula update user JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 
 	// Look at the response status code from Incapsula
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when updating User %s: %s", resp.StatusCode, email, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(email), "Error status code %d from Incapsula service when updating User %s: %s", resp.StatusCode, email, string(responseBody))
 	}
 
 	// Parse the JSON
 	var userUpdateResponse UserApisUpdateResponse
 	err = json.Unmarshal(responseBody, &userUpdateResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing update user JSON response for email %s: %s", email, err)
+		return nil, fmt.Errorf("Error parsing update user JSON response for email %s: %w", email, err)
 	}
 
-	log.Printf("[INFO] ResponseStruct : %+v\n", userUpdateResponse)
 	return &userUpdateResponse, nil
 }
 
 // DeleteAccountUser deletes a user from Incapsula
-func (c *Client) DeleteAccountUser(accountID int, email string) error {
+func (c *Client) DeleteAccountUser(ctx context.Context, accountID int, email string) error {
 	// Specifically shaded this struct, no need to share across funcs or export
 	// We only care about the response code and possibly the message
 	type UserDeleteResponse struct {
@@ -238,10 +235,10 @@ func (c *Client) DeleteAccountUser(accountID int, email string) error {
 	// Delete form to Incapsula
 
 	reqURL := fmt.Sprintf("%s/%s/%s?caid=%d", c.config.BaseURLAPI, endpointUserOperationNew, email, accountID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodDelete, reqURL, nil, DeleteAccountUser)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, DeleteAccountUser)
 
 	if err != nil {
-		return fmt.Errorf("Error from Incapsula service when deleting USER: %s %s", email, err)
+		return fmt.Errorf("Error from Incapsula service when deleting USER: %s %w", email, err)
 	}
 
 	// Read the body
@@ -249,17 +246,17 @@ func (c *Client) DeleteAccountUser(accountID int, email string) error {
 	responseBody, err := ioutil.ReadAll(resp.Body)
 
 	// Dump JSON
-	log.Printf("[DEBUG] Incapsula delete user JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Incapsula delete user JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from Incapsula service when deleting User %s: %s", resp.StatusCode, email, string(responseBody))
+		return newAPIError(resp, responseBody, apiResourceIDs(email), "Error status code %d from Incapsula service when deleting User %s: %s", resp.StatusCode, email, string(responseBody))
 	}
 
 	// Parse the JSON
 	var userDeleteResponse UserDeleteResponse
 	err = json.Unmarshal(responseBody, &userDeleteResponse)
 	if err != nil {
-		return fmt.Errorf("Error parsing delete user JSON response for user %s : %s", email, err)
+		return fmt.Errorf("Error parsing delete user JSON response for user %s : %w", email, err)
 	}
 
 	return nil
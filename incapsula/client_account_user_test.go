@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -21,7 +22,7 @@ func TestClientAddUserBadConnection(t *testing.T) {
 	roleIds := make([]interface{}, 1)
 	roleIds[0] = 0
 	approvedIps := make([]interface{}, 0)
-	UserAddResponse, err := client.AddAccountUser(0, email, "", "", roleIds, approvedIps)
+	UserAddResponse, err := client.AddAccountUser(context.Background(), 0, email, "", "", roleIds, approvedIps)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -49,7 +50,7 @@ func TestClientAddUserBadJSON(t *testing.T) {
 	roleIds := make([]interface{}, 1)
 	roleIds[0] = 10
 	approvedIps := make([]interface{}, 0)
-	UserAddResponse, err := client.AddAccountUser(accountID, email, "f", "l", roleIds, approvedIps)
+	UserAddResponse, err := client.AddAccountUser(context.Background(), accountID, email, "f", "l", roleIds, approvedIps)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -70,7 +71,7 @@ func TestClientUserStatusBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	accountID := 123
 	email := "example@example.com"
-	UserStatusResponse, err := client.GetAccountUser(accountID, email)
+	UserStatusResponse, err := client.GetAccountUser(context.Background(), accountID, email)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -95,7 +96,7 @@ func TestClientUserStatusBadJSON(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	UserStatusResponse, err := client.GetAccountUser(accountID, email)
+	UserStatusResponse, err := client.GetAccountUser(context.Background(), accountID, email)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -116,7 +117,7 @@ func TestClientDeleteUserBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	accountID := 123
 	email := "example@example.com"
-	err := client.DeleteAccountUser(accountID, email)
+	err := client.DeleteAccountUser(context.Background(), accountID, email)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -138,7 +139,7 @@ func TestClientDeleteUserBadJSON(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	err := client.DeleteAccountUser(accountID, email)
+	err := client.DeleteAccountUser(context.Background(), accountID, email)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -159,7 +160,7 @@ func TestClientUpdateUserBadConnection(t *testing.T) {
 	roleIds := make([]interface{}, 1)
 	roleIds[0] = 10
 	approvedIps := make([]interface{}, 0)
-	updateUserResponse, err := client.UpdateAccountUser(accountID, email, roleIds, approvedIps)
+	updateUserResponse, err := client.UpdateAccountUser(context.Background(), accountID, email, roleIds, approvedIps)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -188,7 +189,7 @@ func TestClientUpdateUserBadJSON(t *testing.T) {
 	approvedIps := make([]interface{}, 0)
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	updateUserResponse, err := client.UpdateAccountUser(accountID, email, roleIds, approvedIps)
+	updateUserResponse, err := client.UpdateAccountUser(context.Background(), accountID, email, roleIds, approvedIps)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
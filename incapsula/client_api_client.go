@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -42,7 +43,7 @@ type MetaData struct {
 }
 
 // PatchAPIClient updates or regenerates an API client using the unified PATCH endpoint
-func (c *Client) PatchAPIClient(accountID int, clientID string, req *APIClientUpdateRequest) (*APIClientResponse, error) {
+func (c *Client) PatchAPIClient(ctx context.Context, accountID int, clientID string, req *APIClientUpdateRequest) (*APIClientResponse, error) {
 	url := fmt.Sprintf("%s%s/%s", c.config.BaseURLAPI, endpointAPIClient, clientID)
 
 	body, err := json.Marshal(req)
@@ -52,11 +53,11 @@ func (c *Client) PatchAPIClient(accountID int, clientID string, req *APIClientUp
 
 	params := GetRequestParamsWithCaid(accountID)
 
-	log.Printf("[DEBUG] Patch API client URL: %s, Request:%+v, Params: %s, Body: %s\n", url, req, params, body)
+	log.Printf("[DEBUG] Patch API client URL: %s, Params: %s, Body: %s\n", url, params, redactedBody(contentTypeApplicationJson, body))
 
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodPatch, url, body, params, UpdateApiClient)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodPatch, url, body, params, UpdateApiClient)
 	if err != nil {
-		return nil, fmt.Errorf("Error updating api_client with Id %s: %s", clientID, err)
+		return nil, fmt.Errorf("Error updating api_client with Id %s: %w", clientID, err)
 	}
 
 	defer resp.Body.Close()
@@ -66,18 +67,17 @@ func (c *Client) PatchAPIClient(accountID int, clientID string, req *APIClientUp
 	var apiClientResponse APIClientResponse
 	err = json.Unmarshal([]byte(responseBody), &apiClientResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing update api_client JSON response for id %s: %s", clientID, err)
+		return nil, fmt.Errorf("Error parsing update api_client JSON response for id %s: %w", clientID, err)
 	}
 
 	// Look at the response status code from Incapsula
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when updating api_client %s: %s", resp.StatusCode, clientID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(clientID), "Error status code %d from Incapsula service when updating api_client %s: %s", resp.StatusCode, clientID, string(responseBody))
 	}
-	log.Printf("[DEBUG]  Create API Response : %+v", apiClientResponse)
 	return &apiClientResponse, nil
 }
 
-func (c *Client) GetAPIClient(accountID int, clientID string) (*APIClientResponse, error) {
+func (c *Client) GetAPIClient(ctx context.Context, accountID int, clientID string) (*APIClientResponse, error) {
 
 	log.Printf("[DEBUG] Reading incapsula api_client with account_id:%d, client_id:%s", accountID, clientID)
 
@@ -87,10 +87,10 @@ func (c *Client) GetAPIClient(accountID int, clientID string) (*APIClientRespons
 
 	log.Printf("[DEBUG] GET URL: %s, params: %s", reqURL, params)
 
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodGet, reqURL, nil, params, ReadApiClient)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, params, ReadApiClient)
 
 	if err != nil {
-		return nil, fmt.Errorf("Error getting api_client with id %s: %s", clientID, err)
+		return nil, fmt.Errorf("Error getting api_client with id %s: %w", clientID, err)
 	}
 
 	// Read the body
@@ -98,19 +98,18 @@ func (c *Client) GetAPIClient(accountID int, clientID string) (*APIClientRespons
 	responseBody, err := ioutil.ReadAll(resp.Body)
 
 	// Dump JSON
-	log.Printf("[DEBUG] Incapsula api_client status JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Incapsula api_client status JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when getting api_client %s: %s", resp.StatusCode, clientID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(clientID), "Error status code %d from Incapsula service when getting api_client %s: %s", resp.StatusCode, clientID, string(responseBody))
 	}
 
 	// Parse the JSON
 	var apiClientResponseTemp APIClientResponseTemp
 	err = json.Unmarshal(responseBody, &apiClientResponseTemp)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing api_client status JSON response for api_client id %s: %s", clientID, err)
+		return nil, fmt.Errorf("Error parsing api_client status JSON response for api_client id %s: %w", clientID, err)
 	}
-	log.Printf("[INFO] GET Response temp Struct : %+v", apiClientResponseTemp)
 
 	if len(apiClientResponseTemp.Data) == 0 {
 		return nil, nil
@@ -120,7 +119,7 @@ func (c *Client) GetAPIClient(accountID int, clientID string) (*APIClientRespons
 
 }
 
-func (c *Client) CreateAPIClient(accountID int, userEmail string, req *APIClientUpdateRequest) (*APIClientResponse, error) {
+func (c *Client) CreateAPIClient(ctx context.Context, accountID int, userEmail string, req *APIClientUpdateRequest) (*APIClientResponse, error) {
 	reqURL := fmt.Sprintf("%s%s", c.config.BaseURLAPI, endpointAPIClient)
 
 	params := GetRequestParamsWithCaid(accountID)
@@ -132,12 +131,12 @@ func (c *Client) CreateAPIClient(accountID int, userEmail string, req *APIClient
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	log.Printf("[DEBUG] Create API Client URL: %s, params: %s, body:%s", reqURL, params, string(body))
+	log.Printf("[DEBUG] Create API Client URL: %s, params: %s, body:%s", reqURL, params, redactedBody(contentTypeApplicationJson, body))
 
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodPost, reqURL, body, params, CreateApiClient)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodPost, reqURL, body, params, CreateApiClient)
 
 	if err != nil {
-		return nil, fmt.Errorf("Error creating api_client: %s", err)
+		return nil, fmt.Errorf("Error creating api_client: %w", err)
 	}
 
 	// Read the body
@@ -145,22 +144,21 @@ func (c *Client) CreateAPIClient(accountID int, userEmail string, req *APIClient
 	responseBody, err := ioutil.ReadAll(resp.Body)
 
 	if resp.StatusCode != 201 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when creating api_client: %s", resp.StatusCode, string(responseBody))
+		return nil, newAPIError(resp, responseBody, nil, "Error status code %d from Incapsula service when creating api_client: %s", resp.StatusCode, string(responseBody))
 	}
 
 	// Parse the JSON
 	var apiClientResponse APIClientResponse
 	err = json.Unmarshal(responseBody, &apiClientResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing api_client status JSON response for api_client: %s", err)
+		return nil, fmt.Errorf("Error parsing api_client status JSON response for api_client: %w", err)
 	}
 
-	log.Printf("[DEBUG]  Create API Response : %+v", apiClientResponse)
 	return &apiClientResponse, nil
 
 }
 
-func (c *Client) DeleteAPIClient(accountID int, clientID string) error {
+func (c *Client) DeleteAPIClient(ctx context.Context, accountID int, clientID string) error {
 
 	log.Printf("[INFO] Deleting api client with ID: %s", clientID)
 
@@ -168,14 +166,14 @@ func (c *Client) DeleteAPIClient(accountID int, clientID string) error {
 	log.Printf("[DEBUG] Deleting api client URL: %s\n", string(requestUrl))
 
 	params := GetRequestParamsWithCaid(accountID)
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodDelete, requestUrl, nil, params, DeleteApiClient)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodDelete, requestUrl, nil, params, DeleteApiClient)
 
 	if err != nil {
-		return fmt.Errorf("Error from Incapsula service when deleting api-client: %s %s", clientID, err)
+		return fmt.Errorf("Error from Incapsula service when deleting api-client: %s %w", clientID, err)
 	}
 
 	if resp.StatusCode != 204 {
-		return fmt.Errorf("Error status code %d from Incapsula service when deleting api-client %s:%s", resp.StatusCode, resp.Body, clientID)
+		return newAPIError(resp, nil, apiResourceIDs(clientID), "Error status code %d from Incapsula service when deleting api-client %s:%s", resp.StatusCode, resp.Body, clientID)
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -18,7 +19,7 @@ func TestClientAddApiClientBadConnection(t *testing.T) {
 	request := &APIClientUpdateRequest{}
 	request.Name = "test"
 
-	apiClientResponse, err := client.CreateAPIClient(1234, email, request)
+	apiClientResponse, err := client.CreateAPIClient(context.Background(), 1234, email, request)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -43,7 +44,7 @@ func TestClientAddApiClientBadJSON(t *testing.T) {
 	request := &APIClientUpdateRequest{}
 	request.Name = "test"
 
-	apiClientResponse, err := client.CreateAPIClient(accountID, email, request)
+	apiClientResponse, err := client.CreateAPIClient(context.Background(), accountID, email, request)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -63,7 +64,7 @@ func TestClientApiClientStatusBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	accountID := 123
-	apiClientResponse, err := client.GetAPIClient(accountID, "1234")
+	apiClientResponse, err := client.GetAPIClient(context.Background(), accountID, "1234")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -88,7 +89,7 @@ func TestClientApiClientStatusBadJSON(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	apiClientResponse, err := client.GetAPIClient(accountID, "1234")
+	apiClientResponse, err := client.GetAPIClient(context.Background(), accountID, "1234")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -104,7 +105,7 @@ func TestClientDeleteApiClientBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	accountID := 123
-	err := client.DeleteAPIClient(accountID, "1234")
+	err := client.DeleteAPIClient(context.Background(), accountID, "1234")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -121,7 +122,7 @@ func TestClientUpdateApiClientBadConnection(t *testing.T) {
 	name := "testest"
 	request := &APIClientUpdateRequest{}
 	request.Name = name
-	apiClientResponse, err := client.PatchAPIClient(accountID, clientID, request)
+	apiClientResponse, err := client.PatchAPIClient(context.Background(), accountID, clientID, request)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -149,7 +150,7 @@ func TestClientUpdateApiClientBadJSON(t *testing.T) {
 	request.Name = "test"
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	apiClientResponse, err := client.PatchAPIClient(accountID, clientID, request)
+	apiClientResponse, err := client.PatchAPIClient(context.Background(), accountID, clientID, request)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -2,6 +2,7 @@ package incapsula
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -73,7 +74,7 @@ type ApiSecurityApiConfigDeleteResponse struct {
 	IsError bool   `json:"isError"`
 }
 
-func (c *Client) CreateApiSecurityApiConfig(siteId int64, apiConfigPayload *ApiSecurityApiConfigPostPayload) (*ApiSecurityApiConfigPostResponse, error) {
+func (c *Client) CreateApiSecurityApiConfig(ctx context.Context, siteId int64, apiConfigPayload *ApiSecurityApiConfigPostPayload) (*ApiSecurityApiConfigPostResponse, error) {
 	log.Printf("[INFO] Creating Incapsula API Security API Configuration for Site ID %d\\n", siteId)
 
 	body := &bytes.Buffer{}
@@ -136,9 +137,9 @@ func (c *Client) CreateApiSecurityApiConfig(siteId int64, apiConfigPayload *ApiS
 
 	reqURL := fmt.Sprintf("%s%s%d", c.config.BaseURLAPI, apiConfigUrl, siteId)
 	contentType := writer.FormDataContentType()
-	resp, err := c.DoFormDataRequestWithHeaders(http.MethodPost, reqURL, body.Bytes(), contentType, CreateApiSecApiConfig)
+	resp, err := c.DoFormDataRequestWithHeaders(ctx, http.MethodPost, reqURL, body.Bytes(), contentType, CreateApiSecApiConfig)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error adding API Security API Config for site %d: %s", siteId, err)
+		return nil, fmt.Errorf("[ERROR] Error adding API Security API Config for site %d: %w", siteId, err)
 	}
 
 	// Read the body
@@ -147,20 +148,20 @@ func (c *Client) CreateApiSecurityApiConfig(siteId int64, apiConfigPayload *ApiS
 	log.Printf("[DEBUG] Incapsula Create Api-Security API Config JSON response: %s\n", string(responseBody))
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service while creating API Security API Config for Site ID %d: %v", resp.StatusCode, siteId, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteId), "Error status code %d from Incapsula service while creating API Security API Config for Site ID %d: %v", resp.StatusCode, siteId, string(responseBody))
 	}
 	// Dump JSON
 	var apiAddResponse ApiSecurityApiConfigPostResponse
 	err = json.Unmarshal([]byte(responseBody), &apiAddResponse)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error parsing add API Security API Config JSON response for site id %d: %s", siteId, err)
+		return nil, fmt.Errorf("[ERROR] Error parsing add API Security API Config JSON response for site id %d: %w", siteId, err)
 	}
 
 	return &apiAddResponse, nil
 }
 
 // UpdateApiSecurityApiConfig updates the Api-Security Api Config
-func (c *Client) UpdateApiSecurityApiConfig(siteId int64, apiId string, apiConfigPayload *ApiSecurityApiConfigPostPayload) (*ApiSecurityApiConfigPostResponse, error) {
+func (c *Client) UpdateApiSecurityApiConfig(ctx context.Context, siteId int64, apiId string, apiConfigPayload *ApiSecurityApiConfigPostPayload) (*ApiSecurityApiConfigPostResponse, error) {
 	log.Printf("[INFO] Updating Incapsula API Security API Configuration for Site ID %d, API Config ID %s\n", siteId, apiId)
 
 	bodyMap := map[string]interface{}{}
@@ -187,10 +188,10 @@ func (c *Client) UpdateApiSecurityApiConfig(siteId int64, apiId string, apiConfi
 
 	reqURL := fmt.Sprintf("%s%s%d/%s", c.config.BaseURLAPI, apiConfigUrl, siteId, apiId)
 
-	resp, err := c.DoFormDataRequestWithHeaders(http.MethodPost, reqURL, body, contentType, CreateMtlsClientToImpervaCertifiate)
+	resp, err := c.DoFormDataRequestWithHeaders(ctx, http.MethodPost, reqURL, body, contentType, CreateMtlsClientToImpervaCertifiate)
 
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error updating API Security API Config for site id %d, API id %s :%s", siteId, apiId, err)
+		return nil, fmt.Errorf("[ERROR] Error updating API Security API Config for site id %d, API id %s :%w", siteId, apiId, err)
 	}
 
 	// Read the body
@@ -217,13 +218,13 @@ func (c *Client) UpdateApiSecurityApiConfig(siteId int64, apiId string, apiConfi
 }
 
 // GetApiSecurityApiConfig gets the Api-Security Api Config
-func (c *Client) GetApiSecurityApiConfig(siteId int64, apiId int64) (*ApiSecurityApiConfigGetResponse, error) {
+func (c *Client) GetApiSecurityApiConfig(ctx context.Context, siteId int64, apiId int64) (*ApiSecurityApiConfigGetResponse, error) {
 	log.Printf("[INFO] Getting Incapsula Api-Security API Config for Site ID %d, API Config ID %d\n", siteId, apiId)
 
 	url := fmt.Sprintf("%s%s%d/%d", c.config.BaseURLAPI, apiConfigUrl, siteId, apiId)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, url, nil, ReadApiSecApiConfig)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, url, nil, ReadApiSecApiConfig)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when reading Api-Security Api Config for Api ID %d: %s", apiId, err)
+		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when reading Api-Security Api Config for Api ID %d: %w", apiId, err)
 	}
 
 	// Read the body
@@ -233,26 +234,26 @@ func (c *Client) GetApiSecurityApiConfig(siteId int64, apiId int64) (*ApiSecurit
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when reading Api-Security Api Config for Api ID %d: %s", resp.StatusCode, apiId, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(apiId), "Error status code %d from Incapsula service when reading Api-Security Api Config for Api ID %d: %s", resp.StatusCode, apiId, string(responseBody))
 	}
 
 	// Parse the JSON
 	var apiConfigGetResponse ApiSecurityApiConfigGetResponse
 	err = json.Unmarshal([]byte(responseBody), &apiConfigGetResponse)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error parsing GET Api-Security Api Config JSON response for API ID %d: %s\nresponse: %s", apiId, err, string(responseBody))
+		return nil, fmt.Errorf("[ERROR] Error parsing GET Api-Security Api Config JSON response for API ID %d: %w\nresponse: %s", apiId, err, string(responseBody))
 	}
 	return &apiConfigGetResponse, nil
 }
 
 // GetApiSecurityApiSwaggerConfig gets the Api-Security  API Config Swagger file content
-func (c *Client) GetApiSecurityApiSwaggerConfig(siteId int64, apiId int64) (*ApiSecurityApiConfigGetFileResponse, error) {
+func (c *Client) GetApiSecurityApiSwaggerConfig(ctx context.Context, siteId int64, apiId int64) (*ApiSecurityApiConfigGetFileResponse, error) {
 	log.Printf("[INFO] Getting Incapsula Api-Security API Swagger Config for Site ID %d, API Config ID %d\n", siteId, apiId)
 
 	url := fmt.Sprintf("%s%sfile/%d/%d", c.config.BaseURLAPI, apiConfigUrl, siteId, apiId)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, url, nil, "")
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, url, nil, "")
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when reading Api-Security Api Config for Api ID %d: %s", apiId, err)
+		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when reading Api-Security Api Config for Api ID %d: %w", apiId, err)
 	}
 
 	// Read the body
@@ -262,27 +263,27 @@ func (c *Client) GetApiSecurityApiSwaggerConfig(siteId int64, apiId int64) (*Api
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when reading Api-Security Api Config for Api ID %d: %s", resp.StatusCode, apiId, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(apiId), "Error status code %d from Incapsula service when reading Api-Security Api Config for Api ID %d: %s", resp.StatusCode, apiId, string(responseBody))
 	}
 
 	// Dump JSON
 	var apiSecurityApiConfigGetFileResponse ApiSecurityApiConfigGetFileResponse
 	err = json.Unmarshal([]byte(responseBody), &apiSecurityApiConfigGetFileResponse)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error parsing GET Api-Security Api Config JSON response for API ID %d: %s\nresponse: %s", apiId, err, string(responseBody))
+		return nil, fmt.Errorf("[ERROR] Error parsing GET Api-Security Api Config JSON response for API ID %d: %w\nresponse: %s", apiId, err, string(responseBody))
 	}
 	return &apiSecurityApiConfigGetFileResponse, nil
 }
 
 // DeleteApiSecurityApiConfig deletes the Api-Security Api + endpoints Config
-func (c *Client) DeleteApiSecurityApiConfig(siteID int64, apiID string) error {
+func (c *Client) DeleteApiSecurityApiConfig(ctx context.Context, siteID int64, apiID string) error {
 	log.Printf("[INFO] Deleting Incapsula API Security API for ID %s\n", apiID)
 
 	// Delete request to Incapsula
 	reqURL := fmt.Sprintf("%s%s%d/%s", c.config.BaseURLAPI, apiConfigUrl, siteID, apiID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodDelete, reqURL, nil, DeleteApiSecApiConfig)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, DeleteApiSecApiConfig)
 	if err != nil {
-		return fmt.Errorf("[ERROR] Error from Incapsula service when deleting API Secirity API Config with Site ID %d, API ID %s, : %s", siteID, apiID, err)
+		return fmt.Errorf("[ERROR] Error from Incapsula service when deleting API Secirity API Config with Site ID %d, API ID %s, : %w", siteID, apiID, err)
 	}
 
 	// Read the body
@@ -291,13 +292,13 @@ func (c *Client) DeleteApiSecurityApiConfig(siteID int64, apiID string) error {
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("[ERROR] Error status code %d from Incapsula service when deleting API Security API Config for Site ID %d, API Config ID %s: %s", resp.StatusCode, siteID, apiID, string(responseBody))
+		return newAPIError(resp, responseBody, apiResourceIDs(siteID, apiID), "[ERROR] Error status code %d from Incapsula service when deleting API Security API Config for Site ID %d, API Config ID %s: %s", resp.StatusCode, siteID, apiID, string(responseBody))
 	}
 	// Dump JSON
 	var apiSecurityApiConfigDeleteResponse ApiSecurityApiConfigDeleteResponse
 	err = json.Unmarshal([]byte(responseBody), &apiSecurityApiConfigDeleteResponse)
 	if err != nil {
-		return fmt.Errorf("[ERROR] Error parsing delete API Secirity API Config JSON response for Site ID %d, API Config ID %s: %s", siteID, apiID, err)
+		return fmt.Errorf("[ERROR] Error parsing delete API Secirity API Config JSON response for Site ID %d, API Config ID %s: %w", siteID, apiID, err)
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -19,7 +20,7 @@ func TestGetApiSecurityEndpointConfigBadConnection(t *testing.T) {
 	apiID := int64(100)
 	endpointId := "92"
 	//
-	apiSecurityEndpointConfigGetResponse, err := client.GetApiSecurityEndpointConfig(apiID, endpointId)
+	apiSecurityEndpointConfigGetResponse, err := client.GetApiSecurityEndpointConfig(context.Background(), apiID, endpointId)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -50,7 +51,7 @@ func TestGetApiSecurityEndpointConfigBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	apiSecurityEndpointConfigGetResponse, err := client.GetApiSecurityEndpointConfig(apiConfigID, endpointId)
+	apiSecurityEndpointConfigGetResponse, err := client.GetApiSecurityEndpointConfig(context.Background(), apiConfigID, endpointId)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -88,7 +89,7 @@ func TestGetApiSecurityEndpointConfigInvalidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	apiSecurityEndpointConfigGetResponse, err := client.GetApiSecurityEndpointConfig(apiConfigID, endpointId)
+	apiSecurityEndpointConfigGetResponse, err := client.GetApiSecurityEndpointConfig(context.Background(), apiConfigID, endpointId)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -132,7 +133,7 @@ func TestGetApiSecurityEndpointConfigValidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	apiSecurityEndpointConfigGetResponse, err := client.GetApiSecurityEndpointConfig(apiConfigID, endpointId)
+	apiSecurityEndpointConfigGetResponse, err := client.GetApiSecurityEndpointConfig(context.Background(), apiConfigID, endpointId)
 	if err != nil {
 		t.Errorf("Should not have received an error : %s", err.Error())
 	}
@@ -161,7 +162,7 @@ func TestPostApiSecurityEndpointConfigBadConnection(t *testing.T) {
 		SpecificationViolationAction: "BLOCK_REQUEST",
 	}
 
-	apiSecurityEndpointConfigPostResponse, err := client.PostApiSecurityEndpointConfig(apiConfigID, endpointId, &payload)
+	apiSecurityEndpointConfigPostResponse, err := client.PostApiSecurityEndpointConfig(context.Background(), apiConfigID, endpointId, &payload)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -201,7 +202,7 @@ func TestPostApiSecurityEndpointConfigBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	//
-	apiSecurityEndpointConfigPostResponse, err := client.PostApiSecurityEndpointConfig(apiConfigID, endpointId, &payload)
+	apiSecurityEndpointConfigPostResponse, err := client.PostApiSecurityEndpointConfig(context.Background(), apiConfigID, endpointId, &payload)
 	//
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -250,7 +251,7 @@ func TestPostApiSecurityEndpointConfigInvalidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	//
-	apiSecurityEndpointConfigPostResponse, err := client.PostApiSecurityEndpointConfig(apiConfigID, endpointId, &payload)
+	apiSecurityEndpointConfigPostResponse, err := client.PostApiSecurityEndpointConfig(context.Background(), apiConfigID, endpointId, &payload)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -296,7 +297,7 @@ func TestPostApiSecurityEndpointConfigValidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	//
-	apiSecurityEndpointConfigPostResponse, err := client.PostApiSecurityEndpointConfig(apiConfigID, endpointId, &payload)
+	apiSecurityEndpointConfigPostResponse, err := client.PostApiSecurityEndpointConfig(context.Background(), apiConfigID, endpointId, &payload)
 
 	if err != nil {
 		t.Errorf("Should not have received an error : %s", err.Error())
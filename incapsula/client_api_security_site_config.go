@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -37,16 +38,16 @@ type ApiSecuritySiteConfigPostPayload struct {
 }
 
 // ReadApiSecuritySiteConfig gets the Api-Security Site Config
-func (c *Client) ReadApiSecuritySiteConfig(siteId int64) (*ApiSecuritySiteConfigGetResponse, error) {
+func (c *Client) ReadApiSecuritySiteConfig(ctx context.Context, siteId int64) (*ApiSecuritySiteConfigGetResponse, error) {
 	log.Printf("[INFO] Getting Incapsula Api-Security Site Config: %d\n", siteId)
 
 	// Post form to Incapsula
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet,
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet,
 		fmt.Sprintf("%s%s%d", c.config.BaseURLAPI, siteConfigUrl, siteId),
 		nil,
 		ReadApiSecSiteConfig)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR]Error from Incapsula service while reading Api-Security Site Config for site ID %d: %s", siteId, err)
+		return nil, fmt.Errorf("[ERROR]Error from Incapsula service while reading Api-Security Site Config for site ID %d: %w", siteId, err)
 	}
 
 	// Read the body
@@ -58,33 +59,33 @@ func (c *Client) ReadApiSecuritySiteConfig(siteId int64) (*ApiSecuritySiteConfig
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when reading Api-Security Site Config for site ID %d: %s", resp.StatusCode, siteId, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteId), "Error status code %d from Incapsula service when reading Api-Security Site Config for site ID %d: %s", resp.StatusCode, siteId, string(responseBody))
 	}
 
 	// Parse the JSON
 	var siteConfigGetResponse ApiSecuritySiteConfigGetResponse
 	err = json.Unmarshal(responseBody, &siteConfigGetResponse)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error parsing GET Api-Security Site Config JSON response for site ID %d: %s\nresponse: %s", siteId, err, string(responseBody))
+		return nil, fmt.Errorf("[ERROR] Error parsing GET Api-Security Site Config JSON response for site ID %d: %w\nresponse: %s", siteId, err, string(responseBody))
 	}
 
 	return &siteConfigGetResponse, nil
 }
 
 // UpdateApiSecuritySiteConfig updates an Api-Security Site Config
-func (c *Client) UpdateApiSecuritySiteConfig(siteId int64, siteConfigPayload *ApiSecuritySiteConfigPostPayload) (*ApiSecuritySiteConfigPostResponse, error) {
+func (c *Client) UpdateApiSecuritySiteConfig(ctx context.Context, siteId int64, siteConfigPayload *ApiSecuritySiteConfigPostPayload) (*ApiSecuritySiteConfigPostResponse, error) {
 	siteConfigJSON, err := json.Marshal(siteConfigPayload)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to JSON marshal api security site config: %s", err)
+		return nil, fmt.Errorf("Failed to JSON marshal api security site config: %w", err)
 	}
 
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost,
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost,
 		fmt.Sprintf("%s"+siteConfigUrl+"%d", c.config.BaseURLAPI, siteId),
 		siteConfigJSON,
 		UpdateApiSecSiteConfig)
 
 	if err != nil {
-		return nil, fmt.Errorf("Error from Incapsula service while updating API security site configuration for site ID %d: %s", siteId, err)
+		return nil, fmt.Errorf("Error from Incapsula service while updating API security site configuration for site ID %d: %w", siteId, err)
 	}
 
 	// Read the body
@@ -96,14 +97,14 @@ func (c *Client) UpdateApiSecuritySiteConfig(siteId int64, siteConfigPayload *Ap
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when updating api-security site configuration: %s", resp.StatusCode, string(responseBody))
+		return nil, newAPIError(resp, responseBody, nil, "Error status code %d from Incapsula service when updating api-security site configuration: %s", resp.StatusCode, string(responseBody))
 	}
 
 	// Parse the JSON
 	var response ApiSecuritySiteConfigPostResponse
 	err = json.Unmarshal([]byte(responseBody), &response)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing API security JSON response: %s\nresponse: %s", err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing API security JSON response: %w\nresponse: %s", err, string(responseBody))
 	}
 	return &response, nil
 }
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -31,7 +32,7 @@ func TestUpdateApiSecuritySiteConfigBadConnection(t *testing.T) {
 		},
 	}
 
-	apiSecuritySiteConfigPostResponse, err := client.UpdateApiSecuritySiteConfig(
+	apiSecuritySiteConfigPostResponse, err := client.UpdateApiSecuritySiteConfig(context.Background(),
 		int64(siteID),
 		&payload)
 
@@ -77,7 +78,7 @@ func TestUpdateApiSecuritySiteConfigBadJSON(t *testing.T) {
 		},
 	}
 
-	apiSecuritySiteConfigPostResponse, err := client.UpdateApiSecuritySiteConfig(
+	apiSecuritySiteConfigPostResponse, err := client.UpdateApiSecuritySiteConfig(context.Background(),
 		int64(siteID),
 		&payload)
 
@@ -117,7 +118,7 @@ func TestUpdateApiSecuritySiteConfigInvalidSiteConfig(t *testing.T) {
 		ApiOnlySite: true,
 	}
 
-	apiSecuritySiteConfigPostResponse, err := client.UpdateApiSecuritySiteConfig(
+	apiSecuritySiteConfigPostResponse, err := client.UpdateApiSecuritySiteConfig(context.Background(),
 		int64(siteID),
 		&payload)
 
@@ -163,7 +164,7 @@ func TestUpdateApiSecuritySiteConfigValidSiteConfig(t *testing.T) {
 		},
 	}
 
-	apiSecuritySiteConfigPostResponse, err := client.UpdateApiSecuritySiteConfig(
+	apiSecuritySiteConfigPostResponse, err := client.UpdateApiSecuritySiteConfig(context.Background(),
 		int64(siteID),
 		&payload)
 
@@ -186,7 +187,7 @@ func TestClientReadApiSecuritySiteConfigBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := int64(42)
 
-	apiSecuritySiteConfigGetResponse, err := client.ReadApiSecuritySiteConfig(siteID)
+	apiSecuritySiteConfigGetResponse, err := client.ReadApiSecuritySiteConfig(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -216,7 +217,7 @@ func TestClientReadApiSecuritySiteConfigBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	apiSecuritySiteConfigGetResponse, err := client.ReadApiSecuritySiteConfig(siteID)
+	apiSecuritySiteConfigGetResponse, err := client.ReadApiSecuritySiteConfig(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -253,7 +254,7 @@ func TestClientReadApiSecuritySiteConfigInvalidSiteConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	apiSecuritySiteConfigGetResponse, err := client.ReadApiSecuritySiteConfig(siteID)
+	apiSecuritySiteConfigGetResponse, err := client.ReadApiSecuritySiteConfig(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -284,7 +285,7 @@ func TestClientReadApiSecuritySiteConfigValidSiteConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	apiSecuritySiteConfigGetResponse, err := client.ReadApiSecuritySiteConfig(siteID)
+	apiSecuritySiteConfigGetResponse, err := client.ReadApiSecuritySiteConfig(context.Background(), siteID)
 	if err != nil {
 		t.Errorf("Should not have received an error : %s", err.Error())
 	}
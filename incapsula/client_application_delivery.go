@@ -1,14 +1,14 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
 type Compression struct {
@@ -73,48 +73,35 @@ type ApplicationDelivery struct {
 	Redirection      Redirection      `json:"redirection"`
 }
 
-func (c *Client) GetApplicationDelivery(siteID int) (*ApplicationDelivery, diag.Diagnostics) {
+func (c *Client) GetApplicationDelivery(ctx context.Context, siteID int) (*ApplicationDelivery, error) {
 	log.Printf("[INFO] Getting Incapsula Application Delivery for Site ID %d", siteID)
-	return CrudApplicationDelivery("Read", siteID, http.MethodGet, nil, c)
+	return CrudApplicationDelivery(ctx, "Read", siteID, http.MethodGet, nil, c)
 }
 
-func (c *Client) UpdateApplicationDelivery(siteID int, applicationDelivery *ApplicationDelivery) (*ApplicationDelivery, diag.Diagnostics) {
+func (c *Client) UpdateApplicationDelivery(ctx context.Context, siteID int, applicationDelivery *ApplicationDelivery) (*ApplicationDelivery, error) {
 	log.Printf("[INFO] Updating Incapsula Application Delivery for Site ID %d", siteID)
-	var diags diag.Diagnostics
 	applicationDeliveryJSON, err := json.Marshal(applicationDelivery)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed JSON marshalling Application Delivery resource",
-			Detail:   fmt.Sprintf("Failed to JSON marshal Application Delivery for SiteID %d: %s", siteID, err),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to JSON marshal Application Delivery for SiteID %d: %w", siteID, err)
 	}
-	return CrudApplicationDelivery("Update", siteID, http.MethodPut, applicationDeliveryJSON, c)
+	return CrudApplicationDelivery(ctx, "Update", siteID, http.MethodPut, applicationDeliveryJSON, c)
 }
 
-func (c *Client) DeleteApplicationDelivery(siteID int) (*ApplicationDelivery, diag.Diagnostics) {
+func (c *Client) DeleteApplicationDelivery(ctx context.Context, siteID int) (*ApplicationDelivery, error) {
 	log.Printf("[INFO] Deleting Incapsula Application Delivery for Site ID %d", siteID)
-	return CrudApplicationDelivery("Delete", siteID, http.MethodDelete, nil, c)
+	return CrudApplicationDelivery(ctx, "Delete", siteID, http.MethodDelete, nil, c)
 }
 
-func CrudApplicationDelivery(action string, siteID int, httpMethod string, applicationDeliveyData []byte, c *Client) (*ApplicationDelivery, diag.Diagnostics) {
-	var diags diag.Diagnostics
-
+func CrudApplicationDelivery(ctx context.Context, action string, siteID int, httpMethod string, applicationDeliveyData []byte, c *Client) (*ApplicationDelivery, error) {
 	if applicationDeliveyData != nil {
 		log.Printf("[DEBUG] Incapsula %s Application Delivery JSON request: %s\n", action, string(applicationDeliveyData))
 	}
 
 	applicationDeliveryUrl := fmt.Sprintf("%s/sites/%d/settings/delivery", c.config.BaseURLRev2, siteID)
 
-	resp, err := c.DoJsonRequestWithHeaders(httpMethod, applicationDeliveryUrl, applicationDeliveyData, strings.ToLower(action)+"_application_delivery")
+	resp, err := c.DoJsonRequestWithHeaders(ctx, httpMethod, applicationDeliveryUrl, applicationDeliveyData, strings.ToLower(action)+"_application_delivery")
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("Error from Incapsula service when trying to %s Application Delivery", strings.ToLower(action)),
-			Detail:   fmt.Sprintf("Error from Incapsula service when trying to %s Application Delivery for Site ID %d: %s", strings.ToLower(action), siteID, err),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Error from Incapsula service when trying to %s Application Delivery for Site ID %d: %w", strings.ToLower(action), siteID, err)
 	}
 
 	// Read the body
@@ -124,73 +111,50 @@ func CrudApplicationDelivery(action string, siteID int, httpMethod string, appli
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("Error status code %d from Incapsula service when %s Application Delivery", resp.StatusCode, strings.TrimSuffix(action, "e")+"ing"),
-			Detail:   fmt.Sprintf("Error status code %d from Incapsula service when %s Application Delivery for Site ID %d: %s", resp.StatusCode, strings.TrimSuffix(action, "e")+"ing", siteID, string(responseBody)),
-		})
-		return nil, diags
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(strconv.Itoa(siteID)), "Error status code %d from Incapsula service when %s Application Delivery for Site ID %d: %s", resp.StatusCode, strings.TrimSuffix(action, "e")+"ing", siteID, string(responseBody))
 	}
 
 	// Dump JSON
 	var applicationDelivery ApplicationDelivery
 	err = json.Unmarshal([]byte(responseBody), &applicationDelivery)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("Error parsing Application Delivery Response JSON response"),
-			Detail:   fmt.Sprintf("Error parsing Application Delivery Response JSON response for Site ID %d: %s\nresponse: %s", siteID, err, string(responseBody)),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Error parsing Application Delivery Response JSON response for Site ID %d: %w\nresponse: %s", siteID, err, string(responseBody))
 	}
 
 	return &applicationDelivery, nil
 }
 
-func (c *Client) GetErrorPages(siteID int) (*CustomErrorPage, diag.Diagnostics) {
+func (c *Client) GetErrorPages(ctx context.Context, siteID int) (*CustomErrorPage, error) {
 	log.Printf("[INFO] Getting Incapsula Error Pages for Site ID %d", siteID)
-	return CrudErrorPages("Read", siteID, http.MethodGet, nil, c)
+	return CrudErrorPages(ctx, "Read", siteID, http.MethodGet, nil, c)
 }
 
-func (c *Client) UpdateErrorPages(siteID int, errorPages *CustomErrorPage) (*CustomErrorPage, diag.Diagnostics) {
+func (c *Client) UpdateErrorPages(ctx context.Context, siteID int, errorPages *CustomErrorPage) (*CustomErrorPage, error) {
 	log.Printf("[INFO] Updating Incapsula Application Delivery for Site ID %d", siteID)
-	var diags diag.Diagnostics
 	errorPagesJSON, err := json.Marshal(errorPages)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed JSON marshalling Error Pages resource",
-			Detail:   fmt.Sprintf("Failed to JSON marshal Error Pages for SiteID %d: %s", siteID, err),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to JSON marshal Error Pages for SiteID %d: %w", siteID, err)
 	}
-	return CrudErrorPages("Update", siteID, http.MethodPut, errorPagesJSON, c)
+	return CrudErrorPages(ctx, "Update", siteID, http.MethodPut, errorPagesJSON, c)
 }
 
-func (c *Client) DeleteErrorPages(siteID int) (*CustomErrorPage, diag.Diagnostics) {
+func (c *Client) DeleteErrorPages(ctx context.Context, siteID int) (*CustomErrorPage, error) {
 	log.Printf("[INFO] Deleting Incapsula Application Delivery for Site ID %d", siteID)
 	customErrorPage := CustomErrorPage{}
 	errorPagesJSON, _ := json.Marshal(customErrorPage)
-	return CrudErrorPages("Delete", siteID, http.MethodPut, errorPagesJSON, c)
+	return CrudErrorPages(ctx, "Delete", siteID, http.MethodPut, errorPagesJSON, c)
 }
 
-func CrudErrorPages(action string, siteID int, httpMethod string, errorPagesData []byte, c *Client) (*CustomErrorPage, diag.Diagnostics) {
-	var diags diag.Diagnostics
-
+func CrudErrorPages(ctx context.Context, action string, siteID int, httpMethod string, errorPagesData []byte, c *Client) (*CustomErrorPage, error) {
 	if errorPagesData != nil {
 		log.Printf("[DEBUG] Incapsula %s Error Pages JSON request: %s\n", action, string(errorPagesData))
 	}
 
 	errorPagesUrl := fmt.Sprintf("%s/sites/%d/settings/delivery/error-pages", c.config.BaseURLRev2, siteID)
 
-	resp, err := c.DoJsonRequestWithHeaders(httpMethod, errorPagesUrl, errorPagesData, strings.ToLower(action)+"_error_pages")
+	resp, err := c.DoJsonRequestWithHeaders(ctx, httpMethod, errorPagesUrl, errorPagesData, strings.ToLower(action)+"_error_pages")
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("Error from Incapsula service when trying to %s Error Pages", strings.ToLower(action)),
-			Detail:   fmt.Sprintf("Error from Incapsula service when trying to %s Error Pages for Site ID %d: %s", strings.ToLower(action), siteID, err),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Error from Incapsula service when trying to %s Error Pages for Site ID %d: %w", strings.ToLower(action), siteID, err)
 	}
 
 	// Read the body
@@ -200,24 +164,14 @@ func CrudErrorPages(action string, siteID int, httpMethod string, errorPagesData
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("Error status code %d from Incapsula service when %s Error Pages", resp.StatusCode, strings.TrimSuffix(action, "e")+"ing"),
-			Detail:   fmt.Sprintf("Error status code %d from Incapsula service when %s Error Pages for Site ID %d: %s", resp.StatusCode, strings.TrimSuffix(action, "e")+"ing", siteID, string(responseBody)),
-		})
-		return nil, diags
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(strconv.Itoa(siteID)), "Error status code %d from Incapsula service when %s Error Pages for Site ID %d: %s", resp.StatusCode, strings.TrimSuffix(action, "e")+"ing", siteID, string(responseBody))
 	}
 
 	// Dump JSON
 	var customErrorPage CustomErrorPage
 	err = json.Unmarshal([]byte(responseBody), &customErrorPage)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("Error parsing Error Pages Response JSON response for Site ID %d", siteID),
-			Detail:   fmt.Sprintf("Error parsing Error Pages Response JSON response for Site ID %d: %s\nresponse: %s", siteID, err, string(responseBody)),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Error parsing Error Pages Response JSON response for Site ID %d: %w\nresponse: %s", siteID, err, string(responseBody))
 	}
 
 	return &customErrorPage, nil
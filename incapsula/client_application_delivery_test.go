@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -24,7 +25,7 @@ func TestUpdateApplicationDeliveryBadConnection(t *testing.T) {
 		Redirection:      Redirection{},
 	}
 
-	applicationDeliveryResponse, err := client.UpdateApplicationDelivery(
+	applicationDeliveryResponse, err := client.UpdateApplicationDelivery(context.Background(),
 		siteID,
 		&applicationDeliveryPayload)
 
@@ -37,7 +38,7 @@ func TestUpdateApplicationDeliveryBadConnection(t *testing.T) {
 
 	customErrorPagesPayload := CustomErrorPage{}
 
-	errorPages, err := client.UpdateErrorPages(
+	errorPages, err := client.UpdateErrorPages(context.Background(),
 		siteID,
 		&customErrorPagesPayload)
 
@@ -75,7 +76,7 @@ func TestUpdateApplicationDeliveryBadJSON(t *testing.T) {
 		Redirection:      Redirection{},
 	}
 
-	applicationDeliveryResponse, err := client.UpdateApplicationDelivery(
+	applicationDeliveryResponse, err := client.UpdateApplicationDelivery(context.Background(),
 		siteID,
 		&applicationDeliveryPayload)
 
@@ -88,7 +89,7 @@ func TestUpdateApplicationDeliveryBadJSON(t *testing.T) {
 
 	customErrorPagesPayload := CustomErrorPage{}
 
-	errorPages, err := client.UpdateErrorPages(
+	errorPages, err := client.UpdateErrorPages(context.Background(),
 		siteID,
 		&customErrorPagesPayload)
 
@@ -133,15 +134,15 @@ func TestUpdateApplicationDeliveryInvalidConfig(t *testing.T) {
 	//invalid payload
 	payload := ApplicationDelivery{}
 
-	applicationDeliveryResponse, diags := client.UpdateApplicationDelivery(
+	applicationDeliveryResponse, err := client.UpdateApplicationDelivery(context.Background(),
 		siteID,
 		&payload)
 
-	if diags == nil {
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error status code %d from Incapsula service when Updating Application Delivery for Site ID %d", 500, siteID)) {
-		t.Errorf("Should have received a bad Application Delivery error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error status code %d from Incapsula service when Updating Application Delivery for Site ID %d", 500, siteID)) {
+		t.Errorf("Should have received a bad Application Delivery error, got: %s", err.Error())
 	}
 	if applicationDeliveryResponse != nil {
 		t.Errorf("Should have received a nil applicationDeliveryResponse instance")
@@ -149,15 +150,15 @@ func TestUpdateApplicationDeliveryInvalidConfig(t *testing.T) {
 
 	customErrorPagesPayload := CustomErrorPage{}
 
-	errorPages, diags := client.UpdateErrorPages(
+	errorPages, err := client.UpdateErrorPages(context.Background(),
 		siteID,
 		&customErrorPagesPayload)
 
-	if diags == nil {
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error status code %d from Incapsula service when Updating Error Pages for Site ID %d", 500, siteID)) {
-		t.Errorf("Should have received a bad Application Delivery error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error status code %d from Incapsula service when Updating Error Pages for Site ID %d", 500, siteID)) {
+		t.Errorf("Should have received a bad Application Delivery error, got: %s", err.Error())
 	}
 	if errorPages != nil {
 		t.Errorf("Should have received a nil errorPages instance")
@@ -236,22 +237,22 @@ func TestUpdateApplicationDeliveryConfig(t *testing.T) {
 
 	customErrorPagesPayload := CustomErrorPage{}
 
-	applicationDeliveryResponse, diags := client.UpdateApplicationDelivery(
+	applicationDeliveryResponse, err := client.UpdateApplicationDelivery(context.Background(),
 		siteID,
 		&payload)
 
-	if diags != nil {
-		t.Errorf("Should not have received an error : %s", diags[0].Detail)
+	if err != nil {
+		t.Errorf("Should not have received an error : %s", err.Error())
 	}
 	if applicationDeliveryResponse.Network.SupportNonSniClients != true {
 		t.Errorf("Should have received a SupportNonSniClients equal true\n%v", applicationDeliveryResponse)
 	}
 
-	errorPages, diags := client.UpdateErrorPages(
+	errorPages, err := client.UpdateErrorPages(context.Background(),
 		siteID,
 		&customErrorPagesPayload)
-	if diags != nil {
-		t.Errorf("Should not have received an error : %s", diags[0].Detail)
+	if err != nil {
+		t.Errorf("Should not have received an error : %s", err.Error())
 	}
 	if errorPages.DefaultErrorPage == "" || errorPages.CustomErrorPageTemplates.ErrorConnectionTimeout == "" || errorPages.CustomErrorPageTemplates.ErrorConnectionFailed != "" || errorPages.DefaultErrorPage != "<html><body><h1>$TITLE$</h1><div>$BODY$</div></body></html>" {
 		t.Errorf("unexpected error page response: %v", errorPages)
@@ -266,7 +267,7 @@ func TestReadApplicationDeliveryBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := 42
 
-	applicationDeliveryResponse, err := client.GetApplicationDelivery(siteID)
+	applicationDeliveryResponse, err := client.GetApplicationDelivery(context.Background(), siteID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -275,7 +276,7 @@ func TestReadApplicationDeliveryBadConnection(t *testing.T) {
 		t.Errorf("Should have received a nil applicationDeliveryResponse instance")
 	}
 
-	errorPages, err := client.GetErrorPages(siteID)
+	errorPages, err := client.GetErrorPages(context.Background(), siteID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -304,7 +305,7 @@ func TestReadApplicationDeliveryBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	applicationDeliveryResponse, err := client.GetApplicationDelivery(siteID)
+	applicationDeliveryResponse, err := client.GetApplicationDelivery(context.Background(), siteID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -313,7 +314,7 @@ func TestReadApplicationDeliveryBadJSON(t *testing.T) {
 		t.Errorf("Should have received a nil applicationDeliveryResponse instance")
 	}
 
-	errorPages, err := client.GetErrorPages(siteID)
+	errorPages, err := client.GetErrorPages(context.Background(), siteID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -358,25 +359,25 @@ func TestReadApplicationDeliveryInvalidConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	applicationDeliveryResponse, diags := client.GetApplicationDelivery(siteID)
+	applicationDeliveryResponse, err := client.GetApplicationDelivery(context.Background(), siteID)
 
-	if diags == nil {
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error status code %d from Incapsula service when Reading Application Delivery for Site ID %d", 500, siteID)) {
-		t.Errorf("Should have received a bad Application Delivery error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error status code %d from Incapsula service when Reading Application Delivery for Site ID %d", 500, siteID)) {
+		t.Errorf("Should have received a bad Application Delivery error, got: %s", err.Error())
 	}
 	if applicationDeliveryResponse != nil {
 		t.Errorf("Should have received a nil applicationDeliveryResponse instance")
 	}
 
-	errorPages, diags := client.GetErrorPages(siteID)
+	errorPages, err := client.GetErrorPages(context.Background(), siteID)
 
-	if diags == nil {
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error status code %d from Incapsula service when Reading Error Pages for Site ID %d", 500, siteID)) {
-		t.Errorf("Should have received a bad Application Delivery error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error status code %d from Incapsula service when Reading Error Pages for Site ID %d", 500, siteID)) {
+		t.Errorf("Should have received a bad Application Delivery error, got: %s", err.Error())
 	}
 	if errorPages != nil {
 		t.Errorf("Should have received a nil errorPages instance")
@@ -439,10 +440,10 @@ func TestReadApplicationDeliveryConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	applicationDeliveryResponse, diags := client.GetApplicationDelivery(siteID)
+	applicationDeliveryResponse, err := client.GetApplicationDelivery(context.Background(), siteID)
 
-	if diags != nil {
-		t.Errorf("Should not have received an error : %s", diags[0].Detail)
+	if err != nil {
+		t.Errorf("Should not have received an error : %s", err.Error())
 	}
 	if applicationDeliveryResponse == nil {
 		t.Errorf("Should not have received a nil applicationDeliveryResponse instance")
@@ -460,7 +461,7 @@ func TestDeleteApplicationDeliveryBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := 42
 
-	applicationDeliveryResponse, err := client.DeleteApplicationDelivery(siteID)
+	applicationDeliveryResponse, err := client.DeleteApplicationDelivery(context.Background(), siteID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -488,7 +489,7 @@ func TestDeleteApplicationDeliveryBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	applicationDeliveryResponse, err := client.DeleteApplicationDelivery(siteID)
+	applicationDeliveryResponse, err := client.DeleteApplicationDelivery(context.Background(), siteID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -518,7 +519,7 @@ func TestDeleteApplicationDeliveryInvalidConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	applicationDeliveryResponse, err := client.DeleteApplicationDelivery(siteID)
+	applicationDeliveryResponse, err := client.DeleteApplicationDelivery(context.Background(), siteID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -550,7 +551,7 @@ func TestDeleteApplicationDeliveryConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	_, err := client.DeleteApplicationDelivery(siteID)
+	_, err := client.DeleteApplicationDelivery(context.Background(), siteID)
 
 	if err != nil {
 		t.Errorf("Should not have received an error")
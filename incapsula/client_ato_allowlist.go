@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -132,7 +133,7 @@ func formAtoAllowlistDTOFromMap(atoAllowlistMap map[string]interface{}) (*ATOAll
 	return &atoAllowlistDTO, nil
 }
 
-func (c *Client) GetAtoSiteAllowlistWithRetries(accountId, siteId int) (*ATOAllowlistDTO, int, error) {
+func (c *Client) GetAtoSiteAllowlistWithRetries(ctx context.Context, accountId, siteId int) (*ATOAllowlistDTO, int, error) {
 	// Since the newly created site can take upto 30 seconds to be fully configured, we per.si a simple backoff
 	var backoffSchedule = []time.Duration{
 		5 * time.Second,
@@ -144,17 +145,19 @@ func (c *Client) GetAtoSiteAllowlistWithRetries(accountId, siteId int) (*ATOAllo
 	var lastError error
 
 	for _, backoff := range backoffSchedule {
-		atoAllowlistDTO, status, err := c.GetAtoSiteAllowlist(accountId, siteId)
+		atoAllowlistDTO, status, err := c.GetAtoSiteAllowlist(ctx, accountId, siteId)
 		if err == nil {
 			return atoAllowlistDTO, status, nil
 		}
 		lastError = err
-		time.Sleep(backoff)
+		if err := sleepWithContext(ctx, backoff); err != nil {
+			return nil, 0, err
+		}
 	}
 	return nil, 0, lastError
 }
 
-func (c *Client) GetAtoSiteAllowlist(accountId, siteId int) (*ATOAllowlistDTO, int, error) {
+func (c *Client) GetAtoSiteAllowlist(ctx context.Context, accountId, siteId int) (*ATOAllowlistDTO, int, error) {
 	log.Printf("[INFO] Getting IP allowlist for (Site Id: %d)\n", siteId)
 
 	// Get request to ATO
@@ -167,9 +170,9 @@ func (c *Client) GetAtoSiteAllowlist(accountId, siteId int) (*ATOAllowlistDTO, i
 
 	log.Printf("[INFO] fetching ATO Allowlist for siteId: %d, accountId: %d, BaseURLAPI: %s, endpointATOSiteBase: %s, endpointAtoAllowlist: %s, reqURL: %s\n", siteId, accountId, c.config.BaseURLAPI, endpointATOSiteBase, endpointAtoAllowlist, reqURL)
 
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadATOSiteAllowlistOperation)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadATOSiteAllowlistOperation)
 	if err != nil {
-		return nil, 0, fmt.Errorf("[Error] Error executing get ATO allowlist request for site with id %d: %s", siteId, err)
+		return nil, 0, fmt.Errorf("[Error] Error executing get ATO allowlist request for site with id %d: %w", siteId, err)
 	}
 
 	// Read the body
@@ -179,6 +182,11 @@ func (c *Client) GetAtoSiteAllowlist(accountId, siteId int) (*ATOAllowlistDTO, i
 	// Dump JSON
 	log.Printf("[DEBUG] ATO allowlist JSON response: %s\n", string(responseBody))
 
+	// The site does not exist (anymore)
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, resp.StatusCode, newAPIError(resp, responseBody, apiResourceIDs(siteId), "[Error] ATO allowlist not found for site with ID: %d, response: %s", siteId, string(responseBody))
+	}
+
 	// Parse the JSON
 	var atoAllowlistItems []AtoAllowlistItem
 	var atoAllowlistDTO ATOAllowlistDTO
@@ -187,13 +195,13 @@ func (c *Client) GetAtoSiteAllowlist(accountId, siteId int) (*ATOAllowlistDTO, i
 	atoAllowlistDTO.AccountId = accountId
 	atoAllowlistDTO.Allowlist = atoAllowlistItems
 	if err != nil {
-		return nil, resp.StatusCode, fmt.Errorf("[Error] Q Error parsing ATO allowlist response for site with ID: %d %s\nresponse: %s", siteId, err, string(responseBody))
+		return nil, resp.StatusCode, fmt.Errorf("[Error] Q Error parsing ATO allowlist response for site with ID: %d %w\nresponse: %s", siteId, err, string(responseBody))
 	}
 
 	return &atoAllowlistDTO, resp.StatusCode, nil
 }
 
-func (c *Client) UpdateATOSiteAllowlistWithRetries(atoSiteAllowlistDTO *ATOAllowlistDTO) error {
+func (c *Client) UpdateATOSiteAllowlistWithRetries(ctx context.Context, atoSiteAllowlistDTO *ATOAllowlistDTO) error {
 	// Since the newly created site can take upto 30 seconds to be fully configured, we perform a simple backoff
 	var backoffSchedule = []time.Duration{
 		5 * time.Second,
@@ -205,17 +213,19 @@ func (c *Client) UpdateATOSiteAllowlistWithRetries(atoSiteAllowlistDTO *ATOAllow
 	var lastError error
 
 	for _, backoff := range backoffSchedule {
-		err := c.UpdateATOSiteAllowlist(atoSiteAllowlistDTO)
+		err := c.UpdateATOSiteAllowlist(ctx, atoSiteAllowlistDTO)
 		if err == nil {
 			return nil
 		}
 		lastError = err
-		time.Sleep(backoff)
+		if err := sleepWithContext(ctx, backoff); err != nil {
+			return err
+		}
 	}
 	return lastError
 }
 
-func (c *Client) UpdateATOSiteAllowlist(atoSiteAllowlistDTO *ATOAllowlistDTO) error {
+func (c *Client) UpdateATOSiteAllowlist(ctx context.Context, atoSiteAllowlistDTO *ATOAllowlistDTO) error {
 
 	log.Printf("[INFO] Updating ATO IP allowlist for (Site Id: %d)\n", atoSiteAllowlistDTO.SiteId)
 
@@ -234,7 +244,7 @@ func (c *Client) UpdateATOSiteAllowlist(atoSiteAllowlistDTO *ATOAllowlistDTO) er
 	}
 
 	// Update request to ATO
-	response, err := c.DoJsonRequestWithHeaders(http.MethodPut, reqURL, atoAllowlistJSON, UpdateATOSiteAllowlistOperation)
+	response, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPut, reqURL, atoAllowlistJSON, UpdateATOSiteAllowlistOperation)
 
 	// Read the body
 	defer response.Body.Close()
@@ -244,25 +254,25 @@ func (c *Client) UpdateATOSiteAllowlist(atoSiteAllowlistDTO *ATOAllowlistDTO) er
 
 	// Handle request error
 	if err != nil {
-		return fmt.Errorf("[Error] Error executing update ATO allowlist request for site with id %d: %s", atoSiteAllowlistDTO.SiteId, err)
+		return fmt.Errorf("[Error] Error executing update ATO allowlist request for site with id %d: %w", atoSiteAllowlistDTO.SiteId, err)
 	}
 
 	if response.StatusCode >= http.StatusBadRequest {
-		return fmt.Errorf("[Error] Error executing update ATO allowlist request for site with status %d: %d", response.StatusCode, atoSiteAllowlistDTO.SiteId)
+		return newAPIError(response, responseBody, apiResourceIDs(atoSiteAllowlistDTO.SiteId), "[Error] Error executing update ATO allowlist request for site with status %d: %d", response.StatusCode, atoSiteAllowlistDTO.SiteId)
 	}
 
 	return nil
 
 }
 
-func (c *Client) DeleteATOSiteAllowlist(accountId, siteId int) error {
+func (c *Client) DeleteATOSiteAllowlist(ctx context.Context, accountId, siteId int) error {
 	log.Printf("[INFO] Deleting IP allowlist for (Site Id: %d)\n", siteId)
 
-	err := c.UpdateATOSiteAllowlist(formEmptyAllowlistDTO(accountId, siteId))
+	err := c.UpdateATOSiteAllowlist(ctx, formEmptyAllowlistDTO(accountId, siteId))
 
 	// Handle request error
 	if err != nil {
-		return fmt.Errorf("[Error] Error executing delete ATO allowlist request for site with id %d: %s", siteId, err)
+		return fmt.Errorf("[Error] Error executing delete ATO allowlist request for site with id %d: %w", siteId, err)
 	}
 
 	return nil
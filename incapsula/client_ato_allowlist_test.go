@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -24,7 +25,7 @@ func TestATOSiteAllowlistConfigBadConnection(t *testing.T) {
 	siteId := 42
 	accountId := 55
 
-	ret, _, err := client.GetAtoSiteAllowlist(accountId, siteId)
+	ret, _, err := client.GetAtoSiteAllowlist(context.Background(), accountId, siteId)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -36,7 +37,7 @@ func TestATOSiteAllowlistConfigBadConnection(t *testing.T) {
 		t.Errorf("Should have received a nil response")
 	}
 
-	err = client.UpdateATOSiteAllowlist(&ATOAllowlistDTO{})
+	err = client.UpdateATOSiteAllowlist(context.Background(), &ATOAllowlistDTO{})
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -75,7 +76,7 @@ func TestATOSiteAllowlistConfigErrorResponse(t *testing.T) {
 	config := &Config{APIID: apiId, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	ret, _, err := client.GetAtoSiteAllowlist(accountId, siteId)
+	ret, _, err := client.GetAtoSiteAllowlist(context.Background(), accountId, siteId)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -86,7 +87,7 @@ func TestATOSiteAllowlistConfigErrorResponse(t *testing.T) {
 		t.Errorf("Should have received a nil response")
 	}
 
-	err = client.UpdateATOSiteAllowlist(&ATOAllowlistDTO{})
+	err = client.UpdateATOSiteAllowlist(context.Background(), &ATOAllowlistDTO{})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -118,7 +119,7 @@ func TestATOSiteAllowlistConfigInvalidResponse(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	ret, _, err := client.GetAtoSiteAllowlist(accountId, siteId)
+	ret, _, err := client.GetAtoSiteAllowlist(context.Background(), accountId, siteId)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -129,7 +130,7 @@ func TestATOSiteAllowlistConfigInvalidResponse(t *testing.T) {
 		t.Errorf("Should have received a nil response")
 	}
 
-	err = client.UpdateATOSiteAllowlist(&ATOAllowlistDTO{})
+	err = client.UpdateATOSiteAllowlist(context.Background(), &ATOAllowlistDTO{})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -173,7 +174,7 @@ func TestATOSiteAllowlistConfigResponse(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 
 	// Fetch the allowlist for the site
-	response, _, err := client.GetAtoSiteAllowlistWithRetries(accountId, siteId)
+	response, _, err := client.GetAtoSiteAllowlistWithRetries(context.Background(), accountId, siteId)
 
 	// Check for no value edge cases
 	if err != nil {
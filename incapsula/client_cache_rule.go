@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -28,12 +29,12 @@ type CacheRuleWithID struct {
 }
 
 // AddCacheRule adds an incap rule to be managed by Incapsula
-func (c *Client) AddCacheRule(siteID string, rule *CacheRule) (*CacheRuleWithID, error) {
+func (c *Client) AddCacheRule(ctx context.Context, siteID string, rule *CacheRule) (*CacheRuleWithID, error) {
 	log.Printf("[INFO] Adding Incapsula Cache Rule for Site ID %s\n", siteID)
 
 	ruleJSON, err := json.Marshal(rule)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to JSON marshal CacheRule: %s", err)
+		return nil, fmt.Errorf("Failed to JSON marshal CacheRule: %w", err)
 	}
 
 	// Dump Request JSON
@@ -41,9 +42,9 @@ func (c *Client) AddCacheRule(siteID string, rule *CacheRule) (*CacheRuleWithID,
 
 	// Post form to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%s/settings/cache/rules", c.config.BaseURLRev2, siteID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, ruleJSON, CreateCacheRule)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, ruleJSON, CreateCacheRule)
 	if err != nil {
-		return nil, fmt.Errorf("Error from Incapsula service when adding Cache Rule for Site ID %s: %s", siteID, err)
+		return nil, fmt.Errorf("Error from Incapsula service when adding Cache Rule for Site ID %s: %w", siteID, err)
 	}
 
 	// Read the body
@@ -55,28 +56,28 @@ func (c *Client) AddCacheRule(siteID string, rule *CacheRule) (*CacheRuleWithID,
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when adding Cache Rule for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error status code %d from Incapsula service when adding Cache Rule for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody))
 	}
 
 	// Parse the JSON
 	var cacheRuleWithID CacheRuleWithID
 	err = json.Unmarshal([]byte(responseBody), &cacheRuleWithID)
 	if err != nil || !strings.Contains(string(responseBody), "\"rule_id\":") {
-		return nil, fmt.Errorf("Error parsing Cache Rule JSON response for Site ID %s: %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Cache Rule JSON response for Site ID %s: %w\nresponse: %s", siteID, err, string(responseBody))
 	}
 
 	return &cacheRuleWithID, nil
 }
 
 // ReadCacheRule gets the specific Incap Rule
-func (c *Client) ReadCacheRule(siteID string, ruleID int) (*CacheRuleWithID, int, error) {
+func (c *Client) ReadCacheRule(ctx context.Context, siteID string, ruleID int) (*CacheRuleWithID, int, error) {
 	log.Printf("[INFO] Getting Incapsula Cache Rule %d for Site ID %s\n", ruleID, siteID)
 
 	// Post form to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%s/settings/cache/rules/%d", c.config.BaseURLRev2, siteID, ruleID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadCacheRule)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadCacheRule)
 	if err != nil {
-		return nil, 0, fmt.Errorf("Error from Incapsula service when reading Cache Rule %d for Site ID %s: %s", ruleID, siteID, err)
+		return nil, 0, fmt.Errorf("Error from Incapsula service when reading Cache Rule %d for Site ID %s: %w", ruleID, siteID, err)
 	}
 
 	// Read the body
@@ -88,33 +89,33 @@ func (c *Client) ReadCacheRule(siteID string, ruleID int) (*CacheRuleWithID, int
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, resp.StatusCode, fmt.Errorf("Error status code %d from Incapsula service when reading Cache Rule %d for Site ID %s: %s", resp.StatusCode, ruleID, siteID, string(responseBody))
+		return nil, resp.StatusCode, newAPIError(resp, responseBody, apiResourceIDs(ruleID, siteID), "Error status code %d from Incapsula service when reading Cache Rule %d for Site ID %s: %s", resp.StatusCode, ruleID, siteID, string(responseBody))
 	}
 
 	// Parse the JSON
 	var cacheRuleWithID CacheRuleWithID
 	err = json.Unmarshal([]byte(responseBody), &cacheRuleWithID)
 	if err != nil {
-		return nil, resp.StatusCode, fmt.Errorf("Error parsing Cache Rule %d JSON response for Site ID %s: %s\nresponse: %s", ruleID, siteID, err, string(responseBody))
+		return nil, resp.StatusCode, fmt.Errorf("Error parsing Cache Rule %d JSON response for Site ID %s: %w\nresponse: %s", ruleID, siteID, err, string(responseBody))
 	}
 
 	return &cacheRuleWithID, resp.StatusCode, nil
 }
 
 // UpdateCacheRule updates the Incapsula Incap Rule
-func (c *Client) UpdateCacheRule(siteID string, ruleID int, rule *CacheRule) error {
+func (c *Client) UpdateCacheRule(ctx context.Context, siteID string, ruleID int, rule *CacheRule) error {
 	log.Printf("[INFO] Updating Incapsula Cache Rule %d for Site ID %s\n", ruleID, siteID)
 
 	ruleJSON, err := json.Marshal(rule)
 	if err != nil {
-		return fmt.Errorf("Failed to JSON marshal CacheRule: %s", err)
+		return fmt.Errorf("Failed to JSON marshal CacheRule: %w", err)
 	}
 
 	// Put request to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%s/settings/cache/rules/%d", c.config.BaseURLRev2, siteID, ruleID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPut, reqURL, ruleJSON, UpdateCacheRule)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPut, reqURL, ruleJSON, UpdateCacheRule)
 	if err != nil {
-		return fmt.Errorf("Error from Incapsula service when updating Cache Rule %d for Site ID %s: %s", ruleID, siteID, err)
+		return fmt.Errorf("Error from Incapsula service when updating Cache Rule %d for Site ID %s: %w", ruleID, siteID, err)
 	}
 
 	// Read the body
@@ -126,21 +127,21 @@ func (c *Client) UpdateCacheRule(siteID string, ruleID int, rule *CacheRule) err
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from Incapsula service when updating Cache Rule %d for Site ID %s: %s", resp.StatusCode, ruleID, siteID, string(responseBody))
+		return newAPIError(resp, responseBody, apiResourceIDs(ruleID, siteID), "Error status code %d from Incapsula service when updating Cache Rule %d for Site ID %s: %s", resp.StatusCode, ruleID, siteID, string(responseBody))
 	}
 
 	// Parse the JSON
 	var cacheRuleWithID CacheRuleWithID
 	err = json.Unmarshal([]byte(responseBody), &cacheRuleWithID)
 	if err != nil {
-		return fmt.Errorf("Error parsing Cache Rule %d JSON response for Site ID %s: %s\nresponse: %s", ruleID, siteID, err, string(responseBody))
+		return fmt.Errorf("Error parsing Cache Rule %d JSON response for Site ID %s: %w\nresponse: %s", ruleID, siteID, err, string(responseBody))
 	}
 
 	return nil
 }
 
 // DeleteCacheRule deletes a site currently managed by Incapsula
-func (c *Client) DeleteCacheRule(siteID string, ruleID int) error {
+func (c *Client) DeleteCacheRule(ctx context.Context, siteID string, ruleID int) error {
 	type DeleteCacheRuleResponse struct {
 		Res        int    `json:"res"`
 		ResMessage string `json:"res_message"`
@@ -154,9 +155,9 @@ func (c *Client) DeleteCacheRule(siteID string, ruleID int) error {
 
 	// Delete request to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%s/settings/cache/rules/%d", c.config.BaseURLRev2, siteID, ruleID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodDelete, reqURL, nil, DeleteCacheRule)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, DeleteCacheRule)
 	if err != nil {
-		return fmt.Errorf("Error from Incapsula service when deleting Cache Rule %d for Site ID %s: %s", ruleID, siteID, err)
+		return fmt.Errorf("Error from Incapsula service when deleting Cache Rule %d for Site ID %s: %w", ruleID, siteID, err)
 	}
 
 	// Read the body
@@ -169,18 +170,18 @@ func (c *Client) DeleteCacheRule(siteID string, ruleID int) error {
 	// Check the response code
 	// Unfortunately, this API endpoint is not RESTful and we return 200's back for failures (instead of 40X - joy)
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from Incapsula service when deleting Cache Rule %d for Site ID %s: %s", resp.StatusCode, ruleID, siteID, string(responseBody))
+		return newAPIError(resp, responseBody, apiResourceIDs(ruleID, siteID), "Error status code %d from Incapsula service when deleting Cache Rule %d for Site ID %s: %s", resp.StatusCode, ruleID, siteID, string(responseBody))
 	}
 
 	// Parse the JSON
 	var deleteCacheRuleResponse DeleteCacheRuleResponse
 	err = json.Unmarshal([]byte(responseBody), &deleteCacheRuleResponse)
 	if err != nil {
-		return fmt.Errorf("Error parsing Delete Cache Rule %d JSON response for Site ID %s: %s\nresponse: %s", ruleID, siteID, err, string(responseBody))
+		return fmt.Errorf("Error parsing Delete Cache Rule %d JSON response for Site ID %s: %w\nresponse: %s", ruleID, siteID, err, string(responseBody))
 	}
 
 	if deleteCacheRuleResponse.Res != 0 {
-		return fmt.Errorf("Error deleting Cache Rule %d JSON response for Site ID %s: %s\nresponse: %s", ruleID, siteID, err, string(responseBody))
+		return fmt.Errorf("Error deleting Cache Rule %d JSON response for Site ID %s: %w\nresponse: %s", ruleID, siteID, err, string(responseBody))
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -30,7 +31,7 @@ type HsmCertificatePutResponse struct {
 	ResMessage string `json:"res_message"`
 }
 
-func (c *Client) AddHsmCertificate(siteId, inputHash string, hSMDataDTO *HSMDataDTO) (*HsmCertificatePutResponse, error) {
+func (c *Client) AddHsmCertificate(ctx context.Context, siteId, inputHash string, hSMDataDTO *HSMDataDTO) (*HsmCertificatePutResponse, error) {
 	log.Printf("[INFO] Adding HSM certificate for site_id: %s with inputHash: %s ", siteId, inputHash)
 
 	// Put to MY (This API using put, not post)
@@ -39,35 +40,35 @@ func (c *Client) AddHsmCertificate(siteId, inputHash string, hSMDataDTO *HSMData
 		Data: *hSMDataDTO,
 	}
 
-	log.Printf("[INFO] Adding HSM certificate for site_id: %s with inputHash: %s with json: +%v", siteId, inputHash, hSMDataDTO)
+	log.Printf("[INFO] Adding HSM certificate for site_id: %s with inputHash: %s", siteId, inputHash)
 	hSMDataDTOJSON, err := json.Marshal(hsmCustomCertificate)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to JSON marshal HSMDataDTO: %s ", err)
+		return nil, fmt.Errorf("Failed to JSON marshal HSMDataDTO: %w ", err)
 	}
 
 	var params = map[string]string{}
 	params["input_hash"] = inputHash
-	log.Printf("[DEBUG] Add HSM certificate with params %s and JSON request: %s\n", params, string(hSMDataDTOJSON))
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodPut, reqURL, hSMDataDTOJSON, params, CreateHSMCustomCertificate)
+	log.Printf("[DEBUG] Add HSM certificate with params %s and JSON request: %s\n", params, redactedBody(contentTypeApplicationJson, hSMDataDTOJSON))
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodPut, reqURL, hSMDataDTOJSON, params, CreateHSMCustomCertificate)
 	if err != nil {
-		return nil, fmt.Errorf("error from Imperva service when adding HSM certificate for site_id %s: %s", siteId, err)
+		return nil, fmt.Errorf("error from Imperva service when adding HSM certificate for site_id %s: %w", siteId, err)
 	}
 
 	// Read the body
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
 
-	log.Printf("[DEBUG] Imperva add HSM certificate JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Imperva add HSM certificate JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 
 	// Parse the JSON
 	var hsmCertificateAddResponse HsmCertificatePutResponse
 	err = json.Unmarshal(responseBody, &hsmCertificateAddResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing add HSM certificate JSON response for siteId %s: %s\nresponse: %s", siteId, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing add HSM certificate JSON response for siteId %s: %w\nresponse: %s", siteId, err, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 
 	if hsmCertificateAddResponse.Res != 0 {
-		return nil, fmt.Errorf("error adding HSM certificate- res not 0. siteId: %s resposne:%s", siteId, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteId), "error adding HSM certificate- res not 0. siteId: %s resposne:%s", siteId, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 
 	log.Printf("[DEBUG] Imperva add HSM certificate clent pat ended successfully for site id: %s", siteId)
@@ -80,7 +81,7 @@ func getHsmUrl(siteId string, c *Client) string {
 }
 
 // DeleteHsmCustomCertificate deletes a hsm certificate for a specific site in Imperva
-func (c *Client) DeleteHsmCertificate(siteId string) error {
+func (c *Client) DeleteHsmCertificate(ctx context.Context, siteId string) error {
 	// Specifically shaded this struct, no need to share across funcs or export
 	// We only care about the response code and possibly the message
 	type CertificateDeleteResponse struct {
@@ -92,34 +93,34 @@ func (c *Client) DeleteHsmCertificate(siteId string) error {
 
 	// Post form to Incapsula
 	reqURL := getHsmUrl(siteId, c)
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodDelete, reqURL, nil, nil, DeleteHsmCustomCertificate)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, nil, DeleteHsmCustomCertificate)
 	if err != nil {
-		return fmt.Errorf("error deleting HSM certificate while sending request. siteId: %s %s", siteId, err)
+		return fmt.Errorf("error deleting HSM certificate while sending request. siteId: %s %w", siteId, err)
 	}
 
 	// Read the body
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from Imperva service when deleting hsm certificate for site id %s: %s ", resp.StatusCode, siteId, string(responseBody))
+		return newAPIError(resp, responseBody, apiResourceIDs(siteId), "Error status code %d from Imperva service when deleting hsm certificate for site id %s: %s ", resp.StatusCode, siteId, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 
 	if err != nil {
-		return fmt.Errorf("Error reading response when deleting hsm certificate for site id %s: %s ", siteId, err)
+		return fmt.Errorf("Error reading response when deleting hsm certificate for site id %s: %w ", siteId, err)
 	}
 
-	log.Printf("[DEBUG] Imperva delete HSM certificate JSON response for siteId %s: %s\n", siteId, string(responseBody))
+	log.Printf("[DEBUG] Imperva delete HSM certificate JSON response for siteId %s: %s\n", siteId, redactedBody(contentTypeApplicationJson, responseBody))
 
 	// Parse the JSON
 	var hsmCertificateDeleteResponse CertificateDeleteResponse
 	err = json.Unmarshal(responseBody, &hsmCertificateDeleteResponse)
 	if err != nil {
-		return fmt.Errorf("error deleting HSM certificate, json parse error. siteId: %s %s", siteId, err)
+		return fmt.Errorf("error deleting HSM certificate, json parse error. siteId: %s %w", siteId, err)
 	}
 
 	if hsmCertificateDeleteResponse.Res != 0 {
 		log.Printf("[DEBUG] response: %+v", hsmCertificateDeleteResponse)
-		return fmt.Errorf("error deleting HSM certificate- res not 0. siteId: %s resposne:%s", siteId, string(responseBody))
+		return newAPIError(resp, responseBody, apiResourceIDs(siteId), "error deleting HSM certificate- res not 0. siteId: %s resposne:%s", siteId, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -21,7 +22,7 @@ func TestClientAddCertificateHsmBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := "1234"
 	hSMDataDTO := getFakeHsmDataDto()
-	addCertificateResponse, err := client.AddHsmCertificate(siteID, "dfgdfg", &hSMDataDTO)
+	addCertificateResponse, err := client.AddHsmCertificate(context.Background(), siteID, "dfgdfg", &hSMDataDTO)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -64,7 +65,7 @@ func TestClientAddCertificateHsmBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLRev2: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	hSMDataDTO := getFakeHsmDataDto()
-	addCertificateResponse, err := client.AddHsmCertificate(siteID, "bla", &hSMDataDTO)
+	addCertificateResponse, err := client.AddHsmCertificate(context.Background(), siteID, "bla", &hSMDataDTO)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -101,7 +102,7 @@ func TestClientAddCertificateHsmInvalidRule(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLRev2: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	hsmDataFakeDto := getFakeHsmDataDto()
-	addCertificateResponse, err := client.AddHsmCertificate(siteID, "bla", &hsmDataFakeDto)
+	addCertificateResponse, err := client.AddHsmCertificate(context.Background(), siteID, "bla", &hsmDataFakeDto)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -129,7 +130,7 @@ func TestClientAddCertificateHsmValidRule(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLRev2: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	hsmDataFateDto := getFakeHsmDataDto()
-	addCertificateResponse, err := client.AddHsmCertificate(siteID, "bla", &hsmDataFateDto)
+	addCertificateResponse, err := client.AddHsmCertificate(context.Background(), siteID, "bla", &hsmDataFateDto)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -151,7 +152,7 @@ func TestClientDeleteCertificateHsmBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLRev2: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := "1234"
-	err := client.DeleteHsmCertificate(siteID)
+	err := client.DeleteHsmCertificate(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -175,7 +176,7 @@ func TestClientDeleteCertificateHsmBadJSON(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLRev2: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	err := client.DeleteHsmCertificate(siteID)
+	err := client.DeleteHsmCertificate(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -199,7 +200,7 @@ func TestClientDeleteCertificateHsmInvalidSiteID(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLRev2: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	err := client.DeleteHsmCertificate(siteID)
+	err := client.DeleteHsmCertificate(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -223,7 +224,7 @@ func TestClientDeleteCertificateHsmValidSite(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLRev2: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	err := client.DeleteHsmCertificate(siteID)
+	err := client.DeleteHsmCertificate(context.Background(), siteID)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
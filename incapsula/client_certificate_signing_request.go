@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -20,7 +21,7 @@ type CertificateSigningRequestCreateResponse struct {
 }
 
 // CreateCertificateSigningRequest creates a Certificate Signing Request (CSR)
-func (c *Client) CreateCertificateSigningRequest(siteID, domain, email, country, state, city, organization, organizationUnit string) (*CertificateSigningRequestCreateResponse, error) {
+func (c *Client) CreateCertificateSigningRequest(ctx context.Context, siteID, domain, email, country, state, city, organization, organizationUnit string) (*CertificateSigningRequestCreateResponse, error) {
 
 	log.Printf("[INFO] Creating certificate signing request for site_id: %s", siteID)
 
@@ -59,9 +60,9 @@ func (c *Client) CreateCertificateSigningRequest(siteID, domain, email, country,
 	log.Printf("CertificateSigningRequest\n%v", values)
 	// Post to Incapsula
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointCertificateSigningRequestCreate)
-	resp, err := c.PostFormWithHeaders(reqURL, values, CreateCertificateSigningRequest)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, CreateCertificateSigningRequest)
 	if err != nil {
-		return nil, fmt.Errorf("Error from Incapsula service when creating certificate signing request for site_id %s: %s", siteID, err)
+		return nil, fmt.Errorf("Error from Incapsula service when creating certificate signing request for site_id %s: %w", siteID, err)
 	}
 
 	// Read the body
@@ -75,12 +76,12 @@ func (c *Client) CreateCertificateSigningRequest(siteID, domain, email, country,
 	var certificateSigningRequestCreateResponse CertificateSigningRequestCreateResponse
 	err = json.Unmarshal([]byte(responseBody), &certificateSigningRequestCreateResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing create certificate signing request JSON response for site_id %s: %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing create certificate signing request JSON response for site_id %s: %w\nresponse: %s", siteID, err, string(responseBody))
 	}
 
 	// Look at the response status code from Incapsula
 	if certificateSigningRequestCreateResponse.Res != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when creating certificate signing request for site_id %s: %s", siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error from Incapsula service when creating certificate signing request for site_id %s: %s", siteID, string(responseBody))
 	}
 
 	return &certificateSigningRequestCreateResponse, nil
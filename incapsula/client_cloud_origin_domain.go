@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -43,7 +44,7 @@ func getCloudOriginUrl(baseURL string, siteID int, path string, accountID string
 	return url
 }
 
-func (c *Client) CreateCloudOriginDomain(siteID int, accountID string, domain, region string, port int, sslProtocol string) (*CloudOriginDomainResponse, error) {
+func (c *Client) CreateCloudOriginDomain(ctx context.Context, siteID int, accountID string, domain, region string, port int, sslProtocol string) (*CloudOriginDomainResponse, error) {
 	log.Printf("[INFO] Creating Incapsula cloud origin domain: %s for site: %d\n", domain, siteID)
 
 	payload := CloudOriginDomainCreateRequest{
@@ -57,93 +58,93 @@ func (c *Client) CreateCloudOriginDomain(siteID int, accountID string, domain, r
 
 	payloadJSON, err := json.Marshal(payload)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to JSON marshal cloud origin domain: %s", err)
+		return nil, fmt.Errorf("Failed to JSON marshal cloud origin domain: %w", err)
 	}
 
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost,
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost,
 		getCloudOriginUrl(c.config.BaseURLRev3, siteID, "", accountID),
 		payloadJSON,
 		CreateCloudOriginDomain)
 
 	if err != nil {
-		return nil, fmt.Errorf("Error from Incapsula service while creating cloud origin domain %s for site %d: %s", domain, siteID, err)
+		return nil, fmt.Errorf("Error from Incapsula service while creating cloud origin domain %s for site %d: %w", domain, siteID, err)
 	}
 
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
 
-	log.Printf("[DEBUG] Incapsula create cloud origin domain JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Incapsula create cloud origin domain JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 
 	if resp.StatusCode != 201 && resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when creating cloud origin domain %s for site %d: %s", resp.StatusCode, domain, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(domain, siteID), "Error status code %d from Incapsula service when creating cloud origin domain %s for site %d: %s", resp.StatusCode, domain, siteID, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 
 	var response CloudOriginDomainResponse
 	err = json.Unmarshal(responseBody, &response)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing cloud origin domain JSON response: %s\nresponse: %s", err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing cloud origin domain JSON response: %w\nresponse: %s", err, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 
 	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when creating cloud origin domain %s for site %d: %s", domain, siteID, response.Errors[0].Detail)
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(domain, siteID), "Error from Incapsula service when creating cloud origin domain %s for site %d: %s", domain, siteID, response.Errors[0].Detail)
 	}
 
 	return &response, nil
 }
 
-func (c *Client) GetCloudOriginDomain(siteID, originID int, accountID string) (*CloudOriginDomainResponse, error) {
+func (c *Client) GetCloudOriginDomain(ctx context.Context, siteID, originID int, accountID string) (*CloudOriginDomainResponse, error) {
 	log.Printf("[INFO] Getting Incapsula cloud origin domain: %d for site: %d\n", originID, siteID)
 
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet,
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet,
 		getCloudOriginUrl(c.config.BaseURLRev3, siteID, fmt.Sprintf("/%d", originID), accountID),
 		nil,
 		ReadCloudOriginDomain)
 
 	if err != nil {
-		return nil, fmt.Errorf("Error from Incapsula service while reading cloud origin domain %d for site %d: %s", originID, siteID, err)
+		return nil, fmt.Errorf("Error from Incapsula service while reading cloud origin domain %d for site %d: %w", originID, siteID, err)
 	}
 
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
 
-	log.Printf("[DEBUG] Incapsula get cloud origin domain JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Incapsula get cloud origin domain JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when reading cloud origin domain %d for site %d: %s", resp.StatusCode, originID, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(originID, siteID), "Error status code %d from Incapsula service when reading cloud origin domain %d for site %d: %s", resp.StatusCode, originID, siteID, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 
 	var response CloudOriginDomainResponse
 	err = json.Unmarshal(responseBody, &response)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing cloud origin domain JSON response: %s\nresponse: %s", err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing cloud origin domain JSON response: %w\nresponse: %s", err, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 
 	if len(response.Errors) > 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when reading cloud origin domain %d for site %d: %s", originID, siteID, response.Errors[0].Detail)
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(originID, siteID), "Error from Incapsula service when reading cloud origin domain %d for site %d: %s", originID, siteID, response.Errors[0].Detail)
 	}
 
 	return &response, nil
 }
 
-func (c *Client) DeleteCloudOriginDomain(siteID, originID int, accountID string) error {
+func (c *Client) DeleteCloudOriginDomain(ctx context.Context, siteID, originID int, accountID string) error {
 	log.Printf("[INFO] Deleting Incapsula cloud origin domain: %d for site: %d\n", originID, siteID)
 
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodDelete,
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodDelete,
 		getCloudOriginUrl(c.config.BaseURLRev3, siteID, fmt.Sprintf("/%d", originID), accountID),
 		nil,
 		DeleteCloudOriginDomain)
 
 	if err != nil {
-		return fmt.Errorf("Error from Incapsula service while deleting cloud origin domain %d for site %d: %s", originID, siteID, err)
+		return fmt.Errorf("Error from Incapsula service while deleting cloud origin domain %d for site %d: %w", originID, siteID, err)
 	}
 
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
 
-	log.Printf("[DEBUG] Incapsula delete cloud origin domain JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Incapsula delete cloud origin domain JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 
 	if resp.StatusCode != 204 && resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from Incapsula service when deleting cloud origin domain %d for site %d: %s", resp.StatusCode, originID, siteID, string(responseBody))
+		return newAPIError(resp, responseBody, apiResourceIDs(originID, siteID), "Error status code %d from Incapsula service when deleting cloud origin domain %d for site %d: %s", resp.StatusCode, originID, siteID, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 
 	return nil
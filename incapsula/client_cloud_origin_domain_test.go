@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -91,7 +92,7 @@ func TestClientCloudOriginDomainCreate(t *testing.T) {
 				httpClient: &http.Client{},
 			}
 
-			response, err := client.CreateCloudOriginDomain(1, "", "api.example.com", "us-east-1", 443, "TLS_1_2")
+			response, err := client.CreateCloudOriginDomain(context.Background(), 1, "", "api.example.com", "us-east-1", 443, "TLS_1_2")
 
 			if test.expectedErr && err == nil {
 				t.Errorf("Expected error, got nil")
@@ -177,7 +178,7 @@ func TestClientCloudOriginDomainGet(t *testing.T) {
 				httpClient: &http.Client{},
 			}
 
-			response, err := client.GetCloudOriginDomain(1, 12345, "")
+			response, err := client.GetCloudOriginDomain(context.Background(), 1, 12345, "")
 
 			if test.expectedErr && err == nil {
 				t.Errorf("Expected error, got nil")
@@ -257,7 +258,7 @@ func TestClientCloudOriginDomainDelete(t *testing.T) {
 				httpClient: &http.Client{},
 			}
 
-			err := client.DeleteCloudOriginDomain(1, 12345, "")
+			err := client.DeleteCloudOriginDomain(context.Background(), 1, 12345, "")
 
 			if test.expectedErr && err == nil {
 				t.Errorf("Expected error, got nil")
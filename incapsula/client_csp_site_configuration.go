@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -37,24 +38,24 @@ const (
 )
 
 // GetCSPSite gets the csp site config
-func (c *Client) GetCSPSite(accountID, siteID int) (*CSPSiteConfig, error) {
+func (c *Client) GetCSPSite(ctx context.Context, accountID, siteID int) (*CSPSiteConfig, error) {
 	log.Printf("[INFO] Getting CSP site configuration for site ID: %d of account %d\n", siteID, accountID)
 
 	var resp *http.Response
 	var err error
 	if accountID != 0 {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodGet,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodGet,
 			fmt.Sprintf("%s%s/%d?caid=%d", c.config.BaseURLAPI, CSPSiteApiPath, siteID, accountID),
 			nil,
 			ReadCspSiteConfiguration)
 	} else {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodGet,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodGet,
 			fmt.Sprintf("%s%s/%d", c.config.BaseURLAPI, CSPSiteApiPath, siteID),
 			nil,
 			ReadCspSiteConfiguration)
 	}
 	if err != nil {
-		return nil, fmt.Errorf("Error from CSP API for when reading site ID %d: %s", siteID, err)
+		return nil, fmt.Errorf("Error from CSP API for when reading site ID %d: %w", siteID, err)
 	}
 
 	// Read the body
@@ -66,20 +67,20 @@ func (c *Client) GetCSPSite(accountID, siteID int) (*CSPSiteConfig, error) {
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from CSP API when reading site config for ID %d: %s", resp.StatusCode, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error status code %d from CSP API when reading site config for ID %d: %s", resp.StatusCode, siteID, string(responseBody))
 	}
 
 	// Parse the JSON
 	var cspSiteConfig CSPSiteConfig
 	err = json.Unmarshal([]byte(responseBody), &cspSiteConfig)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing JSON response for site ID %d: %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing JSON response for site ID %d: %w\nresponse: %s", siteID, err, string(responseBody))
 	}
 
 	return &cspSiteConfig, nil
 }
 
-func (c *Client) UpdateCSPSiteWithRetries(accountID, siteID int, config *CSPSiteConfig) (*CSPSiteConfig, error) {
+func (c *Client) UpdateCSPSiteWithRetries(ctx context.Context, accountID, siteID int, config *CSPSiteConfig) (*CSPSiteConfig, error) {
 	var backoffSchedule = []time.Duration{
 		5 * time.Second,
 		15 * time.Second,
@@ -88,40 +89,42 @@ func (c *Client) UpdateCSPSiteWithRetries(accountID, siteID int, config *CSPSite
 	var lastError error
 
 	for _, backoff := range backoffSchedule {
-		ret, err := c.UpdateCSPSite(accountID, siteID, config)
+		ret, err := c.UpdateCSPSite(ctx, accountID, siteID, config)
 		if err == nil && ret != nil {
 			return ret, nil
 		}
 		lastError = err
-		time.Sleep(backoff)
+		if err := sleepWithContext(ctx, backoff); err != nil {
+			return nil, err
+		}
 	}
 	return nil, lastError
 }
 
 // UpdateCSPSite gets the csp site config
-func (c *Client) UpdateCSPSite(accountID, siteID int, config *CSPSiteConfig) (*CSPSiteConfig, error) {
+func (c *Client) UpdateCSPSite(ctx context.Context, accountID, siteID int, config *CSPSiteConfig) (*CSPSiteConfig, error) {
 	log.Printf("[INFO] Updating CSP site configuration for site ID: %d of account %d\n%v", siteID, accountID, config)
 	configJSON, err := json.Marshal(config)
 
 	if err != nil {
-		return nil, fmt.Errorf("Failed to JSON marshal CSP api site config: %s", err)
+		return nil, fmt.Errorf("Failed to JSON marshal CSP api site config: %w", err)
 	}
 
 	var resp *http.Response
 	if accountID != 0 {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodPut,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodPut,
 			fmt.Sprintf("%s%s/%d?caid=%d", c.config.BaseURLAPI, CSPSiteApiPath, siteID, accountID),
 			configJSON,
 			UpdateCspSiteConfiguration)
 	} else {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodPut,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodPut,
 			fmt.Sprintf("%s%s/%d", c.config.BaseURLAPI, CSPSiteApiPath, siteID),
 			configJSON,
 			UpdateCspSiteConfiguration)
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("Error from CSP API while updating site configuration for site ID %d: %s", siteID, err)
+		return nil, fmt.Errorf("Error from CSP API while updating site configuration for site ID %d: %w", siteID, err)
 	}
 
 	// Read the body
@@ -133,14 +136,14 @@ func (c *Client) UpdateCSPSite(accountID, siteID int, config *CSPSiteConfig) (*C
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from CSP API when updating site config for ID %d: %s", resp.StatusCode, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error status code %d from CSP API when updating site config for ID %d: %s", resp.StatusCode, siteID, string(responseBody))
 	}
 
 	// Parse the JSON
 	var cspSiteConfig CSPSiteConfig
 	err = json.Unmarshal([]byte(responseBody), &cspSiteConfig)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing JSON response for site ID %d: %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing JSON response for site ID %d: %w\nresponse: %s", siteID, err, string(responseBody))
 	}
 
 	return &cspSiteConfig, nil
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -15,7 +16,7 @@ func TestCspSiteConfigBadConnection(t *testing.T) {
 	siteID := 42
 	accountID := 55
 
-	ret, err := client.GetCSPSite(accountID, siteID)
+	ret, err := client.GetCSPSite(context.Background(), accountID, siteID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -27,7 +28,7 @@ func TestCspSiteConfigBadConnection(t *testing.T) {
 		t.Errorf("Should have received a nil response")
 	}
 
-	ret, err = client.UpdateCSPSite(accountID, siteID, &CSPSiteConfig{})
+	ret, err = client.UpdateCSPSite(context.Background(), accountID, siteID, &CSPSiteConfig{})
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -63,7 +64,7 @@ func TestCSPSiteConfigErrorResponse(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	ret, err := client.GetCSPSite(accountID, siteID)
+	ret, err := client.GetCSPSite(context.Background(), accountID, siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -74,7 +75,7 @@ func TestCSPSiteConfigErrorResponse(t *testing.T) {
 		t.Errorf("Should have received a nil response")
 	}
 
-	ret, err = client.UpdateCSPSite(accountID, siteID, &CSPSiteConfig{})
+	ret, err = client.UpdateCSPSite(context.Background(), accountID, siteID, &CSPSiteConfig{})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -106,7 +107,7 @@ func TestCSPSiteConfigInvalidResponse(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	ret, err := client.GetCSPSite(accountID, siteID)
+	ret, err := client.GetCSPSite(context.Background(), accountID, siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -117,7 +118,7 @@ func TestCSPSiteConfigInvalidResponse(t *testing.T) {
 		t.Errorf("Should have received a nil response")
 	}
 
-	ret, err = client.UpdateCSPSite(accountID, siteID, &CSPSiteConfig{})
+	ret, err = client.UpdateCSPSite(context.Background(), accountID, siteID, &CSPSiteConfig{})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -159,7 +160,7 @@ func TestCSPSiteConfigResponse(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	ret, err := client.GetCSPSite(accountID, siteID)
+	ret, err := client.GetCSPSite(context.Background(), accountID, siteID)
 	if err != nil {
 		t.Errorf("Should have not received an error")
 	}
@@ -179,7 +180,7 @@ func TestCSPSiteConfigResponse(t *testing.T) {
 		t.Errorf("Incorrect value inresponse from GetCSPSite")
 	}
 
-	ret, err = client.UpdateCSPSite(accountID, siteID, &CSPSiteConfig{})
+	ret, err = client.UpdateCSPSite(context.Background(), accountID, siteID, &CSPSiteConfig{})
 	if err != nil {
 		t.Errorf("Should have not received an error")
 	}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -27,7 +28,7 @@ type CSPPreApprovedDomain struct {
 	ReferenceID string `json:"referenceId"`
 }
 
-func (c *Client) getCSPDomainAPI(accountID, siteID int, domain string, APIPath string, ret interface{}) error {
+func (c *Client) getCSPDomainAPI(ctx context.Context, accountID, siteID int, domain string, APIPath string, ret interface{}) error {
 	log.Printf("[INFO] Getting CSP domain %s for domain %s from site ID: %d\n", APIPath, domain, siteID)
 
 	domainRef := base64.RawURLEncoding.EncodeToString([]byte(domain))
@@ -35,20 +36,20 @@ func (c *Client) getCSPDomainAPI(accountID, siteID int, domain string, APIPath s
 	var resp *http.Response
 	var err error
 	if accountID != 0 {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodGet,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodGet,
 			strings.Trim(fmt.Sprintf("%s%s/%d/domains/%s/%s?caid=%d", c.config.BaseURLAPI, CSPSiteApiPath, siteID, domainRef, APIPath, accountID),
 				"/"),
 			nil,
 			ReadCspSiteDomain)
 	} else {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodGet,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodGet,
 			strings.Trim(fmt.Sprintf("%s%s/%d/domains/%s/%s", c.config.BaseURLAPI, CSPSiteApiPath, siteID, domainRef, APIPath),
 				"/"),
 			nil,
 			ReadCspSiteDomain)
 	}
 	if err != nil {
-		return fmt.Errorf("Error from CSP API for when getting domain %s for domain %s from site ID %d: %s\n", APIPath, domain, siteID, err)
+		return fmt.Errorf("Error from CSP API for when getting domain %s for domain %s from site ID %d: %w\n", APIPath, domain, siteID, err)
 	}
 
 	// Read the body
@@ -60,52 +61,52 @@ func (c *Client) getCSPDomainAPI(accountID, siteID int, domain string, APIPath s
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from CSP API when getting domain %s for domain %s from site %d: %s\n",
+		return newAPIError(resp, responseBody, apiResourceIDs(domain, siteID), "Error status code %d from CSP API when getting domain %s for domain %s from site %d: %s\n",
 			resp.StatusCode, APIPath, domain, siteID, string(responseBody))
 	}
 
 	// Parse the JSON
 	err = json.Unmarshal([]byte(responseBody), ret)
 	if err != nil {
-		return fmt.Errorf("Error parsing JSON response for domain %s for domain %s from site ID %d: %s\nresponse: %s\n",
+		return fmt.Errorf("Error parsing JSON response for domain %s for domain %s from site ID %d: %w\nresponse: %s\n",
 			APIPath, domain, siteID, err, string(responseBody))
 	}
 
 	return nil
 }
 
-func (c *Client) getCSPDomainStatus(accountID, siteID int, domain string) (*CSPDomainStatus, error) {
+func (c *Client) getCSPDomainStatus(ctx context.Context, accountID, siteID int, domain string) (*CSPDomainStatus, error) {
 	ret := &CSPDomainStatus{}
-	if err := c.getCSPDomainAPI(accountID, siteID, domain, "status", ret); err != nil {
+	if err := c.getCSPDomainAPI(ctx, accountID, siteID, domain, "status", ret); err != nil {
 		return nil, err
 	}
 	return ret, nil
 }
 
-func (c *Client) updateCSPDomainStatus(accountID, siteID int, domain string, status *CSPDomainStatus) (*CSPDomainStatus, error) {
+func (c *Client) updateCSPDomainStatus(ctx context.Context, accountID, siteID int, domain string, status *CSPDomainStatus) (*CSPDomainStatus, error) {
 	log.Printf("[INFO] Updating CSP domain status for domain %s from site ID: %d to: %v\n", domain, siteID, status)
 
 	domainRef := base64.RawURLEncoding.EncodeToString([]byte(domain))
 
 	statusJSON, err := json.Marshal(status)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to JSON marshal CSP domain status %v: %s\n", status, err)
+		return nil, fmt.Errorf("Failed to JSON marshal CSP domain status %v: %w\n", status, err)
 	}
 
 	var resp *http.Response
 	if accountID != 0 {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodPut,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodPut,
 			fmt.Sprintf("%s%s/%d/domains/%s/status?caid=%d", c.config.BaseURLAPI, CSPSiteApiPath, siteID, domainRef, accountID),
 			statusJSON,
 			UpdateCspSiteDomain)
 	} else {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodPut,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodPut,
 			fmt.Sprintf("%s%s/%d/domains/%s/status", c.config.BaseURLAPI, CSPSiteApiPath, siteID, domainRef),
 			statusJSON,
 			UpdateCspSiteDomain)
 	}
 	if err != nil {
-		return nil, fmt.Errorf("Error from CSP API for when updating domain status for domain %s from site ID %d: %s\n", domain, siteID, err)
+		return nil, fmt.Errorf("Error from CSP API for when updating domain status for domain %s from site ID %d: %w\n", domain, siteID, err)
 	}
 
 	// Read the body
@@ -117,7 +118,7 @@ func (c *Client) updateCSPDomainStatus(accountID, siteID int, domain string, sta
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from CSP API when updating domain status for domain %s from site %d: %s\n",
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(domain, siteID), "Error status code %d from CSP API when updating domain status for domain %s from site %d: %s\n",
 			resp.StatusCode, domain, siteID, string(responseBody))
 	}
 
@@ -125,22 +126,22 @@ func (c *Client) updateCSPDomainStatus(accountID, siteID int, domain string, sta
 	st := &CSPDomainStatus{}
 	err = json.Unmarshal([]byte(responseBody), st)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing JSON response for domain status for domain %s from site ID %d: %s\nresponse: %s\n",
+		return nil, fmt.Errorf("Error parsing JSON response for domain status for domain %s from site ID %d: %w\nresponse: %s\n",
 			domain, siteID, err, string(responseBody))
 	}
 
 	return st, nil
 }
 
-func (c *Client) getCSPDomainNotes(accountID, siteID int, domain string) ([]CSPDomainNote, error) {
+func (c *Client) getCSPDomainNotes(ctx context.Context, accountID, siteID int, domain string) ([]CSPDomainNote, error) {
 	var ret []CSPDomainNote
-	if err := c.getCSPDomainAPI(accountID, siteID, domain, "notes", &ret); err != nil {
+	if err := c.getCSPDomainAPI(ctx, accountID, siteID, domain, "notes", &ret); err != nil {
 		return nil, err
 	}
 	return ret, nil
 }
 
-func (c *Client) addCSPDomainNote(accountID, siteID int, domain string, note string) error {
+func (c *Client) addCSPDomainNote(ctx context.Context, accountID, siteID int, domain string, note string) error {
 	log.Printf("[INFO] Getting CSP domain notes for domain %s from site ID: %d\n", domain, siteID)
 
 	domainRef := base64.RawURLEncoding.EncodeToString([]byte(domain))
@@ -148,18 +149,18 @@ func (c *Client) addCSPDomainNote(accountID, siteID int, domain string, note str
 	var resp *http.Response
 	var err error
 	if accountID != 0 {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodPost,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodPost,
 			fmt.Sprintf("%s%s/%d/domains/%s/notes?caid=%d", c.config.BaseURLAPI, CSPSiteApiPath, siteID, domainRef, accountID),
 			[]byte(note),
 			CreateCspSiteDomain)
 	} else {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodPost,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodPost,
 			fmt.Sprintf("%s%s/%d/domains/%s/notes", c.config.BaseURLAPI, CSPSiteApiPath, siteID, domainRef),
 			[]byte(note),
 			CreateCspSiteDomain)
 	}
 	if err != nil {
-		return fmt.Errorf("Error from CSP API for when getting domain notes for domain %s from site ID %d: %s\n", domain, siteID, err)
+		return fmt.Errorf("Error from CSP API for when getting domain notes for domain %s from site ID %d: %w\n", domain, siteID, err)
 	}
 
 	// Read the body
@@ -171,7 +172,7 @@ func (c *Client) addCSPDomainNote(accountID, siteID int, domain string, note str
 
 	// Check the response code
 	if resp.StatusCode != 201 {
-		return fmt.Errorf("Error status code %d from CSP API when getting domain notes for domain %s from site %d: %s\n",
+		return newAPIError(resp, responseBody, apiResourceIDs(domain, siteID), "Error status code %d from CSP API when getting domain notes for domain %s from site %d: %s\n",
 			resp.StatusCode, domain, siteID, string(responseBody))
 	}
 
@@ -179,14 +180,14 @@ func (c *Client) addCSPDomainNote(accountID, siteID int, domain string, note str
 	var notes []CSPDomainNote
 	err = json.Unmarshal([]byte(responseBody), &notes)
 	if err != nil {
-		return fmt.Errorf("Error parsing JSON response for domain notes for domain %s from site ID %d: %s\nresponse: %s\n",
+		return fmt.Errorf("Error parsing JSON response for domain notes for domain %s from site ID %d: %w\nresponse: %s\n",
 			domain, siteID, err, string(responseBody))
 	}
 
 	return nil
 }
 
-func (c *Client) deleteCSPDomainNotes(accountID, siteID int, domain string) error {
+func (c *Client) deleteCSPDomainNotes(ctx context.Context, accountID, siteID int, domain string) error {
 	log.Printf("[INFO] Deleting CSP domain notes for domain %s from site ID: %d\n", domain, siteID)
 
 	domainRef := base64.RawURLEncoding.EncodeToString([]byte(domain))
@@ -194,16 +195,16 @@ func (c *Client) deleteCSPDomainNotes(accountID, siteID int, domain string) erro
 	var resp *http.Response
 	var err error
 	if accountID != 0 {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodDelete,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodDelete,
 			fmt.Sprintf("%s%s/%d/domains/%s/notes?caid=%d", c.config.BaseURLAPI, CSPSiteApiPath, siteID, domainRef, accountID),
 			nil, "")
 	} else {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodDelete,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodDelete,
 			fmt.Sprintf("%s%s/%d/domains/%s/notes", c.config.BaseURLAPI, CSPSiteApiPath, siteID, domainRef),
 			nil, "")
 	}
 	if err != nil {
-		return fmt.Errorf("Error from CSP API for when deleting domain notes for domain %s from site ID %d: %s\n", domain, siteID, err)
+		return fmt.Errorf("Error from CSP API for when deleting domain notes for domain %s from site ID %d: %w\n", domain, siteID, err)
 	}
 
 	// Read the body
@@ -211,31 +212,31 @@ func (c *Client) deleteCSPDomainNotes(accountID, siteID int, domain string) erro
 
 	// Check the response code
 	if resp.StatusCode != 204 {
-		return fmt.Errorf("Error status code %d from CSP API when getting domain notes for domain %s from site %d\n",
+		return newAPIError(resp, nil, apiResourceIDs(domain, siteID), "Error status code %d from CSP API when getting domain notes for domain %s from site %d\n",
 			resp.StatusCode, domain, siteID)
 	}
 
 	return nil
 }
 
-func (c *Client) getCSPPreApprovedDomainByRef(accountID, siteID int, domainRef string) (*CSPPreApprovedDomain, error) {
+func (c *Client) getCSPPreApprovedDomainByRef(ctx context.Context, accountID, siteID int, domainRef string) (*CSPPreApprovedDomain, error) {
 	log.Printf("[INFO] Getting CSP pre-approved domain by ref %s from site ID: %d\n", domainRef, siteID)
 
 	var resp *http.Response
 	var err error
 	if accountID != 0 {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodGet,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodGet,
 			fmt.Sprintf("%s%s/%d/preapprovedlist/%s?caid=%d", c.config.BaseURLAPI, CSPSiteApiPath, siteID, domainRef, accountID),
 			nil,
 			ReadCspSiteDomain)
 	} else {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodGet,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodGet,
 			fmt.Sprintf("%s%s/%d/preapprovedlist/%s", c.config.BaseURLAPI, CSPSiteApiPath, siteID, domainRef),
 			nil,
 			ReadCspSiteDomain)
 	}
 	if err != nil {
-		return nil, fmt.Errorf("Error from CSP API for when getting pre-approved domains list for site ID %d: %s\n", siteID, err)
+		return nil, fmt.Errorf("Error from CSP API for when getting pre-approved domains list for site ID %d: %w\n", siteID, err)
 	}
 
 	defer resp.Body.Close()
@@ -244,45 +245,45 @@ func (c *Client) getCSPPreApprovedDomainByRef(accountID, siteID int, domainRef s
 	log.Printf("[DEBUG] CSP API Get Pre-Approved Domain Data JSON response: %s\n", string(responseBody))
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from CSP API when getting pre-approved domain ref %s for site %d: %s\n",
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(domainRef, siteID), "Error status code %d from CSP API when getting pre-approved domain ref %s for site %d: %s\n",
 			resp.StatusCode, domainRef, siteID, string(responseBody))
 	}
 
 	var preApprovedDomain CSPPreApprovedDomain
 	err = json.Unmarshal([]byte(responseBody), &preApprovedDomain)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing JSON response for pre-approved domain ref %s for site ID %d: %s\nresponse: %s\n",
+		return nil, fmt.Errorf("Error parsing JSON response for pre-approved domain ref %s for site ID %d: %w\nresponse: %s\n",
 			domainRef, siteID, err, string(responseBody))
 	}
 
 	return &preApprovedDomain, nil
 }
 
-func (c *Client) getCSPPreApprovedDomain(accountID, siteID int, domain string) (*CSPPreApprovedDomain, error) {
+func (c *Client) getCSPPreApprovedDomain(ctx context.Context, accountID, siteID int, domain string) (*CSPPreApprovedDomain, error) {
 	domainRef := base64.RawURLEncoding.EncodeToString([]byte(domain))
-	return c.getCSPPreApprovedDomainByRef(accountID, siteID, domainRef)
+	return c.getCSPPreApprovedDomainByRef(ctx, accountID, siteID, domainRef)
 }
 
-func (c *Client) updateCSPPreApprovedDomain(accountID, siteID int, dom *CSPPreApprovedDomain) (*CSPPreApprovedDomain, error) {
+func (c *Client) updateCSPPreApprovedDomain(ctx context.Context, accountID, siteID int, dom *CSPPreApprovedDomain) (*CSPPreApprovedDomain, error) {
 	log.Printf("[INFO] Updating CSP pre-approved domain for site ID: %d , domain: %v", siteID, dom)
 
 	domJSON, err := json.Marshal(dom)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to JSON marshal CSP pre-approved domain %v: %s\n", dom, err)
+		return nil, fmt.Errorf("Failed to JSON marshal CSP pre-approved domain %v: %w\n", dom, err)
 	}
 
 	var resp *http.Response
 	if accountID != 0 {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodPost,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodPost,
 			fmt.Sprintf("%s%s/%d/preapprovedlist?caid=%d", c.config.BaseURLAPI, CSPSiteApiPath, siteID, accountID),
 			domJSON, UpdateCspSiteDomain)
 	} else {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodPost,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodPost,
 			fmt.Sprintf("%s%s/%d/preapprovedlist", c.config.BaseURLAPI, CSPSiteApiPath, siteID),
 			domJSON, UpdateCspSiteDomain)
 	}
 	if err != nil {
-		return nil, fmt.Errorf("Error from CSP API while updating pre-approved domain %v for site ID %d: %s\n", dom, siteID, err)
+		return nil, fmt.Errorf("Error from CSP API while updating pre-approved domain %v for site ID %d: %w\n", dom, siteID, err)
 	}
 
 	// Read the body
@@ -294,7 +295,7 @@ func (c *Client) updateCSPPreApprovedDomain(accountID, siteID int, dom *CSPPreAp
 
 	// Check the response code
 	if resp.StatusCode != 201 {
-		return nil, fmt.Errorf("Error status code %d from CSP API when updating pre-approved domain for site %d: %s\n",
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error status code %d from CSP API when updating pre-approved domain for site %d: %s\n",
 			resp.StatusCode, siteID, string(responseBody))
 	}
 
@@ -302,31 +303,31 @@ func (c *Client) updateCSPPreApprovedDomain(accountID, siteID int, dom *CSPPreAp
 	var updatedDom CSPPreApprovedDomain
 	err = json.Unmarshal([]byte(responseBody), &updatedDom)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing JSON response for pre-approved domain %v for site ID %d: %s\nresponse: %s\n",
+		return nil, fmt.Errorf("Error parsing JSON response for pre-approved domain %v for site ID %d: %w\nresponse: %s\n",
 			dom, siteID, err, string(responseBody))
 	}
 
 	return &updatedDom, nil
 }
 
-func (c *Client) deleteCSPPreApprovedDomains(accountID, siteID int, domainRef string) error {
+func (c *Client) deleteCSPPreApprovedDomains(ctx context.Context, accountID, siteID int, domainRef string) error {
 	log.Printf("[INFO] Deleting CSP pre-approved domain %s for site ID: %d\n", domainRef, siteID)
 
 	var resp *http.Response
 	var err error
 	if accountID != 0 {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodDelete,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodDelete,
 			fmt.Sprintf("%s%s/%d/preapprovedlist/%s?caid=%d", c.config.BaseURLAPI, CSPSiteApiPath, siteID, domainRef, accountID),
 			nil,
 			DeleteCspSiteDomain)
 	} else {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodDelete,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodDelete,
 			fmt.Sprintf("%s%s/%d/preapprovedlist/%s", c.config.BaseURLAPI, CSPSiteApiPath, siteID, domainRef),
 			nil,
 			DeleteCspSiteDomain)
 	}
 	if err != nil {
-		return fmt.Errorf("Error from CSP API for when deleting pre-approved domain %s from site ID %d: %s\n", domainRef, siteID, err)
+		return fmt.Errorf("Error from CSP API for when deleting pre-approved domain %s from site ID %d: %w\n", domainRef, siteID, err)
 	}
 
 	// Read the body
@@ -334,7 +335,7 @@ func (c *Client) deleteCSPPreApprovedDomains(accountID, siteID int, domainRef st
 
 	// Check the response code - no content for DELETE
 	if resp.StatusCode != 204 {
-		return fmt.Errorf("Error status code %d from CSP API when deleting pre-approved domain %s for site ID %d\n",
+		return newAPIError(resp, nil, apiResourceIDs(domainRef, siteID), "Error status code %d from CSP API when deleting pre-approved domain %s for site ID %d\n",
 			resp.StatusCode, domainRef, siteID)
 	}
 	log.Printf("[DEBUG] CSP API Delete Pre-Approved Domain %s was successful\n", domainRef)
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -27,12 +28,12 @@ type DataCenterServerEditResponse struct {
 }
 
 // AddDataCenterServer adds an incap data center server to be managed by Incapsula
-func (c *Client) AddDataCenterServer(dcID, serverAddress, isStandby string, isEnabled string) (*DataCenterServerAddResponse, error) {
+func (c *Client) AddDataCenterServer(ctx context.Context, dcID, serverAddress, isStandby string, isEnabled string) (*DataCenterServerAddResponse, error) {
 	log.Printf("[INFO] Adding Incapsula data center server for dcID: %s\n", dcID)
 
 	bIsEnabled, err := strconv.ParseBool(isEnabled)
 	if err != nil {
-		return nil, fmt.Errorf("Error from Incapsula service when adding data center server for dcID %s: %s", dcID, err)
+		return nil, fmt.Errorf("Error from Incapsula service when adding data center server for dcID %s: %w", dcID, err)
 	}
 
 	// Post form to Incapsula
@@ -43,9 +44,9 @@ func (c *Client) AddDataCenterServer(dcID, serverAddress, isStandby string, isEn
 		"is_disabled":    {strconv.FormatBool(!bIsEnabled)},
 	}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataCenterServerAdd)
-	resp, err := c.PostFormWithHeaders(reqURL, values, CreateDataCenterServer)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, CreateDataCenterServer)
 	if err != nil {
-		return nil, fmt.Errorf("Error from Incapsula service when adding data center server for dcID %s: %s", dcID, err)
+		return nil, fmt.Errorf("Error from Incapsula service when adding data center server for dcID %s: %w", dcID, err)
 	}
 
 	// Read the body
@@ -59,7 +60,7 @@ func (c *Client) AddDataCenterServer(dcID, serverAddress, isStandby string, isEn
 	var dataCenterServerAddResponse DataCenterServerAddResponse
 	err = json.Unmarshal([]byte(responseBody), &dataCenterServerAddResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing add data center server JSON response for dcID %s: %s\nresponse: %s", dcID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing add data center server JSON response for dcID %s: %w\nresponse: %s", dcID, err, string(responseBody))
 	}
 
 	// Res can sometimes oscillate between a string and number
@@ -74,14 +75,14 @@ func (c *Client) AddDataCenterServer(dcID, serverAddress, isStandby string, isEn
 
 	// Look at the response status code from Incapsula
 	if resString != "0" {
-		return nil, fmt.Errorf("Error from Incapsula service when adding data center server for dcID %s: %s", dcID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(dcID), "Error from Incapsula service when adding data center server for dcID %s: %s", dcID, string(responseBody))
 	}
 
 	return &dataCenterServerAddResponse, nil
 }
 
 // EditDataCenterServer edits the Incapsula data center server
-func (c *Client) EditDataCenterServer(serverID, serverAddress, isStandby, isEnabled string) (*DataCenterServerEditResponse, error) {
+func (c *Client) EditDataCenterServer(ctx context.Context, serverID, serverAddress, isStandby, isEnabled string) (*DataCenterServerEditResponse, error) {
 	log.Printf("[INFO] Editing Incapsula data center server for serverID: %s\n", serverID)
 
 	// Post form to Incapsula
@@ -92,9 +93,9 @@ func (c *Client) EditDataCenterServer(serverID, serverAddress, isStandby, isEnab
 		"is_enabled":     {isEnabled},
 	}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataCenterServerEdit)
-	resp, err := c.PostFormWithHeaders(reqURL, values, UpdateDataCenterServer)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, UpdateDataCenterServer)
 	if err != nil {
-		return nil, fmt.Errorf("Error editing data center server for serverID: %s: %s", serverID, err)
+		return nil, fmt.Errorf("Error editing data center server for serverID: %s: %w", serverID, err)
 	}
 
 	// Read the body
@@ -108,7 +109,7 @@ func (c *Client) EditDataCenterServer(serverID, serverAddress, isStandby, isEnab
 	var dataCenterServerEditResponse DataCenterServerEditResponse
 	err = json.Unmarshal([]byte(responseBody), &dataCenterServerEditResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing edit data center server JSON response for serverID %s: %s", serverID, err)
+		return nil, fmt.Errorf("Error parsing edit data center server JSON response for serverID %s: %w", serverID, err)
 	}
 
 	// Res can sometimes oscillate between a string and number
@@ -123,14 +124,14 @@ func (c *Client) EditDataCenterServer(serverID, serverAddress, isStandby, isEnab
 
 	// Look at the response status code from Incapsula
 	if resString != "0" {
-		return nil, fmt.Errorf("Error from Incapsula service when editing data center server for serverID %s: %s", serverID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(serverID), "Error from Incapsula service when editing data center server for serverID %s: %s", serverID, string(responseBody))
 	}
 
 	return &dataCenterServerEditResponse, nil
 }
 
 // DeleteDataCenterServer deletes a data center server currently managed by Incapsula
-func (c *Client) DeleteDataCenterServer(serverID string) error {
+func (c *Client) DeleteDataCenterServer(ctx context.Context, serverID string) error {
 	// Specifically shaded this struct, no need to share across funcs or export
 	// We only care about the response code and possibly the message
 	type DataCenterServerDeleteResponse struct {
@@ -143,9 +144,9 @@ func (c *Client) DeleteDataCenterServer(serverID string) error {
 	// Post form to Incapsula
 	values := url.Values{"server_id": {serverID}}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataCenterServerDelete)
-	resp, err := c.PostFormWithHeaders(reqURL, values, DeleteDataCenterServer)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, DeleteDataCenterServer)
 	if err != nil {
-		return fmt.Errorf("Error deleting data center server (server_id: %s): %s", serverID, err)
+		return fmt.Errorf("Error deleting data center server (server_id: %s): %w", serverID, err)
 	}
 
 	// Read the body
@@ -159,7 +160,7 @@ func (c *Client) DeleteDataCenterServer(serverID string) error {
 	var dataCenterServerDeleteResponse DataCenterServerDeleteResponse
 	err = json.Unmarshal([]byte(responseBody), &dataCenterServerDeleteResponse)
 	if err != nil {
-		return fmt.Errorf("Error parsing delete data center server JSON response (server_id: %s): %s", serverID, err)
+		return fmt.Errorf("Error parsing delete data center server JSON response (server_id: %s): %w", serverID, err)
 	}
 
 	// Res can sometimes oscillate between a string and number
@@ -177,5 +178,5 @@ func (c *Client) DeleteDataCenterServer(serverID string) error {
 		return nil
 	}
 
-	return fmt.Errorf("Error from Incapsula service when deleting data center server (server_id: %s): %s", serverID, string(responseBody))
+	return newAPIError(resp, responseBody, apiResourceIDs(serverID), "Error from Incapsula service when deleting data center server (server_id: %s): %s", serverID, string(responseBody))
 }
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -63,15 +64,15 @@ type DataCentersConfigurationDTO struct {
 }
 
 // AddDataCenter adds an incap rule to be managed by Incapsula
-func (c *Client) PutDataCentersConfiguration(siteID string, requestDTO DataCentersConfigurationDTO) (*DataCentersConfigurationDTO, error) {
+func (c *Client) PutDataCentersConfiguration(ctx context.Context, siteID string, requestDTO DataCentersConfigurationDTO) (*DataCentersConfigurationDTO, error) {
 	log.Printf("[INFO] Updating Incapsula data centers configuration for siteID: %s\n", siteID)
 
-	baseURLv3 := c.config.BaseURL[:len(c.config.BaseURL)-3] + "/v3"
+	baseURLv3 := c.config.baseURLV3()
 	dcsJSON, err := json.Marshal(requestDTO)
 	reqURL := fmt.Sprintf("%s/sites/%s/data-centers-configuration", baseURLv3, siteID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPut, reqURL, dcsJSON, CreateDataCenterConfiguration)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPut, reqURL, dcsJSON, CreateDataCenterConfiguration)
 	if err != nil {
-		return nil, fmt.Errorf("Error executing update Data Centers configuration request for siteID %s: %s", siteID, err)
+		return nil, fmt.Errorf("Error executing update Data Centers configuration request for siteID %s: %w", siteID, err)
 	}
 
 	// Read the body
@@ -79,28 +80,33 @@ func (c *Client) PutDataCentersConfiguration(siteID string, requestDTO DataCente
 	responseBody, err := ioutil.ReadAll(resp.Body)
 
 	// Dump JSON
-	log.Printf("[DEBUG] Incapsula Update Data Centers configuration JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Incapsula Update Data Centers configuration JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
+
+	// Check the response code
+	if resp.StatusCode != 200 {
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error status code %d from Incapsula service when updating Data Centers configuration for siteID %s: %s", resp.StatusCode, siteID, string(responseBody))
+	}
 
 	// Parse the JSON
 	var responseDTO DataCentersConfigurationDTO
 	err = json.Unmarshal([]byte(responseBody), &responseDTO)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing update Data Centers configuration JSON response for siteID %s: %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing update Data Centers configuration JSON response for siteID %s: %w\nresponse: %s", siteID, err, string(responseBody))
 	}
 
 	return &responseDTO, nil
 }
 
 // ListDataCenters gets the Incapsula list of data centers
-func (c *Client) GetDataCentersConfiguration(siteID string) (*DataCentersConfigurationDTO, error) {
+func (c *Client) GetDataCentersConfiguration(ctx context.Context, siteID string) (*DataCentersConfigurationDTO, error) {
 	log.Printf("[INFO] Getting Data Centers configuration (site_id: %s)\n", siteID)
 
 	// Get request to Incapsula
-	baseURLv3 := c.config.BaseURL[:len(c.config.BaseURL)-3] + "/v3"
+	baseURLv3 := c.config.baseURLV3()
 	reqURL := fmt.Sprintf("%s/sites/%s/data-centers-configuration", baseURLv3, siteID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadDataCenterConfiguration)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadDataCenterConfiguration)
 	if err != nil {
-		return nil, fmt.Errorf("Error executing get Data Centers configuration request for siteID %s: %s", siteID, err)
+		return nil, fmt.Errorf("Error executing get Data Centers configuration request for siteID %s: %w", siteID, err)
 	}
 
 	// Read the body
@@ -108,13 +114,18 @@ func (c *Client) GetDataCentersConfiguration(siteID string) (*DataCentersConfigu
 	responseBody, err := ioutil.ReadAll(resp.Body)
 
 	// Dump JSON
-	log.Printf("[DEBUG] Incapsula data centers JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Incapsula data centers JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
+
+	// Check the response code
+	if resp.StatusCode != 200 {
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error status code %d from Incapsula service when getting Data Centers configuration for siteID %s: %s", resp.StatusCode, siteID, string(responseBody))
+	}
 
 	// Parse the JSON
 	var responseDTO DataCentersConfigurationDTO
 	err = json.Unmarshal([]byte(responseBody), &responseDTO)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing data centers list JSON response for siteID: %s %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing data centers list JSON response for siteID: %s %w\nresponse: %s", siteID, err, string(responseBody))
 	}
 
 	return &responseDTO, nil
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -22,15 +23,15 @@ type DataStorageRegionResponse struct {
 }
 
 // GetDataStorageRegion gets the data storage region for the site
-func (c *Client) GetDataStorageRegion(siteID string) (*DataStorageRegionResponse, error) {
+func (c *Client) GetDataStorageRegion(ctx context.Context, siteID string) (*DataStorageRegionResponse, error) {
 	log.Printf("[INFO] Getting Incapsula data storage region for site: %s\n", siteID)
 
 	// Post form to Incapsula
 	values := url.Values{"site_id": {siteID}}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataStorageRegionGet)
-	resp, err := c.PostFormWithHeaders(reqURL, values, ReadDataStorageRegion)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, ReadDataStorageRegion)
 	if err != nil {
-		return nil, fmt.Errorf("Error getting data storage region for site id: %s: %s", siteID, err)
+		return nil, fmt.Errorf("Error getting data storage region for site id: %s: %w", siteID, err)
 	}
 
 	// Read the body
@@ -44,19 +45,19 @@ func (c *Client) GetDataStorageRegion(siteID string) (*DataStorageRegionResponse
 	var dataStorageRegionResponse DataStorageRegionResponse
 	err = json.Unmarshal([]byte(responseBody), &dataStorageRegionResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing site data storage region JSON response for site id: %s: %s", siteID, err)
+		return nil, fmt.Errorf("Error parsing site data storage region JSON response for site id: %s: %w", siteID, err)
 	}
 
 	// Look at the response status code from Incapsula
 	if dataStorageRegionResponse.Res != 0 {
-		return &dataStorageRegionResponse, fmt.Errorf("Error from Incapsula service when getting site data storage region for site id: %s: %s", siteID, string(responseBody))
+		return &dataStorageRegionResponse, newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error from Incapsula service when getting site data storage region for site id: %s: %s", siteID, string(responseBody))
 	}
 
 	return &dataStorageRegionResponse, nil
 }
 
 // UpdateDataStorageRegion will update the data storage region on the site
-func (c *Client) UpdateDataStorageRegion(siteID, region string) (*DataStorageRegionResponse, error) {
+func (c *Client) UpdateDataStorageRegion(ctx context.Context, siteID, region string) (*DataStorageRegionResponse, error) {
 	log.Printf("[INFO] Updating Incapsula site data storage region (%s) for siteID: %s\n", region, siteID)
 
 	// Post form to Incapsula
@@ -65,9 +66,9 @@ func (c *Client) UpdateDataStorageRegion(siteID, region string) (*DataStorageReg
 		"data_storage_region": {region},
 	}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataStorageRegionUpdate)
-	resp, err := c.PostFormWithHeaders(reqURL, values, UpdateDataStorageRegion)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, UpdateDataStorageRegion)
 	if err != nil {
-		return nil, fmt.Errorf("Error updating data storage region with value (%s) on site_id: %s: %s", region, siteID, err)
+		return nil, fmt.Errorf("Error updating data storage region with value (%s) on site_id: %s: %w", region, siteID, err)
 	}
 
 	// Read the body
@@ -81,12 +82,12 @@ func (c *Client) UpdateDataStorageRegion(siteID, region string) (*DataStorageReg
 	var dataStorageRegionResponse DataStorageRegionResponse
 	err = json.Unmarshal([]byte(responseBody), &dataStorageRegionResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing update site data storage region JSON response for siteID %s: %s", siteID, err)
+		return nil, fmt.Errorf("Error parsing update site data storage region JSON response for siteID %s: %w", siteID, err)
 	}
 
 	// Look at the response status code from Incapsula
 	if dataStorageRegionResponse.Res != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when updating site data storage region for siteID %s: %s", siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error from Incapsula service when updating site data storage region for siteID %s: %s", siteID, string(responseBody))
 	}
 
 	return &dataStorageRegionResponse, nil
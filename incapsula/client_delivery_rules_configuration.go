@@ -1,13 +1,12 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
 type DeliveryRulesListDTO struct {
@@ -36,20 +35,14 @@ type DeliveryRuleDto struct {
 	Enabled                 bool   `json:"enabled"`
 }
 
-func (c *Client) ReadDeliveryRuleConfiguration(siteID string, category string) (*DeliveryRulesListDTO, diag.Diagnostics) {
-	var diags diag.Diagnostics
+func (c *Client) ReadDeliveryRuleConfiguration(ctx context.Context, siteID string, category string) (*DeliveryRulesListDTO, error) {
 	log.Printf("[INFO] Getting Delivery rules Type Rule %s for Site ID %s\n", category, siteID)
 
 	reqURL := fmt.Sprintf("%s/sites/%s/delivery-rules-configuration?category=%s", c.config.BaseURLRev3, siteID, category)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadDeliveryRuleConfiguration)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadDeliveryRuleConfiguration)
 
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Error from Incapsula service when reading Delivery Rules for category :" + category,
-			Detail:   fmt.Sprintf("Error from Incapsula service when reading Delivery Rules of category %s for Site ID %s: %s", category, siteID, err),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Error from Incapsula service when reading Delivery Rules of category %s for Site ID %s: %w", category, siteID, err)
 	}
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
@@ -58,54 +51,33 @@ func (c *Client) ReadDeliveryRuleConfiguration(siteID string, category string) (
 	log.Printf("[DEBUG] Incapsula Read Delivery Rules JSON response: %s\n", string(responseBody))
 
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "error status code from Incapsula service when reading delivery rules Catagorie",
-			Detail:   fmt.Sprintf("error status code %d from Incapsula service when reading delivery rules Catagorie %s for Site ID %s: %s", resp.StatusCode, category, siteID, string(responseBody)),
-		})
-		return nil, diags
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID, category), "error status code %d from Incapsula service when reading delivery rules Catagorie %s for Site ID %s: %s", resp.StatusCode, category, siteID, string(responseBody))
 	}
 	var rulesPriorities DeliveryRulesListDTO
 	err = json.Unmarshal(responseBody, &rulesPriorities)
 
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("Error parsing Delivery Rules JSON response of categorie %s", category),
-			Detail:   fmt.Sprintf("Error parsing Delivery Rules JSON response of categorie %s for Site ID %s: %s\nresponse: %s", category, siteID, err, string(responseBody)),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Error parsing Delivery Rules JSON response of categorie %s for Site ID %s: %w\nresponse: %s", category, siteID, err, string(responseBody))
 	}
 	log.Printf("[INFO] Getting Delivery rules Type Rule %s for Site ID %s\n - finished", category, siteID)
 
 	return &rulesPriorities, nil
 }
 
-func (c *Client) UpdateDeliveryRuleConfiguration(siteID string, category string, rulesList *DeliveryRulesListDTO) (*DeliveryRulesListDTO, diag.Diagnostics) {
-	var diags diag.Diagnostics
+func (c *Client) UpdateDeliveryRuleConfiguration(ctx context.Context, siteID string, category string, rulesList *DeliveryRulesListDTO) (*DeliveryRulesListDTO, error) {
 	log.Printf("[INFO] Updating Delivery rules Type %s for Site ID %s\n", category, siteID)
 	ruleJSON, err := json.Marshal(rulesList)
 
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("failed to update delivery rules category %s", category),
-			Detail:   fmt.Sprintf("failed to update delivery rules category %s for site ID %s: %s", category, siteID, err),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("failed to update delivery rules category %s for site ID %s: %w", category, siteID, err)
 	}
 	log.Printf("[DEBUG] Update rule DTO request: %v\n", string(ruleJSON[:]))
 
 	// Put request to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%s/delivery-rules-configuration?category=%s", c.config.BaseURLRev3, siteID, category)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPut, reqURL, ruleJSON, UpdateDeliveryRuleConfiguration)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPut, reqURL, ruleJSON, UpdateDeliveryRuleConfiguration)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("Error from Incapsula service when updating delivery rules category : %s", category),
-			Detail:   fmt.Sprintf("Error from Incapsula service when updating delivery rules category %s for Site ID %s: %s", category, siteID, err),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Error from Incapsula service when updating delivery rules category %s for Site ID %s: %w", category, siteID, err)
 	}
 	// Read the body
 	defer resp.Body.Close()
@@ -115,24 +87,14 @@ func (c *Client) UpdateDeliveryRuleConfiguration(siteID string, category string,
 	log.Printf("[DEBUG] Incapsula Update delivery rules JSON response: %s\n", string(responseBody))
 
 	// Check the response code
-	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("error status code %d from Incapsula service", resp.StatusCode),
-			Detail:   fmt.Sprintf("error status code %d from Incapsula service when updating delivery rules category %s for Site ID %s: %s", resp.StatusCode, category, siteID, string(responseBody)),
-		})
-		return nil, diags
+	if resp.StatusCode != 200 {
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID, category), "error status code %d from Incapsula service when updating delivery rules category %s for Site ID %s: %s", resp.StatusCode, category, siteID, string(responseBody))
 	}
 	var rulesPriorities DeliveryRulesListDTO
 	err = json.Unmarshal(responseBody, &rulesPriorities)
 
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("Error parsing Delivery Rules JSON response of categorie %s", category),
-			Detail:   fmt.Sprintf("Error parsing Delivery Rules JSON response of categorie %s for Site ID %s: %s\nresponse: %s", category, siteID, err, string(responseBody)),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Error parsing Delivery Rules JSON response of categorie %s for Site ID %s: %w\nresponse: %s", category, siteID, err, string(responseBody))
 	}
 	return &rulesPriorities, nil
 }
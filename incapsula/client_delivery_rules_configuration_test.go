@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -19,12 +20,12 @@ func TestClientADReadIncaRulePriorityBadConnection(t *testing.T) {
 	siteID := "42"
 	category := "Test"
 
-	addIncapRuleResponse, diags := client.ReadDeliveryRuleConfiguration(siteID, category)
-	if diags == nil {
+	addIncapRuleResponse, err := client.ReadDeliveryRuleConfiguration(context.Background(), siteID, category)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error from Incapsula service when reading Delivery Rules of category %s for Site ID %s:", category, siteID)) {
-		t.Errorf("Should have received a client error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error from Incapsula service when reading Delivery Rules of category %s for Site ID %s:", category, siteID)) {
+		t.Errorf("Should have received a client error, got: %s", err)
 	}
 	if addIncapRuleResponse != nil {
 		t.Errorf("Should have received a nil addIncapRuleResponse instance")
@@ -49,12 +50,12 @@ func TestClientADReadIncaRulePriorityBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev3: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readIncapRuleResponse, diags := client.ReadDeliveryRuleConfiguration(siteID, category)
-	if diags == nil {
+	readIncapRuleResponse, err := client.ReadDeliveryRuleConfiguration(context.Background(), siteID, category)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error parsing Delivery Rules JSON response of categorie %s for Site ID %s", category, siteID)) {
-		t.Errorf("Should have received a JSON parse error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error parsing Delivery Rules JSON response of categorie %s for Site ID %s", category, siteID)) {
+		t.Errorf("Should have received a JSON parse error, got: %s", err)
 	}
 	if readIncapRuleResponse != nil {
 		t.Errorf("Should have received a nil readIncapRuleResponse instance")
@@ -81,7 +82,7 @@ func TestClient_ReadIncapRulePrioritiesValidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURLRev3: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readIncapRuleResponse, err := client.ReadDeliveryRuleConfiguration(siteID, "REDIRECT")
+	readIncapRuleResponse, err := client.ReadDeliveryRuleConfiguration(context.Background(), siteID, "REDIRECT")
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -120,12 +121,12 @@ func TestClientUpdateIncapRulePriorityBadConnection(t *testing.T) {
 		RulesList: rule,
 	}
 
-	updateIncapRuleResponse, diags := client.UpdateDeliveryRuleConfiguration(siteID, category, &rulesList)
-	if diags == nil {
+	updateIncapRuleResponse, err := client.UpdateDeliveryRuleConfiguration(context.Background(), siteID, category, &rulesList)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error from Incapsula service when updating delivery rules category %s for Site ID %s", category, siteID)) {
-		t.Errorf("Should have received an client error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error from Incapsula service when updating delivery rules category %s for Site ID %s", category, siteID)) {
+		t.Errorf("Should have received an client error, got: %s", err)
 	}
 	if updateIncapRuleResponse != nil {
 		t.Errorf("Should have received a nil updateIncapRuleResponse instance")
@@ -163,12 +164,12 @@ func TestClientUpdateIncapRulePriorityBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURLRev3: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	updateIncapRuleResponse, diags := client.UpdateDeliveryRuleConfiguration(siteID, category, &rulesList)
-	if diags == nil {
+	updateIncapRuleResponse, err := client.UpdateDeliveryRuleConfiguration(context.Background(), siteID, category, &rulesList)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error parsing Delivery Rules JSON response of categorie %s for Site ID %s", category, siteID)) {
-		t.Errorf("Should have received a JSON parse error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error parsing Delivery Rules JSON response of categorie %s for Site ID %s", category, siteID)) {
+		t.Errorf("Should have received a JSON parse error, got: %s", err)
 	}
 	if updateIncapRuleResponse != nil {
 		t.Errorf("Should have received a nil updateIncapRuleResponse instance")
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -15,7 +16,7 @@ type AddSiteDetails struct {
 	StrictMode bool   `json:"strictMode"`
 }
 
-func (c *Client) GetDomain(siteId string, domainId string) (*SiteDomainDetails, error) {
+func (c *Client) GetDomain(ctx context.Context, siteId string, domainId string) (*SiteDomainDetails, error) {
 
 	if siteId == "" {
 		return nil, fmt.Errorf("[ERROR] site ID was not provided")
@@ -27,9 +28,9 @@ func (c *Client) GetDomain(siteId string, domainId string) (*SiteDomainDetails,
 
 	reqURL := fmt.Sprintf("%s%s%s%s%s", c.config.BaseURLAPI, endpointDomain, siteId, "/domains/", domainId)
 
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodGet, reqURL, nil, nil, ReadDomain)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, nil, ReadDomain)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when reading domain details. domain id %s, site id %s: %s", siteId, domainId, err)
+		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when reading domain details. domain id %s, site id %s: %w", siteId, domainId, err)
 	}
 
 	defer resp.Body.Close()
@@ -40,17 +41,21 @@ func (c *Client) GetDomain(siteId string, domainId string) (*SiteDomainDetails,
 	err = json.Unmarshal(responseBody, &siteDomainDetailsResponse)
 
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error parsing get domain response for site ID %s: Domain id: %s %s\nresponse: %s", siteId, domainId, err, string(responseBody))
+		return nil, fmt.Errorf("[ERROR] Error parsing get domain response for site ID %s: Domain id: %s %w\nresponse: %s", siteId, domainId, err, string(responseBody))
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteId, domainId), "[ERROR] Error status code %d from Incapsula service when reading domain details. domain id %s, site id %s: %s", resp.StatusCode, domainId, siteId, string(responseBody))
 	}
 
 	return &siteDomainDetailsResponse, nil
 }
 
-func (c *Client) AddDomainToSite(siteID string, domainName string) (*SiteDomainDetails, error) {
+func (c *Client) AddDomainToSite(ctx context.Context, siteID string, domainName string) (*SiteDomainDetails, error) {
 
 	addDomainDto := AddSiteDetails{domainName, true}
 
-	resp, err := handleAddDomainRequest(c, addDomainDto, siteID)
+	resp, err := handleAddDomainRequest(ctx, c, addDomainDto, siteID)
 
 	if err != nil {
 		return nil, err
@@ -59,9 +64,9 @@ func (c *Client) AddDomainToSite(siteID string, domainName string) (*SiteDomainD
 	return resp, nil
 }
 
-func (c *Client) DeleteDomain(siteID string, domainId string) error {
+func (c *Client) DeleteDomain(ctx context.Context, siteID string, domainId string) error {
 
-	err := handleDeleteDomainRequest(c, siteID, domainId)
+	err := handleDeleteDomainRequest(ctx, c, siteID, domainId)
 
 	if err != nil {
 		return err
@@ -70,67 +75,67 @@ func (c *Client) DeleteDomain(siteID string, domainId string) error {
 	return nil
 }
 
-func handleAddDomainRequest(c *Client, addDomainsDto AddSiteDetails, siteId string) (*SiteDomainDetails, error) {
+func handleAddDomainRequest(ctx context.Context, c *Client, addDomainsDto AddSiteDetails, siteId string) (*SiteDomainDetails, error) {
 	reqURL := fmt.Sprintf("%s%s%s%s", c.config.BaseURLAPI, endpointDomain, siteId, "/domains")
 	body, err := json.Marshal(addDomainsDto)
 
 	if err != nil {
-		return nil, fmt.Errorf("Failed to parse addDomainsDto: %s ", err)
+		return nil, fmt.Errorf("Failed to parse addDomainsDto: %w ", err)
 	}
 
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, body, CreateDomain)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, body, CreateDomain)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when creating domains for site %s: %s", siteId, err)
+		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when creating domains for site %s: %w", siteId, err)
 	}
 	defer resp.Body.Close()
 
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %s", err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 	log.Printf("[DEBUG] Incapsula add domain response: %s\n", string(responseBody))
 
 	var siteDomainDetails SiteDomainDetails
 	err = json.Unmarshal(responseBody, &siteDomainDetails)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error parsing create domain response for siteId %s: %s\n response: %s", siteId, err, string(responseBody))
+		return nil, fmt.Errorf("[ERROR] Error parsing create domain response for siteId %s: %w\n response: %s", siteId, err, string(responseBody))
 	}
 
 	if siteDomainDetails.Errors != nil && len(siteDomainDetails.Errors) > 0 {
 		log.Printf("[ERROR] Incapsula create domain failed for site: %s \n", siteId)
-		return nil, fmt.Errorf("add domain request failed (status %d): %s", resp.StatusCode, siteDomainDetails.Errors[0].Detail)
+		return nil, newAPIError(resp, responseBody, nil, "add domain request failed (status %d): %s", resp.StatusCode, siteDomainDetails.Errors[0].Detail)
 	}
 
 	if resp.StatusCode != http.StatusOK {
 		log.Printf("[ERROR] Incapsula create domain failed for site: %s \n", siteId)
-		return nil, fmt.Errorf("create request failed: %d", resp.StatusCode)
+		return nil, newAPIError(resp, responseBody, nil, "create request failed: %d", resp.StatusCode)
 	}
 
 	return &siteDomainDetails, nil
 }
 
-func handleDeleteDomainRequest(c *Client, siteId string, domainId string) error {
+func handleDeleteDomainRequest(ctx context.Context, c *Client, siteId string, domainId string) error {
 	reqURL := fmt.Sprintf("%s%s%s%s%s", c.config.BaseURLAPI, endpointDomain, siteId, "/domains/", domainId)
 
 	var params = map[string]string{}
 	params["deleteLastDomain"] = "true"
 
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodDelete, reqURL, nil, params, DeleteDomain)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, params, DeleteDomain)
 
 	if err != nil {
-		return fmt.Errorf("[ERROR] Error from Incapsula service when deleting domains for site %s: %s", siteId, err)
+		return fmt.Errorf("[ERROR] Error from Incapsula service when deleting domains for site %s: %w", siteId, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		log.Printf("[ERROR] Incapsula delete domain failed for site: %s domain: %s \n", siteId, domainId)
-		return fmt.Errorf("delete domain request failed: %d", resp.StatusCode)
+		return newAPIError(resp, nil, nil, "delete domain request failed: %d", resp.StatusCode)
 	}
 
 	responseBody, err := ioutil.ReadAll(resp.Body)
 
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %s", err)
+		return fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	log.Printf("[DEBUG] Incapsula delete domain response: %s\n", string(responseBody))
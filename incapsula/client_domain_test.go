@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -38,7 +39,7 @@ func TestClientGetDomainForSiteValidCase(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	addDomainResponse, err := client.GetDomain(siteID, domainID)
+	addDomainResponse, err := client.GetDomain(context.Background(), siteID, domainID)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -77,7 +78,7 @@ func TestClientAddDomainForSiteValidCase(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	addDomainResponse, err := client.AddDomainToSite(siteID, "a.co")
+	addDomainResponse, err := client.AddDomainToSite(context.Background(), siteID, "a.co")
 
 	if err != nil {
 		t.Errorf("Should not have received an error")
@@ -124,7 +125,7 @@ func TestClientAddDomainForSiteInvalidCase(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	_, err := client.AddDomainToSite(siteID, "a.co")
+	_, err := client.AddDomainToSite(context.Background(), siteID, "a.co")
 
 	if err.Error() != "add domain request failed (status 400): The provided domain is not valid." {
 		t.Errorf("Should have received an error")
@@ -165,7 +166,7 @@ func TestClientAddWildcardDomainAsPrimaryReturnsDetailedError(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	_, err := client.AddDomainToSite(siteID, "*.example.com")
+	_, err := client.AddDomainToSite(context.Background(), siteID, "*.example.com")
 
 	if err == nil {
 		t.Errorf("Should have received an error when adding wildcard as primary domain")
@@ -205,7 +206,7 @@ func TestClientAddWildcardDomainAfterPrimarySucceeds(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	resp, err := client.AddDomainToSite(siteID, "*.example.com")
+	resp, err := client.AddDomainToSite(context.Background(), siteID, "*.example.com")
 
 	if err != nil {
 		t.Errorf("Should not have received an error, got: %s", err)
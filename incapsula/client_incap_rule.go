@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -49,21 +50,21 @@ type IncapRuleWithID struct {
 }
 
 // AddIncapRule adds an incap rule to be managed by Incapsula
-func (c *Client) AddIncapRule(siteID string, rule *IncapRule) (*IncapRuleWithID, error) {
+func (c *Client) AddIncapRule(ctx context.Context, siteID string, rule *IncapRule) (*IncapRuleWithID, error) {
 	log.Printf("[INFO] Adding Incapsula Incap Rule for Site ID %s\n", siteID)
 
 	ruleJSON, err := json.Marshal(rule)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to JSON marshal IncapRule: %s", err)
+		return nil, fmt.Errorf("Failed to JSON marshal IncapRule: %w", err)
 	}
 
 	log.Printf("[DEBUG] Create rule DTO request: %v\n", string(ruleJSON[:]))
 
 	// Post form to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%s/rules", c.config.BaseURLRev2, siteID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, ruleJSON, CreateIncapRule)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, ruleJSON, CreateIncapRule)
 	if err != nil {
-		return nil, fmt.Errorf("Error from Incapsula service when adding Incap Rule for Site ID %s: %s", siteID, err)
+		return nil, fmt.Errorf("Error from Incapsula service when adding Incap Rule for Site ID %s: %w", siteID, err)
 	}
 
 	// Read the body
@@ -75,28 +76,28 @@ func (c *Client) AddIncapRule(siteID string, rule *IncapRule) (*IncapRuleWithID,
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when adding Incap Rule for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error status code %d from Incapsula service when adding Incap Rule for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody))
 	}
 
 	// Parse the JSON
 	var incapRuleWithID IncapRuleWithID
 	err = json.Unmarshal([]byte(responseBody), &incapRuleWithID)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Incap Rule JSON response for Site ID %s: %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Incap Rule JSON response for Site ID %s: %w\nresponse: %s", siteID, err, string(responseBody))
 	}
 
 	return &incapRuleWithID, nil
 }
 
 // ReadIncapRule gets the specific Incap Rule
-func (c *Client) ReadIncapRule(siteID string, ruleID int) (*IncapRuleWithID, int, error) {
+func (c *Client) ReadIncapRule(ctx context.Context, siteID string, ruleID int) (*IncapRuleWithID, int, error) {
 	log.Printf("[INFO] Getting Incapsula Incap Rule %d for Site ID %s\n", ruleID, siteID)
 
 	// Post form to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%s/rules/%d", c.config.BaseURLRev2, siteID, ruleID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadIncapRule)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadIncapRule)
 	if err != nil {
-		return nil, 0, fmt.Errorf("Error from Incapsula service when reading Incap Rule %d for Site ID %s: %s", ruleID, siteID, err)
+		return nil, 0, fmt.Errorf("Error from Incapsula service when reading Incap Rule %d for Site ID %s: %w", ruleID, siteID, err)
 	}
 
 	// Read the body
@@ -108,35 +109,35 @@ func (c *Client) ReadIncapRule(siteID string, ruleID int) (*IncapRuleWithID, int
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, resp.StatusCode, fmt.Errorf("Error status code %d from Incapsula service when reading Incap Rule %d for Site ID %s: %s", resp.StatusCode, ruleID, siteID, string(responseBody))
+		return nil, resp.StatusCode, newAPIError(resp, responseBody, apiResourceIDs(ruleID, siteID), "Error status code %d from Incapsula service when reading Incap Rule %d for Site ID %s: %s", resp.StatusCode, ruleID, siteID, string(responseBody))
 	}
 
 	// Parse the JSON
 	var incapRuleWithID IncapRuleWithID
 	err = json.Unmarshal([]byte(responseBody), &incapRuleWithID)
 	if err != nil {
-		return nil, resp.StatusCode, fmt.Errorf("Error parsing Incap Rule %d JSON response for Site ID %s: %s\nresponse: %s", ruleID, siteID, err, string(responseBody))
+		return nil, resp.StatusCode, fmt.Errorf("Error parsing Incap Rule %d JSON response for Site ID %s: %w\nresponse: %s", ruleID, siteID, err, string(responseBody))
 	}
 
 	return &incapRuleWithID, resp.StatusCode, nil
 }
 
 // UpdateIncapRule updates the Incapsula Incap Rule
-func (c *Client) UpdateIncapRule(siteID string, ruleID int, rule *IncapRule) (*IncapRuleWithID, error) {
+func (c *Client) UpdateIncapRule(ctx context.Context, siteID string, ruleID int, rule *IncapRule) (*IncapRuleWithID, error) {
 	log.Printf("[INFO] Updating Incapsula Incap Rule %d for Site ID %s\n", ruleID, siteID)
 
 	ruleJSON, err := json.Marshal(rule)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to JSON marshal IncapRule: %s", err)
+		return nil, fmt.Errorf("Failed to JSON marshal IncapRule: %w", err)
 	}
 
 	log.Printf("[DEBUG] Update rule DTO request: %v\n", string(ruleJSON[:]))
 
 	// Put request to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%s/rules/%d", c.config.BaseURLRev2, siteID, ruleID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPut, reqURL, ruleJSON, UpdateIncapRule)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPut, reqURL, ruleJSON, UpdateIncapRule)
 	if err != nil {
-		return nil, fmt.Errorf("Error from Incapsula service when updating Incap Rule %d for Site ID %s: %s", ruleID, siteID, err)
+		return nil, fmt.Errorf("Error from Incapsula service when updating Incap Rule %d for Site ID %s: %w", ruleID, siteID, err)
 	}
 
 	// Read the body
@@ -148,28 +149,28 @@ func (c *Client) UpdateIncapRule(siteID string, ruleID int, rule *IncapRule) (*I
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when updating Incap Rule %d for Site ID %s: %s", resp.StatusCode, ruleID, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(ruleID, siteID), "Error status code %d from Incapsula service when updating Incap Rule %d for Site ID %s: %s", resp.StatusCode, ruleID, siteID, string(responseBody))
 	}
 
 	// Parse the JSON
 	var incapRuleWithID IncapRuleWithID
 	err = json.Unmarshal([]byte(responseBody), &incapRuleWithID)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Incap Rule %d JSON response for Site ID %s: %s\nresponse: %s", ruleID, siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Incap Rule %d JSON response for Site ID %s: %w\nresponse: %s", ruleID, siteID, err, string(responseBody))
 	}
 
 	return &incapRuleWithID, nil
 }
 
 // DeleteIncapRule deletes a site currently managed by Incapsula
-func (c *Client) DeleteIncapRule(siteID string, ruleID int) error {
+func (c *Client) DeleteIncapRule(ctx context.Context, siteID string, ruleID int) error {
 	log.Printf("[INFO] Deleting Incapsula Incap Rule %d for Site ID %s\n", ruleID, siteID)
 
 	// Delete request to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%s/rules/%d", c.config.BaseURLRev2, siteID, ruleID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodDelete, reqURL, nil, DeleteIncapRule)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, DeleteIncapRule)
 	if err != nil {
-		return fmt.Errorf("Error from Incapsula service when deleting Incap Rule %d for Site ID %s: %s", ruleID, siteID, err)
+		return fmt.Errorf("Error from Incapsula service when deleting Incap Rule %d for Site ID %s: %w", ruleID, siteID, err)
 	}
 
 	// Read the body
@@ -181,7 +182,7 @@ func (c *Client) DeleteIncapRule(siteID string, ruleID int) error {
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from Incapsula service when deleting Incap Rule %d for Site ID %s: %s", resp.StatusCode, ruleID, siteID, string(responseBody))
+		return newAPIError(resp, responseBody, apiResourceIDs(ruleID, siteID), "Error status code %d from Incapsula service when deleting Incap Rule %d for Site ID %s: %s", resp.StatusCode, ruleID, siteID, string(responseBody))
 	}
 
 	return nil
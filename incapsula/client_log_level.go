@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -11,7 +12,7 @@ import (
 const endpointSiteLogLevel = "sites/setlog"
 
 // UpdateLogLevel will update the site log level
-func (c *Client) UpdateLogLevel(siteID, logLevel, logsAccountId string) error {
+func (c *Client) UpdateLogLevel(ctx context.Context, siteID, logLevel, logsAccountId string) error {
 	type LogLevelResponse struct {
 		Res        int    `json:"res"`
 		ResMessage string `json:"res_message"`
@@ -30,9 +31,9 @@ func (c *Client) UpdateLogLevel(siteID, logLevel, logsAccountId string) error {
 		"logs_account_id": {logsAccountId},
 	}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSiteLogLevel)
-	resp, err := c.PostFormWithHeaders(reqURL, values, UpdateLogLevel)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, UpdateLogLevel)
 	if err != nil {
-		return fmt.Errorf("Error updating log level (%s) on site_id: %s: %s", logLevel, siteID, err)
+		return fmt.Errorf("Error updating log level (%s) on site_id: %s: %w", logLevel, siteID, err)
 	}
 
 	// Read the body
@@ -46,12 +47,12 @@ func (c *Client) UpdateLogLevel(siteID, logLevel, logsAccountId string) error {
 	var logLevelResponse LogLevelResponse
 	err = json.Unmarshal([]byte(responseBody), &logLevelResponse)
 	if err != nil {
-		return fmt.Errorf("Error parsing update log level JSON response for siteID %s: %s", siteID, err)
+		return fmt.Errorf("Error parsing update log level JSON response for siteID %s: %w", siteID, err)
 	}
 
 	// Look at the response status code from Incapsula
 	if logLevelResponse.Res != 0 {
-		return fmt.Errorf("Error from Incapsula service when updating log level for siteID %s: %s", siteID, string(responseBody))
+		return newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error from Incapsula service when updating log level for siteID %s: %s", siteID, string(responseBody))
 	}
 
 	return nil
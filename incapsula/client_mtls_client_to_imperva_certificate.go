@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -22,20 +23,20 @@ type ClientCaCertificateWithSites struct {
 	AssignedSites []int  `json:"assignedSites"`
 }
 
-func (c *Client) GetClientCaCertificate(accountID, certificateID string) (*ClientCaCertificateWithSites, bool, error) {
+func (c *Client) GetClientCaCertificate(ctx context.Context, accountID, certificateID string) (*ClientCaCertificateWithSites, bool, error) {
 	log.Printf("[INFO] Reading mutual TLS Client To Imperva Certificate for ID %s, Account ID %s", certificateID, accountID)
 
 	reqURL := fmt.Sprintf("%s%s%s/client-certificates/%s", c.config.BaseURLAPI, clientCertificateUrl, accountID, certificateID)
 
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadMtlsClientToImpervaCertifiate)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadMtlsClientToImpervaCertifiate)
 	if err != nil {
-		return nil, true, fmt.Errorf("[ERROR] Error from Incapsula service when reading mTLS Client CA to Imperva Certificate ID %s: %s", certificateID, err)
+		return nil, true, fmt.Errorf("[ERROR] Error from Incapsula service when reading mTLS Client CA to Imperva Certificate ID %s: %w", certificateID, err)
 	}
 
 	// Read the body
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
-	log.Printf("[DEBUG] Incapsula Get mutual TLS Client To Imperva Certificate ID %s JSON response: %s\n", accountID, string(responseBody))
+	log.Printf("[DEBUG] Incapsula Get mutual TLS Client To Imperva Certificate ID %s JSON response: %s\n", accountID, redactedBody(contentTypeApplicationJson, responseBody))
 
 	// Check if certificate exists
 	if resp.StatusCode == 406 && strings.HasPrefix(string(responseBody), "{") {
@@ -43,20 +44,20 @@ func (c *Client) GetClientCaCertificate(accountID, certificateID string) (*Clien
 		return nil, false, nil
 	}
 	if resp.StatusCode != 200 {
-		return nil, true, fmt.Errorf("[ERROR] Error status code %d from Incapsula service on fetching TLS Client to Imperva certificate ID %s\n: %s\n%s", resp.StatusCode, certificateID, err, string(responseBody))
+		return nil, true, newAPIError(resp, responseBody, apiResourceIDs(certificateID), "[ERROR] Error status code %d from Incapsula service on fetching TLS Client to Imperva certificate ID %s\n: %s\n%s", resp.StatusCode, certificateID, err, string(responseBody))
 	}
 
 	// Dump JSON
 	var clientCaCertificateWithSites ClientCaCertificateWithSites
 	err = json.Unmarshal([]byte(responseBody), &clientCaCertificateWithSites)
 	if err != nil {
-		return nil, true, fmt.Errorf("[ERROR] Error parsing mutual GET TLS Client To Imperva Certificate for Account ID %s JSON response: %s\nresponse: %s", accountID, err, string(responseBody))
+		return nil, true, fmt.Errorf("[ERROR] Error parsing mutual GET TLS Client To Imperva Certificate for Account ID %s JSON response: %w\nresponse: %s", accountID, err, string(responseBody))
 	}
 
 	return &clientCaCertificateWithSites, true, nil
 }
 
-func (c *Client) AddClientCaCertificate(certificate []byte, accountID, certificateName string) (*ClientCaCertificate, error) {
+func (c *Client) AddClientCaCertificate(ctx context.Context, certificate []byte, accountID, certificateName string) (*ClientCaCertificate, error) {
 	log.Printf("[INFO] Creating mutual TLS Client To Imperva Certificate for Account ID %s", accountID)
 	reqURL := fmt.Sprintf("%s%s%s/client-certificates", c.config.BaseURLAPI, clientCertificateUrl, accountID)
 	bodyMap := map[string]interface{}{}
@@ -73,7 +74,7 @@ func (c *Client) AddClientCaCertificate(certificate []byte, accountID, certifica
 
 	body, contentType := c.CreateFormDataBody(bodyMap)
 
-	resp, err := c.DoFormDataRequestWithHeaders(http.MethodPost, reqURL, body, contentType, CreateMtlsClientToImpervaCertifiate)
+	resp, err := c.DoFormDataRequestWithHeaders(ctx, http.MethodPost, reqURL, body, contentType, CreateMtlsClientToImpervaCertifiate)
 
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR] Error from Incapsula while creating mutual TLS Client To Imperva Certificate for Account ID %s", accountID)
@@ -82,18 +83,18 @@ func (c *Client) AddClientCaCertificate(certificate []byte, accountID, certifica
 	// Read the body
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
-	log.Printf("[DEBUG] Incapsula create mutual TLS Client To Imperva Certificate for Account ID %s JSON response: %s\n", accountID, string(responseBody))
+	log.Printf("[DEBUG] Incapsula create mutual TLS Client To Imperva Certificate for Account ID %s JSON response: %s\n", accountID, redactedBody(contentTypeApplicationJson, responseBody))
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("[ERROR] Error status code %d from Incapsula service on create mutual TLS Client To Imperva certificate for account ID %s : %s", resp.StatusCode, accountID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(accountID), "[ERROR] Error status code %d from Incapsula service on create mutual TLS Client To Imperva certificate for account ID %s : %s", resp.StatusCode, accountID, string(responseBody))
 	}
 
 	// Dump JSON
 	var clientCaCertificateList []ClientCaCertificate
 	err = json.Unmarshal([]byte(responseBody), &clientCaCertificateList)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error parsing ADD mutual TLS Client To Imperva Certificate for Account ID %s JSON response: %s\nresponse: %s", accountID, err, string(responseBody))
+		return nil, fmt.Errorf("[ERROR] Error parsing ADD mutual TLS Client To Imperva Certificate for Account ID %s JSON response: %w\nresponse: %s", accountID, err, string(responseBody))
 	}
 
 	if len(clientCaCertificateList) < 1 {
@@ -102,18 +103,18 @@ func (c *Client) AddClientCaCertificate(certificate []byte, accountID, certifica
 	return &clientCaCertificateList[0], nil
 }
 
-func (c *Client) DeleteClientCaCertificate(accountID, certificateID string) error {
+func (c *Client) DeleteClientCaCertificate(ctx context.Context, accountID, certificateID string) error {
 	log.Printf("[INFO] Deleting mutual TLS Client To Imperva Certificate ID %s, Account ID %s", certificateID, accountID)
 
 	reqURL := fmt.Sprintf("%s%s%s/client-certificates/%s", c.config.BaseURLAPI, clientCertificateUrl, accountID, certificateID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodDelete, reqURL, nil, DeleteMtlsClientToImpervaCertifiate)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, DeleteMtlsClientToImpervaCertifiate)
 	if err != nil {
-		return fmt.Errorf("[ERROR] Error from Incapsula service when deletingmutual TLS Client To Imperva Certificate ID %s: %s", certificateID, err)
+		return fmt.Errorf("[ERROR] Error from Incapsula service when deletingmutual TLS Client To Imperva Certificate ID %s: %w", certificateID, err)
 	}
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("[ERROR] Error status code %d from Incapsula service on deleting mutual TLS Client To Imperva Certificate ID %s\n: %v", resp.StatusCode, certificateID, err)
+		return newAPIError(resp, nil, apiResourceIDs(certificateID), "[ERROR] Error status code %d from Incapsula service on deleting mutual TLS Client To Imperva Certificate ID %s\n: %v", resp.StatusCode, certificateID, err)
 	}
 
 	// Read the body
@@ -121,7 +122,7 @@ func (c *Client) DeleteClientCaCertificate(accountID, certificateID string) erro
 	_, err = ioutil.ReadAll(resp.Body)
 
 	if err != nil {
-		return fmt.Errorf("[ERROR] Error deleting mutual TLS Client To Imperva Certificate ID %s: %s", certificateID, err)
+		return fmt.Errorf("[ERROR] Error deleting mutual TLS Client To Imperva Certificate ID %s: %w", certificateID, err)
 	}
 	return nil
 }
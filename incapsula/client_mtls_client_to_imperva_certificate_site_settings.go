@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -24,19 +25,19 @@ type SiteTlsSettings struct {
 	IsDisableSessionResumption bool     `json:"isDisableSessionResumption"`
 }
 
-func (c *Client) GetSiteTlsSettings(siteID int) (*SiteTlsSettings, bool, error) {
+func (c *Client) GetSiteTlsSettings(ctx context.Context, siteID int) (*SiteTlsSettings, bool, error) {
 	log.Printf("[INFO] Getting Site TLS Settings for Site ID %d", siteID)
 	reqURL := fmt.Sprintf("%s/certificate-manager/v2/sites/%d%s", c.config.BaseURLAPI, siteID, endpointSiteTlsSettings)
 
 	//todo KATRIN add operation
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadSiteTlsSettings)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadSiteTlsSettings)
 	if err != nil {
-		return nil, true, fmt.Errorf("[ERROR] Error getting Site TLS Settings for Site ID %d: %s", siteID, err)
+		return nil, true, fmt.Errorf("[ERROR] Error getting Site TLS Settings for Site ID %d: %w", siteID, err)
 	}
 	// Read the body
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
-	log.Printf("[DEBUG] Incapsula Get Site TLS Settings JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Incapsula Get Site TLS Settings JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 
 	// Check the response code
 	//add 404 logic
@@ -45,48 +46,48 @@ func (c *Client) GetSiteTlsSettings(siteID int) (*SiteTlsSettings, bool, error)
 		return nil, false, nil
 	}
 	if resp.StatusCode != 200 {
-		return nil, true, fmt.Errorf("[ERROR] Error status code %d from Incapsula service on fetching Incapsula Site TLS Settings for Site ID %d\n: %s", resp.StatusCode, siteID, string(responseBody))
+		return nil, true, newAPIError(resp, responseBody, apiResourceIDs(siteID), "[ERROR] Error status code %d from Incapsula service on fetching Incapsula Site TLS Settings for Site ID %d\n: %s", resp.StatusCode, siteID, string(responseBody))
 	}
 
 	// Dump JSON
 	var siteTlsSettings SiteTlsSettings
 	err = json.Unmarshal([]byte(responseBody), &siteTlsSettings)
 	if err != nil {
-		return nil, true, fmt.Errorf("[ERROR] Error parsing Incapsula Site TLS Settings JSON response for Site ID %d: %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, true, fmt.Errorf("[ERROR] Error parsing Incapsula Site TLS Settings JSON response for Site ID %d: %w\nresponse: %s", siteID, err, string(responseBody))
 	}
 
 	return &siteTlsSettings, true, nil
 }
 
-func (c *Client) UpdateSiteTlsSetings(siteID int, siteTlsSettingsPayload SiteTlsSettings) error {
+func (c *Client) UpdateSiteTlsSetings(ctx context.Context, siteID int, siteTlsSettingsPayload SiteTlsSettings) error {
 	siteTlsSettingsJSON, err := json.Marshal(siteTlsSettingsPayload)
 	if err != nil {
-		return fmt.Errorf("Failed to JSON marshal site TLS settings: %s", err)
+		return fmt.Errorf("Failed to JSON marshal site TLS settings: %w", err)
 	}
-	log.Printf("ssl settings JSON:\n%s", siteTlsSettingsJSON)
+	log.Printf("ssl settings JSON:\n%s", redactedBody(contentTypeApplicationJson, siteTlsSettingsJSON))
 	log.Printf("[INFO] Updating Site TLS Settings for Site ID %d", siteID)
 	reqURL := fmt.Sprintf("%s/certificate-manager/v2/sites/%d/configuration/client-certificates", c.config.BaseURLAPI, siteID)
 
 	//todo KATRIN add operation
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPut, reqURL, siteTlsSettingsJSON, CreateSiteTlsSettings)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPut, reqURL, siteTlsSettingsJSON, CreateSiteTlsSettings)
 	if err != nil {
-		return fmt.Errorf("[ERROR] Error updating Site TLS Settings for Site ID %d: %s", siteID, err)
+		return fmt.Errorf("[ERROR] Error updating Site TLS Settings for Site ID %d: %w", siteID, err)
 	}
 	// Read the body
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
-	log.Printf("[DEBUG] Incapsula Update Site TLS Settings for Site ID %d  JSON response: %s\n", siteID, string(responseBody))
+	log.Printf("[DEBUG] Incapsula Update Site TLS Settings for Site ID %d  JSON response: %s\n", siteID, redactedBody(contentTypeApplicationJson, responseBody))
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("[ERROR] Error status code %d from Incapsula service on update Incapsula Site TLS Settings for Site ID %d\n: %s", resp.StatusCode, siteID, string(responseBody))
+		return newAPIError(resp, responseBody, apiResourceIDs(siteID), "[ERROR] Error status code %d from Incapsula service on update Incapsula Site TLS Settings for Site ID %d\n: %s", resp.StatusCode, siteID, string(responseBody))
 	}
 
 	// Dump JSON
 	var siteTlsSettings SiteTlsSettings
 	err = json.Unmarshal([]byte(responseBody), &siteTlsSettings)
 	if err != nil {
-		return fmt.Errorf("[ERROR] Error parsing Incapsula Site TLS Settings JSON response for Site ID %d: %s\nresponse: %s", siteID, err, string(responseBody))
+		return fmt.Errorf("[ERROR] Error parsing Incapsula Site TLS Settings JSON response for Site ID %d: %w\nresponse: %s", siteID, err, string(responseBody))
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -17,7 +18,7 @@ func TestGetSiteTlsSettingsBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := 42
 
-	siteTlsSettings, _, err := client.GetSiteTlsSettings(siteID)
+	siteTlsSettings, _, err := client.GetSiteTlsSettings(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -48,7 +49,7 @@ func TestGetSiteTlsSettingsBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siteTlsSettings, _, err := client.GetSiteTlsSettings(siteID)
+	siteTlsSettings, _, err := client.GetSiteTlsSettings(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -78,7 +79,7 @@ func TestGetSiteTlsSettingsInvalidApiConfigInvalidConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siteTlsSettings, _, err := client.GetSiteTlsSettings(siteID)
+	siteTlsSettings, _, err := client.GetSiteTlsSettings(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -128,7 +129,7 @@ func TestGetSiteTlsSettingsValidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siteTlsSettings, _, err := client.GetSiteTlsSettings(siteID)
+	siteTlsSettings, _, err := client.GetSiteTlsSettings(context.Background(), siteID)
 
 	if err != nil {
 		t.Errorf("Should not have received an error : %s\n, %v", err.Error(), siteTlsSettings)
@@ -150,7 +151,7 @@ func TestUpdateSiteTlsSetingsBadConnection(t *testing.T) {
 	siteID := 42
 	siteTlsSettingsPayload := SiteTlsSettings{}
 
-	err := client.UpdateSiteTlsSetings(siteID, siteTlsSettingsPayload)
+	err := client.UpdateSiteTlsSetings(context.Background(), siteID, siteTlsSettingsPayload)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -179,7 +180,7 @@ func TestUpdateSiteTlsSetingsBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.UpdateSiteTlsSetings(siteID, siteTlsSettingsPayload)
+	err := client.UpdateSiteTlsSetings(context.Background(), siteID, siteTlsSettingsPayload)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -207,7 +208,7 @@ func TestUpdateSiteTlsSetingsInvalidConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.UpdateSiteTlsSetings(siteID, siteTlsSettingsPayload)
+	err := client.UpdateSiteTlsSetings(context.Background(), siteID, siteTlsSettingsPayload)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -255,7 +256,7 @@ func TestUpdateSiteTlsSetingsValidConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.UpdateSiteTlsSetings(siteID, siteTlsSettingsPayload)
+	err := client.UpdateSiteTlsSetings(context.Background(), siteID, siteTlsSettingsPayload)
 
 	if err != nil {
 		t.Errorf("Should not have received an error : %s\n,", err.Error())
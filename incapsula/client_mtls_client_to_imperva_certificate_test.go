@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -18,7 +19,7 @@ func TestGetClientCaCertificateBadConnection(t *testing.T) {
 	certificateID := "42"
 	accountID := "100"
 
-	mTLSClientToImpervaCertificateResponse, _, err := client.GetClientCaCertificate(accountID, certificateID)
+	mTLSClientToImpervaCertificateResponse, _, err := client.GetClientCaCertificate(context.Background(), accountID, certificateID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -49,7 +50,7 @@ func TestGetClientCaCertificateBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	mTLSClientToImpervaCertificateResponse, _, err := client.GetClientCaCertificate(accountID, certificateID)
+	mTLSClientToImpervaCertificateResponse, _, err := client.GetClientCaCertificate(context.Background(), accountID, certificateID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -95,7 +96,7 @@ func TestGetClientCaCertificateInvalidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	mTLSClientToImpervaCertificateResponse, _, err := client.GetClientCaCertificate(accountID, certificateID)
+	mTLSClientToImpervaCertificateResponse, _, err := client.GetClientCaCertificate(context.Background(), accountID, certificateID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -133,7 +134,7 @@ func TestGetClientCaCertificateValidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	mTLSClientToImpervaCertificateResponse, _, err := client.GetClientCaCertificate(accountID, certificateID)
+	mTLSClientToImpervaCertificateResponse, _, err := client.GetClientCaCertificate(context.Background(), accountID, certificateID)
 
 	if err != nil {
 		t.Errorf("Should not have received an error : %s\n, %v", err.Error(), mTLSClientToImpervaCertificateResponse)
@@ -156,7 +157,7 @@ func TestAddClientCaCertificateBadConnection(t *testing.T) {
 	accountID := "100"
 	certificate := []byte{}
 
-	mTLSClientToImpervaCertificateResponse, err := client.AddClientCaCertificate(certificate, accountID, certificateName)
+	mTLSClientToImpervaCertificateResponse, err := client.AddClientCaCertificate(context.Background(), certificate, accountID, certificateName)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -189,7 +190,7 @@ func TestAddClientCaCertificateBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	mTLSClientToImpervaCertificateResponse, err := client.AddClientCaCertificate(certificate, accountID, certificateName)
+	mTLSClientToImpervaCertificateResponse, err := client.AddClientCaCertificate(context.Background(), certificate, accountID, certificateName)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -234,7 +235,7 @@ func TestAddClientCaCertificateInvalidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	mTLSClientToImpervaCertificateResponse, err := client.AddClientCaCertificate(certificate, accountID, certificateName)
+	mTLSClientToImpervaCertificateResponse, err := client.AddClientCaCertificate(context.Background(), certificate, accountID, certificateName)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -274,7 +275,7 @@ func TestAddClientCaCertificateValidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	mTLSClientToImpervaCertificateResponse, err := client.AddClientCaCertificate(certificate, accountID, certificateName)
+	mTLSClientToImpervaCertificateResponse, err := client.AddClientCaCertificate(context.Background(), certificate, accountID, certificateName)
 
 	if err != nil {
 		t.Errorf("Should not have received an error : %s\n, %v", err.Error(), mTLSClientToImpervaCertificateResponse)
@@ -296,7 +297,7 @@ func TestDeleteClientCaCertificateBadConnection(t *testing.T) {
 	certificateID := "42"
 	accountID := "100"
 
-	err := client.DeleteClientCaCertificate(accountID, certificateID)
+	err := client.DeleteClientCaCertificate(context.Background(), accountID, certificateID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -328,7 +329,7 @@ func TestDeleteClientCaCertificateInvalidConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.DeleteClientCaCertificate(accountID, certificateID)
+	err := client.DeleteClientCaCertificate(context.Background(), accountID, certificateID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -358,7 +359,7 @@ func TestDeleteClientCaCertificateValidConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.DeleteClientCaCertificate(accountID, certificateID)
+	err := client.DeleteClientCaCertificate(context.Background(), accountID, certificateID)
 
 	if err != nil {
 		t.Errorf("Should not have received an error : %v\n", err.Error())
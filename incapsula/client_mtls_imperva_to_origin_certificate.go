@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -32,50 +33,50 @@ type MTLSCertificateResponse struct {
 	Data []MTLSCertificate `json:"data"`
 }
 
-func (c *Client) AddMTLSCertificate(certificate, privateKey []byte, passphrase, certificateName, inputHash, accountID string) (*MTLSCertificate, error) {
+func (c *Client) AddMTLSCertificate(ctx context.Context, certificate, privateKey []byte, passphrase, certificateName, inputHash, accountID string) (*MTLSCertificate, error) {
 	log.Printf("[INFO] Adding mutual TLS Imperva to Origin Certificate")
 	reqURL := fmt.Sprintf("%s%s", c.config.BaseURLAPI, endpointMTLSCertificate)
 	if accountID != "" {
 		reqURL = fmt.Sprintf("%s%s?caid=%s", c.config.BaseURLAPI, endpointMTLSCertificate, accountID)
 	}
-	return c.editMTLSCertificate(http.MethodPost, reqURL, certificate, privateKey, passphrase, certificateName, inputHash, "Create", CreateMtlsImpervaToOriginCertifiate)
+	return c.editMTLSCertificate(ctx, http.MethodPost, reqURL, certificate, privateKey, passphrase, certificateName, inputHash, "Create", CreateMtlsImpervaToOriginCertifiate)
 }
 
-func (c *Client) UpdateMTLSCertificate(certificateID string, certificate, privateKey []byte, passphrase, certificateName, inputHash, accountID string) (*MTLSCertificate, error) {
+func (c *Client) UpdateMTLSCertificate(ctx context.Context, certificateID string, certificate, privateKey []byte, passphrase, certificateName, inputHash, accountID string) (*MTLSCertificate, error) {
 	log.Printf("[INFO] Updating mutual TLS Imperva to Origin Certificate with ID %s", certificateID)
 	reqURL := fmt.Sprintf("%s%s/%s", c.config.BaseURLAPI, endpointMTLSCertificate, certificateID)
 	if accountID != "" {
 		reqURL = fmt.Sprintf("%s%s/%s?caid=%s", c.config.BaseURLAPI, endpointMTLSCertificate, certificateID, accountID)
 	}
-	return c.editMTLSCertificate(http.MethodPut, reqURL, certificate, privateKey, passphrase, certificateName, inputHash, "Update", UpdateMtlsImpervaToOriginCertifiate)
+	return c.editMTLSCertificate(ctx, http.MethodPut, reqURL, certificate, privateKey, passphrase, certificateName, inputHash, "Update", UpdateMtlsImpervaToOriginCertifiate)
 }
 
-func (c *Client) GetMTLSCertificate(certificateID, accountID string) (*MTLSCertificate, error) {
+func (c *Client) GetMTLSCertificate(ctx context.Context, certificateID, accountID string) (*MTLSCertificate, error) {
 	log.Printf("[INFO] Reading mutual TLS Imperva to Origin Certificate with ID %s", certificateID)
 	//todo refactor !! move to separate method
 	reqURL := fmt.Sprintf("%s%s/%s", c.config.BaseURLAPI, endpointMTLSCertificate, certificateID)
 	if accountID != "" {
 		reqURL = fmt.Sprintf("%s%s/%s?caid=%s", c.config.BaseURLAPI, endpointMTLSCertificate, certificateID, accountID)
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadMtlsImpervaToOriginCertifiate)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadMtlsImpervaToOriginCertifiate)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when reading mTLS Imperva to Origin Certificate ID %s: %s", certificateID, err)
+		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when reading mTLS Imperva to Origin Certificate ID %s: %w", certificateID, err)
 	}
 
 	// Read the body
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
-	log.Printf("[DEBUG] Incapsula Get mutual TLS Imperva to Origin Certificate JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Incapsula Get mutual TLS Imperva to Origin Certificate JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("[ERROR] Error status code %d from Incapsula service on fetching mutual TLS Imperva to Origin certificate ID %s\n: %s\n%s", resp.StatusCode, certificateID, err, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(certificateID), "[ERROR] Error status code %d from Incapsula service on fetching mutual TLS Imperva to Origin certificate ID %s\n: %s\n%s", resp.StatusCode, certificateID, err, string(responseBody))
 	}
 	// Dump JSON
 	var mtlsCertificate MTLSCertificateResponse
 	err = json.Unmarshal([]byte(responseBody), &mtlsCertificate)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error parsing mutual TLS Imperva to Origin Certificate JSON response for certificate ID %s: %s\nresponse: %s", certificateID, err, string(responseBody))
+		return nil, fmt.Errorf("[ERROR] Error parsing mutual TLS Imperva to Origin Certificate JSON response for certificate ID %s: %w\nresponse: %s", certificateID, err, string(responseBody))
 	}
 	if len(mtlsCertificate.Data) > 0 {
 		return &mtlsCertificate.Data[0], nil
@@ -84,7 +85,7 @@ func (c *Client) GetMTLSCertificate(certificateID, accountID string) (*MTLSCerti
 	}
 
 }
-func (c *Client) editMTLSCertificate(hhtpMethod, reqURL string, certificate, privateKey []byte, passphrase, certificateName, inputHash, action, operation string) (*MTLSCertificate, error) {
+func (c *Client) editMTLSCertificate(ctx context.Context, hhtpMethod, reqURL string, certificate, privateKey []byte, passphrase, certificateName, inputHash, action, operation string) (*MTLSCertificate, error) {
 	bodyMap := map[string]interface{}{}
 	bodyMap["certificateFile"] = []byte(certificate)
 
@@ -104,26 +105,26 @@ func (c *Client) editMTLSCertificate(hhtpMethod, reqURL string, certificate, pri
 	}
 
 	bodyNew, contentTypeNew := c.CreateFormDataBody(bodyMap)
-	resp, err := c.DoFormDataRequestWithHeaders(hhtpMethod, reqURL, bodyNew, contentTypeNew, operation)
+	resp, err := c.DoFormDataRequestWithHeaders(ctx, hhtpMethod, reqURL, bodyNew, contentTypeNew, operation)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error while %s mTLS Imperva to Origin Certificate: %s", action, err)
+		return nil, fmt.Errorf("[ERROR] Error while %s mTLS Imperva to Origin Certificate: %w", action, err)
 	}
 
 	// Read the body
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
-	log.Printf("[DEBUG] Incapsula %s mutual TLS Imperva to Origin Certificate JSON response: %s\n", action, string(responseBody))
+	log.Printf("[DEBUG] Incapsula %s mutual TLS Imperva to Origin Certificate JSON response: %s\n", action, redactedBody(contentTypeApplicationJson, responseBody))
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("[ERROR] Error status code %d from Incapsula service on %s mutual TLS Imperva to Origin certificate: %s", resp.StatusCode, action, string(responseBody))
+		return nil, newAPIError(resp, responseBody, nil, "[ERROR] Error status code %d from Incapsula service on %s mutual TLS Imperva to Origin certificate: %s", resp.StatusCode, action, string(responseBody))
 	}
 
 	// Dump JSON
 	var mtlsCertificate MTLSCertificateResponse
 	err = json.Unmarshal([]byte(responseBody), &mtlsCertificate)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error parsing mutual TLS Imperva to Origin Certificate JSON response: %s\nresponse: %s", err, string(responseBody))
+		return nil, fmt.Errorf("[ERROR] Error parsing mutual TLS Imperva to Origin Certificate JSON response: %w\nresponse: %s", err, string(responseBody))
 	}
 	if len(mtlsCertificate.Data) > 0 {
 		return &mtlsCertificate.Data[0], nil
@@ -132,19 +133,19 @@ func (c *Client) editMTLSCertificate(hhtpMethod, reqURL string, certificate, pri
 	}
 
 }
-func (c *Client) DeleteMTLSCertificate(certificateID, accountID string) error {
+func (c *Client) DeleteMTLSCertificate(ctx context.Context, certificateID, accountID string) error {
 	log.Printf("[INFO] Deleting mTLS certificate with ID %s", certificateID)
 
 	reqURL := fmt.Sprintf("%s%s/%s", c.config.BaseURLAPI, endpointMTLSCertificate, certificateID)
 
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodDelete, reqURL, nil, DeleteMtlsImpervaToOriginCertifiate)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, DeleteMtlsImpervaToOriginCertifiate)
 	if err != nil {
-		return fmt.Errorf("[ERROR] Error from Incapsula service when deleting mTLS Imperva to Origin Certificate ID %s: %s", certificateID, err)
+		return fmt.Errorf("[ERROR] Error from Incapsula service when deleting mTLS Imperva to Origin Certificate ID %s: %w", certificateID, err)
 	}
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("[ERROR] Error status code %d from Incapsula service on deleting mutual TLS Imperva to Origin certificate ID %s\n: %s", resp.StatusCode, certificateID, err)
+		return newAPIError(resp, nil, apiResourceIDs(certificateID), "[ERROR] Error status code %d from Incapsula service on deleting mutual TLS Imperva to Origin certificate ID %s\n: %s", resp.StatusCode, certificateID, err)
 	}
 
 	// Read the body
@@ -152,7 +153,7 @@ func (c *Client) DeleteMTLSCertificate(certificateID, accountID string) error {
 	_, err = ioutil.ReadAll(resp.Body)
 
 	if err != nil {
-		return fmt.Errorf("[ERROR] Error deleting mTLS Imperva to Origin Certificate: %s", err)
+		return fmt.Errorf("[ERROR] Error deleting mTLS Imperva to Origin Certificate: %w", err)
 	}
 	return nil
 }
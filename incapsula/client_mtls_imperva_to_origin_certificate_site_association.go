@@ -2,26 +2,27 @@ package incapsula
 
 import (
 	//"encoding/json"
+	"context"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 )
 
-func (c *Client) GetSiteMtlsCertificateAssociation(certificateID, siteID int, accountId string) (bool, error) {
+func (c *Client) GetSiteMtlsCertificateAssociation(ctx context.Context, certificateID, siteID int, accountId string) (bool, error) {
 	log.Printf("[INFO] Getting Site to mutual TLS Imperva to Origin Certificate association for Site ID %d", siteID)
 	reqURL := fmt.Sprintf("%s%s/%d/associated-sites/%d", c.config.BaseURLAPI, endpointMTLSCertificate, certificateID, siteID)
 	if accountId != "" {
 		reqURL = fmt.Sprintf("%s?caid=%s", reqURL, accountId)
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, "ReadSiteMtlsImpervaToOriginCertifiateAssociation")
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, "ReadSiteMtlsImpervaToOriginCertifiateAssociation")
 	if err != nil {
-		return false, fmt.Errorf("[ERROR] Error getting Site to mutual TLS Imperva to Origin Certificate association for Site ID %d: %s", siteID, err)
+		return false, fmt.Errorf("[ERROR] Error getting Site to mutual TLS Imperva to Origin Certificate association for Site ID %d: %w", siteID, err)
 	}
 	// Read the body
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
-	log.Printf("[DEBUG] Incapsula Get  Site to mutual TLS Imperva to Origin Certificate association JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Incapsula Get  Site to mutual TLS Imperva to Origin Certificate association JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 
 	// Check the response code
 	if resp.StatusCode == 404 {
@@ -29,50 +30,50 @@ func (c *Client) GetSiteMtlsCertificateAssociation(certificateID, siteID int, ac
 	} else if resp.StatusCode == 200 {
 		return true, err
 	} else {
-		return false, fmt.Errorf("[ERROR] Error status code %d from Incapsula service on fetching Incapsula Site to mutual TLS Imperva to Origin certificate association for Site ID %d\n: %s", resp.StatusCode, siteID, string(responseBody))
+		return false, newAPIError(resp, responseBody, apiResourceIDs(siteID), "[ERROR] Error status code %d from Incapsula service on fetching Incapsula Site to mutual TLS Imperva to Origin certificate association for Site ID %d\n: %s", resp.StatusCode, siteID, string(responseBody))
 	}
 }
 
-func (c *Client) CreateSiteMtlsCertificateAssociation(certificateID, siteID int, accountId string) error {
+func (c *Client) CreateSiteMtlsCertificateAssociation(ctx context.Context, certificateID, siteID int, accountId string) error {
 	log.Printf("[INFO] Updating Site to mutual TLS Imperva to Origin Certificate association for certificate ID %d, Site ID %d", certificateID, siteID)
 	reqURL := fmt.Sprintf("%s%s/%d/associated-sites/%d", c.config.BaseURLAPI, endpointMTLSCertificate, certificateID, siteID)
 	if accountId != "" {
 		reqURL = fmt.Sprintf("%s?caid=%s", reqURL, accountId)
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPut, reqURL, nil, CreateSiteMtlsImpervaToOriginCertifiateAssociation)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPut, reqURL, nil, CreateSiteMtlsImpervaToOriginCertifiateAssociation)
 	if err != nil {
-		return fmt.Errorf("[ERROR] Error creating Incapsula Site to Imperva to Origin mutual TLS Certificate Association for certificate ID %d, Site ID %d\n%s", certificateID, siteID, err)
+		return fmt.Errorf("[ERROR] Error creating Incapsula Site to Imperva to Origin mutual TLS Certificate Association for certificate ID %d, Site ID %d\n%w", certificateID, siteID, err)
 	}
 
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
-	log.Printf("[DEBUG] Incapsula update Site to mutual TLS Imperva to Origin Certificate association JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Incapsula update Site to mutual TLS Imperva to Origin Certificate association JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("[ERROR] Error status code %d from Incapsula service on creating Incapsula Site to mutual TLS Imperva to Origin certificate Association for Site ID %d, Certificate ID %d:\n%s", resp.StatusCode, siteID, certificateID, string(responseBody))
+		return newAPIError(resp, responseBody, apiResourceIDs(siteID, certificateID), "[ERROR] Error status code %d from Incapsula service on creating Incapsula Site to mutual TLS Imperva to Origin certificate Association for Site ID %d, Certificate ID %d:\n%s", resp.StatusCode, siteID, certificateID, string(responseBody))
 	}
 	return nil
 }
 
-func (c *Client) DeleteSiteMtlsCertificateAssociation(certificateID, siteID int, accountId string) error {
+func (c *Client) DeleteSiteMtlsCertificateAssociation(ctx context.Context, certificateID, siteID int, accountId string) error {
 	log.Printf("[INFO] Unassigning Site to mutual TLS Imperva to Origin Certificate association for certificate ID %d, Site ID %d", certificateID, siteID)
 	reqURL := fmt.Sprintf("%s%s/%d/associated-sites/%d", c.config.BaseURLAPI, endpointMTLSCertificate, certificateID, siteID)
 	if accountId != "" {
 		reqURL = fmt.Sprintf("%s?caid=%s", reqURL, accountId)
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodDelete, reqURL, nil, DeleteSiteMtlsImpervaToOriginCertifiateAssociation)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, DeleteSiteMtlsImpervaToOriginCertifiateAssociation)
 	if err != nil {
-		return fmt.Errorf("[ERROR] Error deleting Incapsula Site to Imperva to Origin mutual TLS Certificate Association for certificate ID %d for Site ID %d\n%s", certificateID, siteID, err)
+		return fmt.Errorf("[ERROR] Error deleting Incapsula Site to Imperva to Origin mutual TLS Certificate Association for certificate ID %d for Site ID %d\n%w", certificateID, siteID, err)
 	}
 
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
-	log.Printf("[DEBUG] Incapsula delete Site to Imperva to Origin mutual TLS Certificate Association JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Incapsula delete Site to Imperva to Origin mutual TLS Certificate Association JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 
 	// Check the response code
 	if resp.StatusCode != 200 && resp.StatusCode != 404 {
-		return fmt.Errorf("[ERROR] Error status code %d from Incapsula service on fetching site to mutual TLS Imperva to Origin certificate Association for certificate ID %d for Site ID %d\n%s", resp.StatusCode, certificateID, siteID, string(responseBody))
+		return newAPIError(resp, responseBody, apiResourceIDs(certificateID, siteID), "[ERROR] Error status code %d from Incapsula service on fetching site to mutual TLS Imperva to Origin certificate Association for certificate ID %d for Site ID %d\n%s", resp.StatusCode, certificateID, siteID, string(responseBody))
 	}
 	return nil
 }
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -18,7 +19,7 @@ func TestGetMTLSCertificateBadConnection(t *testing.T) {
 	certificateID := "42"
 	accountID := "777"
 
-	mTLSCertificateResponse, err := client.GetMTLSCertificate(certificateID, accountID)
+	mTLSCertificateResponse, err := client.GetMTLSCertificate(context.Background(), certificateID, accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -50,7 +51,7 @@ func TestGetMTLSCertificateBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	mTLSCertificateResponse, err := client.GetMTLSCertificate(certificateID, accountID)
+	mTLSCertificateResponse, err := client.GetMTLSCertificate(context.Background(), certificateID, accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -97,7 +98,7 @@ func TestGetMTLSCertificateInvalidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	mTLSCertificateResponse, err := client.GetMTLSCertificate(certificateID, accountID)
+	mTLSCertificateResponse, err := client.GetMTLSCertificate(context.Background(), certificateID, accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -152,7 +153,7 @@ func TestGetMTLSCertificateValidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	mTLSCertificateResponse, err := client.GetMTLSCertificate(certificateID, accountID)
+	mTLSCertificateResponse, err := client.GetMTLSCertificate(context.Background(), certificateID, accountID)
 
 	if err != nil {
 		t.Errorf("Should not have received an error : %s\n, %v", err.Error(), mTLSCertificateResponse)
@@ -178,7 +179,7 @@ func TestEditMTLSCertificateBadConnection(t *testing.T) {
 	inputHash := "inputHash"
 	accountID := "777"
 
-	mTLSCertificateResponse, err := client.AddMTLSCertificate(certificate, privateKey, passphrase, certificateName, inputHash, accountID)
+	mTLSCertificateResponse, err := client.AddMTLSCertificate(context.Background(), certificate, privateKey, passphrase, certificateName, inputHash, accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -215,7 +216,7 @@ func TestEditMTLSCertificateBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	mTLSCertificateResponse, err := client.UpdateMTLSCertificate(certificateID, certificate, privateKey, passphrase, certificateName, inputHash, accountID)
+	mTLSCertificateResponse, err := client.UpdateMTLSCertificate(context.Background(), certificateID, certificate, privateKey, passphrase, certificateName, inputHash, accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -262,7 +263,7 @@ func TestEditMTLSCertificateApiConfig(t *testing.T) {
 	certificateName := "certificateName"
 	inputHash := "inputHash"
 
-	mTLSCertificateResponse, err := client.AddMTLSCertificate(certificate, privateKey, passphrase, certificateName, inputHash, "")
+	mTLSCertificateResponse, err := client.AddMTLSCertificate(context.Background(), certificate, privateKey, passphrase, certificateName, inputHash, "")
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -323,7 +324,7 @@ func TestEditMTLSCertificateValidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	mTLSCertificateResponse, err := client.AddMTLSCertificate(certificate, privateKey, passphrase, certificateName, inputHash, accountID)
+	mTLSCertificateResponse, err := client.AddMTLSCertificate(context.Background(), certificate, privateKey, passphrase, certificateName, inputHash, accountID)
 
 	if err != nil {
 		t.Errorf("Should not have received an error : %s\n, %v", err.Error(), mTLSCertificateResponse)
@@ -345,7 +346,7 @@ func TestDeleteMTLSCertificateBadConnection(t *testing.T) {
 	certificateID := "42"
 	accountID := "777"
 
-	err := client.DeleteMTLSCertificate(certificateID, accountID)
+	err := client.DeleteMTLSCertificate(context.Background(), certificateID, accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -386,7 +387,7 @@ func TestDeleteMTLSCertificateInvalidConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.DeleteMTLSCertificate(certificateID, accountID)
+	err := client.DeleteMTLSCertificate(context.Background(), certificateID, accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -416,7 +417,7 @@ func TestDeleteMTLSCertificateValidConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.DeleteMTLSCertificate(certificateID, accountID)
+	err := client.DeleteMTLSCertificate(context.Background(), certificateID, accountID)
 
 	if err != nil {
 		t.Errorf("Should not have received an error : %v\n", err.Error())
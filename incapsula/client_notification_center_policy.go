@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -52,7 +53,7 @@ type NotificationPolicy struct {
 	Data NotificationPolicyFullDto `json:"data"`
 }
 
-func (c *Client) AddNotificationCenterPolicy(notificationPolicyFullDto *NotificationPolicyFullDto) (*NotificationPolicy, error) {
+func (c *Client) AddNotificationCenterPolicy(ctx context.Context, notificationPolicyFullDto *NotificationPolicyFullDto) (*NotificationPolicy, error) {
 	notificationPolicy := NotificationPolicy{
 		Data: *notificationPolicyFullDto,
 	}
@@ -61,35 +62,34 @@ func (c *Client) AddNotificationCenterPolicy(notificationPolicyFullDto *Notifica
 	reqURL := getRequestUrl(c)
 	policyJSON, err := json.Marshal(notificationPolicy)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to JSON marshal NotificationCenterPolicy: %s ", err)
+		return nil, fmt.Errorf("Failed to JSON marshal NotificationCenterPolicy: %w ", err)
 	}
 
-	log.Printf("[DEBUG] Add NotificationCenterPolicy with params %s and JSON request: %s\n", params, string(policyJSON))
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodPost, reqURL, policyJSON, params, CreateNotificationCenterPolicy)
-	log.Printf("[DEBUG] client_notification_center_policy Post rest response:\n%+v", resp)
+	log.Printf("[DEBUG] Add NotificationCenterPolicy with params %s and JSON request: %s\n", params, redactedBody(contentTypeApplicationJson, policyJSON))
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodPost, reqURL, policyJSON, params, CreateNotificationCenterPolicy)
 	if err != nil {
-		return nil, fmt.Errorf("Error from NotificationCenter service when adding policy: %s ", err)
+		return nil, fmt.Errorf("Error from NotificationCenter service when adding policy: %w ", err)
 	}
 
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
-	log.Printf("[DEBUG] Add NotificationCenterPolicy JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Add NotificationCenterPolicy JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from NotificationCenter service when adding policy: %s ", resp.StatusCode, string(responseBody))
+		return nil, newAPIError(resp, responseBody, nil, "Error status code %d from NotificationCenter service when adding policy: %s ", resp.StatusCode, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 
 	// Parse the JSON
 	var policy NotificationPolicy
 	err = json.Unmarshal(responseBody, &policy)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing NotificationCenterPolicy JSON response: %s\nresponse: %s", err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing NotificationCenterPolicy JSON response: %w\nresponse: %s", err, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 
 	return &policy, nil
 
 }
 
-func (c *Client) UpdateNotificationCenterPolicy(notificationPolicyFullDto *NotificationPolicyFullDto) (*NotificationPolicy, error) {
+func (c *Client) UpdateNotificationCenterPolicy(ctx context.Context, notificationPolicyFullDto *NotificationPolicyFullDto) (*NotificationPolicy, error) {
 	notificationPolicy := NotificationPolicy{
 		Data: *notificationPolicyFullDto,
 	}
@@ -97,49 +97,47 @@ func (c *Client) UpdateNotificationCenterPolicy(notificationPolicyFullDto *Notif
 	reqURL := getRequestUrlWithId(c, notificationPolicy.Data.PolicyId)
 	policyJSON, err := json.Marshal(notificationPolicy)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to JSON marshal NotificationCenterPolicy: %s ", err)
+		return nil, fmt.Errorf("Failed to JSON marshal NotificationCenterPolicy: %w ", err)
 	}
 	params := GetRequestParamsWithCaid(notificationPolicyFullDto.AccountId)
 
-	log.Printf("[DEBUG] Update NotificationCenterPolicy JSON request: %s\n", string(policyJSON))
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodPut, reqURL, policyJSON, params, UpdateNotificationCenterPolicy)
-	log.Printf("[DEBUG] client_notification_center_policy Put rest response:\n%+v", resp)
+	log.Printf("[DEBUG] Update NotificationCenterPolicy JSON request: %s\n", redactedBody(contentTypeApplicationJson, policyJSON))
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodPut, reqURL, policyJSON, params, UpdateNotificationCenterPolicy)
 	if err != nil {
-		return nil, fmt.Errorf("Error from NotificationCenter service when updateing policy: %s ", err)
+		return nil, fmt.Errorf("Error from NotificationCenter service when updateing policy: %w ", err)
 	}
 
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
-	log.Printf("[DEBUG] Update NotificationCenterPolicy JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Update NotificationCenterPolicy JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from NotificationCenter service when updateing policy: %s ", resp.StatusCode, string(responseBody))
+		return nil, newAPIError(resp, responseBody, nil, "Error status code %d from NotificationCenter service when updateing policy: %s ", resp.StatusCode, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 
 	// Parse the JSON
 	var policy NotificationPolicy
 	err = json.Unmarshal(responseBody, &policy)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing NotificationCenterPolicy JSON response: %s\nresponse: %s", err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing NotificationCenterPolicy JSON response: %w\nresponse: %s", err, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 
 	return &policy, nil
 }
 
-func (c *Client) DeleteNotificationCenterPolicy(policyId int, accountId int) error {
+func (c *Client) DeleteNotificationCenterPolicy(ctx context.Context, policyId int, accountId int) error {
 	log.Printf("[INFO] Deleting NotificationCenterPolicy with ID %d ", policyId)
 	requestUrl := getRequestUrlWithId(c, policyId)
 	params := GetRequestParamsWithCaid(accountId)
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodDelete, requestUrl, nil, params, DeleteNotificationCenterPolicy)
-	log.Printf("[DEBUG] client_notification_center_policy Delete rest response:\n%+v", resp)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodDelete, requestUrl, nil, params, DeleteNotificationCenterPolicy)
 	if err != nil {
-		return fmt.Errorf("Error from NotificationCenterPolicy service when deleting Policy with Id %d: %s ", policyId, err)
+		return fmt.Errorf("Error from NotificationCenterPolicy service when deleting Policy with Id %d: %w ", policyId, err)
 	}
 
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
-	log.Printf("[DEBUG] NotificationCenter Delete policy JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] NotificationCenter Delete policy JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from NotificationCenter service when deleting policy with Id %d: %s ", resp.StatusCode, policyId, string(responseBody))
+		return newAPIError(resp, responseBody, apiResourceIDs(policyId), "Error status code %d from NotificationCenter service when deleting policy with Id %d: %s ", resp.StatusCode, policyId, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 
 	return nil
@@ -157,29 +155,28 @@ func getRequestUrlWithId(c *Client, policyId int) string {
 	return requestUrl
 }
 
-func (c *Client) GetNotificationCenterPolicy(policyId int, accountId int) (*NotificationPolicy, error) {
+func (c *Client) GetNotificationCenterPolicy(ctx context.Context, policyId int, accountId int) (*NotificationPolicy, error) {
 	log.Printf("[INFO] Getting  NotificationCenterPolicy with policyId: %d and accountId: %d", policyId, accountId)
 	requestUrl := getRequestUrlWithId(c, policyId)
 	log.Printf("[INFO]  requestUrl:%s", requestUrl)
 
 	params := GetRequestParamsWithCaid(accountId)
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodGet, requestUrl, nil, params, ReadNotificationCenterPolicy)
-	log.Printf("[DEBUG] client_notification_center_policy Get rest response:\n%+v", resp)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodGet, requestUrl, nil, params, ReadNotificationCenterPolicy)
 	if err != nil {
-		return nil, fmt.Errorf("Error from NotificationCenter service when reading policy with Id %d: %s ", policyId, err)
+		return nil, fmt.Errorf("Error from NotificationCenter service when reading policy with Id %d: %w ", policyId, err)
 	}
 
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
-	log.Printf("[DEBUG] NotificationCenter Read policy JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] NotificationCenter Read policy JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from NotificationCenter service when reading policy for ID %d: %s ", resp.StatusCode, policyId, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(policyId), "Error status code %d from NotificationCenter service when reading policy for ID %d: %s ", resp.StatusCode, policyId, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 
 	var notificationCenterPolicy NotificationPolicy
 	err = json.Unmarshal(responseBody, &notificationCenterPolicy)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing NotificationCenterPolicy JSON response with policy ID %d: %s\nresponse: %s", policyId, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing NotificationCenterPolicy JSON response with policy ID %d: %w\nresponse: %s", policyId, err, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 
 	return &notificationCenterPolicy, nil
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -18,7 +19,7 @@ var notificationPolicyFullDto = NotificationPolicyFullDto{
 func TestClientCreateNotificationCenterPolicyBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
-	notificationCenterPolicyAddResponse, err := client.AddNotificationCenterPolicy(&notificationPolicyFullDto)
+	notificationCenterPolicyAddResponse, err := client.AddNotificationCenterPolicy(context.Background(), &notificationPolicyFullDto)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -41,7 +42,7 @@ func TestClientNotificationCenterPolicyBadJSON(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	notificationCenterPolicyAddResponse, err := client.AddNotificationCenterPolicy(&notificationPolicyFullDto)
+	notificationCenterPolicyAddResponse, err := client.AddNotificationCenterPolicy(context.Background(), &notificationPolicyFullDto)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -65,7 +66,7 @@ func TestClientAddNotificationCenterPolicyInvalidResponse(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	notificationPolicyResponse, err := client.AddNotificationCenterPolicy(&notificationPolicyFullDto)
+	notificationPolicyResponse, err := client.AddNotificationCenterPolicy(context.Background(), &notificationPolicyFullDto)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -124,7 +125,7 @@ func TestAddNotificationCenterPolicyValidPolicy(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	addNotificationPolicyResponse, err := client.AddNotificationCenterPolicy(&notificationPolicyFullDto)
+	addNotificationPolicyResponse, err := client.AddNotificationCenterPolicy(context.Background(), &notificationPolicyFullDto)
 	if err != nil {
 		t.Errorf("Should not have received an error, the error: %s", err)
 	}
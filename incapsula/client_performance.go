@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -53,14 +54,14 @@ type PerformanceSettings struct {
 }
 
 // GetPerformanceSettings gets the site performance settings
-func (c *Client) GetPerformanceSettings(siteID string) (*PerformanceSettings, error) {
+func (c *Client) GetPerformanceSettings(ctx context.Context, siteID string) (*PerformanceSettings, error) {
 	log.Printf("[INFO] Getting Incapsula Performance Settings for Site ID %s\n", siteID)
 
 	// Post form to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%s/settings/cache", c.config.BaseURLRev2, siteID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadSitePerformance)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadSitePerformance)
 	if err != nil {
-		return nil, fmt.Errorf("Error from Incapsula service when reading Incap Performance Settings for Site ID %s: %s", siteID, err)
+		return nil, fmt.Errorf("Error from Incapsula service when reading Incap Performance Settings for Site ID %s: %w", siteID, err)
 	}
 
 	// Read the body
@@ -72,26 +73,26 @@ func (c *Client) GetPerformanceSettings(siteID string) (*PerformanceSettings, er
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when reading Incap Performance Settings for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error status code %d from Incapsula service when reading Incap Performance Settings for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody))
 	}
 
 	// Parse the JSON
 	var performanceSettings PerformanceSettings
 	err = json.Unmarshal([]byte(responseBody), &performanceSettings)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Incap Performance Settings JSON response for Site ID %s: %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Incap Performance Settings JSON response for Site ID %s: %w\nresponse: %s", siteID, err, string(responseBody))
 	}
 
 	return &performanceSettings, nil
 }
 
 // UpdatePerformanceSettings updates the site performance settings
-func (c *Client) UpdatePerformanceSettings(siteID string, performanceSettings *PerformanceSettings) (*PerformanceSettings, error) {
+func (c *Client) UpdatePerformanceSettings(ctx context.Context, siteID string, performanceSettings *PerformanceSettings) (*PerformanceSettings, error) {
 	log.Printf("[INFO] Updating Incapsula Performance Settings for Site ID %s\n", siteID)
 
 	performanceSettingsJSON, err := json.Marshal(performanceSettings)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to JSON marshal PerformanceSettings: %s", err)
+		return nil, fmt.Errorf("Failed to JSON marshal PerformanceSettings: %w", err)
 	}
 
 	// Post request to Incapsula
@@ -104,9 +105,9 @@ func (c *Client) UpdatePerformanceSettings(siteID string, performanceSettings *P
 	}
 
 	reqURL := fmt.Sprintf("%s/sites/%s/settings/cache", c.config.BaseURLRev2, siteID)
-	resp, err := c.DoJsonRequestWithCustomHeaders(http.MethodPut, reqURL, performanceSettingsJSON, headers, UpdateSitePerformance)
+	resp, err := c.DoJsonRequestWithCustomHeaders(ctx, http.MethodPut, reqURL, performanceSettingsJSON, headers, UpdateSitePerformance)
 	if err != nil {
-		return nil, fmt.Errorf("Error from Incapsula service when updating Incap Performance Settings for Site ID %s: %s", siteID, err)
+		return nil, fmt.Errorf("Error from Incapsula service when updating Incap Performance Settings for Site ID %s: %w", siteID, err)
 	}
 
 	// Read the body
@@ -118,14 +119,14 @@ func (c *Client) UpdatePerformanceSettings(siteID string, performanceSettings *P
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when updating Incap Performance Settings for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error status code %d from Incapsula service when updating Incap Performance Settings for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody))
 	}
 
 	// Parse the JSON
 	var updatedPerformanceSettings PerformanceSettings
 	err = json.Unmarshal([]byte(responseBody), &updatedPerformanceSettings)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Incap Performance Settings JSON response for Site ID %s: %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Incap Performance Settings JSON response for Site ID %s: %w\nresponse: %s", siteID, err, string(responseBody))
 	}
 
 	return &updatedPerformanceSettings, nil
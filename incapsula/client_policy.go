@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -74,12 +75,12 @@ type PolicySetting struct {
 }
 
 // AddPolicy adds a policy to be managed by Incapsula
-func (c *Client) AddPolicy(policySubmitted *PolicySubmitted) (*PolicyExtended, error) {
+func (c *Client) AddPolicy(ctx context.Context, policySubmitted *PolicySubmitted) (*PolicyExtended, error) {
 	log.Printf("[INFO] Adding Incapsula Policy\n")
 
 	policyJSON, err := json.Marshal(policySubmitted)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to JSON marshal IncapRule: %s", err)
+		return nil, fmt.Errorf("Failed to JSON marshal IncapRule: %w", err)
 	}
 
 	// Post form to Incapsula
@@ -88,9 +89,9 @@ func (c *Client) AddPolicy(policySubmitted *PolicySubmitted) (*PolicyExtended, e
 	if policySubmitted.AccountID != 0 {
 		reqURL = fmt.Sprintf("%s?caid=%d", reqURL, policySubmitted.AccountID)
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, policyJSON, CreatePolicy)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, policyJSON, CreatePolicy)
 	if err != nil {
-		return nil, fmt.Errorf("Error from Incapsula service when adding Policy: %s", err)
+		return nil, fmt.Errorf("Error from Incapsula service when adding Policy: %w", err)
 	}
 
 	// Read the body
@@ -102,21 +103,21 @@ func (c *Client) AddPolicy(policySubmitted *PolicySubmitted) (*PolicyExtended, e
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when adding Policy: %s", resp.StatusCode, string(responseBody))
+		return nil, newAPIError(resp, responseBody, nil, "Error status code %d from Incapsula service when adding Policy: %s", resp.StatusCode, string(responseBody))
 	}
 
 	// Parse the JSON
 	var policyExtended PolicyExtended
 	err = json.Unmarshal([]byte(responseBody), &policyExtended)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Policy JSON response: %s\nresponse: %s", err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Policy JSON response: %w\nresponse: %s", err, string(responseBody))
 	}
 
 	return &policyExtended, nil
 }
 
 // GetPolicy gets the policy
-func (c *Client) GetPolicy(policyID string, currentAccountId *int) (*PolicyExtended, error) {
+func (c *Client) GetPolicy(ctx context.Context, policyID string, currentAccountId *int) (*PolicyExtended, error) {
 	log.Printf("[INFO] Getting Incapsula Policy: %s\n", policyID)
 
 	// Post form to Incapsula
@@ -125,9 +126,9 @@ func (c *Client) GetPolicy(policyID string, currentAccountId *int) (*PolicyExten
 		reqURL = fmt.Sprintf("%s&caid=%d", reqURL, *currentAccountId)
 	}
 
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadPolicy)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadPolicy)
 	if err != nil {
-		return nil, fmt.Errorf("Error from Incapsula service when reading Policy for ID %s: %s", policyID, err)
+		return nil, fmt.Errorf("Error from Incapsula service when reading Policy for ID %s: %w", policyID, err)
 	}
 
 	// Read the body
@@ -139,26 +140,26 @@ func (c *Client) GetPolicy(policyID string, currentAccountId *int) (*PolicyExten
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when reading Policy for ID %s: %s", resp.StatusCode, policyID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(policyID), "Error status code %d from Incapsula service when reading Policy for ID %s: %s", resp.StatusCode, policyID, string(responseBody))
 	}
 
 	// Parse the JSON
 	var policyExtended PolicyExtended
 	err = json.Unmarshal([]byte(responseBody), &policyExtended)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Policy JSON response for Policy ID %s: %s\nresponse: %s", policyID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Policy JSON response for Policy ID %s: %w\nresponse: %s", policyID, err, string(responseBody))
 	}
 
 	return &policyExtended, nil
 }
 
 // UpdatePolicy updates the Incapsula Policy
-func (c *Client) UpdatePolicy(policyID int, policySubmitted *PolicySubmitted, currentAccountId *int) (*PolicyExtended, error) {
+func (c *Client) UpdatePolicy(ctx context.Context, policyID int, policySubmitted *PolicySubmitted, currentAccountId *int) (*PolicyExtended, error) {
 	log.Printf("[INFO] Updating Incapsula Policy with ID %d\n", policyID)
 
 	policyJSON, err := json.Marshal(policySubmitted)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to JSON marshal Policy: %s", err)
+		return nil, fmt.Errorf("Failed to JSON marshal Policy: %w", err)
 	}
 
 	// Post form to Incapsula
@@ -167,9 +168,9 @@ func (c *Client) UpdatePolicy(policyID int, policySubmitted *PolicySubmitted, cu
 	if currentAccountId != nil {
 		reqURL = fmt.Sprintf("%s?caid=%d", reqURL, *currentAccountId)
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPut, reqURL, policyJSON, UpdatePolicy)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPut, reqURL, policyJSON, UpdatePolicy)
 	if err != nil {
-		return nil, fmt.Errorf("Error from Incapsula service when updating Policy: %s", err)
+		return nil, fmt.Errorf("Error from Incapsula service when updating Policy: %w", err)
 	}
 
 	// Read the body
@@ -181,21 +182,21 @@ func (c *Client) UpdatePolicy(policyID int, policySubmitted *PolicySubmitted, cu
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when updating Policy with ID %d: %s", resp.StatusCode, policyID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(policyID), "Error status code %d from Incapsula service when updating Policy with ID %d: %s", resp.StatusCode, policyID, string(responseBody))
 	}
 
 	// Parse the JSON
 	var updatedPolicyExtended PolicyExtended
 	err = json.Unmarshal([]byte(responseBody), &updatedPolicyExtended)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Policy JSON response for Policy ID %d: %s\nresponse: %s", policyID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Policy JSON response for Policy ID %d: %w\nresponse: %s", policyID, err, string(responseBody))
 	}
 
 	return &updatedPolicyExtended, nil
 }
 
 // DeletePolicy deletes a policy currently managed by Incapsula
-func (c *Client) DeletePolicy(policyID string, currentAccountId *int) error {
+func (c *Client) DeletePolicy(ctx context.Context, policyID string, currentAccountId *int) error {
 	log.Printf("[INFO] Deleting Incapsula Policy for ID %s\n", policyID)
 
 	// Delete request to Incapsula
@@ -203,9 +204,9 @@ func (c *Client) DeletePolicy(policyID string, currentAccountId *int) error {
 	if currentAccountId != nil {
 		reqURL = fmt.Sprintf("%s?caid=%d", reqURL, *currentAccountId)
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodDelete, reqURL, nil, DeletePolicy)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, DeletePolicy)
 	if err != nil {
-		return fmt.Errorf("Error from Incapsula service when deleting Policy with ID %s: %s", policyID, err)
+		return fmt.Errorf("Error from Incapsula service when deleting Policy with ID %s: %w", policyID, err)
 	}
 
 	// Read the body
@@ -217,21 +218,21 @@ func (c *Client) DeletePolicy(policyID string, currentAccountId *int) error {
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from Incapsula service when deleting Policy with ID %s: %s", resp.StatusCode, policyID, string(responseBody))
+		return newAPIError(resp, responseBody, apiResourceIDs(policyID), "Error status code %d from Incapsula service when deleting Policy with ID %s: %s", resp.StatusCode, policyID, string(responseBody))
 	}
 
 	return nil
 }
 
 // GetAllPoliciesForAccount gets all policies for specific account
-func (c *Client) GetAllPoliciesForAccount(accountId string) (*[]Policy, error) {
+func (c *Client) GetAllPoliciesForAccount(ctx context.Context, accountId string) (*[]Policy, error) {
 	log.Printf("[INFO] Getting All Incapsula Policies for account: %s\n", accountId)
 	//
 	// Post form to Incapsula
 	reqURL := fmt.Sprintf("%s/policies/v2/policies?caid=%s&extended=true", c.config.BaseURLAPI, accountId)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadPoliciesAll)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadPoliciesAll)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when reading All Policies for Account ID %s: %s", accountId, err)
+		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when reading All Policies for Account ID %s: %w", accountId, err)
 	}
 
 	// Read the body
@@ -243,14 +244,14 @@ func (c *Client) GetAllPoliciesForAccount(accountId string) (*[]Policy, error) {
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("[ERROR] Error status code %d from Incapsula service when reading All Policies for Account ID %s: %s", resp.StatusCode, accountId, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(accountId), "[ERROR] Error status code %d from Incapsula service when reading All Policies for Account ID %s: %s", resp.StatusCode, accountId, string(responseBody))
 	}
 
 	// Parse the JSON
 	var policyExtendedAll PolicyExtendedAll
 	err = json.Unmarshal([]byte(responseBody), &policyExtendedAll)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error parsing All Policies JSON response for Account ID %s: %s\nresponse: %s", accountId, err, string(responseBody))
+		return nil, fmt.Errorf("[ERROR] Error parsing All Policies JSON response for Account ID %s: %w\nresponse: %s", accountId, err, string(responseBody))
 	}
 
 	return &policyExtendedAll.Value, nil
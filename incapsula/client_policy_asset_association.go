@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -14,7 +15,7 @@ type PolicyAssetAssociationStatus struct {
 }
 
 // AddPolicyAssetAssociation adds a policy to be managed by Incapsula
-func (c *Client) AddPolicyAssetAssociation(policyID, assetID, assetType string, currentAccountId *int) error {
+func (c *Client) AddPolicyAssetAssociation(ctx context.Context, policyID, assetID, assetType string, currentAccountId *int) error {
 	log.Printf("[INFO] Adding Incapsula Policy Asset Association: %s/%s/%s\n", policyID, assetID, assetType)
 
 	// Post form to Incapsula
@@ -22,9 +23,9 @@ func (c *Client) AddPolicyAssetAssociation(policyID, assetID, assetType string,
 	if currentAccountId != nil && *currentAccountId != 0 {
 		reqURL = fmt.Sprintf("%s?caid=%d", reqURL, *currentAccountId)
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, nil, CreatePolicyAssetAssociation)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, nil, CreatePolicyAssetAssociation)
 	if err != nil {
-		return fmt.Errorf("Error from Incapsula service when adding Policy Asset Association: %s", err)
+		return fmt.Errorf("Error from Incapsula service when adding Policy Asset Association: %w", err)
 	}
 
 	// Read the body
@@ -36,14 +37,14 @@ func (c *Client) AddPolicyAssetAssociation(policyID, assetID, assetType string,
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from Incapsula service when adding Policy Asset Association: %s", resp.StatusCode, string(responseBody))
+		return newAPIError(resp, responseBody, nil, "Error status code %d from Incapsula service when adding Policy Asset Association: %s", resp.StatusCode, string(responseBody))
 	}
 
 	return nil
 }
 
 // DeletePolicyAssetAssociation deletes a policy asset association currently managed by Incapsula
-func (c *Client) DeletePolicyAssetAssociation(policyID, assetID, assetType string, currentAccountId *int) error {
+func (c *Client) DeletePolicyAssetAssociation(ctx context.Context, policyID, assetID, assetType string, currentAccountId *int) error {
 	log.Printf("[INFO] Deleting Incapsula Policy Asset Association: %s/%s/%s\n", policyID, assetID, assetType)
 
 	// Delete request to Incapsula
@@ -51,9 +52,9 @@ func (c *Client) DeletePolicyAssetAssociation(policyID, assetID, assetType strin
 	if currentAccountId != nil {
 		reqURL = fmt.Sprintf("%s?caid=%d", reqURL, *currentAccountId)
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodDelete, reqURL, nil, DeletePolicyAssetAssociation)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, DeletePolicyAssetAssociation)
 	if err != nil {
-		return fmt.Errorf("Error from Incapsula service when deleting Policy Asset Association (%s): %s", policyID, err)
+		return fmt.Errorf("Error from Incapsula service when deleting Policy Asset Association (%s): %w", policyID, err)
 	}
 
 	// Read the body
@@ -65,13 +66,13 @@ func (c *Client) DeletePolicyAssetAssociation(policyID, assetID, assetType strin
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from Incapsula service when deleting Policy Asset Association: %s", resp.StatusCode, string(responseBody))
+		return newAPIError(resp, responseBody, nil, "Error status code %d from Incapsula service when deleting Policy Asset Association: %s", resp.StatusCode, string(responseBody))
 	}
 
 	return nil
 }
 
-func (c *Client) isPolicyAssetAssociated(policyID, assetID, assetType string, currentAccountId *int) (bool, error) {
+func (c *Client) isPolicyAssetAssociated(ctx context.Context, policyID, assetID, assetType string, currentAccountId *int) (bool, error) {
 	log.Printf("[INFO] Checking Policy Asset Association: %s/%s/%s\n", policyID, assetID, assetType)
 
 	// Check with Policies if the association exist
@@ -79,9 +80,9 @@ func (c *Client) isPolicyAssetAssociated(policyID, assetID, assetType string, cu
 	if currentAccountId != nil {
 		reqURL = fmt.Sprintf("%s?caid=%d", reqURL, *currentAccountId)
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadPolicyAssetAssociation)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadPolicyAssetAssociation)
 	if err != nil {
-		return false, fmt.Errorf("error from Incapsula service when checking if Policy Asset Association exist: %s/%s/%s, err: %s", policyID, assetID, assetType, err)
+		return false, fmt.Errorf("error from Incapsula service when checking if Policy Asset Association exist: %s/%s/%s, err: %w", policyID, assetID, assetType, err)
 	}
 
 	// Read the body
@@ -96,14 +97,14 @@ func (c *Client) isPolicyAssetAssociated(policyID, assetID, assetType string, cu
 		return false, nil
 	}
 	if resp.StatusCode != 200 {
-		return false, fmt.Errorf("Error status code %d from Incapsula service when checking the reading Policy Asset Association: %s/%s/%s, response is: %s", resp.StatusCode, policyID, assetID, assetType, string(responseBody))
+		return false, newAPIError(resp, responseBody, apiResourceIDs(policyID, assetID), "Error status code %d from Incapsula service when checking the reading Policy Asset Association: %s/%s/%s, response is: %s", resp.StatusCode, policyID, assetID, assetType, string(responseBody))
 	}
 
 	// Parse the JSON
 	var policyAssetAssociationStatus PolicyAssetAssociationStatus
 	err = json.Unmarshal([]byte(responseBody), &policyAssetAssociationStatus)
 	if err != nil {
-		return false, fmt.Errorf("error parsing Policy Asset Association JSON response for Policy Asset Association: %d/%s/%s: %s\nresponse: %s, err: %s", resp.StatusCode, policyID, assetID, assetType, err, string(responseBody))
+		return false, fmt.Errorf("error parsing Policy Asset Association JSON response for Policy Asset Association: %d/%s/%s: %s\nresponse: %w, err: %s", resp.StatusCode, policyID, assetID, assetType, err, string(responseBody))
 	}
 
 	return true, nil
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -55,6 +56,6 @@ func ClientPolicyAssetAssociatedBase(t *testing.T, shouldBeAssociated bool) (boo
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	return client.isPolicyAssetAssociated(policyID, assetID, assetType, nil)
+	return client.isPolicyAssetAssociated(context.Background(), policyID, assetID, assetType, nil)
 
 }
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -38,7 +39,7 @@ type SecurityRuleExceptionCreateResponse struct {
 }
 
 // AddSecurityRuleException adds a security rule exception
-func (c *Client) AddSecurityRuleException(siteID int, ruleID, clientAppTypes, clientApps, countries, continents, ips, urls, userAgents, parameters string) (*SecurityRuleExceptionCreateResponse, error) {
+func (c *Client) AddSecurityRuleException(ctx context.Context, siteID int, ruleID, clientAppTypes, clientApps, countries, continents, ips, urls, userAgents, parameters string) (*SecurityRuleExceptionCreateResponse, error) {
 	// Base URL values
 	values := url.Values{
 		"site_id":           {strconv.Itoa(siteID)},
@@ -77,7 +78,7 @@ func (c *Client) AddSecurityRuleException(siteID int, ruleID, clientAppTypes, cl
 
 	// Post form to Incapsula
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointExceptionConfigure)
-	resp, err := c.PostFormWithHeaders(reqURL, values, CreateSecurityRuleException)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, CreateSecurityRuleException)
 	if err != nil {
 		return nil, fmt.Errorf("Error configuring security rule exception rule_id (%s) for site_id (%d)", ruleID, siteID)
 	}
@@ -98,14 +99,14 @@ func (c *Client) AddSecurityRuleException(siteID int, ruleID, clientAppTypes, cl
 
 	// Look at the response status code from Incapsula
 	if securityRuleExceptionCreateResponse.Res != "0" {
-		return nil, fmt.Errorf("Error from Incapsula service when adding security rule exception for rule_id (%s) and site_id (%d): %s", ruleID, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(ruleID, siteID), "Error from Incapsula service when adding security rule exception for rule_id (%s) and site_id (%d): %s", ruleID, siteID, string(responseBody))
 	}
 
 	return &securityRuleExceptionCreateResponse, nil
 }
 
 // EditSecurityRuleException edits a security rule exception
-func (c *Client) EditSecurityRuleException(siteID int, ruleID, clientAppTypes, clientApps, countries, continents, ips, urls, userAgents, parameters, whitelistID string) (*SiteStatusResponse, error) {
+func (c *Client) EditSecurityRuleException(ctx context.Context, siteID int, ruleID, clientAppTypes, clientApps, countries, continents, ips, urls, userAgents, parameters, whitelistID string) (*SiteStatusResponse, error) {
 	// Base URL values
 	values := url.Values{
 		"site_id":      {strconv.Itoa(siteID)},
@@ -144,7 +145,7 @@ func (c *Client) EditSecurityRuleException(siteID int, ruleID, clientAppTypes, c
 
 	// Post form to Incapsula
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointExceptionConfigure)
-	resp, err := c.PostFormWithHeaders(reqURL, values, UpdateSecurityRuleException)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, UpdateSecurityRuleException)
 	if err != nil {
 		return nil, fmt.Errorf("Error configuring security rule exception rule_id (%s) for site_id (%d)", ruleID, siteID)
 	}
@@ -175,22 +176,22 @@ func (c *Client) EditSecurityRuleException(siteID int, ruleID, clientAppTypes, c
 
 	// Look at the response status code from Incapsula
 	if resString != "0" {
-		return nil, fmt.Errorf("Error from Incapsula service when adding security rule exception for rule_id (%s) and site_id (%d): %s", ruleID, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(ruleID, siteID), "Error from Incapsula service when adding security rule exception for rule_id (%s) and site_id (%d): %s", ruleID, siteID, string(responseBody))
 	}
 
 	return &siteStatusResponse, nil
 }
 
 // ListSecurityRuleExceptions gets the site status including the list of exceptions for security rules
-func (c *Client) ListSecurityRuleExceptions(siteID, ruleID string) (*SiteStatusResponse, error) {
+func (c *Client) ListSecurityRuleExceptions(ctx context.Context, siteID, ruleID string) (*SiteStatusResponse, error) {
 	log.Printf("[INFO] Getting Incapsula security rule exeptions for rule_id (%s) on site_id (%s)\n", ruleID, siteID)
 
 	// Post form to Incapsula
 	values := url.Values{"site_id": {siteID}}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointExceptionList)
-	resp, err := c.PostFormWithHeaders(reqURL, values, ReadSecurityRuleException)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, ReadSecurityRuleException)
 	if err != nil {
-		return nil, fmt.Errorf("Error getting security rule exceptions for rule_id (%s) on siteID (%s): %s", ruleID, siteID, err)
+		return nil, fmt.Errorf("Error getting security rule exceptions for rule_id (%s) on siteID (%s): %w", ruleID, siteID, err)
 	}
 
 	// Read the body
@@ -204,7 +205,7 @@ func (c *Client) ListSecurityRuleExceptions(siteID, ruleID string) (*SiteStatusR
 	var siteStatusResponse SiteStatusResponse
 	err = json.Unmarshal([]byte(responseBody), &siteStatusResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing ListSecurityRuleExceptions JSON response for siteID: %s %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing ListSecurityRuleExceptions JSON response for siteID: %s %w\nresponse: %s", siteID, err, string(responseBody))
 	}
 
 	// Res can sometimes oscillate between a string and number
@@ -219,14 +220,14 @@ func (c *Client) ListSecurityRuleExceptions(siteID, ruleID string) (*SiteStatusR
 
 	// Look at the response status code from Incapsula
 	if resString != "0" {
-		return &siteStatusResponse, fmt.Errorf("Error from Incapsula service when getting security rule exceptions (site_id: %s): %s", siteID, string(responseBody))
+		return &siteStatusResponse, newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error from Incapsula service when getting security rule exceptions (site_id: %s): %s", siteID, string(responseBody))
 	}
 
 	return &siteStatusResponse, nil
 }
 
 // DeleteSecurityRuleException deletes a security rule exception
-func (c *Client) DeleteSecurityRuleException(siteID int, ruleID, whitelistID string) error {
+func (c *Client) DeleteSecurityRuleException(ctx context.Context, siteID int, ruleID, whitelistID string) error {
 	type ExceptionDeleteResponse struct {
 		Res        int    `json:"res"`
 		ResMessage string `json:"res_message"`
@@ -243,7 +244,7 @@ func (c *Client) DeleteSecurityRuleException(siteID int, ruleID, whitelistID str
 
 	// Post form to Incapsula
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointExceptionConfigure)
-	resp, err := c.PostFormWithHeaders(reqURL, values, DeleteSecurityRuleException)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, DeleteSecurityRuleException)
 	if err != nil {
 		return fmt.Errorf("Error deleting security rule exception whitelist_id (%s) for rule_id (%s) for site_id (%d)", whitelistID, ruleID, siteID)
 	}
@@ -264,7 +265,7 @@ func (c *Client) DeleteSecurityRuleException(siteID int, ruleID, whitelistID str
 
 	// Look at the response status code from Incapsula
 	if exceptionDeleteResponse.Res != 0 {
-		return fmt.Errorf("Error from Incapsula service when deleting security rule exception for rule_id (%s) and site_id (%d): %s", ruleID, siteID, string(responseBody))
+		return newAPIError(resp, responseBody, apiResourceIDs(ruleID, siteID), "Error from Incapsula service when deleting security rule exception for rule_id (%s) and site_id (%d): %s", ruleID, siteID, string(responseBody))
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -21,7 +22,7 @@ func TestClientAddSecurityRuleExceptionBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := 1234
 	ruleID := "api.threats.backdoor"
-	addSecurityRuleExceptionResponse, err := client.AddSecurityRuleException(siteID, ruleID, "", "", "", "", "", "", "", "")
+	addSecurityRuleExceptionResponse, err := client.AddSecurityRuleException(context.Background(), siteID, ruleID, "", "", "", "", "", "", "", "")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -48,7 +49,7 @@ func TestClientAddSecurityRuleExceptionBadJSON(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := 1234
 	ruleID := "api.threats.backdoor"
-	addSecurityRuleExceptionResponse, err := client.AddSecurityRuleException(siteID, ruleID, "", "", "", "", "", "", "", "")
+	addSecurityRuleExceptionResponse, err := client.AddSecurityRuleException(context.Background(), siteID, ruleID, "", "", "", "", "", "", "", "")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -75,7 +76,7 @@ func TestClientAddSecurityRuleExceptionInvalidRuleID(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := 1234
 	ruleID := "bad_rule_id"
-	addSecurityRuleExceptionResponse, err := client.AddSecurityRuleException(siteID, ruleID, "", "", "", "AN,AS", "", "", "", "")
+	addSecurityRuleExceptionResponse, err := client.AddSecurityRuleException(context.Background(), siteID, ruleID, "", "", "", "AN,AS", "", "", "", "")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -103,7 +104,7 @@ func TestClientAddSecurityRuleExceptionInvalidParam(t *testing.T) {
 	siteID := 1234
 	ruleID := "api.threats.backdoor"
 	badIps := "1234"
-	addSecurityRuleExceptionResponse, err := client.AddSecurityRuleException(siteID, ruleID, "", "", "", "", badIps, "", "", "")
+	addSecurityRuleExceptionResponse, err := client.AddSecurityRuleException(context.Background(), siteID, ruleID, "", "", "", "", badIps, "", "", "")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -126,7 +127,7 @@ func TestClientEditSecurityRuleExceptionBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := 1234
 	ruleID := "api.threats.backdoor"
-	editSecurityRuleExceptionResponse, err := client.EditSecurityRuleException(siteID, ruleID, "", "", "", "", "", "", "", "", "")
+	editSecurityRuleExceptionResponse, err := client.EditSecurityRuleException(context.Background(), siteID, ruleID, "", "", "", "", "", "", "", "", "")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -153,7 +154,7 @@ func TestClientEditecurityRuleExceptionBadJSON(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := 1234
 	ruleID := "api.threats.backdoor"
-	editSecurityRuleExceptionResponse, err := client.EditSecurityRuleException(siteID, ruleID, "", "", "", "", "", "", "", "", "")
+	editSecurityRuleExceptionResponse, err := client.EditSecurityRuleException(context.Background(), siteID, ruleID, "", "", "", "", "", "", "", "", "")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -180,7 +181,7 @@ func TestClientEditSecurityRuleExceptionInvalidRuleID(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := 1234
 	ruleID := "bad_rule_id"
-	editSecurityRuleExceptionResponse, err := client.EditSecurityRuleException(siteID, ruleID, "", "", "", "", "", "", "", "", "")
+	editSecurityRuleExceptionResponse, err := client.EditSecurityRuleException(context.Background(), siteID, ruleID, "", "", "", "", "", "", "", "", "")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -209,7 +210,7 @@ func TestClientEditSecurityRuleExceptionInvalidWhitelistID(t *testing.T) {
 	ruleID := "api.threats.backdoor"
 	badIps := "1.2.3.4,1.2.4"
 	badWhitelistID := "1234"
-	editSecurityRuleExceptionResponse, err := client.EditSecurityRuleException(siteID, ruleID, "", "", "", "", badIps, "", "", "", badWhitelistID)
+	editSecurityRuleExceptionResponse, err := client.EditSecurityRuleException(context.Background(), siteID, ruleID, "", "", "", "", badIps, "", "", "", badWhitelistID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -237,7 +238,7 @@ func TestClientEditSecurityRuleExceptionInvalidParam(t *testing.T) {
 	siteID := 1234
 	ruleID := "api.threats.backdoor"
 	badIps := "1234"
-	editSecurityRuleExceptionResponse, err := client.EditSecurityRuleException(siteID, ruleID, "", "", "", "", badIps, "", "", "", "")
+	editSecurityRuleExceptionResponse, err := client.EditSecurityRuleException(context.Background(), siteID, ruleID, "", "", "", "", badIps, "", "", "", "")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -261,7 +262,7 @@ func TestClientDeleteSecurityRuleExceptionBadConnection(t *testing.T) {
 	siteID := 1234
 	ruleID := "api.threats.backdoor"
 	whitelistID := "12345"
-	err := client.DeleteSecurityRuleException(siteID, ruleID, whitelistID)
+	err := client.DeleteSecurityRuleException(context.Background(), siteID, ruleID, whitelistID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -286,7 +287,7 @@ func TestClientDeleteSecurityRuleExceptionBadJSON(t *testing.T) {
 	siteID := 1234
 	ruleID := "api.threats.backdoor"
 	whitelistID := "12345"
-	err := client.DeleteSecurityRuleException(siteID, ruleID, whitelistID)
+	err := client.DeleteSecurityRuleException(context.Background(), siteID, ruleID, whitelistID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -311,7 +312,7 @@ func TestClientDeleteSecurityRuleExceptionInvalidRuleID(t *testing.T) {
 	siteID := 1234
 	ruleID := "bad_rule_id"
 	whitelistID := "12345"
-	err := client.DeleteSecurityRuleException(siteID, ruleID, whitelistID)
+	err := client.DeleteSecurityRuleException(context.Background(), siteID, ruleID, whitelistID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -336,7 +337,7 @@ func TestClientDeleteSecurityRuleExceptionInvalidWhitelistID(t *testing.T) {
 	siteID := 1234
 	ruleID := "api.threats.backdoor"
 	badWhitelistID := "abc"
-	err := client.DeleteSecurityRuleException(siteID, ruleID, badWhitelistID)
+	err := client.DeleteSecurityRuleException(context.Background(), siteID, ruleID, badWhitelistID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -361,7 +362,7 @@ func TestClientDeleteSecurityRuleExceptionValidRule(t *testing.T) {
 	siteID := 1234
 	ruleID := "api.threats.backdoor"
 	badWhitelistID := "abc"
-	err := client.DeleteSecurityRuleException(siteID, ruleID, badWhitelistID)
+	err := client.DeleteSecurityRuleException(context.Background(), siteID, ruleID, badWhitelistID)
 	if err != nil {
 		t.Errorf("Should have received an error")
 	}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -19,7 +20,7 @@ type ShortRenewalCycleConfiguration struct {
 	ShortRenewalCycle bool `json:"shortRenewalCycle"`
 }
 
-func (c *Client) GetShortRenewalCycleConfiguration(siteId string, caid string) (*ShortRenewalCycleConfigurationDto, error) {
+func (c *Client) GetShortRenewalCycleConfiguration(ctx context.Context, siteId string, caid string) (*ShortRenewalCycleConfigurationDto, error) {
 	if siteId == "" {
 		fmt.Errorf("[ERROR] site id was not provided")
 	}
@@ -31,25 +32,29 @@ func (c *Client) GetShortRenewalCycleConfiguration(siteId string, caid string) (
 	} else {
 		params["caid"] = caid
 	}
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodGet, reqURL, nil, params, ReadShortRenewalCycleConfiguration)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, params, ReadShortRenewalCycleConfiguration)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] error from Incapsula service when reading short renewal cycle configuration %s: %s", siteId, err)
+		return nil, fmt.Errorf("[ERROR] error from Incapsula service when reading short renewal cycle configuration %s: %w", siteId, err)
 	}
 
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	log.Printf("[DEBUG] incapsula Get short renewal cycle configuration response: %s\n", string(responseBody))
 
+	if resp.StatusCode != 200 {
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteId), "[ERROR] error status code %d from Incapsula service when reading short renewal cycle configuration for site id %s: %s", resp.StatusCode, siteId, string(responseBody))
+	}
+
 	var shortRenewalCycleConfigurationResponse ShortRenewalCycleConfigurationDto
 	err = json.Unmarshal([]byte(responseBody), &shortRenewalCycleConfigurationResponse)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] error parsing get short renewal cycle configuration response for site id %s: %s\nresponse: %s", siteId, err, string(responseBody))
+		return nil, fmt.Errorf("[ERROR] error parsing get short renewal cycle configuration response for site id %s: %w\nresponse: %s", siteId, err, string(responseBody))
 	}
 
 	return &shortRenewalCycleConfigurationResponse, nil
 }
 
-func (c *Client) EnableShortRenewalCycleConfiguration(siteId string, caid string) (*ShortRenewalCycleConfigurationDto, error) {
+func (c *Client) EnableShortRenewalCycleConfiguration(ctx context.Context, siteId string, caid string) (*ShortRenewalCycleConfigurationDto, error) {
 	if siteId == "" {
 		fmt.Errorf("[ERROR] site id was not provided")
 	}
@@ -61,25 +66,29 @@ func (c *Client) EnableShortRenewalCycleConfiguration(siteId string, caid string
 	} else {
 		params["caid"] = caid
 	}
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodPost, reqURL, nil, params, CreateShortRenewalCycleConfiguration)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodPost, reqURL, nil, params, CreateShortRenewalCycleConfiguration)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] error from Incapsula service when enabling short renewal cycle configuration %s: %s", siteId, err)
+		return nil, fmt.Errorf("[ERROR] error from Incapsula service when enabling short renewal cycle configuration %s: %w", siteId, err)
 	}
 
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	log.Printf("[DEBUG] incapsula create short renewal cycle configuration response: %s\n", string(responseBody))
 
+	if resp.StatusCode != 200 {
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteId), "[ERROR] error status code %d from Incapsula service when enabling short renewal cycle configuration for site id %s: %s", resp.StatusCode, siteId, string(responseBody))
+	}
+
 	var shortRenewalCycleConfigurationResponse ShortRenewalCycleConfigurationDto
 	err = json.Unmarshal([]byte(responseBody), &shortRenewalCycleConfigurationResponse)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] error parsing get short renewal cycle create configuration response for site id %s: %s\nresponse: %s", siteId, err, string(responseBody))
+		return nil, fmt.Errorf("[ERROR] error parsing get short renewal cycle create configuration response for site id %s: %w\nresponse: %s", siteId, err, string(responseBody))
 	}
 
 	return &shortRenewalCycleConfigurationResponse, nil
 }
 
-func (c *Client) DeleteShortRenewalCycleConfiguration(siteId string, caid string) (*ShortRenewalCycleConfigurationDto, error) {
+func (c *Client) DeleteShortRenewalCycleConfiguration(ctx context.Context, siteId string, caid string) (*ShortRenewalCycleConfigurationDto, error) {
 	if siteId == "" {
 		fmt.Errorf("[ERROR] site id was not provided")
 	}
@@ -91,24 +100,18 @@ func (c *Client) DeleteShortRenewalCycleConfiguration(siteId string, caid string
 	} else {
 		params["caid"] = caid
 	}
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodDelete, reqURL, nil, params, DeleteShortRenewalCycleConfiguration)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, params, DeleteShortRenewalCycleConfiguration)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] error from Incapsula service when deleting short renewal cycle configuration. site id: %s\n: %s", siteId, err)
+		return nil, fmt.Errorf("[ERROR] error from Incapsula service when deleting short renewal cycle configuration. site id: %s\n: %w", siteId, err)
 	}
 
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	log.Printf("[DEBUG] incapsula delete short renewal cycle configuration response: %s\n", string(responseBody))
 
-	if resp.StatusCode == 200 {
-		return nil, nil
-	}
-
-	var shortRenewalCycleConfigurationResponse ShortRenewalCycleConfigurationDto
-	err = json.Unmarshal([]byte(responseBody), &shortRenewalCycleConfigurationResponse)
-	if err != nil {
-		return nil, fmt.Errorf("[ERROR] error parsing get short renewal cycle delete configuration response for site id %s: %s\nresponse: %s", siteId, err, string(responseBody))
+	if resp.StatusCode != 200 {
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteId), "[ERROR] error status code %d from Incapsula service when deleting short renewal cycle configuration for site id %s: %s", resp.StatusCode, siteId, string(responseBody))
 	}
 
-	return &shortRenewalCycleConfigurationResponse, nil
+	return nil, nil
 }
@@ -1,6 +1,8 @@
 package incapsula
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -27,7 +29,7 @@ func TestClient_GetShortRenewalCycleConfigurationConfiguration(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	response, err := client.GetShortRenewalCycleConfiguration(siteId, "")
+	response, err := client.GetShortRenewalCycleConfiguration(context.Background(), siteId, "")
 	if err != nil {
 		t.Errorf("unexcpected error: %s", err)
 	}
@@ -54,7 +56,7 @@ func TestClient_EnableShortRenewalCycleConfigurationConfiguration(t *testing.T)
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	response, err := client.EnableShortRenewalCycleConfiguration(siteId, "")
+	response, err := client.EnableShortRenewalCycleConfiguration(context.Background(), siteId, "")
 	if err != nil {
 		t.Errorf("unexcpected error: %s", err)
 	}
@@ -81,7 +83,7 @@ func TestDeleteShortRenewalCycleConfiguration(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	response, err := client.DeleteShortRenewalCycleConfiguration(siteId, "")
+	response, err := client.DeleteShortRenewalCycleConfiguration(context.Background(), siteId, "")
 	if err != nil {
 		t.Errorf("unexcpected error: %s", err)
 	}
@@ -115,13 +117,14 @@ func TestDeleteShortRenewalCycleConfigurationError(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	shortRenewalCycleConfigurationDto, err := client.DeleteShortRenewalCycleConfiguration(siteId, "")
-	if err != nil {
-		t.Errorf("expected to get error")
+	_, err := client.DeleteShortRenewalCycleConfiguration(context.Background(), siteId, "")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 400 {
+		t.Fatalf("expected to get an APIError with status 400, got %v", err)
 	}
 	var expectedError = "account 1111 is not allowed to manage short renewal cycle configuration"
 
-	if !strings.Contains(shortRenewalCycleConfigurationDto.Errors[0].Detail, expectedError) {
+	if !strings.Contains(apiErr.Message, expectedError) {
 		t.Errorf("expected to get error: %s", expectedError)
 	}
 }
@@ -150,12 +153,12 @@ func TestEnableShortRenewalCycleConfigurationError(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	shortRenewalCycleConfigurationDto, err := client.EnableShortRenewalCycleConfiguration(siteId, "")
-	if err != nil {
-		t.Errorf("unexcpected error: %s", err)
+	_, err := client.EnableShortRenewalCycleConfiguration(context.Background(), siteId, "")
+	if !IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
 	}
 	var expectedError = "account 1111 is not allowed to manage short renewal cycle configuration"
-	if !strings.Contains(shortRenewalCycleConfigurationDto.Errors[0].Detail, expectedError) {
+	if !strings.Contains(err.Error(), expectedError) {
 		t.Errorf("expected to get error: %s", expectedError)
 	}
 }
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -115,32 +116,32 @@ type SiemConnection struct {
 	Data []SiemConnectionData `json:"data"`
 }
 
-func (c *Client) CreateSiemConnection(connection *SiemConnection) (*SiemConnection, *int, error) {
+func (c *Client) CreateSiemConnection(ctx context.Context, connection *SiemConnection) (*SiemConnection, *int, error) {
 	connectionJSON, err := json.Marshal(connection)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to produce JSON from SiemConnection: %s", err)
+		return nil, nil, fmt.Errorf("failed to produce JSON from SiemConnection: %w", err)
 	}
 	reqURL := fmt.Sprintf("%s/%s/", c.config.BaseURLAPI, endpointSiemConnection)
-	return siemConnectionRequestWithResponse(c, CreateSiemConnection, http.MethodPost, reqURL, connectionJSON, connection.Data[0].AssetID, 201)
+	return siemConnectionRequestWithResponse(ctx, c, CreateSiemConnection, http.MethodPost, reqURL, connectionJSON, connection.Data[0].AssetID, 201)
 }
 
-func (c *Client) ReadSiemConnection(ID string, accountId string) (*SiemConnection, *int, error) {
+func (c *Client) ReadSiemConnection(ctx context.Context, ID string, accountId string) (*SiemConnection, *int, error) {
 	reqURL := fmt.Sprintf("%s/%s/%s", c.config.BaseURLAPI, endpointSiemConnection, ID)
-	return siemConnectionRequestWithResponse(c, ReadSiemConnection, http.MethodGet, reqURL, nil, accountId, 200)
+	return siemConnectionRequestWithResponse(ctx, c, ReadSiemConnection, http.MethodGet, reqURL, nil, accountId, 200)
 }
 
-func (c *Client) UpdateSiemConnection(siemConnection *SiemConnection) (*SiemConnection, *int, error) {
+func (c *Client) UpdateSiemConnection(ctx context.Context, siemConnection *SiemConnection) (*SiemConnection, *int, error) {
 	siemConnectionJSON, err := json.Marshal(siemConnection)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to produce JSON from SiemConnectionWithID: %s", err)
+		return nil, nil, fmt.Errorf("failed to produce JSON from SiemConnectionWithID: %w", err)
 	}
 	reqURL := fmt.Sprintf("%s/%s/%s", c.config.BaseURLAPI, endpointSiemConnection, siemConnection.Data[0].ID)
-	return siemConnectionRequestWithResponse(c, UpdateSiemConnection, http.MethodPut, reqURL, siemConnectionJSON, siemConnection.Data[0].AssetID, 200)
+	return siemConnectionRequestWithResponse(ctx, c, UpdateSiemConnection, http.MethodPut, reqURL, siemConnectionJSON, siemConnection.Data[0].AssetID, 200)
 }
 
-func (c *Client) DeleteSiemConnection(ID string, accountId string) (*int, error) {
+func (c *Client) DeleteSiemConnection(ctx context.Context, ID string, accountId string) (*int, error) {
 	reqURL := fmt.Sprintf("%s/%s/%s", c.config.BaseURLAPI, endpointSiemConnection, ID)
-	_, _, statusCode, err := siemConnectionRequest(c, DeleteSiemConnection, http.MethodDelete, reqURL, nil, accountId, 200)
+	_, _, statusCode, err := siemConnectionRequest(ctx, c, DeleteSiemConnection, http.MethodDelete, reqURL, nil, accountId, 200)
 	return statusCode, err
 }
 
@@ -150,8 +151,8 @@ func dSiemConnectionResponseClose(c io.Closer) {
 	}
 }
 
-func siemConnectionRequest(c *Client, operation string, method string, reqURL string, data []byte, accountIdStr string, expectedSuccessStatusCode int) (*string, *[]byte, *int, error) {
-	log.Printf("[INFO] Executing operation %s on SIEM connection with data: %s", operation, data)
+func siemConnectionRequest(ctx context.Context, c *Client, operation string, method string, reqURL string, data []byte, accountIdStr string, expectedSuccessStatusCode int) (*string, *[]byte, *int, error) {
+	log.Printf("[INFO] Executing operation %s on SIEM connection with data: %s", operation, redactedBody(contentTypeApplicationJson, data))
 
 	var params = map[string]string{}
 	accountId, err := strconv.Atoi(accountIdStr)
@@ -159,9 +160,9 @@ func siemConnectionRequest(c *Client, operation string, method string, reqURL st
 		params["caid"] = accountIdStr
 	}
 
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(method, reqURL, data, params, operation)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, method, reqURL, data, params, operation)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("error from Incapsula service when executing %s operation on SIEM connection: %s", operation, err)
+		return nil, nil, nil, fmt.Errorf("error from Incapsula service when executing %s operation on SIEM connection: %w", operation, err)
 	}
 
 	defer dSiemConnectionResponseClose(resp.Body)
@@ -169,20 +170,20 @@ func siemConnectionRequest(c *Client, operation string, method string, reqURL st
 	body := string(responseBody)
 
 	if err != nil {
-		return nil, nil, &resp.StatusCode, fmt.Errorf("error occurred: %s\n when reading response from body: %s", err, body)
+		return nil, nil, &resp.StatusCode, fmt.Errorf("error occurred: %w\n when reading response from body: %s", err, body)
 	}
-	log.Printf("[DEBUG] Incapsula returned response: %s\nfor %s operation on SIEM connection", body, operation)
+	log.Printf("[DEBUG] Incapsula returned response: %s\nfor %s operation on SIEM connection", redactedBody(contentTypeApplicationJson, responseBody), operation)
 
 	if resp.StatusCode != expectedSuccessStatusCode {
-		return nil, nil, &resp.StatusCode, fmt.Errorf("received failure response for operation: %s on SIEM connection\nstatus code: %d\nbody: %s",
+		return nil, nil, &resp.StatusCode, newAPIError(resp, responseBody, nil, "received failure response for operation: %s on SIEM connection\nstatus code: %d\nbody: %s",
 			operation, resp.StatusCode, body)
 	}
 
 	return &body, &responseBody, &resp.StatusCode, nil
 }
 
-func siemConnectionRequestWithResponse(c *Client, operation string, method string, reqURL string, data []byte, accountIdStr string, expectedSuccessStatusCode int) (*SiemConnection, *int, error) {
-	body, responseBody, responseStatusCode, err := siemConnectionRequest(c, operation, method, reqURL, data, accountIdStr, expectedSuccessStatusCode)
+func siemConnectionRequestWithResponse(ctx context.Context, c *Client, operation string, method string, reqURL string, data []byte, accountIdStr string, expectedSuccessStatusCode int) (*SiemConnection, *int, error) {
+	body, responseBody, responseStatusCode, err := siemConnectionRequest(ctx, c, operation, method, reqURL, data, accountIdStr, expectedSuccessStatusCode)
 	if responseBody == nil {
 		return nil, responseStatusCode, err
 	}
@@ -190,7 +191,7 @@ func siemConnectionRequestWithResponse(c *Client, operation string, method strin
 	var response SiemConnection
 	err = json.Unmarshal(*responseBody, &response)
 	if err != nil {
-		return nil, responseStatusCode, fmt.Errorf("error obtained %s\n when constructing response for %s operation on SIEM connection from: %p",
+		return nil, responseStatusCode, fmt.Errorf("error obtained %w\n when constructing response for %s operation on SIEM connection from: %p",
 			err, operation, body)
 	}
 
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/go-uuid"
 	"log"
@@ -24,7 +25,7 @@ func TestClientCreateSiemConnectionBadConfig(t *testing.T) {
 	}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 
-	siemConnection, _, err := client.CreateSiemConnection(&SiemConnection{Data: []SiemConnectionData{{
+	siemConnection, _, err := client.CreateSiemConnection(context.Background(), &SiemConnection{Data: []SiemConnectionData{{
 		AssetID:        RandomNumbersExcludingZeroString(10),
 		ConnectionName: RandomLetterAndNumberString(20),
 		StorageType:    RandomCapitalLetterString(10),
@@ -69,7 +70,7 @@ func TestClientCreateSiemConnectionBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siemConnection, _, err := client.CreateSiemConnection(&SiemConnection{Data: []SiemConnectionData{{
+	siemConnection, _, err := client.CreateSiemConnection(context.Background(), &SiemConnection{Data: []SiemConnectionData{{
 		AssetID:        assetId,
 		ConnectionName: RandomLetterAndNumberString(20),
 		StorageType:    RandomCapitalLetterString(10),
@@ -155,7 +156,7 @@ func TestClientCreateSiemConnectionBadRequest(t *testing.T) {
 			storageType = "CUSTOMER_S3_" + RandomLetterAndNumberString(1)
 		}
 
-		siemConnection, _, err := client.CreateSiemConnection(&SiemConnection{Data: []SiemConnectionData{{
+		siemConnection, _, err := client.CreateSiemConnection(context.Background(), &SiemConnection{Data: []SiemConnectionData{{
 			AssetID:        assetId,
 			ConnectionName: RandomLetterAndNumberString(20),
 			StorageType:    storageType,
@@ -223,7 +224,7 @@ func TestClientCreateValidSiemConnection(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siemConnection, _, err := client.CreateSiemConnection(&SiemConnection{Data: []SiemConnectionData{{
+	siemConnection, _, err := client.CreateSiemConnection(context.Background(), &SiemConnection{Data: []SiemConnectionData{{
 		AssetID:        assetId,
 		ConnectionName: RandomLetterAndNumberString(20),
 		StorageType:    RandomCapitalLetterString(10),
@@ -287,7 +288,7 @@ func TestClientReadExistingSiemConnection(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siemConnection, _, err := client.ReadSiemConnection(ID, "")
+	siemConnection, _, err := client.ReadSiemConnection(context.Background(), ID, "")
 
 	if err != nil {
 		t.Errorf("Should not have received an error")
@@ -335,7 +336,7 @@ func TestClientReadNonExistingSiemConnection(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siemConnection, _, err := client.ReadSiemConnection(ID, "")
+	siemConnection, _, err := client.ReadSiemConnection(context.Background(), ID, "")
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -415,7 +416,7 @@ func TestClientUpdateExistingS3SiemConnection(t *testing.T) {
 		},
 	}
 
-	siemConnection, _, err := client.UpdateSiemConnection(&SiemConnection{Data: []SiemConnectionData{sent}})
+	siemConnection, _, err := client.UpdateSiemConnection(context.Background(), &SiemConnection{Data: []SiemConnectionData{sent}})
 
 	if err != nil {
 		t.Errorf("Should not have received an error")
@@ -488,7 +489,7 @@ func TestClientUpdateExistingS3ArnSiemConnection(t *testing.T) {
 		},
 	}
 
-	siemConnection, _, err := client.UpdateSiemConnection(&SiemConnection{Data: []SiemConnectionData{sent}})
+	siemConnection, _, err := client.UpdateSiemConnection(context.Background(), &SiemConnection{Data: []SiemConnectionData{sent}})
 
 	if err != nil {
 		t.Errorf("Should not have received an error")
@@ -596,7 +597,7 @@ func ClientDeleteSiemConnectionBase(t *testing.T, responseStatusCode int) error
 
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	_, err := client.DeleteSiemConnection(ID, "")
+	_, err := client.DeleteSiemConnection(context.Background(), ID, "")
 	return err
 }
 
@@ -649,7 +650,7 @@ func TestClientCreateValidSiemSplunkConnection(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siemConnection, _, err := client.CreateSiemConnection(&SiemConnection{Data: []SiemConnectionData{{
+	siemConnection, _, err := client.CreateSiemConnection(context.Background(), &SiemConnection{Data: []SiemConnectionData{{
 		AssetID:        assetId,
 		ConnectionName: RandomLetterAndNumberString(20),
 		StorageType:    RandomCapitalLetterString(10),
@@ -720,7 +721,7 @@ func TestClientReadExistingSiemSplunkConnection(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siemConnection, _, err := client.ReadSiemConnection(ID, "")
+	siemConnection, _, err := client.ReadSiemConnection(context.Background(), ID, "")
 
 	if err != nil {
 		t.Errorf("Should not have received an error")
@@ -798,7 +799,7 @@ func TestClientUpdateExistingSplunkSiemConnection(t *testing.T) {
 		},
 	}
 
-	siemConnection, _, err := client.UpdateSiemConnection(&SiemConnection{Data: []SiemConnectionData{sent}})
+	siemConnection, _, err := client.UpdateSiemConnection(context.Background(), &SiemConnection{Data: []SiemConnectionData{sent}})
 
 	if err != nil {
 		t.Errorf("Should not have received an error")
@@ -870,7 +871,7 @@ func TestClientCreateValidSiemSftpConnection(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siemConnection, _, err := client.CreateSiemConnection(&SiemConnection{Data: []SiemConnectionData{{
+	siemConnection, _, err := client.CreateSiemConnection(context.Background(), &SiemConnection{Data: []SiemConnectionData{{
 		AssetID:        assetId,
 		ConnectionName: RandomLetterAndNumberString(20),
 		StorageType:    "CUSTOMER_SFTP",
@@ -941,7 +942,7 @@ func TestClientReadExistingSiemSftpConnection(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siemConnection, _, err := client.ReadSiemConnection(ID, "")
+	siemConnection, _, err := client.ReadSiemConnection(context.Background(), ID, "")
 
 	if err != nil {
 		t.Errorf("Should not have received an error")
@@ -1019,7 +1020,7 @@ func TestClientUpdateExistingSftpSiemConnection(t *testing.T) {
 		},
 	}
 
-	siemConnection, _, err := client.UpdateSiemConnection(&SiemConnection{Data: []SiemConnectionData{sent}})
+	siemConnection, _, err := client.UpdateSiemConnection(context.Background(), &SiemConnection{Data: []SiemConnectionData{sent}})
 
 	if err != nil {
 		t.Errorf("Should not have received an error")
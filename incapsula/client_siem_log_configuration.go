@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -30,32 +31,32 @@ type SiemLogConfiguration struct {
 	Data []SiemLogConfigurationData `json:"data"`
 }
 
-func (c *Client) CreateSiemLogConfiguration(siemLogConfiguration *SiemLogConfiguration) (*SiemLogConfiguration, *int, error) {
+func (c *Client) CreateSiemLogConfiguration(ctx context.Context, siemLogConfiguration *SiemLogConfiguration) (*SiemLogConfiguration, *int, error) {
 	logConfigurationJSON, err := json.Marshal(siemLogConfiguration)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to produce JSON from SiemLogConfiguration: %s", err)
+		return nil, nil, fmt.Errorf("failed to produce JSON from SiemLogConfiguration: %w", err)
 	}
 	reqURL := fmt.Sprintf("%s/%s/", c.config.BaseURLAPI, endpointSiemLogConfiguration)
-	return siemLogConfigurationRequestWithResponse(c, CreateSiemLogConfiguration, http.MethodPost, reqURL, logConfigurationJSON, siemLogConfiguration.Data[0].AssetID, 201)
+	return siemLogConfigurationRequestWithResponse(ctx, c, CreateSiemLogConfiguration, http.MethodPost, reqURL, logConfigurationJSON, siemLogConfiguration.Data[0].AssetID, 201)
 }
 
-func (c *Client) ReadSiemLogConfiguration(ID string, accountId string) (*SiemLogConfiguration, *int, error) {
+func (c *Client) ReadSiemLogConfiguration(ctx context.Context, ID string, accountId string) (*SiemLogConfiguration, *int, error) {
 	reqURL := fmt.Sprintf("%s/%s/%s", c.config.BaseURLAPI, endpointSiemLogConfiguration, ID)
-	return siemLogConfigurationRequestWithResponse(c, ReadSiemLogConfiguration, http.MethodGet, reqURL, nil, accountId, 200)
+	return siemLogConfigurationRequestWithResponse(ctx, c, ReadSiemLogConfiguration, http.MethodGet, reqURL, nil, accountId, 200)
 }
 
-func (c *Client) UpdateSiemLogConfiguration(siemLogConfiguration *SiemLogConfiguration) (*SiemLogConfiguration, *int, error) {
+func (c *Client) UpdateSiemLogConfiguration(ctx context.Context, siemLogConfiguration *SiemLogConfiguration) (*SiemLogConfiguration, *int, error) {
 	siemLogConfigurationJSON, err := json.Marshal(siemLogConfiguration)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to produce JSON from SiemLogConfigurationWithID: %s", err)
+		return nil, nil, fmt.Errorf("failed to produce JSON from SiemLogConfigurationWithID: %w", err)
 	}
 	reqURL := fmt.Sprintf("%s/%s/%s", c.config.BaseURLAPI, endpointSiemLogConfiguration, siemLogConfiguration.Data[0].ID)
-	return siemLogConfigurationRequestWithResponse(c, UpdateSiemLogConfiguration, http.MethodPut, reqURL, siemLogConfigurationJSON, siemLogConfiguration.Data[0].AssetID, 200)
+	return siemLogConfigurationRequestWithResponse(ctx, c, UpdateSiemLogConfiguration, http.MethodPut, reqURL, siemLogConfigurationJSON, siemLogConfiguration.Data[0].AssetID, 200)
 }
 
-func (c *Client) DeleteSiemLogConfiguration(ID string, accountId string) (*int, error) {
+func (c *Client) DeleteSiemLogConfiguration(ctx context.Context, ID string, accountId string) (*int, error) {
 	reqURL := fmt.Sprintf("%s/%s/%s", c.config.BaseURLAPI, endpointSiemLogConfiguration, ID)
-	_, _, responseStatusCode, err := siemLogConfigurationRequest(c, DeleteSiemLogConfiguration, http.MethodDelete, reqURL, nil, accountId, 200)
+	_, _, responseStatusCode, err := siemLogConfigurationRequest(ctx, c, DeleteSiemLogConfiguration, http.MethodDelete, reqURL, nil, accountId, 200)
 	return responseStatusCode, err
 }
 
@@ -65,7 +66,7 @@ func dSiemLogConfigurationResponseClose(c io.Closer) {
 	}
 }
 
-func siemLogConfigurationRequest(c *Client, operation string, method string, reqURL string, data []byte, accountIdStr string, expectedSuccessStatusCode int) (*string, *[]byte, *int, error) {
+func siemLogConfigurationRequest(ctx context.Context, c *Client, operation string, method string, reqURL string, data []byte, accountIdStr string, expectedSuccessStatusCode int) (*string, *[]byte, *int, error) {
 	log.Printf("[INFO] Executing operation %s on SIEM log configuration with data: %s", operation, data)
 
 	var params = map[string]string{}
@@ -74,9 +75,9 @@ func siemLogConfigurationRequest(c *Client, operation string, method string, req
 		params["caid"] = accountIdStr
 	}
 
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(method, reqURL, data, params, operation)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, method, reqURL, data, params, operation)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("error from Incapsula service when executing %s operation on SIEM log configuration: %s", operation, err)
+		return nil, nil, nil, fmt.Errorf("error from Incapsula service when executing %s operation on SIEM log configuration: %w", operation, err)
 	}
 
 	defer dSiemLogConfigurationResponseClose(resp.Body)
@@ -84,20 +85,20 @@ func siemLogConfigurationRequest(c *Client, operation string, method string, req
 	body := string(responseBody)
 
 	if err != nil {
-		return nil, nil, &resp.StatusCode, fmt.Errorf("error occurred: %s\n when reading response from body: %s", err, body)
+		return nil, nil, &resp.StatusCode, fmt.Errorf("error occurred: %w\n when reading response from body: %s", err, body)
 	}
 	log.Printf("[DEBUG] Incapsula returned response: %s\nfor %s operation on SIEM log configuration", body, operation)
 
 	if resp.StatusCode != expectedSuccessStatusCode {
-		return nil, nil, &resp.StatusCode, fmt.Errorf("received failure response for operation: %s on SIEM log configuration\nstatus code: %d\nbody: %s",
+		return nil, nil, &resp.StatusCode, newAPIError(resp, responseBody, nil, "received failure response for operation: %s on SIEM log configuration\nstatus code: %d\nbody: %s",
 			operation, resp.StatusCode, body)
 	}
 
 	return &body, &responseBody, &resp.StatusCode, nil
 }
 
-func siemLogConfigurationRequestWithResponse(c *Client, operation string, method string, reqURL string, data []byte, accountIdStr string, expectedSuccessStatusCode int) (*SiemLogConfiguration, *int, error) {
-	body, responseBody, responseStatusCode, err := siemLogConfigurationRequest(c, operation, method, reqURL, data, accountIdStr, expectedSuccessStatusCode)
+func siemLogConfigurationRequestWithResponse(ctx context.Context, c *Client, operation string, method string, reqURL string, data []byte, accountIdStr string, expectedSuccessStatusCode int) (*SiemLogConfiguration, *int, error) {
+	body, responseBody, responseStatusCode, err := siemLogConfigurationRequest(ctx, c, operation, method, reqURL, data, accountIdStr, expectedSuccessStatusCode)
 	if responseBody == nil {
 		return nil, responseStatusCode, err
 	}
@@ -105,7 +106,7 @@ func siemLogConfigurationRequestWithResponse(c *Client, operation string, method
 	var response SiemLogConfiguration
 	err = json.Unmarshal(*responseBody, &response)
 	if err != nil {
-		return nil, responseStatusCode, fmt.Errorf("error obtained %s\n when constructing response for %s operation on SIEM log configuration from: %p",
+		return nil, responseStatusCode, fmt.Errorf("error obtained %w\n when constructing response for %s operation on SIEM log configuration from: %p",
 			err, operation, body)
 	}
 
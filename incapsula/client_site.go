@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -227,7 +228,7 @@ type Response struct {
 }
 
 // AddSite adds a site to be managed by Incapsula
-func (c *Client) AddSite(domain, refID, sendSiteSetupEmails, siteIP, forceSSL string, accountID int, nakedDomainSan bool, wildcarSan bool, logsAccountId string) (*SiteAddResponse, error) {
+func (c *Client) AddSite(ctx context.Context, domain, refID, sendSiteSetupEmails, siteIP, forceSSL string, accountID int, nakedDomainSan bool, wildcarSan bool, logsAccountId string) (*SiteAddResponse, error) {
 	log.Printf("[INFO] Adding Incapsula site for domain: %s (account ID %d)\n", domain, accountID)
 
 	values := url.Values{
@@ -246,9 +247,9 @@ func (c *Client) AddSite(domain, refID, sendSiteSetupEmails, siteIP, forceSSL st
 	}
 
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSiteAdd)
-	resp, err := c.PostFormWithHeaders(reqURL, values, CreateSite)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, CreateSite)
 	if err != nil {
-		return nil, fmt.Errorf("Error adding site for domain %s: %s", domain, err)
+		return nil, fmt.Errorf("Error adding site for domain %s: %w", domain, err)
 	}
 
 	// Read the body
@@ -256,33 +257,33 @@ func (c *Client) AddSite(domain, refID, sendSiteSetupEmails, siteIP, forceSSL st
 	responseBody, err := ioutil.ReadAll(resp.Body)
 
 	// Dump JSON
-	log.Printf("[DEBUG] Incapsula add site JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Incapsula add site JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 
 	// Parse the JSON
 	var siteAddResponse SiteAddResponse
 	err = json.Unmarshal([]byte(responseBody), &siteAddResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing add site JSON response for domain %s: %s", domain, err)
+		return nil, fmt.Errorf("Error parsing add site JSON response for domain %s: %w", domain, err)
 	}
 
 	// Look at the response status code from Incapsula
 	if siteAddResponse.Res != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when adding site for domain %s: %s", domain, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(domain), "Error from Incapsula service when adding site for domain %s: %s", domain, string(responseBody))
 	}
 
 	return &siteAddResponse, nil
 }
 
 // SiteStatus gets the Incapsula managed site's status
-func (c *Client) SiteStatus(domain string, siteID int) (*SiteStatusResponse, error) {
+func (c *Client) SiteStatus(ctx context.Context, domain string, siteID int) (*SiteStatusResponse, error) {
 	log.Printf("[INFO] Getting Incapsula site status for domain: %s (site id: %d)\n", domain, siteID)
 
 	// Post form to Incapsula
 	values := url.Values{"site_id": {strconv.Itoa(siteID)}}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSiteStatus)
-	resp, err := c.PostFormWithHeaders(reqURL, values, ReadSite)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, ReadSite)
 	if err != nil {
-		return nil, fmt.Errorf("Error getting site status for domain %s (site id: %d): %s", domain, siteID, err)
+		return nil, fmt.Errorf("Error getting site status for domain %s (site id: %d): %w", domain, siteID, err)
 	}
 
 	// Read the body
@@ -290,13 +291,13 @@ func (c *Client) SiteStatus(domain string, siteID int) (*SiteStatusResponse, err
 	responseBody, err := ioutil.ReadAll(resp.Body)
 
 	// Dump JSON
-	log.Printf("[DEBUG] Incapsula site status JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Incapsula site status JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 
 	// Parse the JSON
 	var siteStatusResponse SiteStatusResponse
 	err = json.Unmarshal([]byte(responseBody), &siteStatusResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing site status JSON response for domain %s (site id: %d): %s", domain, siteID, err)
+		return nil, fmt.Errorf("Error parsing site status JSON response for domain %s (site id: %d): %w", domain, siteID, err)
 	}
 
 	var resString string
@@ -309,14 +310,14 @@ func (c *Client) SiteStatus(domain string, siteID int) (*SiteStatusResponse, err
 
 	// Look at the response status code from Incapsula
 	if resString != "0" {
-		return &siteStatusResponse, fmt.Errorf("Error from Incapsula service when getting site status for domain %s (site id: %d): %s", domain, siteID, string(responseBody))
+		return &siteStatusResponse, newAPIError(resp, responseBody, apiResourceIDs(domain, siteID), "Error from Incapsula service when getting site status for domain %s (site id: %d): %s", domain, siteID, string(responseBody))
 	}
 
 	return &siteStatusResponse, nil
 }
 
 // UpdateSite will update the specific param/value on the site resource
-func (c *Client) UpdateSite(siteID, param, value string) (*SiteUpdateResponse, error) {
+func (c *Client) UpdateSite(ctx context.Context, siteID, param, value string) (*SiteUpdateResponse, error) {
 	log.Printf("[INFO] Updating Incapsula site for siteID: %s\n", siteID)
 
 	// Post form to Incapsula
@@ -326,9 +327,9 @@ func (c *Client) UpdateSite(siteID, param, value string) (*SiteUpdateResponse, e
 		"value":   {value},
 	}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSiteUpdate)
-	resp, err := c.PostFormWithHeaders(reqURL, values, UpdateSite)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, UpdateSite)
 	if err != nil {
-		return nil, fmt.Errorf("Error updating param (%s) with value (%s) on site_id: %s: %s", param, value, siteID, err)
+		return nil, fmt.Errorf("Error updating param (%s) with value (%s) on site_id: %s: %w", param, value, siteID, err)
 	}
 
 	// Read the body
@@ -336,13 +337,13 @@ func (c *Client) UpdateSite(siteID, param, value string) (*SiteUpdateResponse, e
 	responseBody, err := ioutil.ReadAll(resp.Body)
 
 	// Dump JSON
-	log.Printf("[DEBUG] Incapsula update site JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Incapsula update site JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 
 	// Parse the JSON
 	var siteUpdateResponse SiteUpdateResponse
 	err = json.Unmarshal([]byte(responseBody), &siteUpdateResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing update site JSON response for siteID %s: %s", siteID, err)
+		return nil, fmt.Errorf("Error parsing update site JSON response for siteID %s: %w", siteID, err)
 	}
 
 	// Look at the response status code from Incapsula
@@ -353,10 +354,10 @@ func (c *Client) UpdateSite(siteID, param, value string) (*SiteUpdateResponse, e
 			queryParams := url.Values{}
 			queryParams.Add("extSiteId", siteID)
 
-			resp, err := c.GetWithHeaders(reqURL, queryParams, UpdateSite)
+			resp, err := c.GetWithHeaders(ctx, reqURL, queryParams, UpdateSite)
 
 			if err != nil {
-				return nil, fmt.Errorf("Error checking certificate on site_id: %s: %s", siteID, err)
+				return nil, fmt.Errorf("Error checking certificate on site_id: %s: %w", siteID, err)
 			}
 
 			// Read the body
@@ -364,13 +365,13 @@ func (c *Client) UpdateSite(siteID, param, value string) (*SiteUpdateResponse, e
 			responseBody, err := ioutil.ReadAll(resp.Body)
 
 			// Dump JSON
-			log.Printf("[DEBUG] Incapsula check certificate JSON response: %s\n", string(responseBody))
+			log.Printf("[DEBUG] Incapsula check certificate JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 
 			// Parse the JSON
 			var response Response
 			err = json.Unmarshal([]byte(responseBody), &response)
 			if err != nil {
-				return nil, fmt.Errorf("Error parsing check certificate JSON response for siteID %s: %s", siteID, err)
+				return nil, fmt.Errorf("Error parsing check certificate JSON response for siteID %s: %w", siteID, err)
 			}
 
 			// Check all SANs to verify if there's an active certificate already
@@ -383,14 +384,14 @@ func (c *Client) UpdateSite(siteID, param, value string) (*SiteUpdateResponse, e
 				}
 			}
 		}
-		return nil, fmt.Errorf("Error from Incapsula service when updating site for siteID %s: %s", siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error from Incapsula service when updating site for siteID %s: %s", siteID, string(responseBody))
 	}
 
 	return &siteUpdateResponse, nil
 }
 
 // DeleteSite deletes a site currently managed by Incapsula
-func (c *Client) DeleteSite(domain string, siteID int) error {
+func (c *Client) DeleteSite(ctx context.Context, domain string, siteID int) error {
 	// Specifically shaded this struct, no need to share across funcs or export
 	// We only care about the response code and possibly the message
 	type SiteDeleteResponse struct {
@@ -406,9 +407,9 @@ func (c *Client) DeleteSite(domain string, siteID int) error {
 		"ignore_grace_period": {strconv.FormatBool(true)},
 	}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSiteDelete)
-	resp, err := c.PostFormWithHeaders(reqURL, values, DeleteSite)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, DeleteSite)
 	if err != nil {
-		return fmt.Errorf("Error deleting site for domain %s (site id: %d): %s", domain, siteID, err)
+		return fmt.Errorf("Error deleting site for domain %s (site id: %d): %w", domain, siteID, err)
 	}
 
 	// Read the body
@@ -416,18 +417,18 @@ func (c *Client) DeleteSite(domain string, siteID int) error {
 	responseBody, err := ioutil.ReadAll(resp.Body)
 
 	// Dump JSON
-	log.Printf("[DEBUG] Incapsula delete site JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Incapsula delete site JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 
 	// Parse the JSON
 	var siteDeleteResponse SiteDeleteResponse
 	err = json.Unmarshal([]byte(responseBody), &siteDeleteResponse)
 	if err != nil {
-		return fmt.Errorf("Error parsing delete site JSON response for domain %s (site id: %d): %s", domain, siteID, err)
+		return fmt.Errorf("Error parsing delete site JSON response for domain %s (site id: %d): %w", domain, siteID, err)
 	}
 
 	// Look at the response status code from Incapsula
 	if siteDeleteResponse.Res != 0 {
-		return fmt.Errorf("Error from Incapsula service when deleting site for domain %s (site id: %d): %s", domain, siteID, string(responseBody))
+		return newAPIError(resp, responseBody, apiResourceIDs(domain, siteID), "Error from Incapsula service when deleting site for domain %s (site id: %d): %s", domain, siteID, string(responseBody))
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -26,19 +27,19 @@ type BotsConfigurationDTO struct {
 }
 
 // UpdateBotAccessControlConfiguration - Update the Bot Access Control configuration for a given website
-func (c *Client) UpdateBotAccessControlConfiguration(siteID string, requestDTO BotsConfigurationDTO) (*BotsConfigurationDTO, error) {
+func (c *Client) UpdateBotAccessControlConfiguration(ctx context.Context, siteID string, requestDTO BotsConfigurationDTO) (*BotsConfigurationDTO, error) {
 	log.Printf("[INFO] Updating the Bot Access Control configuration for siteID: %s\n", siteID)
 
 	log.Printf("[INFO]  requestDTO: %+v\n", requestDTO)
 
-	baseURLv3 := c.config.BaseURL[:len(c.config.BaseURL)-3] + "/v3"
+	baseURLv3 := c.config.baseURLV3()
 	botsJSON, err := json.Marshal(requestDTO)
 	log.Printf("[INFO]  botsJSON: %v\n", string(botsJSON))
 	reqURL := fmt.Sprintf("%s/sites/%s/settings/botConfiguration", baseURLv3, siteID)
 	log.Printf("[INFO]  reqURL: %v\n", reqURL)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, botsJSON, CreateBotConfiguration)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, botsJSON, CreateBotConfiguration)
 	if err != nil {
-		return nil, fmt.Errorf("Error executing update Bot Access Control configuration request for siteID %s: %s", siteID, err)
+		return nil, fmt.Errorf("Error executing update Bot Access Control configuration request for siteID %s: %w", siteID, err)
 	}
 
 	// Read the body
@@ -48,26 +49,31 @@ func (c *Client) UpdateBotAccessControlConfiguration(siteID string, requestDTO B
 	// Dump JSON
 	log.Printf("[DEBUG] Incapsula Update Bot Access Control configuration JSON response: %s\n", string(responseBody))
 
+	// Check the response code
+	if resp.StatusCode != 200 {
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error status code %d from Incapsula service when updating Bot Access Control configuration for siteID %s: %s", resp.StatusCode, siteID, string(responseBody))
+	}
+
 	// Parse the JSON
 	var responseDTO BotsConfigurationDTO
 	err = json.Unmarshal([]byte(responseBody), &responseDTO)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Update Bot Access Control configuration JSON response for siteID %s: %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Update Bot Access Control configuration JSON response for siteID %s: %w\nresponse: %s", siteID, err, string(responseBody))
 	}
 
 	return &responseDTO, nil
 }
 
 // GetBotAccessControlConfiguration - Retrieve the Bot Access Control configuration for a given website
-func (c *Client) GetBotAccessControlConfiguration(siteID string) (*BotsConfigurationDTO, error) {
+func (c *Client) GetBotAccessControlConfiguration(ctx context.Context, siteID string) (*BotsConfigurationDTO, error) {
 	log.Printf("[INFO] Getting Bot Access Control configuration (site_id: %s)\n", siteID)
 
 	// Get request to Incapsula
-	baseURLv3 := c.config.BaseURL[:len(c.config.BaseURL)-3] + "/v3"
+	baseURLv3 := c.config.baseURLV3()
 	reqURL := fmt.Sprintf("%s/sites/%s/settings/botConfiguration", baseURLv3, siteID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadBotConfiguration)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadBotConfiguration)
 	if err != nil {
-		return nil, fmt.Errorf("Error executing get Bot Access Control configuration request for siteID %s: %s", siteID, err)
+		return nil, fmt.Errorf("Error executing get Bot Access Control configuration request for siteID %s: %w", siteID, err)
 	}
 
 	// Read the body
@@ -77,26 +83,31 @@ func (c *Client) GetBotAccessControlConfiguration(siteID string) (*BotsConfigura
 	// Dump JSON
 	log.Printf("[DEBUG] Incapsula Bot Access Control JSON response: %s\n", string(responseBody))
 
+	// Check the response code
+	if resp.StatusCode != 200 {
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error status code %d from Incapsula service when getting Bot Access Control configuration for siteID %s: %s", resp.StatusCode, siteID, string(responseBody))
+	}
+
 	// Parse the JSON
 	var responseDTO BotsConfigurationDTO
 	err = json.Unmarshal([]byte(responseBody), &responseDTO)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Bot Access Control list JSON response for siteID: %s %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Bot Access Control list JSON response for siteID: %s %w\nresponse: %s", siteID, err, string(responseBody))
 	}
 
 	return &responseDTO, nil
 }
 
 // GetClientApplicationsMetadata - Retrieve the Bot Access Control Metadata
-func (c *Client) GetClientApplicationsMetadata() (*ClientApps, error) {
+func (c *Client) GetClientApplicationsMetadata(ctx context.Context) (*ClientApps, error) {
 	log.Printf("[INFO] Getting Client Applications Metadata\n")
 
 	// Get request to Incapsula
 	baseURLIntegration := strings.Replace(c.config.BaseURL, "/prov/", "/integration/", 1)
 	reqURL := fmt.Sprintf("%s/clapps", baseURLIntegration)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, nil, ReadClientApplications)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, nil, ReadClientApplications)
 	if err != nil {
-		return nil, fmt.Errorf("Error executing get Bot Access Control Metadata request %s", err)
+		return nil, fmt.Errorf("Error executing get Bot Access Control Metadata request %w", err)
 	}
 
 	// Read the body
@@ -110,7 +121,7 @@ func (c *Client) GetClientApplicationsMetadata() (*ClientApps, error) {
 	var responseDTO ClientApps
 	err = json.Unmarshal([]byte(responseBody), &responseDTO)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Client Applications Metadata list JSON response: %s\nresponse: %s", err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Client Applications Metadata list JSON response: %w\nresponse: %s", err, string(responseBody))
 	}
 
 	return &responseDTO, nil
@@ -1,13 +1,12 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
 const endpointSiteCertV3BasePath = "/certificates-ui/v3/sites/"
@@ -53,8 +52,7 @@ type SiteCertificateV3Response struct {
 }
 
 // RequestSiteCertificate request site certificate
-func (c *Client) RequestSiteCertificate(siteId int, validationMethod string, accountId *int) (*SiteCertificateV3Response, diag.Diagnostics) {
-	var diags diag.Diagnostics
+func (c *Client) RequestSiteCertificate(ctx context.Context, siteId int, validationMethod string, accountId *int) (*SiteCertificateV3Response, error) {
 	log.Printf("[INFO] request site certificate to: %d ", siteId)
 	siteCertificateDTO := SiteCertificateDTO{}
 	siteCertificateDTO.DefaultValidationMethod = validationMethod
@@ -63,43 +61,23 @@ func (c *Client) RequestSiteCertificate(siteId int, validationMethod string, acc
 	if accountId != nil {
 		url = fmt.Sprintf("%s?caid=%d", url, *accountId)
 	}
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodPost, url, []byte(siteCertificateDTOJSON), nil, RequestSiteCert)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodPost, url, []byte(siteCertificateDTOJSON), nil, RequestSiteCert)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Error response from Imperva service on request site certificate",
-			Detail:   fmt.Sprintf("Failed to request site certificate for site %d,%s", siteId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to request site certificate for site %d,%w", siteId, err)
 	}
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on request site certificate",
-			Detail:   fmt.Sprintf("Failed to read response for site id %d, %s", siteId, err.Error()),
-		})
-		return nil, diags
-	}
-	log.Printf("[DEBUG] Imperva request site certificate JSON response: %s\n", string(responseBody))
+		return nil, fmt.Errorf("Failed to read response for site id %d, %w", siteId, err)
+	}
+	log.Printf("[DEBUG] Imperva request site certificate JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on request site certificate",
-			Detail:   fmt.Sprintf("Failed to read response for site id %d, got response status %d, %s", siteId, resp.StatusCode, string(responseBody)),
-		})
-		return nil, diags
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteId), "Failed to read response for site id %d, got response status %d, %s", siteId, resp.StatusCode, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 	var siteCertificateV3Response SiteCertificateV3Response
 	err = json.Unmarshal(responseBody, &siteCertificateV3Response)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to parse request site certificate response",
-			Detail:   fmt.Sprintf("Failed to parse request site certificate JSON response for site %d, %s", siteId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to parse request site certificate JSON response for site %d, %w", siteId, err)
 	}
 
 	log.Printf("[DEBUG] Imperva request site certificate ended successfully for site id: %d", siteId)
@@ -108,51 +86,30 @@ func (c *Client) RequestSiteCertificate(siteId int, validationMethod string, acc
 }
 
 // DeleteRequestSiteCertificate deletes a site certificate request
-func (c *Client) DeleteRequestSiteCertificate(siteId int, accountId *int) (*SiteCertificateV3Response, diag.Diagnostics) {
-	var diags diag.Diagnostics
+func (c *Client) DeleteRequestSiteCertificate(ctx context.Context, siteId int, accountId *int) (*SiteCertificateV3Response, error) {
 	log.Printf("[INFO] deleting request site certificate %d", siteId)
 
 	url := fmt.Sprintf("%s%s%d%s", c.config.BaseURLAPI, endpointSiteCertV3BasePath, siteId, endpointSiteCertV3Suffix)
 	if accountId != nil {
 		url = fmt.Sprintf("%s?caid=%d", url, *accountId)
 	}
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodDelete, url, []byte("{}"), nil, RequestSiteCert)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodDelete, url, []byte("{}"), nil, RequestSiteCert)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Error response from Imperva service on delete request site certificate",
-			Detail:   fmt.Sprintf("Failed to delete request site certificate for site %d, %s", siteId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to delete request site certificate for site %d, %w", siteId, err)
 	}
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on delete request site certificate",
-			Detail:   fmt.Sprintf("Failed to read response for site id %d, %s", siteId, err.Error()),
-		})
-		return nil, diags
-	}
-	log.Printf("[DEBUG] Imperva delete request site certificate JSON response: %s\n", string(responseBody))
+		return nil, fmt.Errorf("Failed to read response for site id %d, %w", siteId, err)
+	}
+	log.Printf("[DEBUG] Imperva delete request site certificate JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on delete request site certificate",
-			Detail:   fmt.Sprintf("Failed to read response for site id %d, got response status %d, %s", siteId, resp.StatusCode, string(responseBody)),
-		})
-		return nil, diags
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteId), "Failed to read response for site id %d, got response status %d, %s", siteId, resp.StatusCode, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 	var siteCertificateV3Response SiteCertificateV3Response
 	err = json.Unmarshal(responseBody, &siteCertificateV3Response)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to parse delete request site certificate response",
-			Detail:   fmt.Sprintf("Failed to parse delete request site certificate JSON response for site %d, %s", siteId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to parse delete request site certificate JSON response for site %d, %w", siteId, err)
 	}
 
 	log.Printf("[DEBUG] Imperva delete request site certificate ended successfully for site id: %d", siteId)
@@ -161,51 +118,30 @@ func (c *Client) DeleteRequestSiteCertificate(siteId int, accountId *int) (*Site
 }
 
 // GetSiteCertificateRequestStatus get site cert request
-func (c *Client) GetSiteCertificateRequestStatus(siteId int, accountId *int) (*SiteCertificateV3Response, diag.Diagnostics) {
-	var diags diag.Diagnostics
+func (c *Client) GetSiteCertificateRequestStatus(ctx context.Context, siteId int, accountId *int) (*SiteCertificateV3Response, error) {
 	log.Printf("[INFO] get request site certificate status %d", siteId)
 
 	url := fmt.Sprintf("%s%s%d%s", c.config.BaseURLAPI, endpointSiteCertV3BasePath, siteId, endpointSiteCertV3Suffix)
 	if accountId != nil {
 		url = fmt.Sprintf("%s?caid=%d", url, *accountId)
 	}
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodGet, url, nil, nil, RequestSiteCert)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodGet, url, nil, nil, RequestSiteCert)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Error response from Imperva service on get request site certificate",
-			Detail:   fmt.Sprintf("Failed to get request site certificate for site %d, %s", siteId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to get request site certificate for site %d, %w", siteId, err)
 	}
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on get request site certificate",
-			Detail:   fmt.Sprintf("Failed to read response for site id %d, %s", siteId, err.Error()),
-		})
-		return nil, diags
-	}
-	log.Printf("[DEBUG] Imperva get request site certificate JSON response: %s\n", string(responseBody))
+		return nil, fmt.Errorf("Failed to read response for site id %d, %w", siteId, err)
+	}
+	log.Printf("[DEBUG] Imperva get request site certificate JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on get request site certificate",
-			Detail:   fmt.Sprintf("Failed to read response for site id %d, got response status %d, %s", siteId, resp.StatusCode, string(responseBody)),
-		})
-		return nil, diags
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteId), "Failed to read response for site id %d, got response status %d, %s", siteId, resp.StatusCode, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 	var siteCertificateV3Response SiteCertificateV3Response
 	err = json.Unmarshal(responseBody, &siteCertificateV3Response)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to parse get request site certificate response",
-			Detail:   fmt.Sprintf("Failed to parse get request site certificate JSON response for site %d, %s", siteId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to parse get request site certificate JSON response for site %d, %w", siteId, err)
 	}
 
 	log.Printf("[DEBUG] Imperva get request site certificate ended successfully for site id: %d", siteId)
@@ -214,38 +150,23 @@ func (c *Client) GetSiteCertificateRequestStatus(siteId int, accountId *int) (*S
 }
 
 // ValidateDomains SSL validation of domains
-func (c *Client) ValidateDomains(siteId int, domainIds []int) diag.Diagnostics {
-	var diags diag.Diagnostics
+func (c *Client) ValidateDomains(ctx context.Context, siteId int, domainIds []int) error {
 	log.Printf("[INFO] request ssl validation to: %v of site %d", domainIds, siteId)
 
 	url := fmt.Sprintf("%s%s%d%s", c.config.BaseURLAPI, endpointSiteCertV3BasePath, siteId, endpointSSLValidationSuffix)
 	domainJson, err := json.Marshal(domainIds)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to parse domain Ids for ssl validation",
-			Detail:   fmt.Sprintf("Failed to parse domain Ids %v for ssl validation %d, %s", domainIds, siteId, err.Error()),
-		})
-		return diags
-	}
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodPost, url, domainJson, nil, RequestSiteCert)
+		return fmt.Errorf("Failed to parse domain Ids %v for ssl validation %d, %w", domainIds, siteId, err)
+	}
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodPost, url, domainJson, nil, RequestSiteCert)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Error response from Imperva service on request for ssl validation",
-			Detail:   fmt.Sprintf("Failed to request site SSL validation for domains %v and site %d, %s", siteId, domainIds, err.Error()),
-		})
-		return diags
+		return fmt.Errorf("Failed to request site SSL validation for domains %v and site %d, %w", siteId, domainIds, err)
 	}
+	defer resp.Body.Close()
 	log.Printf("[DEBUG] Imperva ssl validation response: %d\n", resp.StatusCode)
 	if resp.StatusCode != 201 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to request ssl validation",
-			Detail:   fmt.Sprintf("Failed to request ssl validation for site id %d and domains %v, got response status %d", siteId, domainIds, resp.StatusCode),
-		})
-		return diags
+		responseBody, _ := ioutil.ReadAll(resp.Body)
+		return newAPIError(resp, responseBody, apiResourceIDs(siteId), "Failed to request ssl validation for site id %d and domains %v, got response status %d", siteId, domainIds, resp.StatusCode)
 	}
-	defer resp.Body.Close()
-	return diags
+	return nil
 }
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -12,8 +13,8 @@ import (
 func TestClientRequestSiteCertificateBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: "http://badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
-	requestSiteCertificateResponse, diag := client.RequestSiteCertificate(123, "DNS", nil)
-	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "Timeout exceeded while awaiting") {
+	requestSiteCertificateResponse, err := client.RequestSiteCertificate(context.Background(), 123, "DNS", nil)
+	if err == nil || !strings.Contains(err.Error(), "Timeout exceeded while awaiting") {
 		t.Errorf("Should have received an time out error")
 	}
 	if requestSiteCertificateResponse != nil {
@@ -32,8 +33,8 @@ func TestClientRequestSiteCertificateInternalError(t *testing.T) {
 	defer server.Close()
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	_, diag := client.RequestSiteCertificate(123, "DNS", nil)
-	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "got response status 500, error") {
+	_, err := client.RequestSiteCertificate(context.Background(), 123, "DNS", nil)
+	if err == nil || !strings.Contains(err.Error(), "got response status 500, error") {
 		t.Errorf("Should have received an error")
 	}
 }
@@ -49,8 +50,8 @@ func TestClientRequestSiteCertificateErrorsInBody(t *testing.T) {
 	defer server.Close()
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	accountSSLSettingsResponse, diag := client.RequestSiteCertificate(123, "DNS", nil)
-	if diag != nil {
+	accountSSLSettingsResponse, err := client.RequestSiteCertificate(context.Background(), 123, "DNS", nil)
+	if err != nil {
 		t.Errorf("Should not received an error")
 	}
 	if accountSSLSettingsResponse.Errors == nil || accountSSLSettingsResponse.Errors[0].Status != 400 || accountSSLSettingsResponse.Data != nil {
@@ -69,8 +70,8 @@ func TestClientRequestSiteCertificateDataInBody(t *testing.T) {
 	defer server.Close()
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	requestSiteCertificateResponse, diag := client.RequestSiteCertificate(123, "DNS", nil)
-	if diag != nil {
+	requestSiteCertificateResponse, err := client.RequestSiteCertificate(context.Background(), 123, "DNS", nil)
+	if err != nil {
 		t.Errorf("Should not received an error")
 	}
 	if requestSiteCertificateResponse.Errors != nil || requestSiteCertificateResponse.Data == nil || requestSiteCertificateResponse.Data[0].DefaultValidationMethod != "CNAME" {
@@ -90,8 +91,8 @@ func TestClientRequestSiteCertificateWithAccountId(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountId := 111
-	response, diag := client.RequestSiteCertificate(123, "DNS", &accountId)
-	if diag != nil {
+	response, err := client.RequestSiteCertificate(context.Background(), 123, "DNS", &accountId)
+	if err != nil {
 		t.Errorf("Should not received an error")
 	}
 	if response.Errors != nil || response.Data == nil || response.Data[0].DefaultValidationMethod != "CNAME" {
@@ -110,8 +111,8 @@ func TestClientGetSiteCertificateRequestStatusDataInBody(t *testing.T) {
 	defer server.Close()
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	siteCertificateResponse, diag := client.GetSiteCertificateRequestStatus(123, nil)
-	if diag != nil {
+	siteCertificateResponse, err := client.GetSiteCertificateRequestStatus(context.Background(), 123, nil)
+	if err != nil {
 		t.Errorf("Should not received an error")
 	}
 	if siteCertificateResponse.Errors != nil || siteCertificateResponse.Data == nil || siteCertificateResponse.Data[0].DefaultValidationMethod != "CNAME" {
@@ -131,8 +132,8 @@ func TestClientGetSiteCertificateRequestStatusErrorsInBody(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siteCertificateResponse, diag := client.GetSiteCertificateRequestStatus(123, nil)
-	if diag != nil {
+	siteCertificateResponse, err := client.GetSiteCertificateRequestStatus(context.Background(), 123, nil)
+	if err != nil {
 		t.Errorf("Should not received an error")
 	}
 	if siteCertificateResponse.Errors == nil || siteCertificateResponse.Errors[0].Status != 400 || siteCertificateResponse.Data != nil {
@@ -154,8 +155,8 @@ func TestClientGetSiteCertificateRequestStatusError500(t *testing.T) {
 	defer server.Close()
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	_, diag := client.GetSiteCertificateRequestStatus(123, nil)
-	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "got response status 500, error") {
+	_, err := client.GetSiteCertificateRequestStatus(context.Background(), 123, nil)
+	if err == nil || !strings.Contains(err.Error(), "got response status 500, error") {
 		t.Errorf("Should have received an error")
 	}
 }
@@ -163,9 +164,9 @@ func TestClientGetSiteCertificateRequestStatusError500(t *testing.T) {
 func TestClientGetSiteCertificateRequestStatusBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: "http://invalid.invalid"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
-	updateAccountSSLSettingsResponse, diag := client.GetSiteCertificateRequestStatus(123, nil)
-	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "Failed to get request site certificate") {
-		t.Errorf("Should have received an error, got: %v", diag)
+	updateAccountSSLSettingsResponse, err := client.GetSiteCertificateRequestStatus(context.Background(), 123, nil)
+	if err == nil || !strings.Contains(err.Error(), "Failed to get request site certificate") {
+		t.Errorf("Should have received an error, got: %v", err)
 	}
 	if updateAccountSSLSettingsResponse != nil {
 		t.Errorf("Should have received a nil addAccountResponse instance")
@@ -175,9 +176,9 @@ func TestClientGetSiteCertificateRequestStatusBadConnection(t *testing.T) {
 func TestClientDeleteRequestSiteCertificateBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: "http://invalid.invalid"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
-	_, diag := client.DeleteRequestSiteCertificate(123, nil)
-	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "Failed to delete request site certificate") {
-		t.Errorf("Should have received an error, got: %v", diag)
+	_, err := client.DeleteRequestSiteCertificate(context.Background(), 123, nil)
+	if err == nil || !strings.Contains(err.Error(), "Failed to delete request site certificate") {
+		t.Errorf("Should have received an error, got: %v", err)
 	}
 }
 
@@ -192,8 +193,8 @@ func TestClientDeleteRequestSiteCertificateError500(t *testing.T) {
 	defer server.Close()
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	_, diag := client.DeleteRequestSiteCertificate(123, nil)
-	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "got response status 500") {
+	_, err := client.DeleteRequestSiteCertificate(context.Background(), 123, nil)
+	if err == nil || !strings.Contains(err.Error(), "got response status 500") {
 		t.Errorf("Should have received an error")
 	}
 }
@@ -210,8 +211,8 @@ func TestClientDeleteRequestSiteCertificateWithAccountId(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountId := 111
-	response, diag := client.DeleteRequestSiteCertificate(123, &accountId)
-	if diag != nil || diag.HasError() {
+	response, err := client.DeleteRequestSiteCertificate(context.Background(), 123, &accountId)
+	if err != nil {
 		t.Errorf("Should not received an error")
 	}
 	if response.Errors != nil || response.Data == nil || response.Data[0].DefaultValidationMethod != "CNAME" {
@@ -222,9 +223,9 @@ func TestClientDeleteRequestSiteCertificateWithAccountId(t *testing.T) {
 func TestClientValidateDomainBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: "http://invalid.invalid"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
-	diag := client.ValidateDomains(123, []int{222})
-	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "Failed to request site SSL validation") {
-		t.Errorf("Should have received an error, got: %v", diag)
+	err := client.ValidateDomains(context.Background(), 123, []int{222})
+	if err == nil || !strings.Contains(err.Error(), "Failed to request site SSL validation") {
+		t.Errorf("Should have received an error, got: %v", err)
 	}
 }
 func TestClientValidateDomainError500(t *testing.T) {
@@ -238,8 +239,8 @@ func TestClientValidateDomainError500(t *testing.T) {
 	defer server.Close()
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	diag := client.ValidateDomains(123, []int{222})
-	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "got response status 500") {
+	err := client.ValidateDomains(context.Background(), 123, []int{222})
+	if err == nil || !strings.Contains(err.Error(), "got response status 500") {
 		t.Errorf("Should have received an error")
 	}
 }
@@ -255,8 +256,8 @@ func TestClientValidateDomain(t *testing.T) {
 	defer server.Close()
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	deleteSiteCertificateResponse, diag := client.DeleteRequestSiteCertificate(123, nil)
-	if diag != nil || diag.HasError() {
+	deleteSiteCertificateResponse, err := client.DeleteRequestSiteCertificate(context.Background(), 123, nil)
+	if err != nil {
 		t.Errorf("Should not received an error")
 	}
 	if deleteSiteCertificateResponse.Errors != nil || deleteSiteCertificateResponse.Data == nil || deleteSiteCertificateResponse.Data[0].DefaultValidationMethod != "CNAME" {
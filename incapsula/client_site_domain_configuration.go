@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -80,7 +81,7 @@ type ApiErrorResponse struct {
 	Source ApiErrorSource `json:"source"`
 }
 
-func (c *Client) GetWebsiteDomains(siteId string) (*SiteDomainDetailsDto, error) {
+func (c *Client) GetWebsiteDomains(ctx context.Context, siteId string) (*SiteDomainDetailsDto, error) {
 	reqURL := fmt.Sprintf("%s%s%s%s", c.config.BaseURLAPI, endpointDomainManagement, siteId, "/domains")
 	if siteId == "" {
 		fmt.Errorf("[ERROR] site ID was not provided")
@@ -88,9 +89,9 @@ func (c *Client) GetWebsiteDomains(siteId string) (*SiteDomainDetailsDto, error)
 	var params = map[string]string{}
 	params["pageSize"] = "-1"
 	params["excludeAutoDiscovered"] = "true"
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodGet, reqURL, nil, params, ReadDomain)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, params, ReadDomain)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when reading domain configuration details %s: %s", siteId, err)
+		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when reading domain configuration details %s: %w", siteId, err)
 	}
 
 	defer resp.Body.Close()
@@ -100,14 +101,19 @@ func (c *Client) GetWebsiteDomains(siteId string) (*SiteDomainDetailsDto, error)
 	var siteDomainDetailsResponse SiteDomainDetailsDto
 	err = json.Unmarshal([]byte(responseBody), &siteDomainDetailsResponse)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error parsing get domain details response for site ID %s: %s\nresponse: %s", siteId, err, string(responseBody))
+		return nil, fmt.Errorf("[ERROR] Error parsing get domain details response for site ID %s: %w\nresponse: %s", siteId, err, string(responseBody))
+	}
+
+	// Check the response code
+	if resp.StatusCode != 200 {
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteId), "[ERROR] Error status code %d from Incapsula service when reading domain configuration details %s: %s", resp.StatusCode, siteId, string(responseBody))
 	}
 
 	return &siteDomainDetailsResponse, nil
 }
 
-func (c *Client) BulkUpdateDomainsToSite(siteID string, siteDomainDetails []SiteDomainDetails) error {
-	err := verifyDomainsAmountBelowMaxAllowed(c, siteID, siteDomainDetails)
+func (c *Client) BulkUpdateDomainsToSite(ctx context.Context, siteID string, siteDomainDetails []SiteDomainDetails) error {
+	err := verifyDomainsAmountBelowMaxAllowed(ctx, c, siteID, siteDomainDetails)
 	if err != nil {
 		return err
 	}
@@ -120,13 +126,13 @@ func (c *Client) BulkUpdateDomainsToSite(siteID string, siteDomainDetails []Site
 	}
 
 	addBulkDomainsDto := BulkAddDomainsDto{Data: domainNames}
-	resp, err := handleAddBulkRequest(c, addBulkDomainsDto, siteID)
+	resp, err := handleAddBulkRequest(ctx, c, addBulkDomainsDto, siteID)
 	if err != nil {
 		return err
 	}
 
 	for i := 0; i < 15; i++ {
-		asyncResponseDataDto, err := checkForAsyncRequestStatus(c, siteID, resp.Data[0].Handler)
+		asyncResponseDataDto, err := checkForAsyncRequestStatus(ctx, c, siteID, resp.Data[0].Handler)
 		if err != nil {
 			return err
 		}
@@ -135,7 +141,9 @@ func (c *Client) BulkUpdateDomainsToSite(siteID string, siteDomainDetails []Site
 		log.Printf("iteration %d: update domains for site status: %s", i, status)
 		switch status {
 		case "IN_PROGRESS":
-			time.Sleep(10 * time.Second)
+			if err := sleepWithContext(ctx, 10*time.Second); err != nil {
+				return err
+			}
 		case "FAILED":
 			return fmt.Errorf("async update domains for siteId %s returned FAILED status", siteID)
 		case "COMPLETED_SUCCESSFULLY":
@@ -146,20 +154,25 @@ func (c *Client) BulkUpdateDomainsToSite(siteID string, siteDomainDetails []Site
 	return fmt.Errorf("async request status for siteId %s reached timeout", siteID)
 }
 
-func checkForAsyncRequestStatus(c *Client, siteId string, requestUuid string) (*AsyncResponseDetailsDto, error) {
+func checkForAsyncRequestStatus(ctx context.Context, c *Client, siteId string, requestUuid string) (*AsyncResponseDetailsDto, error) {
 	reqURL := fmt.Sprintf("%s%s%s%s%s", c.config.BaseURLAPI, endpointDomainManagement, siteId, "/domains/status/", requestUuid)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, UpdateDomain)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, UpdateDomain)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when update domains for siteId %s: %s", siteId, err)
+		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when update domains for siteId %s: %w", siteId, err)
 	}
 	defer resp.Body.Close()
 
 	responseBody, err := ioutil.ReadAll(resp.Body)
 
+	// Check the response code
+	if resp.StatusCode != 200 {
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteId), "[ERROR] Error status code %d from Incapsula service when reading async update domains request %s for siteId %s: %s", resp.StatusCode, requestUuid, siteId, string(responseBody))
+	}
+
 	var asyncResponseDetailsDto AsyncResponseDetailsDto
 	err = json.Unmarshal([]byte(responseBody), &asyncResponseDetailsDto)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error parsing async request status %s: %s\nresponse: %s", requestUuid, err, string(responseBody))
+		return nil, fmt.Errorf("[ERROR] Error parsing async request status %s: %w\nresponse: %s", requestUuid, err, string(responseBody))
 	}
 
 	if asyncResponseDetailsDto.Errors != nil && len(asyncResponseDetailsDto.Errors) > 0 {
@@ -169,26 +182,31 @@ func checkForAsyncRequestStatus(c *Client, siteId string, requestUuid string) (*
 	return &asyncResponseDetailsDto, nil
 }
 
-func handleAddBulkRequest(c *Client, bulkAddDomainsDto BulkAddDomainsDto, siteId string) (*AsyncResponseDetailsDto, error) {
+func handleAddBulkRequest(ctx context.Context, c *Client, bulkAddDomainsDto BulkAddDomainsDto, siteId string) (*AsyncResponseDetailsDto, error) {
 	reqURL := fmt.Sprintf("%s%s%s%s", c.config.BaseURLAPI, endpointDomainManagement, siteId, "/domains")
 	body, err := json.Marshal(bulkAddDomainsDto)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to parse bulkAddDomainsDto: %s ", err)
+		return nil, fmt.Errorf("Failed to parse bulkAddDomainsDto: %w ", err)
 	}
 
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPut, reqURL, body, UpdateDomain)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPut, reqURL, body, UpdateDomain)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when updating domains for site %s: %s", siteId, err)
+		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when updating domains for site %s: %w", siteId, err)
 	}
 	defer resp.Body.Close()
 
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	log.Printf("[DEBUG] Incapsula update domain management response: %s\n", string(responseBody))
 
+	// Check the response code
+	if resp.StatusCode != 200 && resp.StatusCode != 202 {
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteId), "[ERROR] Error status code %d from Incapsula service when updating domains for site %s: %s", resp.StatusCode, siteId, string(responseBody))
+	}
+
 	var asyncResponseDetailsDto AsyncResponseDetailsDto
 	err = json.Unmarshal([]byte(responseBody), &asyncResponseDetailsDto)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error parsing async request for update domains for siteId %s: %s\nresponse: %s", siteId, err, string(responseBody))
+		return nil, fmt.Errorf("[ERROR] Error parsing async request for update domains for siteId %s: %w\nresponse: %s", siteId, err, string(responseBody))
 	}
 
 	if asyncResponseDetailsDto.Errors != nil && len(asyncResponseDetailsDto.Errors) > 0 {
@@ -197,8 +215,8 @@ func handleAddBulkRequest(c *Client, bulkAddDomainsDto BulkAddDomainsDto, siteId
 	return &asyncResponseDetailsDto, nil
 }
 
-func verifyDomainsAmountBelowMaxAllowed(c *Client, siteId string, siteDomainDetails []SiteDomainDetails) error {
-	siteExtraDetails, err := GetSiteExtraDetails(c, siteId)
+func verifyDomainsAmountBelowMaxAllowed(ctx context.Context, c *Client, siteId string, siteDomainDetails []SiteDomainDetails) error {
+	siteExtraDetails, err := GetSiteExtraDetails(ctx, c, siteId)
 	if err != nil {
 		return err
 	}
@@ -214,11 +232,11 @@ func verifyDomainsAmountBelowMaxAllowed(c *Client, siteId string, siteDomainDeta
 	return nil
 }
 
-func GetSiteExtraDetails(c *Client, siteID string) (*SiteDomainsExtraDetailsDto, error) {
+func GetSiteExtraDetails(ctx context.Context, c *Client, siteID string) (*SiteDomainsExtraDetailsDto, error) {
 	reqURL := fmt.Sprintf("%s%s%s%s", c.config.BaseURLAPI, endpointDomainManagement, siteID, "/domains/extraDetails")
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadDomainExtraDetails)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadDomainExtraDetails)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when geting site domains extra details %s: %s", siteID, err)
+		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when geting site domains extra details %s: %w", siteID, err)
 	}
 
 	// Read the body
@@ -226,10 +244,15 @@ func GetSiteExtraDetails(c *Client, siteID string) (*SiteDomainsExtraDetailsDto,
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	log.Printf("[DEBUG] Incapsula get site domains extra details JSON response: %s\n", string(responseBody))
 
+	// Check the response code
+	if resp.StatusCode != 200 {
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID), "[ERROR] Error status code %d from Incapsula service when geting site domains extra details %s: %s", resp.StatusCode, siteID, string(responseBody))
+	}
+
 	var siteExtraDetailsResponse SiteDomainsExtraDetailsResponse
 	err = json.Unmarshal([]byte(responseBody), &siteExtraDetailsResponse)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error parsing add domains to site JSON response for site ID %s: %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("[ERROR] Error parsing add domains to site JSON response for site ID %s: %w\nresponse: %s", siteID, err, string(responseBody))
 	}
 	if siteExtraDetailsResponse.Errors != nil && len(siteExtraDetailsResponse.Errors) > 0 {
 		return nil, fmt.Errorf("got error when trying to get site extra details: %s", siteExtraDetailsResponse.Errors[0].Detail)
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -64,7 +65,7 @@ func TestClientGetDomainsForSiteValidCase(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	addCertificateResponse, err := client.GetWebsiteDomains(siteID)
+	addCertificateResponse, err := client.GetWebsiteDomains(context.Background(), siteID)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -97,7 +98,7 @@ func TestClientGetDomainsForSiteBadJsonResponse(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	addCertificateResponse, err := client.GetWebsiteDomains(siteID)
+	addCertificateResponse, err := client.GetWebsiteDomains(context.Background(), siteID)
 
 	if err == nil {
 		t.Errorf("expected to get error")
@@ -133,7 +134,7 @@ func TestClientGetDomainsForSiteUnAuthorizedResponse(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	addCertificateResponse, err := client.GetWebsiteDomains(siteID)
+	addCertificateResponse, err := client.GetWebsiteDomains(context.Background(), siteID)
 
 	if err == nil {
 		t.Errorf("expected to get error")
@@ -205,7 +206,7 @@ func TestClientAddDomainsForSiteValidCase(t *testing.T) {
 	if err != nil {
 		t.Errorf("error")
 	}
-	err = client.BulkUpdateDomainsToSite(siteID, getFakeSiteDomainDetailsArray(siteIdStr, "a.com", "b.com"))
+	err = client.BulkUpdateDomainsToSite(context.Background(), siteID, getFakeSiteDomainDetailsArray(siteIdStr, "a.com", "b.com"))
 	if err != nil {
 		t.Errorf("Should not receive error")
 	}
@@ -269,7 +270,7 @@ func TestClientAddDomainsForSiteErrorResponse(t *testing.T) {
 	if err != nil {
 		t.Errorf("error")
 	}
-	err = client.BulkUpdateDomainsToSite(siteID, getFakeSiteDomainDetailsArray(siteIdStr, "a.com", "b.com"))
+	err = client.BulkUpdateDomainsToSite(context.Background(), siteID, getFakeSiteDomainDetailsArray(siteIdStr, "a.com", "b.com"))
 	if err == nil {
 		t.Errorf("Should receive error")
 	}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -15,14 +16,14 @@ type MaskingSettings struct {
 }
 
 // GetMaskingSettings gets the site masking settings
-func (c *Client) GetMaskingSettings(siteID string) (*MaskingSettings, error) {
+func (c *Client) GetMaskingSettings(ctx context.Context, siteID string) (*MaskingSettings, error) {
 	log.Printf("[INFO] Getting Incapsula Masking Settings for Site ID %s\n", siteID)
 
 	// Post form to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%s/settings/masking", c.config.BaseURLRev2, siteID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadSiteMasking)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadSiteMasking)
 	if err != nil {
-		return nil, fmt.Errorf("Error from Incapsula service when reading masking settings for Site ID %s: %s", siteID, err)
+		return nil, fmt.Errorf("Error from Incapsula service when reading masking settings for Site ID %s: %w", siteID, err)
 	}
 
 	// Read the body
@@ -34,33 +35,33 @@ func (c *Client) GetMaskingSettings(siteID string) (*MaskingSettings, error) {
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when reading masking settings for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error status code %d from Incapsula service when reading masking settings for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody))
 	}
 
 	// Parse the JSON
 	var maskingSettings MaskingSettings
 	err = json.Unmarshal([]byte(responseBody), &maskingSettings)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Incap masking settings JSON response for Site ID %s: %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Incap masking settings JSON response for Site ID %s: %w\nresponse: %s", siteID, err, string(responseBody))
 	}
 
 	return &maskingSettings, nil
 }
 
 // UpdateMaskingSettings updates the site masking settings
-func (c *Client) UpdateMaskingSettings(siteID string, maskingSettings *MaskingSettings) error {
+func (c *Client) UpdateMaskingSettings(ctx context.Context, siteID string, maskingSettings *MaskingSettings) error {
 	log.Printf("[INFO] Updating Incapsula masking settings for Site ID %s\n", siteID)
 
 	maskingSettingsJSON, err := json.Marshal(maskingSettings)
 	if err != nil {
-		return fmt.Errorf("Failed to JSON marshal MaskingSettings: %s", err)
+		return fmt.Errorf("Failed to JSON marshal MaskingSettings: %w", err)
 	}
 
 	// Put request to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%s/settings/masking", c.config.BaseURLRev2, siteID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, maskingSettingsJSON, UpdateSiteMasking)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, maskingSettingsJSON, UpdateSiteMasking)
 	if err != nil {
-		return fmt.Errorf("Error from Incapsula service when updating masking settings for Site ID %s: %s", siteID, err)
+		return fmt.Errorf("Error from Incapsula service when updating masking settings for Site ID %s: %w", siteID, err)
 	}
 
 	// Read the body
@@ -72,7 +73,7 @@ func (c *Client) UpdateMaskingSettings(siteID string, maskingSettings *MaskingSe
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from Incapsula service when updating masking settings for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody))
+		return newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error status code %d from Incapsula service when updating masking settings for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody))
 	}
 
 	return nil
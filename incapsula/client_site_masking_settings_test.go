@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -17,7 +18,7 @@ func TestClientGetMaskingSettingsBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := "123"
-	maskingSettings, err := client.GetMaskingSettings(siteID)
+	maskingSettings, err := client.GetMaskingSettings(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -47,7 +48,7 @@ func TestClientGetMaskingSettingsBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	maskingSettings, err := client.GetMaskingSettings(siteID)
+	maskingSettings, err := client.GetMaskingSettings(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -78,7 +79,7 @@ func TestClientGetMaskingSettingsInvalidSite(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	maskingSettings, err := client.GetMaskingSettings(siteID)
+	maskingSettings, err := client.GetMaskingSettings(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -108,7 +109,7 @@ func TestClientGetMaskingSettingsValidSite(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	maskingSettings, err := client.GetMaskingSettings(siteID)
+	maskingSettings, err := client.GetMaskingSettings(context.Background(), siteID)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -132,7 +133,7 @@ func TestClientUpdateMaskingSettingsSettingsBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := "123"
 	maskingSettings := MaskingSettings{HashingEnabled: true, HashSalt: "salt"}
-	err := client.UpdateMaskingSettings(siteID, &maskingSettings)
+	err := client.UpdateMaskingSettings(context.Background(), siteID, &maskingSettings)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -161,7 +162,7 @@ func TestClientUpdateMaskingSettingsInvalidSite(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.UpdateMaskingSettings(siteID, &maskingSettings)
+	err := client.UpdateMaskingSettings(context.Background(), siteID, &maskingSettings)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -189,7 +190,7 @@ func TestClientUpdateMaskingSettingsValidSite(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.UpdateMaskingSettings(siteID, &maskingSettings)
+	err := client.UpdateMaskingSettings(context.Background(), siteID, &maskingSettings)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
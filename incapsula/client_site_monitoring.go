@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -49,27 +50,27 @@ type SiteMonitoringResponse struct {
 	Data []SiteMonitoring `json:"data"`
 }
 
-func (c *Client) GetSiteMonitoring(siteID int) (*SiteMonitoringResponse, error) {
+func (c *Client) GetSiteMonitoring(ctx context.Context, siteID int) (*SiteMonitoringResponse, error) {
 	log.Printf("[INFO] Getting Incapsula Site Monitoring for Site ID %d", siteID)
-	return CrudSiteMonitoring("Read", siteID, http.MethodGet, nil, c)
+	return CrudSiteMonitoring(ctx, "Read", siteID, http.MethodGet, nil, c)
 }
 
-func (c *Client) UpdateSiteMonitoring(siteID int, siteMonitoring *SiteMonitoring) (*SiteMonitoringResponse, error) {
+func (c *Client) UpdateSiteMonitoring(ctx context.Context, siteID int, siteMonitoring *SiteMonitoring) (*SiteMonitoringResponse, error) {
 	log.Printf("[INFO] Updating Incapsula Site Monitoring for Site ID %d", siteID)
 	siteMopnitoringJSON, err := json.Marshal(siteMonitoring)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to JSON marshal Site Monitoring for SiteID: %s", err)
+		return nil, fmt.Errorf("Failed to JSON marshal Site Monitoring for SiteID: %w", err)
 	}
-	return CrudSiteMonitoring("Update", siteID, http.MethodPost, siteMopnitoringJSON, c)
+	return CrudSiteMonitoring(ctx, "Update", siteID, http.MethodPost, siteMopnitoringJSON, c)
 }
 
-func CrudSiteMonitoring(action string, siteID int, hhtpMethod string, data []byte, c *Client) (*SiteMonitoringResponse, error) {
+func CrudSiteMonitoring(ctx context.Context, action string, siteID int, hhtpMethod string, data []byte, c *Client) (*SiteMonitoringResponse, error) {
 	url := fmt.Sprintf("%s/appdlv-site-settings/v2/site/%d/monitoring", c.config.BaseURLAPI, siteID)
 	//todo tolowerCase for operation name
-	resp, err := c.DoJsonRequestWithHeaders(hhtpMethod, url, data, action+"_site_monitoring")
+	resp, err := c.DoJsonRequestWithHeaders(ctx, hhtpMethod, url, data, action+"_site_monitoring")
 	//remove e in the end of action
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when %s Site Monitoring for Site ID %d: %s", strings.ToLower(action)+"ing", siteID, err)
+		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when %s Site Monitoring for Site ID %d: %w", strings.ToLower(action)+"ing", siteID, err)
 	}
 
 	// Read the body
@@ -78,19 +79,19 @@ func CrudSiteMonitoring(action string, siteID int, hhtpMethod string, data []byt
 	log.Printf("[DEBUG] Incapsula %s Site Monitoring JSON response: %s\n", action, string(responseBody))
 
 	if resp.StatusCode == 404 {
-		return nil, fmt.Errorf("Missing Load Balancing subscription for Site ID %d: %s", siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID), "Missing Load Balancing subscription for Site ID %d: %s", siteID, string(responseBody))
 	}
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when %s Site Monitoring for Site ID %d: %s", resp.StatusCode, strings.TrimSuffix(action, "e")+"ing", siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error status code %d from Incapsula service when %s Site Monitoring for Site ID %d: %s", resp.StatusCode, strings.TrimSuffix(action, "e")+"ing", siteID, string(responseBody))
 	}
 
 	// Dump JSON
 	var siteMonitoringResponse SiteMonitoringResponse
 	err = json.Unmarshal([]byte(responseBody), &siteMonitoringResponse)
 	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error parsing Site Monitoring Response JSON response for Site ID %d: %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("[ERROR] Error parsing Site Monitoring Response JSON response for Site ID %d: %w\nresponse: %s", siteID, err, string(responseBody))
 	}
 	//todo check if data.length is >0
 	return &siteMonitoringResponse, nil
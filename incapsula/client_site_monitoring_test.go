@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -24,7 +25,7 @@ func TestUpdateSiteMonitoringBadConnection(t *testing.T) {
 		Notifications:         Notifications{},
 	}
 
-	siteMonitoringResponse, err := client.UpdateSiteMonitoring(
+	siteMonitoringResponse, err := client.UpdateSiteMonitoring(context.Background(),
 		siteID,
 		&payload)
 
@@ -64,7 +65,7 @@ func TestUpdateSiteMonitoringBadJSON(t *testing.T) {
 		Notifications:         Notifications{},
 	}
 
-	siteMonitoringResponse, err := client.UpdateSiteMonitoring(
+	siteMonitoringResponse, err := client.UpdateSiteMonitoring(context.Background(),
 		siteID,
 		&payload)
 
@@ -111,7 +112,7 @@ func TestUpdateSiteMonitoringInvalidConfig(t *testing.T) {
 	//invalid payload
 	payload := SiteMonitoring{}
 
-	siteMonitoringResponse, err := client.UpdateSiteMonitoring(
+	siteMonitoringResponse, err := client.UpdateSiteMonitoring(context.Background(),
 		siteID,
 		&payload)
 
@@ -158,7 +159,7 @@ func TestUpdateSiteMonitoringNoSubscription(t *testing.T) {
 
 	payload := SiteMonitoring{}
 
-	siteMonitoringResponse, err := client.UpdateSiteMonitoring(
+	siteMonitoringResponse, err := client.UpdateSiteMonitoring(context.Background(),
 		siteID,
 		&payload)
 
@@ -228,7 +229,7 @@ func TestUpdateSiteMonitoringConfig(t *testing.T) {
 		Notifications:         Notifications{},
 	}
 
-	siteMonitoringResponse, err := client.UpdateSiteMonitoring(
+	siteMonitoringResponse, err := client.UpdateSiteMonitoring(context.Background(),
 		siteID,
 		&payload)
 
@@ -251,7 +252,7 @@ func TestReadSiteMonitoringBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := 42
 
-	siteMonitoringResponse, err := client.GetSiteMonitoring(siteID)
+	siteMonitoringResponse, err := client.GetSiteMonitoring(context.Background(), siteID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -282,7 +283,7 @@ func TestUpdatReadSiteMonitoringBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siteMonitoringResponse, err := client.GetSiteMonitoring(siteID)
+	siteMonitoringResponse, err := client.GetSiteMonitoring(context.Background(), siteID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -329,7 +330,7 @@ func TestReadSiteMonitoringInvalidConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siteMonitoringResponse, err := client.GetSiteMonitoring(siteID)
+	siteMonitoringResponse, err := client.GetSiteMonitoring(context.Background(), siteID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -373,7 +374,7 @@ func TestReadSiteMonitoringNoSubscription(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siteMonitoringResponse, err := client.GetSiteMonitoring(siteID)
+	siteMonitoringResponse, err := client.GetSiteMonitoring(context.Background(), siteID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -434,7 +435,7 @@ func TestReadSiteMonitoringConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siteMonitoringResponse, err := client.GetSiteMonitoring(siteID)
+	siteMonitoringResponse, err := client.GetSiteMonitoring(context.Background(), siteID)
 
 	if err != nil {
 		t.Errorf("Should not have received an error : %s", err.Error())
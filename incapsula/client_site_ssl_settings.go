@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -35,12 +36,12 @@ type SSLSettingsResponse struct {
 	Data []SSLSettingsDTO `json:"data"`
 }
 
-func (c *Client) UpdateSiteSSLSettings(siteID int, accountID int, mySSLSettings SSLSettingsResponse) (*SSLSettingsResponse, error) {
+func (c *Client) UpdateSiteSSLSettings(ctx context.Context, siteID int, accountID int, mySSLSettings SSLSettingsResponse) (*SSLSettingsResponse, error) {
 	log.Printf("[INFO] Updating Incapsula Site SSL settings for Site ID %d\n", siteID)
 
 	requestJSON, err := json.Marshal(mySSLSettings)
 	if err != nil {
-		return nil, fmt.Errorf("failed to JSON marshal SSLSettings: %s", err)
+		return nil, fmt.Errorf("failed to JSON marshal SSLSettings: %w", err)
 	}
 
 	// Patch request to Incapsula
@@ -50,9 +51,9 @@ func (c *Client) UpdateSiteSSLSettings(siteID int, accountID int, mySSLSettings
 	}
 	log.Printf("[INFO] SSL Settings request json looks like this %s\n", requestJSON)
 	log.Printf("[INFO] SSL Settings request URL looks like this %s\n", reqURL)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPatch, reqURL, requestJSON, UpdateSiteSSLSettings)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPatch, reqURL, requestJSON, UpdateSiteSSLSettings)
 	if err != nil {
-		return nil, fmt.Errorf("error from Incapsula service when updating Site SSL settings %s for Site ID %d: %s", requestJSON, siteID, err)
+		return nil, fmt.Errorf("error from Incapsula service when updating Site SSL settings %s for Site ID %d: %w", requestJSON, siteID, err)
 	}
 
 	// Read the body
@@ -64,20 +65,20 @@ func (c *Client) UpdateSiteSSLSettings(siteID int, accountID int, mySSLSettings
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("error status code %d from Incapsula service when updating Site SSL settings %s for Site ID %d: %s", resp.StatusCode, requestJSON, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID), "error status code %d from Incapsula service when updating Site SSL settings %s for Site ID %d: %s", resp.StatusCode, requestJSON, siteID, string(responseBody))
 	}
 
 	// Parse the JSON
 	var sslSettingsResponse SSLSettingsResponse
 	err = json.Unmarshal([]byte(responseBody), &sslSettingsResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Incap Site settings JSON response for Site ID %d: %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Incap Site settings JSON response for Site ID %d: %w\nresponse: %s", siteID, err, string(responseBody))
 	}
 
 	return &sslSettingsResponse, nil
 }
 
-func (c *Client) ReadSiteSSLSettings(siteID int, accountID int) (*SSLSettingsResponse, int, error) {
+func (c *Client) ReadSiteSSLSettings(ctx context.Context, siteID int, accountID int) (*SSLSettingsResponse, int, error) {
 	log.Printf("[INFO] Getting Incapsula Incap SSL settings for Site ID %d\n", siteID)
 
 	// Get form to Incapsula
@@ -86,9 +87,9 @@ func (c *Client) ReadSiteSSLSettings(siteID int, accountID int) (*SSLSettingsRes
 		reqURL = fmt.Sprintf("%s?caid=%d", reqURL, accountID)
 	}
 	log.Printf("[INFO] SSL Settings request URL looks like this %s\n", reqURL)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadSiteSSLSettings)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadSiteSSLSettings)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error from Incapsula service when reading SSL Settings for Site ID %d: %s", siteID, err)
+		return nil, 0, fmt.Errorf("error from Incapsula service when reading SSL Settings for Site ID %d: %w", siteID, err)
 	}
 
 	// Read the body
@@ -100,14 +101,14 @@ func (c *Client) ReadSiteSSLSettings(siteID int, accountID int) (*SSLSettingsRes
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, resp.StatusCode, fmt.Errorf("error status code %d from Incapsula service when reading SSL settings for Site ID %d: %s", resp.StatusCode, siteID, string(responseBody))
+		return nil, resp.StatusCode, newAPIError(resp, responseBody, apiResourceIDs(siteID), "error status code %d from Incapsula service when reading SSL settings for Site ID %d: %s", resp.StatusCode, siteID, string(responseBody))
 	}
 
 	// Parse the JSON
 	var sslSettingsResponse SSLSettingsResponse
 	err = json.Unmarshal([]byte(responseBody), &sslSettingsResponse)
 	if err != nil {
-		return nil, resp.StatusCode, fmt.Errorf("error parsing Site SSL settings JSON response for Site ID %d: %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, resp.StatusCode, fmt.Errorf("error parsing Site SSL settings JSON response for Site ID %d: %w\nresponse: %s", siteID, err, string(responseBody))
 	}
 
 	return &sslSettingsResponse, resp.StatusCode, nil
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -35,14 +36,14 @@ type TXTRecordResponse struct {
 }
 
 // ReadTXTRecords gets the site TXT Records
-func (c *Client) ReadTXTRecords(siteID int) (*TXTRecordResponse, error) {
+func (c *Client) ReadTXTRecords(ctx context.Context, siteID int) (*TXTRecordResponse, error) {
 	log.Printf("[INFO] Getting Incapsula TXT record(s) for siteID %d\n", siteID)
 
 	// GET records from Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%d/settings/general/additionalTxtRecords", c.config.BaseURLRev2, siteID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadTxtRecord)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadTxtRecord)
 	if err != nil {
-		return nil, fmt.Errorf("Error from Incapsula service when reading TXT record(s) for siteID: %d\n %s", siteID, err)
+		return nil, fmt.Errorf("Error from Incapsula service when reading TXT record(s) for siteID: %d\n %w", siteID, err)
 	}
 
 	// Read the body
@@ -54,7 +55,7 @@ func (c *Client) ReadTXTRecords(siteID int) (*TXTRecordResponse, error) {
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when reading TXT record(s) for siteID: %d\n%s", resp.StatusCode, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error status code %d from Incapsula service when reading TXT record(s) for siteID: %d\n%s", resp.StatusCode, siteID, string(responseBody))
 	}
 
 	// Parse the JSON
@@ -62,7 +63,7 @@ func (c *Client) ReadTXTRecords(siteID int) (*TXTRecordResponse, error) {
 	err = json.Unmarshal([]byte(responseBody), &txtRecords)
 
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Incap TXT record(s) JSON response for siteID: %d\n%s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Incap TXT record(s) JSON response for siteID: %d\n%w\nresponse: %s", siteID, err, string(responseBody))
 	}
 
 	response := []byte(responseBody)
@@ -74,7 +75,7 @@ func (c *Client) ReadTXTRecords(siteID int) (*TXTRecordResponse, error) {
 }
 
 // CreateTXTRecord creates TXT record(s) on the siteID.
-func (c *Client) CreateTXTRecord(siteID int, txtRecordValueOne string, txtRecordValueTwo string, txtRecordValueThree string, txtRecordValueFour string, txtRecordValueFive string) (*TXTResponse, error) {
+func (c *Client) CreateTXTRecord(ctx context.Context, siteID int, txtRecordValueOne string, txtRecordValueTwo string, txtRecordValueThree string, txtRecordValueFour string, txtRecordValueFive string) (*TXTResponse, error) {
 	log.Printf("[INFO] Create Incapsula TXT record(s) for siteID %d\n txt_record_value_one=%s, txt_record_value_two=%s, txt_record_value_three=%s, txt_record_value_four=%s, txt_record_value_five=%s", siteID, txtRecordValueOne, txtRecordValueTwo, txtRecordValueThree, txtRecordValueFour, txtRecordValueFive)
 
 	// Post request to Incapsula
@@ -88,9 +89,9 @@ func (c *Client) CreateTXTRecord(siteID int, txtRecordValueOne string, txtRecord
 
 	// Post request to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%d/settings/general/additionalTxtRecords", c.config.BaseURLRev2, siteID)
-	resp, err := c.PostFormWithHeaders(reqURL, values, CreateTxtRecord)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, CreateTxtRecord)
 	if err != nil {
-		return nil, fmt.Errorf("Error creating TXT record(s) for siteID %d: %s", siteID, err)
+		return nil, fmt.Errorf("Error creating TXT record(s) for siteID %d: %w", siteID, err)
 	}
 
 	// Read the body
@@ -102,7 +103,7 @@ func (c *Client) CreateTXTRecord(siteID int, txtRecordValueOne string, txtRecord
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when updating TXT record(s) for siteID: %d\n%s", resp.StatusCode, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error status code %d from Incapsula service when updating TXT record(s) for siteID: %d\n%s", resp.StatusCode, siteID, string(responseBody))
 	}
 
 	// Parse the JSON
@@ -110,14 +111,14 @@ func (c *Client) CreateTXTRecord(siteID int, txtRecordValueOne string, txtRecord
 	err = json.Unmarshal([]byte(responseBody), &txtResponse)
 
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Incap TXT response JSON response for siteID %d: %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Incap TXT response JSON response for siteID %d: %w\nresponse: %s", siteID, err, string(responseBody))
 	}
 
 	return &txtResponse, nil
 }
 
 // UpdateTXTRecord updates TXT record(s) on the siteID.
-func (c *Client) UpdateTXTRecord(siteID int, txtRecordValueOne string, txtRecordValueTwo string, txtRecordValueThree string, txtRecordValueFour string, txtRecordValueFive string) (*TXTResponse, error) {
+func (c *Client) UpdateTXTRecord(ctx context.Context, siteID int, txtRecordValueOne string, txtRecordValueTwo string, txtRecordValueThree string, txtRecordValueFour string, txtRecordValueFive string) (*TXTResponse, error) {
 	log.Printf("[INFO] Update Incapsula TXT record(s) for siteID %d\n txt_record_value_one=%s, txt_record_value_two=%s, txt_record_value_three=%s, txt_record_value_four=%s, txt_record_value_five=%s",
 		siteID, txtRecordValueOne, txtRecordValueTwo, txtRecordValueThree, txtRecordValueFour, txtRecordValueFive)
 
@@ -132,9 +133,9 @@ func (c *Client) UpdateTXTRecord(siteID int, txtRecordValueOne string, txtRecord
 
 	// Post request to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%d/settings/general/additionalTxtRecords", c.config.BaseURLRev2, siteID)
-	resp, err := c.PostFormWithHeaders(reqURL, values, UpdateTxtRecord)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, UpdateTxtRecord)
 	if err != nil {
-		return nil, fmt.Errorf("Error updating TXT record(s) for siteID: %d\n%s", siteID, err)
+		return nil, fmt.Errorf("Error updating TXT record(s) for siteID: %d\n%w", siteID, err)
 	}
 
 	// Read the body
@@ -146,7 +147,7 @@ func (c *Client) UpdateTXTRecord(siteID int, txtRecordValueOne string, txtRecord
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when updating TXT record(s) for siteID: %d\n%s", resp.StatusCode, siteID, string(responseBody))
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error status code %d from Incapsula service when updating TXT record(s) for siteID: %d\n%s", resp.StatusCode, siteID, string(responseBody))
 	}
 
 	// Parse the JSON
@@ -154,18 +155,18 @@ func (c *Client) UpdateTXTRecord(siteID int, txtRecordValueOne string, txtRecord
 	err = json.Unmarshal([]byte(responseBody), &txtResponse)
 
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing Incap TXT response JSON response for siteID %d: %s\nresponse: %s", siteID, err, string(responseBody))
+		return nil, fmt.Errorf("Error parsing Incap TXT response JSON response for siteID %d: %w\nresponse: %s", siteID, err, string(responseBody))
 	}
 
 	return &txtResponse, nil
 }
 
 // DeleteTXTRecord deletes TXT record(s) on the siteID.
-func (c *Client) DeleteTXTRecord(siteID int, recordNumber string) error {
+func (c *Client) DeleteTXTRecord(ctx context.Context, siteID int, recordNumber string) error {
 	log.Printf("[INFO] Delete Incapsula TXT record number %s for siteID %d\n ", recordNumber, siteID)
 
 	reqURL := fmt.Sprintf("%s/sites/%d/settings/general/additionalTxtRecords?record_number=%s", c.config.BaseURLRev2, siteID, recordNumber)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodDelete, reqURL, nil, DeleteTxtRecord)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, DeleteTxtRecord)
 	if err != nil {
 		log.Printf("[DEBUG] Error deleting TXT record for siteID %d: %s", siteID, err)
 		return fmt.Errorf("Error deleting TXT record for siteID %d", siteID)
@@ -182,19 +183,19 @@ func (c *Client) DeleteTXTRecord(siteID int, recordNumber string) error {
 	// Check the response code
 	// The response code of successful request is 400
 	if resp.StatusCode != 400 && !strings.Contains(string(response), "OK") {
-		return fmt.Errorf("Error status code %d from Incapsula service when deleting TXT record for siteID %d: %s", resp.StatusCode, siteID, string(responseBody))
+		return newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error status code %d from Incapsula service when deleting TXT record for siteID %d: %s", resp.StatusCode, siteID, string(responseBody))
 	}
 
 	return nil
 }
 
-func (c *Client) DeleteTXTRecordAll(siteID int) error {
+func (c *Client) DeleteTXTRecordAll(ctx context.Context, siteID int) error {
 	log.Printf("[INFO] Delete Incapsula All TXT records for siteID %d\n ", siteID)
 	reqURL := fmt.Sprintf("%s/sites/%d/settings/general/additionalTxtRecords/delete-all", c.config.BaseURLRev2, siteID)
 
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodDelete, reqURL, nil, DeleteTxtRecord)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, DeleteTxtRecord)
 	if err != nil {
-		return fmt.Errorf("Error deleting TXT records for siteID %d: %v", siteID, err)
+		return fmt.Errorf("Error deleting TXT records for siteID %d: %w", siteID, err)
 	}
 	// Read the body
 	defer resp.Body.Close()
@@ -206,7 +207,7 @@ func (c *Client) DeleteTXTRecordAll(siteID int) error {
 	response := []byte(responseBody)
 	// Check the response code
 	if resp.StatusCode != 400 && !strings.Contains(string(response), "OK") {
-		return fmt.Errorf("Error status code %d from Incapsula service when deleting all "+
+		return newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error status code %d from Incapsula service when deleting all "+
 			"TXT records for siteID %d: %s", resp.StatusCode, siteID, string(responseBody))
 	}
 
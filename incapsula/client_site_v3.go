@@ -1,9 +1,9 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"io/ioutil"
 	"log"
 	"net/http"
@@ -31,57 +31,31 @@ type SiteV3Response struct {
 }
 
 // AddV3Site adds a v3 site to be managed by Incapsula
-func (c *Client) AddV3Site(siteV3Request *SiteV3Request, accountId string) (*SiteV3Response, diag.Diagnostics) {
-	var diags diag.Diagnostics
+func (c *Client) AddV3Site(ctx context.Context, siteV3Request *SiteV3Request, accountId string) (*SiteV3Response, error) {
 	log.Printf("[INFO] adding v3 site to: %v ", siteV3Request)
 
 	updateUrl := getSiteV3Url(accountId, "", c.config.BaseURLAPI)
 	siteV3RequestJson, err := json.Marshal(siteV3Request)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to parse add site v3 dto",
-			Detail:   fmt.Sprintf("Failed to parse add site v3 dto account id %s, %s", accountId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to parse add site v3 dto account id %s, %w", accountId, err)
 	}
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodPost, updateUrl, siteV3RequestJson, nil, AddV3Site)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodPost, updateUrl, siteV3RequestJson, nil, AddV3Site)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Error response from Imperva service on add v3 site",
-			Detail:   fmt.Sprintf("Failed to add v3 site account id %s, %s", accountId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to add v3 site account id %s, %w", accountId, err)
 	}
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on add v3 site",
-			Detail:   fmt.Sprintf("Failed to read response for account id %s, %s", accountId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to read response for account id %s, %w", accountId, err)
 	}
-	log.Printf("[DEBUG] Imperva add v3 site JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Imperva add v3 site JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on add v3 site",
-			Detail:   fmt.Sprintf("Failed to read response for account id %s, got response status %d, %s", accountId, resp.StatusCode, string(responseBody)),
-		})
-		return nil, diags
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteV3Request.Id), "Failed to read response for account id %s, got response status %d, %s", accountId, resp.StatusCode, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 	var siteV3Response SiteV3Response
 	err = json.Unmarshal(responseBody, &siteV3Response)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to parse add site v3 response",
-			Detail:   fmt.Sprintf("Failed to parse add v3 site JSON response for account %s, %s", accountId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to parse add v3 site JSON response for account %s, %w", accountId, err)
 	}
 
 	log.Printf("[DEBUG] Imperva add v3 site ended successfully for account id: %s", accountId)
@@ -90,57 +64,31 @@ func (c *Client) AddV3Site(siteV3Request *SiteV3Request, accountId string) (*Sit
 }
 
 // UpdateV3Site update a v3 site currently managed by Incapsula
-func (c *Client) UpdateV3Site(siteV3Request *SiteV3Request, accountId string) (*SiteV3Response, diag.Diagnostics) {
-	var diags diag.Diagnostics
+func (c *Client) UpdateV3Site(ctx context.Context, siteV3Request *SiteV3Request, accountId string) (*SiteV3Response, error) {
 	log.Printf("[INFO] updating v3 site %d to: %v ", siteV3Request.Id, siteV3Request)
 
 	updateUrl := getSiteV3Url(accountId, "/"+strconv.Itoa(siteV3Request.Id), c.config.BaseURLAPI)
 	siteV3RequestJson, err := json.Marshal(siteV3Request)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to parse update site v3 dto",
-			Detail:   fmt.Sprintf("Failed to parse update site v3 dto account id %s, %s", accountId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to parse update site v3 dto account id %s, %w", accountId, err)
 	}
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodPatch, updateUrl, siteV3RequestJson, nil, UpdateV3Site)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodPatch, updateUrl, siteV3RequestJson, nil, UpdateV3Site)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Error response from Imperva service on update v3 site",
-			Detail:   fmt.Sprintf("Failed to update v3 site account id %s, %s", accountId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to update v3 site account id %s, %w", accountId, err)
 	}
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on update v3 site",
-			Detail:   fmt.Sprintf("Failed to read response for account id %s, %s", accountId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to read response for account id %s, %w", accountId, err)
 	}
-	log.Printf("[DEBUG] Imperva update v3 site JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Imperva update v3 site JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on update v3 site",
-			Detail:   fmt.Sprintf("Failed to read response for account id %s, got response status %d, %s", accountId, resp.StatusCode, string(responseBody)),
-		})
-		return nil, diags
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteV3Request.Id), "Failed to read response for account id %s, got response status %d, %s", accountId, resp.StatusCode, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 	var siteV3Response SiteV3Response
 	err = json.Unmarshal(responseBody, &siteV3Response)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to parse update site v3 response",
-			Detail:   fmt.Sprintf("Failed to parse update v3 site JSON response for account %s, %s", accountId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to parse update v3 site JSON response for account %s, %w", accountId, err)
 	}
 
 	log.Printf("[DEBUG] Imperva update v3 site ended successfully for account id: %s", accountId)
@@ -149,57 +97,31 @@ func (c *Client) UpdateV3Site(siteV3Request *SiteV3Request, accountId string) (*
 }
 
 // DeleteV3Site deletes a site currently managed by Incapsula
-func (c *Client) DeleteV3Site(siteV3Request *SiteV3Request, accountId string) (*SiteV3Response, diag.Diagnostics) {
-	var diags diag.Diagnostics
+func (c *Client) DeleteV3Site(ctx context.Context, siteV3Request *SiteV3Request, accountId string) (*SiteV3Response, error) {
 	log.Printf("[INFO] deleting v3 site %d to: %v ", siteV3Request.Id, siteV3Request)
 
 	updateUrl := getSiteV3Url(accountId, "/"+strconv.Itoa(siteV3Request.Id), c.config.BaseURLAPI)
 	siteV3RequestJson, err := json.Marshal(siteV3Request)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to parse delete site v3 dto",
-			Detail:   fmt.Sprintf("Failed to parse delete site v3 dto account id %s, %s", accountId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to parse delete site v3 dto account id %s, %w", accountId, err)
 	}
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodDelete, updateUrl, siteV3RequestJson, nil, UpdateV3Site)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodDelete, updateUrl, siteV3RequestJson, nil, UpdateV3Site)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Error response from Imperva service on delete v3 site",
-			Detail:   fmt.Sprintf("Failed to delete v3 site account id %s, %s", accountId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to delete v3 site account id %s, %w", accountId, err)
 	}
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on delete v3 site",
-			Detail:   fmt.Sprintf("Failed to read response for account id %s, %s", accountId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to read response for account id %s, %w", accountId, err)
 	}
-	log.Printf("[DEBUG] Imperva delete v3 site JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Imperva delete v3 site JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on delete v3 site",
-			Detail:   fmt.Sprintf("Failed to read response for account id %s, got response status %d, %s", accountId, resp.StatusCode, string(responseBody)),
-		})
-		return nil, diags
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteV3Request.Id), "Failed to read response for account id %s, got response status %d, %s", accountId, resp.StatusCode, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 	var siteV3Response SiteV3Response
 	err = json.Unmarshal(responseBody, &siteV3Response)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to parse delete site v3 response",
-			Detail:   fmt.Sprintf("Failed to parse delete v3 site JSON response for account %s, %s", accountId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to parse delete v3 site JSON response for account %s, %w", accountId, err)
 	}
 
 	log.Printf("[DEBUG] Imperva delete v3 site ended successfully for account id: %s", accountId)
@@ -208,57 +130,31 @@ func (c *Client) DeleteV3Site(siteV3Request *SiteV3Request, accountId string) (*
 }
 
 // GetV3Site deletes a site currently managed by Incapsula
-func (c *Client) GetV3Site(siteV3Request *SiteV3Request, accountId string) (*SiteV3Response, diag.Diagnostics) {
-	var diags diag.Diagnostics
+func (c *Client) GetV3Site(ctx context.Context, siteV3Request *SiteV3Request, accountId string) (*SiteV3Response, error) {
 	log.Printf("[INFO] getting v3 site %d to: %v ", siteV3Request.Id, siteV3Request)
 
 	updateUrl := getSiteV3Url(accountId, "/"+strconv.Itoa(siteV3Request.Id), c.config.BaseURLAPI)
 	siteV3RequestJson, err := json.Marshal(siteV3Request)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to parse get site v3 dto",
-			Detail:   fmt.Sprintf("Failed to parse get site v3 dto account id %s, %s", accountId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to parse get site v3 dto account id %s, %w", accountId, err)
 	}
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodGet, updateUrl, siteV3RequestJson, map[string]string{"caid": accountId}, UpdateV3Site)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodGet, updateUrl, siteV3RequestJson, map[string]string{"caid": accountId}, UpdateV3Site)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Error response from Imperva service on get v3 site",
-			Detail:   fmt.Sprintf("Failed to get v3 site account id %s, %s", accountId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to get v3 site account id %s, %w", accountId, err)
 	}
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on get v3 site",
-			Detail:   fmt.Sprintf("Failed to read response for account id %s, %s", accountId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to read response for account id %s, %w", accountId, err)
 	}
-	log.Printf("[DEBUG] Imperva get v3 site JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Imperva get v3 site JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on get v3 site",
-			Detail:   fmt.Sprintf("Failed to read response for account id %s, got response status %d, %s", accountId, resp.StatusCode, string(responseBody)),
-		})
-		return nil, diags
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteV3Request.Id), "Failed to read response for account id %s, got response status %d, %s", accountId, resp.StatusCode, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 	var siteV3Response SiteV3Response
 	err = json.Unmarshal(responseBody, &siteV3Response)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to parse get site v3 response",
-			Detail:   fmt.Sprintf("Failed to parse get v3 site JSON response for account %s, %s", accountId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to parse get v3 site JSON response for account %s, %w", accountId, err)
 	}
 
 	log.Printf("[DEBUG] Imperva get v3 site ended successfully for account id: %s", accountId)
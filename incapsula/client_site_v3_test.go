@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -29,10 +30,10 @@ func TestAddV3SiteWithNameAndType(t *testing.T) {
 	siteV3Request.Name = "de3affdrere.inddcapcwafteam.net"
 
 	siteV3Request.SiteType = "CLOUD_WAF"
-	siteV3Response, diags := client.AddV3Site(&siteV3Request, accountID)
+	siteV3Response, err := client.AddV3Site(context.Background(), &siteV3Request, accountID)
 
-	if diags != nil && diags.HasError() {
-		log.Printf("[ERROR] failed to add v3 site to Account ID: %s, %v\n", accountID, diags)
+	if err != nil {
+		log.Printf("[ERROR] failed to add v3 site to Account ID: %s, %v\n", accountID, err)
 	} else if siteV3Response.Errors != nil {
 		log.Printf("[ERROR] Failed to add v3 site to Account ID: %s, %v\n", accountID, siteV3Response.Errors[0].Detail)
 	}
@@ -86,10 +87,10 @@ func TestUpdateV3SiteWithName(t *testing.T) {
 	siteV3Request := SiteV3Request{}
 	siteV3Request.Name = "de3affdrere.inddcapcwafteam.net"
 	siteV3Request.Id = 111
-	siteV3Response, diags := client.UpdateV3Site(&siteV3Request, accountID)
+	siteV3Response, err := client.UpdateV3Site(context.Background(), &siteV3Request, accountID)
 
-	if diags != nil && diags.HasError() {
-		log.Printf("[ERROR] failed to add v3 site to Account ID: %s, %v\n", accountID, diags)
+	if err != nil {
+		log.Printf("[ERROR] failed to add v3 site to Account ID: %s, %v\n", accountID, err)
 	} else if siteV3Response.Errors != nil {
 		log.Printf("[ERROR] Failed to add v3 site to Account ID: %s, %v\n", accountID, siteV3Response.Errors[0].Detail)
 	}
@@ -118,9 +119,9 @@ func TestDeleteV3Site(t *testing.T) {
 	siteV3Request.SiteType = "CLOUD_WAF"
 	siteV3Request.Id = 1234
 
-	siteV3Response, diags := client.DeleteV3Site(&siteV3Request, accountID)
-	if diags != nil && diags.HasError() {
-		log.Printf("[ERROR] failed to delete v3 site of Account ID: %s, %v\n", accountID, diags)
+	siteV3Response, err := client.DeleteV3Site(context.Background(), &siteV3Request, accountID)
+	if err != nil {
+		log.Printf("[ERROR] failed to delete v3 site of Account ID: %s, %v\n", accountID, err)
 	} else if siteV3Response.Errors != nil {
 		log.Printf("[ERROR] Failed to delete v3 site of Account ID: %s, %v\n", accountID, siteV3Response.Errors[0].Detail)
 
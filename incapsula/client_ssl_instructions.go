@@ -1,9 +1,9 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"io/ioutil"
 	"log"
 	"net/http"
@@ -35,51 +35,30 @@ type SSLInstructionsResponse struct {
 }
 
 // GetSiteSSLInstructions request site ssl instructions
-func (c *Client) GetSiteSSLInstructions(siteId int) (*SSLInstructionsResponse, diag.Diagnostics) {
-	var diags diag.Diagnostics
+func (c *Client) GetSiteSSLInstructions(ctx context.Context, siteId int) (*SSLInstructionsResponse, error) {
 	log.Printf("[INFO] request site SSL instructions to: %d ", siteId)
 
 	url := fmt.Sprintf("%s%s", c.config.BaseURLAPI, endpointSSLInstructions)
 	var params = map[string]string{}
 	params["extSiteId"] = strconv.Itoa(siteId)
 	params["certificateType"] = "MANAGED"
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodGet, url, []byte("{}"), params, RequestSiteCert)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodGet, url, []byte("{}"), params, RequestSiteCert)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Error response from Imperva service on request site SSL instructions",
-			Detail:   fmt.Sprintf("Failed to request site SSL instructions site %d,%s", siteId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to request site SSL instructions site %d,%w", siteId, err)
 	}
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on request site SSL instructions",
-			Detail:   fmt.Sprintf("Failed to read response for site id %d, %s", siteId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to read response for site id %d, %w", siteId, err)
 	}
 	log.Printf("[DEBUG] Imperva request site SSL instructions JSON response: %s\n", string(responseBody))
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on request site SSL instructions",
-			Detail:   fmt.Sprintf("Failed to read response for site id %d, got response status %d, %s", siteId, resp.StatusCode, string(responseBody)),
-		})
-		return nil, diags
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(strconv.Itoa(siteId)), "Failed to read response for site id %d, got response status %d, %s", siteId, resp.StatusCode, string(responseBody))
 	}
 	var sSLInstructionsResponse SSLInstructionsResponse
 	err = json.Unmarshal(responseBody, &sSLInstructionsResponse)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to parse request site SSL instructions response",
-			Detail:   fmt.Sprintf("Failed to parse request site SSL instructions JSON response for site %d, %s", siteId, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to parse request site SSL instructions JSON response for site %d, %w", siteId, err)
 	}
 
 	log.Printf("[DEBUG] Imperva request site SSL instructions ended successfully for site id: %d", siteId)
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -25,7 +26,7 @@ func TestGetSslInstructions(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 
 	siteId := 123
-	sslInstructions, _ := client.GetSiteSSLInstructions(siteId)
+	sslInstructions, _ := client.GetSiteSSLInstructions(context.Background(), siteId)
 
 	checkSslInstructionsResponse(t, "Domain", "example.com", sslInstructions.Data[0].Domain)
 	checkSslInstructionsResponse(t, "ValidationMethod", "DNS", sslInstructions.Data[0].ValidationMethod)
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -41,7 +42,7 @@ type SubAccountPayload struct {
 }
 
 // AddSubAccount adds a SubAccount to be managed by Incapsula
-func (c *Client) AddSubAccount(subAccountPayload *SubAccountPayload) (*SubAccountAddResponse, error) {
+func (c *Client) AddSubAccount(ctx context.Context, subAccountPayload *SubAccountPayload) (*SubAccountAddResponse, error) {
 	log.Printf("[INFO] Adding Incapsula subaccount: %s\n", subAccountPayload.SubAccountName)
 
 	values := url.Values{
@@ -74,9 +75,9 @@ func (c *Client) AddSubAccount(subAccountPayload *SubAccountPayload) (*SubAccoun
 	log.Printf("[DEBUG] refID %s\n", subAccountPayload.RefID)
 	log.Printf("[DEBUG] values %s\n", values)
 
-	resp, err := c.PostFormWithHeaders(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSubAccountAdd), values, CreateSubAccount)
+	resp, err := c.PostFormWithHeaders(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSubAccountAdd), values, CreateSubAccount)
 	if err != nil {
-		return nil, fmt.Errorf("Error adding subaccount %s: %s", subAccountPayload.SubAccountName, err)
+		return nil, fmt.Errorf("Error adding subaccount %s: %w", subAccountPayload.SubAccountName, err)
 	}
 
 	// Read the body
@@ -90,19 +91,19 @@ func (c *Client) AddSubAccount(subAccountPayload *SubAccountPayload) (*SubAccoun
 	var subAccountAddResponse SubAccountAddResponse
 	err = json.Unmarshal([]byte(responseBody), &subAccountAddResponse)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing add subaccount JSON response for subaccount %s: %s", subAccountPayload.SubAccountName, err)
+		return nil, fmt.Errorf("Error parsing add subaccount JSON response for subaccount %s: %w", subAccountPayload.SubAccountName, err)
 	}
 
 	// Look at the response status code from Incapsula
 	if subAccountAddResponse.Res != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when adding subaccount %s: %s", subAccountPayload.SubAccountName, string(responseBody))
+		return nil, newAPIError(resp, responseBody, nil, "Error from Incapsula service when adding subaccount %s: %s", subAccountPayload.SubAccountName, string(responseBody))
 	}
 
 	return &subAccountAddResponse, nil
 }
 
 // DeleteSubAccount deletes a SubAcccount currently managed by Incapsula
-func (c *Client) DeleteSubAccount(subAccountID int) error {
+func (c *Client) DeleteSubAccount(ctx context.Context, subAccountID int) error {
 	// Specifically shaded this struct, no need to share across funcs or export
 	// We only care about the response code and possibly the message
 	type SubAccountDeleteResponse struct {
@@ -113,11 +114,11 @@ func (c *Client) DeleteSubAccount(subAccountID int) error {
 	log.Printf("[INFO] Deleting Incapsula subaccount id: %d\n", subAccountID)
 
 	// Post form to Incapsula
-	resp, err := c.PostFormWithHeaders(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSubAccountDelete), url.Values{
+	resp, err := c.PostFormWithHeaders(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSubAccountDelete), url.Values{
 		"sub_account_id": {strconv.Itoa(subAccountID)},
 	}, DeleteSubAccount)
 	if err != nil {
-		return fmt.Errorf("Error deleting subaccount id: %d: %s", subAccountID, err)
+		return fmt.Errorf("Error deleting subaccount id: %d: %w", subAccountID, err)
 	}
 
 	// Read the body
@@ -131,12 +132,12 @@ func (c *Client) DeleteSubAccount(subAccountID int) error {
 	var subaccountDeleteResponse SubAccountDeleteResponse
 	err = json.Unmarshal([]byte(responseBody), &subaccountDeleteResponse)
 	if err != nil {
-		return fmt.Errorf("Error parsing delete account JSON response for subaccount id: %d: %s", subAccountID, err)
+		return fmt.Errorf("Error parsing delete account JSON response for subaccount id: %d: %w", subAccountID, err)
 	}
 
 	// Look at the response status code from Incapsula
 	if subaccountDeleteResponse.Res != 0 {
-		return fmt.Errorf("Error from Incapsula service when deleting subaccount id: %d: %s", subAccountID, string(responseBody))
+		return newAPIError(resp, responseBody, apiResourceIDs(subAccountID), "Error from Incapsula service when deleting subaccount id: %d: %s", subAccountID, string(responseBody))
 	}
 
 	return nil
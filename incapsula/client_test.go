@@ -1,6 +1,8 @@
 package incapsula
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -17,7 +19,7 @@ import (
 func TestClientVerifyBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
-	_, err := client.Verify()
+	_, err := client.Verify(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -37,7 +39,7 @@ func TestClientVerifyBadJSON(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	_, err := client.Verify()
+	_, err := client.Verify(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -57,7 +59,7 @@ func TestClientVerifyInvalidAccount(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	_, err := client.Verify()
+	_, err := client.Verify(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -77,7 +79,7 @@ func TestClientVerifyValidAccount(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	_, err := client.Verify()
+	_, err := client.Verify(context.Background())
 	if err != nil {
 		t.Errorf("Should not have received an error, got: %s", err)
 	}
@@ -87,18 +89,12 @@ func TestClientVerifyValidAccount(t *testing.T) {
 // executeRequest retry Tests
 // //////////////////////////////////////////////////////////////
 
-// withShortRetries overrides package-level retry vars for fast tests and returns a restore function.
+// withShortRetries overrides the default retry policy for fast tests and returns a restore function.
 func withShortRetries() func() {
-	origMax := maxRetries
-	origMin := retryWaitMinSeconds
-	origMaxWait := retryWaitMaxSeconds
-	maxRetries = 1
-	retryWaitMinSeconds = 0
-	retryWaitMaxSeconds = 0
+	orig := defaultRetryPolicy
+	defaultRetryPolicy = RetryPolicy{MaxAttempts: 2}
 	return func() {
-		maxRetries = origMax
-		retryWaitMinSeconds = origMin
-		retryWaitMaxSeconds = origMaxWait
+		defaultRetryPolicy = orig
 	}
 }
 
@@ -127,7 +123,7 @@ func TestExecuteRequestRetriesExhaustedReturnsResponse(t *testing.T) {
 
 	client := newTestClient(server.URL)
 
-	req, err := PrepareJsonRequest(http.MethodGet, server.URL, nil)
+	req, err := PrepareJsonRequest(context.Background(), http.MethodGet, server.URL, nil)
 	if err != nil {
 		t.Fatalf("unexpected error preparing request: %s", err)
 	}
@@ -168,7 +164,7 @@ func TestExecuteRequestRetriesThenSucceeds(t *testing.T) {
 
 	client := newTestClient(server.URL)
 
-	req, err := PrepareJsonRequest(http.MethodGet, server.URL, nil)
+	req, err := PrepareJsonRequest(context.Background(), http.MethodGet, server.URL, nil)
 	if err != nil {
 		t.Fatalf("unexpected error preparing request: %s", err)
 	}
@@ -206,7 +202,7 @@ func TestGetPerformanceSettingsNoPanicOnPersistent502(t *testing.T) {
 	client := NewClient(config)
 	client.httpClient = &http.Client{}
 
-	performanceSettings, err := client.GetPerformanceSettings("76050311")
+	performanceSettings, err := client.GetPerformanceSettings(context.Background(), "76050311")
 	if err == nil {
 		t.Errorf("Should have received an error on persistent 502 instead of a nil response")
 	}
@@ -235,7 +231,7 @@ func TestRetryOn503ReadOperation(t *testing.T) {
 	defer server.Close()
 
 	client := newTestClient(server.URL)
-	req, _ := PrepareJsonRequest(http.MethodGet, server.URL, nil)
+	req, _ := PrepareJsonRequest(context.Background(), http.MethodGet, server.URL, nil)
 	SetHeaders(client, req, contentTypeApplicationJson, ReadSite, nil)
 
 	resp, err := client.executeRequest(req)
@@ -267,7 +263,7 @@ func TestRetryOn504ReadOperation(t *testing.T) {
 	defer server.Close()
 
 	client := newTestClient(server.URL)
-	req, _ := PrepareJsonRequest(http.MethodGet, server.URL, nil)
+	req, _ := PrepareJsonRequest(context.Background(), http.MethodGet, server.URL, nil)
 	SetHeaders(client, req, contentTypeApplicationJson, ReadSite, nil)
 
 	resp, err := client.executeRequest(req)
@@ -299,7 +295,7 @@ func TestRetryOn429(t *testing.T) {
 	defer server.Close()
 
 	client := newTestClient(server.URL)
-	req, _ := PrepareJsonRequest(http.MethodPost, server.URL, []byte(`{"data":"test"}`))
+	req, _ := PrepareJsonRequest(context.Background(), http.MethodPost, server.URL, []byte(`{"data":"test"}`))
 	SetHeaders(client, req, contentTypeApplicationJson, CreateSite, nil)
 
 	resp, err := client.executeRequest(req)
@@ -334,7 +330,7 @@ func TestRetryOnHTMLResponse(t *testing.T) {
 	defer server.Close()
 
 	client := newTestClient(server.URL)
-	req, _ := PrepareJsonRequest(http.MethodGet, server.URL, nil)
+	req, _ := PrepareJsonRequest(context.Background(), http.MethodGet, server.URL, nil)
 	SetHeaders(client, req, contentTypeApplicationJson, ReadSite, nil)
 
 	resp, err := client.executeRequest(req)
@@ -369,7 +365,7 @@ func TestRetryWriteOnHTMLGatewayError(t *testing.T) {
 	defer server.Close()
 
 	client := newTestClient(server.URL)
-	req, _ := PrepareJsonRequest(http.MethodPost, server.URL, []byte(`{"domain":"example.com"}`))
+	req, _ := PrepareJsonRequest(context.Background(), http.MethodPost, server.URL, []byte(`{"domain":"example.com"}`))
 	SetHeaders(client, req, contentTypeApplicationJson, CreateSite, nil)
 
 	resp, err := client.executeRequest(req)
@@ -399,7 +395,7 @@ func TestNoRetryWriteOn502WithJSON(t *testing.T) {
 	defer server.Close()
 
 	client := newTestClient(server.URL)
-	req, _ := PrepareJsonRequest(http.MethodPost, server.URL, []byte(`{"domain":"example.com"}`))
+	req, _ := PrepareJsonRequest(context.Background(), http.MethodPost, server.URL, []byte(`{"domain":"example.com"}`))
 	SetHeaders(client, req, contentTypeApplicationJson, CreateSite, nil)
 
 	resp, err := client.executeRequest(req)
@@ -429,7 +425,7 @@ func TestNoRetryOn400(t *testing.T) {
 	defer server.Close()
 
 	client := newTestClient(server.URL)
-	req, _ := PrepareJsonRequest(http.MethodGet, server.URL, nil)
+	req, _ := PrepareJsonRequest(context.Background(), http.MethodGet, server.URL, nil)
 	SetHeaders(client, req, contentTypeApplicationJson, ReadSite, nil)
 
 	resp, err := client.executeRequest(req)
@@ -465,7 +461,7 @@ func TestRetryWithRequestBodyIntact(t *testing.T) {
 
 	client := newTestClient(server.URL)
 	payload := []byte(`{"domain":"example.com","account_id":12345}`)
-	req, _ := PrepareJsonRequest(http.MethodPost, server.URL, payload)
+	req, _ := PrepareJsonRequest(context.Background(), http.MethodPost, server.URL, payload)
 	SetHeaders(client, req, contentTypeApplicationJson, CreateSite, nil)
 
 	resp, err := client.executeRequest(req)
@@ -495,7 +491,7 @@ func TestRetryOnTransientNetworkError(t *testing.T) {
 	defer server.Close()
 
 	client := newTestClient(server.URL)
-	req, _ := PrepareJsonRequest(http.MethodGet, server.URL, nil)
+	req, _ := PrepareJsonRequest(context.Background(), http.MethodGet, server.URL, nil)
 	SetHeaders(client, req, contentTypeApplicationJson, ReadSite, nil)
 
 	resp, err := client.executeRequest(req)
@@ -518,7 +514,7 @@ func TestRetryOnNetworkErrorExhaustedReturnsError(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "http://192.0.2.1:1", BaseURLRev2: "http://192.0.2.1:1", BaseURLAPI: "http://192.0.2.1:1"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 50}}
 
-	req, _ := PrepareJsonRequest(http.MethodGet, "http://192.0.2.1:1/test", nil)
+	req, _ := PrepareJsonRequest(context.Background(), http.MethodGet, "http://192.0.2.1:1/test", nil)
 	SetHeaders(client, req, contentTypeApplicationJson, ReadSite, nil)
 
 	_, err := client.executeRequest(req)
@@ -548,7 +544,7 @@ func TestVerifyRetriesOnTransientHTMLResponse(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := NewClient(config)
 
-	result, err := client.Verify()
+	result, err := client.Verify(context.Background())
 	if err != nil {
 		t.Fatalf("Verify should have succeeded after retry, got: %s", err)
 	}
@@ -576,7 +572,7 @@ func TestNormalJSONResponseBodyNotCorrupted(t *testing.T) {
 	defer server.Close()
 
 	client := newTestClient(server.URL)
-	req, _ := PrepareJsonRequest(http.MethodGet, server.URL, nil)
+	req, _ := PrepareJsonRequest(context.Background(), http.MethodGet, server.URL, nil)
 	SetHeaders(client, req, contentTypeApplicationJson, ReadSite, nil)
 
 	resp, err := client.executeRequest(req)
@@ -589,3 +585,279 @@ func TestNormalJSONResponseBodyNotCorrupted(t *testing.T) {
 		t.Errorf("Response body was corrupted.\nExpected: %s\nGot: %s", expectedBody, string(body))
 	}
 }
+
+// //////////////////////////////////////////////////////////////
+// Context cancellation Tests
+// //////////////////////////////////////////////////////////////
+
+// TestExecuteRequestCancelledDuringBackoff verifies that cancelling the request
+// context ends the retry backoff early instead of sleeping it out.
+func TestExecuteRequestCancelledDuringBackoff(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.config.Retry = &RetryPolicy{MaxAttempts: 5, MinBackoff: 30 * time.Second, MaxBackoff: 30 * time.Second}
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetWithHeaders(ctx, server.URL, nil, ReadSite)
+	if err == nil {
+		t.Fatal("Expected an error once the context deadline passed")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got: %s", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Backoff was not interrupted by the context, took %s", elapsed)
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("Expected 1 call before cancellation, got %d", atomic.LoadInt32(&calls))
+	}
+}
+
+// TestExecuteRequestCancelledContextNotRetried verifies that a request whose
+// context is already cancelled fails immediately without any retries.
+func TestExecuteRequestCancelledContextNotRetried(t *testing.T) {
+	restore := withShortRetries()
+	defer restore()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.DoJsonRequestWithHeaders(ctx, http.MethodGet, server.URL, nil, ReadSite)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got: %v", err)
+	}
+	if atomic.LoadInt32(&calls) != 0 {
+		t.Errorf("Expected no calls with a cancelled context, got %d", atomic.LoadInt32(&calls))
+	}
+}
+
+// TestRetryHonoursRetryAfterHeader verifies that a 429 carrying Retry-After
+// delays the next attempt by the requested amount rather than the default backoff.
+func TestRetryHonoursRetryAfterHeader(t *testing.T) {
+	restore := withShortRetries()
+	defer restore()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			rw.Header().Set("Retry-After", "1")
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		rw.WriteHeader(http.StatusOK)
+		rw.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.config.Retry = &RetryPolicy{MaxAttempts: 2, MaxBackoff: 5 * time.Second}
+	req, _ := PrepareJsonRequest(context.Background(), http.MethodGet, server.URL, nil)
+	SetHeaders(client, req, contentTypeApplicationJson, ReadSite, nil)
+
+	start := time.Now()
+	resp, err := client.executeRequest(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Expected the retry to wait for Retry-After (1s), took only %s", elapsed)
+	}
+	if atomic.LoadInt32(&calls) != 2 {
+		t.Errorf("Expected 2 calls (1 throttled + 1 success), got %d", atomic.LoadInt32(&calls))
+	}
+}
+
+// TestRetryAfterCappedByMaxBackoff verifies that a Retry-After longer than the
+// policy's MaxBackoff only delays the next attempt (and the limiter) by MaxBackoff.
+func TestRetryAfterCappedByMaxBackoff(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			rw.Header().Set("Retry-After", "3600")
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		rw.WriteHeader(http.StatusOK)
+		rw.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.config.Retry = &RetryPolicy{MaxAttempts: 2, MaxBackoff: 100 * time.Millisecond}
+	client.limiter = newRequestLimiter(0, 1)
+	req, _ := PrepareJsonRequest(context.Background(), http.MethodGet, server.URL, nil)
+	SetHeaders(client, req, contentTypeApplicationJson, ReadSite, nil)
+
+	start := time.Now()
+	resp, err := client.executeRequest(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the Retry-After to be capped at MaxBackoff (100ms), took %s", elapsed)
+	}
+	if atomic.LoadInt32(&calls) != 2 {
+		t.Errorf("Expected 2 calls (1 throttled + 1 success), got %d", atomic.LoadInt32(&calls))
+	}
+}
+
+// //////////////////////////////////////////////////////////////
+// Retry policy Tests
+// //////////////////////////////////////////////////////////////
+
+func TestRetryPolicyIsPerClient(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	noRetryClient := newTestClient(server.URL)
+	noRetryClient.config.Retry = &RetryPolicy{MaxAttempts: 1}
+	threeAttemptsClient := newTestClient(server.URL)
+	threeAttemptsClient.config.Retry = &RetryPolicy{MaxAttempts: 3}
+
+	resp, err := noRetryClient.GetWithHeaders(context.Background(), server.URL, nil, ReadSite)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	resp.Body.Close()
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("Expected 1 call with max_attempts = 1, got %d", atomic.LoadInt32(&calls))
+	}
+
+	atomic.StoreInt32(&calls, 0)
+	resp, err = threeAttemptsClient.GetWithHeaders(context.Background(), server.URL, nil, ReadSite)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	resp.Body.Close()
+	if atomic.LoadInt32(&calls) != 3 {
+		t.Errorf("Expected 3 calls with max_attempts = 3, got %d", atomic.LoadInt32(&calls))
+	}
+}
+
+func TestRetryPolicyExtraRetryableStatusCodes(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			rw.WriteHeader(http.StatusConflict)
+			return
+		}
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.config.Retry = &RetryPolicy{MaxAttempts: 2, RetryableStatusCodes: []int{http.StatusConflict}}
+
+	resp, err := client.GetWithHeaders(context.Background(), server.URL, nil, ReadSite)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected 200 after retrying the 409, got %d", resp.StatusCode)
+	}
+	if atomic.LoadInt32(&calls) != 2 {
+		t.Errorf("Expected 2 calls (1 conflict + 1 success), got %d", atomic.LoadInt32(&calls))
+	}
+}
+
+func TestRetryPolicyNonIdempotentWriteOn502WithJSON(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			rw.Header().Set("Content-Type", "application/json")
+			rw.WriteHeader(http.StatusBadGateway)
+			rw.Write([]byte(`{"error":"backend processing failed"}`))
+			return
+		}
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.config.Retry = &RetryPolicy{MaxAttempts: 2, RetryNonIdempotent: true}
+
+	resp, err := client.DoJsonRequestWithHeaders(context.Background(), http.MethodPost, server.URL, []byte(`{"domain":"example.com"}`), CreateSite)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected 200 after retrying the write, got %d", resp.StatusCode)
+	}
+	if atomic.LoadInt32(&calls) != 2 {
+		t.Errorf("Expected 2 calls (write retried on opt-in), got %d", atomic.LoadInt32(&calls))
+	}
+}
+
+func TestReadOnlyRefusesWrites(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+		rw.Write([]byte(`{"res":0}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLRev3: server.URL, BaseURLAPI: server.URL, ReadOnly: true}
+	client := NewClient(config)
+
+	allowed := []struct {
+		method    string
+		operation string
+	}{
+		{http.MethodGet, ReadIncapRule},
+		{http.MethodPost, ReadSite},
+		{http.MethodPost, VerifyAccount},
+	}
+	for _, request := range allowed {
+		resp, err := client.DoJsonRequestWithHeaders(context.Background(), request.method, server.URL+"/sites/status", nil, request.operation)
+		if err != nil {
+			t.Errorf("Expected %s %s to be allowed in read-only mode, got: %s", request.method, request.operation, err)
+			continue
+		}
+		resp.Body.Close()
+	}
+
+	refused := []struct {
+		method    string
+		operation string
+	}{
+		{http.MethodPost, CreateSite},
+		{http.MethodPut, UpdateIncapRule},
+		{http.MethodPatch, UpdateSite},
+		{http.MethodDelete, DeleteIncapRule},
+		{http.MethodPost, UpdateATOSiteMitigationConfigurationOperation},
+	}
+	for _, request := range refused {
+		_, err := client.DoJsonRequestWithHeaders(context.Background(), request.method, server.URL+"/sites/42", nil, request.operation)
+		if !errors.Is(err, ErrReadOnly) {
+			t.Errorf("Expected %s %s to be refused in read-only mode, got: %v", request.method, request.operation, err)
+		}
+	}
+
+	if calls != len(allowed) {
+		t.Errorf("Expected %d API calls, got %d", len(allowed), calls)
+	}
+}
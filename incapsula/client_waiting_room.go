@@ -1,13 +1,12 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
 type ThresholdSettings struct {
@@ -41,36 +40,25 @@ type WaitingRoomDTOResponse struct {
 	Errors []APIErrors      `json:"errors"`
 }
 
-func (c *Client) CreateWaitingRoom(accountId string, siteID string, waitingRoom *WaitingRoomDTO) (*WaitingRoomDTOResponse, diag.Diagnostics) {
-	var diags diag.Diagnostics
+func (c *Client) CreateWaitingRoom(ctx context.Context, accountId string, siteID string, waitingRoom *WaitingRoomDTO) (*WaitingRoomDTOResponse, error) {
 	log.Printf("[INFO] Creating Waiting Room for Site ID %s\n", siteID)
 
 	waitingRoomJSON, err := json.Marshal(waitingRoom)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failure generating Waiting Room create request",
-			Detail:   fmt.Sprintf("Failed to JSON marshal WaitingRoom: %s", err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to JSON marshal WaitingRoom: %w", err)
 	}
 
 	// Dump JSON
-	log.Printf("[DEBUG] Waiting Room payload: %s\n", string(waitingRoomJSON))
+	log.Printf("[DEBUG] Waiting Room payload: %s\n", redactedBody(contentTypeApplicationJson, waitingRoomJSON))
 
 	// Post form to Incapsula
 	reqURL := fmt.Sprintf("%s/waiting-room-settings/v3/sites/%s/waiting-rooms", c.config.BaseURLAPI, siteID)
 	if accountId != "" {
 		reqURL += "?caid=" + accountId
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, waitingRoomJSON, CreateWaitingRoom)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, waitingRoomJSON, CreateWaitingRoom)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failure Creating Waiting Room",
-			Detail:   fmt.Sprintf("Error from Incapsula service when creating Waiting Room for Site ID %s: %s", siteID, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Error from Incapsula service when creating Waiting Room for Site ID %s: %w", siteID, err)
 	}
 
 	// Read the body
@@ -78,34 +66,24 @@ func (c *Client) CreateWaitingRoom(accountId string, siteID string, waitingRoom
 	responseBody, err := ioutil.ReadAll(resp.Body)
 
 	// Dump JSON
-	log.Printf("[DEBUG] Incapsula Create Waiting Room JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Incapsula Create Waiting Room JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 
 	// Check the response code
 	if resp.StatusCode != 201 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failure Creating Waiting Room",
-			Detail:   fmt.Sprintf("Error status code %d from Incapsula service when creating Waiting Room for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody)),
-		})
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID), "Error status code %d from Incapsula service when creating Waiting Room for Site ID %s: %s", resp.StatusCode, siteID, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 
 	// Parse the JSON
 	var newWaitingRoom WaitingRoomDTOResponse
 	err = json.Unmarshal([]byte(responseBody), &newWaitingRoom)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failure parsing Waiting Room create response",
-			Detail:   fmt.Sprintf("Error parsing Waiting Room JSON response for Site ID %s: %s\nresponse: %s", siteID, err.Error(), string(responseBody)),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Error parsing Waiting Room JSON response for Site ID %s: %w\nresponse: %s", siteID, err, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 
-	return &newWaitingRoom, diags
+	return &newWaitingRoom, nil
 }
 
-func (c *Client) ReadWaitingRoom(accountId string, siteID string, waitingRoomID int64) (*WaitingRoomDTOResponse, diag.Diagnostics) {
-	var diags diag.Diagnostics
+func (c *Client) ReadWaitingRoom(ctx context.Context, accountId string, siteID string, waitingRoomID int64) (*WaitingRoomDTOResponse, error) {
 	log.Printf("[INFO] Getting Incapsula Waiting Room %d for Site ID %s\n", waitingRoomID, siteID)
 
 	// Post form to Incapsula
@@ -113,14 +91,9 @@ func (c *Client) ReadWaitingRoom(accountId string, siteID string, waitingRoomID
 	if accountId != "" {
 		reqURL += "?caid=" + accountId
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadWaitingRoom)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadWaitingRoom)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failure sending Waiting Room read request",
-			Detail:   fmt.Sprintf("Error from Incapsula service when reading Waiting Room %d for Site ID %s: %s", waitingRoomID, siteID, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Error from Incapsula service when reading Waiting Room %d for Site ID %s: %w", waitingRoomID, siteID, err)
 	}
 
 	// Read the body
@@ -128,44 +101,29 @@ func (c *Client) ReadWaitingRoom(accountId string, siteID string, waitingRoomID
 	responseBody, err := ioutil.ReadAll(resp.Body)
 
 	// Dump JSON
-	log.Printf("[DEBUG] Incapsula Read Waiting Room JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Incapsula Read Waiting Room JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failure Reading Waiting Room",
-			Detail:   fmt.Sprintf("Error status code %d from Incapsula service when reading Waiting Room %d for Site ID %s: %s", resp.StatusCode, waitingRoomID, siteID, string(responseBody)),
-		})
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID, waitingRoomID), "Error status code %d from Incapsula service when reading Waiting Room %d for Site ID %s: %s", resp.StatusCode, waitingRoomID, siteID, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 
 	// Parse the JSON
 	var waitingRoom WaitingRoomDTOResponse
 	err = json.Unmarshal([]byte(responseBody), &waitingRoom)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failure parsing Waiting Room read response",
-			Detail:   fmt.Sprintf("Error parsing Waiting Room %d JSON response for Site ID %s: %s\nresponse: %s", waitingRoomID, siteID, err.Error(), string(responseBody)),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Error parsing Waiting Room %d JSON response for Site ID %s: %w\nresponse: %s", waitingRoomID, siteID, err, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 
-	return &waitingRoom, diags
+	return &waitingRoom, nil
 }
 
-func (c *Client) UpdateWaitingRoom(accountId string, siteID string, waitingRoomID int64, waitingRoom *WaitingRoomDTO) (*WaitingRoomDTOResponse, diag.Diagnostics) {
-	var diags diag.Diagnostics
+func (c *Client) UpdateWaitingRoom(ctx context.Context, accountId string, siteID string, waitingRoomID int64, waitingRoom *WaitingRoomDTO) (*WaitingRoomDTOResponse, error) {
 	log.Printf("[INFO] Updating Incapsula Waiting Room %d for Site ID %s\n", waitingRoomID, siteID)
 
 	waitingRoomJSON, err := json.Marshal(waitingRoom)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failure generating Waiting Room update request",
-			Detail:   fmt.Sprintf("Failed to JSON marshal WaitingRoom: %s", err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Failed to JSON marshal WaitingRoom: %w", err)
 	}
 
 	// Put request to Incapsula
@@ -173,14 +131,9 @@ func (c *Client) UpdateWaitingRoom(accountId string, siteID string, waitingRoomI
 	if accountId != "" {
 		reqURL += "?caid=" + accountId
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPut, reqURL, waitingRoomJSON, UpdateWaitingRoom)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPut, reqURL, waitingRoomJSON, UpdateWaitingRoom)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failure Updating Waiting Room",
-			Detail:   fmt.Sprintf("Error from Incapsula service when updating Waiting Room %d for Site ID %s: %s", waitingRoomID, siteID, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Error from Incapsula service when updating Waiting Room %d for Site ID %s: %w", waitingRoomID, siteID, err)
 	}
 
 	// Read the body
@@ -188,34 +141,24 @@ func (c *Client) UpdateWaitingRoom(accountId string, siteID string, waitingRoomI
 	responseBody, err := ioutil.ReadAll(resp.Body)
 
 	// Dump JSON
-	log.Printf("[DEBUG] Incapsula Update Waiting Room JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Incapsula Update Waiting Room JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failure Updating Waiting Room",
-			Detail:   fmt.Sprintf("Error status code %d from Incapsula service when updating Waiting Room %d for Site ID %s: %s", resp.StatusCode, waitingRoomID, siteID, string(responseBody)),
-		})
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID, waitingRoomID), "Error status code %d from Incapsula service when updating Waiting Room %d for Site ID %s: %s", resp.StatusCode, waitingRoomID, siteID, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 
 	// Parse the JSON
 	var updatedWaitingRoom WaitingRoomDTOResponse
 	err = json.Unmarshal([]byte(responseBody), &updatedWaitingRoom)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failure parsing Waiting Room create response",
-			Detail:   fmt.Sprintf("Error parsing Waiting Room %d JSON response for Site ID %s: %s\nresponse: %s", waitingRoomID, siteID, err.Error(), string(responseBody)),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Error parsing Waiting Room %d JSON response for Site ID %s: %w\nresponse: %s", waitingRoomID, siteID, err, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 
-	return &updatedWaitingRoom, diags
+	return &updatedWaitingRoom, nil
 }
 
-func (c *Client) DeleteWaitingRoom(accountId string, siteID string, waitingRoomID int64) (*WaitingRoomDTOResponse, diag.Diagnostics) {
-	var diags diag.Diagnostics
+func (c *Client) DeleteWaitingRoom(ctx context.Context, accountId string, siteID string, waitingRoomID int64) (*WaitingRoomDTOResponse, error) {
 	log.Printf("[INFO] Deleting Incapsula Waiting Room %d for Site ID %s\n", waitingRoomID, siteID)
 
 	// Delete request to Incapsula
@@ -223,14 +166,9 @@ func (c *Client) DeleteWaitingRoom(accountId string, siteID string, waitingRoomI
 	if accountId != "" {
 		reqURL += "?caid=" + accountId
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodDelete, reqURL, nil, DeleteWaitingRoom)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, DeleteWaitingRoom)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failure sending Waiting Room delete request",
-			Detail:   fmt.Sprintf("Error from Incapsula service when deleting Waiting Room %d for Site ID %s: %s", waitingRoomID, siteID, err.Error()),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Error from Incapsula service when deleting Waiting Room %d for Site ID %s: %w", waitingRoomID, siteID, err)
 	}
 
 	// Read the body
@@ -238,28 +176,19 @@ func (c *Client) DeleteWaitingRoom(accountId string, siteID string, waitingRoomI
 	responseBody, err := ioutil.ReadAll(resp.Body)
 
 	// Dump JSON
-	log.Printf("[DEBUG] Incapsula Delete Waiting Room JSON response: %s\n", string(responseBody))
+	log.Printf("[DEBUG] Incapsula Delete Waiting Room JSON response: %s\n", redactedBody(contentTypeApplicationJson, responseBody))
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failure Deleting Waiting Room",
-			Detail:   fmt.Sprintf("Error status code %d from Incapsula service when deleting Waiting Room %d for Site ID %s: %s", resp.StatusCode, waitingRoomID, siteID, string(responseBody)),
-		})
+		return nil, newAPIError(resp, responseBody, apiResourceIDs(siteID, waitingRoomID), "Error status code %d from Incapsula service when deleting Waiting Room %d for Site ID %s: %s", resp.StatusCode, waitingRoomID, siteID, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 
 	// Parse the JSON
 	var waitingRoom WaitingRoomDTOResponse
 	err = json.Unmarshal([]byte(responseBody), &waitingRoom)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failure parsing Waiting Room delete response",
-			Detail:   fmt.Sprintf("Error parsing Waiting Room %d JSON response for Site ID %s: %s\nresponse: %s", waitingRoomID, siteID, err.Error(), string(responseBody)),
-		})
-		return nil, diags
+		return nil, fmt.Errorf("Error parsing Waiting Room %d JSON response for Site ID %s: %w\nresponse: %s", waitingRoomID, siteID, err, redactedBody(contentTypeApplicationJson, responseBody))
 	}
 
-	return &waitingRoom, diags
+	return &waitingRoom, nil
 }
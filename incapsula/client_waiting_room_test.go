@@ -1,6 +1,8 @@
 package incapsula
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -28,12 +30,12 @@ func TestClientCreateWaitingRoomBadConnection(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	createWaitingRoomResponse, diags := client.CreateWaitingRoom(accountId, siteID, &waitingRoom)
-	if diags == nil || len(diags) == 0 {
+	createWaitingRoomResponse, err := client.CreateWaitingRoom(context.Background(), accountId, siteID, &waitingRoom)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error from Incapsula service when creating Waiting Room for Site ID %s", siteID)) {
-		t.Errorf("Should have received a client error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error from Incapsula service when creating Waiting Room for Site ID %s", siteID)) {
+		t.Errorf("Should have received a client error, got: %s", err)
 	}
 	if createWaitingRoomResponse != nil {
 		t.Errorf("Should have received a nil createWaitingRoomResponse instance")
@@ -71,12 +73,12 @@ func TestClientCreateWaitingRoomBadJSON(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	createWaitingRoomResponse, diags := client.CreateWaitingRoom(accountId, siteID, &waitingRoom)
-	if diags == nil || len(diags) == 0 {
+	createWaitingRoomResponse, err := client.CreateWaitingRoom(context.Background(), accountId, siteID, &waitingRoom)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error parsing Waiting Room JSON response for Site ID %s", siteID)) {
-		t.Errorf("Should have received a JSON parse error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error parsing Waiting Room JSON response for Site ID %s", siteID)) {
+		t.Errorf("Should have received a JSON parse error, got: %s", err)
 	}
 	if createWaitingRoomResponse != nil {
 		t.Errorf("Should have received a nil createWaitingRoomResponse instance")
@@ -113,12 +115,12 @@ func TestClientCreateWaitingRoomBadStatusCodeWithEmptyBody(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	createWaitingRoomResponse, diags := client.CreateWaitingRoom(accountId, siteID, &waitingRoom)
-	if diags == nil || len(diags) == 0 {
+	createWaitingRoomResponse, err := client.CreateWaitingRoom(context.Background(), accountId, siteID, &waitingRoom)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error status code %d from Incapsula service when creating Waiting Room for Site ID %s", 401, siteID)) {
-		t.Errorf("Should have received a JSON parse error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error status code %d from Incapsula service when creating Waiting Room for Site ID %s", 401, siteID)) {
+		t.Errorf("Should have received a JSON parse error, got: %s", err)
 	}
 	if createWaitingRoomResponse != nil {
 		t.Errorf("Should have received a nil createWaitingRoomResponse instance")
@@ -156,15 +158,19 @@ func TestClientCreateWaitingRoomBadStatusCode(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	createWaitingRoomResponse, diags := client.CreateWaitingRoom(accountId, siteID, &waitingRoom)
-	if diags == nil || len(diags) == 0 {
+	createWaitingRoomResponse, err := client.CreateWaitingRoom(context.Background(), accountId, siteID, &waitingRoom)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error status code %d from Incapsula service when creating Waiting Room for Site ID %s", 404, siteID)) {
-		t.Errorf("Should have received a JSON parse error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error status code %d from Incapsula service when creating Waiting Room for Site ID %s", 404, siteID)) {
+		t.Errorf("Should have received a JSON parse error, got: %s", err)
 	}
-	if createWaitingRoomResponse == nil || createWaitingRoomResponse.Errors[0].Status != 404 {
-		t.Errorf("Should have received an error DTO")
+	if createWaitingRoomResponse != nil {
+		t.Errorf("Should not have received a response")
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Errors[0].Status != 404 || !IsNotFound(err) {
+		t.Errorf("Should have received a not found APIError, got: %v", err)
 	}
 }
 
@@ -198,12 +204,12 @@ func TestClientCreateWaitingRoomEmptyBody(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	createWaitingRoomResponse, diags := client.CreateWaitingRoom(accountId, siteID, &waitingRoom)
-	if diags == nil || len(diags) == 0 {
+	createWaitingRoomResponse, err := client.CreateWaitingRoom(context.Background(), accountId, siteID, &waitingRoom)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error parsing Waiting Room JSON response for Site ID %s", siteID)) {
-		t.Errorf("Should have received a JSON parse error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error parsing Waiting Room JSON response for Site ID %s", siteID)) {
+		t.Errorf("Should have received a JSON parse error, got: %s", err)
 	}
 	if createWaitingRoomResponse != nil {
 		t.Errorf("Should have received a nil createWaitingRoomResponse instance")
@@ -241,12 +247,12 @@ func TestClientCreateWaitingRoomInvalidJSONValue(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	createWaitingRoomResponse, diags := client.CreateWaitingRoom(accountId, siteID, &waitingRoom)
-	if diags == nil || len(diags) == 0 {
+	createWaitingRoomResponse, err := client.CreateWaitingRoom(context.Background(), accountId, siteID, &waitingRoom)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error parsing Waiting Room JSON response for Site ID %s", siteID)) {
-		t.Errorf("Should have received a JSON parse error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error parsing Waiting Room JSON response for Site ID %s", siteID)) {
+		t.Errorf("Should have received a JSON parse error, got: %s", err)
 	}
 	if createWaitingRoomResponse != nil {
 		t.Errorf("Should have received a nil createWaitingRoomResponse instance")
@@ -285,8 +291,8 @@ func TestClientCreateWaitingRoomValidResponse(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	createWaitingRoomResponse, diags := client.CreateWaitingRoom(accountId, siteID, &waitingRoom)
-	if diags != nil {
+	createWaitingRoomResponse, err := client.CreateWaitingRoom(context.Background(), accountId, siteID, &waitingRoom)
+	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
 	if len(createWaitingRoomResponse.Data) != 1 {
@@ -318,12 +324,12 @@ func TestClientReadWaitingRoomBadConnection(t *testing.T) {
 	accountId := "1234"
 	waitingRoomID := int64(1)
 
-	readWaitingRoomResponse, diags := client.ReadWaitingRoom(accountId, siteID, waitingRoomID)
-	if diags == nil || len(diags) == 0 {
+	readWaitingRoomResponse, err := client.ReadWaitingRoom(context.Background(), accountId, siteID, waitingRoomID)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error from Incapsula service when reading Waiting Room %d for Site ID %s", waitingRoomID, siteID)) {
-		t.Errorf("Should have received a client error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error from Incapsula service when reading Waiting Room %d for Site ID %s", waitingRoomID, siteID)) {
+		t.Errorf("Should have received a client error, got: %s", err)
 	}
 	if readWaitingRoomResponse != nil {
 		t.Errorf("Should have received a nil readWaitingRoomResponse instance")
@@ -350,12 +356,12 @@ func TestClientReadWaitingRoomBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readWaitingRoomResponse, diags := client.ReadWaitingRoom(accountId, siteID, waitingRoomID)
-	if diags == nil || len(diags) == 0 {
+	readWaitingRoomResponse, err := client.ReadWaitingRoom(context.Background(), accountId, siteID, waitingRoomID)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error parsing Waiting Room %d JSON response for Site ID %s", waitingRoomID, siteID)) {
-		t.Errorf("Should have received a JSON parse error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error parsing Waiting Room %d JSON response for Site ID %s", waitingRoomID, siteID)) {
+		t.Errorf("Should have received a JSON parse error, got: %s", err)
 	}
 	if readWaitingRoomResponse != nil {
 		t.Errorf("Should have received a nil readWaitingRoomResponse instance")
@@ -382,12 +388,12 @@ func TestClientReadWaitingRoomBadStatusCodeWithEmptyBody(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readWaitingRoomResponse, diags := client.ReadWaitingRoom(accountId, siteID, waitingRoomID)
-	if diags == nil || len(diags) == 0 {
+	readWaitingRoomResponse, err := client.ReadWaitingRoom(context.Background(), accountId, siteID, waitingRoomID)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error status code %d from Incapsula service when reading Waiting Room %d for Site ID %s", 404, waitingRoomID, siteID)) {
-		t.Errorf("Should have received a JSON parse error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error status code %d from Incapsula service when reading Waiting Room %d for Site ID %s", 404, waitingRoomID, siteID)) {
+		t.Errorf("Should have received a JSON parse error, got: %s", err)
 	}
 	if readWaitingRoomResponse != nil {
 		t.Errorf("Should have received a nil readWaitingRoomResponse instance")
@@ -415,15 +421,19 @@ func TestClientReadWaitingRoomBadStatusCode(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readWaitingRoomResponse, diags := client.ReadWaitingRoom(accountId, siteID, waitingRoomID)
-	if diags == nil || len(diags) == 0 {
+	readWaitingRoomResponse, err := client.ReadWaitingRoom(context.Background(), accountId, siteID, waitingRoomID)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error status code %d from Incapsula service when reading Waiting Room %d for Site ID %s", 404, waitingRoomID, siteID)) {
-		t.Errorf("Should have received a JSON parse error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error status code %d from Incapsula service when reading Waiting Room %d for Site ID %s", 404, waitingRoomID, siteID)) {
+		t.Errorf("Should have received a JSON parse error, got: %s", err)
+	}
+	if readWaitingRoomResponse != nil {
+		t.Errorf("Should not have received a response")
 	}
-	if readWaitingRoomResponse == nil || readWaitingRoomResponse.Errors[0].Status != 404 {
-		t.Errorf("Should have received an error DTO")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Errors[0].Status != 404 || !IsNotFound(err) {
+		t.Errorf("Should have received a not found APIError, got: %v", err)
 	}
 }
 
@@ -447,12 +457,12 @@ func TestClientReadWaitingRoomEmptyBody(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readWaitingRoomResponse, diags := client.ReadWaitingRoom(accountId, siteID, waitingRoomID)
-	if diags == nil || len(diags) == 0 {
+	readWaitingRoomResponse, err := client.ReadWaitingRoom(context.Background(), accountId, siteID, waitingRoomID)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error parsing Waiting Room %d JSON response for Site ID %s", waitingRoomID, siteID)) {
-		t.Errorf("Should have received a JSON parse error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error parsing Waiting Room %d JSON response for Site ID %s", waitingRoomID, siteID)) {
+		t.Errorf("Should have received a JSON parse error, got: %s", err)
 	}
 	if readWaitingRoomResponse != nil {
 		t.Errorf("Should have received a nil readWaitingRoomResponse instance")
@@ -480,8 +490,8 @@ func TestClientReadWaitingRoomValidResponse(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readWaitingRoomResponse, diags := client.ReadWaitingRoom(accountId, siteID, waitingRoomID)
-	if diags != nil {
+	readWaitingRoomResponse, err := client.ReadWaitingRoom(context.Background(), accountId, siteID, waitingRoomID)
+	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
 	if len(readWaitingRoomResponse.Data) != 1 {
@@ -524,12 +534,12 @@ func TestClientUpdateWaitingRoomBadConnection(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	updateWaitingRoomResponse, diags := client.UpdateWaitingRoom(accountId, siteID, waitingRoomID, &waitingRoom)
-	if diags == nil || len(diags) == 0 {
+	updateWaitingRoomResponse, err := client.UpdateWaitingRoom(context.Background(), accountId, siteID, waitingRoomID, &waitingRoom)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error from Incapsula service when updating Waiting Room %d for Site ID %s", waitingRoomID, siteID)) {
-		t.Errorf("Should have received a client error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error from Incapsula service when updating Waiting Room %d for Site ID %s", waitingRoomID, siteID)) {
+		t.Errorf("Should have received a client error, got: %s", err)
 	}
 	if updateWaitingRoomResponse != nil {
 		t.Errorf("Should have received a nil updateWaitingRoomResponse instance")
@@ -567,12 +577,12 @@ func TestClientUpdateWaitingRoomBadJSON(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	updateWaitingRoomResponse, diags := client.UpdateWaitingRoom(accountId, siteID, waitingRoomID, &waitingRoom)
-	if diags == nil || len(diags) == 0 {
+	updateWaitingRoomResponse, err := client.UpdateWaitingRoom(context.Background(), accountId, siteID, waitingRoomID, &waitingRoom)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error parsing Waiting Room %d JSON response for Site ID %s", waitingRoomID, siteID)) {
-		t.Errorf("Should have received a JSON parse error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error parsing Waiting Room %d JSON response for Site ID %s", waitingRoomID, siteID)) {
+		t.Errorf("Should have received a JSON parse error, got: %s", err)
 	}
 	if updateWaitingRoomResponse != nil {
 		t.Errorf("Should have received a nil updateWaitingRoomResponse instance")
@@ -610,12 +620,12 @@ func TestClientUpdateWaitingRoomBadStatusCodeWithEmptyBody(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	updateWaitingRoomResponse, diags := client.UpdateWaitingRoom(accountId, siteID, waitingRoomID, &waitingRoom)
-	if diags == nil || len(diags) == 0 {
+	updateWaitingRoomResponse, err := client.UpdateWaitingRoom(context.Background(), accountId, siteID, waitingRoomID, &waitingRoom)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error status code %d from Incapsula service when updating Waiting Room %d for Site ID %s", 404, waitingRoomID, siteID)) {
-		t.Errorf("Should have received a Status Code error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error status code %d from Incapsula service when updating Waiting Room %d for Site ID %s", 404, waitingRoomID, siteID)) {
+		t.Errorf("Should have received a Status Code error, got: %s", err)
 	}
 	if updateWaitingRoomResponse != nil {
 		t.Errorf("Should have received a nil updateWaitingRoomResponse instance")
@@ -654,15 +664,19 @@ func TestClientUpdateWaitingRoomBadStatusCode(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	updateWaitingRoomResponse, diags := client.UpdateWaitingRoom(accountId, siteID, waitingRoomID, &waitingRoom)
-	if diags == nil || len(diags) == 0 {
+	updateWaitingRoomResponse, err := client.UpdateWaitingRoom(context.Background(), accountId, siteID, waitingRoomID, &waitingRoom)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error status code %d from Incapsula service when updating Waiting Room %d for Site ID %s", 404, waitingRoomID, siteID)) {
-		t.Errorf("Should have received a JSON parse error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error status code %d from Incapsula service when updating Waiting Room %d for Site ID %s", 404, waitingRoomID, siteID)) {
+		t.Errorf("Should have received a JSON parse error, got: %s", err)
 	}
-	if updateWaitingRoomResponse == nil || updateWaitingRoomResponse.Errors[0].Status != 404 {
-		t.Errorf("Should have received an error DTO")
+	if updateWaitingRoomResponse != nil {
+		t.Errorf("Should not have received a response")
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Errors[0].Status != 404 || !IsNotFound(err) {
+		t.Errorf("Should have received a not found APIError, got: %v", err)
 	}
 }
 
@@ -697,12 +711,12 @@ func TestClientUpdateWaitingRoomEmptyBody(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	updateWaitingRoomResponse, diags := client.UpdateWaitingRoom(accountId, siteID, waitingRoomID, &waitingRoom)
-	if diags == nil || len(diags) == 0 {
+	updateWaitingRoomResponse, err := client.UpdateWaitingRoom(context.Background(), accountId, siteID, waitingRoomID, &waitingRoom)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error parsing Waiting Room %d JSON response for Site ID %s", waitingRoomID, siteID)) {
-		t.Errorf("Should have received a JSON parse error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error parsing Waiting Room %d JSON response for Site ID %s", waitingRoomID, siteID)) {
+		t.Errorf("Should have received a JSON parse error, got: %s", err)
 	}
 	if updateWaitingRoomResponse != nil {
 		t.Errorf("Should have received a nil updateWaitingRoomResponse instance")
@@ -741,8 +755,8 @@ func TestClientUpdateWaitingRoomValidResponse(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	updateWaitingRoomResponse, diags := client.UpdateWaitingRoom(accountId, siteID, waitingRoomID, &waitingRoom)
-	if diags != nil {
+	updateWaitingRoomResponse, err := client.UpdateWaitingRoom(context.Background(), accountId, siteID, waitingRoomID, &waitingRoom)
+	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
 	if len(updateWaitingRoomResponse.Data) != 1 {
@@ -774,12 +788,12 @@ func TestClientDeleteWaitingRoomBadConnection(t *testing.T) {
 	accountId := "1234"
 	waitingRoomID := int64(1)
 
-	deleteWaitingRoomResponse, diags := client.DeleteWaitingRoom(accountId, siteID, waitingRoomID)
-	if diags == nil || len(diags) == 0 {
+	deleteWaitingRoomResponse, err := client.DeleteWaitingRoom(context.Background(), accountId, siteID, waitingRoomID)
+	if err == nil {
 		t.Errorf("Should have received a error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error from Incapsula service when deleting Waiting Room %d for Site ID %s", waitingRoomID, siteID)) {
-		t.Errorf("Should have received a client error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error from Incapsula service when deleting Waiting Room %d for Site ID %s", waitingRoomID, siteID)) {
+		t.Errorf("Should have received a client error, got: %s", err)
 	}
 	if deleteWaitingRoomResponse != nil {
 		t.Errorf("Should have received a nil deleteWaitingRoomResponse instance")
@@ -806,12 +820,12 @@ func TestClientDeleteWaitingRoomBadStatusCodeWithEmptyBody(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	deleteWaitingRoomResponse, diags := client.DeleteWaitingRoom(accountId, siteID, waitingRoomID)
-	if diags == nil || len(diags) == 0 {
+	deleteWaitingRoomResponse, err := client.DeleteWaitingRoom(context.Background(), accountId, siteID, waitingRoomID)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error status code %d from Incapsula service when deleting Waiting Room %d for Site ID %s", 404, waitingRoomID, siteID)) {
-		t.Errorf("Should have received a Status Code error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error status code %d from Incapsula service when deleting Waiting Room %d for Site ID %s", 404, waitingRoomID, siteID)) {
+		t.Errorf("Should have received a Status Code error, got: %s", err)
 	}
 	if deleteWaitingRoomResponse != nil {
 		t.Errorf("Should have received a nil deleteWaitingRoomResponse instance")
@@ -839,15 +853,19 @@ func TestClientDeleteWaitingRoomBadStatusCode(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	deleteWaitingRoomResponse, diags := client.DeleteWaitingRoom(accountId, siteID, waitingRoomID)
-	if diags == nil || len(diags) == 0 {
+	deleteWaitingRoomResponse, err := client.DeleteWaitingRoom(context.Background(), accountId, siteID, waitingRoomID)
+	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(diags[0].Detail, fmt.Sprintf("Error status code %d from Incapsula service when deleting Waiting Room %d for Site ID %s", 404, waitingRoomID, siteID)) {
-		t.Errorf("Should have received a Status Code error, got: %s", diags[0].Detail)
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error status code %d from Incapsula service when deleting Waiting Room %d for Site ID %s", 404, waitingRoomID, siteID)) {
+		t.Errorf("Should have received a Status Code error, got: %s", err)
+	}
+	if deleteWaitingRoomResponse != nil {
+		t.Errorf("Should not have received a response")
 	}
-	if deleteWaitingRoomResponse == nil || deleteWaitingRoomResponse.Errors[0].Status != 404 {
-		t.Errorf("Should have received an error DTO")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Errors[0].Status != 404 || !IsNotFound(err) {
+		t.Errorf("Should have received a not found APIError, got: %v", err)
 	}
 }
 
@@ -872,8 +890,8 @@ func TestClientDeleteWaitingRoomValidWaitingRoom(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	deleteWaitingRoomResponse, diags := client.DeleteWaitingRoom(accountId, siteID, waitingRoomID)
-	if diags != nil {
+	deleteWaitingRoomResponse, err := client.DeleteWaitingRoom(context.Background(), accountId, siteID, waitingRoomID)
+	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
 	if deleteWaitingRoomResponse == nil || len(deleteWaitingRoomResponse.Data) == 0 {
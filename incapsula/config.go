@@ -1,9 +1,18 @@
 package incapsula
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
+	"fmt"
 	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
 	"strings"
+	"time"
 )
 
 // Config represents the configuration required for the Incapsula Client
@@ -32,6 +41,61 @@ type Config struct {
 	// API V2
 	// Same as revision 2 but with a different subdomain
 	BaseURLAPI string
+
+	// Maximum number of API requests per second sent by the client (0 means unlimited)
+	MaxRequestsPerSecond float64
+
+	// Maximum number of API requests in flight at the same time (0 means unlimited)
+	MaxConcurrentRequests int
+
+	// Retry policy for transient API failures (nil means the default policy)
+	Retry *RetryPolicy
+
+	// Proxy URL for all API requests (empty means the HTTPS_PROXY/HTTP_PROXY/NO_PROXY environment variables)
+	ProxyURL string
+
+	// PEM file with the CA certificates trusted for the API endpoints, instead of the system roots
+	CABundleFile string
+
+	// PEM files with the client certificate and key presented to the API endpoints or proxy (mTLS)
+	ClientCertFile string
+	ClientKeyFile  string
+
+	// Skip the verification of the server certificate. Only meant for the mock server.
+	InsecureSkipVerify bool
+
+	// Timeout of a single API request, including reading the response body (0 means no timeout)
+	RequestTimeout time.Duration
+
+	// Idle (keep-alive) connection settings of the HTTP transport (0 means the Go defaults)
+	IdleConnTimeout     time.Duration
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+
+	// File the full request and response bodies are written to, with secrets redacted (empty means disabled)
+	LogBodyFile string
+
+	// How long the responses of the heavy read operations (e.g. SiteStatus) are cached (0 means no cache)
+	ReadCacheTTL time.Duration
+
+	// Refuse all the API requests that change the configuration
+	ReadOnly bool
+
+	// Don't check the credentials when the client is created, but before its first API call
+	SkipCredentialsValidation bool
+
+	// Account sent as caid by the requests that don't name their account (0 means the API key's account)
+	DefaultAccountID int
+
+	// Environment whose preset base URLs are used for the base URLs that are not set (empty means none)
+	Environment string
+
+	// Profile of the shared credentials file the credentials and base URLs are read from
+	// (empty means INCAPSULA_PROFILE, then "default")
+	Profile string
+
+	// Shared credentials file (empty means INCAPSULA_SHARED_CREDENTIALS_FILE, then ~/.incapsula/credentials)
+	SharedCredentialsFile string
 }
 
 var missingAPIIDMessage = "API Identifier (api_id) must be provided"
@@ -40,11 +104,22 @@ var missingBaseURLMessage = "Base URL must be provided"
 var missingBaseURLRev2Message = "Base URL Revision 2 must be provided"
 var missingBaseURLRev3Message = "Base URL Revision 3 must be provided"
 var missingBaseURLAPIMessage = "Base URL API must be provided"
+var missingClientKeyFileMessage = "Client key file (client_key_file) must be provided with the client certificate file"
+var missingClientCertFileMessage = "Client certificate file (client_cert_file) must be provided with the client key file"
+var invalidRetryBackoffMessage = "Retry min_backoff_seconds (%d) must not be greater than max_backoff_seconds (%d)"
 
 // Client configures and returns a fully initialized Incapsula Client
-func (c *Config) Client() (interface{}, error) {
+func (c *Config) Client(ctx context.Context) (interface{}, error) {
 	log.Println("[INFO] Checking API credentials for client instantiation")
 
+	// Fill the settings that are not set explicitly from the environment and the shared credentials file
+	if err := c.resolveCredentials(); err != nil {
+		return nil, err
+	}
+	if err := c.applyEnvironment(); err != nil {
+		return nil, err
+	}
+
 	// Check API Identifier
 	if strings.TrimSpace(c.APIID) == "" {
 		return nil, errors.New(missingAPIIDMessage)
@@ -75,15 +150,125 @@ func (c *Config) Client() (interface{}, error) {
 		return nil, errors.New(missingBaseURLAPIMessage)
 	}
 
+	// Check the retry backoff range
+	if c.Retry != nil && c.Retry.MinBackoff > c.Retry.MaxBackoff {
+		return nil, fmt.Errorf(invalidRetryBackoffMessage, c.Retry.MinBackoff/time.Second, c.Retry.MaxBackoff/time.Second)
+	}
+
+	// Create the HTTP client (proxy, TLS and connection settings)
+	httpClient, err := c.httpClient()
+	if err != nil {
+		return nil, err
+	}
+
 	// Create client
 	client := NewClient(c)
+	client.httpClient = httpClient
+
+	if c.SkipCredentialsValidation {
+		log.Println("[INFO] Skipping the API credentials check until the first API call (skip_credentials_validation)")
+		return client, nil
+	}
 
 	// Verify client credentials
-	accountStatusResponse, err := client.Verify()
-	client.accountStatus = accountStatusResponse
-	if err != nil {
+	if _, err := client.currentAccountStatus(ctx); err != nil {
 		return nil, err
 	}
 
 	return client, nil
 }
+
+// httpClient builds the HTTP client used for the API requests from the proxy, TLS, timeout,
+// idle connection and logging settings of the configuration
+func (c *Config) httpClient() (*http.Client, error) {
+	transport, err := c.httpTransport()
+	if err != nil {
+		return nil, err
+	}
+
+	// Record or replay the API interactions when INCAPSULA_RECORD or INCAPSULA_REPLAY is set
+	roundTripper, err := cassetteTransportFromEnv(transport)
+	if err != nil {
+		return nil, err
+	}
+
+	var bodyLog *bodyLogFile
+	if strings.TrimSpace(c.LogBodyFile) != "" {
+		bodyLog, err = openBodyLogFile(c.LogBodyFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &http.Client{Transport: newLoggingTransport(roundTripper, bodyLog), Timeout: c.RequestTimeout}, nil
+}
+
+func (c *Config) httpTransport() (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if strings.TrimSpace(c.ProxyURL) != "" {
+		proxyURL, err := url.Parse(c.ProxyURL)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("Invalid proxy URL (proxy_url) %q: must be an absolute URL such as http://proxy.example.com:3128", c.ProxyURL)
+		}
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("Invalid proxy URL (proxy_url) %q: unsupported scheme %q, use http, https or socks5", c.ProxyURL, proxyURL.Scheme)
+		}
+		log.Printf("[INFO] Sending the API requests through proxy %s\n", proxyURL.Redacted())
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if strings.TrimSpace(c.CABundleFile) != "" {
+		pem, err := os.ReadFile(c.CABundleFile)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading CA bundle file (ca_bundle_file) %s: %w", c.CABundleFile, err)
+		}
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA bundle file (ca_bundle_file) %s does not contain any PEM certificate", c.CABundleFile)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	certFile, keyFile := strings.TrimSpace(c.ClientCertFile), strings.TrimSpace(c.ClientKeyFile)
+	if certFile != "" && keyFile == "" {
+		return nil, errors.New(missingClientKeyFileMessage)
+	}
+	if keyFile != "" && certFile == "" {
+		return nil, errors.New(missingClientCertFileMessage)
+	}
+	if certFile != "" {
+		certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("Error loading client certificate (client_cert_file/client_key_file) %s: %w", certFile, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	if c.InsecureSkipVerify {
+		log.Println("[WARN] TLS certificate verification of the API endpoints is disabled (insecure_skip_verify). Only use it with the mock server.")
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	if c.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = c.IdleConnTimeout
+	}
+	if c.MaxIdleConns > 0 {
+		transport.MaxIdleConns = c.MaxIdleConns
+	}
+	if c.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = c.MaxIdleConnsPerHost
+	}
+	if c.RequestTimeout > 0 {
+		// Don't wait longer for a connection than for the whole request
+		transport.DialContext = (&net.Dialer{Timeout: c.RequestTimeout, KeepAlive: 30 * time.Second}).DialContext
+	}
+
+	return transport, nil
+}
@@ -1,15 +1,27 @@
 package incapsula
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestMissingCredentials(t *testing.T) {
 	config := Config{}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error, got a client: %q", client)
 	}
@@ -20,7 +32,7 @@ func TestMissingCredentials(t *testing.T) {
 
 func TestMissingAPIID(t *testing.T) {
 	config := Config{APIID: "", APIKey: "foo"}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error, got a client: %q", client)
 	}
@@ -31,7 +43,7 @@ func TestMissingAPIID(t *testing.T) {
 
 func TestMissingAPIKey(t *testing.T) {
 	config := Config{APIID: "foo", APIKey: ""}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error, got a client: %q", client)
 	}
@@ -42,7 +54,7 @@ func TestMissingAPIKey(t *testing.T) {
 
 func TestMissingBaseURL(t *testing.T) {
 	config := Config{APIID: "foo", APIKey: "bar", BaseURL: ""}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error, got a client: %q", client)
 	}
@@ -53,7 +65,7 @@ func TestMissingBaseURL(t *testing.T) {
 
 func TestMissingBaseURLRev2(t *testing.T) {
 	config := Config{APIID: "foo", APIKey: "bar", BaseURL: "foobar.com", BaseURLRev2: "", BaseURLRev3: "foobar.com"}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error, got a client: %q", client)
 	}
@@ -64,7 +76,7 @@ func TestMissingBaseURLRev2(t *testing.T) {
 
 func TestMissingBaseURLRev3(t *testing.T) {
 	config := Config{APIID: "foo", APIKey: "bar", BaseURL: "foobar.com", BaseURLRev2: "foobar.com", BaseURLRev3: ""}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error, got a client: %q", client)
 	}
@@ -75,7 +87,7 @@ func TestMissingBaseURLRev3(t *testing.T) {
 
 func TestMissingBaseURLAPI(t *testing.T) {
 	config := Config{APIID: "foo", APIKey: "bar", BaseURL: "foobar.com", BaseURLRev2: "foobar.com", BaseURLRev3: "foobar.com", BaseURLAPI: ""}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error, got a client: %q", client)
 	}
@@ -94,7 +106,7 @@ func TestInvalidCredentials(t *testing.T) {
 	defer server.Close()
 
 	config := Config{APIID: "bad", APIKey: "bad", BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLRev3: server.URL, BaseURLAPI: server.URL}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error, got a client: %q", client)
 	}
@@ -113,7 +125,7 @@ func TestValidCredentials(t *testing.T) {
 	defer server.Close()
 
 	config := Config{APIID: "good", APIKey: "good", BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLRev3: server.URL, BaseURLAPI: server.URL}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err != nil {
 		t.Errorf("Should not have received an error, got: %s", err)
 	}
@@ -121,3 +133,223 @@ func TestValidCredentials(t *testing.T) {
 		t.Error("Client should not be nil")
 	}
 }
+
+const testVerifyResponse = `{"account_type":"Account","account_id":1,"parent_id":0,"account_name":"test","plan_id":"ent100","plan_name":"ENTERPRISE","res":0,"res_message":"OK","debug_info":{"id-info":""}}`
+
+func testVerifyHandler(rw http.ResponseWriter, req *http.Request) {
+	rw.Write([]byte(testVerifyResponse))
+}
+
+func testConfigForURL(url string) Config {
+	return Config{APIID: "good", APIKey: "good", BaseURL: url, BaseURLRev2: url, BaseURLRev3: url, BaseURLAPI: url, Retry: &RetryPolicy{MaxAttempts: 1}}
+}
+
+// writeTestPEMFile writes a PEM block to a file in a temporary directory and returns its path
+func writeTestPEMFile(t *testing.T, name string, blockType string, der []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600); err != nil {
+		t.Fatalf("Error writing %s: %s", path, err)
+	}
+	return path
+}
+
+func TestCABundleFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(testVerifyHandler))
+	defer server.Close()
+
+	config := testConfigForURL(server.URL)
+	if _, err := config.Client(context.Background()); err == nil {
+		t.Errorf("Should have received an error for a server certificate signed by an unknown authority")
+	}
+
+	config.CABundleFile = writeTestPEMFile(t, "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+	if _, err := config.Client(context.Background()); err != nil {
+		t.Errorf("Should not have received an error with the server CA in the bundle, got: %s", err)
+	}
+}
+
+func TestInvalidCABundleFile(t *testing.T) {
+	config := testConfigForURL("https://127.0.0.1")
+
+	config.CABundleFile = filepath.Join(t.TempDir(), "missing.pem")
+	_, err := config.Client(context.Background())
+	if err == nil || !strings.HasPrefix(err.Error(), "Error reading CA bundle file (ca_bundle_file)") {
+		t.Errorf("Should have received a CA bundle read error, got: %v", err)
+	}
+
+	config.CABundleFile = filepath.Join(t.TempDir(), "empty.pem")
+	os.WriteFile(config.CABundleFile, []byte("not a certificate"), 0600)
+	_, err = config.Client(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "does not contain any PEM certificate") {
+		t.Errorf("Should have received an invalid CA bundle error, got: %v", err)
+	}
+}
+
+func TestInsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(testVerifyHandler))
+	defer server.Close()
+
+	config := testConfigForURL(server.URL)
+	config.InsecureSkipVerify = true
+	if _, err := config.Client(context.Background()); err != nil {
+		t.Errorf("Should not have received an error, got: %s", err)
+	}
+}
+
+func TestClientCertificate(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Error generating key: %s", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Error creating certificate: %s", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Error marshalling key: %s", err)
+	}
+	clientCert, _ := x509.ParseCertificate(certDER)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(testVerifyHandler))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	config := testConfigForURL(server.URL)
+	config.CABundleFile = writeTestPEMFile(t, "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+	if _, err := config.Client(context.Background()); err == nil {
+		t.Errorf("Should have received an error without a client certificate")
+	}
+
+	config.ClientCertFile = writeTestPEMFile(t, "client.pem", "CERTIFICATE", certDER)
+	_, err = config.Client(context.Background())
+	if err == nil || err.Error() != missingClientKeyFileMessage {
+		t.Errorf("Should have received missing client key file message, got: %v", err)
+	}
+
+	config.ClientKeyFile = writeTestPEMFile(t, "client.key", "EC PRIVATE KEY", keyDER)
+	if _, err := config.Client(context.Background()); err != nil {
+		t.Errorf("Should not have received an error with a client certificate, got: %s", err)
+	}
+}
+
+func TestProxyURL(t *testing.T) {
+	var proxiedURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		proxiedURL = req.URL.String()
+		testVerifyHandler(rw, req)
+	}))
+	defer proxy.Close()
+
+	config := testConfigForURL("http://my.incapsula.test/api/prov/v1")
+	config.ProxyURL = proxy.URL
+	if _, err := config.Client(context.Background()); err != nil {
+		t.Errorf("Should not have received an error, got: %s", err)
+	}
+	if proxiedURL != "http://my.incapsula.test/api/prov/v1/account/verify" {
+		t.Errorf("Should have sent the request through the proxy, got: %q", proxiedURL)
+	}
+
+	config.ProxyURL = "ftp://proxy.example.com"
+	_, err := config.Client(context.Background())
+	if err == nil || !strings.HasPrefix(err.Error(), "Invalid proxy URL (proxy_url)") {
+		t.Errorf("Should have received an invalid proxy URL error, got: %v", err)
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+		testVerifyHandler(rw, req)
+	}))
+	defer server.Close()
+
+	config := testConfigForURL(server.URL)
+	config.RequestTimeout = 50 * time.Millisecond
+	if _, err := config.Client(context.Background()); err == nil {
+		t.Errorf("Should have received a timeout error")
+	}
+}
+
+func TestHTTPTransportIdleSettings(t *testing.T) {
+	config := Config{IdleConnTimeout: 30 * time.Second, MaxIdleConns: 10, MaxIdleConnsPerHost: 4}
+	transport, err := config.httpTransport()
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if transport.IdleConnTimeout != 30*time.Second || transport.MaxIdleConns != 10 || transport.MaxIdleConnsPerHost != 4 {
+		t.Errorf("Unexpected idle connection settings: %s %d %d", transport.IdleConnTimeout, transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+	}
+	if transport.Proxy == nil {
+		t.Errorf("Should use the proxy environment variables when no proxy URL is set")
+	}
+}
+
+func TestSkipCredentialsValidation(t *testing.T) {
+	var verifyCalls, calls int32
+	valid := true
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/account/verify" {
+			verifyCalls++
+			if !valid {
+				rw.Write([]byte(`{"res":1,"res_message":"fail"}`))
+				return
+			}
+			testVerifyHandler(rw, req)
+			return
+		}
+		calls++
+		rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	config := testConfigForURL(server.URL)
+	config.SkipCredentialsValidation = true
+	client, err := config.Client(context.Background())
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if verifyCalls != 0 {
+		t.Errorf("Should not have checked the credentials when configuring the client")
+	}
+
+	// The credentials are checked once, before the first API call
+	for i := 0; i < 2; i++ {
+		resp, err := client.(*Client).DoJsonRequestWithHeaders(context.Background(), http.MethodGet, server.URL+"/sites/42/rules/7", nil, ReadIncapRule)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		resp.Body.Close()
+	}
+	if verifyCalls != 1 || calls != 2 {
+		t.Errorf("Expected 1 credentials check and 2 API calls, got %d and %d", verifyCalls, calls)
+	}
+	accountStatus, err := client.(*Client).currentAccountStatus(context.Background())
+	if err != nil || accountStatus.AccountID != 1 || verifyCalls != 1 {
+		t.Errorf("Expected the account status of the credentials check, got %+v %v", accountStatus, err)
+	}
+
+	// Invalid credentials fail the first API call instead of the provider configuration
+	valid = false
+	client, err = config.Client(context.Background())
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	_, err = client.(*Client).DoJsonRequestWithHeaders(context.Background(), http.MethodGet, server.URL+"/sites/42/rules/7", nil, ReadIncapRule)
+	if err == nil || !strings.HasPrefix(err.Error(), "Error from Incapsula service when checking account") {
+		t.Errorf("Should have received Incapsula service error, got: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Should not have made the API call with invalid credentials")
+	}
+}
@@ -0,0 +1,216 @@
+package incapsula
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const defaultProfileName = "default"
+
+// Environment variables read by Config.Client for the settings that are not set explicitly
+const (
+	apiIDEnv                 = "INCAPSULA_API_ID"
+	apiKeyEnv                = "INCAPSULA_API_KEY"
+	baseURLEnv               = "INCAPSULA_BASE_URL"
+	baseURLRev2Env           = "INCAPSULA_BASE_URL_REV_2"
+	baseURLRev3Env           = "INCAPSULA_BASE_URL_REV_3"
+	baseURLAPIEnv            = "INCAPSULA_BASE_URL_API"
+	profileEnv               = "INCAPSULA_PROFILE"
+	sharedCredentialsFileEnv = "INCAPSULA_SHARED_CREDENTIALS_FILE"
+)
+
+// CredentialsProfile is a named profile of the shared credentials file
+type CredentialsProfile struct {
+	APIID       string
+	APIKey      string
+	BaseURL     string
+	BaseURLRev2 string
+	BaseURLRev3 string
+	BaseURLAPI  string
+}
+
+// defaultSharedCredentialsFile returns ~/.incapsula/credentials
+func defaultSharedCredentialsFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".incapsula", "credentials")
+}
+
+// readCredentialsProfiles reads the profiles of a shared credentials file, in INI or JSON format:
+//
+//	[production]
+//	api_id  = 12345
+//	api_key = abcdef
+//	base_url_api = https://api.imperva.com
+//
+//	{"production": {"api_id": "12345", "api_key": "abcdef", "base_url_api": "https://api.imperva.com"}}
+func readCredentialsProfiles(path string) (map[string]map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading shared credentials file %s: %w", path, err)
+	}
+
+	var profiles map[string]map[string]string
+	if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '{' {
+		profiles, err = parseJSONCredentials(trimmed)
+	} else {
+		profiles, err = parseINICredentials(content)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing shared credentials file %s: %w", path, err)
+	}
+	return profiles, nil
+}
+
+func newCredentialsProfile(path string, profileName string, values map[string]string) (*CredentialsProfile, error) {
+	for key := range values {
+		switch key {
+		case "api_id", "api_key", "base_url", "base_url_rev_2", "base_url_rev_3", "base_url_api":
+		default:
+			return nil, fmt.Errorf("Unknown key %s in profile %s of shared credentials file %s", key, profileName, path)
+		}
+	}
+
+	return &CredentialsProfile{
+		APIID:       values["api_id"],
+		APIKey:      values["api_key"],
+		BaseURL:     values["base_url"],
+		BaseURLRev2: values["base_url_rev_2"],
+		BaseURLRev3: values["base_url_rev_3"],
+		BaseURLAPI:  values["base_url_api"],
+	}, nil
+}
+
+func parseJSONCredentials(content []byte) (map[string]map[string]string, error) {
+	var raw map[string]map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	profiles := map[string]map[string]string{}
+	for name, values := range raw {
+		profiles[name] = map[string]string{}
+		for key, value := range values {
+			// API IDs are often written as numbers
+			profiles[name][key] = fmt.Sprint(value)
+		}
+	}
+	return profiles, nil
+}
+
+func parseINICredentials(content []byte) (map[string]map[string]string, error) {
+	profiles := map[string]map[string]string{}
+	var current map[string]string
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";"):
+			continue
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			// Like the AWS config file, "[profile name]" is accepted as well
+			name = strings.TrimSpace(strings.TrimPrefix(name, "profile "))
+			if profiles[name] == nil {
+				profiles[name] = map[string]string{}
+			}
+			current = profiles[name]
+		default:
+			key, value, found := strings.Cut(line, "=")
+			if !found {
+				return nil, fmt.Errorf("line %d: expected key = value", lineNumber)
+			}
+			if current == nil {
+				return nil, fmt.Errorf("line %d: %s is not in a [profile] section", lineNumber, strings.TrimSpace(key))
+			}
+			current[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+		}
+	}
+	return profiles, scanner.Err()
+}
+
+// resolveCredentials fills the credentials and base URLs that are not set explicitly, from the
+// environment variables and then from the profile of the shared credentials file. The lookup order
+// is: explicit value > environment variable > profile.
+func (c *Config) resolveCredentials() error {
+	fromEnv := func(value *string, env string) {
+		if strings.TrimSpace(*value) == "" {
+			*value = os.Getenv(env)
+		}
+	}
+	fromEnv(&c.APIID, apiIDEnv)
+	fromEnv(&c.APIKey, apiKeyEnv)
+	fromEnv(&c.BaseURL, baseURLEnv)
+	fromEnv(&c.BaseURLRev2, baseURLRev2Env)
+	fromEnv(&c.BaseURLRev3, baseURLRev3Env)
+	fromEnv(&c.BaseURLAPI, baseURLAPIEnv)
+
+	profile, err := c.credentialsProfile()
+	if err != nil || profile == nil {
+		return err
+	}
+	fromProfile := func(value *string, profileValue string) {
+		if strings.TrimSpace(*value) == "" {
+			*value = profileValue
+		}
+	}
+	fromProfile(&c.APIID, profile.APIID)
+	fromProfile(&c.APIKey, profile.APIKey)
+	fromProfile(&c.BaseURL, profile.BaseURL)
+	fromProfile(&c.BaseURLRev2, profile.BaseURLRev2)
+	fromProfile(&c.BaseURLRev3, profile.BaseURLRev3)
+	fromProfile(&c.BaseURLAPI, profile.BaseURLAPI)
+	return nil
+}
+
+// credentialsProfile loads the selected profile of the shared credentials file. Without an explicitly
+// selected profile or file, a missing file or "default" profile is not an error.
+func (c *Config) credentialsProfile() (*CredentialsProfile, error) {
+	profileName, path := c.Profile, c.SharedCredentialsFile
+	if profileName == "" {
+		profileName = os.Getenv(profileEnv)
+	}
+	if path == "" {
+		path = os.Getenv(sharedCredentialsFileEnv)
+	}
+	explicit := profileName != "" || path != ""
+
+	if profileName == "" {
+		profileName = defaultProfileName
+	}
+	if path == "" {
+		path = defaultSharedCredentialsFile()
+	}
+	if path == "" {
+		return nil, nil
+	}
+
+	if !explicit {
+		if _, err := os.Stat(path); err != nil {
+			return nil, nil
+		}
+	}
+	profiles, err := readCredentialsProfiles(path)
+	if err != nil {
+		return nil, err
+	}
+	values, ok := profiles[profileName]
+	if !ok {
+		// The default file may only hold named profiles
+		if !explicit {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Profile %s not found in shared credentials file %s", profileName, path)
+	}
+	return newCredentialsProfile(path, profileName, values)
+}
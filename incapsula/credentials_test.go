@@ -0,0 +1,145 @@
+package incapsula
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// isolateCredentialsEnv clears the credentials environment variables and points HOME to an empty directory
+func isolateCredentialsEnv(t *testing.T) {
+	for _, env := range []string{apiIDEnv, apiKeyEnv, baseURLEnv, baseURLRev2Env, baseURLRev3Env, baseURLAPIEnv, profileEnv, sharedCredentialsFileEnv} {
+		t.Setenv(env, "")
+	}
+	t.Setenv("HOME", t.TempDir())
+}
+
+func writeTestCredentialsFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Error writing %s: %s", path, err)
+	}
+	return path
+}
+
+func TestReadCredentialsProfiles(t *testing.T) {
+	iniPath := writeTestCredentialsFile(t, "credentials", `
+# Imperva accounts
+[default]
+api_id  = 111
+api_key = "default-key"
+
+[profile staging]
+api_id = 222
+api_key = staging-key
+base_url_api = https://api.staging.example.com
+`)
+	jsonPath := writeTestCredentialsFile(t, "credentials.json", `{
+  "default": {"api_id": 111, "api_key": "default-key"},
+  "staging": {"api_id": "222", "api_key": "staging-key", "base_url_api": "https://api.staging.example.com"}
+}`)
+
+	for _, path := range []string{iniPath, jsonPath} {
+		profiles, err := readCredentialsProfiles(path)
+		if err != nil {
+			t.Fatalf("Unexpected error reading %s: %s", path, err)
+		}
+		profile, err := newCredentialsProfile(path, "staging", profiles["staging"])
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if profile.APIID != "222" || profile.APIKey != "staging-key" || profile.BaseURLAPI != "https://api.staging.example.com" || profile.BaseURL != "" {
+			t.Errorf("Unexpected staging profile read from %s: %+v", path, profile)
+		}
+		if profiles["default"]["api_id"] != "111" || profiles["default"]["api_key"] != "default-key" {
+			t.Errorf("Unexpected default profile read from %s: %v", path, profiles["default"])
+		}
+	}
+
+	if _, err := readCredentialsProfiles(writeTestCredentialsFile(t, "invalid", "api_id = 111\n")); err == nil {
+		t.Errorf("Expected an error for a key outside of a profile section")
+	}
+	if _, err := newCredentialsProfile("credentials", "default", map[string]string{"api_secret": "foo"}); err == nil {
+		t.Errorf("Expected an error for an unknown key")
+	}
+}
+
+func TestCredentialsLookupOrder(t *testing.T) {
+	isolateCredentialsEnv(t)
+	var apiID string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		apiID = req.Header.Get("x-API-Id")
+		testVerifyHandler(rw, req)
+	}))
+	defer server.Close()
+
+	path := writeTestCredentialsFile(t, "credentials", `
+[ci]
+api_id = profile-id
+api_key = profile-key
+base_url = `+server.URL+`
+base_url_rev_2 = `+server.URL+`
+base_url_rev_3 = `+server.URL+`
+base_url_api = `+server.URL+`
+`)
+	t.Setenv(sharedCredentialsFileEnv, path)
+
+	// Profile selected explicitly
+	config := Config{Profile: "ci", Retry: &RetryPolicy{MaxAttempts: 1}}
+	if _, err := config.Client(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if apiID != "profile-id" || config.APIKey != "profile-key" || config.BaseURLAPI != server.URL {
+		t.Errorf("Expected the credentials and base URLs of the profile, got %s %+v", apiID, config)
+	}
+
+	// Environment variables take precedence over the profile, and the profile can be selected in the environment
+	t.Setenv(profileEnv, "ci")
+	t.Setenv(apiIDEnv, "env-id")
+	config = Config{Retry: &RetryPolicy{MaxAttempts: 1}}
+	if _, err := config.Client(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if apiID != "env-id" || config.APIKey != "profile-key" {
+		t.Errorf("Expected the API ID of the environment, got %s %+v", apiID, config)
+	}
+
+	// Explicit values take precedence over the environment variables
+	config = Config{APIID: "explicit-id", Retry: &RetryPolicy{MaxAttempts: 1}}
+	if _, err := config.Client(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if apiID != "explicit-id" {
+		t.Errorf("Expected the explicit API ID, got %s", apiID)
+	}
+}
+
+func TestCredentialsProfileNotFound(t *testing.T) {
+	isolateCredentialsEnv(t)
+	path := writeTestCredentialsFile(t, "credentials", "[ci]\napi_id = 1\napi_key = foo\n")
+
+	config := Config{Profile: "production", SharedCredentialsFile: path}
+	_, err := config.Client(context.Background())
+	if err == nil || !strings.HasPrefix(err.Error(), "Profile production not found") {
+		t.Errorf("Expected a profile not found error, got: %v", err)
+	}
+
+	config = Config{SharedCredentialsFile: filepath.Join(t.TempDir(), "missing")}
+	if _, err := config.Client(context.Background()); err == nil || !strings.HasPrefix(err.Error(), "Error reading shared credentials file") {
+		t.Errorf("Expected an error for a missing shared credentials file, got: %v", err)
+	}
+
+	// Without a selected profile or file, a default file without a default profile is ignored
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	os.MkdirAll(filepath.Join(home, ".incapsula"), 0700)
+	os.WriteFile(filepath.Join(home, ".incapsula", "credentials"), []byte("[ci]\napi_id = 1\n"), 0600)
+	config = Config{}
+	if _, err := config.Client(context.Background()); err == nil || err.Error() != missingAPIIDMessage {
+		t.Errorf("Should have received missing API ID message, got: %v", err)
+	}
+}
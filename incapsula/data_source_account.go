@@ -31,7 +31,7 @@ func dataSourceAccount() *schema.Resource {
 func dataSourceAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
-	accountStatusResponse, err := client.Verify()
+	accountStatusResponse, err := client.Verify(ctx)
 	if err != nil {
 		return diag.Errorf("Error checking account details: %v", err)
 	}
@@ -76,8 +76,8 @@ func dataSourceSSLInstructionsRead(ctx context.Context, d *schema.ResourceData,
 	client := m.(*Client)
 	siteId := d.Get("site_id").(string)
 	atoi, _ := strconv.Atoi(siteId)
-	waitForInstructions(client, atoi)
-	sSLInstructionsResponse, err := client.GetSiteSSLInstructions(atoi)
+	waitForInstructions(ctx, client, atoi)
+	sSLInstructionsResponse, err := client.GetSiteSSLInstructions(ctx, atoi)
 	if err != nil {
 		return diag.Errorf("Error request site SSL instructions: %v", err)
 	}
@@ -88,20 +88,22 @@ func dataSourceSSLInstructionsRead(ctx context.Context, d *schema.ResourceData,
 	return nil
 }
 
-func waitForInstructions(client *Client, siteId int) diag.Diagnostics {
-	siteDomainDetailsDto, err := client.GetWebsiteDomains(strconv.Itoa(siteId))
+func waitForInstructions(ctx context.Context, client *Client, siteId int) diag.Diagnostics {
+	siteDomainDetailsDto, err := client.GetWebsiteDomains(ctx, strconv.Itoa(siteId))
 	if err != nil {
 		return diag.Errorf("Error getting site %d domains: %v", siteId, err)
 	}
 	if siteDomainDetailsDto.Data != nil && len(siteDomainDetailsDto.Data) > 0 {
 		for i := 0; i < 20; i++ {
-			siteCertificateV3Response, _ := client.GetSiteCertificateRequestStatus(siteId, nil)
+			siteCertificateV3Response, _ := client.GetSiteCertificateRequestStatus(ctx, siteId, nil)
 			b := siteCertificateV3Response != nil && siteCertificateV3Response.Data != nil && len(siteCertificateV3Response.Data) > 0
 			b = b && siteCertificateV3Response.Data[0].CertificatesDetails != nil && validateSans(siteCertificateV3Response.Data[0], siteDomainDetailsDto.Data)
 			if b {
 				return nil
 			}
-			time.Sleep(10 * time.Second)
+			if err := sleepWithContext(ctx, 10*time.Second); err != nil {
+				return diag.FromErr(err)
+			}
 		}
 		panic("managed certificate was not created as expected")
 	}
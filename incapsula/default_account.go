@@ -0,0 +1,162 @@
+package incapsula
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Names of the query params, form fields and JSON body fields that name the account a request operates on
+var accountIDParams = []string{"caid", "account_id", "accountId"}
+
+// Operations of the APIs that take the account they operate on as the caid query param (the client functions
+// of these operations send caid when they are given an account). The v1 APIs taking account_id, the account
+// and subaccount APIs and the v2/v3 APIs without caid are left out.
+var caidOperations = map[string]bool{
+	ReadPolicyAccountAssociatiation:                    true,
+	UpdatePolicyAccountAssociatiation:                  true,
+	UpdateAccountRole:                                  true,
+	DeleteAccountRole:                                  true,
+	GetAccountSSLSettings:                              true,
+	UpdateAccountSSLSettings:                           true,
+	DeleteAccountSSLSettings:                           true,
+	CreateAccountUser:                                  true,
+	CreateSubAccountUser:                               true,
+	ReadAccountUser:                                    true,
+	UpdateAccountUser:                                  true,
+	DeleteAccountUser:                                  true,
+	CreateApiClient:                                    true,
+	ReadApiClient:                                      true,
+	UpdateApiClient:                                    true,
+	DeleteApiClient:                                    true,
+	ReadATOSiteAllowlistOperation:                      true,
+	UpdateATOSiteAllowlistOperation:                    true,
+	ReadATOSiteMitigationConfigurationOperation:        true,
+	UpdateATOSiteMitigationConfigurationOperation:      true,
+	CreateCloudOriginDomain:                            true,
+	ReadCloudOriginDomain:                              true,
+	DeleteCloudOriginDomain:                            true,
+	ReadCspSiteConfiguration:                           true,
+	UpdateCspSiteConfiguration:                         true,
+	CreateCspSiteDomain:                                true,
+	ReadCspSiteDomain:                                  true,
+	UpdateCspSiteDomain:                                true,
+	DeleteCspSiteDomain:                                true,
+	CreateMtlsClientToImpervaCertifiateSiteAssociation: true,
+	ReadMtlsClientToImpervaCertifiateSiteAssociation:   true,
+	DeleteMtlsClientToImpervaCertifiateSiteAssociation: true,
+	CreateMtlsImpervaToOriginCertifiate:                true,
+	ReadMtlsImpervaToOriginCertifiate:                  true,
+	UpdateMtlsImpervaToOriginCertifiate:                true,
+	DeleteMtlsImpervaToOriginCertifiate:                true,
+	CreateSiteMtlsImpervaToOriginCertifiateAssociation: true,
+	ReadSiteMtlsImpervaToOriginCertifiateAssociation:   true,
+	DeleteSiteMtlsImpervaToOriginCertifiateAssociation: true,
+	CreateNotificationCenterPolicy:                     true,
+	ReadNotificationCenterPolicy:                       true,
+	UpdateNotificationCenterPolicy:                     true,
+	DeleteNotificationCenterPolicy:                     true,
+	CreatePolicy:                                       true,
+	ReadPolicy:                                         true,
+	ReadPoliciesAll:                                    true,
+	UpdatePolicy:                                       true,
+	DeletePolicy:                                       true,
+	CreatePolicyAssetAssociation:                       true,
+	ReadPolicyAssetAssociation:                         true,
+	DeletePolicyAssetAssociation:                       true,
+	CreateShortRenewalCycleConfiguration:               true,
+	ReadShortRenewalCycleConfiguration:                 true,
+	DeleteShortRenewalCycleConfiguration:               true,
+	CreateSiemConnection:                               true,
+	ReadSiemConnection:                                 true,
+	UpdateSiemConnection:                               true,
+	DeleteSiemConnection:                               true,
+	CreateSiemLogConfiguration:                         true,
+	ReadSiemLogConfiguration:                           true,
+	UpdateSiemLogConfiguration:                         true,
+	DeleteSiemLogConfiguration:                         true,
+	RequestSiteCert:                                    true,
+	ReadSiteSSLSettings:                                true,
+	UpdateSiteSSLSettings:                              true,
+	AddV3Site:                                          true,
+	UpdateV3Site:                                       true,
+	CreateWaitingRoom:                                  true,
+	ReadWaitingRoom:                                    true,
+	UpdateWaitingRoom:                                  true,
+	DeleteWaitingRoom:                                  true,
+}
+
+// addDefaultAccountID sends the provider's default account (default_account_id) as the caid query param
+// of the requests of the caid operations that don't already name the account they operate on, in a
+// caid/account_id/accountId query param, form field or top level JSON body field.
+func (c *Client) addDefaultAccountID(req *http.Request) {
+	if c.config.DefaultAccountID == 0 || !caidOperations[req.Header.Get("x-tf-operation")] {
+		return
+	}
+	query := req.URL.Query()
+	for _, param := range accountIDParams {
+		if query.Get(param) != "" {
+			return
+		}
+	}
+	contentType := req.Header.Get("Content-Type")
+	if strings.HasPrefix(contentType, contentTypeApplicationUrlEncoded) {
+		if values, err := url.ParseQuery(string(requestBody(req))); err == nil {
+			for _, param := range accountIDParams {
+				if values.Get(param) != "" {
+					return
+				}
+			}
+		}
+	}
+	if strings.HasPrefix(contentType, contentTypeApplicationJson) {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(requestBody(req), &fields); err == nil {
+			for _, param := range accountIDParams {
+				if jsonInt(fields[param]) != 0 {
+					return
+				}
+			}
+		}
+	}
+
+	query.Set("caid", strconv.Itoa(c.config.DefaultAccountID))
+	req.URL.RawQuery = query.Encode()
+}
+
+// defaultAccountIDResources makes the resources whose account_id argument is optional and computed
+// plan the provider's default account when account_id is not set on a new resource
+func defaultAccountIDResources(provider *schema.Provider) {
+	for _, res := range provider.ResourcesMap {
+		accountID, ok := res.Schema["account_id"]
+		if !ok || !accountID.Optional || !accountID.Computed {
+			continue
+		}
+
+		customizeDiff := res.CustomizeDiff
+		res.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			config := d.GetRawConfig()
+			if client, ok := m.(*Client); ok && client.config.DefaultAccountID != 0 && d.Id() == "" &&
+				!config.IsNull() && config.IsKnown() && config.GetAttr("account_id").IsNull() {
+				var err error
+				if accountID.Type == schema.TypeString {
+					err = d.SetNew("account_id", strconv.Itoa(client.config.DefaultAccountID))
+				} else {
+					err = d.SetNew("account_id", client.config.DefaultAccountID)
+				}
+				if err != nil {
+					return err
+				}
+			}
+			if customizeDiff != nil {
+				return customizeDiff(ctx, d, m)
+			}
+			return nil
+		}
+	}
+}
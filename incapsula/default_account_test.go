@@ -0,0 +1,111 @@
+package incapsula
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDefaultAccountIDSentAsCaid(t *testing.T) {
+	var caid string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		caid = req.URL.Query().Get("caid")
+		rw.Write([]byte(`{"res":0}`))
+	}))
+	defer server.Close()
+
+	config := testConfigForURL(server.URL)
+	config.DefaultAccountID = 777
+	client := NewClient(&config)
+
+	send := func(method string, reqURL string, body []byte, contentType string, operation string) string {
+		caid = ""
+		resp, err := client.DoFormDataRequestWithHeaders(context.Background(), method, reqURL, body, contentType, operation)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		resp.Body.Close()
+		return caid
+	}
+
+	if got := send(http.MethodGet, server.URL+"/policies/v2/policies/1", nil, contentTypeApplicationJson, ReadPolicy); got != "777" {
+		t.Errorf("Expected the default account to be sent as caid, got %q", got)
+	}
+	if got := send(http.MethodDelete, server.URL+"/policies/v2/policies/1?caid=5", nil, contentTypeApplicationJson, DeletePolicy); got != "5" {
+		t.Errorf("Expected the caid of the request to be kept, got %q", got)
+	}
+	form := []byte(url.Values{"account_id": {"6"}, "domain": {"example.com"}}.Encode())
+	if got := send(http.MethodPost, server.URL+"/user-management/v1/users", form, contentTypeApplicationUrlEncoded, CreateAccountUser); got != "" {
+		t.Errorf("Expected no caid for a request naming its account_id, got %q", got)
+	}
+	if got := send(http.MethodGet, server.URL+"/policies/v2/policies?accountId=8", nil, contentTypeApplicationJson, ReadPoliciesAll); got != "" {
+		t.Errorf("Expected no caid for a request naming its accountId query param, got %q", got)
+	}
+	if got := send(http.MethodPost, server.URL+"/notification-settings/v3/policies", []byte(`{"policyName":"policy","accountId":9}`), contentTypeApplicationJson, CreateNotificationCenterPolicy); got != "" {
+		t.Errorf("Expected no caid for a request naming its accountId in the JSON body, got %q", got)
+	}
+	if got := send(http.MethodPost, server.URL+"/notification-settings/v3/policies", []byte(`{"policyName":"policy","accountId":0}`), contentTypeApplicationJson, CreateNotificationCenterPolicy); got != "777" {
+		t.Errorf("Expected the default account to be sent as caid for an empty JSON body accountId, got %q", got)
+	}
+	if got := send(http.MethodPost, server.URL+"/account/verify", nil, contentTypeApplicationUrlEncoded, VerifyAccount); got != "" {
+		t.Errorf("Expected no caid for the credentials check, got %q", got)
+	}
+	if got := send(http.MethodPost, server.URL+"/accounts/add", nil, contentTypeApplicationUrlEncoded, CreateAccount); got != "" {
+		t.Errorf("Expected no caid for the account creation, got %q", got)
+	}
+	if got := send(http.MethodPost, server.URL+"/subaccounts/add", nil, contentTypeApplicationUrlEncoded, CreateSubAccount); got != "" {
+		t.Errorf("Expected no caid for the subaccount creation, got %q", got)
+	}
+	if got := send(http.MethodGet, server.URL+"/site-domain-manager/v2/sites/1/domains", nil, contentTypeApplicationJson, ReadDomain); got != "" {
+		t.Errorf("Expected no caid for an API that doesn't take caid, got %q", got)
+	}
+
+	config.DefaultAccountID = 0
+	if got := send(http.MethodGet, server.URL+"/policies/v2/policies/1", nil, contentTypeApplicationJson, ReadPolicy); got != "" {
+		t.Errorf("Expected no caid without a default account, got %q", got)
+	}
+}
+
+func TestDefaultAccountIDPlannedForNewResources(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"api_id":             "mock-api-id",
+		"api_key":            "mock-api-key",
+		"base_url":           mock.URL(),
+		"base_url_rev_2":     mock.URL(),
+		"base_url_rev_3":     mock.URL(),
+		"base_url_api":       mock.URL(),
+		"default_account_id": 777,
+	}))
+	if diags.HasError() {
+		t.Fatalf("Unexpected error configuring provider: %v", diags)
+	}
+
+	for resourceType, attribute := range map[string]string{"incapsula_site": "domain", "incapsula_site_v3": "name"} {
+		res := provider.ResourcesMap[resourceType]
+
+		// The raw config (as sent by Terraform) tells account_id is not set
+		attributes := map[string]cty.Value{}
+		for name, attributeType := range res.CoreConfigSchema().ImpliedType().AttributeTypes() {
+			attributes[name] = cty.NullVal(attributeType)
+		}
+		attributes[attribute] = cty.StringVal("example.com")
+		state := &terraform.InstanceState{RawConfig: cty.ObjectVal(attributes)}
+
+		diff, err := res.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{attribute: "example.com"}), provider.Meta())
+		if err != nil {
+			t.Fatalf("Unexpected error planning %s: %s", resourceType, err)
+		}
+		if attr := diff.Attributes["account_id"]; attr == nil || attr.New != "777" {
+			t.Errorf("Expected %s to plan the default account_id, got %+v", resourceType, attr)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package incapsula
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// MockServerDefaultPort is the port cmd/mock-server listens on by default, used by the "mock" environment
+const MockServerDefaultPort = 19443
+
+const environmentEnv = "INCAPSULA_ENVIRONMENT"
+
+const (
+	environmentProduction = "production"
+	environmentMock       = "mock"
+)
+
+// endpointPreset is the consistent set of base URLs of an environment
+type endpointPreset struct {
+	BaseURL     string
+	BaseURLRev2 string
+	BaseURLRev3 string
+	BaseURLAPI  string
+}
+
+// endpointPresets returns the base URLs of the environments the provider can select with the environment argument
+func endpointPresets() map[string]endpointPreset {
+	mockURL := fmt.Sprintf("http://localhost:%d", MockServerDefaultPort)
+	return map[string]endpointPreset{
+		environmentProduction: {BaseURL: baseURL, BaseURLRev2: baseURLRev2, BaseURLRev3: baseURLRev3, BaseURLAPI: baseURLAPI},
+		environmentMock:       {BaseURL: mockURL, BaseURLRev2: mockURL, BaseURLRev3: mockURL, BaseURLAPI: mockURL},
+	}
+}
+
+func environmentNames() []string {
+	var names []string
+	for name := range endpointPresets() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyEnvironment fills the base URLs that are not set with the preset of the selected environment, and
+// checks that the base URLs don't mix the URLs of different environments (e.g. a production base_url with a
+// mock base_url_api)
+func (c *Config) applyEnvironment() error {
+	presets := endpointPresets()
+	if c.Environment != "" {
+		preset, ok := presets[c.Environment]
+		if !ok {
+			return fmt.Errorf("Unknown environment %s, expected one of %s", c.Environment, strings.Join(environmentNames(), ", "))
+		}
+		setDefault := func(value *string, presetValue string) {
+			if strings.TrimSpace(*value) == "" {
+				*value = presetValue
+			}
+		}
+		setDefault(&c.BaseURL, preset.BaseURL)
+		setDefault(&c.BaseURLRev2, preset.BaseURLRev2)
+		setDefault(&c.BaseURLRev3, preset.BaseURLRev3)
+		setDefault(&c.BaseURLAPI, preset.BaseURLAPI)
+	}
+
+	// The base URLs on a host that is not known (e.g. a custom deployment) are not checked
+	var first, firstEnvironment string
+	for _, baseURL := range []struct{ name, value string }{
+		{"base_url", c.BaseURL}, {"base_url_rev_2", c.BaseURLRev2}, {"base_url_rev_3", c.BaseURLRev3}, {"base_url_api", c.BaseURLAPI},
+	} {
+		environment := urlEnvironment(presets, baseURL.value)
+		if environment == "" {
+			continue
+		}
+		if firstEnvironment == "" {
+			first, firstEnvironment = baseURL.name, environment
+			continue
+		}
+		if environment != firstEnvironment {
+			return fmt.Errorf("Base URLs of different environments: %s is a %s URL but %s is a %s URL", first, firstEnvironment, baseURL.name, environment)
+		}
+	}
+	return nil
+}
+
+// urlEnvironment returns the environment a base URL belongs to: production for the hosts of the production
+// preset, mock for a server on the local machine (on any port), and "" for any other host
+func urlEnvironment(presets map[string]endpointPreset, rawURL string) string {
+	host := urlHost(rawURL)
+	if host == "" {
+		return ""
+	}
+	switch host {
+	case "localhost", "127.0.0.1", "::1":
+		return environmentMock
+	}
+	production := presets[environmentProduction]
+	for _, presetURL := range []string{production.BaseURL, production.BaseURLRev2, production.BaseURLRev3, production.BaseURLAPI} {
+		if host == urlHost(presetURL) {
+			return environmentProduction
+		}
+	}
+	return ""
+}
+
+func urlHost(rawURL string) string {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(parsed.Hostname())
+}
+
+// baseURLV3 returns the v3 API served next to the v1 API of base_url (https://my.incapsula.com/api/prov/v1 gives
+// https://my.incapsula.com/api/prov/v3). A base URL without a version, like the mock server's, is used as is.
+func (c *Config) baseURLV3() string {
+	if strings.HasSuffix(c.BaseURL, "/v1") {
+		return strings.TrimSuffix(c.BaseURL, "/v1") + "/v3"
+	}
+	return c.BaseURL
+}
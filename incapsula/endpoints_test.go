@@ -0,0 +1,72 @@
+package incapsula
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestApplyEnvironment(t *testing.T) {
+	config := Config{Environment: environmentProduction}
+	if err := config.applyEnvironment(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if config.BaseURL != baseURL || config.BaseURLRev2 != baseURLRev2 || config.BaseURLRev3 != baseURLRev3 || config.BaseURLAPI != baseURLAPI {
+		t.Errorf("Expected the production base URLs, got %+v", config)
+	}
+
+	// Explicit base URLs override the preset
+	config = Config{Environment: environmentMock, BaseURLAPI: "http://localhost:8080"}
+	if err := config.applyEnvironment(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if config.BaseURL != "http://localhost:19443" || config.BaseURLAPI != "http://localhost:8080" {
+		t.Errorf("Expected the mock base URLs and the explicit API URL, got %+v", config)
+	}
+
+	config = Config{Environment: "staging"}
+	if err := config.applyEnvironment(); err == nil || !strings.HasPrefix(err.Error(), "Unknown environment staging") {
+		t.Errorf("Expected an unknown environment error, got: %v", err)
+	}
+}
+
+func TestApplyEnvironmentMixedURLs(t *testing.T) {
+	config := Config{Environment: environmentProduction, BaseURLAPI: "http://localhost:19443/"}
+	err := config.applyEnvironment()
+	if err == nil || err.Error() != "Base URLs of different environments: base_url is a production URL but base_url_api is a mock URL" {
+		t.Errorf("Expected a mixed environments error, got: %v", err)
+	}
+
+	// Any port of the local machine is a mock URL, and the other production paths are production URLs
+	config = Config{BaseURL: baseURL, BaseURLRev2: "https://my.imperva.com/api/prov/v2/", BaseURLAPI: "http://127.0.0.1:8080"}
+	err = config.applyEnvironment()
+	if err == nil || err.Error() != "Base URLs of different environments: base_url is a production URL but base_url_api is a mock URL" {
+		t.Errorf("Expected a mixed environments error, got: %v", err)
+	}
+
+	// The URLs of unknown hosts are not checked
+	config = Config{Environment: environmentProduction, BaseURLAPI: "https://imperva-proxy.example.com"}
+	if err := config.applyEnvironment(); err != nil {
+		t.Errorf("Unexpected error for a custom host: %s", err)
+	}
+
+	// The environment is also checked when the client is created
+	isolateCredentialsEnv(t)
+	config = Config{APIID: "foo", APIKey: "bar", BaseURL: baseURL, BaseURLRev2: baseURLRev2, BaseURLRev3: baseURLRev3, BaseURLAPI: "http://localhost:19443"}
+	if _, err := config.Client(context.Background()); err == nil || !strings.HasPrefix(err.Error(), "Base URLs of different environments") {
+		t.Errorf("Expected a mixed environments error, got: %v", err)
+	}
+}
+
+func TestConfigBaseURLV3(t *testing.T) {
+	config := Config{BaseURL: baseURL}
+	if config.baseURLV3() != "https://my.incapsula.com/api/prov/v3" {
+		t.Errorf("Expected the production v3 API, got %s", config.baseURLV3())
+	}
+
+	// A base URL without a version is used as is, instead of losing its last three characters
+	config = Config{BaseURL: "http://localhost:19443"}
+	if config.baseURLV3() != "http://localhost:19443" {
+		t.Errorf("Expected the base URL to be used as is, got %s", config.baseURLV3())
+	}
+}
@@ -1,12 +1,13 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 )
 
-func updateHttp2Properties(client *Client, d *schema.ResourceData) error {
+func updateHttp2Properties(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	enableHttp2ForNewSiteChanged := d.HasChange("enable_http2_for_new_sites") && d.Get("enable_http2_for_new_sites") != ""
 	enableHttp2ToOriginForNewSitesChanged := d.HasChange("enable_http2_to_origin_for_new_sites") && d.Get("enable_http2_to_origin_for_new_sites") != ""
 
@@ -25,7 +26,7 @@ func updateHttp2Properties(client *Client, d *schema.ResourceData) error {
 
 	updateParamsList := getParamsToUpdateInOrder(enableHttp2ForNewSiteChanged, enableHttp2ToOriginForNewSitesChanged, d)
 
-	return updateParams(client, d, updateParamsList)
+	return updateParams(ctx, client, d, updateParamsList)
 }
 
 func getParamsToUpdateInOrder(enableHttp2ForNewSiteChanged bool, enableHttp2ToOriginForNewSitesChanged bool, d *schema.ResourceData) []string {
@@ -43,12 +44,12 @@ func getParamsToUpdateInOrder(enableHttp2ForNewSiteChanged bool, enableHttp2ToOr
 	return updateParamsList
 }
 
-func updateParams(client *Client, d *schema.ResourceData, updateParams []string) error {
+func updateParams(ctx context.Context, client *Client, d *schema.ResourceData, updateParams []string) error {
 	for i := 0; i < len(updateParams); i++ {
 		param := updateParams[i]
 		if d.HasChange(param) && d.Get(param) != "" {
 			log.Printf("[INFO] Updating Incapsula account param (%s) with value (%s) for account_id: %s\n", param, d.Get(param), d.Id())
-			_, err := client.UpdateAccount(d.Id(), param, d.Get(param).(string))
+			_, err := client.UpdateAccount(ctx, d.Id(), param, d.Get(param).(string))
 			if err != nil {
 				log.Printf("[ERROR] Could not update Incapsula account param (%s) with value (%t) for account_id: %s %s\n", param, d.Get(param).(bool), d.Id(), err)
 				return err
@@ -0,0 +1,236 @@
+package incapsula
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const redactedValue = "REDACTED"
+
+// Headers never written to the logs (compared in canonical form)
+var secretHeaders = map[string]bool{
+	"X-Api-Key":           true,
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+// Body fields never written to the logs, compared lower case and without "_" and "-"
+// (so "private_key", "privateKey" and "private-key" all match "privatekey")
+var secretFields = map[string]bool{
+	"password":          true,
+	"passphrase":        true,
+	"privatekey":        true,
+	"secret":            true,
+	"secretkey":         true,
+	"clientsecret":      true,
+	"accesskey":         true,
+	"apikey":            true,
+	"token":             true,
+	"kickstartpass":     true,
+	"kickstartpassword": true,
+}
+
+func isSecretField(name string) bool {
+	normalized := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+	return secretFields[normalized] || strings.HasSuffix(normalized, "password")
+}
+
+type requestAttemptKey struct{}
+
+// withRequestAttempt stores the attempt number (1 for the first attempt) of a request in its context,
+// so that the logging transport can report it
+func withRequestAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, requestAttemptKey{}, attempt)
+}
+
+func requestAttempt(ctx context.Context) int {
+	if attempt, ok := ctx.Value(requestAttemptKey{}).(int); ok {
+		return attempt
+	}
+	return 1
+}
+
+// loggingTransport logs one line per API call (method, path, operation, status, latency and attempt).
+// When a body log file is configured, it also writes the request and response headers and bodies to
+// that file, with the secret headers and fields redacted.
+type loggingTransport struct {
+	next    http.RoundTripper
+	bodyLog *bodyLogFile
+}
+
+func newLoggingTransport(next http.RoundTripper, bodyLog *bodyLogFile) *loggingTransport {
+	return &loggingTransport{next: next, bodyLog: bodyLog}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if t.bodyLog != nil && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			requestBody, _ = io.ReadAll(body)
+			body.Close()
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	latency := time.Since(start)
+
+	operation := req.Header.Get("x-tf-operation")
+	attempt := requestAttempt(req.Context())
+	if err != nil {
+		log.Printf("[DEBUG] Incapsula API call: method=%s path=%s operation=%s status=error latency=%dms attempt=%d error=%q\n",
+			req.Method, req.URL.Path, operation, latency.Milliseconds(), attempt, err)
+	} else {
+		log.Printf("[DEBUG] Incapsula API call: method=%s path=%s operation=%s status=%d latency=%dms attempt=%d\n",
+			req.Method, req.URL.Path, operation, resp.StatusCode, latency.Milliseconds(), attempt)
+	}
+
+	if t.bodyLog != nil {
+		var responseBody []byte
+		if resp != nil && resp.Body != nil {
+			responseBody, _ = io.ReadAll(resp.Body)
+			resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+		}
+		t.bodyLog.write(req, requestBody, resp, responseBody, err, latency, attempt)
+	}
+
+	return resp, err
+}
+
+// bodyLogFile is the file the full (redacted) request and response bodies are written to
+type bodyLogFile struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func openBodyLogFile(path string) (*bodyLogFile, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("Error opening log body file (log_body_file) %s: %w", path, err)
+	}
+	return &bodyLogFile{file: file}, nil
+}
+
+func (f *bodyLogFile) write(req *http.Request, requestBody []byte, resp *http.Response, responseBody []byte, callErr error, latency time.Duration, attempt int) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== %s %s %s (operation=%s attempt=%d latency=%dms)\n",
+		time.Now().UTC().Format(time.RFC3339), req.Method, redactedURL(req.URL), req.Header.Get("x-tf-operation"), attempt, latency.Milliseconds())
+	writeRedactedHeaders(&b, req.Header)
+	b.WriteString(redactedBody(req.Header.Get("Content-Type"), requestBody))
+	b.WriteString("\n--- response")
+	if callErr != nil {
+		fmt.Fprintf(&b, ": %s\n", callErr)
+	} else {
+		fmt.Fprintf(&b, ": %s\n", resp.Status)
+		writeRedactedHeaders(&b, resp.Header)
+		b.WriteString(redactedBody(resp.Header.Get("Content-Type"), responseBody))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.file.WriteString(b.String()); err != nil {
+		log.Printf("[WARN] Error writing to the log body file %s: %s\n", f.file.Name(), err)
+	}
+}
+
+func writeRedactedHeaders(b *strings.Builder, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if secretHeaders[http.CanonicalHeaderKey(name)] {
+			value = redactedValue
+		}
+		fmt.Fprintf(b, "%s: %s\n", name, value)
+	}
+}
+
+// redactedURL returns the URL with the values of secret query parameters redacted
+func redactedURL(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.String()
+	}
+	redacted := *u
+	redacted.RawQuery = redactedForm(u.Query())
+	return redacted.String()
+}
+
+// redactedForm encodes form values with the values of secret fields redacted
+func redactedForm(values url.Values) string {
+	redacted := url.Values{}
+	for name, fieldValues := range values {
+		if isSecretField(name) {
+			redacted[name] = []string{redactedValue}
+		} else {
+			redacted[name] = fieldValues
+		}
+	}
+	return redacted.Encode()
+}
+
+// redactedBody returns a request or response body with the values of secret fields redacted.
+// JSON and form bodies are redacted field by field, multipart bodies (certificate uploads) are omitted.
+func redactedBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	switch {
+	case strings.HasPrefix(contentType, "multipart/"):
+		return fmt.Sprintf("[multipart body omitted, %d bytes]", len(body))
+	case strings.HasPrefix(contentType, contentTypeApplicationUrlEncoded):
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return fmt.Sprintf("[form body omitted, %d bytes]", len(body))
+		}
+		return redactedForm(values)
+	}
+
+	var parsed interface{}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return string(body)
+	}
+	redacted, err := json.Marshal(redactJSONValue(parsed))
+	if err != nil {
+		return fmt.Sprintf("[body omitted, %d bytes]", len(body))
+	}
+	return string(redacted)
+}
+
+func redactJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, fieldValue := range v {
+			if isSecretField(name) {
+				if fieldValue != nil && fieldValue != "" {
+					v[name] = redactedValue
+				}
+			} else {
+				v[name] = redactJSONValue(fieldValue)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactJSONValue(v[i])
+		}
+	}
+	return value
+}
@@ -0,0 +1,133 @@
+package incapsula
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRedactedBodyJSON(t *testing.T) {
+	body := []byte(`{"data":[{"name":"dc","kickStartURL":"https://kick","kickStartPass":"p4ss","servers":[{"address":"1.2.3.4"}]}],"private_key":"KEY","connectionInfo":{"accessKey":"AK","secretKey":"SK","bucket":"logs"},"password":""}`)
+
+	redacted := redactedBody(contentTypeApplicationJson, body)
+
+	for _, secret := range []string{"p4ss", "KEY", "AK", "SK"} {
+		if strings.Contains(redacted, `"`+secret+`"`) {
+			t.Errorf("Secret %s was not redacted: %s", secret, redacted)
+		}
+	}
+	for _, value := range []string{"https://kick", "1.2.3.4", "logs", `"password":""`} {
+		if !strings.Contains(redacted, value) {
+			t.Errorf("Expected %s to be kept: %s", value, redacted)
+		}
+	}
+}
+
+func TestRedactedBodyFormAndMultipart(t *testing.T) {
+	form := url.Values{"site_id": {"42"}, "certificate": {"CERT"}, "private_key": {"KEY"}, "passphrase": {"secret"}}
+
+	redacted := redactedBody(contentTypeApplicationUrlEncoded, []byte(form.Encode()))
+	if strings.Contains(redacted, "KEY") || strings.Contains(redacted, "=secret") {
+		t.Errorf("Form secrets were not redacted: %s", redacted)
+	}
+	if !strings.Contains(redacted, "site_id=42") || !strings.Contains(redacted, "certificate=CERT") {
+		t.Errorf("Expected the other form fields to be kept: %s", redacted)
+	}
+
+	redacted = redactedBody("multipart/form-data; boundary=x", []byte("--x\r\nKEY\r\n--x--"))
+	if strings.Contains(redacted, "KEY") {
+		t.Errorf("Multipart body was not omitted: %s", redacted)
+	}
+
+	if redactedBody(contentTypeApplicationJson, []byte("<html>error</html>")) != "<html>error</html>" {
+		t.Errorf("Expected a non JSON body to be kept")
+	}
+}
+
+func TestLoggingTransport(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		attempts++
+		if attempts == 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		rw.Header().Set("Content-Type", contentTypeApplicationJson)
+		rw.Write([]byte(`{"data":[{"id":1,"token":"response-token"}]}`))
+	}))
+	defer server.Close()
+
+	var logOutput bytes.Buffer
+	log.SetOutput(&logOutput)
+	defer log.SetOutput(os.Stderr)
+
+	bodyLogPath := filepath.Join(t.TempDir(), "bodies.log")
+	config := &Config{APIID: "foo", APIKey: "super-secret-key", BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLRev3: server.URL, BaseURLAPI: server.URL,
+		Retry: &RetryPolicy{MaxAttempts: 2}, LogBodyFile: bodyLogPath}
+	httpClient, err := config.httpClient()
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	client := NewClient(config)
+	client.httpClient = httpClient
+
+	resp, err := client.DoJsonRequestWithHeaders(context.Background(), http.MethodPost, server.URL+"/sites/42/settings", []byte(`{"password":"request-password","name":"site"}`), ReadIncapRule)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	responseBody, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(responseBody), "response-token") {
+		t.Errorf("The response body returned to the caller should not be redacted: %s", responseBody)
+	}
+
+	logs := logOutput.String()
+	for _, line := range []string{
+		"method=POST path=/sites/42/settings operation=" + ReadIncapRule + " status=503",
+		"attempt=1",
+		"method=POST path=/sites/42/settings operation=" + ReadIncapRule + " status=200",
+		"attempt=2",
+	} {
+		if !strings.Contains(logs, line) {
+			t.Errorf("Expected the logs to contain %q, got:\n%s", line, logs)
+		}
+	}
+
+	dump, err := os.ReadFile(bodyLogPath)
+	if err != nil {
+		t.Fatalf("Error reading the body log file: %s", err)
+	}
+	for _, secret := range []string{"super-secret-key", "request-password", "response-token"} {
+		if strings.Contains(string(dump), secret) || strings.Contains(logs, secret) {
+			t.Errorf("Secret %s was written to the logs", secret)
+		}
+	}
+	if !strings.Contains(string(dump), `"name":"site"`) || !strings.Contains(string(dump), "X-Api-Key: "+redactedValue) {
+		t.Errorf("Expected the redacted request to be in the body log file, got:\n%s", dump)
+	}
+}
+
+func TestRequestAttemptDefault(t *testing.T) {
+	if requestAttempt(context.Background()) != 1 {
+		t.Errorf("Expected attempt 1 for a request without attempt number")
+	}
+	if requestAttempt(withRequestAttempt(context.Background(), 3)) != 3 {
+		t.Errorf("Expected attempt 3")
+	}
+}
+
+func TestInvalidLogBodyFile(t *testing.T) {
+	config := Config{LogBodyFile: filepath.Join(t.TempDir(), "missing", "bodies.log"), RequestTimeout: time.Second}
+	if _, err := config.httpClient(); err == nil || !strings.HasPrefix(err.Error(), "Error opening log body file (log_body_file)") {
+		t.Errorf("Should have received a log body file error, got: %v", err)
+	}
+}
@@ -12,6 +12,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -25,6 +26,10 @@ import (
 	"time"
 )
 
+// mockAPIKeyAccountID is the account of the mock API key, returned by the credentials check. It is the reseller at the
+// root of the account hierarchy.
+const mockAPIKeyAccountID = 1000
+
 // MockImpervaServer provides a mock implementation of the Imperva API for testing
 type MockImpervaServer struct {
 	Server *httptest.Server
@@ -34,17 +39,76 @@ type MockImpervaServer struct {
 	accounts map[int]*MockAccount
 	sites    map[int]*MockSite
 
+	// API keys by API ID, each bound to an account
+	apiKeys map[string]*MockAPIKey
+
 	// CSP domain storage: map[siteID]map[domain]*MockCSPDomain
 	cspDomains map[int]map[string]*MockCSPDomain
 
+	// Rules storage: map[siteID]map[ruleID] for incap and cache rules, map[siteID]map[category] for delivery rules
+	incapRules    map[int]map[int]*IncapRuleWithID
+	cacheRules    map[int]map[int]*CacheRuleWithID
+	deliveryRules map[int]map[string][]DeliveryRuleDto
+
+	// Policies storage: policies by ID, policy IDs by asset ("WEBSITE/{siteId}") and policy associations by account
+	policies        map[int]*Policy
+	policyAssets    map[string]map[int]bool
+	accountPolicies map[int]*AccountPolicyAssociationV3
+
+	// Data centers storage: map[siteID]
+	dataCenters map[int]*MockDataCentersConfiguration
+
+	// SIEM storage: connections and log configurations by ID
+	siemConnections       map[string]*MockSiemConnection
+	siemLogConfigurations map[string]*MockSiemLogConfiguration
+
+	// Certificates storage: custom certificates by site and auth type, managed certificates by site
+	customCertificates  map[int]map[string]*MockCustomCertificate
+	managedCertificates map[int]*SiteCertificateDTO
+
+	// Mutual TLS storage: certificates by ID, the Imperva to origin certificate of each site, the client CA
+	// certificates assigned to each site and the client certificate settings by site
+	mtlsOriginCertificates map[int]*MockMTLSCertificate
+	mtlsOriginSites        map[int]int
+	clientCaCertificates   map[int]*MockClientCaCertificate
+	clientCaSites          map[int]map[int]bool
+	siteTlsSettings        map[int]*SiteTlsSettings
+
+	// User management storage: users by account and email, roles and API clients by ID
+	users      map[int]map[string]*MockUser
+	roles      map[int]*MockRole
+	apiClients map[int]*MockAPIClient
+
+	// Site settings storage: the settings documents by site, waiting rooms by ID
+	siteSettings map[int]*MockSiteSettings
+	waitingRooms map[int]*MockWaitingRoom
+
+	// Injected faults, in the order they were added
+	faults []*MockFault
+
+	// Request journal, oldest first
+	requests []MockRequest
+
 	// ID generators
-	nextAccountID int
-	nextSiteID    int
+	nextAccountID      int
+	nextSiteID         int
+	nextRuleID         int
+	nextPolicyID       int
+	nextDataCenterID   int
+	nextOriginServerID int
+	nextSiemID         int
+	nextCertificateID  int
+	nextRoleID         int
+	nextUserID         int
+	nextAPIClientID    int
+	nextWaitingRoomID  int
+	nextFaultID        int
 }
 
 // MockAccount represents an account in the mock server
 type MockAccount struct {
 	AccountID    int         `json:"account_id"`
+	AccountType  string      `json:"account_type,omitempty"`
 	Email        string      `json:"email"`
 	ParentID     int         `json:"parent_id"`
 	AccountName  string      `json:"account_name"`
@@ -107,11 +171,44 @@ type MockCSPStatus struct {
 // NewMockImpervaServer creates a new mock server instance
 func NewMockImpervaServer() *MockImpervaServer {
 	mock := &MockImpervaServer{
-		accounts:      make(map[int]*MockAccount),
-		sites:         make(map[int]*MockSite),
-		cspDomains:    make(map[int]map[string]*MockCSPDomain),
-		nextAccountID: 1000,
-		nextSiteID:    10000,
+		accounts:               make(map[int]*MockAccount),
+		sites:                  make(map[int]*MockSite),
+		apiKeys:                make(map[string]*MockAPIKey),
+		cspDomains:             make(map[int]map[string]*MockCSPDomain),
+		incapRules:             make(map[int]map[int]*IncapRuleWithID),
+		cacheRules:             make(map[int]map[int]*CacheRuleWithID),
+		deliveryRules:          make(map[int]map[string][]DeliveryRuleDto),
+		policies:               make(map[int]*Policy),
+		policyAssets:           make(map[string]map[int]bool),
+		accountPolicies:        make(map[int]*AccountPolicyAssociationV3),
+		dataCenters:            make(map[int]*MockDataCentersConfiguration),
+		siemConnections:        make(map[string]*MockSiemConnection),
+		siemLogConfigurations:  make(map[string]*MockSiemLogConfiguration),
+		customCertificates:     make(map[int]map[string]*MockCustomCertificate),
+		managedCertificates:    make(map[int]*SiteCertificateDTO),
+		mtlsOriginCertificates: make(map[int]*MockMTLSCertificate),
+		mtlsOriginSites:        make(map[int]int),
+		clientCaCertificates:   make(map[int]*MockClientCaCertificate),
+		clientCaSites:          make(map[int]map[int]bool),
+		siteTlsSettings:        make(map[int]*SiteTlsSettings),
+		users:                  make(map[int]map[string]*MockUser),
+		roles:                  make(map[int]*MockRole),
+		apiClients:             make(map[int]*MockAPIClient),
+		siteSettings:           make(map[int]*MockSiteSettings),
+		waitingRooms:           make(map[int]*MockWaitingRoom),
+		nextAccountID:          mockAPIKeyAccountID + 1,
+		nextSiteID:             10000,
+		nextRuleID:             50000,
+		nextPolicyID:           200000,
+		nextDataCenterID:       300000,
+		nextOriginServerID:     400000,
+		nextSiemID:             1,
+		nextCertificateID:      500000,
+		nextRoleID:             600000,
+		nextUserID:             700000,
+		nextAPIClientID:        800000,
+		nextWaitingRoomID:      900000,
+		nextFaultID:            1,
 	}
 
 	// Create the HTTP server with the router
@@ -142,6 +239,31 @@ func (m *MockImpervaServer) router(w http.ResponseWriter, r *http.Request) {
 	// Remove leading slash for matching
 	path = strings.TrimPrefix(path, "/")
 
+	// Control plane endpoints are neither journaled nor subject to faults
+	switch {
+	case mockFaultsPattern.MatchString(path):
+		m.handleFaults(w, r, path)
+		return
+	case path == mockRequestsPath:
+		m.handleRequests(w, r)
+		return
+	}
+
+	recorder := m.startRequestRecord(w, r, path)
+	defer m.endRequestRecord(recorder)
+	w = recorder
+
+	if m.injectFault(w, r, path) {
+		return
+	}
+
+	// Requests are made on behalf of the account of their API key
+	callerID, authorized := m.authorizeRequest(w, r, path)
+	if !authorized {
+		return
+	}
+	r = r.WithContext(context.WithValue(r.Context(), mockCallerKey{}, callerID))
+
 	// Account endpoints
 	switch {
 	case path == "accounts/add" && r.Method == http.MethodPost:
@@ -158,6 +280,20 @@ func (m *MockImpervaServer) router(w http.ResponseWriter, r *http.Request) {
 		m.handleDataPrivacyShow(w, r)
 	case path == "accounts/data-privacy/set-region-default" && r.Method == http.MethodPost:
 		m.handleDataPrivacySetRegionDefault(w, r)
+	case path == "subaccounts/add" && r.Method == http.MethodPost:
+		m.handleSubAccountAdd(w, r)
+	case path == "subaccounts/delete" && r.Method == http.MethodPost:
+		m.handleSubAccountDelete(w, r)
+
+	// User management endpoints
+	case mockAbilitiesPattern.MatchString(path):
+		m.handleAbilities(w, r, path)
+	case mockRolesPattern.MatchString(path):
+		m.handleRoles(w, r, path)
+	case mockUsersPattern.MatchString(path):
+		m.handleUsers(w, r, path)
+	case mockAPIClientsPattern.MatchString(path):
+		m.handleAPIClients(w, r, path)
 
 	// Site endpoints
 	case path == "sites/add" && r.Method == http.MethodPost:
@@ -169,6 +305,84 @@ func (m *MockImpervaServer) router(w http.ResponseWriter, r *http.Request) {
 	case path == "sites/delete" && r.Method == http.MethodPost:
 		m.handleSiteDelete(w, r)
 
+	// Rules endpoints
+	case mockIncapRulesPattern.MatchString(path):
+		m.handleIncapRules(w, r, path)
+	case mockCacheRulesPattern.MatchString(path):
+		m.handleCacheRules(w, r, path)
+	case mockDeliveryRulesPattern.MatchString(path):
+		m.handleDeliveryRules(w, r, path)
+
+	// Policies endpoints
+	case mockPoliciesPattern.MatchString(path):
+		m.handlePolicies(w, r, path)
+	case mockPolicyAssetPattern.MatchString(path):
+		m.handlePolicyAssetStatus(w, r, path)
+	case mockAssetPolicyPattern.MatchString(path):
+		m.handleAssetPolicy(w, r, path)
+	case path == mockAccountAssociatedPoliciesRoute:
+		m.handleAccountAssociatedPolicies(w, r)
+
+	// Data centers endpoints
+	case mockDataCentersConfigurationPattern.MatchString(path):
+		m.handleDataCentersConfiguration(w, r, path)
+	case path == "sites/dataCenters/add" && r.Method == http.MethodPost:
+		m.handleDataCenterAdd(w, r)
+	case path == "sites/dataCenters/list" && r.Method == http.MethodPost:
+		m.handleDataCenterList(w, r)
+	case path == "sites/dataCenters/edit" && r.Method == http.MethodPost:
+		m.handleDataCenterEdit(w, r)
+	case path == "sites/dataCenters/delete" && r.Method == http.MethodPost:
+		m.handleDataCenterDelete(w, r)
+	case path == "sites/dataCenters/servers/add" && r.Method == http.MethodPost:
+		m.handleDataCenterServerAdd(w, r)
+	case path == "sites/dataCenters/servers/edit" && r.Method == http.MethodPost:
+		m.handleDataCenterServerEdit(w, r)
+	case path == "sites/dataCenters/servers/delete" && r.Method == http.MethodPost:
+		m.handleDataCenterServerDelete(w, r)
+
+	// SIEM endpoints
+	case mockSiemConnectionsPattern.MatchString(path):
+		m.handleSiemConnections(w, r, path)
+	case mockSiemLogConfigurationsPattern.MatchString(path):
+		m.handleSiemLogConfigurations(w, r, path)
+
+	// Certificates endpoints
+	case path == "sites/customCertificate/upload" && r.Method == http.MethodPost:
+		m.handleCustomCertificateUpload(w, r)
+	case path == "sites/customCertificate/remove" && r.Method == http.MethodPost:
+		m.handleCustomCertificateRemove(w, r)
+	case mockHsmCertificatePattern.MatchString(path):
+		m.handleHsmCertificate(w, r, path)
+	case mockManagedCertificatePattern.MatchString(path):
+		m.handleManagedCertificate(w, r, path)
+
+	// Mutual TLS endpoints
+	case mockMTLSOriginCertificatesPattern.MatchString(path):
+		m.handleMTLSOriginCertificates(w, r, path)
+	case mockClientCaCertificatesPattern.MatchString(path):
+		m.handleClientCaCertificates(w, r, path)
+	case mockSiteClientCaCertificates.MatchString(path):
+		m.handleSiteClientCaCertificates(w, r, path)
+	case mockSiteTlsSettingsPattern.MatchString(path):
+		m.handleSiteTlsSettings(w, r, path)
+
+	// Site settings endpoints
+	case mockDeliverySettingsPattern.MatchString(path):
+		m.handleDeliverySettings(w, r, path)
+	case mockErrorPagesPattern.MatchString(path):
+		m.handleErrorPages(w, r, path)
+	case mockCacheSettingsPattern.MatchString(path):
+		m.handleCacheSettings(w, r, path)
+	case mockTXTRecordsPattern.MatchString(path):
+		m.handleTXTRecords(w, r, path)
+	case mockSiteMonitoringPattern.MatchString(path):
+		m.handleSiteMonitoring(w, r, path)
+	case mockSiteSSLSettingsPattern.MatchString(path):
+		m.handleSiteSSLSettings(w, r, path)
+	case mockWaitingRoomsPattern.MatchString(path):
+		m.handleWaitingRooms(w, r, path)
+
 	// CSP API endpoints
 	case strings.HasPrefix(path, "csp-api/v1/sites/"):
 		m.handleCSPAPI(w, r, path)
@@ -206,6 +420,14 @@ func (m *MockImpervaServer) writeSuccessResponse(w http.ResponseWriter, data int
 	}
 }
 
+// requestAccountID returns the account a request operates on: the caid query param, or the account of the API key
+func (m *MockImpervaServer) requestAccountID(r *http.Request) int {
+	if caid, err := strconv.Atoi(r.URL.Query().Get("caid")); err == nil && caid != 0 {
+		return caid
+	}
+	return mockCallerAccountID(r)
+}
+
 // parseFormValue extracts a form value from the request
 func (m *MockImpervaServer) parseFormValue(r *http.Request, key string) string {
 	if r.Form == nil {
@@ -236,6 +458,16 @@ func (m *MockImpervaServer) handleAccountAdd(w http.ResponseWriter, r *http.Requ
 	email := m.parseFormValue(r, "email")
 	userName := m.parseFormValue(r, "user_name")
 
+	// Accounts are added under a reseller, the account of the API key by default
+	parentID := m.parseFormInt(r, "parent_id")
+	if parentID == 0 {
+		parentID = mockCallerAccountID(r)
+	}
+	if parent := m.lookupAccount(parentID); parent == nil || parent.AccountType != MockAccountTypeReseller {
+		m.writeErrorResponse(w, apiV1ResOperationNotAllowed, fmt.Sprintf("Operation not allowed: account %d is not a reseller", parentID))
+		return
+	}
+
 	accountID := m.nextAccountID
 	m.nextAccountID++
 
@@ -249,8 +481,9 @@ func (m *MockImpervaServer) handleAccountAdd(w http.ResponseWriter, r *http.Requ
 
 	account := &MockAccount{
 		AccountID:    accountID,
+		AccountType:  MockAccountTypeCustomer,
 		Email:        email,
-		ParentID:     m.parseFormInt(r, "parent_id"),
+		ParentID:     parentID,
 		AccountName:  m.parseFormValue(r, "account_name"),
 		PlanID:       m.parseFormValue(r, "plan_id"),
 		RefID:        m.parseFormValue(r, "ref_id"),
@@ -276,17 +509,19 @@ func (m *MockImpervaServer) handleAccountAdd(w http.ResponseWriter, r *http.Requ
 	m.writeJSONResponse(w, response)
 }
 
-// handleAccountVerify handles POST /account/verify (lightweight credential verification)
+// handleAccountVerify handles POST /account/verify (lightweight credential verification), describing the account of
+// the API key
 func (m *MockImpervaServer) handleAccountVerify(w http.ResponseWriter, r *http.Request) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
+	account := m.lookupAccount(mockCallerAccountID(r))
 	response := map[string]interface{}{
-		"account_type": "Reseller Customer",
-		"account_id":   1000,
-		"parent_id":    0,
-		"account_name": "test account",
-		"plan_id":      "ent100",
+		"account_type": mockAccountType(account),
+		"account_id":   account.AccountID,
+		"parent_id":    account.ParentID,
+		"account_name": account.AccountName,
+		"plan_id":      account.PlanID,
 		"plan_name":    "ENTERPRISE",
 		"res":          0,
 		"res_message":  "OK",
@@ -306,44 +541,14 @@ func (m *MockImpervaServer) handleAccountStatus(w http.ResponseWriter, r *http.R
 	r.ParseForm()
 	accountID := m.parseFormInt(r, "account_id")
 
-	// If no account_id specified, return default account status (for credential verification)
+	// Without account_id, the status of the account of the API key is returned (for credential verification)
 	if accountID == 0 {
-		defaultLogins := []map[string]interface{}{
-			{"login_id": 1000.0, "email": "test@example.com", "email_verified": true},
-		}
-		response := map[string]interface{}{
-			"res":         0,
-			"res_message": "OK",
-			"account_id":  1000,
-			"email":       "test@example.com",
-			"plan_name":   "Enterprise",
-			"plan_id":     "entTrial",
-			"user_name":   "test",
-			"logins":      defaultLogins,
-			"account": map[string]interface{}{
-				"account_id":                           1000,
-				"email":                                "test@example.com",
-				"plan_name":                            "Enterprise",
-				"plan_id":                              "entTrial",
-				"user_name":                            "test",
-				"trial_end_date":                       "",
-				"logins":                               defaultLogins,
-				"support_level":                        "Standard",
-				"supprt_all_tls_versions":              false,
-				"wildcard_san_for_new_sites":           "Default",
-				"naked_domain_san_for_new_www_sites":   true,
-				"inactivity_timeout":                   15,
-				"enable_http2_for_new_sites":           true,
-				"enable_http2_to_origin_for_new_sites": false,
-			},
-		}
-		m.writeJSONResponse(w, response)
-		return
+		accountID = mockCallerAccountID(r)
 	}
 
-	account, exists := m.accounts[accountID]
-	if !exists {
-		m.writeErrorResponse(w, 9413, "Unknown/unauthorized account_id")
+	account := m.lookupAccount(accountID)
+	if account == nil {
+		m.writeErrorResponse(w, apiV1ResUnknownAccountID, "Unknown/unauthorized account_id")
 		return
 	}
 
@@ -367,7 +572,7 @@ func (m *MockImpervaServer) handleAccountStatus(w http.ResponseWriter, r *http.R
 		"plan_id":      account.PlanID,
 		"user_name":    account.UserName,
 		"ref_id":       account.RefID,
-		"account_type": "SubAccount",
+		"account_type": mockAccountType(account),
 		"logins":       loginsInterface,
 		"account": map[string]interface{}{
 			"account_id":                           account.AccountID,
@@ -402,11 +607,13 @@ func (m *MockImpervaServer) handleAccountUpdate(w http.ResponseWriter, r *http.R
 	r.ParseForm()
 	accountID := m.parseFormInt(r, "account_id")
 
-	account, exists := m.accounts[accountID]
-	if !exists {
-		m.writeErrorResponse(w, 9413, "Unknown/unauthorized account_id")
+	account := m.lookupAccount(accountID)
+	if account == nil {
+		m.writeErrorResponse(w, apiV1ResUnknownAccountID, "Unknown/unauthorized account_id")
 		return
 	}
+	// The default account is stored once updated
+	m.accounts[account.AccountID] = account
 
 	// API uses param/value pattern for updates
 	param := m.parseFormValue(r, "param")
@@ -455,8 +662,12 @@ func (m *MockImpervaServer) handleAccountDelete(w http.ResponseWriter, r *http.R
 	r.ParseForm()
 	accountID := m.parseFormInt(r, "account_id")
 
-	if _, exists := m.accounts[accountID]; !exists {
-		m.writeErrorResponse(w, 9413, "Unknown/unauthorized account_id")
+	if m.lookupAccount(accountID) == nil {
+		m.writeErrorResponse(w, apiV1ResUnknownAccountID, "Unknown/unauthorized account_id")
+		return
+	}
+	if accountID == mockCallerAccountID(r) {
+		m.writeErrorResponse(w, apiV1ResOperationNotAllowed, "Operation not allowed: an account can't delete itself")
 		return
 	}
 
@@ -501,6 +712,12 @@ func (m *MockImpervaServer) handleSiteAdd(w http.ResponseWriter, r *http.Request
 	r.ParseForm()
 	domain := m.parseFormValue(r, "domain")
 
+	// Sites are added to the account of the API key by default
+	accountID := m.parseFormInt(r, "account_id")
+	if accountID == 0 {
+		accountID = mockCallerAccountID(r)
+	}
+
 	// Generate new site ID
 	siteID := m.nextSiteID
 	m.nextSiteID++
@@ -508,7 +725,7 @@ func (m *MockImpervaServer) handleSiteAdd(w http.ResponseWriter, r *http.Request
 	// Create site
 	site := &MockSite{
 		SiteID:     siteID,
-		AccountID:  m.parseFormInt(r, "account_id"),
+		AccountID:  accountID,
 		Domain:     domain,
 		Status:     "pending",
 		SiteType:   m.parseFormValue(r, "site_type"),
@@ -518,6 +735,7 @@ func (m *MockImpervaServer) handleSiteAdd(w http.ResponseWriter, r *http.Request
 	}
 
 	m.sites[siteID] = site
+	m.applyDefaultPolicies(site)
 
 	// Return response
 	response := map[string]interface{}{
@@ -569,6 +787,7 @@ func (m *MockImpervaServer) handleSiteStatus(w http.ResponseWriter, r *http.Requ
 				"set_data_to":     []string{site.DnsARecord},
 			},
 		},
+		"ssl": m.siteSSLStatus(site),
 	}
 	m.writeJSONResponse(w, response)
 }
@@ -629,6 +848,20 @@ func (m *MockImpervaServer) handleSiteDelete(w http.ResponseWriter, r *http.Requ
 	}
 
 	delete(m.sites, siteID)
+	delete(m.incapRules, siteID)
+	delete(m.cacheRules, siteID)
+	delete(m.deliveryRules, siteID)
+	delete(m.policyAssets, mockAssetKey(mockPolicyAssetTypeWebsite, strconv.Itoa(siteID)))
+	delete(m.dataCenters, siteID)
+	delete(m.customCertificates, siteID)
+	delete(m.managedCertificates, siteID)
+	delete(m.mtlsOriginSites, siteID)
+	delete(m.clientCaSites, siteID)
+	delete(m.siteTlsSettings, siteID)
+	delete(m.siteSettings, siteID)
+	for _, waitingRoom := range m.siteWaitingRooms(siteID) {
+		delete(m.waitingRooms, int(waitingRoom.Id))
+	}
 
 	response := map[string]interface{}{
 		"res":         0,
@@ -1121,17 +1354,58 @@ func (m *MockImpervaServer) AddSite(site *MockSite) {
 		m.nextSiteID++
 	}
 	m.sites[site.SiteID] = site
+	m.applyDefaultPolicies(site)
 }
 
-// Reset clears all data from the mock server
+// Reset clears all data, faults and journaled requests from the mock server
 func (m *MockImpervaServer) Reset() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.resetState()
+	m.faults = nil
+	m.nextFaultID = 1
+	m.requests = nil
+}
+
+// resetState clears all data and resets the ID generators. The caller must hold the lock.
+func (m *MockImpervaServer) resetState() {
 	m.accounts = make(map[int]*MockAccount)
 	m.sites = make(map[int]*MockSite)
+	m.apiKeys = make(map[string]*MockAPIKey)
 	m.cspDomains = make(map[int]map[string]*MockCSPDomain)
-	m.nextAccountID = 1000
+	m.incapRules = make(map[int]map[int]*IncapRuleWithID)
+	m.cacheRules = make(map[int]map[int]*CacheRuleWithID)
+	m.deliveryRules = make(map[int]map[string][]DeliveryRuleDto)
+	m.nextAccountID = mockAPIKeyAccountID + 1
 	m.nextSiteID = 10000
+	m.policies = make(map[int]*Policy)
+	m.policyAssets = make(map[string]map[int]bool)
+	m.accountPolicies = make(map[int]*AccountPolicyAssociationV3)
+	m.nextRuleID = 50000
+	m.nextPolicyID = 200000
+	m.dataCenters = make(map[int]*MockDataCentersConfiguration)
+	m.nextDataCenterID = 300000
+	m.nextOriginServerID = 400000
+	m.siemConnections = make(map[string]*MockSiemConnection)
+	m.siemLogConfigurations = make(map[string]*MockSiemLogConfiguration)
+	m.nextSiemID = 1
+	m.customCertificates = make(map[int]map[string]*MockCustomCertificate)
+	m.managedCertificates = make(map[int]*SiteCertificateDTO)
+	m.mtlsOriginCertificates = make(map[int]*MockMTLSCertificate)
+	m.mtlsOriginSites = make(map[int]int)
+	m.clientCaCertificates = make(map[int]*MockClientCaCertificate)
+	m.clientCaSites = make(map[int]map[int]bool)
+	m.siteTlsSettings = make(map[int]*SiteTlsSettings)
+	m.nextCertificateID = 500000
+	m.users = make(map[int]map[string]*MockUser)
+	m.roles = make(map[int]*MockRole)
+	m.apiClients = make(map[int]*MockAPIClient)
+	m.nextRoleID = 600000
+	m.nextUserID = 700000
+	m.nextAPIClientID = 800000
+	m.siteSettings = make(map[int]*MockSiteSettings)
+	m.waitingRooms = make(map[int]*MockWaitingRoom)
+	m.nextWaitingRoomID = 900000
 }
 
 // GetCSPDomain returns a CSP domain by site ID and domain name (for test assertions)
@@ -0,0 +1,283 @@
+// Mock Imperva API Server - account hierarchy and authorization
+//
+// Accounts form reseller → account → sub-account trees through their parent ID. API keys are bound to accounts and
+// may only act on their account and its descendants: requests naming an account (caid, account_id...) or a site
+// outside the tree of their API key get the permission denied responses of the API. Credentials that are not
+// registered with AddAPIKey are those of the default account, the reseller at the root of the hierarchy, so that
+// tests that don't care about accounts can use any credentials.
+// See: https://docs-cybersec-be.thalesgroup.com/api/bundle/api-docs/page/cloud-v1-api-definition.htm
+
+package incapsula
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Account types, as returned in the account_type field of the account status
+const (
+	MockAccountTypeReseller   = "Reseller"
+	MockAccountTypeCustomer   = "Reseller Customer"
+	MockAccountTypeSubAccount = "Sub Account"
+)
+
+var (
+	mockAccountPathPattern = regexp.MustCompile(`(?:^|/)accounts/(\d+)(?:/|$)`)
+	mockSitePathPattern    = regexp.MustCompile(`(?:^|/)(?:sites?|WEBSITE)/(\d+)(?:/|$)`)
+)
+
+// MockAPIKey is an API key of the mock server, bound to an account
+type MockAPIKey struct {
+	APIID     string `json:"api_id"`
+	APIKey    string `json:"api_key"`
+	AccountID int    `json:"account_id"`
+}
+
+// mockCallerKey is the request context key of the account of the API key of a request
+type mockCallerKey struct{}
+
+// lookupAccount returns an account by ID, or nil if it doesn't exist. The default account exists even when it isn't
+// stored: it is only stored once updated, or loaded from a seed. The caller must hold the lock.
+func (m *MockImpervaServer) lookupAccount(accountID int) *MockAccount {
+	if account, exists := m.accounts[accountID]; exists {
+		return account
+	}
+	if accountID == mockAPIKeyAccountID {
+		return newMockDefaultAccount()
+	}
+	return nil
+}
+
+// newMockDefaultAccount returns the default account, the account of the credentials that are not registered
+func newMockDefaultAccount() *MockAccount {
+	return &MockAccount{
+		AccountID:   mockAPIKeyAccountID,
+		AccountType: MockAccountTypeReseller,
+		Email:       "test@example.com",
+		AccountName: "test account",
+		PlanID:      "ent100",
+		UserName:    "test",
+		Logins: []MockLogin{
+			{LoginID: float64(mockAPIKeyAccountID), Email: "test@example.com", EmailVerified: true},
+		},
+	}
+}
+
+// mockAccountType returns the type of an account, accounts added without a type being reseller customers
+func mockAccountType(account *MockAccount) string {
+	if account.AccountType == "" {
+		return MockAccountTypeCustomer
+	}
+	return account.AccountType
+}
+
+// mockCallerAccountID returns the account of the API key of a request
+func mockCallerAccountID(r *http.Request) int {
+	if accountID, ok := r.Context().Value(mockCallerKey{}).(int); ok {
+		return accountID
+	}
+	return mockAPIKeyAccountID
+}
+
+// mockIsV1Request tells whether a request is a form request of the v1 API, which reports errors with a res code
+func mockIsV1Request(r *http.Request) bool {
+	return r.Method == http.MethodPost && strings.HasPrefix(r.Header.Get("Content-Type"), contentTypeApplicationUrlEncoded)
+}
+
+// writeDeniedResponse writes the response to a request its API key is not allowed to make: the res code of the v1
+// API for v1 requests, a 401 error for the other APIs
+func (m *MockImpervaServer) writeDeniedResponse(w http.ResponseWriter, r *http.Request, resCode int, message string) {
+	if mockIsV1Request(r) {
+		m.writeErrorResponse(w, resCode, message)
+		return
+	}
+	m.writeV3ErrorResponse(w, http.StatusUnauthorized, "", message)
+}
+
+// authorizeRequest checks the credentials of a request, and that the accounts and sites it names belong to the tree
+// of the account of its API key. It returns the account of the API key, or false if the request was denied.
+func (m *MockImpervaServer) authorizeRequest(w http.ResponseWriter, r *http.Request, path string) (int, bool) {
+	if mockIsV1Request(r) {
+		r.ParseForm()
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// The v1 API also takes the credentials as form params
+	apiID, apiKey := r.Header.Get("x-api-id"), r.Header.Get("x-api-key")
+	if apiID == "" {
+		apiID, apiKey = r.PostForm.Get("api_id"), r.PostForm.Get("api_key")
+	}
+	callerID, authenticated := m.apiKeyAccountID(apiID, apiKey)
+	if !authenticated {
+		m.writeDeniedResponse(w, r, apiV1ResAuthenticationFailed, "Authentication parameters missing or incorrect")
+		return 0, false
+	}
+
+	accountIDs := []string{r.URL.Query().Get("caid")}
+	for _, param := range []string{"caid", "account_id", "parent_id", "sub_account_id"} {
+		accountIDs = append(accountIDs, r.PostForm.Get(param))
+	}
+	if matches := mockAccountPathPattern.FindStringSubmatch(path); matches != nil {
+		accountIDs = append(accountIDs, matches[1])
+	}
+	for _, value := range accountIDs {
+		if accountID, err := strconv.Atoi(value); err == nil && accountID != 0 && !m.accountInTree(callerID, accountID) {
+			m.writeDeniedResponse(w, r, apiV1ResUnknownAccountID, "Unknown/unauthorized account_id")
+			return 0, false
+		}
+	}
+
+	siteIDs := []string{r.PostForm.Get("site_id")}
+	if matches := mockSitePathPattern.FindStringSubmatch(path); matches != nil {
+		siteIDs = append(siteIDs, matches[1])
+	}
+	for _, value := range siteIDs {
+		siteID, _ := strconv.Atoi(value)
+		// Unknown sites are left to the handlers
+		if site, exists := m.sites[siteID]; exists && !m.accountInTree(callerID, siteAccountID(site)) {
+			m.writeDeniedResponse(w, r, apiV1ResUnknownSiteID, "Unknown/unauthorized site_id")
+			return 0, false
+		}
+	}
+	return callerID, true
+}
+
+// apiKeyAccountID returns the account of an API key, registered with AddAPIKey or issued to an API client.
+// Unregistered API IDs are those of the default account; a registered API ID with another key, or whose account was
+// deleted, fails authentication. The caller must hold the lock.
+func (m *MockImpervaServer) apiKeyAccountID(apiID, apiKey string) (int, bool) {
+	key, registered := m.apiKeys[apiID]
+	if !registered {
+		if accountID, authenticated, isClient := m.apiClientAccountID(apiID, apiKey); isClient {
+			return accountID, authenticated
+		}
+		return mockAPIKeyAccountID, true
+	}
+	if key.APIKey != apiKey {
+		return 0, false
+	}
+	if m.lookupAccount(key.AccountID) == nil {
+		return 0, false
+	}
+	return key.AccountID, true
+}
+
+// accountInTree tells whether an account is the root account or one of its descendants. Accounts without parent are
+// children of the default account. The caller must hold the lock.
+func (m *MockImpervaServer) accountInTree(rootID, accountID int) bool {
+	// The depth is bounded, in case a seed loops
+	for depth := 0; depth <= len(m.accounts); depth++ {
+		if accountID == rootID {
+			return true
+		}
+		account := m.lookupAccount(accountID)
+		if account == nil || accountID == mockAPIKeyAccountID {
+			return false
+		}
+		accountID = account.ParentID
+		if accountID == 0 {
+			accountID = mockAPIKeyAccountID
+		}
+	}
+	return false
+}
+
+// ownedByRequest tells whether an object of an account can be accessed by a request: the object must belong to the
+// caid query param if there is one, or to the tree of the API key otherwise. The caller must hold the lock.
+func (m *MockImpervaServer) ownedByRequest(r *http.Request, accountID int) bool {
+	if caid := r.URL.Query().Get("caid"); caid != "" {
+		return caid == strconv.Itoa(accountID)
+	}
+	return m.accountInTree(mockCallerAccountID(r), accountID)
+}
+
+// Sub-Account Handlers
+
+// handleSubAccountAdd handles POST /subaccounts/add
+// See: https://docs-cybersec-be.thalesgroup.com/api/bundle/api-docs/page/cloud-v1-api-definition.htm
+func (m *MockImpervaServer) handleSubAccountAdd(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r.ParseForm()
+	name := m.parseFormValue(r, "sub_account_name")
+	if name == "" {
+		m.writeErrorResponse(w, 1, "Missing parameter: sub_account_name")
+		return
+	}
+
+	// Sub-accounts are added under an account, the account of the API key by default, and can't be nested
+	parentID := m.parseFormInt(r, "parent_id")
+	if parentID == 0 {
+		parentID = mockCallerAccountID(r)
+	}
+	parent := m.lookupAccount(parentID)
+	if parent == nil || mockAccountType(parent) == MockAccountTypeSubAccount {
+		m.writeErrorResponse(w, apiV1ResOperationNotAllowed, fmt.Sprintf("Operation not allowed: account %d can't have sub-accounts", parentID))
+		return
+	}
+
+	subAccount := &MockAccount{
+		AccountID:   m.nextAccountID,
+		AccountType: MockAccountTypeSubAccount,
+		ParentID:    parentID,
+		AccountName: name,
+		PlanID:      parent.PlanID,
+		RefID:       m.parseFormValue(r, "ref_id"),
+		Logins:      []MockLogin{},
+	}
+	m.nextAccountID++
+	m.accounts[subAccount.AccountID] = subAccount
+
+	response := map[string]interface{}{
+		"res":         0,
+		"res_message": "OK",
+		"sub_account": map[string]interface{}{
+			"sub_account_id":   subAccount.AccountID,
+			"sub_account_name": subAccount.AccountName,
+			"ref_id":           subAccount.RefID,
+			"parent_id":        subAccount.ParentID,
+			"log_level":        m.parseFormValue(r, "log_level"),
+			"logs_account_id":  m.parseFormInt(r, "logs_account_id"),
+		},
+	}
+	m.writeJSONResponse(w, response)
+}
+
+// handleSubAccountDelete handles POST /subaccounts/delete
+// See: https://docs-cybersec-be.thalesgroup.com/api/bundle/api-docs/page/cloud-v1-api-definition.htm
+func (m *MockImpervaServer) handleSubAccountDelete(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r.ParseForm()
+	subAccountID := m.parseFormInt(r, "sub_account_id")
+
+	subAccount, exists := m.accounts[subAccountID]
+	if !exists || mockAccountType(subAccount) != MockAccountTypeSubAccount {
+		m.writeErrorResponse(w, apiV1ResUnknownAccountID, "Unknown/unauthorized account_id")
+		return
+	}
+	delete(m.accounts, subAccountID)
+
+	response := map[string]interface{}{
+		"res":         0,
+		"res_message": "OK",
+	}
+	m.writeJSONResponse(w, response)
+}
+
+// Helper methods for tests
+
+// AddAPIKey registers an API key bound to an account, requests made with it may only act on the account and its
+// descendants (for test setup)
+func (m *MockImpervaServer) AddAPIKey(apiID, apiKey string, accountID int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.apiKeys[apiID] = &MockAPIKey{APIID: apiID, APIKey: apiKey, AccountID: accountID}
+}
@@ -0,0 +1,138 @@
+package incapsula
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+// testMockAccountClient returns a client of the mock server whose API key is bound to an account
+func testMockAccountClient(mock *MockImpervaServer, accountID int) *Client {
+	apiID := fmt.Sprintf("api-id-%d", accountID)
+	mock.AddAPIKey(apiID, "api-key", accountID)
+	config := testConfigForURL(mock.URL())
+	config.APIID, config.APIKey = apiID, "api-key"
+	return NewClient(&config)
+}
+
+func TestMockAccountHierarchy(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	reseller := &MockAccount{AccountType: MockAccountTypeReseller, Email: "reseller@example.com", PlanID: "ent100"}
+	mock.AddAccount(reseller)
+	client := testMockAccountClient(mock, reseller.AccountID)
+	ctx := context.Background()
+
+	verified, err := client.Verify(ctx)
+	if err != nil || verified.AccountID != reseller.AccountID || verified.AccountType != MockAccountTypeReseller {
+		t.Fatalf("Expected the credentials of the reseller, got %+v %v", verified, err)
+	}
+
+	// Accounts and sub-accounts are added under the account of the API key by default
+	account, err := client.AddAccount(ctx, "customer@example.com", "", "", "ent100", "Customer", "", 0, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error adding account: %s", err)
+	}
+	accountID := account.Account.AccountID
+	if account.Account.ParentID != reseller.AccountID {
+		t.Errorf("Expected the account to be added under the reseller, got parent %d", account.Account.ParentID)
+	}
+	subAccount, err := client.AddSubAccount(ctx, &SubAccountPayload{SubAccountName: "Sub", ParentID: accountID})
+	if err != nil {
+		t.Fatalf("Unexpected error adding sub-account: %s", err)
+	}
+	subAccountID := subAccount.SubAccount.SubAccountID
+	status, err := client.AccountStatus(ctx, subAccountID, ReadSubAccount)
+	if err != nil || !status.isSubAccount() || status.Account.ParentID != accountID || status.Account.AccountName != "Sub" {
+		t.Fatalf("Unexpected sub-account status: %+v %v", status, err)
+	}
+
+	// Sub-accounts can't be nested, and only resellers add accounts
+	if _, err := client.AddSubAccount(ctx, &SubAccountPayload{SubAccountName: "Nested", ParentID: subAccountID}); !IsPermissionDenied(err) {
+		t.Errorf("Expected an operation not allowed error for a nested sub-account, got %v", err)
+	}
+	customerClient := testMockAccountClient(mock, accountID)
+	if _, err := customerClient.AddAccount(ctx, "other@example.com", "", "", "ent100", "Other", "", 0, 0); !IsPermissionDenied(err) {
+		t.Errorf("Expected an operation not allowed error for an account added by a customer, got %v", err)
+	}
+
+	// The API key of the account can act on its sub-accounts, not on its parent
+	if _, err := customerClient.AccountStatus(ctx, subAccountID, ReadSubAccount); err != nil {
+		t.Errorf("Unexpected error reading the sub-account of the account: %s", err)
+	}
+	if _, err := customerClient.AccountStatus(ctx, reseller.AccountID, ReadAccount); !IsPermissionDenied(err) {
+		t.Errorf("Expected an unknown account error reading the reseller, got %v", err)
+	}
+
+	// Accounts are deleted by their ancestors, never by themselves
+	if err := customerClient.DeleteAccount(ctx, accountID); err == nil {
+		t.Errorf("Expected an error deleting the account of the API key")
+	}
+	if err := client.DeleteAccount(ctx, subAccountID); err != nil {
+		t.Errorf("Unexpected error deleting sub-account: %s", err)
+	}
+	if err := client.DeleteSubAccount(ctx, accountID); err == nil {
+		t.Errorf("Expected an error deleting an account as a sub-account")
+	}
+}
+
+func TestMockAccountAuthorization(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	reseller := &MockAccount{AccountType: MockAccountTypeReseller, Email: "reseller@example.com"}
+	mock.AddAccount(reseller)
+	customer := &MockAccount{ParentID: reseller.AccountID, Email: "customer@example.com"}
+	mock.AddAccount(customer)
+	other := &MockAccount{Email: "other@example.com"}
+	mock.AddAccount(other)
+	otherSite := &MockSite{Domain: "other.example.com", AccountID: other.AccountID}
+	mock.AddSite(otherSite)
+
+	client := testMockAccountClient(mock, reseller.AccountID)
+	ctx := context.Background()
+	otherID := strconv.Itoa(other.AccountID)
+
+	// v1 requests naming an account or a site outside the tree get the unknown/unauthorized errors: an
+	// account outside the tree is denied (9403), a site outside the tree is not found (9413)
+	if _, err := client.AccountStatus(ctx, other.AccountID, ReadAccount); !IsPermissionDenied(err) {
+		t.Errorf("Expected an unknown account error reading another account, got %v", err)
+	}
+	if _, err := client.UpdateAccount(ctx, otherID, "ref_id", "mine"); err == nil || mock.GetAccount(other.AccountID).RefID == "mine" {
+		t.Errorf("Expected the update of another account to be denied, got %v", err)
+	}
+	if _, err := client.AddSubAccount(ctx, &SubAccountPayload{SubAccountName: "Sub", ParentID: other.AccountID}); !IsPermissionDenied(err) {
+		t.Errorf("Expected an unknown account error adding a sub-account to another account, got %v", err)
+	}
+	if _, err := client.AddSite(ctx, "mine.example.com", "", "", "", "", other.AccountID, false, false, ""); !IsPermissionDenied(err) {
+		t.Errorf("Expected an unknown account error adding a site to another account, got %v", err)
+	}
+	if _, err := client.SiteStatus(ctx, otherSite.Domain, otherSite.SiteID); !IsNotFound(err) {
+		t.Errorf("Expected an unknown site error reading the site of another account, got %v", err)
+	}
+
+	// The other APIs deny requests passing the caid of another account or naming its sites
+	if _, err := client.GetAccountPolicyAssociation(ctx, otherID); !IsPermissionDenied(err) {
+		t.Errorf("Expected a permission denied error for the caid of another account, got %v", err)
+	}
+	if _, _, err := client.ReadIncapRule(ctx, strconv.Itoa(otherSite.SiteID), 1); !IsPermissionDenied(err) {
+		t.Errorf("Expected a permission denied error for the site of another account, got %v", err)
+	}
+	if _, err := client.GetAccountPolicyAssociation(ctx, strconv.Itoa(customer.AccountID)); err != nil {
+		t.Errorf("Unexpected error for the caid of a customer of the reseller: %s", err)
+	}
+
+	// A registered API ID only works with its key, the other credentials are those of the default account
+	config := testConfigForURL(mock.URL())
+	config.APIID, config.APIKey = fmt.Sprintf("api-id-%d", reseller.AccountID), "wrong"
+	if _, err := NewClient(&config).Verify(ctx); !IsPermissionDenied(err) {
+		t.Errorf("Expected an authentication error for a wrong API key, got %v", err)
+	}
+	config = testConfigForURL(mock.URL())
+	if verified, err := NewClient(&config).Verify(ctx); err != nil || verified.AccountID != mockAPIKeyAccountID {
+		t.Errorf("Expected the default account for unregistered credentials, got %+v %v", verified, err)
+	}
+	if _, err := NewClient(&config).SiteStatus(ctx, otherSite.Domain, otherSite.SiteID); err != nil {
+		t.Errorf("Expected the default account to reach every account, got %s", err)
+	}
+}
@@ -0,0 +1,481 @@
+// Mock Imperva API Server - custom, HSM and managed certificates
+//
+// The endpoints mirror the requests of client_certificate.go, client_certificate_hsm.go and
+// client_site_certificate.go. Uploaded certificates are parsed like the real API does: PEM, DER and PFX files
+// are accepted, the private key must match the certificate and expired certificates are rejected. The
+// metadata of the certificate (subject, issuer, fingerprint, expiration) is kept and returned.
+// See: https://docs-cybersec-be.thalesgroup.com/api/bundle/api-docs/page/cloud-v1-api-definition.htm
+
+package incapsula
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/pkcs12"
+)
+
+var (
+	mockHsmCertificatePattern     = regexp.MustCompile(`^sites/(\d+)/hsmCertificate$`)
+	mockManagedCertificatePattern = regexp.MustCompile(`^certificates-ui/v3/sites/(\d+)/certificates/managed(/validate)?$`)
+)
+
+var mockManagedCertificateValidationMethods = []string{"CNAME", "DNS", "EMAIL"}
+
+// MockCertificateMetadata is the metadata of an uploaded certificate
+type MockCertificateMetadata struct {
+	Subject        string   `json:"subject"`
+	Issuer         string   `json:"issuer"`
+	Fingerprint    string   `json:"fingerprint"`
+	ExpirationDate int64    `json:"expirationDate"`
+	KeyType        string   `json:"keyType"`
+	Sans           []string `json:"sans"`
+}
+
+// MockCustomCertificate represents a custom certificate of a site in the mock server. HSM certificates have their
+// private key in the HSM, whose details are kept instead.
+type MockCustomCertificate struct {
+	MockCertificateMetadata
+	AuthType   string          `json:"auth_type"`
+	InputHash  string          `json:"input_hash"`
+	HsmDetails []HSMDetailsDTO `json:"hsm_details,omitempty"`
+}
+
+// newMockCertificateMetadata returns the metadata of a certificate
+func newMockCertificateMetadata(certificate *x509.Certificate) MockCertificateMetadata {
+	fingerprint := sha1.Sum(certificate.Raw)
+	keyType := "RSA"
+	if _, ok := certificate.PublicKey.(*ecdsa.PublicKey); ok {
+		keyType = "ECC"
+	}
+	// Certificates without SANs cover their common name
+	sans := append([]string{}, certificate.DNSNames...)
+	if len(sans) == 0 && certificate.Subject.CommonName != "" {
+		sans = []string{certificate.Subject.CommonName}
+	}
+	return MockCertificateMetadata{
+		Subject:        certificate.Subject.String(),
+		Issuer:         certificate.Issuer.String(),
+		Fingerprint:    strings.ToUpper(hex.EncodeToString(fingerprint[:])),
+		ExpirationDate: certificate.NotAfter.UnixNano() / int64(time.Millisecond),
+		KeyType:        keyType,
+		Sans:           sans,
+	}
+}
+
+// parseMockCertificate parses an uploaded certificate file, in PEM (the certificate chain, optionally followed by
+// the private key), DER or PFX format, and the private key file, in PEM or DER format, if any. The private key is nil
+// when neither file holds one. An error is returned if the private key doesn't match the certificate or if the
+// certificate has expired.
+func parseMockCertificate(certificateFile, privateKeyFile []byte, passphrase string) (*x509.Certificate, crypto.PrivateKey, error) {
+	var certificate *x509.Certificate
+	var privateKey crypto.PrivateKey
+	var err error
+
+	if block, _ := pem.Decode(certificateFile); block != nil {
+		rest := certificateFile
+		for {
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+			if block.Type == "CERTIFICATE" && certificate == nil {
+				if certificate, err = x509.ParseCertificate(block.Bytes); err != nil {
+					return nil, nil, fmt.Errorf("Invalid certificate: %s", err)
+				}
+			} else if strings.HasSuffix(block.Type, "PRIVATE KEY") && privateKey == nil {
+				if privateKey, err = parseMockPrivateKey(block.Bytes); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+		if certificate == nil {
+			return nil, nil, fmt.Errorf("No certificate found in the certificate file")
+		}
+	} else if certificate, err = x509.ParseCertificate(certificateFile); err != nil {
+		// Neither PEM nor DER, the last supported format is PFX
+		if privateKey, certificate, err = pkcs12.Decode(certificateFile, passphrase); err != nil {
+			return nil, nil, fmt.Errorf("Unsupported certificate file format or wrong passphrase: %s", err)
+		}
+	}
+
+	if len(privateKeyFile) > 0 {
+		keyBytes := privateKeyFile
+		if block, _ := pem.Decode(privateKeyFile); block != nil {
+			if _, encrypted := block.Headers["Proc-Type"]; encrypted {
+				return nil, nil, fmt.Errorf("Encrypted PEM private keys are not supported, use a PFX file")
+			}
+			keyBytes = block.Bytes
+		}
+		if privateKey, err = parseMockPrivateKey(keyBytes); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if privateKey != nil {
+		signer, ok := privateKey.(crypto.Signer)
+		publicKey, comparable := certificate.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+		if !ok || !comparable || !publicKey.Equal(signer.Public()) {
+			return nil, nil, fmt.Errorf("The private key does not match the certificate")
+		}
+	}
+	if time.Now().After(certificate.NotAfter) {
+		return nil, nil, fmt.Errorf("The certificate expired on %s", certificate.NotAfter.Format(time.RFC3339))
+	}
+	return certificate, privateKey, nil
+}
+
+// parseMockPrivateKey parses a PKCS #8, PKCS #1 or SEC 1 DER private key
+func parseMockPrivateKey(der []byte) (crypto.PrivateKey, error) {
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(der); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("Invalid private key")
+}
+
+// decodeMockBase64File decodes a file sent in base64 format. Files that are not base64 encoded are used as is.
+func decodeMockBase64File(value string) []byte {
+	if decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value)); err == nil {
+		return decoded
+	}
+	return []byte(value)
+}
+
+// mockCertificateAuthType returns the auth type of a certificate's key: RSA or ECC
+func mockCertificateAuthType(certificate *x509.Certificate) string {
+	if _, ok := certificate.PublicKey.(*rsa.PublicKey); ok {
+		return "RSA"
+	}
+	return "ECC"
+}
+
+// siteSSLStatus returns the ssl section of the site status. The caller must hold the lock.
+func (m *MockImpervaServer) siteSSLStatus(site *MockSite) map[string]interface{} {
+	customCertificate := map[string]interface{}{"active": false}
+	certificates := m.customCertificates[site.SiteID]
+	for _, authType := range []string{"RSA", "ECC"} {
+		if certificate, exists := certificates[authType]; exists {
+			customCertificate = map[string]interface{}{
+				"active":                true,
+				"expirationDate":        certificate.ExpirationDate,
+				"revocationError":       false,
+				"validityError":         false,
+				"chain":                 certificate.Issuer,
+				"hostnameMismatchError": !mockCertificateCoversDomain(certificate.MockCertificateMetadata, site.Domain),
+				"inputHash":             certificate.InputHash,
+				"fingerprint":           certificate.Fingerprint,
+				"subject":               certificate.Subject,
+			}
+			break
+		}
+	}
+	return map[string]interface{}{"custom_certificate": customCertificate}
+}
+
+// mockCertificateCoversDomain tells whether a SAN of the certificate matches the domain, wildcards included
+func mockCertificateCoversDomain(metadata MockCertificateMetadata, domain string) bool {
+	for _, san := range metadata.Sans {
+		if strings.EqualFold(san, domain) {
+			return true
+		}
+		if strings.HasPrefix(san, "*.") {
+			if i := strings.Index(domain, "."); i > 0 && strings.EqualFold(san[2:], domain[i+1:]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Custom Certificates Handlers (v1)
+
+// handleCustomCertificateUpload handles POST /sites/customCertificate/upload, which adds or replaces the certificate
+// of an auth type (RSA or ECC)
+func (m *MockImpervaServer) handleCustomCertificateUpload(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r.ParseForm()
+	site, exists := m.sites[m.parseFormInt(r, "site_id")]
+	if !exists {
+		m.writeErrorResponse(w, 9413, "Unknown/unauthorized site_id")
+		return
+	}
+	authType := m.parseFormValue(r, "auth_type")
+	if authType == "" {
+		authType = "RSA"
+	}
+	if authType != "RSA" && authType != "ECC" {
+		m.writeErrorResponse(w, 1, fmt.Sprintf("Invalid auth_type: %s", authType))
+		return
+	}
+	if m.parseFormValue(r, "certificate") == "" {
+		m.writeErrorResponse(w, 1, "Missing certificate")
+		return
+	}
+
+	var privateKeyFile []byte
+	if privateKey := m.parseFormValue(r, "private_key"); privateKey != "" {
+		privateKeyFile = decodeMockBase64File(privateKey)
+	}
+	certificate, privateKey, err := parseMockCertificate(decodeMockBase64File(m.parseFormValue(r, "certificate")), privateKeyFile, m.parseFormValue(r, "passphrase"))
+	if err != nil {
+		m.writeErrorResponse(w, 1, err.Error())
+		return
+	}
+	if privateKey == nil {
+		m.writeErrorResponse(w, 1, "Missing private key")
+		return
+	}
+	if keyAuthType := mockCertificateAuthType(certificate); keyAuthType != authType {
+		m.writeErrorResponse(w, 1, fmt.Sprintf("The certificate has an %s key, auth_type %s was requested", keyAuthType, authType))
+		return
+	}
+
+	if m.customCertificates[site.SiteID] == nil {
+		m.customCertificates[site.SiteID] = make(map[string]*MockCustomCertificate)
+	}
+	m.customCertificates[site.SiteID][authType] = &MockCustomCertificate{
+		MockCertificateMetadata: newMockCertificateMetadata(certificate),
+		AuthType:                authType,
+		InputHash:               m.parseFormValue(r, "input_hash"),
+	}
+
+	m.writeSuccessResponse(w, map[string]interface{}{"ssl": m.siteSSLStatus(site)})
+}
+
+// handleCustomCertificateRemove handles POST /sites/customCertificate/remove
+func (m *MockImpervaServer) handleCustomCertificateRemove(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r.ParseForm()
+	siteID := m.parseFormInt(r, "site_id")
+	if _, exists := m.sites[siteID]; !exists {
+		m.writeErrorResponse(w, 9413, "Unknown/unauthorized site_id")
+		return
+	}
+	authType := m.parseFormValue(r, "auth_type")
+	if authType == "" {
+		authType = "RSA"
+	}
+	if certificate, exists := m.customCertificates[siteID][authType]; !exists || certificate.HsmDetails != nil {
+		m.writeErrorResponse(w, 1, fmt.Sprintf("No %s custom certificate for site %d", authType, siteID))
+		return
+	}
+
+	delete(m.customCertificates[siteID], authType)
+	m.writeSuccessResponse(w, map[string]interface{}{})
+}
+
+// HSM Certificates Handlers (v2)
+
+// handleHsmCertificate handles PUT/DELETE /sites/{siteId}/hsmCertificate. The private key of an HSM certificate is
+// kept in the HSM, so only the certificate is uploaded, along with the details of the HSM.
+func (m *MockImpervaServer) handleHsmCertificate(w http.ResponseWriter, r *http.Request, path string) {
+	matches := mockHsmCertificatePattern.FindStringSubmatch(path)
+	siteID, _ := strconv.Atoi(matches[1])
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.sites[siteID]; !exists {
+		m.writeV2ErrorResponse(w, http.StatusNotFound, 9413, "Unknown/unauthorized site_id")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		var request HsmCustomCertificate
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &request); err != nil {
+			m.writeV2ErrorResponse(w, http.StatusBadRequest, 1, fmt.Sprintf("Invalid request body: %s", err))
+			return
+		}
+		if len(request.Data.HsmDetailsList) == 0 {
+			m.writeV2ErrorResponse(w, http.StatusBadRequest, 1, "At least one HSM is required")
+			return
+		}
+		for _, hsm := range request.Data.HsmDetailsList {
+			if hsm.KeyId == "" || hsm.ApiKey == "" || hsm.HostName == "" {
+				m.writeV2ErrorResponse(w, http.StatusBadRequest, 1, "key_id, api_key and host_name are required for each HSM")
+				return
+			}
+		}
+		certificate, _, err := parseMockCertificate(decodeMockBase64File(request.Data.Certificate), nil, "")
+		if err != nil {
+			m.writeV2ErrorResponse(w, http.StatusBadRequest, 1, err.Error())
+			return
+		}
+
+		authType := mockCertificateAuthType(certificate)
+		if m.customCertificates[siteID] == nil {
+			m.customCertificates[siteID] = make(map[string]*MockCustomCertificate)
+		}
+		m.customCertificates[siteID][authType] = &MockCustomCertificate{
+			MockCertificateMetadata: newMockCertificateMetadata(certificate),
+			AuthType:                authType,
+			InputHash:               r.URL.Query().Get("input_hash"),
+			HsmDetails:              request.Data.HsmDetailsList,
+		}
+	case http.MethodDelete:
+		removed := false
+		for authType, certificate := range m.customCertificates[siteID] {
+			if certificate.HsmDetails != nil {
+				delete(m.customCertificates[siteID], authType)
+				removed = true
+			}
+		}
+		if !removed {
+			m.writeV2ErrorResponse(w, http.StatusNotFound, 1, fmt.Sprintf("No HSM certificate for site %d", siteID))
+			return
+		}
+	default:
+		m.writeV2ErrorResponse(w, http.StatusMethodNotAllowed, 1, fmt.Sprintf("Method not allowed: %s", r.Method))
+		return
+	}
+	m.writeSuccessResponse(w, map[string]interface{}{})
+}
+
+// Managed Certificates Handlers (v3)
+
+// handleManagedCertificate handles GET/POST/DELETE /certificates-ui/v3/sites/{siteId}/certificates/managed and
+// POST /certificates-ui/v3/sites/{siteId}/certificates/managed/validate
+func (m *MockImpervaServer) handleManagedCertificate(w http.ResponseWriter, r *http.Request, path string) {
+	matches := mockManagedCertificatePattern.FindStringSubmatch(path)
+	siteID, _ := strconv.Atoi(matches[1])
+	validate := matches[2] != ""
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	site, exists := m.sites[siteID]
+	if !exists {
+		m.writeV3ErrorResponse(w, http.StatusNotFound, "", fmt.Sprintf("Site %d not found", siteID))
+		return
+	}
+	managedCertificate := m.managedCertificates[siteID]
+
+	switch {
+	case validate && r.Method == http.MethodPost:
+		var domainIDs []int
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &domainIDs); err != nil {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "", fmt.Sprintf("Expected a list of domain IDs: %s", err))
+			return
+		}
+		if managedCertificate == nil {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "", fmt.Sprintf("No managed certificate was requested for site %d", siteID))
+			return
+		}
+		// Validation succeeds at once, which makes the certificate active
+		for i := range managedCertificate.CertificatesDetails {
+			details := &managedCertificate.CertificatesDetails[i]
+			details.Status = "ACTIVE"
+			for j := range details.Sans {
+				details.Sans[j].Status = "VALIDATED"
+				details.Sans[j].StatusDate = time.Now().UnixNano() / int64(time.Millisecond)
+			}
+		}
+		w.WriteHeader(http.StatusCreated)
+		return
+	case validate:
+		m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+		return
+	case r.Method == http.MethodGet:
+		if managedCertificate == nil {
+			m.writeJSONResponse(w, SiteCertificateV3Response{Data: []SiteCertificateDTO{{SiteId: siteID}}})
+			return
+		}
+	case r.Method == http.MethodPost:
+		var request SiteCertificateDTO
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &request); err != nil {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "", fmt.Sprintf("Invalid request body: %s", err))
+			return
+		}
+		validationMethod := request.DefaultValidationMethod
+		if validationMethod == "" {
+			validationMethod = "CNAME"
+		}
+		if !contains(mockManagedCertificateValidationMethods, validationMethod) {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "/defaultValidationMethod", fmt.Sprintf("Invalid defaultValidationMethod: %s", validationMethod))
+			return
+		}
+		if managedCertificate == nil {
+			managedCertificate = m.newMockManagedCertificate(site)
+			m.managedCertificates[siteID] = managedCertificate
+		}
+		// Requesting the certificate again changes the validation method of the domains that are not validated yet
+		managedCertificate.DefaultValidationMethod = validationMethod
+		for i := range managedCertificate.CertificatesDetails[0].Sans {
+			if san := &managedCertificate.CertificatesDetails[0].Sans[i]; san.Status != "VALIDATED" {
+				san.ValidationMethod = validationMethod
+			}
+		}
+	case r.Method == http.MethodDelete:
+		delete(m.managedCertificates, siteID)
+		m.writeJSONResponse(w, SiteCertificateV3Response{Data: []SiteCertificateDTO{{SiteId: siteID}}})
+		return
+	default:
+		m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+		return
+	}
+	m.writeJSONResponse(w, SiteCertificateV3Response{Data: []SiteCertificateDTO{*managedCertificate}})
+}
+
+// newMockManagedCertificate returns a new managed certificate request, covering the site's domain. The caller must
+// hold the lock.
+func (m *MockImpervaServer) newMockManagedCertificate(site *MockSite) *SiteCertificateDTO {
+	certificateID := m.nextCertificateID
+	m.nextCertificateID++
+	now := time.Now()
+	return &SiteCertificateDTO{
+		SiteId: site.SiteID,
+		CertificatesDetails: []CertificateDTO{{
+			Id:             certificateID,
+			Name:           fmt.Sprintf("Imperva managed certificate for %s", site.Domain),
+			Status:         "IN_PROCESS",
+			Type:           "ATLAS",
+			ExpirationDate: now.AddDate(0, 3, 0).UnixNano() / int64(time.Millisecond),
+			Sans: []SanDTO{{
+				SanId:                certificateID,
+				SanValue:             site.Domain,
+				Status:               "PENDING_USER_ACTION",
+				StatusDate:           now.UnixNano() / int64(time.Millisecond),
+				NumSitesCovered:      1,
+				VerificationCode:     fmt.Sprintf("mock-verification-%d", certificateID),
+				CnameValidationValue: fmt.Sprintf("_%d.validation.incapsula.com", certificateID),
+			}},
+		}},
+	}
+}
+
+// Helper methods for tests
+
+// GetCustomCertificate returns the custom certificate of a site by auth type (for test assertions)
+func (m *MockImpervaServer) GetCustomCertificate(siteID int, authType string) *MockCustomCertificate {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.customCertificates[siteID][authType]
+}
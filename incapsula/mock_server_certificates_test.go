@@ -0,0 +1,245 @@
+package incapsula
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testMockPFXCertificate is a self-signed ECC certificate for pfx.example.com and its private key in PFX format,
+// protected by the passphrase "secret"
+const testMockPFXCertificate = `
+MIIDigIBAzCCA1AGCSqGSIb3DQEHAaCCA0EEggM9MIIDOTCCAi8GCSqGSIb3DQEHBqCCAiAwggIc
+AgEAMIICFQYJKoZIhvcNAQcBMBwGCiqGSIb3DQEMAQMwDgQIp24uaednTPUCAggAgIIB6M9Ktjpt
+IxU57hAqlDGMiymGMk0gHRgm30zUes/Jmvb8uzpzLoKNnIphdM2v4iKJMthN5a9fcBcwX6MWiiQO
+L56s3Pq5jCOyBxIcpW+0IuaTTM/5N/riBvfeDGzbM8pGKruMlF+nQRZ+uMrVeJo9Emb2x+VzJKGh
+XEJKt4pTc3kNSZua7v1KZ6LvoX5kz/6qPy+hbEEqJjRXD9R8cxUUvJOb7eTmJnAGdaKvP9lUrHqA
+QW3bAVxzQtslm4AzpSBpdeIedl2WwHPLtaNMwI8H0doPbAzD8UG0M/bZF6kppDm4YDPQr58Fu100
+R4TOOMmkeDJVH1E42yXmcZIt1Z5/Qhu9OJr02Hp60ABVAgvNfFKY3Z6TsFvtZC+Lh0EjEmZiUU0f
+V9rf4PL9NRYQl9znaHmwvuk/zzqJdZEUaDgMNChU/CHXmhKojq2iUbVMF9JVOeJltY9DcIC3jClZ
+l+CRTrWWtkyYz+eH6/DhfySSjUxQ0+ecG/gLBQRpR7mmJ87CmLU08uordDV8WEaiksbTG5p7zNYr
+NPBTVu1JmeBvnossdD+W+BNcBW+25r9aKK0rMOsquUFqSPkHozS3WRQYsfpUTF84Bv5jGxQkksRx
+8Jw10pQyFxSmGAF5z0mEQoSbfuTNuAYCqPVeMIIBAgYJKoZIhvcNAQcBoIH0BIHxMIHuMIHrBgsq
+hkiG9w0BDAoBAqCBtDCBsTAcBgoqhkiG9w0BDAEDMA4ECLeMnQ7UZLeUAgIIAASBkN/Gm+v5Vn9d
+2gAU5u41IB47VBzcBh30jCTf8IG6Brf2TirGuCScg3bs6SNfeV2qbTeGdCVrRvfxSRmBOwBgWQFC
+w3/Nomc/9S8ns5gfMBJgkumGzXAWsW4Fqx+fDEXaqgaEdvNjlY3UVcORw6gMJsOMhnU7W997A+cF
+vEWcmTXWYc7dTyCNnqhRXm9L/tyD/TElMCMGCSqGSIb3DQEJFTEWBBQMTuOhmvsW0ok9Zlx4jZ7J
+X8YAlzAxMCEwCQYFKw4DAhoFAAQUmX56Y2CEFZZ+7ST9eY09LqwvAVQECNLOZwrFZSGRAgIIAA==`
+
+func testMockRSAKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Unexpected error generating RSA key: %s", err)
+	}
+	return key
+}
+
+func testMockECCKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unexpected error generating ECC key: %s", err)
+	}
+	return key
+}
+
+// testMockCertificate creates a certificate for the key, issued by the parent or self-signed when parent is nil. It
+// returns the parsed certificate and its PEM encoding.
+func testMockCertificate(t *testing.T, commonName string, key crypto.Signer, isCA bool, notAfter time.Time, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, []byte) {
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName, Organization: []string{"Mock"}},
+		DNSNames:              []string{commonName},
+		NotBefore:             notAfter.AddDate(-1, 0, 0),
+		NotAfter:              notAfter,
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+	if isCA {
+		template.DNSNames = nil
+		template.KeyUsage = x509.KeyUsageCertSign
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatalf("Unexpected error creating certificate: %s", err)
+	}
+	certificate, _ := x509.ParseCertificate(der)
+	return certificate, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func testMockKeyPEM(t *testing.T, key crypto.Signer) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("Unexpected error encoding private key: %s", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func testMockBase64(content []byte) string {
+	return base64.StdEncoding.EncodeToString(content)
+}
+
+func TestMockCustomCertificates(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	site := &MockSite{Domain: "www.example.com"}
+	mock.AddSite(site)
+	siteID := strconv.Itoa(site.SiteID)
+
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+
+	notAfter := time.Now().AddDate(1, 0, 0).Truncate(time.Second)
+	caKey := testMockRSAKey(t)
+	ca, _ := testMockCertificate(t, "Mock CA", caKey, true, notAfter, nil, nil)
+	rsaKey := testMockRSAKey(t)
+	rsaCertificate, rsaPEM := testMockCertificate(t, "www.example.com", rsaKey, false, notAfter, ca, caKey)
+
+	if _, err := client.AddCertificate(ctx, siteID, testMockBase64(rsaPEM), testMockBase64(testMockKeyPEM(t, rsaKey)), "", "RSA", "hash-1"); err != nil {
+		t.Fatalf("Unexpected error adding custom certificate: %s", err)
+	}
+	list, err := client.ListCertificates(ctx, siteID, ReadCustomCertificate)
+	if err != nil || list.SSL.CustomCertificate.InputHash != "hash-1" {
+		t.Fatalf("Expected the input hash in the site status, got %+v %v", list, err)
+	}
+
+	// The metadata is the one of the uploaded certificate
+	fingerprint := sha1.Sum(rsaCertificate.Raw)
+	stored := mock.GetCustomCertificate(site.SiteID, "RSA")
+	if stored.Fingerprint != strings.ToUpper(hex.EncodeToString(fingerprint[:])) || !strings.Contains(stored.Subject, "CN=www.example.com") || !strings.Contains(stored.Issuer, "CN=Mock CA") {
+		t.Errorf("Unexpected certificate metadata: %+v", stored.MockCertificateMetadata)
+	}
+	if stored.ExpirationDate != notAfter.UnixNano()/int64(time.Millisecond) || stored.KeyType != "RSA" {
+		t.Errorf("Unexpected certificate metadata: %+v", stored.MockCertificateMetadata)
+	}
+
+	// A PFX file holds both the certificate and its private key
+	if _, err := client.EditCertificate(ctx, siteID, testMockPFXCertificate, "", "wrong", "ECC", "hash-2"); err == nil {
+		t.Errorf("Expected an error for a wrong PFX passphrase")
+	}
+	if _, err := client.EditCertificate(ctx, siteID, testMockPFXCertificate, "", "secret", "ECC", "hash-2"); err != nil {
+		t.Fatalf("Unexpected error adding PFX certificate: %s", err)
+	}
+	if pfx := mock.GetCustomCertificate(site.SiteID, "ECC"); pfx == nil || pfx.Sans[0] != "pfx.example.com" {
+		t.Errorf("Expected the PFX certificate to be stored, got %+v", pfx)
+	}
+
+	// Invalid uploads are rejected
+	eccKey := testMockECCKey(t)
+	_, eccPEM := testMockCertificate(t, "www.example.com", eccKey, false, notAfter, ca, caKey)
+	_, expiredPEM := testMockCertificate(t, "www.example.com", rsaKey, false, time.Now().AddDate(0, 0, -1), ca, caKey)
+	for name, upload := range map[string][3]string{
+		"mismatched key":      {testMockBase64(eccPEM), testMockBase64(testMockKeyPEM(t, testMockECCKey(t))), "ECC"},
+		"wrong auth type":     {testMockBase64(eccPEM), testMockBase64(testMockKeyPEM(t, eccKey)), "RSA"},
+		"expired certificate": {testMockBase64(expiredPEM), testMockBase64(testMockKeyPEM(t, rsaKey)), "RSA"},
+		"missing private key": {testMockBase64(rsaPEM), "", "RSA"},
+	} {
+		if _, err := client.AddCertificate(ctx, siteID, upload[0], upload[1], "", upload[2], "hash-3"); err == nil {
+			t.Errorf("Expected an error for %s", name)
+		}
+	}
+	if stored := mock.GetCustomCertificate(site.SiteID, "RSA"); stored.InputHash != "hash-1" {
+		t.Errorf("Expected rejected uploads not to replace the certificate, got %+v", stored)
+	}
+
+	if err := client.DeleteCertificate(ctx, siteID, "RSA"); err != nil {
+		t.Fatalf("Unexpected error deleting custom certificate: %s", err)
+	}
+	if err := client.DeleteCertificate(ctx, siteID, "RSA"); err == nil {
+		t.Errorf("Expected an error deleting a deleted certificate")
+	}
+}
+
+func TestMockHsmCertificates(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	site := &MockSite{Domain: "hsm.example.com"}
+	mock.AddSite(site)
+	siteID := strconv.Itoa(site.SiteID)
+
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+
+	key := testMockECCKey(t)
+	_, certificatePEM := testMockCertificate(t, "hsm.example.com", key, false, time.Now().AddDate(1, 0, 0), nil, nil)
+	hsm := HSMDetailsDTO{KeyId: "key-id", ApiKey: "api-key", HostName: "hsm.example.com"}
+
+	if _, err := client.AddHsmCertificate(ctx, siteID, "hash", &HSMDataDTO{Certificate: testMockBase64(certificatePEM)}); err == nil {
+		t.Errorf("Expected an error for an HSM certificate without HSM details")
+	}
+	if _, err := client.AddHsmCertificate(ctx, siteID, "hash", &HSMDataDTO{Certificate: testMockBase64(certificatePEM), HsmDetailsList: []HSMDetailsDTO{hsm}}); err != nil {
+		t.Fatalf("Unexpected error adding HSM certificate: %s", err)
+	}
+	if stored := mock.GetCustomCertificate(site.SiteID, "ECC"); stored == nil || stored.InputHash != "hash" || len(stored.HsmDetails) != 1 {
+		t.Errorf("Expected the HSM certificate to be stored, got %+v", stored)
+	}
+
+	if err := client.DeleteHsmCertificate(ctx, siteID); err != nil {
+		t.Fatalf("Unexpected error deleting HSM certificate: %s", err)
+	}
+	if err := client.DeleteHsmCertificate(ctx, siteID); err == nil {
+		t.Errorf("Expected an error deleting a deleted HSM certificate")
+	}
+}
+
+func TestMockManagedCertificates(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	site := &MockSite{Domain: "managed.example.com"}
+	mock.AddSite(site)
+
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+
+	// Nothing was requested yet
+	status, err := client.GetSiteCertificateRequestStatus(ctx, site.SiteID, nil)
+	if err != nil || len(status.Data) != 1 || len(status.Data[0].CertificatesDetails) != 0 {
+		t.Fatalf("Expected no managed certificate, got %+v %v", status, err)
+	}
+
+	if _, err := client.RequestSiteCertificate(ctx, site.SiteID, "EMAIL_AND_DNS", nil); err == nil {
+		t.Errorf("Expected an error for an invalid validation method")
+	}
+	requested, err := client.RequestSiteCertificate(ctx, site.SiteID, "DNS", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error requesting managed certificate: %v", err)
+	}
+	details := requested.Data[0].CertificatesDetails[0]
+	if details.Status != "IN_PROCESS" || details.Sans[0].SanValue != "managed.example.com" || details.Sans[0].ValidationMethod != "DNS" {
+		t.Errorf("Unexpected managed certificate: %+v", details)
+	}
+
+	if err := client.ValidateDomains(ctx, site.SiteID, []int{details.Sans[0].SanId}); err != nil {
+		t.Fatalf("Unexpected error validating domains: %v", err)
+	}
+	status, err = client.GetSiteCertificateRequestStatus(ctx, site.SiteID, nil)
+	if err != nil || status.Data[0].CertificatesDetails[0].Status != "ACTIVE" || status.Data[0].CertificatesDetails[0].Sans[0].Status != "VALIDATED" {
+		t.Errorf("Expected the validated certificate to be active, got %+v %v", status, err)
+	}
+
+	if _, err := client.DeleteRequestSiteCertificate(ctx, site.SiteID, nil); err != nil {
+		t.Fatalf("Unexpected error deleting managed certificate request: %v", err)
+	}
+	if status, _ := client.GetSiteCertificateRequestStatus(ctx, site.SiteID, nil); len(status.Data[0].CertificatesDetails) != 0 {
+		t.Errorf("Expected the managed certificate to be deleted, got %+v", status)
+	}
+}
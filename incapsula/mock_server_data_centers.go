@@ -0,0 +1,637 @@
+// Mock Imperva API Server - data centers and origin servers
+//
+// The v3 data centers configuration (client_data_centers_configuration.go) and the legacy v1 sites/dataCenters
+// endpoints (client_data_center.go, client_data_center_server.go) operate on the same data centers, like in the
+// real API. A site starts with a single data center holding one origin server, the site's domain.
+// See: https://docs-cybersec-be.thalesgroup.com/api/bundle/api-docs/page/cloud-v1-api-definition.htm
+
+package incapsula
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var mockDataCentersConfigurationPattern = regexp.MustCompile(`^sites/(\d+)/data-centers-configuration$`)
+
+var (
+	mockSiteLbAlgorithms         = []string{"BEST_CONNECTION_TIME", "GEO_PREFERRED", "GEO_REQUIRED", "WEIGHTED_LB"}
+	mockDataCenterModes          = []string{"SINGLE_SERVER", "SINGLE_DC", "MULTIPLE_DC"}
+	mockFailOverRequiredMonitors = []string{"ONE", "MANY", "MOST", "ALL"}
+	mockDcLbAlgorithms           = []string{"LB_LEAST_PENDING_REQUESTS", "LB_LEAST_OPEN_CONNECTIONS", "LB_SOURCE_IP_HASH", "RANDOM", "WEIGHTED"}
+	mockGeoLocations             = []string{"EUROPE", "AUSTRALIA", "US_EAST", "US_WEST", "AFRICA", "ASIA", "SOUTH_AMERICA", "NORTH_AMERICA"}
+)
+
+// MockDataCentersConfiguration represents the data centers of a site in the mock server. The settings are kept
+// in a DataCentersStruct whose DataCenters are not used.
+type MockDataCentersConfiguration struct {
+	Settings    DataCentersStruct `json:"settings"`
+	DataCenters []*MockDataCenter `json:"data_centers"`
+}
+
+// MockDataCenter represents a data center in the mock server
+type MockDataCenter struct {
+	DataCenterStruct
+	Servers []*MockOriginServer `json:"origin_servers"`
+}
+
+// MockOriginServer represents an origin server in the mock server. The ID is only exposed by the v1 API.
+type MockOriginServer struct {
+	ID int `json:"server_id"`
+	OriginServerStruct
+}
+
+// dataCentersConfiguration returns the data centers of a site, creating the initial data center on first use.
+// The caller must hold the lock.
+func (m *MockImpervaServer) dataCentersConfiguration(site *MockSite) *MockDataCentersConfiguration {
+	if configuration, exists := m.dataCenters[site.SiteID]; exists {
+		return configuration
+	}
+
+	address := site.Domain
+	if address == "" {
+		address = site.DnsARecord
+	}
+	dcID := m.nextDataCenterID
+	m.nextDataCenterID++
+	dataCenter := &MockDataCenter{
+		DataCenterStruct: DataCenterStruct{
+			Name:          "Main DC",
+			ID:            &dcID,
+			IpMode:        "MULTIPLE_IP",
+			DcLbAlgorithm: "LB_LEAST_PENDING_REQUESTS",
+			IsEnabled:     true,
+			IsActive:      true,
+			GeoLocations:  []string{},
+		},
+		Servers: []*MockOriginServer{{ID: m.nextOriginServerID, OriginServerStruct: OriginServerStruct{Address: address, IsEnabled: true, ServerMode: "ACTIVE"}}},
+	}
+	m.nextOriginServerID++
+
+	configuration := &MockDataCentersConfiguration{
+		Settings: DataCentersStruct{
+			SiteLbAlgorithm:                    "BEST_CONNECTION_TIME",
+			FailOverRequiredMonitors:           "MOST",
+			DataCenterMode:                     "SINGLE_SERVER",
+			MinAvailableServersForDataCenterUp: 1,
+		},
+		DataCenters: []*MockDataCenter{dataCenter},
+	}
+	m.dataCenters[site.SiteID] = configuration
+	return configuration
+}
+
+// v3 returns the configuration as the v3 API does
+func (configuration *MockDataCentersConfiguration) v3() DataCentersStruct {
+	result := configuration.Settings
+	result.DataCenters = make([]DataCenterStruct, 0, len(configuration.DataCenters))
+	for _, dataCenter := range configuration.DataCenters {
+		dc := dataCenter.DataCenterStruct
+		dc.OriginServers = make([]OriginServerStruct, 0, len(dataCenter.Servers))
+		for _, server := range dataCenter.Servers {
+			dc.OriginServers = append(dc.OriginServers, server.OriginServerStruct)
+		}
+		if dc.GeoLocations == nil {
+			dc.GeoLocations = []string{}
+		}
+		result.DataCenters = append(result.DataCenters, dc)
+	}
+	return result
+}
+
+// findDataCenter returns a data center and the configuration holding it. The caller must hold the lock.
+func (m *MockImpervaServer) findDataCenter(dcID int) (*MockDataCentersConfiguration, *MockDataCenter) {
+	for _, configuration := range m.dataCenters {
+		for _, dataCenter := range configuration.DataCenters {
+			if *dataCenter.ID == dcID {
+				return configuration, dataCenter
+			}
+		}
+	}
+	return nil, nil
+}
+
+// findOriginServer returns an origin server, the data center and the configuration holding it. The caller must hold the lock.
+func (m *MockImpervaServer) findOriginServer(serverID int) (*MockDataCentersConfiguration, *MockDataCenter, *MockOriginServer) {
+	for _, configuration := range m.dataCenters {
+		for _, dataCenter := range configuration.DataCenters {
+			for _, server := range dataCenter.Servers {
+				if server.ID == serverID {
+					return configuration, dataCenter, server
+				}
+			}
+		}
+	}
+	return nil, nil, nil
+}
+
+// hasServerAddress tells whether an origin server other than except has the address
+func (configuration *MockDataCentersConfiguration) hasServerAddress(address string, except *MockOriginServer) bool {
+	for _, dataCenter := range configuration.DataCenters {
+		for _, server := range dataCenter.Servers {
+			if server != except && strings.EqualFold(server.Address, address) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// writeDataCentersErrorResponse writes an error of the data centers configuration API, whose statuses are strings
+func (m *MockImpervaServer) writeDataCentersErrorResponse(w http.ResponseWriter, status int, pointer string, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(DataCentersConfigurationDTO{Errors: []ApiError{{
+		ID:      fmt.Sprintf("mock-%d", status),
+		Status:  strconv.Itoa(status),
+		Code:    strconv.Itoa(status),
+		Message: message,
+		Source:  ApiErrorSource{Pointer: pointer},
+	}}})
+}
+
+// Data Centers Configuration Handlers (v3)
+
+// handleDataCentersConfiguration handles GET/PUT /sites/{siteId}/data-centers-configuration.
+// A PUT replaces the whole configuration: data centers without an ID are created, the others are updated, and the
+// data centers that are not listed are deleted.
+func (m *MockImpervaServer) handleDataCentersConfiguration(w http.ResponseWriter, r *http.Request, path string) {
+	matches := mockDataCentersConfigurationPattern.FindStringSubmatch(path)
+	siteID, _ := strconv.Atoi(matches[1])
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	site, exists := m.sites[siteID]
+	if !exists {
+		m.writeDataCentersErrorResponse(w, http.StatusNotFound, "", fmt.Sprintf("Site %d not found", siteID))
+		return
+	}
+	configuration := m.dataCentersConfiguration(site)
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		body, _ := ioutil.ReadAll(r.Body)
+		var request DataCentersConfigurationDTO
+		if err := json.Unmarshal(body, &request); err != nil || len(request.Data) != 1 {
+			m.writeDataCentersErrorResponse(w, http.StatusBadRequest, "/data", "Expected a single data centers configuration")
+			return
+		}
+		requested := request.Data[0]
+		if pointer, message := validateMockDataCentersConfiguration(&requested); message != "" {
+			m.writeDataCentersErrorResponse(w, http.StatusBadRequest, pointer, message)
+			return
+		}
+
+		existing := map[int]*MockDataCenter{}
+		for _, dataCenter := range configuration.DataCenters {
+			existing[*dataCenter.ID] = dataCenter
+		}
+		listed := map[int]bool{}
+		for i, dc := range requested.DataCenters {
+			if dc.ID == nil {
+				continue
+			}
+			pointer := fmt.Sprintf("/data/0/dataCenters/%d/id", i)
+			if _, ok := existing[*dc.ID]; !ok {
+				m.writeDataCentersErrorResponse(w, http.StatusBadRequest, pointer, fmt.Sprintf("Data center %d not found in site %d", *dc.ID, siteID))
+				return
+			}
+			if listed[*dc.ID] {
+				m.writeDataCentersErrorResponse(w, http.StatusBadRequest, pointer, fmt.Sprintf("Data center %d is listed more than once", *dc.ID))
+				return
+			}
+			listed[*dc.ID] = true
+		}
+
+		// The configuration is valid, the data centers are updated in place to keep the IDs of their servers
+		var dataCenters []*MockDataCenter
+		for _, dc := range requested.DataCenters {
+			dataCenter := &MockDataCenter{}
+			if dc.ID != nil {
+				_, dataCenter = m.findDataCenter(*dc.ID)
+			} else {
+				dcID := m.nextDataCenterID
+				m.nextDataCenterID++
+				dc.ID = &dcID
+			}
+			dataCenter.DataCenterStruct = dc
+			dataCenter.OriginServers = nil
+			dataCenter.Servers = m.mergeOriginServers(dataCenter.Servers, dc.OriginServers)
+			dataCenters = append(dataCenters, dataCenter)
+		}
+
+		requested.DataCenters = nil
+		requested.KickStartPass = ""
+		configuration.Settings = requested
+		configuration.DataCenters = dataCenters
+	default:
+		m.writeDataCentersErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+		return
+	}
+	m.writeJSONResponse(w, DataCentersConfigurationDTO{Data: []DataCentersStruct{configuration.v3()}})
+}
+
+// mergeOriginServers returns the requested origin servers, keeping the IDs of the servers whose address is unchanged
+func (m *MockImpervaServer) mergeOriginServers(previous []*MockOriginServer, requested []OriginServerStruct) []*MockOriginServer {
+	previousIDs := map[string]int{}
+	for _, server := range previous {
+		previousIDs[strings.ToLower(server.Address)] = server.ID
+	}
+	servers := make([]*MockOriginServer, 0, len(requested))
+	for _, originServer := range requested {
+		server := &MockOriginServer{ID: previousIDs[strings.ToLower(originServer.Address)], OriginServerStruct: originServer}
+		if server.ID == 0 {
+			server.ID = m.nextOriginServerID
+			m.nextOriginServerID++
+		}
+		servers = append(servers, server)
+	}
+	return servers
+}
+
+// validateMockDataCentersConfiguration validates a configuration like the data centers configuration API does.
+// It returns the JSON pointer of the field at fault and the error message.
+func validateMockDataCentersConfiguration(configuration *DataCentersStruct) (string, string) {
+	if !contains(mockSiteLbAlgorithms, configuration.SiteLbAlgorithm) {
+		return "/data/0/lbAlgorithm", fmt.Sprintf("Invalid lbAlgorithm: %s", configuration.SiteLbAlgorithm)
+	}
+	if !contains(mockDataCenterModes, configuration.DataCenterMode) {
+		return "/data/0/dataCenterMode", fmt.Sprintf("Invalid dataCenterMode: %s", configuration.DataCenterMode)
+	}
+	if !contains(mockFailOverRequiredMonitors, configuration.FailOverRequiredMonitors) {
+		return "/data/0/failOverRequiredMonitors", fmt.Sprintf("Invalid failOverRequiredMonitors: %s", configuration.FailOverRequiredMonitors)
+	}
+
+	dataCenters := configuration.DataCenters
+	if len(dataCenters) == 0 {
+		return "/data/0/dataCenters", "At least one data center is required"
+	}
+	if configuration.DataCenterMode != "MULTIPLE_DC" && len(dataCenters) != 1 {
+		return "/data/0/dataCenters", fmt.Sprintf("dataCenterMode %s requires exactly one data center", configuration.DataCenterMode)
+	}
+	if configuration.DataCenterMode == "SINGLE_SERVER" && len(dataCenters[0].OriginServers) != 1 {
+		return "/data/0/dataCenters/0/servers", "dataCenterMode SINGLE_SERVER requires exactly one origin server"
+	}
+
+	geo := configuration.SiteLbAlgorithm == "GEO_PREFERRED" || configuration.SiteLbAlgorithm == "GEO_REQUIRED"
+	weighted := configuration.SiteLbAlgorithm == "WEIGHTED_LB"
+	names := map[string]bool{}
+	addresses := map[string]bool{}
+	geoLocations := map[string]bool{}
+	restOfTheWorld, totalWeight := 0, 0
+
+	for i, dc := range dataCenters {
+		pointer := fmt.Sprintf("/data/0/dataCenters/%d", i)
+		if dc.Name == "" {
+			return pointer + "/name", "Data center name is required"
+		}
+		if names[dc.Name] {
+			return pointer + "/name", fmt.Sprintf("Duplicate data center name: %s", dc.Name)
+		}
+		names[dc.Name] = true
+
+		if dc.IpMode != "SINGLE_IP" && dc.IpMode != "MULTIPLE_IP" {
+			return pointer + "/ipMode", fmt.Sprintf("Invalid ipMode: %s", dc.IpMode)
+		}
+		if dc.WebServersPerServer != nil && dc.IpMode != "SINGLE_IP" {
+			return pointer + "/webServersPerServer", "webServersPerServer is only applicable with ipMode SINGLE_IP"
+		}
+		if !contains(mockDcLbAlgorithms, dc.DcLbAlgorithm) {
+			return pointer + "/lbAlgorithm", fmt.Sprintf("Invalid lbAlgorithm: %s", dc.DcLbAlgorithm)
+		}
+
+		// Weights only apply under weighted load balancing, where they must add up to 100
+		if weighted {
+			if dc.Weight == nil {
+				return pointer + "/weight", "A weight is required for each data center with lbAlgorithm WEIGHTED_LB"
+			}
+			totalWeight += *dc.Weight
+		} else if dc.Weight != nil {
+			return pointer + "/weight", "Data center weights are only applicable with lbAlgorithm WEIGHTED_LB"
+		}
+
+		if dc.IsRestOfTheWorld {
+			if !geo {
+				return pointer + "/isRestOfTheWorld", "isRestOfTheWorld is only applicable with lbAlgorithm GEO_PREFERRED or GEO_REQUIRED"
+			}
+			restOfTheWorld++
+		}
+		if len(dc.GeoLocations) > 0 && !geo {
+			return pointer + "/geoLocations", "geoLocations are only applicable with lbAlgorithm GEO_PREFERRED or GEO_REQUIRED"
+		}
+		if geo && !dc.IsRestOfTheWorld && len(dc.GeoLocations) == 0 {
+			return pointer + "/geoLocations", fmt.Sprintf("geoLocations are required for data center %s", dc.Name)
+		}
+		for _, geoLocation := range dc.GeoLocations {
+			if !contains(mockGeoLocations, geoLocation) {
+				return pointer + "/geoLocations", fmt.Sprintf("Invalid geo location: %s", geoLocation)
+			}
+			if geoLocations[geoLocation] {
+				return pointer + "/geoLocations", fmt.Sprintf("Geo location %s is assigned to more than one data center", geoLocation)
+			}
+			geoLocations[geoLocation] = true
+		}
+
+		if len(dc.OriginServers) == 0 {
+			return pointer + "/servers", fmt.Sprintf("At least one origin server is required for data center %s", dc.Name)
+		}
+		serverWeight := 0
+		for j, server := range dc.OriginServers {
+			serverPointer := fmt.Sprintf("%s/servers/%d", pointer, j)
+			if server.Address == "" {
+				return serverPointer + "/address", "Origin server address is required"
+			}
+			if addresses[strings.ToLower(server.Address)] {
+				return serverPointer + "/address", fmt.Sprintf("Duplicate origin server address: %s", server.Address)
+			}
+			addresses[strings.ToLower(server.Address)] = true
+			if server.ServerMode != "ACTIVE" && server.ServerMode != "STANDBY" {
+				return serverPointer + "/serverMode", fmt.Sprintf("Invalid serverMode: %s", server.ServerMode)
+			}
+			if dc.DcLbAlgorithm == "WEIGHTED" {
+				if server.Weight == nil {
+					return serverPointer + "/weight", "A weight is required for each origin server of a data center with lbAlgorithm WEIGHTED"
+				}
+				serverWeight += *server.Weight
+			} else if server.Weight != nil {
+				return serverPointer + "/weight", "Origin server weights are only applicable with lbAlgorithm WEIGHTED"
+			}
+		}
+		if dc.DcLbAlgorithm == "WEIGHTED" && serverWeight != 100 {
+			return pointer + "/servers", fmt.Sprintf("The weights of the origin servers of data center %s must add up to 100, got %d", dc.Name, serverWeight)
+		}
+	}
+
+	if geo && restOfTheWorld != 1 {
+		return "/data/0/dataCenters", fmt.Sprintf("Exactly one data center must be the rest of the world data center with lbAlgorithm %s, got %d", configuration.SiteLbAlgorithm, restOfTheWorld)
+	}
+	if weighted && totalWeight != 100 {
+		return "/data/0/dataCenters", fmt.Sprintf("The weights of the data centers must add up to 100, got %d", totalWeight)
+	}
+	return "", ""
+}
+
+// Data Centers Handlers (v1)
+
+// handleDataCenterAdd handles POST /sites/dataCenters/add
+func (m *MockImpervaServer) handleDataCenterAdd(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r.ParseForm()
+	site, exists := m.sites[m.parseFormInt(r, "site_id")]
+	if !exists {
+		m.writeErrorResponse(w, 9413, "Unknown/unauthorized site_id")
+		return
+	}
+	name := m.parseFormValue(r, "name")
+	address := m.parseFormValue(r, "server_address")
+	if name == "" || address == "" {
+		m.writeErrorResponse(w, 1, "Missing name or server_address")
+		return
+	}
+
+	configuration := m.dataCentersConfiguration(site)
+	for _, dataCenter := range configuration.DataCenters {
+		if dataCenter.Name == name {
+			m.writeErrorResponse(w, 1, fmt.Sprintf("Duplicate data center name: %s", name))
+			return
+		}
+	}
+	if configuration.hasServerAddress(address, nil) {
+		m.writeErrorResponse(w, 1, fmt.Sprintf("Duplicate origin server address: %s", address))
+		return
+	}
+
+	dcID := m.nextDataCenterID
+	m.nextDataCenterID++
+	dataCenter := &MockDataCenter{
+		DataCenterStruct: DataCenterStruct{
+			Name:          name,
+			ID:            &dcID,
+			IpMode:        "MULTIPLE_IP",
+			DcLbAlgorithm: "LB_LEAST_PENDING_REQUESTS",
+			IsEnabled:     m.parseFormValue(r, "is_enabled") != "false",
+			IsActive:      true,
+			IsContent:     m.parseFormValue(r, "is_content") == "true",
+			GeoLocations:  []string{},
+		},
+		Servers: []*MockOriginServer{{ID: m.nextOriginServerID, OriginServerStruct: OriginServerStruct{Address: address, IsEnabled: true, ServerMode: "ACTIVE"}}},
+	}
+	m.nextOriginServerID++
+	configuration.DataCenters = append(configuration.DataCenters, dataCenter)
+	configuration.Settings.DataCenterMode = "MULTIPLE_DC"
+
+	m.writeSuccessResponse(w, map[string]interface{}{"datacenter_id": strconv.Itoa(dcID)})
+}
+
+// handleDataCenterList handles POST /sites/dataCenters/list
+func (m *MockImpervaServer) handleDataCenterList(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r.ParseForm()
+	site, exists := m.sites[m.parseFormInt(r, "site_id")]
+	if !exists {
+		m.writeErrorResponse(w, 9413, "Unknown/unauthorized site_id")
+		return
+	}
+
+	// The v1 API returns booleans as strings
+	dcs := make([]map[string]interface{}, 0)
+	for _, dataCenter := range m.dataCentersConfiguration(site).DataCenters {
+		servers := make([]map[string]interface{}, 0)
+		for _, server := range dataCenter.Servers {
+			servers = append(servers, map[string]interface{}{
+				"id":        strconv.Itoa(server.ID),
+				"enabled":   strconv.FormatBool(server.IsEnabled),
+				"address":   server.Address,
+				"isStandby": strconv.FormatBool(server.ServerMode == "STANDBY"),
+			})
+		}
+		dcs = append(dcs, map[string]interface{}{
+			"id":          strconv.Itoa(*dataCenter.ID),
+			"enabled":     strconv.FormatBool(dataCenter.IsEnabled),
+			"servers":     servers,
+			"name":        dataCenter.Name,
+			"contentOnly": strconv.FormatBool(dataCenter.IsContent),
+			"isActive":    strconv.FormatBool(dataCenter.IsActive),
+			"originPop":   dataCenter.OriginPoP,
+		})
+	}
+	m.writeSuccessResponse(w, map[string]interface{}{"DCs": dcs})
+}
+
+// handleDataCenterEdit handles POST /sites/dataCenters/edit
+func (m *MockImpervaServer) handleDataCenterEdit(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r.ParseForm()
+	configuration, dataCenter := m.findDataCenter(m.parseFormInt(r, "dc_id"))
+	if dataCenter == nil {
+		m.writeErrorResponse(w, 9413, "Unknown/unauthorized dc_id")
+		return
+	}
+
+	if name := m.parseFormValue(r, "name"); name != "" && name != dataCenter.Name {
+		for _, other := range configuration.DataCenters {
+			if other.Name == name {
+				m.writeErrorResponse(w, 1, fmt.Sprintf("Duplicate data center name: %s", name))
+				return
+			}
+		}
+		dataCenter.Name = name
+	}
+	if isContent := m.parseFormValue(r, "is_content"); isContent != "" {
+		dataCenter.IsContent = isContent == "true"
+	}
+	if isEnabled := m.parseFormValue(r, "is_enabled"); isEnabled != "" {
+		dataCenter.IsEnabled = isEnabled == "true"
+	}
+	m.writeSuccessResponse(w, map[string]interface{}{})
+}
+
+// handleDataCenterDelete handles POST /sites/dataCenters/delete. The last data center of a site can't be deleted.
+func (m *MockImpervaServer) handleDataCenterDelete(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r.ParseForm()
+	configuration, dataCenter := m.findDataCenter(m.parseFormInt(r, "dc_id"))
+	if dataCenter == nil {
+		m.writeErrorResponse(w, 9413, "Unknown/unauthorized dc_id")
+		return
+	}
+	if len(configuration.DataCenters) == 1 {
+		m.writeErrorResponse(w, 1, "The last data center of a site cannot be deleted")
+		return
+	}
+
+	dataCenters := make([]*MockDataCenter, 0, len(configuration.DataCenters)-1)
+	for _, other := range configuration.DataCenters {
+		if other != dataCenter {
+			dataCenters = append(dataCenters, other)
+		}
+	}
+	configuration.DataCenters = dataCenters
+	m.writeSuccessResponse(w, map[string]interface{}{})
+}
+
+// handleDataCenterServerAdd handles POST /sites/dataCenters/servers/add
+func (m *MockImpervaServer) handleDataCenterServerAdd(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r.ParseForm()
+	configuration, dataCenter := m.findDataCenter(m.parseFormInt(r, "dc_id"))
+	if dataCenter == nil {
+		m.writeErrorResponse(w, 9413, "Unknown/unauthorized dc_id")
+		return
+	}
+	address := m.parseFormValue(r, "server_address")
+	if address == "" {
+		m.writeErrorResponse(w, 1, "Missing server_address")
+		return
+	}
+	if configuration.hasServerAddress(address, nil) {
+		m.writeErrorResponse(w, 1, fmt.Sprintf("Duplicate origin server address: %s", address))
+		return
+	}
+
+	server := &MockOriginServer{ID: m.nextOriginServerID, OriginServerStruct: OriginServerStruct{
+		Address:    address,
+		IsEnabled:  m.parseFormValue(r, "is_disabled") != "true",
+		ServerMode: mockServerMode(m.parseFormValue(r, "is_standby")),
+	}}
+	m.nextOriginServerID++
+	dataCenter.Servers = append(dataCenter.Servers, server)
+	if configuration.Settings.DataCenterMode == "SINGLE_SERVER" {
+		configuration.Settings.DataCenterMode = "SINGLE_DC"
+	}
+
+	m.writeSuccessResponse(w, map[string]interface{}{"server_id": strconv.Itoa(server.ID)})
+}
+
+// handleDataCenterServerEdit handles POST /sites/dataCenters/servers/edit
+func (m *MockImpervaServer) handleDataCenterServerEdit(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r.ParseForm()
+	configuration, dataCenter, server := m.findOriginServer(m.parseFormInt(r, "server_id"))
+	if server == nil {
+		m.writeErrorResponse(w, 9413, "Unknown/unauthorized server_id")
+		return
+	}
+	if address := m.parseFormValue(r, "server_address"); address != "" {
+		if configuration.hasServerAddress(address, server) {
+			m.writeErrorResponse(w, 1, fmt.Sprintf("Duplicate origin server address: %s", address))
+			return
+		}
+		server.Address = address
+	}
+	if isStandby := m.parseFormValue(r, "is_standby"); isStandby != "" {
+		server.ServerMode = mockServerMode(isStandby)
+	}
+	if isEnabled := m.parseFormValue(r, "is_enabled"); isEnabled != "" {
+		server.IsEnabled = isEnabled == "true"
+	}
+
+	m.writeSuccessResponse(w, map[string]interface{}{"datacenter_id": strconv.Itoa(*dataCenter.ID)})
+}
+
+// handleDataCenterServerDelete handles POST /sites/dataCenters/servers/delete. The last server of a data center
+// can't be deleted.
+func (m *MockImpervaServer) handleDataCenterServerDelete(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r.ParseForm()
+	_, dataCenter, server := m.findOriginServer(m.parseFormInt(r, "server_id"))
+	if server == nil {
+		m.writeErrorResponse(w, 9413, "Unknown/unauthorized server_id")
+		return
+	}
+	if len(dataCenter.Servers) == 1 {
+		m.writeErrorResponse(w, 1, "The last origin server of a data center cannot be deleted")
+		return
+	}
+
+	servers := make([]*MockOriginServer, 0, len(dataCenter.Servers)-1)
+	for _, other := range dataCenter.Servers {
+		if other != server {
+			servers = append(servers, other)
+		}
+	}
+	dataCenter.Servers = servers
+	m.writeSuccessResponse(w, map[string]interface{}{"server_id": strconv.Itoa(server.ID)})
+}
+
+func mockServerMode(isStandby string) string {
+	if isStandby == "true" {
+		return "STANDBY"
+	}
+	return "ACTIVE"
+}
+
+// Helper methods for tests
+
+// GetDataCentersConfiguration returns the data centers configuration of a site as the v3 API does (for test assertions)
+func (m *MockImpervaServer) GetDataCentersConfiguration(siteID int) *DataCentersStruct {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	site, exists := m.sites[siteID]
+	if !exists {
+		return nil
+	}
+	configuration := m.dataCentersConfiguration(site).v3()
+	return &configuration
+}
@@ -0,0 +1,174 @@
+package incapsula
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func testMockIntPtr(i int) *int {
+	return &i
+}
+
+func TestMockDataCentersConfiguration(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	site := &MockSite{Domain: "origin.example.com"}
+	mock.AddSite(site)
+	siteID := strconv.Itoa(site.SiteID)
+
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+
+	// A new site has a single data center with the site's domain as origin server
+	read, err := client.GetDataCentersConfiguration(ctx, siteID)
+	if err != nil || len(read.Errors) > 0 || len(read.Data) != 1 || len(read.Data[0].DataCenters) != 1 {
+		t.Fatalf("Unexpected initial data centers configuration: %+v %v", read, err)
+	}
+	mainDC := read.Data[0].DataCenters[0]
+	if mainDC.ID == nil || len(mainDC.OriginServers) != 1 || mainDC.OriginServers[0].Address != "origin.example.com" {
+		t.Errorf("Expected the site's domain as origin server, got %+v", mainDC)
+	}
+
+	configuration := DataCentersStruct{
+		SiteLbAlgorithm:          "GEO_PREFERRED",
+		FailOverRequiredMonitors: "MOST",
+		DataCenterMode:           "MULTIPLE_DC",
+		DataCenters: []DataCenterStruct{
+			{Name: "Europe", ID: mainDC.ID, IpMode: "MULTIPLE_IP", DcLbAlgorithm: "WEIGHTED", IsEnabled: true, GeoLocations: []string{"EUROPE"}, OriginServers: []OriginServerStruct{
+				{Address: "1.1.1.1", IsEnabled: true, ServerMode: "ACTIVE", Weight: testMockIntPtr(70)},
+				{Address: "1.1.1.2", IsEnabled: true, ServerMode: "ACTIVE", Weight: testMockIntPtr(30)},
+			}},
+			{Name: "Rest of the world", IpMode: "SINGLE_IP", WebServersPerServer: testMockIntPtr(2), DcLbAlgorithm: "RANDOM", IsEnabled: true, IsRestOfTheWorld: true, OriginServers: []OriginServerStruct{
+				{Address: "2.2.2.2", IsEnabled: true, ServerMode: "ACTIVE"},
+				{Address: "2.2.2.3", IsEnabled: true, ServerMode: "STANDBY"},
+			}},
+		},
+	}
+	updated, err := client.PutDataCentersConfiguration(ctx, siteID, DataCentersConfigurationDTO{Data: []DataCentersStruct{configuration}})
+	if err != nil || len(updated.Errors) > 0 {
+		t.Fatalf("Unexpected error updating data centers configuration: %+v %v", updated, err)
+	}
+	stored := mock.GetDataCentersConfiguration(site.SiteID)
+	if len(stored.DataCenters) != 2 || *stored.DataCenters[0].ID != *mainDC.ID || stored.DataCenters[1].ID == nil {
+		t.Fatalf("Expected the main data center to be kept and a new one to be created, got %+v", stored)
+	}
+	if stored.SiteLbAlgorithm != "GEO_PREFERRED" || stored.DataCenters[1].OriginServers[1].ServerMode != "STANDBY" {
+		t.Errorf("Expected the configuration to be stored, got %+v", stored)
+	}
+
+	// Invalid configurations are rejected with a 400 pointing at the field at fault
+	invalid := map[string]func(c *DataCentersStruct){
+		"two rest of the world data centers": func(c *DataCentersStruct) {
+			c.DataCenters[0].IsRestOfTheWorld = true
+			c.DataCenters[0].GeoLocations = nil
+		},
+		"data center weight without weighted LB": func(c *DataCentersStruct) {
+			c.DataCenters[1].Weight = testMockIntPtr(50)
+		},
+		"server weight without weighted DC LB": func(c *DataCentersStruct) {
+			c.DataCenters[1].OriginServers[0].Weight = testMockIntPtr(100)
+		},
+		"duplicate server address": func(c *DataCentersStruct) {
+			c.DataCenters[1].OriginServers[0].Address = "1.1.1.1"
+		},
+		"geo location of another data center": func(c *DataCentersStruct) {
+			c.DataCenters[1].IsRestOfTheWorld = false
+			c.DataCenters[1].GeoLocations = []string{"EUROPE"}
+		},
+		"unknown data center": func(c *DataCentersStruct) {
+			c.DataCenters[1].ID = testMockIntPtr(1)
+		},
+	}
+	for name, mutate := range invalid {
+		request := configuration
+		request.DataCenters = make([]DataCenterStruct, len(configuration.DataCenters))
+		for i, dc := range configuration.DataCenters {
+			dc.OriginServers = append([]OriginServerStruct{}, dc.OriginServers...)
+			request.DataCenters[i] = dc
+		}
+		mutate(&request)
+		_, err := client.PutDataCentersConfiguration(ctx, siteID, DataCentersConfigurationDTO{Data: []DataCentersStruct{request}})
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != 400 || len(apiErr.Errors) != 1 || apiErr.Errors[0].Source["pointer"] == "" {
+			t.Errorf("Expected a 400 error for %s, got %v", name, err)
+		}
+	}
+	if stored := mock.GetDataCentersConfiguration(site.SiteID); len(stored.DataCenters) != 2 || stored.DataCenters[1].OriginServers[0].Address != "2.2.2.2" {
+		t.Errorf("Expected rejected configurations not to be stored, got %+v", stored)
+	}
+
+	// A deleted site is reported with a 404, so that the resource is removed from the state
+	_, err = client.GetDataCentersConfiguration(ctx, "1")
+	if !IsNotFound(err) {
+		t.Errorf("Expected a 404 error for an unknown site, got %v", err)
+	}
+}
+
+func TestMockDataCentersV1(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	site := &MockSite{Domain: "legacy.example.com"}
+	mock.AddSite(site)
+	siteID := strconv.Itoa(site.SiteID)
+
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+
+	added, err := client.AddDataCenter(ctx, siteID, "Backup", "3.3.3.3", "true", "true")
+	if err != nil {
+		t.Fatalf("Unexpected error adding data center: %s", err)
+	}
+	if _, err := client.AddDataCenter(ctx, siteID, "Other", "3.3.3.3", "false", "true"); err == nil || !strings.Contains(err.Error(), "Duplicate origin server address") {
+		t.Errorf("Expected a duplicate server address to be rejected, got %v", err)
+	}
+
+	server, err := client.AddDataCenterServer(ctx, added.DataCenterID, "3.3.3.4", "true", "true")
+	if err != nil {
+		t.Fatalf("Unexpected error adding data center server: %s", err)
+	}
+	if _, err := client.EditDataCenterServer(ctx, server.ServerID, "3.3.3.5", "false", "false"); err != nil {
+		t.Fatalf("Unexpected error editing data center server: %s", err)
+	}
+	if _, err := client.EditDataCenter(ctx, added.DataCenterID, "Backup DC", "", ""); err != nil {
+		t.Fatalf("Unexpected error editing data center: %s", err)
+	}
+
+	// The v1 and v3 APIs share the data centers
+	list, err := client.ListDataCenters(ctx, siteID)
+	if err != nil || len(list.DCs) != 2 {
+		t.Fatalf("Expected two data centers, got %+v %v", list, err)
+	}
+	backup := list.DCs[1]
+	if backup.ID != added.DataCenterID || backup.Name != "Backup DC" || backup.ContentOnly != "true" || len(backup.Servers) != 2 {
+		t.Errorf("Unexpected data center: %+v", backup)
+	}
+	if edited := backup.Servers[1]; edited.ID != server.ServerID || edited.Address != "3.3.3.5" || edited.Enabled != "false" || edited.IsStandBy != "false" {
+		t.Errorf("Unexpected data center server: %+v", edited)
+	}
+	if stored := mock.GetDataCentersConfiguration(site.SiteID); stored.DataCenterMode != "MULTIPLE_DC" || stored.DataCenters[1].Name != "Backup DC" {
+		t.Errorf("Expected the data center in the v3 configuration, got %+v", stored)
+	}
+
+	// The last server of a data center and the last data center of a site can't be deleted
+	if err := client.DeleteDataCenterServer(ctx, server.ServerID); err != nil {
+		t.Fatalf("Unexpected error deleting data center server: %s", err)
+	}
+	if err := client.DeleteDataCenterServer(ctx, backup.Servers[0].ID); err == nil {
+		t.Errorf("Expected an error deleting the last server of a data center")
+	}
+	if err := client.DeleteDataCenter(ctx, added.DataCenterID); err != nil {
+		t.Fatalf("Unexpected error deleting data center: %s", err)
+	}
+	if err := client.DeleteDataCenter(ctx, list.DCs[0].ID); err == nil {
+		t.Errorf("Expected an error deleting the last data center of a site")
+	}
+
+	if _, err := client.ListDataCenters(ctx, "1"); err == nil {
+		t.Errorf("Expected an error listing the data centers of an unknown site")
+	}
+}
@@ -0,0 +1,349 @@
+// Mock Imperva API Server - fault injection
+//
+// Faults make the mock server fail the requests matching a path pattern, so that tests can exercise the retries of
+// the client and the error paths of the resources. They are managed in Go with AddFault and friends, or over HTTP
+// with the /__mock/faults control plane endpoints, which are never subject to faults themselves.
+
+package incapsula
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Fault types
+const (
+	// MockFaultStatus answers with StatusCode: an HTML gateway error page for 5xx status codes, a JSON error otherwise
+	MockFaultStatus = "status"
+
+	// MockFaultHTML answers with an HTML error page and status 200, like the edge does when the API is unreachable
+	MockFaultHTML = "html"
+
+	// MockFaultReset resets the connection without answering
+	MockFaultReset = "reset"
+
+	// MockFaultLatency delays the request by Latency, then lets it through (or through the next matching fault)
+	MockFaultLatency = "latency"
+
+	// MockFaultResCode answers with status 200 and the v1 error ResCode
+	MockFaultResCode = "res"
+)
+
+var mockFaultsPattern = regexp.MustCompile(`^__mock/faults(?:/(\d+))?$`)
+
+// MockFault describes a failure injected into the requests matching PathPattern and Method
+type MockFault struct {
+	ID   int    `json:"id"`
+	Type string `json:"type"`
+
+	// Regular expression matched against the request path, without its leading slash. Empty matches every path.
+	PathPattern string `json:"path_pattern,omitempty"`
+
+	// HTTP method of the requests to fail. Empty matches every method.
+	Method string `json:"method,omitempty"`
+
+	// Number of consecutive matching requests to fail, 0 to fail all of them
+	Count int `json:"count,omitempty"`
+
+	StatusCode int           `json:"status_code,omitempty"`
+	ResCode    int           `json:"res_code,omitempty"`
+	Message    string        `json:"message,omitempty"`
+	Latency    time.Duration `json:"latency,omitempty"`
+
+	// Value of the Retry-After header in seconds, for status faults
+	RetryAfter int `json:"retry_after,omitempty"`
+
+	// Number of requests the fault was applied to
+	Hits int `json:"hits"`
+
+	pattern *regexp.Regexp
+}
+
+// MarshalJSON encodes the latency of the fault as a duration string, such as "250ms"
+func (fault MockFault) MarshalJSON() ([]byte, error) {
+	type mockFault MockFault
+	encoded := struct {
+		mockFault
+		Latency string `json:"latency,omitempty"`
+	}{mockFault: mockFault(fault)}
+	if fault.Latency > 0 {
+		encoded.Latency = fault.Latency.String()
+	}
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON decodes a fault whose latency is a duration string, such as "250ms"
+func (fault *MockFault) UnmarshalJSON(data []byte) error {
+	type mockFault MockFault
+	decoded := struct {
+		*mockFault
+		Latency string `json:"latency,omitempty"`
+	}{mockFault: (*mockFault)(fault)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if decoded.Latency != "" {
+		latency, err := time.ParseDuration(decoded.Latency)
+		if err != nil {
+			return fmt.Errorf("Invalid latency: %s", err)
+		}
+		fault.Latency = latency
+	}
+	return nil
+}
+
+// validateMockFault checks a fault, sets its defaults and compiles its path pattern
+func validateMockFault(fault *MockFault) error {
+	switch fault.Type {
+	case MockFaultStatus:
+		if fault.StatusCode == 0 {
+			fault.StatusCode = http.StatusServiceUnavailable
+		}
+		if fault.StatusCode < 400 || fault.StatusCode > 599 {
+			return fmt.Errorf("Invalid status_code %d, expected an error status code", fault.StatusCode)
+		}
+	case MockFaultResCode:
+		if fault.ResCode == 0 {
+			return fmt.Errorf("res_code is required for %s faults", MockFaultResCode)
+		}
+	case MockFaultLatency:
+		if fault.Latency <= 0 {
+			return fmt.Errorf("A positive latency is required for %s faults", MockFaultLatency)
+		}
+	case MockFaultHTML, MockFaultReset:
+	default:
+		return fmt.Errorf("Invalid fault type %q, expected one of %s, %s, %s, %s or %s", fault.Type, MockFaultStatus, MockFaultHTML, MockFaultReset, MockFaultLatency, MockFaultResCode)
+	}
+	if fault.Count < 0 {
+		return fmt.Errorf("Invalid count %d", fault.Count)
+	}
+
+	pattern, err := regexp.Compile(fault.PathPattern)
+	if err != nil {
+		return fmt.Errorf("Invalid path_pattern: %s", err)
+	}
+	fault.pattern = pattern
+	return nil
+}
+
+// matches tells whether the fault applies to a request
+func (fault *MockFault) matches(r *http.Request, path string) bool {
+	if fault.Count > 0 && fault.Hits >= fault.Count {
+		return false
+	}
+	if fault.Method != "" && fault.Method != r.Method {
+		return false
+	}
+	return fault.pattern.MatchString(path)
+}
+
+// injectFault applies the faults matching the request. It returns true if a fault answered the request, in which
+// case the request must not be handled any further.
+func (m *MockImpervaServer) injectFault(w http.ResponseWriter, r *http.Request, path string) bool {
+	var latency time.Duration
+	var fault *MockFault
+
+	m.mu.Lock()
+	for _, candidate := range m.faults {
+		if !candidate.matches(r, path) {
+			continue
+		}
+		candidate.Hits++
+		if candidate.Type == MockFaultLatency {
+			latency += candidate.Latency
+			continue
+		}
+		copied := *candidate
+		fault = &copied
+		break
+	}
+	m.mu.Unlock()
+
+	// The lock is not held while waiting, other requests go on meanwhile
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return true
+		}
+	}
+	if fault == nil {
+		return false
+	}
+
+	switch fault.Type {
+	case MockFaultStatus:
+		if fault.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(fault.RetryAfter))
+		}
+		if fault.StatusCode >= 500 {
+			writeMockErrorPage(w, fault.StatusCode, fault.Message)
+			return true
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(fault.StatusCode)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"res":         fault.StatusCode,
+			"res_message": mockFaultMessage(fault.Message, http.StatusText(fault.StatusCode)),
+		})
+	case MockFaultHTML:
+		writeMockErrorPage(w, http.StatusOK, fault.Message)
+	case MockFaultReset:
+		resetMockConnection(w)
+	case MockFaultResCode:
+		m.writeErrorResponse(w, fault.ResCode, mockFaultMessage(fault.Message, fmt.Sprintf("Injected error %d", fault.ResCode)))
+	}
+	return true
+}
+
+// mockFaultMessage returns the message of a fault, or the default message if it has none
+func mockFaultMessage(message, defaultMessage string) string {
+	if message == "" {
+		return defaultMessage
+	}
+	return message
+}
+
+// writeMockErrorPage writes an HTML error page, like the ones of the gateways in front of the API
+func writeMockErrorPage(w http.ResponseWriter, status int, message string) {
+	title := http.StatusText(status)
+	if status == http.StatusOK {
+		title = "Service Unavailable"
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<html><head><title>%s</title></head><body><h1>%s</h1><p>%s</p></body></html>", title, title, mockFaultMessage(message, "The request could not be processed."))
+}
+
+// resetMockConnection closes the connection of a request without answering. Unsent data is discarded so that the
+// client sees a connection reset rather than an orderly close.
+func resetMockConnection(w http.ResponseWriter) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		panic(http.ErrAbortHandler)
+	}
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		panic(http.ErrAbortHandler)
+	}
+	if tcpConn, ok := conn.(*net.TCPConn); ok {
+		tcpConn.SetLinger(0)
+	}
+	conn.Close()
+}
+
+// handleFaults handles GET/POST/DELETE /__mock/faults and GET/DELETE /__mock/faults/{faultId}
+func (m *MockImpervaServer) handleFaults(w http.ResponseWriter, r *http.Request, path string) {
+	matches := mockFaultsPattern.FindStringSubmatch(path)
+
+	if matches[1] != "" {
+		faultID, _ := strconv.Atoi(matches[1])
+		fault := m.Fault(faultID)
+		if fault == nil {
+			m.writeV3ErrorResponse(w, http.StatusNotFound, "", fmt.Sprintf("Fault %d not found", faultID))
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			m.writeJSONResponse(w, fault)
+		case http.MethodDelete:
+			m.RemoveFault(faultID)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		m.writeJSONResponse(w, m.Faults())
+	case http.MethodPost:
+		var fault MockFault
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &fault); err != nil {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "", fmt.Sprintf("Invalid request body: %s", err))
+			return
+		}
+		id, err := m.AddFault(fault)
+		if err != nil {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "", err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(m.Fault(id))
+	case http.MethodDelete:
+		m.ClearFaults()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+	}
+}
+
+// Helper methods for tests
+
+// AddFault adds a fault and returns its ID. Faults are applied in the order they were added: the latency of every
+// matching latency fault adds up, and the first other matching fault answers the request.
+func (m *MockImpervaServer) AddFault(fault MockFault) (int, error) {
+	if err := validateMockFault(&fault); err != nil {
+		return 0, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	fault.ID = m.nextFaultID
+	fault.Hits = 0
+	m.nextFaultID++
+	m.faults = append(m.faults, &fault)
+	return fault.ID, nil
+}
+
+// RemoveFault removes a fault by ID
+func (m *MockImpervaServer) RemoveFault(faultID int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, fault := range m.faults {
+		if fault.ID == faultID {
+			m.faults = append(m.faults[:i], m.faults[i+1:]...)
+			return
+		}
+	}
+}
+
+// ClearFaults removes all faults
+func (m *MockImpervaServer) ClearFaults() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.faults = nil
+}
+
+// Fault returns a copy of a fault by ID, including the number of requests it was applied to (for test assertions)
+func (m *MockImpervaServer) Fault(faultID int) *MockFault {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, fault := range m.faults {
+		if fault.ID == faultID {
+			copied := *fault
+			return &copied
+		}
+	}
+	return nil
+}
+
+// Faults returns a copy of all faults (for test assertions)
+func (m *MockImpervaServer) Faults() []MockFault {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	faults := []MockFault{}
+	for _, fault := range m.faults {
+		faults = append(faults, *fault)
+	}
+	return faults
+}
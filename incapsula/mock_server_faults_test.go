@@ -0,0 +1,143 @@
+package incapsula
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// testMockRetryingClient returns a client of the mock server retrying up to three times, without waiting long
+func testMockRetryingClient(url string) *Client {
+	config := testConfigForURL(url)
+	config.Retry = &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+	return NewClient(&config)
+}
+
+func TestMockFaultsRecovery(t *testing.T) {
+	WithMockServer(t, func(ctx *MockTestContext) {
+		site := ctx.CreateTestSite(0)
+		client := testMockRetryingClient(ctx.Server.URL())
+		background := context.Background()
+
+		for name, addFault := range map[string]func() int{
+			"502 gateway errors": func() int { return ctx.FailRequests("^sites/status$", 2, http.StatusBadGateway) },
+			"429 rate limiting":  func() int { return ctx.FailRequests("^sites/status$", 1, http.StatusTooManyRequests) },
+			"HTML error pages":   func() int { return ctx.FailRequestsWithHTML("^sites/status$", 2) },
+			"connection reset":   func() int { return ctx.ResetConnections("^sites/status$", 1) },
+		} {
+			faultID := addFault()
+			if _, err := client.SiteStatus(background, site.Domain, site.SiteID); err != nil {
+				t.Errorf("Expected the client to recover from %s, got %s", name, err)
+			}
+			if fault := ctx.Server.Fault(faultID); fault.Hits != fault.Count {
+				t.Errorf("Expected %s to fail %d requests, got %d", name, fault.Count, fault.Hits)
+			}
+			ctx.Server.RemoveFault(faultID)
+		}
+	})
+}
+
+func TestMockFaultsCountAndOrder(t *testing.T) {
+	WithMockServer(t, func(ctx *MockTestContext) {
+		site := ctx.CreateTestSite(0)
+		client := testMockRetryingClient(ctx.Server.URL())
+		background := context.Background()
+
+		// Faults apply in order, each to as many requests as its count
+		first := ctx.FailRequests("^sites/status$", 1, http.StatusBadGateway)
+		second := ctx.ResetConnections("^sites/status$", 1)
+		if _, err := client.SiteStatus(background, site.Domain, site.SiteID); err != nil {
+			t.Fatalf("Expected the client to recover, got %s", err)
+		}
+		if ctx.FaultHits(first) != 1 || ctx.FaultHits(second) != 1 {
+			t.Errorf("Expected each fault to be applied once, got %d and %d", ctx.FaultHits(first), ctx.FaultHits(second))
+		}
+
+		// Retries are exhausted by a fault without count
+		always := ctx.FailRequests("^sites/status$", 0, http.StatusServiceUnavailable)
+		if _, err := client.SiteStatus(background, site.Domain, site.SiteID); err == nil {
+			t.Errorf("Expected an error once retries are exhausted")
+		}
+		if hits := ctx.FaultHits(always); hits != 3 {
+			t.Errorf("Expected the three attempts to fail, got %d", hits)
+		}
+		ctx.Server.RemoveFault(always)
+
+		// Writes are not retried on a v1 error
+		resCode := ctx.FailRequestsWithResCode("^sites/configure$", 1, apiV1ResUnknownSiteID, "")
+		if _, err := client.UpdateSite(background, strconv.Itoa(site.SiteID), "domain", "updated.example.com"); !IsNotFound(err) {
+			t.Errorf("Expected a not found error, got %v", err)
+		}
+		if ctx.FaultHits(resCode) != 1 || ctx.Server.GetSite(site.SiteID).Domain == "updated.example.com" {
+			t.Errorf("Expected the update not to be retried nor applied")
+		}
+		if _, err := client.UpdateSite(background, strconv.Itoa(site.SiteID), "domain", "updated.example.com"); err != nil {
+			t.Errorf("Expected the update to succeed once the fault is exhausted, got %s", err)
+		}
+
+		// Latency delays the request, which then goes through
+		ctx.DelayRequests("^sites/status$", 50*time.Millisecond)
+		start := time.Now()
+		if _, err := client.SiteStatus(background, site.Domain, site.SiteID); err != nil {
+			t.Errorf("Unexpected error with latency: %s", err)
+		}
+		if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+			t.Errorf("Expected the request to take at least 50ms, took %s", elapsed)
+		}
+	})
+}
+
+func TestMockFaultsControlPlane(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+
+	post := func(body string) *http.Response {
+		resp, err := http.Post(mock.URL()+"/__mock/faults", contentTypeApplicationJson, bytes.NewBufferString(body))
+		if err != nil {
+			t.Fatalf("Unexpected error adding fault: %s", err)
+		}
+		return resp
+	}
+
+	resp := post(`{"type": "latency", "path_pattern": "^account$", "latency": "20ms"}`)
+	var fault MockFault
+	json.NewDecoder(resp.Body).Decode(&fault)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated || fault.ID == 0 || fault.Latency != 20*time.Millisecond {
+		t.Fatalf("Unexpected added fault: %d %+v", resp.StatusCode, fault)
+	}
+	for _, body := range []string{`{"type": "unknown"}`, `{"type": "status", "status_code": 200}`, `{"type": "html", "path_pattern": "("}`, `{"type": "latency", "latency": "soon"}`} {
+		if resp := post(body); resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected a 400 error for %s, got %d", body, resp.StatusCode)
+		}
+	}
+
+	// The control plane itself is never subject to faults
+	post(`{"type": "status", "status_code": 503}`).Body.Close()
+	resp, err := http.Get(mock.URL() + "/__mock/faults")
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Unexpected error listing faults: %v", err)
+	}
+	var faults []MockFault
+	json.NewDecoder(resp.Body).Decode(&faults)
+	resp.Body.Close()
+	if len(faults) != 2 || faults[1].StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Unexpected faults: %+v", faults)
+	}
+
+	request, _ := http.NewRequest(http.MethodDelete, mock.URL()+"/__mock/faults/"+strconv.Itoa(fault.ID), nil)
+	if resp, err := http.DefaultClient.Do(request); err != nil || resp.StatusCode != http.StatusNoContent {
+		t.Errorf("Unexpected error removing fault: %v", err)
+	}
+	request, _ = http.NewRequest(http.MethodDelete, mock.URL()+"/__mock/faults", nil)
+	if resp, err := http.DefaultClient.Do(request); err != nil || resp.StatusCode != http.StatusNoContent {
+		t.Errorf("Unexpected error clearing faults: %v", err)
+	}
+	if faults := mock.Faults(); len(faults) != 0 {
+		t.Errorf("Expected no faults left, got %+v", faults)
+	}
+}
@@ -3,6 +3,7 @@ package incapsula
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 // MockEnvironment holds the original environment variables for restoration
@@ -88,6 +89,8 @@ func (e *MockEnvironment) Restore() {
 type MockTestContext struct {
 	Server *MockImpervaServer
 	Env    *MockEnvironment
+
+	t *testing.T
 }
 
 // WithMockServer creates a mock server, sets up the environment, and executes the test function.
@@ -102,6 +105,7 @@ func WithMockServer(t *testing.T, testFunc func(ctx *MockTestContext)) {
 	ctx := &MockTestContext{
 		Server: server,
 		Env:    env,
+		t:      t,
 	}
 
 	testFunc(ctx)
@@ -145,19 +149,68 @@ func (ctx *MockTestContext) CreateTestCSPDomain(siteID int, domain string) *Mock
 	return cspDomain
 }
 
+// FailRequests makes the next count requests matching the path pattern fail with the status code (all of them if
+// count is 0) and returns the fault ID. 5xx status codes come with an HTML gateway error page.
+func (ctx *MockTestContext) FailRequests(pathPattern string, count, statusCode int) int {
+	return ctx.addFault(MockFault{Type: MockFaultStatus, PathPattern: pathPattern, Count: count, StatusCode: statusCode})
+}
+
+// FailRequestsWithHTML makes the next count requests matching the path pattern return an HTML error page with
+// status 200 (all of them if count is 0) and returns the fault ID
+func (ctx *MockTestContext) FailRequestsWithHTML(pathPattern string, count int) int {
+	return ctx.addFault(MockFault{Type: MockFaultHTML, PathPattern: pathPattern, Count: count})
+}
+
+// FailRequestsWithResCode makes the next count requests matching the path pattern fail with the v1 res code (all of
+// them if count is 0) and returns the fault ID
+func (ctx *MockTestContext) FailRequestsWithResCode(pathPattern string, count, resCode int, message string) int {
+	return ctx.addFault(MockFault{Type: MockFaultResCode, PathPattern: pathPattern, Count: count, ResCode: resCode, Message: message})
+}
+
+// ResetConnections resets the connection of the next count requests matching the path pattern (all of them if count
+// is 0) and returns the fault ID
+func (ctx *MockTestContext) ResetConnections(pathPattern string, count int) int {
+	return ctx.addFault(MockFault{Type: MockFaultReset, PathPattern: pathPattern, Count: count})
+}
+
+// DelayRequests delays all the requests matching the path pattern by latency and returns the fault ID
+func (ctx *MockTestContext) DelayRequests(pathPattern string, latency time.Duration) int {
+	return ctx.addFault(MockFault{Type: MockFaultLatency, PathPattern: pathPattern, Latency: latency})
+}
+
+// FaultHits returns the number of requests a fault was applied to
+func (ctx *MockTestContext) FaultHits(faultID int) int {
+	if fault := ctx.Server.Fault(faultID); fault != nil {
+		return fault.Hits
+	}
+	return 0
+}
+
+func (ctx *MockTestContext) addFault(fault MockFault) int {
+	faultID, err := ctx.Server.AddFault(fault)
+	if err != nil {
+		if ctx.t == nil {
+			panic(err)
+		}
+		ctx.t.Helper()
+		ctx.t.Fatalf("Error adding %s fault: %s", fault.Type, err)
+	}
+	return faultID
+}
+
 // ShouldUseMockServer returns true if tests should use the mock server
 // This allows tests to be run against either real API or mock server
 func ShouldUseMockServer() bool {
 	_, hasAPIID := os.LookupEnv("INCAPSULA_API_ID")
 	useMock := os.Getenv("USE_MOCK_SERVER")
 
-	// Use mock if explicitly requested or if no API credentials are set
-	return useMock == "true" || useMock == "1" || !hasAPIID
+	// Use mock if explicitly requested or if no API credentials are set (unless replaying recorded interactions)
+	return useMock == "true" || useMock == "1" || (!hasAPIID && !IsReplayMode())
 }
 
-// SkipIfNoMockAndNoCredentials skips the test if neither mock server nor real credentials are available
+// SkipIfNoMockAndNoCredentials skips the test if neither mock server, recorded interactions nor real credentials are available
 func SkipIfNoMockAndNoCredentials(t *testing.T) {
-	if !ShouldUseMockServer() {
+	if !ShouldUseMockServer() && !IsReplayMode() {
 		_, hasAPIID := os.LookupEnv("INCAPSULA_API_ID")
 		_, hasAPIKey := os.LookupEnv("INCAPSULA_API_KEY")
 		if !hasAPIID || !hasAPIKey {
@@ -0,0 +1,467 @@
+// Mock Imperva API Server - mutual TLS certificates
+//
+// The endpoints mirror the requests of the client_mtls_*.go files: Imperva to origin certificates and their site
+// associations, client CA certificates, their site associations and the client certificate settings of sites.
+// Like the real API, an Imperva to origin certificate must be an RSA certificate of 2048 bits or less issued by a
+// CA, a site has at most one of them, and client CA certificates must be CA certificates.
+
+package incapsula
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	mockMTLSOriginCertificatesPattern = regexp.MustCompile(`^certificates-ui/v3/mtls/origin(?:/(\d+))?(?:/associated-sites/(\d+))?$`)
+	mockClientCaCertificatesPattern   = regexp.MustCompile(`^certificate-manager/v2/accounts/(\d+)/client-certificates(?:/(\d+))?$`)
+	mockSiteClientCaCertificates      = regexp.MustCompile(`^certificate-manager/v2/sites/(\d+)/client-certificates(?:/(\d+))?$`)
+	mockSiteTlsSettingsPattern        = regexp.MustCompile(`^certificate-manager/v2/sites/(\d+)/configuration/client-certificates$`)
+)
+
+// MockMTLSCertificate represents an Imperva to origin mTLS certificate in the mock server
+type MockMTLSCertificate struct {
+	MockCertificateMetadata
+	ID        int    `json:"id"`
+	AccountID int    `json:"account_id"`
+	Name      string `json:"name"`
+	Hash      string `json:"hash"`
+}
+
+// MockClientCaCertificate represents a client CA certificate in the mock server
+type MockClientCaCertificate struct {
+	MockCertificateMetadata
+	ID        int    `json:"id"`
+	AccountID int    `json:"account_id"`
+	Name      string `json:"name"`
+}
+
+// mockFormFile returns the content of a file of a multipart request, nil if the file wasn't sent
+func mockFormFile(r *http.Request, key string) []byte {
+	file, _, err := r.FormFile(key)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+	content, _ := ioutil.ReadAll(file)
+	return content
+}
+
+// Imperva to Origin Certificates Handlers
+
+// handleMTLSOriginCertificates handles POST /certificates-ui/v3/mtls/origin,
+// GET/PUT/DELETE /certificates-ui/v3/mtls/origin/{certificateId} and
+// GET/PUT/DELETE /certificates-ui/v3/mtls/origin/{certificateId}/associated-sites/{siteId}
+func (m *MockImpervaServer) handleMTLSOriginCertificates(w http.ResponseWriter, r *http.Request, path string) {
+	matches := mockMTLSOriginCertificatesPattern.FindStringSubmatch(path)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if matches[1] == "" {
+		if r.Method != http.MethodPost || matches[2] != "" {
+			m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+			return
+		}
+		m.writeMTLSOriginCertificate(w, r, &MockMTLSCertificate{AccountID: m.requestAccountID(r)})
+		return
+	}
+
+	certificateID, _ := strconv.Atoi(matches[1])
+	certificate, exists := m.mtlsOriginCertificates[certificateID]
+	if !exists || !m.ownedByRequest(r, certificate.AccountID) {
+		m.writeV3ErrorResponse(w, http.StatusNotFound, "", fmt.Sprintf("Certificate %d not found", certificateID))
+		return
+	}
+
+	if matches[2] != "" {
+		siteID, _ := strconv.Atoi(matches[2])
+		m.handleMTLSOriginSiteAssociation(w, r, certificate, siteID)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		m.writeJSONResponse(w, map[string]interface{}{"data": []interface{}{certificate.data()}})
+	case http.MethodPut:
+		m.writeMTLSOriginCertificate(w, r, certificate)
+	case http.MethodDelete:
+		for siteID, associatedID := range m.mtlsOriginSites {
+			if associatedID == certificateID {
+				m.writeV3ErrorResponse(w, http.StatusBadRequest, "", fmt.Sprintf("Certificate %d is associated with site %d", certificateID, siteID))
+				return
+			}
+		}
+		delete(m.mtlsOriginCertificates, certificateID)
+		m.writeJSONResponse(w, map[string]interface{}{"data": []interface{}{}})
+	default:
+		m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+	}
+}
+
+// data returns the certificate as the API does
+func (certificate *MockMTLSCertificate) data() interface{} {
+	return struct {
+		MTLSCertificate
+		MockCertificateMetadata
+	}{
+		MTLSCertificate:         MTLSCertificate{Id: certificate.ID, Hash: certificate.Hash, Name: certificate.Name, AccountId: certificate.AccountID},
+		MockCertificateMetadata: certificate.MockCertificateMetadata,
+	}
+}
+
+// writeMTLSOriginCertificate validates an upload, stores the certificate and writes it. The caller must hold the lock.
+func (m *MockImpervaServer) writeMTLSOriginCertificate(w http.ResponseWriter, r *http.Request, certificate *MockMTLSCertificate) {
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "", fmt.Sprintf("Expected a multipart form: %s", err))
+		return
+	}
+	certificateFile := mockFormFile(r, "certificateFile")
+	if certificateFile == nil {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "/certificateFile", "certificateFile is required")
+		return
+	}
+
+	parsed, privateKey, err := parseMockCertificate(certificateFile, mockFormFile(r, "privateKeyFile"), r.FormValue("passphrase"))
+	if err != nil {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "/certificateFile", err.Error())
+		return
+	}
+	if privateKey == nil {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "/privateKeyFile", "privateKeyFile is required unless the certificate is a PFX file")
+		return
+	}
+	publicKey, ok := parsed.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "/certificateFile", "Only RSA certificates are supported")
+		return
+	}
+	if publicKey.N.BitLen() > 2048 {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "/certificateFile", fmt.Sprintf("The RSA key size must be 2048 bits or less, got %d", publicKey.N.BitLen()))
+		return
+	}
+	if bytes.Equal(parsed.RawIssuer, parsed.RawSubject) {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "/certificateFile", "Self-signed certificates are not supported, the certificate must be issued by a CA")
+		return
+	}
+
+	if certificate.ID == 0 {
+		certificate.ID = m.nextCertificateID
+		m.nextCertificateID++
+		m.mtlsOriginCertificates[certificate.ID] = certificate
+	}
+	certificate.MockCertificateMetadata = newMockCertificateMetadata(parsed)
+	certificate.Name = r.FormValue("certificateName")
+	if certificate.Name == "" {
+		certificate.Name = parsed.Subject.CommonName
+	}
+	// The hash is not sent when the sensitive fields are ignored, the previous one is kept then
+	if hash := r.FormValue("hash"); hash != "" {
+		certificate.Hash = hash
+	}
+
+	m.writeJSONResponse(w, map[string]interface{}{"data": []interface{}{certificate.data()}})
+}
+
+// handleMTLSOriginSiteAssociation handles the association of a site with an Imperva to origin certificate. A site has
+// at most one such certificate, associating another one replaces it. The caller must hold the lock.
+func (m *MockImpervaServer) handleMTLSOriginSiteAssociation(w http.ResponseWriter, r *http.Request, certificate *MockMTLSCertificate, siteID int) {
+	if _, exists := m.sites[siteID]; !exists {
+		m.writeV3ErrorResponse(w, http.StatusNotFound, "", fmt.Sprintf("Site %d not found", siteID))
+		return
+	}
+	associated := m.mtlsOriginSites[siteID] == certificate.ID
+
+	switch r.Method {
+	case http.MethodGet:
+		if !associated {
+			m.writeV3ErrorResponse(w, http.StatusNotFound, "", fmt.Sprintf("Site %d is not associated with certificate %d", siteID, certificate.ID))
+			return
+		}
+	case http.MethodPut:
+		m.mtlsOriginSites[siteID] = certificate.ID
+	case http.MethodDelete:
+		if !associated {
+			m.writeV3ErrorResponse(w, http.StatusNotFound, "", fmt.Sprintf("Site %d is not associated with certificate %d", siteID, certificate.ID))
+			return
+		}
+		delete(m.mtlsOriginSites, siteID)
+	default:
+		m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+		return
+	}
+	m.writeJSONResponse(w, map[string]interface{}{"data": []interface{}{certificate.data()}})
+}
+
+// Client CA Certificates Handlers
+
+// handleClientCaCertificates handles POST /certificate-manager/v2/accounts/{accountId}/client-certificates and
+// GET/DELETE /certificate-manager/v2/accounts/{accountId}/client-certificates/{certificateId}
+func (m *MockImpervaServer) handleClientCaCertificates(w http.ResponseWriter, r *http.Request, path string) {
+	matches := mockClientCaCertificatesPattern.FindStringSubmatch(path)
+	accountID, _ := strconv.Atoi(matches[1])
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if matches[2] == "" {
+		if r.Method != http.MethodPost {
+			m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+			return
+		}
+		m.handleClientCaCertificateAdd(w, r, accountID)
+		return
+	}
+
+	// The API answers 406 for a certificate that doesn't exist
+	certificateID, _ := strconv.Atoi(matches[2])
+	certificate, exists := m.clientCaCertificates[certificateID]
+	if !exists || certificate.AccountID != accountID {
+		m.writeV3ErrorResponse(w, http.StatusNotAcceptable, "", fmt.Sprintf("Certificate %d not found in account %d", certificateID, accountID))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		m.writeJSONResponse(w, m.clientCaCertificateData(certificate))
+	case http.MethodDelete:
+		if sites := m.clientCaCertificateSites(certificateID); len(sites) > 0 {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "", fmt.Sprintf("Certificate %d is assigned to sites %v", certificateID, sites))
+			return
+		}
+		delete(m.clientCaCertificates, certificateID)
+		w.WriteHeader(http.StatusOK)
+	default:
+		m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+	}
+}
+
+// handleClientCaCertificateAdd validates an upload and stores the certificate. The caller must hold the lock.
+func (m *MockImpervaServer) handleClientCaCertificateAdd(w http.ResponseWriter, r *http.Request, accountID int) {
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "", fmt.Sprintf("Expected a multipart form: %s", err))
+		return
+	}
+	caFile := mockFormFile(r, "ca_file")
+	if caFile == nil {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "/ca_file", "ca_file is required")
+		return
+	}
+	parsed, _, err := parseMockCertificate(caFile, nil, "")
+	if err != nil {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "/ca_file", err.Error())
+		return
+	}
+	if !parsed.IsCA {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "/ca_file", "The certificate is not a CA certificate")
+		return
+	}
+
+	certificate := &MockClientCaCertificate{
+		MockCertificateMetadata: newMockCertificateMetadata(parsed),
+		ID:                      m.nextCertificateID,
+		AccountID:               accountID,
+		Name:                    r.FormValue("name"),
+	}
+	m.nextCertificateID++
+	if certificate.Name == "" {
+		certificate.Name = parsed.Subject.CommonName
+	}
+	m.clientCaCertificates[certificate.ID] = certificate
+
+	m.writeJSONResponse(w, []ClientCaCertificate{{Id: certificate.ID, Name: certificate.Name}})
+}
+
+// clientCaCertificateSites returns the sites a client CA certificate is assigned to. The caller must hold the lock.
+func (m *MockImpervaServer) clientCaCertificateSites(certificateID int) []int {
+	sites := []int{}
+	for siteID, certificates := range m.clientCaSites {
+		if certificates[certificateID] {
+			sites = append(sites, siteID)
+		}
+	}
+	sort.Ints(sites)
+	return sites
+}
+
+// clientCaCertificateData returns a client CA certificate as the API does. The caller must hold the lock.
+func (m *MockImpervaServer) clientCaCertificateData(certificate *MockClientCaCertificate) interface{} {
+	return struct {
+		ClientCaCertificateWithSites
+		MockCertificateMetadata
+	}{
+		ClientCaCertificateWithSites: ClientCaCertificateWithSites{Id: certificate.ID, Name: certificate.Name, AssignedSites: m.clientCaCertificateSites(certificate.ID)},
+		MockCertificateMetadata:      certificate.MockCertificateMetadata,
+	}
+}
+
+// handleSiteClientCaCertificates handles GET /certificate-manager/v2/sites/{siteId}/client-certificates and
+// POST/DELETE /certificate-manager/v2/sites/{siteId}/client-certificates/{certificateId}
+func (m *MockImpervaServer) handleSiteClientCaCertificates(w http.ResponseWriter, r *http.Request, path string) {
+	matches := mockSiteClientCaCertificates.FindStringSubmatch(path)
+	siteID, _ := strconv.Atoi(matches[1])
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// The API answers 401 for a site that doesn't exist
+	site, exists := m.sites[siteID]
+	if !exists {
+		m.writeV3ErrorResponse(w, http.StatusUnauthorized, "", fmt.Sprintf("Site %d not found", siteID))
+		return
+	}
+
+	if matches[2] == "" {
+		if r.Method != http.MethodGet {
+			m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+			return
+		}
+		var certificateIDs []int
+		for certificateID := range m.clientCaSites[siteID] {
+			certificateIDs = append(certificateIDs, certificateID)
+		}
+		sort.Ints(certificateIDs)
+		certificates := []interface{}{}
+		for _, certificateID := range certificateIDs {
+			certificates = append(certificates, m.clientCaCertificateData(m.clientCaCertificates[certificateID]))
+		}
+		m.writeJSONResponse(w, certificates)
+		return
+	}
+
+	certificateID, _ := strconv.Atoi(matches[2])
+	certificate, exists := m.clientCaCertificates[certificateID]
+	if !exists || certificate.AccountID != siteAccountID(site) {
+		m.writeV3ErrorResponse(w, http.StatusNotFound, "", fmt.Sprintf("Certificate %d not found in the account of site %d", certificateID, siteID))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		if m.clientCaSites[siteID] == nil {
+			m.clientCaSites[siteID] = make(map[int]bool)
+		}
+		m.clientCaSites[siteID][certificateID] = true
+	case http.MethodDelete:
+		if !m.clientCaSites[siteID][certificateID] {
+			m.writeV3ErrorResponse(w, http.StatusNotFound, "", fmt.Sprintf("Certificate %d is not assigned to site %d", certificateID, siteID))
+			return
+		}
+		// Mandatory client certificates need a CA certificate to check them against
+		if settings := m.siteTlsSettings[siteID]; settings != nil && settings.Mandatory && len(m.clientCaSites[siteID]) == 1 {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "", fmt.Sprintf("Client certificates are mandatory for site %d, its last CA certificate cannot be unassigned", siteID))
+			return
+		}
+		delete(m.clientCaSites[siteID], certificateID)
+	default:
+		m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+		return
+	}
+	m.writeJSONResponse(w, m.clientCaCertificateData(certificate))
+}
+
+// handleSiteTlsSettings handles GET/PUT /certificate-manager/v2/sites/{siteId}/configuration/client-certificates
+func (m *MockImpervaServer) handleSiteTlsSettings(w http.ResponseWriter, r *http.Request, path string) {
+	matches := mockSiteTlsSettingsPattern.FindStringSubmatch(path)
+	siteID, _ := strconv.Atoi(matches[1])
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.sites[siteID]; !exists {
+		m.writeV3ErrorResponse(w, http.StatusUnauthorized, "", fmt.Sprintf("Site %d not found", siteID))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var settings SiteTlsSettings
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &settings); err != nil {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "", fmt.Sprintf("Invalid request body: %s", err))
+			return
+		}
+		if pointer, message := validateMockSiteTlsSettings(&settings); message != "" {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, pointer, message)
+			return
+		}
+		if settings.Mandatory && len(m.clientCaSites[siteID]) == 0 {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "/mandatory", fmt.Sprintf("Client certificates can only be mandatory once a CA certificate is assigned to site %d", siteID))
+			return
+		}
+		m.siteTlsSettings[siteID] = &settings
+	default:
+		m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+		return
+	}
+
+	settings := m.siteTlsSettings[siteID]
+	if settings == nil {
+		settings = &SiteTlsSettings{Ports: []int{}, Hosts: []string{}, Fingerprints: []string{}}
+	}
+	m.writeJSONResponse(w, settings)
+}
+
+// validateMockSiteTlsSettings validates client certificate settings like the API does. It returns the JSON pointer
+// of the field at fault and the error message.
+func validateMockSiteTlsSettings(settings *SiteTlsSettings) (string, string) {
+	for i, port := range settings.Ports {
+		if port < 1 || port > 65535 {
+			return fmt.Sprintf("/ports/%d", i), fmt.Sprintf("Invalid port: %d", port)
+		}
+	}
+	for i, host := range settings.Hosts {
+		if host == "" {
+			return fmt.Sprintf("/hosts/%d", i), "Hosts cannot be empty"
+		}
+	}
+	// Fingerprints are the SHA-1 or SHA-256 fingerprints of client certificates
+	for i, fingerprint := range settings.Fingerprints {
+		fingerprint = strings.ReplaceAll(fingerprint, ":", "")
+		if _, err := hex.DecodeString(fingerprint); err != nil || (len(fingerprint) != 40 && len(fingerprint) != 64) {
+			return fmt.Sprintf("/fingerprints/%d", i), fmt.Sprintf("Invalid fingerprint: %s", settings.Fingerprints[i])
+		}
+	}
+	if !settings.ForwardToOrigin && (settings.HeaderName != "" || settings.HeaderValue != "") {
+		return "/headerName", "headerName and headerValue are only applicable when forwardToOrigin is enabled"
+	}
+	if settings.HeaderValue != "" && settings.HeaderName == "" {
+		return "/headerName", "headerName is required with headerValue"
+	}
+	if settings.Ports == nil {
+		settings.Ports = []int{}
+	}
+	if settings.Hosts == nil {
+		settings.Hosts = []string{}
+	}
+	if settings.Fingerprints == nil {
+		settings.Fingerprints = []string{}
+	}
+	return "", ""
+}
+
+// Helper methods for tests
+
+// GetMTLSOriginCertificate returns an Imperva to origin certificate by ID (for test assertions)
+func (m *MockImpervaServer) GetMTLSOriginCertificate(certificateID int) *MockMTLSCertificate {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.mtlsOriginCertificates[certificateID]
+}
+
+// GetClientCaCertificate returns a client CA certificate by ID (for test assertions)
+func (m *MockImpervaServer) GetClientCaCertificate(certificateID int) *MockClientCaCertificate {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.clientCaCertificates[certificateID]
+}
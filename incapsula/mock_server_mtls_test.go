@@ -0,0 +1,173 @@
+package incapsula
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMockMTLSOriginCertificates(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	site := &MockSite{Domain: "origin.example.com", AccountID: mockAPIKeyAccountID}
+	mock.AddSite(site)
+
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+	accountID := strconv.Itoa(mockAPIKeyAccountID)
+
+	notAfter := time.Now().AddDate(1, 0, 0)
+	caKey := testMockRSAKey(t)
+	ca, caPEM := testMockCertificate(t, "Mock CA", caKey, true, notAfter, nil, nil)
+	key := testMockRSAKey(t)
+	_, certificatePEM := testMockCertificate(t, "origin.example.com", key, false, notAfter, ca, caKey)
+	keyPEM := testMockKeyPEM(t, key)
+
+	created, err := client.AddMTLSCertificate(ctx, certificatePEM, keyPEM, "", "", "hash-1", accountID)
+	if err != nil {
+		t.Fatalf("Unexpected error adding mTLS certificate: %s", err)
+	}
+	// The name defaults to the common name of the certificate
+	if created.Name != "origin.example.com" || created.Hash != "hash-1" || created.AccountId != mockAPIKeyAccountID {
+		t.Errorf("Unexpected mTLS certificate: %+v", created)
+	}
+	certificateID := strconv.Itoa(created.Id)
+
+	// The hash is kept when it isn't sent
+	updated, err := client.UpdateMTLSCertificate(ctx, certificateID, certificatePEM, keyPEM, "", "renamed", "", accountID)
+	if err != nil || updated.Name != "renamed" || updated.Hash != "hash-1" {
+		t.Fatalf("Unexpected updated mTLS certificate: %+v %v", updated, err)
+	}
+	if _, err := client.GetMTLSCertificate(ctx, certificateID, "2000"); err == nil {
+		t.Errorf("Expected an error reading the certificate of another account")
+	}
+
+	// Only RSA certificates issued by a CA, along with their private key, are accepted
+	eccKey := testMockECCKey(t)
+	_, eccPEM := testMockCertificate(t, "origin.example.com", eccKey, false, notAfter, ca, caKey)
+	for name, upload := range map[string][2][]byte{
+		"self-signed certificate": {caPEM, testMockKeyPEM(t, caKey)},
+		"ECC certificate":         {eccPEM, testMockKeyPEM(t, eccKey)},
+		"mismatched key":          {certificatePEM, testMockKeyPEM(t, caKey)},
+		"missing private key":     {certificatePEM, nil},
+	} {
+		if _, err := client.AddMTLSCertificate(ctx, upload[0], upload[1], "", name, "", accountID); err == nil {
+			t.Errorf("Expected an error for %s", name)
+		}
+	}
+
+	if err := client.CreateSiteMtlsCertificateAssociation(ctx, created.Id, site.SiteID, accountID); err != nil {
+		t.Fatalf("Unexpected error associating site: %s", err)
+	}
+	if associated, err := client.GetSiteMtlsCertificateAssociation(ctx, created.Id, site.SiteID, accountID); err != nil || !associated {
+		t.Errorf("Expected the site to be associated, got %t %v", associated, err)
+	}
+	if err := client.DeleteMTLSCertificate(ctx, certificateID, accountID); err == nil {
+		t.Errorf("Expected an error deleting an associated certificate")
+	}
+
+	if err := client.DeleteSiteMtlsCertificateAssociation(ctx, created.Id, site.SiteID, accountID); err != nil {
+		t.Fatalf("Unexpected error removing site association: %s", err)
+	}
+	if associated, err := client.GetSiteMtlsCertificateAssociation(ctx, created.Id, site.SiteID, accountID); err != nil || associated {
+		t.Errorf("Expected the site not to be associated, got %t %v", associated, err)
+	}
+	if err := client.DeleteMTLSCertificate(ctx, certificateID, accountID); err != nil {
+		t.Fatalf("Unexpected error deleting mTLS certificate: %s", err)
+	}
+	if mock.GetMTLSOriginCertificate(created.Id) != nil {
+		t.Errorf("Expected the mTLS certificate to be deleted")
+	}
+}
+
+func TestMockClientCaCertificates(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	site := &MockSite{Domain: "client.example.com", AccountID: mockAPIKeyAccountID}
+	mock.AddSite(site)
+
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+	accountID := strconv.Itoa(mockAPIKeyAccountID)
+
+	notAfter := time.Now().AddDate(1, 0, 0)
+	caKey := testMockECCKey(t)
+	ca, caPEM := testMockCertificate(t, "Client CA", caKey, true, notAfter, nil, nil)
+	_, leafPEM := testMockCertificate(t, "client", testMockECCKey(t), false, notAfter, ca, caKey)
+
+	if _, err := client.AddClientCaCertificate(ctx, leafPEM, accountID, "leaf"); err == nil {
+		t.Errorf("Expected an error for a certificate that is not a CA")
+	}
+	created, err := client.AddClientCaCertificate(ctx, caPEM, accountID, "")
+	if err != nil || created.Name != "Client CA" {
+		t.Fatalf("Unexpected client CA certificate: %+v %v", created, err)
+	}
+	certificateID := strconv.Itoa(created.Id)
+
+	if err := client.CreateSiteMtlsClientToImpervaCertificateAssociation(ctx, created.Id, site.SiteID, ""); err != nil {
+		t.Fatalf("Unexpected error assigning certificate: %s", err)
+	}
+	read, exists, err := client.GetClientCaCertificate(ctx, accountID, certificateID)
+	if err != nil || !exists || len(read.AssignedSites) != 1 || read.AssignedSites[0] != site.SiteID {
+		t.Errorf("Expected the certificate to be assigned to the site, got %+v %v", read, err)
+	}
+	if _, found, err := client.GetSiteMtlsClientToImpervaCertificateAssociation(ctx, site.SiteID, created.Id, ""); err != nil || !found {
+		t.Errorf("Expected the site association to be found, got %v", err)
+	}
+	if err := client.DeleteClientCaCertificate(ctx, accountID, certificateID); err == nil {
+		t.Errorf("Expected an error deleting an assigned certificate")
+	}
+
+	settings := SiteTlsSettings{Mandatory: true, Ports: []int{443}, Fingerprints: []string{strings.Repeat("AB", 20)}, ForwardToOrigin: true, HeaderName: "X-Client-Cert"}
+	if err := client.UpdateSiteTlsSetings(ctx, site.SiteID, settings); err != nil {
+		t.Fatalf("Unexpected error updating site TLS settings: %s", err)
+	}
+	stored, exists, err := client.GetSiteTlsSettings(ctx, site.SiteID)
+	if err != nil || !exists || !stored.Mandatory || stored.HeaderName != "X-Client-Cert" || len(stored.Hosts) != 0 {
+		t.Errorf("Unexpected site TLS settings: %+v %v", stored, err)
+	}
+
+	// Invalid settings are rejected
+	for name, mutate := range map[string]func(s *SiteTlsSettings){
+		"invalid port":                func(s *SiteTlsSettings) { s.Ports = []int{70000} },
+		"invalid fingerprint":         func(s *SiteTlsSettings) { s.Fingerprints = []string{"not a fingerprint"} },
+		"header without forwarding":   func(s *SiteTlsSettings) { s.ForwardToOrigin = false },
+		"header value without a name": func(s *SiteTlsSettings) { s.HeaderName, s.HeaderValue = "", "value" },
+	} {
+		request := settings
+		mutate(&request)
+		if err := client.UpdateSiteTlsSetings(ctx, site.SiteID, request); err == nil {
+			t.Errorf("Expected an error for %s", name)
+		}
+	}
+
+	// Mandatory client certificates need an assigned CA certificate
+	if err := client.DeleteSiteMtlsClientToImpervaCertificateAssociation(ctx, created.Id, site.SiteID, ""); err == nil {
+		t.Errorf("Expected an error unassigning the last CA certificate of a site with mandatory client certificates")
+	}
+	settings.Mandatory = false
+	if err := client.UpdateSiteTlsSetings(ctx, site.SiteID, settings); err != nil {
+		t.Fatalf("Unexpected error updating site TLS settings: %s", err)
+	}
+	if err := client.DeleteSiteMtlsClientToImpervaCertificateAssociation(ctx, created.Id, site.SiteID, ""); err != nil {
+		t.Fatalf("Unexpected error unassigning certificate: %s", err)
+	}
+	settings.Mandatory = true
+	if err := client.UpdateSiteTlsSetings(ctx, site.SiteID, settings); err == nil {
+		t.Errorf("Expected an error making client certificates mandatory without a CA certificate")
+	}
+
+	if err := client.DeleteClientCaCertificate(ctx, accountID, certificateID); err != nil {
+		t.Fatalf("Unexpected error deleting client CA certificate: %s", err)
+	}
+	if _, exists, err := client.GetClientCaCertificate(ctx, accountID, certificateID); err != nil || exists {
+		t.Errorf("Expected the deleted certificate not to exist, got %v", err)
+	}
+	if _, exists, err := client.GetSiteTlsSettings(ctx, 1); err != nil || exists {
+		t.Errorf("Expected an unknown site not to exist, got %v", err)
+	}
+}
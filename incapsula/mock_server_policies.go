@@ -0,0 +1,483 @@
+// Mock Imperva API Server - policies, policy asset associations and account policy associations
+//
+// The endpoints mirror the requests of client_policy.go, client_policy_asset_association.go and
+// client_account_policy_association.go. Like the real API, every account has a default WAF policy,
+// created with the account's first use of the policies API, and a site always has exactly one WAF policy:
+// new sites get the account's default WAF policy, associating another WAF policy replaces it, and
+// removing it restores the default.
+
+package incapsula
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+const mockPolicyAssetTypeWebsite = "WEBSITE"
+
+var (
+	mockPoliciesPattern                = regexp.MustCompile(`^policies/v2/policies(?:/(\d+))?$`)
+	mockPolicyAssetPattern             = regexp.MustCompile(`^policies/v2/policies/(\d+)/assets/([^/]+)/([^/]+)$`)
+	mockAssetPolicyPattern             = regexp.MustCompile(`^policies/v2/assets/([^/]+)/([^/]+)/policies/(\d+)$`)
+	mockAccountAssociatedPoliciesRoute = "policies/v3/accounts/associated-policies"
+)
+
+// mockWafPolicySettingTypes are the settings every WAF_RULES policy must have
+var mockWafPolicySettingTypes = []string{"REMOTE_FILE_INCLUSION", "ILLEGAL_RESOURCE_ACCESS", "CROSS_SITE_SCRIPTING", "SQL_INJECTION"}
+
+// mockPolicySettingTypes maps the policy types to the setting types of their policies
+var mockPolicySettingTypes = map[string][]string{
+	"ACL":         {"IP", "GEO", "URL"},
+	"WHITELIST":   {"IP", "GEO", "URL"},
+	"FILE_UPLOAD": {"MALICIOUS_FILE_UPLOAD"},
+	"WAF_RULES":   append([]string{"RESP_DATA_LEAK"}, mockWafPolicySettingTypes...),
+}
+
+var (
+	mockPolicySettingsActions = []string{"BLOCK", "ALLOW", "ALERT", "BLOCK_USER", "BLOCK_IP", "IGNORE"}
+	mockPolicyExceptionTypes  = []string{"GEO", "IP", "URL", "CLIENT_ID", "SITE_ID", "FILE_HASH"}
+)
+
+// writePolicyErrorResponse writes an error of the policies API
+func (m *MockImpervaServer) writePolicyErrorResponse(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"isError": true,
+		"message": message,
+	})
+}
+
+// siteAccountID returns the account of a site, sites added without an account belonging to the account of the API key
+func siteAccountID(site *MockSite) int {
+	if site.AccountID == 0 {
+		return mockAPIKeyAccountID
+	}
+	return site.AccountID
+}
+
+// accountPolicyAssociation returns the policy association of an account, creating the account's default WAF policy
+// on first use. The caller must hold the lock.
+func (m *MockImpervaServer) accountPolicyAssociation(accountID int) *AccountPolicyAssociationV3 {
+	if association, exists := m.accountPolicies[accountID]; exists {
+		return association
+	}
+
+	policy := &Policy{
+		ID:          m.nextPolicyID,
+		Name:        "Default Policy",
+		Description: "Default WAF policy",
+		Enabled:     true,
+		AccountID:   accountID,
+		PolicyType:  "WAF_RULES",
+	}
+	m.nextPolicyID++
+	for _, settingType := range mockWafPolicySettingTypes {
+		policy.PolicySettings = append(policy.PolicySettings, PolicySetting{SettingsAction: "BLOCK", PolicySettingType: settingType})
+	}
+	m.policies[policy.ID] = policy
+
+	association := &AccountPolicyAssociationV3{
+		AccountID:                               accountID,
+		AvailablePolicyIds:                      []int{},
+		DefaultNonMandatoryNonDistinctPolicyIds: []int{},
+		DefaultWafPolicyId:                      policy.ID,
+	}
+	m.accountPolicies[accountID] = association
+	return association
+}
+
+// applyDefaultPolicies associates the default WAF policy and the default non mandatory policies of its account
+// to a new site. The caller must hold the lock.
+func (m *MockImpervaServer) applyDefaultPolicies(site *MockSite) {
+	association := m.accountPolicyAssociation(siteAccountID(site))
+	assetKey := mockAssetKey(mockPolicyAssetTypeWebsite, strconv.Itoa(site.SiteID))
+	m.policyAssets[assetKey] = map[int]bool{association.DefaultWafPolicyId: true}
+	for _, policyID := range association.DefaultNonMandatoryNonDistinctPolicyIds {
+		m.policyAssets[assetKey][policyID] = true
+	}
+}
+
+func mockAssetKey(assetType string, assetID string) string {
+	return assetType + "/" + assetID
+}
+
+// policyResponse returns a policy with the attributes the API computes: whether it is the default WAF policy of its account
+func (m *MockImpervaServer) policyResponse(policy *Policy) Policy {
+	response := *policy
+	response.DefaultPolicyConfig = []DefaultPolicyConfig{}
+	if association, exists := m.accountPolicies[policy.AccountID]; exists && association.DefaultWafPolicyId == policy.ID {
+		response.IsMarkedAsDefault = true
+		response.DefaultPolicyConfig = append(response.DefaultPolicyConfig, DefaultPolicyConfig{
+			AccountID: policy.AccountID,
+			AssetType: mockPolicyAssetTypeWebsite,
+			PolicyID:  policy.ID,
+		})
+	}
+	if response.PolicySettings == nil {
+		response.PolicySettings = []PolicySetting{}
+	}
+	return response
+}
+
+// Policies Handlers
+
+// handlePolicies handles POST/GET /policies/v2/policies and GET/PUT/DELETE /policies/v2/policies/{policyId}
+func (m *MockImpervaServer) handlePolicies(w http.ResponseWriter, r *http.Request, path string) {
+	matches := mockPoliciesPattern.FindStringSubmatch(path)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if matches[1] == "" {
+		switch r.Method {
+		case http.MethodGet:
+			accountID := m.requestAccountID(r)
+			m.accountPolicyAssociation(accountID)
+			policies := make([]Policy, 0)
+			for _, policy := range m.policies {
+				if policy.AccountID == accountID {
+					policies = append(policies, m.policyResponse(policy))
+				}
+			}
+			sort.Slice(policies, func(i, j int) bool { return policies[i].ID < policies[j].ID })
+			m.writeJSONResponse(w, PolicyExtendedAll{Value: policies})
+		case http.MethodPost:
+			submitted, ok := m.decodePolicy(w, r)
+			if !ok {
+				return
+			}
+			accountID := submitted.AccountID
+			if accountID == 0 {
+				accountID = m.requestAccountID(r)
+			}
+			m.accountPolicyAssociation(accountID)
+
+			policy := &Policy{
+				ID:             m.nextPolicyID,
+				Name:           submitted.Name,
+				Description:    submitted.Description,
+				Enabled:        submitted.Enabled,
+				AccountID:      accountID,
+				PolicyType:     submitted.PolicyType,
+				PolicySettings: submitted.PolicySettings,
+			}
+			m.nextPolicyID++
+			m.policies[policy.ID] = policy
+			m.writeJSONResponse(w, PolicyExtended{Value: m.policyResponse(policy)})
+		default:
+			m.writePolicyErrorResponse(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method not allowed: %s", r.Method))
+		}
+		return
+	}
+
+	policyID, _ := strconv.Atoi(matches[1])
+	policy, exists := m.policies[policyID]
+	if !exists {
+		m.writePolicyErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Policy %d not found", policyID))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		m.writeJSONResponse(w, PolicyExtended{Value: m.policyResponse(policy)})
+	case http.MethodPut:
+		submitted, ok := m.decodePolicy(w, r)
+		if !ok {
+			return
+		}
+		if submitted.PolicyType != policy.PolicyType {
+			m.writePolicyErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("The type of policy %d cannot be changed from %s to %s", policyID, policy.PolicyType, submitted.PolicyType))
+			return
+		}
+		policy.Name = submitted.Name
+		policy.Description = submitted.Description
+		policy.Enabled = submitted.Enabled
+		policy.PolicySettings = submitted.PolicySettings
+		m.writeJSONResponse(w, PolicyExtended{Value: m.policyResponse(policy)})
+	case http.MethodDelete:
+		m.handlePolicyDelete(w, policy)
+	default:
+		m.writePolicyErrorResponse(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method not allowed: %s", r.Method))
+	}
+}
+
+// handlePolicyDelete deletes a policy. The default WAF policy of an account cannot be deleted, and the sites of a
+// deleted WAF policy get the default WAF policy of their account back.
+func (m *MockImpervaServer) handlePolicyDelete(w http.ResponseWriter, policy *Policy) {
+	for _, association := range m.accountPolicies {
+		if association.DefaultWafPolicyId == policy.ID {
+			m.writePolicyErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Policy %d is the default WAF policy of account %d and cannot be deleted", policy.ID, association.AccountID))
+			return
+		}
+	}
+
+	for assetKey, policyIDs := range m.policyAssets {
+		if !policyIDs[policy.ID] {
+			continue
+		}
+		delete(policyIDs, policy.ID)
+		if policy.PolicyType == "WAF_RULES" {
+			if site := m.sites[m.assetSiteID(assetKey)]; site != nil {
+				policyIDs[m.accountPolicyAssociation(siteAccountID(site)).DefaultWafPolicyId] = true
+			}
+		}
+	}
+	for _, association := range m.accountPolicies {
+		association.AvailablePolicyIds = removeMockPolicyID(association.AvailablePolicyIds, policy.ID)
+		association.DefaultNonMandatoryNonDistinctPolicyIds = removeMockPolicyID(association.DefaultNonMandatoryNonDistinctPolicyIds, policy.ID)
+	}
+	delete(m.policies, policy.ID)
+
+	m.writeJSONResponse(w, map[string]interface{}{"value": "Policy deleted", "isError": false})
+}
+
+// assetSiteID returns the site of a WEBSITE asset key, or 0
+func (m *MockImpervaServer) assetSiteID(assetKey string) int {
+	var siteID int
+	fmt.Sscanf(assetKey, mockPolicyAssetTypeWebsite+"/%d", &siteID)
+	return siteID
+}
+
+func removeMockPolicyID(policyIDs []int, policyID int) []int {
+	result := make([]int, 0, len(policyIDs))
+	for _, id := range policyIDs {
+		if id != policyID {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// decodePolicy reads a policy from the request body and validates it like the policies API does
+func (m *MockImpervaServer) decodePolicy(w http.ResponseWriter, r *http.Request) (*PolicySubmitted, bool) {
+	body, _ := ioutil.ReadAll(r.Body)
+	var policy PolicySubmitted
+	if err := json.Unmarshal(body, &policy); err != nil {
+		m.writePolicyErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid JSON: %s", err))
+		return nil, false
+	}
+	if message := validateMockPolicy(&policy); message != "" {
+		m.writePolicyErrorResponse(w, http.StatusBadRequest, message)
+		return nil, false
+	}
+	return &policy, true
+}
+
+func validateMockPolicy(policy *PolicySubmitted) string {
+	if policy.Name == "" {
+		return "Policy name is required"
+	}
+	settingTypes, ok := mockPolicySettingTypes[policy.PolicyType]
+	if !ok {
+		return fmt.Sprintf("Invalid policy type: %s", policy.PolicyType)
+	}
+
+	seen := map[string]bool{}
+	for i, setting := range policy.PolicySettings {
+		if !contains(settingTypes, setting.PolicySettingType) {
+			return fmt.Sprintf("policySettings[%d]: invalid policySettingType %s for policy type %s", i, setting.PolicySettingType, policy.PolicyType)
+		}
+		if !contains(mockPolicySettingsActions, setting.SettingsAction) {
+			return fmt.Sprintf("policySettings[%d]: invalid settingsAction %s", i, setting.SettingsAction)
+		}
+		for _, exception := range setting.PolicyDataExceptions {
+			for _, data := range exception.Data {
+				if !contains(mockPolicyExceptionTypes, data.ExceptionType) {
+					return fmt.Sprintf("policySettings[%d]: invalid exceptionType %s", i, data.ExceptionType)
+				}
+				if len(data.Values) == 0 {
+					return fmt.Sprintf("policySettings[%d]: exception of type %s has no values", i, data.ExceptionType)
+				}
+			}
+		}
+		seen[setting.PolicySettingType] = true
+	}
+
+	if policy.PolicyType == "WAF_RULES" {
+		for _, settingType := range mockWafPolicySettingTypes {
+			if !seen[settingType] {
+				return fmt.Sprintf("Setting %s is mandatory for WAF_RULES policies", settingType)
+			}
+		}
+	}
+	return ""
+}
+
+// Policy Asset Associations Handlers
+
+// handlePolicyAssetStatus handles GET /policies/v2/policies/{policyId}/assets/{assetType}/{assetId}
+func (m *MockImpervaServer) handlePolicyAssetStatus(w http.ResponseWriter, r *http.Request, path string) {
+	matches := mockPolicyAssetPattern.FindStringSubmatch(path)
+	policyID, _ := strconv.Atoi(matches[1])
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if r.Method != http.MethodGet {
+		m.writePolicyErrorResponse(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method not allowed: %s", r.Method))
+		return
+	}
+	if !m.policyAssets[mockAssetKey(matches[2], matches[3])][policyID] {
+		m.writePolicyErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Policy %d is not associated to asset %s %s", policyID, matches[2], matches[3]))
+		return
+	}
+	m.writeJSONResponse(w, PolicyAssetAssociationStatus{Value: true})
+}
+
+// handleAssetPolicy handles POST/DELETE /policies/v2/assets/{assetType}/{assetId}/policies/{policyId}
+func (m *MockImpervaServer) handleAssetPolicy(w http.ResponseWriter, r *http.Request, path string) {
+	matches := mockAssetPolicyPattern.FindStringSubmatch(path)
+	assetType, assetID := matches[1], matches[2]
+	policyID, _ := strconv.Atoi(matches[3])
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if assetType != mockPolicyAssetTypeWebsite {
+		m.writePolicyErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid asset type: %s", assetType))
+		return
+	}
+	siteID, _ := strconv.Atoi(assetID)
+	site, exists := m.sites[siteID]
+	if !exists {
+		m.writePolicyErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Asset %s %s not found", assetType, assetID))
+		return
+	}
+	policy, exists := m.policies[policyID]
+	if !exists {
+		m.writePolicyErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Policy %d not found", policyID))
+		return
+	}
+
+	assetKey := mockAssetKey(assetType, assetID)
+	if m.policyAssets[assetKey] == nil {
+		m.policyAssets[assetKey] = map[int]bool{}
+	}
+	policyIDs := m.policyAssets[assetKey]
+
+	switch r.Method {
+	case http.MethodPost:
+		// A site has a single WAF policy, which is replaced
+		if policy.PolicyType == "WAF_RULES" {
+			for id := range policyIDs {
+				if other := m.policies[id]; other != nil && other.PolicyType == "WAF_RULES" {
+					delete(policyIDs, id)
+				}
+			}
+		}
+		policyIDs[policyID] = true
+	case http.MethodDelete:
+		if !policyIDs[policyID] {
+			m.writePolicyErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Policy %d is not associated to asset %s %s", policyID, assetType, assetID))
+			return
+		}
+		if policy.PolicyType == "WAF_RULES" {
+			defaultWafPolicyID := m.accountPolicyAssociation(siteAccountID(site)).DefaultWafPolicyId
+			if defaultWafPolicyID == policyID {
+				m.writePolicyErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Policy %d is the default WAF policy and cannot be removed from site %d, a site must have a WAF policy", policyID, siteID))
+				return
+			}
+			policyIDs[defaultWafPolicyID] = true
+		}
+		delete(policyIDs, policyID)
+	default:
+		m.writePolicyErrorResponse(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method not allowed: %s", r.Method))
+		return
+	}
+	m.writeJSONResponse(w, map[string]interface{}{"value": "OK", "isError": false})
+}
+
+// Account Policy Associations Handlers
+
+// handleAccountAssociatedPolicies handles GET/PATCH /policies/v3/accounts/associated-policies?caid={accountId}
+func (m *MockImpervaServer) handleAccountAssociatedPolicies(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	accountID := m.requestAccountID(r)
+	association := m.accountPolicyAssociation(accountID)
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPatch:
+		body, _ := ioutil.ReadAll(r.Body)
+		var request AccountPolicyAssociationV3RequestResponse
+		if err := json.Unmarshal(body, &request); err != nil || len(request.Data) != 1 {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "/data", "Expected a single account policy association")
+			return
+		}
+		patch := request.Data[0]
+		if patch.AccountID != 0 && patch.AccountID != accountID {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "/data/0/accountId", fmt.Sprintf("Account %d does not match caid %d", patch.AccountID, accountID))
+			return
+		}
+
+		if patch.DefaultWafPolicyId != 0 {
+			if policy := m.policies[patch.DefaultWafPolicyId]; policy == nil || policy.PolicyType != "WAF_RULES" {
+				m.writeV3ErrorResponse(w, http.StatusBadRequest, "/data/0/defaultWafPolicyId", fmt.Sprintf("Policy %d is not a WAF_RULES policy", patch.DefaultWafPolicyId))
+				return
+			}
+		}
+		for _, policyID := range patch.DefaultNonMandatoryNonDistinctPolicyIds {
+			if policy := m.policies[policyID]; policy == nil || policy.PolicyType == "WAF_RULES" {
+				m.writeV3ErrorResponse(w, http.StatusBadRequest, "/data/0/defaultNonMandatoryNonDistinctPolicyIds", fmt.Sprintf("Policy %d cannot be a default non mandatory policy", policyID))
+				return
+			}
+		}
+		for _, policyID := range patch.AvailablePolicyIds {
+			if m.policies[policyID] == nil {
+				m.writeV3ErrorResponse(w, http.StatusBadRequest, "/data/0/availablePolicyIds", fmt.Sprintf("Policy %d not found", policyID))
+				return
+			}
+		}
+
+		// The default policies apply to the sites created afterwards
+		if patch.DefaultWafPolicyId != 0 {
+			association.DefaultWafPolicyId = patch.DefaultWafPolicyId
+		}
+		if patch.DefaultNonMandatoryNonDistinctPolicyIds != nil {
+			association.DefaultNonMandatoryNonDistinctPolicyIds = append([]int{}, patch.DefaultNonMandatoryNonDistinctPolicyIds...)
+		}
+		if patch.AvailablePolicyIds != nil {
+			association.AvailablePolicyIds = append([]int{}, patch.AvailablePolicyIds...)
+		}
+	default:
+		m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+		return
+	}
+	m.writeJSONResponse(w, AccountPolicyAssociationV3RequestResponse{Data: []AccountPolicyAssociationV3{*association}})
+}
+
+// Helper methods for tests
+
+// GetPolicy returns a policy by ID (for test assertions)
+func (m *MockImpervaServer) GetPolicy(policyID int) *Policy {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.policies[policyID]
+}
+
+// GetAssetPolicies returns the sorted IDs of the policies associated to an asset (for test assertions)
+func (m *MockImpervaServer) GetAssetPolicies(assetType string, assetID string) []int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	policyIDs := make([]int, 0)
+	for policyID := range m.policyAssets[mockAssetKey(assetType, assetID)] {
+		policyIDs = append(policyIDs, policyID)
+	}
+	sort.Ints(policyIDs)
+	return policyIDs
+}
+
+// GetDefaultWafPolicyID returns the default WAF policy of an account, creating it if needed (for test setup and assertions)
+func (m *MockImpervaServer) GetDefaultWafPolicyID(accountID int) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.accountPolicyAssociation(accountID).DefaultWafPolicyId
+}
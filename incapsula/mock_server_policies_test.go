@@ -0,0 +1,168 @@
+package incapsula
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func testMockPolicySettings(settingTypes ...string) []PolicySetting {
+	var settings []PolicySetting
+	for _, settingType := range settingTypes {
+		settings = append(settings, PolicySetting{SettingsAction: "BLOCK", PolicySettingType: settingType})
+	}
+	return settings
+}
+
+func TestMockPoliciesCRUD(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+
+	created, err := client.AddPolicy(ctx, &PolicySubmitted{Name: "Block IPs", Enabled: true, PolicyType: "ACL", PolicySettings: testMockPolicySettings("IP")})
+	if err != nil {
+		t.Fatalf("Unexpected error adding policy: %s", err)
+	}
+	policyID := strconv.Itoa(created.Value.ID)
+	if created.Value.AccountID != mockAPIKeyAccountID {
+		t.Errorf("Expected the policy to belong to the account of the API key, got %d", created.Value.AccountID)
+	}
+
+	if _, err := client.UpdatePolicy(ctx, created.Value.ID, &PolicySubmitted{Name: "Block countries", Enabled: true, PolicyType: "ACL", PolicySettings: testMockPolicySettings("GEO")}, nil); err != nil {
+		t.Fatalf("Unexpected error updating policy: %s", err)
+	}
+	read, err := client.GetPolicy(ctx, policyID, nil)
+	if err != nil || read.Value.Name != "Block countries" || read.Value.PolicySettings[0].PolicySettingType != "GEO" {
+		t.Errorf("Expected the updated policy, got %+v %v", read, err)
+	}
+
+	policies, err := client.GetAllPoliciesForAccount(ctx, strconv.Itoa(mockAPIKeyAccountID))
+	if err != nil || len(*policies) != 2 || !(*policies)[0].IsMarkedAsDefault {
+		t.Errorf("Expected the default WAF policy and the ACL policy, got %+v %v", policies, err)
+	}
+
+	if err := client.DeletePolicy(ctx, policyID, nil); err != nil {
+		t.Fatalf("Unexpected error deleting policy: %s", err)
+	}
+	if _, err := client.GetPolicy(ctx, policyID, nil); !IsNotFound(err) {
+		t.Errorf("Expected a not found error for a deleted policy, got %v", err)
+	}
+
+	// Invalid policies are rejected
+	for _, policy := range []PolicySubmitted{
+		{Name: "Unknown type", PolicyType: "FIREWALL"},
+		{Name: "Wrong setting", PolicyType: "ACL", PolicySettings: testMockPolicySettings("SQL_INJECTION")},
+		{Name: "Incomplete WAF", PolicyType: "WAF_RULES", PolicySettings: testMockPolicySettings("SQL_INJECTION")},
+	} {
+		if _, err := client.AddPolicy(ctx, &policy); err == nil {
+			t.Errorf("Expected policy %s to be rejected", policy.Name)
+		}
+	}
+}
+
+func TestMockPolicyAssetAssociations(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	site := &MockSite{Domain: "policies.example.com"}
+	mock.AddSite(site)
+	siteID := strconv.Itoa(site.SiteID)
+
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+
+	// A new site has the default WAF policy of its account
+	defaultWafPolicyID := mock.GetDefaultWafPolicyID(mockAPIKeyAccountID)
+	if policies := mock.GetAssetPolicies("WEBSITE", siteID); !reflect.DeepEqual(policies, []int{defaultWafPolicyID}) {
+		t.Fatalf("Expected the site to have the default WAF policy %d, got %v", defaultWafPolicyID, policies)
+	}
+
+	waf, err := client.AddPolicy(ctx, &PolicySubmitted{Name: "Strict WAF", Enabled: true, PolicyType: "WAF_RULES", PolicySettings: testMockPolicySettings(mockWafPolicySettingTypes...)})
+	if err != nil {
+		t.Fatalf("Unexpected error adding policy: %s", err)
+	}
+	acl, err := client.AddPolicy(ctx, &PolicySubmitted{Name: "ACL", Enabled: true, PolicyType: "ACL", PolicySettings: testMockPolicySettings("IP")})
+	if err != nil {
+		t.Fatalf("Unexpected error adding policy: %s", err)
+	}
+	wafID, aclID := strconv.Itoa(waf.Value.ID), strconv.Itoa(acl.Value.ID)
+
+	// Associating a WAF policy replaces the site's WAF policy
+	for _, policyID := range []string{wafID, aclID} {
+		if err := client.AddPolicyAssetAssociation(ctx, policyID, siteID, "WEBSITE", nil); err != nil {
+			t.Fatalf("Unexpected error associating policy %s: %s", policyID, err)
+		}
+	}
+	if policies := mock.GetAssetPolicies("WEBSITE", siteID); !reflect.DeepEqual(policies, []int{waf.Value.ID, acl.Value.ID}) {
+		t.Errorf("Expected the site to have the WAF and ACL policies, got %v", policies)
+	}
+	if associated, err := client.isPolicyAssetAssociated(ctx, strconv.Itoa(defaultWafPolicyID), siteID, "WEBSITE", nil); err != nil || associated {
+		t.Errorf("Expected the default WAF policy to be replaced, got %t %v", associated, err)
+	}
+
+	// Removing the WAF policy restores the default one, which can't be removed
+	if err := client.DeletePolicyAssetAssociation(ctx, wafID, siteID, "WEBSITE", nil); err != nil {
+		t.Fatalf("Unexpected error removing the WAF policy: %s", err)
+	}
+	if associated, err := client.isPolicyAssetAssociated(ctx, strconv.Itoa(defaultWafPolicyID), siteID, "WEBSITE", nil); err != nil || !associated {
+		t.Errorf("Expected the default WAF policy to be restored, got %t %v", associated, err)
+	}
+	if err := client.DeletePolicyAssetAssociation(ctx, strconv.Itoa(defaultWafPolicyID), siteID, "WEBSITE", nil); err == nil {
+		t.Errorf("Expected an error removing the only WAF policy of a site")
+	}
+	if err := client.DeletePolicy(ctx, strconv.Itoa(defaultWafPolicyID), nil); err == nil {
+		t.Errorf("Expected an error deleting the default WAF policy")
+	}
+
+	if err := client.AddPolicyAssetAssociation(ctx, aclID, siteID, "API", nil); err == nil {
+		t.Errorf("Expected an error for an unknown asset type")
+	}
+	if err := client.AddPolicyAssetAssociation(ctx, aclID, "1", "WEBSITE", nil); err == nil {
+		t.Errorf("Expected an error for an unknown site")
+	}
+}
+
+func TestMockAccountPolicyAssociation(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+	accountID := strconv.Itoa(mockAPIKeyAccountID)
+
+	association, err := client.GetAccountPolicyAssociation(ctx, accountID)
+	if err != nil || association.DefaultWafPolicyId == 0 {
+		t.Fatalf("Expected the account to have a default WAF policy, got %+v %v", association, err)
+	}
+
+	waf, err := client.AddPolicy(ctx, &PolicySubmitted{Name: "Strict WAF", Enabled: true, PolicyType: "WAF_RULES", PolicySettings: testMockPolicySettings(mockWafPolicySettingTypes...)})
+	if err != nil {
+		t.Fatalf("Unexpected error adding policy: %s", err)
+	}
+	acl, err := client.AddPolicy(ctx, &PolicySubmitted{Name: "ACL", Enabled: true, PolicyType: "ACL", PolicySettings: testMockPolicySettings("IP")})
+	if err != nil {
+		t.Fatalf("Unexpected error adding policy: %s", err)
+	}
+
+	association, err = client.PatchAccountPolicyAssociation(ctx, accountID, nil, []int{acl.Value.ID}, strconv.Itoa(waf.Value.ID))
+	if err != nil || association.DefaultWafPolicyId != waf.Value.ID || !reflect.DeepEqual(association.DefaultNonMandatoryNonDistinctPolicyIds, []int{acl.Value.ID}) {
+		t.Fatalf("Unexpected account policy association: %+v %v", association, err)
+	}
+
+	// The default policies apply to new sites
+	site := &MockSite{Domain: "defaults.example.com"}
+	mock.AddSite(site)
+	if policies := mock.GetAssetPolicies("WEBSITE", strconv.Itoa(site.SiteID)); !reflect.DeepEqual(policies, []int{waf.Value.ID, acl.Value.ID}) {
+		t.Errorf("Expected the new site to have the default policies, got %v", policies)
+	}
+
+	// Only a WAF policy can be the default WAF policy
+	if _, err := client.PatchAccountPolicyAssociation(ctx, accountID, nil, nil, strconv.Itoa(acl.Value.ID)); err == nil {
+		t.Errorf("Expected an error setting an ACL policy as the default WAF policy")
+	}
+}
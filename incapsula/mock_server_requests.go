@@ -0,0 +1,158 @@
+// Mock Imperva API Server - request journal
+//
+// Every request the mock server receives, except the control plane ones, is journaled with its form params, body
+// and response status, so that tests can assert the exact sequence of calls a resource makes. The journal is read in
+// Go with Requests and FindRequests, or over HTTP with the /__mock/requests control plane endpoint.
+
+package incapsula
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const mockRequestsPath = "__mock/requests"
+
+// mockRequestJournalSize is the number of requests kept in the journal, the oldest ones are dropped first
+const mockRequestJournalSize = 10000
+
+// MockRequest is a request journaled by the mock server
+type MockRequest struct {
+	Time   time.Time `json:"time"`
+	Method string    `json:"method"`
+
+	// Path of the request, without its leading slash
+	Path  string     `json:"path"`
+	Query url.Values `json:"query,omitempty"`
+
+	// Operation sent by the client in the x-tf-operation header
+	Operation string `json:"operation,omitempty"`
+
+	// Params of form requests
+	Form url.Values `json:"form,omitempty"`
+	Body string     `json:"body,omitempty"`
+
+	// Status of the response, 0 if the connection was reset
+	Status int `json:"status"`
+}
+
+// mockRequestRecorder journals a request along with the status of its response
+type mockRequestRecorder struct {
+	http.ResponseWriter
+	request MockRequest
+}
+
+func (recorder *mockRequestRecorder) WriteHeader(status int) {
+	if recorder.request.Status == 0 {
+		recorder.request.Status = status
+	}
+	recorder.ResponseWriter.WriteHeader(status)
+}
+
+func (recorder *mockRequestRecorder) Write(data []byte) (int, error) {
+	if recorder.request.Status == 0 {
+		recorder.request.Status = http.StatusOK
+	}
+	return recorder.ResponseWriter.Write(data)
+}
+
+// Hijack lets faults reset the connection of the request
+func (recorder *mockRequestRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := recorder.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("The response writer doesn't support hijacking")
+	}
+	return hijacker.Hijack()
+}
+
+// startRequestRecord reads the request body, which is restored for the handlers, and returns the response writer
+// recording the request
+func (m *MockImpervaServer) startRequestRecord(w http.ResponseWriter, r *http.Request, path string) *mockRequestRecorder {
+	body, _ := ioutil.ReadAll(r.Body)
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	request := MockRequest{
+		Time:      time.Now(),
+		Method:    r.Method,
+		Path:      path,
+		Operation: r.Header.Get("x-tf-operation"),
+		Body:      string(body),
+	}
+	if len(r.URL.Query()) > 0 {
+		request.Query = r.URL.Query()
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		request.Form, _ = url.ParseQuery(string(body))
+	}
+	return &mockRequestRecorder{ResponseWriter: w, request: request}
+}
+
+// endRequestRecord adds the recorded request to the journal
+func (m *MockImpervaServer) endRequestRecord(recorder *mockRequestRecorder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.requests) >= mockRequestJournalSize {
+		m.requests = m.requests[len(m.requests)-mockRequestJournalSize+1:]
+	}
+	m.requests = append(m.requests, recorder.request)
+}
+
+// handleRequests handles GET/DELETE /__mock/requests. GET lists the journaled requests, optionally filtered by the
+// method and path query params, the latter being a regular expression.
+func (m *MockImpervaServer) handleRequests(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		pattern, err := regexp.Compile(r.URL.Query().Get("path"))
+		if err != nil {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "", fmt.Sprintf("Invalid path pattern: %s", err))
+			return
+		}
+		m.writeJSONResponse(w, m.findRequests(r.URL.Query().Get("method"), pattern))
+	case http.MethodDelete:
+		m.ClearRequests()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+	}
+}
+
+// findRequests returns the journaled requests of the method (any method if empty) whose path matches the pattern
+func (m *MockImpervaServer) findRequests(method string, pattern *regexp.Regexp) []MockRequest {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	requests := []MockRequest{}
+	for _, request := range m.requests {
+		if (method == "" || request.Method == method) && pattern.MatchString(request.Path) {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+// Helper methods for tests
+
+// Requests returns the journaled requests, oldest first (for test assertions)
+func (m *MockImpervaServer) Requests() []MockRequest {
+	return m.findRequests("", regexp.MustCompile(""))
+}
+
+// FindRequests returns the journaled requests of the method (any method if empty) whose path, without its leading
+// slash, matches the regular expression, oldest first (for test assertions)
+func (m *MockImpervaServer) FindRequests(method, pathPattern string) []MockRequest {
+	return m.findRequests(method, regexp.MustCompile(pathPattern))
+}
+
+// ClearRequests empties the request journal
+func (m *MockImpervaServer) ClearRequests() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = nil
+}
@@ -0,0 +1,103 @@
+package incapsula
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestMockRequestJournal(t *testing.T) {
+	WithMockServer(t, func(ctx *MockTestContext) {
+		site := ctx.CreateTestSite(0)
+		siteID := strconv.Itoa(site.SiteID)
+		config := testConfigForURL(ctx.Server.URL())
+		client := NewClient(&config)
+		background := context.Background()
+
+		// An update of the site sends only the changed param to sites/configure
+		siteSchema := schema.InternalMap(resourceSite().Schema)
+		previous := schema.TestResourceDataRaw(t, resourceSite().Schema, map[string]interface{}{"domain": site.Domain})
+		previous.SetId(siteID)
+		state := previous.State()
+		diff, err := siteSchema.Diff(background, state, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"domain": site.Domain,
+			"ref_id": "journal-ref",
+		}), nil, nil, true)
+		if err != nil {
+			t.Fatalf("Unexpected error computing diff: %s", err)
+		}
+		d, err := siteSchema.Data(state, diff)
+		if err != nil {
+			t.Fatalf("Unexpected error applying diff: %s", err)
+		}
+		ctx.Server.ClearRequests()
+		if err := updateAdditionalSiteProperties(background, 0, client, d); err != nil {
+			t.Fatalf("Unexpected error updating site: %s", err)
+		}
+		requests := ctx.Server.FindRequests(http.MethodPost, "^sites/configure$")
+		if len(requests) != 1 {
+			t.Fatalf("Expected a single sites/configure request, got %+v", requests)
+		}
+		request := requests[0]
+		if request.Form.Get("site_id") != siteID || request.Form.Get("param") != "ref_id" || request.Form.Get("value") != "journal-ref" {
+			t.Errorf("Unexpected sites/configure params: %v", request.Form)
+		}
+		if request.Status != http.StatusOK || request.Operation != "update_site" {
+			t.Errorf("Unexpected journaled request: %+v", request)
+		}
+
+		// A reset connection is journaled without status
+		ctx.ResetConnections("^sites/status$", 1)
+		client.SiteStatus(background, site.Domain, site.SiteID)
+		requests = ctx.Server.FindRequests(http.MethodPost, "^sites/status$")
+		if len(requests) != 1 || requests[0].Status != 0 {
+			t.Errorf("Expected the reset request to be journaled with status 0, got %+v", requests)
+		}
+		if all := ctx.Server.Requests(); len(all) != 2 || all[0].Path != "sites/configure" {
+			t.Errorf("Expected the journal to list the requests in order, got %+v", all)
+		}
+	})
+}
+
+func TestMockRequestJournalControlPlane(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	mock.AddSite(&MockSite{Domain: "journal.example.com"})
+
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	client.Verify(context.Background())
+	client.UpdateSite(context.Background(), "10000", "active", "bypass")
+
+	resp, err := http.Get(mock.URL() + "/__mock/requests?method=POST&path=^sites/")
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Unexpected error listing requests: %v", err)
+	}
+	var requests []MockRequest
+	json.NewDecoder(resp.Body).Decode(&requests)
+	resp.Body.Close()
+	if len(requests) != 1 || requests[0].Path != "sites/configure" || requests[0].Form.Get("value") != "bypass" {
+		t.Errorf("Unexpected filtered requests: %+v", requests)
+	}
+
+	// The control plane requests are not journaled
+	if all := mock.Requests(); len(all) != 2 {
+		t.Errorf("Expected two journaled requests, got %+v", all)
+	}
+	if resp, err := http.Get(mock.URL() + "/__mock/requests?path=("); err != nil || resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected a 400 error for an invalid path pattern, got %v", err)
+	}
+
+	request, _ := http.NewRequest(http.MethodDelete, mock.URL()+"/__mock/requests", nil)
+	if resp, err := http.DefaultClient.Do(request); err != nil || resp.StatusCode != http.StatusNoContent {
+		t.Errorf("Unexpected error clearing requests: %v", err)
+	}
+	if all := mock.Requests(); len(all) != 0 {
+		t.Errorf("Expected an empty journal, got %+v", all)
+	}
+}
@@ -0,0 +1,482 @@
+// Mock Imperva API Server - incap rules, cache rules and delivery rules
+//
+// The endpoints mirror the requests of client_incap_rule.go, client_cache_rule.go and
+// client_delivery_rules_configuration.go: the v2 rules and cache rules APIs, and the v3 delivery rules API.
+
+package incapsula
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strconv"
+)
+
+var (
+	mockIncapRulesPattern     = regexp.MustCompile(`^sites/(\d+)/rules(?:/(\d+))?$`)
+	mockCacheRulesPattern     = regexp.MustCompile(`^sites/(\d+)/settings/cache/rules(?:/(\d+))?$`)
+	mockDeliveryRulesPattern  = regexp.MustCompile(`^sites/(\d+)/delivery-rules-configuration$`)
+	mockRedirectResponseCodes = []int{301, 302, 303, 307, 308}
+)
+
+// mockIncapRuleActions are the actions accepted by the rules API
+var mockIncapRuleActions = []string{
+	"RULE_ACTION_REDIRECT", "RULE_ACTION_SIMPLIFIED_REDIRECT", "RULE_ACTION_REWRITE_URL", "RULE_ACTION_REWRITE_HEADER",
+	"RULE_ACTION_REWRITE_COOKIE", "RULE_ACTION_DELETE_HEADER", "RULE_ACTION_DELETE_COOKIE", "RULE_ACTION_RESPONSE_REWRITE_HEADER",
+	"RULE_ACTION_RESPONSE_DELETE_HEADER", "RULE_ACTION_RESPONSE_REWRITE_RESPONSE_CODE", "RULE_ACTION_FORWARD_TO_DC",
+	"RULE_ACTION_FORWARD_TO_PORT", "RULE_ACTION_ALERT", "RULE_ACTION_BLOCK", "RULE_ACTION_BLOCK_USER", "RULE_ACTION_BLOCK_IP",
+	"RULE_ACTION_RETRY", "RULE_ACTION_INTRUSIVE_HTML", "RULE_ACTION_CAPTCHA", "RULE_ACTION_RATE", "RULE_ACTION_CUSTOM_ERROR_RESPONSE",
+	"RULE_ACTION_WAF_OVERRIDE",
+}
+
+// mockCacheRuleActions are the actions accepted by the cache rules API
+var mockCacheRuleActions = []string{
+	"HTTP_CACHE_MAKE_STATIC", "HTTP_CACHE_CLIENT_CACHE_CTL", "HTTP_CACHE_FORCE_UNCACHEABLE", "HTTP_CACHE_ADD_TAG",
+	"HTTP_CACHE_DIFFERENTIATE_SSL", "HTTP_CACHE_DIFFERENTIATE_BY_HEADER", "HTTP_CACHE_DIFFERENTIATE_BY_COOKIE",
+	"HTTP_CACHE_DIFFERENTIATE_BY_GEO", "HTTP_CACHE_IGNORE_PARAMS", "HTTP_CACHE_ENRICH_CACHE_KEY", "HTTP_CACHE_FORCE_VALIDATION",
+	"HTTP_CACHE_IGNORE_AUTH_HEADER",
+}
+
+// mockDeliveryRuleCategoryActions maps the categories of the delivery rules API to the actions of their rules
+var mockDeliveryRuleCategoryActions = map[string][]string{
+	"REDIRECT":            {"RULE_ACTION_REDIRECT"},
+	"SIMPLIFIED_REDIRECT": {"RULE_ACTION_SIMPLIFIED_REDIRECT"},
+	"REWRITE":             {"RULE_ACTION_REWRITE_URL", "RULE_ACTION_REWRITE_HEADER", "RULE_ACTION_REWRITE_COOKIE", "RULE_ACTION_DELETE_HEADER", "RULE_ACTION_DELETE_COOKIE"},
+	"REWRITE_RESPONSE":    {"RULE_ACTION_RESPONSE_REWRITE_HEADER", "RULE_ACTION_RESPONSE_DELETE_HEADER", "RULE_ACTION_RESPONSE_REWRITE_RESPONSE_CODE", "RULE_ACTION_CUSTOM_ERROR_RESPONSE"},
+	"FORWARD":             {"RULE_ACTION_FORWARD_TO_DC", "RULE_ACTION_FORWARD_TO_PORT"},
+}
+
+// writeV2ErrorResponse writes an error of the v2 APIs, which use HTTP status codes along with the res code
+func (m *MockImpervaServer) writeV2ErrorResponse(w http.ResponseWriter, status int, resCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"res":         resCode,
+		"res_message": message,
+		"debug_info":  map[string]interface{}{"id-info": strconv.Itoa(resCode)},
+	})
+}
+
+// writeV3ErrorResponse writes an error of the v3 APIs, in the errors list of the response body
+func (m *MockImpervaServer) writeV3ErrorResponse(w http.ResponseWriter, status int, pointer string, detail string) {
+	apiError := APIErrors{
+		Status: status,
+		Id:     fmt.Sprintf("mock-%d", status),
+		Title:  http.StatusText(status),
+		Detail: detail,
+	}
+	if pointer != "" {
+		apiError.Source = map[string]string{"pointer": pointer}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{"errors": []APIErrors{apiError}})
+}
+
+// Incap Rules Handlers
+
+// handleIncapRules handles POST /sites/{siteId}/rules and GET/PUT/DELETE /sites/{siteId}/rules/{ruleId}
+func (m *MockImpervaServer) handleIncapRules(w http.ResponseWriter, r *http.Request, path string) {
+	matches := mockIncapRulesPattern.FindStringSubmatch(path)
+	siteID, _ := strconv.Atoi(matches[1])
+	ruleID, _ := strconv.Atoi(matches[2])
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.sites[siteID]; !exists {
+		m.writeV2ErrorResponse(w, http.StatusNotFound, apiV1ResUnknownSiteID, "Unknown/unauthorized site_id")
+		return
+	}
+
+	if matches[2] == "" {
+		if r.Method != http.MethodPost {
+			m.writeV2ErrorResponse(w, http.StatusMethodNotAllowed, 9999, fmt.Sprintf("Method not allowed: %s", r.Method))
+			return
+		}
+		rule, ok := m.decodeIncapRule(w, r)
+		if !ok {
+			return
+		}
+		ruleWithID := &IncapRuleWithID{IncapRule: *rule, RuleID: m.nextRuleID}
+		m.nextRuleID++
+		if m.incapRules[siteID] == nil {
+			m.incapRules[siteID] = make(map[int]*IncapRuleWithID)
+		}
+		m.incapRules[siteID][ruleWithID.RuleID] = ruleWithID
+		m.writeJSONResponse(w, ruleWithID)
+		return
+	}
+
+	existing, exists := m.incapRules[siteID][ruleID]
+	if !exists {
+		m.writeV2ErrorResponse(w, http.StatusNotFound, apiV1ResObjectNotFound, fmt.Sprintf("Rule %d not found", ruleID))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		m.writeJSONResponse(w, existing)
+	case http.MethodPut:
+		rule, ok := m.decodeIncapRule(w, r)
+		if !ok {
+			return
+		}
+		existing.IncapRule = *rule
+		m.writeJSONResponse(w, existing)
+	case http.MethodDelete:
+		delete(m.incapRules[siteID], ruleID)
+		m.writeSuccessResponse(w, map[string]interface{}{})
+	default:
+		m.writeV2ErrorResponse(w, http.StatusMethodNotAllowed, 9999, fmt.Sprintf("Method not allowed: %s", r.Method))
+	}
+}
+
+// decodeIncapRule reads an incap rule from the request body and validates it like the rules API does
+func (m *MockImpervaServer) decodeIncapRule(w http.ResponseWriter, r *http.Request) (*IncapRule, bool) {
+	body, _ := ioutil.ReadAll(r.Body)
+	var rule IncapRule
+	if err := json.Unmarshal(body, &rule); err != nil {
+		m.writeV2ErrorResponse(w, http.StatusBadRequest, 1, fmt.Sprintf("Invalid JSON: %s", err))
+		return nil, false
+	}
+	if message := validateMockIncapRule(&rule); message != "" {
+		m.writeV2ErrorResponse(w, http.StatusBadRequest, 2, message)
+		return nil, false
+	}
+	return &rule, true
+}
+
+func validateMockIncapRule(rule *IncapRule) string {
+	if rule.Name == "" {
+		return "Rule name is required"
+	}
+	if !contains(mockIncapRuleActions, rule.Action) {
+		return fmt.Sprintf("Invalid action: %s", rule.Action)
+	}
+
+	switch rule.Action {
+	case "RULE_ACTION_SIMPLIFIED_REDIRECT":
+		if rule.Filter != "" {
+			return "Filter is not supported for action RULE_ACTION_SIMPLIFIED_REDIRECT"
+		}
+		fallthrough
+	case "RULE_ACTION_REDIRECT":
+		if rule.From == "" || rule.To == "" {
+			return fmt.Sprintf("From and to are required for action %s", rule.Action)
+		}
+		if !mockIntInSlice(mockRedirectResponseCodes, rule.ResponseCode) {
+			return fmt.Sprintf("Invalid response code %d for action %s", rule.ResponseCode, rule.Action)
+		}
+	case "RULE_ACTION_REWRITE_URL":
+		if rule.From == "" || rule.To == "" {
+			return "From and to are required for action RULE_ACTION_REWRITE_URL"
+		}
+	case "RULE_ACTION_REWRITE_HEADER", "RULE_ACTION_REWRITE_COOKIE", "RULE_ACTION_RESPONSE_REWRITE_HEADER":
+		if rule.RewriteName == "" {
+			return fmt.Sprintf("Rewrite name is required for action %s", rule.Action)
+		}
+	case "RULE_ACTION_FORWARD_TO_DC":
+		if rule.DCID == 0 {
+			return "Data center ID is required for action RULE_ACTION_FORWARD_TO_DC"
+		}
+	case "RULE_ACTION_RATE":
+		if rule.RateContext != "IP" && rule.RateContext != "Session" {
+			return fmt.Sprintf("Invalid rate context: %s", rule.RateContext)
+		}
+		if rule.RateInterval < 10 || rule.RateInterval > 300 || rule.RateInterval%10 != 0 {
+			return fmt.Sprintf("Invalid rate interval: %d", rule.RateInterval)
+		}
+	}
+
+	if rule.Action != "RULE_ACTION_REWRITE_HEADER" && rule.Action != "RULE_ACTION_REWRITE_COOKIE" &&
+		rule.Action != "RULE_ACTION_RESPONSE_REWRITE_HEADER" && rule.RewriteExisting != nil {
+		return fmt.Sprintf("Rewrite existing is not supported for action %s", rule.Action)
+	}
+	return ""
+}
+
+// Cache Rules Handlers
+
+// handleCacheRules handles POST /sites/{siteId}/settings/cache/rules and GET/PUT/DELETE /sites/{siteId}/settings/cache/rules/{ruleId}
+func (m *MockImpervaServer) handleCacheRules(w http.ResponseWriter, r *http.Request, path string) {
+	matches := mockCacheRulesPattern.FindStringSubmatch(path)
+	siteID, _ := strconv.Atoi(matches[1])
+	ruleID, _ := strconv.Atoi(matches[2])
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.sites[siteID]; !exists {
+		m.writeV2ErrorResponse(w, http.StatusNotFound, apiV1ResUnknownSiteID, "Unknown/unauthorized site_id")
+		return
+	}
+
+	if matches[2] == "" {
+		if r.Method != http.MethodPost {
+			m.writeV2ErrorResponse(w, http.StatusMethodNotAllowed, 9999, fmt.Sprintf("Method not allowed: %s", r.Method))
+			return
+		}
+		rule, ok := m.decodeCacheRule(w, r)
+		if !ok {
+			return
+		}
+		ruleWithID := &CacheRuleWithID{CacheRule: *rule, RuleID: m.nextRuleID}
+		m.nextRuleID++
+		if m.cacheRules[siteID] == nil {
+			m.cacheRules[siteID] = make(map[int]*CacheRuleWithID)
+		}
+		m.cacheRules[siteID][ruleWithID.RuleID] = ruleWithID
+		m.writeJSONResponse(w, ruleWithID)
+		return
+	}
+
+	existing, exists := m.cacheRules[siteID][ruleID]
+	if !exists {
+		m.writeV2ErrorResponse(w, http.StatusNotFound, apiV1ResObjectNotFound, fmt.Sprintf("Rule %d not found", ruleID))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		m.writeJSONResponse(w, existing)
+	case http.MethodPut:
+		rule, ok := m.decodeCacheRule(w, r)
+		if !ok {
+			return
+		}
+		existing.CacheRule = *rule
+		m.writeJSONResponse(w, existing)
+	case http.MethodDelete:
+		delete(m.cacheRules[siteID], ruleID)
+		m.writeSuccessResponse(w, map[string]interface{}{
+			"debug_info": map[string]interface{}{"rule_id": strconv.Itoa(ruleID), "id-info": "13007"},
+		})
+	default:
+		m.writeV2ErrorResponse(w, http.StatusMethodNotAllowed, 9999, fmt.Sprintf("Method not allowed: %s", r.Method))
+	}
+}
+
+// decodeCacheRule reads a cache rule from the request body and validates it like the cache rules API does
+func (m *MockImpervaServer) decodeCacheRule(w http.ResponseWriter, r *http.Request) (*CacheRule, bool) {
+	body, _ := ioutil.ReadAll(r.Body)
+	var rule CacheRule
+	if err := json.Unmarshal(body, &rule); err != nil {
+		m.writeV2ErrorResponse(w, http.StatusBadRequest, 1, fmt.Sprintf("Invalid JSON: %s", err))
+		return nil, false
+	}
+	if message := validateMockCacheRule(&rule); message != "" {
+		m.writeV2ErrorResponse(w, http.StatusBadRequest, 2, message)
+		return nil, false
+	}
+	return &rule, true
+}
+
+func validateMockCacheRule(rule *CacheRule) string {
+	if rule.Name == "" {
+		return "Rule name is required"
+	}
+	if !contains(mockCacheRuleActions, rule.Action) {
+		return fmt.Sprintf("Invalid action: %s", rule.Action)
+	}
+
+	switch rule.Action {
+	case "HTTP_CACHE_MAKE_STATIC", "HTTP_CACHE_CLIENT_CACHE_CTL":
+		if rule.TTL <= 0 {
+			return fmt.Sprintf("TTL is required for action %s", rule.Action)
+		}
+	case "HTTP_CACHE_DIFFERENTIATE_BY_HEADER", "HTTP_CACHE_DIFFERENTIATE_BY_COOKIE", "HTTP_CACHE_DIFFERENTIATE_BY_GEO":
+		if rule.DifferentiateByValue == "" {
+			return fmt.Sprintf("Differentiate by value is required for action %s", rule.Action)
+		}
+	case "HTTP_CACHE_ADD_TAG", "HTTP_CACHE_ENRICH_CACHE_KEY":
+		if rule.Text == "" {
+			return fmt.Sprintf("Text is required for action %s", rule.Action)
+		}
+	case "HTTP_CACHE_IGNORE_PARAMS":
+		if rule.IgnoredParams == "" {
+			return "Ignored params are required for action HTTP_CACHE_IGNORE_PARAMS"
+		}
+	}
+	return ""
+}
+
+// Delivery Rules Handlers
+
+// handleDeliveryRules handles GET/PUT /sites/{siteId}/delivery-rules-configuration?category={category}.
+// A PUT replaces all the rules of the category, in the given order.
+func (m *MockImpervaServer) handleDeliveryRules(w http.ResponseWriter, r *http.Request, path string) {
+	matches := mockDeliveryRulesPattern.FindStringSubmatch(path)
+	siteID, _ := strconv.Atoi(matches[1])
+	category := r.URL.Query().Get("category")
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.sites[siteID]; !exists {
+		m.writeV3ErrorResponse(w, http.StatusNotFound, "", fmt.Sprintf("Site %d not found", siteID))
+		return
+	}
+	if _, ok := mockDeliveryRuleCategoryActions[category]; !ok {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "", fmt.Sprintf("Invalid category: %s", category))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		m.writeJSONResponse(w, DeliveryRulesListDTO{RulesList: m.deliveryRulesOf(siteID, category)})
+	case http.MethodPut:
+		body, _ := ioutil.ReadAll(r.Body)
+		var rulesList DeliveryRulesListDTO
+		if err := json.Unmarshal(body, &rulesList); err != nil {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "", fmt.Sprintf("Invalid JSON: %s", err))
+			return
+		}
+		names := map[string]bool{}
+		for i, rule := range rulesList.RulesList {
+			if field, message := validateMockDeliveryRule(category, &rule); message != "" {
+				pointer := fmt.Sprintf("/data/%d", i)
+				if field != "" {
+					pointer += "/" + field
+				}
+				m.writeV3ErrorResponse(w, http.StatusBadRequest, pointer, message)
+				return
+			}
+			if names[rule.RuleName] {
+				m.writeV3ErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("/data/%d/rule_name", i), fmt.Sprintf("Duplicate rule name: %s", rule.RuleName))
+				return
+			}
+			names[rule.RuleName] = true
+		}
+
+		if m.deliveryRules[siteID] == nil {
+			m.deliveryRules[siteID] = make(map[string][]DeliveryRuleDto)
+		}
+		m.deliveryRules[siteID][category] = rulesList.RulesList
+		m.writeJSONResponse(w, DeliveryRulesListDTO{RulesList: m.deliveryRulesOf(siteID, category)})
+	default:
+		m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+	}
+}
+
+// deliveryRulesOf returns the rules of a category, as a non nil list (the API returns "data": [] when there are none)
+func (m *MockImpervaServer) deliveryRulesOf(siteID int, category string) []DeliveryRuleDto {
+	rules := make([]DeliveryRuleDto, 0)
+	return append(rules, m.deliveryRules[siteID][category]...)
+}
+
+// validateMockDeliveryRule validates a rule of a category like the delivery rules API does. It returns the field
+// at fault, if any, and the error message.
+func validateMockDeliveryRule(category string, rule *DeliveryRuleDto) (string, string) {
+	if rule.RuleName == "" {
+		return "rule_name", "Rule name is required"
+	}
+	if !contains(mockDeliveryRuleCategoryActions[category], rule.Action) {
+		return "action", fmt.Sprintf("Action %s is not allowed in category %s", rule.Action, category)
+	}
+
+	fields := map[string]bool{
+		"filter":                    rule.Filter != "",
+		"from":                      rule.From != "",
+		"to":                        rule.To != "",
+		"response_code":             rule.ResponseCode != 0,
+		"header_name":               rule.HeaderName != "",
+		"cookie_name":               rule.CookieName != "",
+		"rewrite_existing":          rule.RewriteExisting != nil,
+		"add_if_missing":            rule.AddMissing,
+		"multiple_headers_deletion": rule.MultipleHeaderDeletions,
+		"error_type":                rule.ErrorType != "",
+		"error_response_format":     rule.ErrorResponseFormat != "",
+		"error_response_data":       rule.ErrorResponseData != "",
+		"port_forwarding_context":   rule.PortForwardingContext != "",
+		"port_forwarding_value":     rule.PortForwardingValue != "",
+		"dc_id":                     rule.DCID != 0,
+	}
+
+	if rule.Action == "RULE_ACTION_SIMPLIFIED_REDIRECT" {
+		// Simplified redirect rules only match the path given in from, they don't have a filter
+		for field, set := range fields {
+			if set && field != "from" && field != "to" && field != "response_code" {
+				return field, fmt.Sprintf("Field %s is not applicable to action %s", field, rule.Action)
+			}
+		}
+	} else {
+		for field, set := range fields {
+			if set && !contains(ruleArgsToActionMap[field], rule.Action) {
+				return field, fmt.Sprintf("Field %s is not applicable to action %s", field, rule.Action)
+			}
+		}
+	}
+
+	switch rule.Action {
+	case "RULE_ACTION_REDIRECT", "RULE_ACTION_SIMPLIFIED_REDIRECT":
+		if rule.From == "" {
+			return "from", fmt.Sprintf("From is required for action %s", rule.Action)
+		}
+		if rule.To == "" {
+			return "to", fmt.Sprintf("To is required for action %s", rule.Action)
+		}
+		if !mockIntInSlice(mockRedirectResponseCodes, rule.ResponseCode) {
+			return "response_code", fmt.Sprintf("Invalid response code %d for action %s", rule.ResponseCode, rule.Action)
+		}
+	case "RULE_ACTION_REWRITE_URL":
+		if rule.From == "" || rule.To == "" {
+			return "to", "From and to are required for action RULE_ACTION_REWRITE_URL"
+		}
+	case "RULE_ACTION_REWRITE_HEADER", "RULE_ACTION_RESPONSE_REWRITE_HEADER", "RULE_ACTION_DELETE_HEADER", "RULE_ACTION_RESPONSE_DELETE_HEADER":
+		if rule.HeaderName == "" {
+			return "header_name", fmt.Sprintf("Header name is required for action %s", rule.Action)
+		}
+	case "RULE_ACTION_REWRITE_COOKIE", "RULE_ACTION_DELETE_COOKIE":
+		if rule.CookieName == "" {
+			return "cookie_name", fmt.Sprintf("Cookie name is required for action %s", rule.Action)
+		}
+	case "RULE_ACTION_FORWARD_TO_DC":
+		if rule.DCID == 0 {
+			return "dc_id", "Data center ID is required for action RULE_ACTION_FORWARD_TO_DC"
+		}
+	case "RULE_ACTION_FORWARD_TO_PORT":
+		if rule.PortForwardingValue == "" {
+			return "port_forwarding_value", "Port forwarding value is required for action RULE_ACTION_FORWARD_TO_PORT"
+		}
+	case "RULE_ACTION_CUSTOM_ERROR_RESPONSE":
+		if rule.ErrorResponseFormat != "" && rule.ErrorResponseFormat != "json" && rule.ErrorResponseFormat != "xml" {
+			return "error_response_format", fmt.Sprintf("Invalid error response format: %s", rule.ErrorResponseFormat)
+		}
+	}
+	return "", ""
+}
+
+func mockIntInSlice(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Helper methods for tests
+
+// GetIncapRule returns an incap rule by site ID and rule ID (for test assertions)
+func (m *MockImpervaServer) GetIncapRule(siteID int, ruleID int) *IncapRuleWithID {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.incapRules[siteID][ruleID]
+}
+
+// GetCacheRule returns a cache rule by site ID and rule ID (for test assertions)
+func (m *MockImpervaServer) GetCacheRule(siteID int, ruleID int) *CacheRuleWithID {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.cacheRules[siteID][ruleID]
+}
+
+// GetDeliveryRules returns the delivery rules of a site's category (for test assertions)
+func (m *MockImpervaServer) GetDeliveryRules(siteID int, category string) []DeliveryRuleDto {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.deliveryRulesOf(siteID, category)
+}
@@ -0,0 +1,152 @@
+package incapsula
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestMockIncapRulesCRUD(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	site := &MockSite{Domain: "rules.example.com"}
+	mock.AddSite(site)
+	siteID := strconv.Itoa(site.SiteID)
+
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+
+	rule := &IncapRule{Name: "Block bots", Action: "RULE_ACTION_BLOCK", Filter: `ClientType == "Bad Bot"`, Enabled: true}
+	created, err := client.AddIncapRule(ctx, siteID, rule)
+	if err != nil {
+		t.Fatalf("Unexpected error adding incap rule: %s", err)
+	}
+	if created.RuleID == 0 || mock.GetIncapRule(site.SiteID, created.RuleID) == nil {
+		t.Fatalf("Expected the incap rule to be stored, got %+v", created)
+	}
+
+	rule.Action = "RULE_ACTION_ALERT"
+	if _, err := client.UpdateIncapRule(ctx, siteID, created.RuleID, rule); err != nil {
+		t.Fatalf("Unexpected error updating incap rule: %s", err)
+	}
+	read, status, err := client.ReadIncapRule(ctx, siteID, created.RuleID)
+	if err != nil || status != 200 || read.Action != "RULE_ACTION_ALERT" {
+		t.Errorf("Expected the updated incap rule, got %+v %d %v", read, status, err)
+	}
+
+	if err := client.DeleteIncapRule(ctx, siteID, created.RuleID); err != nil {
+		t.Fatalf("Unexpected error deleting incap rule: %s", err)
+	}
+	_, status, err = client.ReadIncapRule(ctx, siteID, created.RuleID)
+	var apiErr *APIError
+	if status != 404 || !errors.As(err, &apiErr) || apiErr.Code != apiV1ResObjectNotFound || IsFeatureUnavailable(err) {
+		t.Errorf("Expected an object not found error for a deleted incap rule, got %d %v", status, err)
+	}
+
+	// The rules API rejects a filter on simplified redirect rules
+	redirect := &IncapRule{Name: "Redirect", Action: "RULE_ACTION_SIMPLIFIED_REDIRECT", Filter: `URL == "/old"`, From: "/old", To: "/new", ResponseCode: 301, Enabled: true}
+	if _, err := client.AddIncapRule(ctx, siteID, redirect); err == nil || !strings.Contains(err.Error(), "Filter is not supported") {
+		t.Errorf("Expected a filter on a simplified redirect rule to be rejected, got %v", err)
+	}
+	redirect.Filter = ""
+	if _, err := client.AddIncapRule(ctx, siteID, redirect); err != nil {
+		t.Errorf("Unexpected error adding simplified redirect rule: %s", err)
+	}
+
+	if _, err := client.AddIncapRule(ctx, "1", rule); err == nil {
+		t.Errorf("Expected an error adding an incap rule to an unknown site")
+	}
+}
+
+func TestMockCacheRulesCRUD(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	site := &MockSite{Domain: "cache.example.com"}
+	mock.AddSite(site)
+	siteID := strconv.Itoa(site.SiteID)
+
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+
+	rule := &CacheRule{Name: "Static images", Action: "HTTP_CACHE_MAKE_STATIC", Filter: `URL contains ".png"`, TTL: 3600, Enabled: true}
+	created, err := client.AddCacheRule(ctx, siteID, rule)
+	if err != nil {
+		t.Fatalf("Unexpected error adding cache rule: %s", err)
+	}
+
+	rule.TTL = 60
+	if err := client.UpdateCacheRule(ctx, siteID, created.RuleID, rule); err != nil {
+		t.Fatalf("Unexpected error updating cache rule: %s", err)
+	}
+	if stored := mock.GetCacheRule(site.SiteID, created.RuleID); stored == nil || stored.TTL != 60 {
+		t.Errorf("Expected the updated cache rule to be stored, got %+v", stored)
+	}
+
+	if err := client.DeleteCacheRule(ctx, siteID, created.RuleID); err != nil {
+		t.Fatalf("Unexpected error deleting cache rule: %s", err)
+	}
+	if _, status, err := client.ReadCacheRule(ctx, siteID, created.RuleID); err == nil || status != 404 {
+		t.Errorf("Expected a 404 for a deleted cache rule, got %d %v", status, err)
+	}
+
+	if _, err := client.AddCacheRule(ctx, siteID, &CacheRule{Name: "No TTL", Action: "HTTP_CACHE_MAKE_STATIC"}); err == nil {
+		t.Errorf("Expected a make static rule without a TTL to be rejected")
+	}
+}
+
+func TestMockDeliveryRulesConfiguration(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	site := &MockSite{Domain: "delivery.example.com"}
+	mock.AddSite(site)
+	siteID := strconv.Itoa(site.SiteID)
+
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+
+	rules, err := client.ReadDeliveryRuleConfiguration(ctx, siteID, "REWRITE")
+	if err != nil || rules.RulesList == nil || len(rules.RulesList) != 0 {
+		t.Fatalf("Expected an empty list of rules, got %+v %v", rules, err)
+	}
+
+	rewriteExisting := true
+	rules, err = client.UpdateDeliveryRuleConfiguration(ctx, siteID, "REWRITE", &DeliveryRulesListDTO{RulesList: []DeliveryRuleDto{
+		{RuleName: "Rewrite header", Action: "RULE_ACTION_REWRITE_HEADER", HeaderName: "X-Test", From: "a", To: "b", RewriteExisting: &rewriteExisting, Enabled: true},
+		{RuleName: "Delete cookie", Action: "RULE_ACTION_DELETE_COOKIE", CookieName: "tracking", Enabled: true},
+	}})
+	if err != nil || len(rules.Errors) > 0 || len(rules.RulesList) != 2 {
+		t.Fatalf("Unexpected update response: %+v %v", rules, err)
+	}
+	if stored := mock.GetDeliveryRules(site.SiteID, "REWRITE"); len(stored) != 2 || stored[1].RuleName != "Delete cookie" {
+		t.Errorf("Expected the rules to be stored in order, got %+v", stored)
+	}
+
+	// Actions of another category and filters on simplified redirect rules are rejected with a 400
+	for category, rule := range map[string]DeliveryRuleDto{
+		"REDIRECT":            {RuleName: "Forward", Action: "RULE_ACTION_FORWARD_TO_DC", DCID: 1},
+		"SIMPLIFIED_REDIRECT": {RuleName: "Redirect", Action: "RULE_ACTION_SIMPLIFIED_REDIRECT", Filter: `URL == "/old"`, From: "/old", To: "/new", ResponseCode: 301},
+	} {
+		_, err = client.UpdateDeliveryRuleConfiguration(ctx, siteID, category, &DeliveryRulesListDTO{RulesList: []DeliveryRuleDto{rule}})
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || len(apiErr.Errors) != 1 || apiErr.Errors[0].Status != 400 {
+			t.Errorf("Expected a 400 error for %s rule %+v, got %v", category, rule, err)
+		}
+	}
+
+	rules, err = client.UpdateDeliveryRuleConfiguration(ctx, siteID, "SIMPLIFIED_REDIRECT", &DeliveryRulesListDTO{RulesList: []DeliveryRuleDto{
+		{RuleName: "Redirect", Action: "RULE_ACTION_SIMPLIFIED_REDIRECT", From: "/old", To: "/new", ResponseCode: 301, Enabled: true},
+	}})
+	if err != nil || len(rules.Errors) > 0 || len(rules.RulesList) != 1 {
+		t.Errorf("Unexpected simplified redirect update response: %+v %v", rules, err)
+	}
+
+	// A deleted site is reported with a 404, so that the resources are removed from the state
+	if _, err = client.ReadDeliveryRuleConfiguration(ctx, "1", "REWRITE"); !IsNotFound(err) {
+		t.Errorf("Expected a 404 error for an unknown site, got %v", err)
+	}
+}
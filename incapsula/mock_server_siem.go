@@ -0,0 +1,392 @@
+// Mock Imperva API Server - SIEM connections and log configurations
+//
+// The endpoints mirror the requests of client_siem_connection.go and client_siem_log_configuration.go.
+// Like the real API, the secrets of a connection (S3 secret key, Splunk token, SFTP password) are never
+// returned: responses hold their SHA-256 hash instead, which is what the resources keep as input_hash.
+
+package incapsula
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	mockSiemConnectionsPattern       = regexp.MustCompile(`^siem-config-service/v3/connections/?([0-9a-f]*)$`)
+	mockSiemLogConfigurationsPattern = regexp.MustCompile(`^siem-config-service/v3/log-configurations/?([0-9a-f]*)$`)
+)
+
+// mockSiemProducerDatasets maps the producers of the log configurations to the datasets they can send
+var mockSiemProducerDatasets = map[string][]string{
+	AbpProvider:             AbpDatasets,
+	NetsecProvider:          NetsecDatasets,
+	AtoProvider:             AtoDatasets,
+	AuditProvider:           AuditDatasets,
+	CspProvider:             CspDatasets,
+	CloudWafProvider:        CloudWafDatasets,
+	AttackAnalyticsProvider: AttackAnalyticsDatasets,
+}
+
+// mockSiemConnectionInfo holds the connection info of every storage type, as sent in requests
+type mockSiemConnectionInfo struct {
+	AccessKey               string `json:"accessKey"`
+	SecretKey               string `json:"secretKey"`
+	Path                    string `json:"path"`
+	Host                    string `json:"host"`
+	Port                    int    `json:"port"`
+	Token                   string `json:"token"`
+	DisableCertVerification bool   `json:"disableCertVerification"`
+	Username                string `json:"username"`
+	Password                string `json:"password"`
+}
+
+// MockSiemConnection represents a SIEM connection in the mock server. The secret of the connection is only
+// kept as its hash.
+type MockSiemConnection struct {
+	ID             string                 `json:"id"`
+	AccountID      int                    `json:"account_id"`
+	ConnectionName string                 `json:"connection_name"`
+	StorageType    string                 `json:"storage_type"`
+	ConnectionInfo mockSiemConnectionInfo `json:"connection_info"`
+}
+
+// MockSiemLogConfiguration represents a SIEM log configuration in the mock server
+type MockSiemLogConfiguration struct {
+	AccountID int `json:"account_id"`
+	SiemLogConfigurationData
+}
+
+// nextSiemObjectID returns a new ID, formatted like the IDs of the SIEM config service. The caller must hold the lock.
+func (m *MockImpervaServer) nextSiemObjectID() string {
+	id := fmt.Sprintf("%024x", m.nextSiemID)
+	m.nextSiemID++
+	return id
+}
+
+// data returns the connection as the API does
+func (connection *MockSiemConnection) data() SiemConnectionData {
+	info := connection.ConnectionInfo
+	var connectionInfo ConnectionInfo
+	switch connection.StorageType {
+	case StorageTypeCustomerS3:
+		connectionInfo = S3ConnectionInfo{AccessKey: info.AccessKey, SecretKey: info.SecretKey, Path: info.Path}
+	case StorageTypeCustomerS3Arn:
+		connectionInfo = S3ConnectionInfo{Path: info.Path}
+	case StorageTypeCustomerSplunk:
+		connectionInfo = SplunkConnectionInfo{Host: info.Host, Port: info.Port, Token: info.Token, DisableCertVerification: info.DisableCertVerification}
+	case StorageTypeCustomerSftp:
+		connectionInfo = SftpConnectionInfo{Host: info.Host, Username: info.Username, Password: info.Password, Path: info.Path}
+	}
+	return SiemConnectionData{
+		ID:             connection.ID,
+		AssetID:        fmt.Sprintf("%d", connection.AccountID),
+		ConnectionName: connection.ConnectionName,
+		StorageType:    connection.StorageType,
+		ConnectionInfo: connectionInfo,
+	}
+}
+
+// validateMockSiemConnectionInfo validates the connection info of a storage type. Secrets are optional on update,
+// where the stored secret is kept. It returns the JSON pointer of the field at fault and the error message.
+func validateMockSiemConnectionInfo(storageType string, info *mockSiemConnectionInfo, update bool) (string, string) {
+	var required map[string]string
+	switch storageType {
+	case StorageTypeCustomerS3:
+		if len(info.AccessKey) != 20 {
+			return "/data/0/connectionInfo/accessKey", "accessKey must be 20 characters long"
+		}
+		if len(info.SecretKey) != 40 && !(update && info.SecretKey == "") {
+			return "/data/0/connectionInfo/secretKey", "secretKey must be 40 characters long"
+		}
+		required = map[string]string{"path": info.Path}
+	case StorageTypeCustomerS3Arn:
+		if info.AccessKey != "" || info.SecretKey != "" {
+			return "/data/0/connectionInfo", fmt.Sprintf("accessKey and secretKey are not supported for storageType %s", storageType)
+		}
+		required = map[string]string{"path": info.Path}
+	case StorageTypeCustomerSplunk:
+		if info.Port < 1 || info.Port > 65535 {
+			return "/data/0/connectionInfo/port", fmt.Sprintf("Invalid port: %d", info.Port)
+		}
+		required = map[string]string{"host": info.Host}
+		if !update {
+			required["token"] = info.Token
+		}
+	case StorageTypeCustomerSftp:
+		required = map[string]string{"host": info.Host, "username": info.Username, "path": info.Path}
+		if !update {
+			required["password"] = info.Password
+		}
+	default:
+		return "/data/0/storageType", fmt.Sprintf("Unsupported storageType: %s", storageType)
+	}
+	for field, value := range required {
+		if value == "" {
+			return "/data/0/connectionInfo/" + field, fmt.Sprintf("%s is required for storageType %s", field, storageType)
+		}
+	}
+	return "", ""
+}
+
+// hashMockSiemConnectionSecret replaces the secret of the connection info with its hash, keeping the previous
+// hash when no secret is given
+func hashMockSiemConnectionSecret(storageType string, info *mockSiemConnectionInfo, previous *mockSiemConnectionInfo) {
+	switch storageType {
+	case StorageTypeCustomerS3:
+		if info.SecretKey != "" {
+			info.SecretKey = calculateS3SiemConnectionHash(info.SecretKey)
+		} else if previous != nil {
+			info.SecretKey = previous.SecretKey
+		}
+	case StorageTypeCustomerSplunk:
+		if info.Token != "" {
+			info.Token = calculateSplunkSiemConnectionHash(info.Token)
+		} else if previous != nil {
+			info.Token = previous.Token
+		}
+	case StorageTypeCustomerSftp:
+		if info.Password != "" {
+			info.Password = calculateSftpSiemConnectionHash(info.Password)
+		} else if previous != nil {
+			info.Password = previous.Password
+		}
+	}
+}
+
+// SIEM Connections Handlers
+
+// handleSiemConnections handles POST /siem-config-service/v3/connections/ and
+// GET/PUT/DELETE /siem-config-service/v3/connections/{connectionId}
+func (m *MockImpervaServer) handleSiemConnections(w http.ResponseWriter, r *http.Request, path string) {
+	matches := mockSiemConnectionsPattern.FindStringSubmatch(path)
+	connectionID := matches[1]
+	accountID := m.requestAccountID(r)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if connectionID == "" {
+		if r.Method != http.MethodPost {
+			m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+			return
+		}
+		m.writeSiemConnection(w, r, &MockSiemConnection{AccountID: accountID}, http.StatusCreated)
+		return
+	}
+
+	// Connections of other accounts are not found
+	connection, exists := m.siemConnections[connectionID]
+	if !exists || connection.AccountID != accountID {
+		m.writeV3ErrorResponse(w, http.StatusNotFound, "", fmt.Sprintf("Connection %s not found", connectionID))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		m.writeSiemResponse(w, http.StatusOK, connection.data())
+	case http.MethodPut:
+		m.writeSiemConnection(w, r, connection, http.StatusOK)
+	case http.MethodDelete:
+		// A connection can't be deleted while log configurations send logs to it
+		var users []string
+		for _, logConfiguration := range m.siemLogConfigurations {
+			if logConfiguration.ConnectionId == connectionID {
+				users = append(users, logConfiguration.ConfigurationName)
+			}
+		}
+		if len(users) > 0 {
+			sort.Strings(users)
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "", fmt.Sprintf("Connection %s is used by log configurations: %s", connectionID, strings.Join(users, ", ")))
+			return
+		}
+		delete(m.siemConnections, connectionID)
+		m.writeSiemResponse(w, http.StatusOK)
+	default:
+		m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+	}
+}
+
+// writeSiemConnection validates a create or update request, stores the connection and writes it. The caller must
+// hold the lock.
+func (m *MockImpervaServer) writeSiemConnection(w http.ResponseWriter, r *http.Request, connection *MockSiemConnection, status int) {
+	var request struct {
+		Data []struct {
+			ConnectionName string                 `json:"connectionName"`
+			StorageType    string                 `json:"storageType"`
+			ConnectionInfo mockSiemConnectionInfo `json:"connectionInfo"`
+		} `json:"data"`
+	}
+	body, _ := ioutil.ReadAll(r.Body)
+	if err := json.Unmarshal(body, &request); err != nil || len(request.Data) != 1 {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "/data", "Expected a single connection")
+		return
+	}
+	data := request.Data[0]
+
+	update := connection.ID != ""
+	if data.ConnectionName == "" {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "/data/0/connectionName", "connectionName is required")
+		return
+	}
+	for _, other := range m.siemConnections {
+		if other != connection && other.AccountID == connection.AccountID && other.ConnectionName == data.ConnectionName {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "/data/0/connectionName", fmt.Sprintf("A connection named %s already exists", data.ConnectionName))
+			return
+		}
+	}
+	// Secrets can only be omitted on update when the storage type is unchanged
+	if pointer, message := validateMockSiemConnectionInfo(data.StorageType, &data.ConnectionInfo, update && data.StorageType == connection.StorageType); message != "" {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, pointer, message)
+		return
+	}
+
+	var previous *mockSiemConnectionInfo
+	if update && data.StorageType == connection.StorageType {
+		previous = &connection.ConnectionInfo
+	}
+	hashMockSiemConnectionSecret(data.StorageType, &data.ConnectionInfo, previous)
+
+	if !update {
+		connection.ID = m.nextSiemObjectID()
+		m.siemConnections[connection.ID] = connection
+	}
+	connection.ConnectionName = data.ConnectionName
+	connection.StorageType = data.StorageType
+	connection.ConnectionInfo = data.ConnectionInfo
+
+	m.writeSiemResponse(w, status, connection.data())
+}
+
+// SIEM Log Configurations Handlers
+
+// handleSiemLogConfigurations handles POST /siem-config-service/v3/log-configurations/ and
+// GET/PUT/DELETE /siem-config-service/v3/log-configurations/{configurationId}
+func (m *MockImpervaServer) handleSiemLogConfigurations(w http.ResponseWriter, r *http.Request, path string) {
+	matches := mockSiemLogConfigurationsPattern.FindStringSubmatch(path)
+	configurationID := matches[1]
+	accountID := m.requestAccountID(r)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if configurationID == "" {
+		if r.Method != http.MethodPost {
+			m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+			return
+		}
+		m.writeSiemLogConfiguration(w, r, &MockSiemLogConfiguration{AccountID: accountID}, http.StatusCreated)
+		return
+	}
+
+	logConfiguration, exists := m.siemLogConfigurations[configurationID]
+	if !exists || logConfiguration.AccountID != accountID {
+		m.writeV3ErrorResponse(w, http.StatusNotFound, "", fmt.Sprintf("Log configuration %s not found", configurationID))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		m.writeSiemResponse(w, http.StatusOK, logConfiguration.SiemLogConfigurationData)
+	case http.MethodPut:
+		m.writeSiemLogConfiguration(w, r, logConfiguration, http.StatusOK)
+	case http.MethodDelete:
+		delete(m.siemLogConfigurations, configurationID)
+		m.writeSiemResponse(w, http.StatusOK)
+	default:
+		m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+	}
+}
+
+// writeSiemLogConfiguration validates a create or update request, stores the log configuration and writes it.
+// The caller must hold the lock.
+func (m *MockImpervaServer) writeSiemLogConfiguration(w http.ResponseWriter, r *http.Request, logConfiguration *MockSiemLogConfiguration, status int) {
+	var request SiemLogConfiguration
+	body, _ := ioutil.ReadAll(r.Body)
+	if err := json.Unmarshal(body, &request); err != nil || len(request.Data) != 1 {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "/data", "Expected a single log configuration")
+		return
+	}
+	data := request.Data[0]
+
+	if data.ConfigurationName == "" {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "/data/0/configurationName", "configurationName is required")
+		return
+	}
+	for _, other := range m.siemLogConfigurations {
+		if other != logConfiguration && other.AccountID == logConfiguration.AccountID && other.ConfigurationName == data.ConfigurationName {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "/data/0/configurationName", fmt.Sprintf("A log configuration named %s already exists", data.ConfigurationName))
+			return
+		}
+	}
+
+	// The datasets must be sent by the producer, each at most once
+	datasets, exists := mockSiemProducerDatasets[data.Provider]
+	if !exists {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "/data/0/provider", fmt.Sprintf("Unsupported provider: %s", data.Provider))
+		return
+	}
+	if len(data.Datasets) == 0 {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "/data/0/datasets", "At least one dataset is required")
+		return
+	}
+	seen := map[string]bool{}
+	for i, value := range data.Datasets {
+		dataset, _ := value.(string)
+		if !contains(datasets, dataset) {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("/data/0/datasets/%d", i), fmt.Sprintf("Unsupported dataset %v for provider %s", value, data.Provider))
+			return
+		}
+		if seen[dataset] {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("/data/0/datasets/%d", i), fmt.Sprintf("Duplicate dataset %s", dataset))
+			return
+		}
+		seen[dataset] = true
+	}
+
+	if connection, exists := m.siemConnections[data.ConnectionId]; !exists || connection.AccountID != logConfiguration.AccountID {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "/data/0/connectionId", fmt.Sprintf("Connection %s not found", data.ConnectionId))
+		return
+	}
+
+	if logConfiguration.ID == "" {
+		data.ID = m.nextSiemObjectID()
+		m.siemLogConfigurations[data.ID] = logConfiguration
+	} else {
+		data.ID = logConfiguration.ID
+	}
+	data.AssetID = fmt.Sprintf("%d", logConfiguration.AccountID)
+	logConfiguration.SiemLogConfigurationData = data
+
+	m.writeSiemResponse(w, status, logConfiguration.SiemLogConfigurationData)
+}
+
+// writeSiemResponse writes the data list of a SIEM config service response
+func (m *MockImpervaServer) writeSiemResponse(w http.ResponseWriter, status int, data ...interface{}) {
+	if data == nil {
+		data = []interface{}{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+}
+
+// Helper methods for tests
+
+// GetSiemConnection returns a SIEM connection by ID (for test assertions)
+func (m *MockImpervaServer) GetSiemConnection(connectionID string) *MockSiemConnection {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.siemConnections[connectionID]
+}
+
+// GetSiemLogConfiguration returns a SIEM log configuration by ID (for test assertions)
+func (m *MockImpervaServer) GetSiemLogConfiguration(configurationID string) *MockSiemLogConfiguration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.siemLogConfigurations[configurationID]
+}
@@ -0,0 +1,173 @@
+package incapsula
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestMockSiemConnections(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+	accountID := strconv.Itoa(mockAPIKeyAccountID)
+
+	secretKey := strings.Repeat("s", 40)
+	created, status, err := client.CreateSiemConnection(ctx, &SiemConnection{Data: []SiemConnectionData{{
+		AssetID:        accountID,
+		ConnectionName: "S3",
+		StorageType:    StorageTypeCustomerS3,
+		ConnectionInfo: S3ConnectionInfo{AccessKey: strings.Repeat("A", 20), SecretKey: secretKey, Path: "bucket/logs"},
+	}}})
+	if err != nil || *status != 201 {
+		t.Fatalf("Unexpected error creating S3 connection: %v", err)
+	}
+	s3 := created.Data[0]
+	if s3.AssetID != accountID {
+		t.Errorf("Expected the connection to belong to account %s, got %s", accountID, s3.AssetID)
+	}
+	// The secret is never returned, only the hash the resource keeps as input_hash
+	if info := s3.ConnectionInfo.(S3ConnectionInfo); info.SecretKey != calculateS3SiemConnectionHash(secretKey) || info.Path != "bucket/logs" {
+		t.Errorf("Expected the hash of the secret key, got %+v", info)
+	}
+
+	splunk, _, err := client.CreateSiemConnection(ctx, &SiemConnection{Data: []SiemConnectionData{{
+		AssetID:        accountID,
+		ConnectionName: "Splunk",
+		StorageType:    StorageTypeCustomerSplunk,
+		ConnectionInfo: SplunkConnectionInfo{Host: "splunk.example.com", Port: 8088, Token: "splunk-token"},
+	}}})
+	if err != nil {
+		t.Fatalf("Unexpected error creating Splunk connection: %s", err)
+	}
+	splunkID := splunk.Data[0].ID
+
+	// The token can be omitted on update, the stored one is kept
+	if _, _, err := client.UpdateSiemConnection(ctx, &SiemConnection{Data: []SiemConnectionData{{
+		ID:             splunkID,
+		AssetID:        accountID,
+		ConnectionName: "Splunk",
+		StorageType:    StorageTypeCustomerSplunk,
+		ConnectionInfo: SplunkConnectionInfo{Host: "splunk2.example.com", Port: 8088, DisableCertVerification: true},
+	}}}); err != nil {
+		t.Fatalf("Unexpected error updating Splunk connection: %s", err)
+	}
+	read, _, err := client.ReadSiemConnection(ctx, splunkID, accountID)
+	if err != nil {
+		t.Fatalf("Unexpected error reading Splunk connection: %s", err)
+	}
+	if info := read.Data[0].ConnectionInfo.(SplunkConnectionInfo); info.Host != "splunk2.example.com" || !info.DisableCertVerification || info.Token != calculateSplunkSiemConnectionHash("splunk-token") {
+		t.Errorf("Unexpected Splunk connection info: %+v", info)
+	}
+
+	sftp, _, err := client.CreateSiemConnection(ctx, &SiemConnection{Data: []SiemConnectionData{{
+		AssetID:        accountID,
+		ConnectionName: "SFTP",
+		StorageType:    StorageTypeCustomerSftp,
+		ConnectionInfo: SftpConnectionInfo{Host: "sftp.example.com", Username: "logs", Password: "password", Path: "/logs"},
+	}}})
+	if err != nil || sftp.Data[0].ConnectionInfo.(SftpConnectionInfo).Password != calculateSftpSiemConnectionHash("password") {
+		t.Fatalf("Unexpected SFTP connection: %+v %v", sftp, err)
+	}
+
+	// Invalid connections are rejected
+	for name, connection := range map[string]SiemConnectionData{
+		"duplicate name":   {AssetID: accountID, ConnectionName: "S3", StorageType: StorageTypeCustomerS3Arn, ConnectionInfo: S3ConnectionInfo{Path: "bucket"}},
+		"keys with an ARN": {AssetID: accountID, ConnectionName: "ARN", StorageType: StorageTypeCustomerS3Arn, ConnectionInfo: S3ConnectionInfo{AccessKey: strings.Repeat("A", 20), SecretKey: secretKey, Path: "bucket"}},
+		"short secret key": {AssetID: accountID, ConnectionName: "Short", StorageType: StorageTypeCustomerS3, ConnectionInfo: S3ConnectionInfo{AccessKey: strings.Repeat("A", 20), SecretKey: "short", Path: "bucket"}},
+		"missing password": {AssetID: accountID, ConnectionName: "No password", StorageType: StorageTypeCustomerSftp, ConnectionInfo: SftpConnectionInfo{Host: "sftp.example.com", Username: "logs", Path: "/logs"}},
+	} {
+		if _, status, err := client.CreateSiemConnection(ctx, &SiemConnection{Data: []SiemConnectionData{connection}}); err == nil || *status != 400 {
+			t.Errorf("Expected a 400 error for %s, got %v", name, err)
+		}
+	}
+
+	// Connections of other accounts are not found
+	other := &MockAccount{Email: "other@example.com"}
+	mock.AddAccount(other)
+	if _, _, err := client.ReadSiemConnection(ctx, s3.ID, strconv.Itoa(other.AccountID)); !IsNotFound(err) {
+		t.Errorf("Expected a not found error reading the connection of another account, got %v", err)
+	}
+
+	if _, err := client.DeleteSiemConnection(ctx, s3.ID, accountID); err != nil {
+		t.Fatalf("Unexpected error deleting S3 connection: %s", err)
+	}
+	if _, _, err := client.ReadSiemConnection(ctx, s3.ID, accountID); !IsNotFound(err) {
+		t.Errorf("Expected a not found error for a deleted connection, got %v", err)
+	}
+}
+
+func TestMockSiemLogConfigurations(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+	accountID := strconv.Itoa(mockAPIKeyAccountID)
+
+	connection, _, err := client.CreateSiemConnection(ctx, &SiemConnection{Data: []SiemConnectionData{{
+		AssetID:        accountID,
+		ConnectionName: "ARN",
+		StorageType:    StorageTypeCustomerS3Arn,
+		ConnectionInfo: S3ConnectionInfo{Path: "bucket/logs"},
+	}}})
+	if err != nil {
+		t.Fatalf("Unexpected error creating connection: %s", err)
+	}
+	connectionID := connection.Data[0].ID
+
+	created, status, err := client.CreateSiemLogConfiguration(ctx, &SiemLogConfiguration{Data: []SiemLogConfigurationData{{
+		AssetID:           accountID,
+		ConfigurationName: "WAF logs",
+		Provider:          CloudWafProvider,
+		Datasets:          []interface{}{"WAF_RAW_LOGS"},
+		Enabled:           true,
+		ConnectionId:      connectionID,
+	}}})
+	if err != nil || *status != 201 {
+		t.Fatalf("Unexpected error creating log configuration: %v", err)
+	}
+	configurationID := created.Data[0].ID
+
+	if _, _, err := client.UpdateSiemLogConfiguration(ctx, &SiemLogConfiguration{Data: []SiemLogConfigurationData{{
+		ID:                configurationID,
+		AssetID:           accountID,
+		ConfigurationName: "WAF logs",
+		Provider:          CloudWafProvider,
+		Datasets:          []interface{}{"WAF_RAW_LOGS", "CLOUD_WAF_ACCESS"},
+		ConnectionId:      connectionID,
+	}}}); err != nil {
+		t.Fatalf("Unexpected error updating log configuration: %s", err)
+	}
+	if stored := mock.GetSiemLogConfiguration(configurationID); stored == nil || stored.Enabled || len(stored.Datasets) != 2 {
+		t.Errorf("Expected the updated log configuration to be stored, got %+v", stored)
+	}
+
+	// The datasets must belong to the producer and the connection must exist
+	for name, logConfiguration := range map[string]SiemLogConfigurationData{
+		"dataset of another producer": {ConfigurationName: "ABP", Provider: AbpProvider, Datasets: []interface{}{"WAF_RAW_LOGS"}, ConnectionId: connectionID},
+		"duplicate dataset":           {ConfigurationName: "Netsec", Provider: NetsecProvider, Datasets: []interface{}{"IP", "IP"}, ConnectionId: connectionID},
+		"unknown connection":          {ConfigurationName: "Audit", Provider: AuditProvider, Datasets: []interface{}{"AUDIT_TRAIL"}, ConnectionId: "ffffffffffffffffffffffff"},
+	} {
+		logConfiguration.AssetID = accountID
+		if _, status, err := client.CreateSiemLogConfiguration(ctx, &SiemLogConfiguration{Data: []SiemLogConfigurationData{logConfiguration}}); err == nil || *status != 400 {
+			t.Errorf("Expected a 400 error for %s, got %v", name, err)
+		}
+	}
+
+	// A connection can't be deleted while a log configuration uses it
+	if _, err := client.DeleteSiemConnection(ctx, connectionID, accountID); err == nil || !strings.Contains(err.Error(), "WAF logs") {
+		t.Errorf("Expected an error deleting a connection used by a log configuration, got %v", err)
+	}
+	if _, err := client.DeleteSiemLogConfiguration(ctx, configurationID, accountID); err != nil {
+		t.Fatalf("Unexpected error deleting log configuration: %s", err)
+	}
+	if _, err := client.DeleteSiemConnection(ctx, connectionID, accountID); err != nil {
+		t.Errorf("Unexpected error deleting unused connection: %s", err)
+	}
+}
@@ -0,0 +1,588 @@
+// Mock Imperva API Server - site settings
+//
+// The endpoints mirror the requests of client_application_delivery.go, client_performance.go,
+// client_site_monitoring.go, client_site_ssl_settings.go and client_site_txt_record.go. Each site has one document per
+// settings endpoint, holding the defaults of the API until it is updated. Like in the real API, updates are partial:
+// the fields missing from a request keep their values. Error pages are the exception, a PUT replaces them all.
+
+package incapsula
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	mockDeliverySettingsPattern = regexp.MustCompile(`^sites/(\d+)/settings/delivery$`)
+	mockErrorPagesPattern       = regexp.MustCompile(`^sites/(\d+)/settings/delivery/error-pages$`)
+	mockCacheSettingsPattern    = regexp.MustCompile(`^sites/(\d+)/settings/cache$`)
+	mockTXTRecordsPattern       = regexp.MustCompile(`^sites/(\d+)/settings/general/additionalTxtRecords(/delete-all)?$`)
+	mockSiteMonitoringPattern   = regexp.MustCompile(`^appdlv-site-settings/v2/site/(\d+)/monitoring$`)
+	mockSiteSSLSettingsPattern  = regexp.MustCompile(`^sites-mgmt/v3/sites/(\d+)/settings/TLSConfiguration$`)
+)
+
+// mockTXTRecordParams are the form params of the TXT records, by record number minus one
+var mockTXTRecordParams = []string{"txt_record_value_one", "txt_record_value_two", "txt_record_value_three", "txt_record_value_four", "txt_record_value_five"}
+
+// mockTLS13Ciphers are the cipher suites of TLS 1.3, which the other TLS versions don't support
+var mockTLS13Ciphers = []string{"TLS_AES_128_GCM_SHA256", "TLS_AES_256_GCM_SHA384", "TLS_CHACHA20_POLY1305_SHA256"}
+
+// MockSiteSettings holds the settings documents of a site. Documents missing from a seed have the defaults of the
+// API.
+type MockSiteSettings struct {
+	Delivery    *ApplicationDelivery `json:"delivery,omitempty"`
+	ErrorPages  *CustomErrorPage     `json:"error_pages,omitempty"`
+	Performance *PerformanceSettings `json:"performance,omitempty"`
+	Monitoring  *SiteMonitoring      `json:"monitoring,omitempty"`
+	SSL         *SSLSettingsDTO      `json:"ssl,omitempty"`
+	TXTRecords  [5]string            `json:"txt_records"`
+}
+
+// mockSSLSettingsPatch is a settings object of a TLS configuration update. The settings it omits are left unchanged.
+type mockSSLSettingsPatch struct {
+	HstsConfiguration               *HSTSConfiguration               `json:"hstsConfiguration"`
+	InboundTLSSettingsConfiguration *InboundTLSSettingsConfiguration `json:"inboundTlsSettings"`
+	DisablePQCSupport               *bool                            `json:"disablePQCSupport"`
+}
+
+func newMockApplicationDelivery() *ApplicationDelivery {
+	enableHttp2, http2ToOrigin := true, false
+	return &ApplicationDelivery{
+		Compression:      Compression{FileCompression: true, CompressionType: "GZIP", MinifyJs: true, MinifyCss: true, MinifyStaticHtml: true},
+		ImageCompression: ImageCompression{CompressJpeg: true, CompressPng: true},
+		Network: Network{
+			TcpPrePooling:         true,
+			OriginConnectionReuse: true,
+			SupportNonSniClients:  true,
+			EnableHttp2:           &enableHttp2,
+			Http2ToOrigin:         &http2ToOrigin,
+			Port:                  Port{To: strconv.Itoa(defaultPortTo)},
+			SslPort:               SslPort{To: strconv.Itoa(defaultSslPortTo)},
+		},
+	}
+}
+
+func newMockPerformanceSettings() *PerformanceSettings {
+	settings := &PerformanceSettings{}
+	settings.Mode.Level = "smart"
+	settings.Mode.HTTPS = "disabled"
+	settings.Response.StaleContent.Mode = "disabled"
+	settings.Response.CacheResponseHeader.Mode = "disabled"
+	settings.Response.CacheResponseHeader.Headers = []interface{}{}
+	return settings
+}
+
+func newMockSiteMonitoring() *SiteMonitoring {
+	return &SiteMonitoring{
+		MonitoringParameters:  MonitoringParameters{FailedRequestsPercentage: 40, FailedRequestsMinNumber: 3, FailedRequestsDuration: 40, FailedRequestsDurationUnits: "SECONDS"},
+		FailedRequestCriteria: FailedRequestCriteria{HttpRequestTimeout: 35, HttpRequestTimeoutUnits: "SECONDS", HttpResponseError: "501-599"},
+		UpDownVerification:    UpDownVerification{UseVerificationForDown: true, MonitoringUrl: "/", UpChecksInterval: 20, UpChecksIntervalUnits: "SECONDS", UpCheckRetries: 3},
+		Notifications:         Notifications{AlarmOnStandsByFailover: true, AlarmOnDcFailover: true, RequiredMonitors: "MOST"},
+	}
+}
+
+func newMockSSLSettings() *SSLSettingsDTO {
+	return &SSLSettingsDTO{
+		HstsConfiguration:               &HSTSConfiguration{MaxAge: 31536000},
+		InboundTLSSettingsConfiguration: &InboundTLSSettingsConfiguration{ConfigurationProfile: "DEFAULT", TLSConfigurations: []TLSConfiguration{}},
+	}
+}
+
+// siteSettingsOf returns the settings of a site, giving the documents it doesn't have their defaults. The caller must
+// hold the lock.
+func (m *MockImpervaServer) siteSettingsOf(siteID int) *MockSiteSettings {
+	settings := m.siteSettings[siteID]
+	if settings == nil {
+		settings = &MockSiteSettings{}
+		m.siteSettings[siteID] = settings
+	}
+	if settings.Delivery == nil {
+		settings.Delivery = newMockApplicationDelivery()
+	}
+	if settings.ErrorPages == nil {
+		settings.ErrorPages = &CustomErrorPage{}
+	}
+	if settings.Performance == nil {
+		settings.Performance = newMockPerformanceSettings()
+	}
+	if settings.Monitoring == nil {
+		settings.Monitoring = newMockSiteMonitoring()
+	}
+	if settings.SSL == nil {
+		settings.SSL = newMockSSLSettings()
+	}
+	return settings
+}
+
+// mockMergeDocument applies a JSON request body to a copy of a settings document, stored in merged: the fields the
+// body omits keep the values of the document.
+func mockMergeDocument(document interface{}, body []byte, merged interface{}) error {
+	encoded, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(encoded, merged); err != nil {
+		return err
+	}
+	return json.Unmarshal(body, merged)
+}
+
+// Application Delivery Handlers
+
+// handleDeliverySettings handles GET/PUT/DELETE /sites/{siteId}/settings/delivery. A DELETE restores the defaults.
+func (m *MockImpervaServer) handleDeliverySettings(w http.ResponseWriter, r *http.Request, path string) {
+	matches := mockDeliverySettingsPattern.FindStringSubmatch(path)
+	siteID, _ := strconv.Atoi(matches[1])
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.sites[siteID]; !exists {
+		m.writeV2ErrorResponse(w, http.StatusNotFound, 9413, "Unknown/unauthorized site_id")
+		return
+	}
+	settings := m.siteSettingsOf(siteID)
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var delivery ApplicationDelivery
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := mockMergeDocument(settings.Delivery, body, &delivery); err != nil {
+			m.writeV2ErrorResponse(w, http.StatusBadRequest, 1, fmt.Sprintf("Invalid JSON: %s", err))
+			return
+		}
+		// The HTTP/2 settings are only changed when they are sent
+		if delivery.Network.EnableHttp2 == nil {
+			delivery.Network.EnableHttp2 = settings.Delivery.Network.EnableHttp2
+		}
+		if delivery.Network.Http2ToOrigin == nil {
+			delivery.Network.Http2ToOrigin = settings.Delivery.Network.Http2ToOrigin
+		}
+		if message := validateMockApplicationDelivery(&delivery); message != "" {
+			m.writeV2ErrorResponse(w, http.StatusBadRequest, 2, message)
+			return
+		}
+		settings.Delivery = &delivery
+	case http.MethodDelete:
+		settings.Delivery = newMockApplicationDelivery()
+	default:
+		m.writeV2ErrorResponse(w, http.StatusMethodNotAllowed, 9999, fmt.Sprintf("Method not allowed: %s", r.Method))
+		return
+	}
+	m.writeJSONResponse(w, settings.Delivery)
+}
+
+// validateMockApplicationDelivery validates delivery settings like the API does. It returns the error message.
+func validateMockApplicationDelivery(delivery *ApplicationDelivery) string {
+	if !contains([]string{"GZIP", "BROTLI"}, delivery.Compression.CompressionType) {
+		return fmt.Sprintf("Invalid compression_type: %s", delivery.Compression.CompressionType)
+	}
+	for _, port := range []struct{ name, value string }{{"port", delivery.Network.Port.To}, {"ssl_port", delivery.Network.SslPort.To}} {
+		if number, err := strconv.Atoi(port.value); err != nil || number < 1 || number > 65535 {
+			return fmt.Sprintf("Invalid %s: %s", port.name, port.value)
+		}
+	}
+	if delivery.Network.Port.To == delivery.Network.SslPort.To {
+		return "port and ssl_port must be different"
+	}
+	if *delivery.Network.Http2ToOrigin && !*delivery.Network.EnableHttp2 {
+		return "HTTP/2 to Origin support requires that HTTP/2 will be enabled for your website"
+	}
+	return ""
+}
+
+// handleErrorPages handles GET/PUT /sites/{siteId}/settings/delivery/error-pages. A PUT replaces all the error pages,
+// the templates it omits are removed.
+func (m *MockImpervaServer) handleErrorPages(w http.ResponseWriter, r *http.Request, path string) {
+	matches := mockErrorPagesPattern.FindStringSubmatch(path)
+	siteID, _ := strconv.Atoi(matches[1])
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.sites[siteID]; !exists {
+		m.writeV2ErrorResponse(w, http.StatusNotFound, 9413, "Unknown/unauthorized site_id")
+		return
+	}
+	settings := m.siteSettingsOf(siteID)
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var errorPages CustomErrorPage
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &errorPages); err != nil {
+			m.writeV2ErrorResponse(w, http.StatusBadRequest, 1, fmt.Sprintf("Invalid JSON: %s", err))
+			return
+		}
+		settings.ErrorPages = &errorPages
+	default:
+		m.writeV2ErrorResponse(w, http.StatusMethodNotAllowed, 9999, fmt.Sprintf("Method not allowed: %s", r.Method))
+		return
+	}
+	m.writeJSONResponse(w, settings.ErrorPages)
+}
+
+// Cache Settings Handlers
+
+// handleCacheSettings handles GET/PUT /sites/{siteId}/settings/cache. Caching all resources, including all of them
+// over HTTPS, is a risky operation that must be forced with a header.
+func (m *MockImpervaServer) handleCacheSettings(w http.ResponseWriter, r *http.Request, path string) {
+	matches := mockCacheSettingsPattern.FindStringSubmatch(path)
+	siteID, _ := strconv.Atoi(matches[1])
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.sites[siteID]; !exists {
+		m.writeV2ErrorResponse(w, http.StatusNotFound, 9413, "Unknown/unauthorized site_id")
+		return
+	}
+	settings := m.siteSettingsOf(siteID)
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var performance PerformanceSettings
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := mockMergeDocument(settings.Performance, body, &performance); err != nil {
+			m.writeV2ErrorResponse(w, http.StatusBadRequest, 1, fmt.Sprintf("Invalid JSON: %s", err))
+			return
+		}
+		if message := validateMockPerformanceSettings(&performance); message != "" {
+			m.writeV2ErrorResponse(w, http.StatusBadRequest, 2, message)
+			return
+		}
+		if performance.Mode.Level == "all_resources" && performance.Mode.HTTPS == "include_all_resources" &&
+			r.Header.Get(FORCE_RISKY_OP_HEADER_NAME) != "true" {
+			m.writeV2ErrorResponse(w, http.StatusBadRequest, 2, fmt.Sprintf("Caching all resources over HTTPS is a risky operation, set the %s header to confirm it", FORCE_RISKY_OP_HEADER_NAME))
+			return
+		}
+		settings.Performance = &performance
+	default:
+		m.writeV2ErrorResponse(w, http.StatusMethodNotAllowed, 9999, fmt.Sprintf("Method not allowed: %s", r.Method))
+		return
+	}
+	m.writeJSONResponse(w, settings.Performance)
+}
+
+// validateMockPerformanceSettings validates cache settings like the API does. It returns the error message.
+func validateMockPerformanceSettings(performance *PerformanceSettings) string {
+	if !contains([]string{"disabled", "custom_cache_rules_only", "standard", "smart", "all_resources"}, performance.Mode.Level) {
+		return fmt.Sprintf("Invalid mode level: %s", performance.Mode.Level)
+	}
+	if !contains([]string{"disabled", "dont_include_html", "include_html", "include_all_resources"}, performance.Mode.HTTPS) {
+		return fmt.Sprintf("Invalid mode https: %s", performance.Mode.HTTPS)
+	}
+	if performance.Mode.Time < 0 {
+		return fmt.Sprintf("Invalid mode time: %d", performance.Mode.Time)
+	}
+	cache404 := performance.Response.Cache404
+	if cache404.Time < 0 || cache404.Time%60 != 0 {
+		return fmt.Sprintf("The time to cache 404 responses must be a number of minutes, got %d seconds", cache404.Time)
+	}
+	if !contains([]string{"disabled", "custom", "all"}, performance.Response.CacheResponseHeader.Mode) {
+		return fmt.Sprintf("Invalid cache response header mode: %s", performance.Response.CacheResponseHeader.Mode)
+	}
+	for _, header := range performance.Response.CacheResponseHeader.Headers {
+		if name, ok := header.(string); !ok || name == "" {
+			return fmt.Sprintf("Invalid cache response header: %v", header)
+		}
+	}
+	if performance.Response.CacheResponseHeader.Headers == nil {
+		performance.Response.CacheResponseHeader.Headers = []interface{}{}
+	}
+	if !contains([]string{"disabled", "adaptive", "custom"}, performance.Response.StaleContent.Mode) {
+		return fmt.Sprintf("Invalid stale content mode: %s", performance.Response.StaleContent.Mode)
+	}
+	if performance.Response.StaleContent.Time < 0 {
+		return fmt.Sprintf("Invalid stale content time: %d", performance.Response.StaleContent.Time)
+	}
+	return ""
+}
+
+// TXT Records Handlers
+
+// handleTXTRecords handles GET/POST/DELETE /sites/{siteId}/settings/general/additionalTxtRecords and
+// DELETE /sites/{siteId}/settings/general/additionalTxtRecords/delete-all. A POST sets the records it has a value for,
+// a DELETE removes the record of the record_number query param.
+func (m *MockImpervaServer) handleTXTRecords(w http.ResponseWriter, r *http.Request, path string) {
+	matches := mockTXTRecordsPattern.FindStringSubmatch(path)
+	siteID, _ := strconv.Atoi(matches[1])
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.sites[siteID]; !exists {
+		m.writeV2ErrorResponse(w, http.StatusNotFound, 9413, "Unknown/unauthorized site_id")
+		return
+	}
+	settings := m.siteSettingsOf(siteID)
+
+	if matches[2] != "" {
+		if r.Method != http.MethodDelete {
+			m.writeV2ErrorResponse(w, http.StatusMethodNotAllowed, 9999, fmt.Sprintf("Method not allowed: %s", r.Method))
+			return
+		}
+		settings.TXTRecords = [5]string{}
+		m.writeTXTRecordsResponse(w)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		// The API answers with a message rather than an error when there are no records
+		response := map[string]interface{}{"site_id": siteID, "res": 0, "res_message": fmt.Sprintf("Site %d has no TXT records", siteID)}
+		for i, value := range settings.TXTRecords {
+			if value != "" {
+				response[mockTXTRecordParams[i]] = value
+				response["res_message"] = "OK"
+			}
+		}
+		m.writeJSONResponse(w, response)
+	case http.MethodPost:
+		r.ParseForm()
+		records := settings.TXTRecords
+		updated := false
+		for i, param := range mockTXTRecordParams {
+			value := r.PostForm.Get(param)
+			if value == "" {
+				continue
+			}
+			if len(value) > 255 {
+				m.writeV2ErrorResponse(w, http.StatusBadRequest, 2, fmt.Sprintf("%s exceeds 255 characters", param))
+				return
+			}
+			records[i] = value
+			updated = true
+		}
+		if !updated {
+			m.writeV2ErrorResponse(w, http.StatusBadRequest, 2, "At least one TXT record value is required")
+			return
+		}
+		settings.TXTRecords = records
+		m.writeTXTRecordsResponse(w)
+	case http.MethodDelete:
+		recordNumber, err := strconv.Atoi(r.URL.Query().Get("record_number"))
+		if err != nil || recordNumber < 1 || recordNumber > len(settings.TXTRecords) {
+			m.writeV2ErrorResponse(w, http.StatusBadRequest, 2, fmt.Sprintf("Invalid record_number: %s", r.URL.Query().Get("record_number")))
+			return
+		}
+		settings.TXTRecords[recordNumber-1] = ""
+		m.writeTXTRecordsResponse(w)
+	default:
+		m.writeV2ErrorResponse(w, http.StatusMethodNotAllowed, 9999, fmt.Sprintf("Method not allowed: %s", r.Method))
+	}
+}
+
+func (m *MockImpervaServer) writeTXTRecordsResponse(w http.ResponseWriter) {
+	m.writeJSONResponse(w, map[string]interface{}{
+		"res":         0,
+		"res_message": "OK",
+		"debug_info":  map[string]interface{}{"id-info": "13007"},
+	})
+}
+
+// Site Monitoring Handlers
+
+// handleSiteMonitoring handles GET/POST /appdlv-site-settings/v2/site/{siteId}/monitoring
+func (m *MockImpervaServer) handleSiteMonitoring(w http.ResponseWriter, r *http.Request, path string) {
+	matches := mockSiteMonitoringPattern.FindStringSubmatch(path)
+	siteID, _ := strconv.Atoi(matches[1])
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Sites without a load balancing subscription are not found, which is the case of unknown sites
+	if _, exists := m.sites[siteID]; !exists {
+		m.writeV3ErrorResponse(w, http.StatusNotFound, "", fmt.Sprintf("Site %d not found", siteID))
+		return
+	}
+	settings := m.siteSettingsOf(siteID)
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		var monitoring SiteMonitoring
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := mockMergeDocument(settings.Monitoring, body, &monitoring); err != nil {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "", fmt.Sprintf("Invalid request body: %s", err))
+			return
+		}
+		if pointer, message := validateMockSiteMonitoring(&monitoring); message != "" {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, pointer, message)
+			return
+		}
+		settings.Monitoring = &monitoring
+	default:
+		m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+		return
+	}
+	m.writeJSONResponse(w, SiteMonitoringResponse{Data: []SiteMonitoring{*settings.Monitoring}})
+}
+
+// validateMockSiteMonitoring validates monitoring settings like the API does, durations being bounded according to
+// their units. It returns the JSON pointer of the field at fault and the error message.
+func validateMockSiteMonitoring(monitoring *SiteMonitoring) (string, string) {
+	parameters := monitoring.MonitoringParameters
+	if parameters.FailedRequestsPercentage < 0 || parameters.FailedRequestsPercentage > 100 {
+		return "/monitoringParameters/failedRequestsPercentage", "failedRequestsPercentage must be between 0 and 100"
+	}
+	if parameters.FailedRequestsMinNumber < 1 || parameters.FailedRequestsMinNumber > 500 {
+		return "/monitoringParameters/failedRequestsMinNumber", "failedRequestsMinNumber must be between 1 and 500"
+	}
+	durations := []struct {
+		pointer    string
+		name       string
+		value      int
+		units      string
+		minSeconds int
+		maxSeconds int
+	}{
+		{"/monitoringParameters/failedRequestsDuration", "failedRequestsDuration", parameters.FailedRequestsDuration, parameters.FailedRequestsDurationUnits, 20, 180},
+		{"/failedRequestCriteria/httpRequestTimeout", "httpRequestTimeout", monitoring.FailedRequestCriteria.HttpRequestTimeout, monitoring.FailedRequestCriteria.HttpRequestTimeoutUnits, 1, 200},
+		{"/upDownVerification/upChecksInterval", "upChecksInterval", monitoring.UpDownVerification.UpChecksInterval, monitoring.UpDownVerification.UpChecksIntervalUnits, 10, 120},
+	}
+	for _, duration := range durations {
+		switch duration.units {
+		case "SECONDS":
+			if duration.value < duration.minSeconds || duration.value > duration.maxSeconds {
+				return duration.pointer, fmt.Sprintf("%s must be between %d and %d SECONDS", duration.name, duration.minSeconds, duration.maxSeconds)
+			}
+		case "MINUTES":
+			if duration.value < 1 || duration.value > 2 {
+				return duration.pointer, fmt.Sprintf("%s must be between 1 and 2 MINUTES", duration.name)
+			}
+		default:
+			return duration.pointer + "Units", fmt.Sprintf("Invalid %sUnits: %s", duration.name, duration.units)
+		}
+	}
+	if retries := monitoring.UpDownVerification.UpCheckRetries; retries < 1 || retries > 50 {
+		return "/upDownVerification/upCheckRetries", "upCheckRetries must be between 1 and 50"
+	}
+	if !contains([]string{"ONE", "MANY", "MOST", "ALL"}, monitoring.Notifications.RequiredMonitors) {
+		return "/notifications/requiredMonitors", fmt.Sprintf("Invalid requiredMonitors: %s", monitoring.Notifications.RequiredMonitors)
+	}
+	return "", ""
+}
+
+// Site SSL Settings Handlers
+
+// handleSiteSSLSettings handles GET/PATCH /sites-mgmt/v3/sites/{siteId}/settings/TLSConfiguration. A PATCH replaces
+// the settings it sends, HSTS or inbound TLS settings, as a whole.
+func (m *MockImpervaServer) handleSiteSSLSettings(w http.ResponseWriter, r *http.Request, path string) {
+	matches := mockSiteSSLSettingsPattern.FindStringSubmatch(path)
+	siteID, _ := strconv.Atoi(matches[1])
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	site, exists := m.sites[siteID]
+	if !exists || !m.ownedByRequest(r, siteAccountID(site)) {
+		m.writeV3ErrorResponse(w, http.StatusNotFound, "", fmt.Sprintf("Site %d not found", siteID))
+		return
+	}
+	settings := m.siteSettingsOf(siteID)
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPatch:
+		var request struct {
+			Data []mockSSLSettingsPatch `json:"data"`
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &request); err != nil {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "", fmt.Sprintf("Invalid request body: %s", err))
+			return
+		}
+		if len(request.Data) != 1 {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "/data", "Exactly one settings object is expected")
+			return
+		}
+		patch := request.Data[0]
+		ssl := *settings.SSL
+		if patch.HstsConfiguration != nil {
+			ssl.HstsConfiguration = patch.HstsConfiguration
+		}
+		if patch.InboundTLSSettingsConfiguration != nil {
+			ssl.InboundTLSSettingsConfiguration = patch.InboundTLSSettingsConfiguration
+		}
+		if patch.DisablePQCSupport != nil {
+			ssl.DisablePQCSupport = *patch.DisablePQCSupport
+		}
+		if pointer, message := validateMockSSLSettings(&ssl); message != "" {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, pointer, message)
+			return
+		}
+		settings.SSL = &ssl
+	default:
+		m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+		return
+	}
+	m.writeJSONResponse(w, SSLSettingsResponse{Data: []SSLSettingsDTO{*settings.SSL}})
+}
+
+// validateMockSSLSettings validates TLS settings like the API does. It returns the JSON pointer of the field at fault
+// and the error message.
+func validateMockSSLSettings(ssl *SSLSettingsDTO) (string, string) {
+	hsts := ssl.HstsConfiguration
+	if hsts.IsEnabled && hsts.MaxAge <= 0 {
+		return "/data/0/hstsConfiguration/maxAge", "maxAge must be positive when HSTS is enabled"
+	}
+	// These are the requirements of the HSTS preload list
+	if hsts.PreLoaded && (!hsts.IsEnabled || !hsts.SubDomainsIncluded || hsts.MaxAge < 31536000) {
+		return "/data/0/hstsConfiguration/preLoaded", "Preloading requires HSTS to be enabled, sub domains to be included and a maxAge of at least 31536000"
+	}
+
+	inbound := ssl.InboundTLSSettingsConfiguration
+	if !contains([]string{"DEFAULT", "ENHANCED_SECURITY", "CUSTOM"}, inbound.ConfigurationProfile) {
+		return "/data/0/inboundTlsSettings/configurationProfile", fmt.Sprintf("Invalid configurationProfile: %s", inbound.ConfigurationProfile)
+	}
+	if inbound.ConfigurationProfile != "CUSTOM" && len(inbound.TLSConfigurations) > 0 {
+		return "/data/0/inboundTlsSettings/tlsConfiguration", "tlsConfiguration is only applicable to the CUSTOM configuration profile"
+	}
+	if inbound.ConfigurationProfile == "CUSTOM" && len(inbound.TLSConfigurations) == 0 {
+		return "/data/0/inboundTlsSettings/tlsConfiguration", "tlsConfiguration is required for the CUSTOM configuration profile"
+	}
+	versions := map[string]bool{}
+	for i, configuration := range inbound.TLSConfigurations {
+		pointer := fmt.Sprintf("/data/0/inboundTlsSettings/tlsConfiguration/%d", i)
+		if !contains([]string{"TLS_1_0", "TLS_1_1", "TLS_1_2", "TLS_1_3"}, configuration.TLSVersion) {
+			return pointer + "/tlsVersion", fmt.Sprintf("Invalid tlsVersion: %s", configuration.TLSVersion)
+		}
+		if versions[configuration.TLSVersion] {
+			return pointer + "/tlsVersion", fmt.Sprintf("Duplicate tlsVersion: %s", configuration.TLSVersion)
+		}
+		versions[configuration.TLSVersion] = true
+		if len(configuration.CiphersSupport) == 0 {
+			return pointer + "/ciphersSupport", fmt.Sprintf("At least one cipher is required for %s", configuration.TLSVersion)
+		}
+		for j, cipher := range configuration.CiphersSupport {
+			if !strings.HasPrefix(cipher, "TLS_") || contains(mockTLS13Ciphers, cipher) != (configuration.TLSVersion == "TLS_1_3") {
+				return fmt.Sprintf("%s/ciphersSupport/%d", pointer, j), fmt.Sprintf("Cipher %s is not supported by %s", cipher, configuration.TLSVersion)
+			}
+		}
+	}
+	if inbound.TLSConfigurations == nil {
+		inbound.TLSConfigurations = []TLSConfiguration{}
+	}
+	return "", ""
+}
+
+// Helper methods for tests
+
+// GetSiteSettings returns the settings of a site, nil for an unknown site (for test assertions)
+func (m *MockImpervaServer) GetSiteSettings(siteID int) *MockSiteSettings {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, exists := m.sites[siteID]; !exists {
+		return nil
+	}
+	return m.siteSettingsOf(siteID)
+}
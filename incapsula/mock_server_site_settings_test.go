@@ -0,0 +1,368 @@
+package incapsula
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testMockSettingsRequest sends a JSON request to a settings endpoint of the mock server, returning the status code
+// and the response body
+func testMockSettingsRequest(t *testing.T, client *Client, method, url, body string, headers map[string]string) (int, string) {
+	resp, err := client.DoJsonRequestWithCustomHeaders(context.Background(), method, url, []byte(body), headers, "mock_settings")
+	if err != nil {
+		t.Fatalf("Unexpected error sending %s %s: %s", method, url, err)
+	}
+	defer resp.Body.Close()
+	responseBody, _ := ioutil.ReadAll(resp.Body)
+	return resp.StatusCode, string(responseBody)
+}
+
+func TestMockApplicationDelivery(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	site := &MockSite{Domain: "delivery.example.com"}
+	mock.AddSite(site)
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+
+	// Settings are defaulted until updated, HTTP/2 settings that aren't sent are kept
+	delivery, err := client.GetApplicationDelivery(ctx, site.SiteID)
+	if err != nil || delivery.Compression.CompressionType != "GZIP" || !*delivery.Network.EnableHttp2 || delivery.Network.Port.To != "80" {
+		t.Fatalf("Expected the default application delivery, got %+v %v", delivery, err)
+	}
+	delivery.Compression.CompressionType = "BROTLI"
+	delivery.Network.Port.To = "8080"
+	delivery.Network.EnableHttp2, delivery.Network.Http2ToOrigin = nil, nil
+	delivery.Redirection.RedirectHttpToHttps = true
+	delivery, err = client.UpdateApplicationDelivery(ctx, site.SiteID, delivery)
+	if err != nil {
+		t.Fatalf("Unexpected error updating application delivery: %v", err)
+	}
+	if delivery.Compression.CompressionType != "BROTLI" || delivery.Network.Port.To != "8080" || !delivery.Redirection.RedirectHttpToHttps {
+		t.Errorf("Unexpected application delivery: %+v", delivery)
+	}
+	if delivery.Network.EnableHttp2 == nil || !*delivery.Network.EnableHttp2 || *delivery.Network.Http2ToOrigin || delivery.Network.SslPort.To != "443" {
+		t.Errorf("Expected the settings that weren't sent to be kept, got %+v", delivery.Network)
+	}
+
+	// The API rejects HTTP/2 to the origin without HTTP/2, and invalid ports
+	http2, http2ToOrigin := false, true
+	invalid := *delivery
+	invalid.Network.EnableHttp2, invalid.Network.Http2ToOrigin = &http2, &http2ToOrigin
+	if _, err := client.UpdateApplicationDelivery(ctx, site.SiteID, &invalid); err == nil || !strings.Contains(err.Error(), "HTTP/2 to Origin") {
+		t.Errorf("Expected an error enabling HTTP/2 to the origin only, got %v", err)
+	}
+	invalid = *delivery
+	invalid.Network.Port.To = "70000"
+	if _, err := client.UpdateApplicationDelivery(ctx, site.SiteID, &invalid); err == nil {
+		t.Errorf("Expected an error for an invalid port")
+	}
+
+	invalid = *delivery
+	invalid.Compression.CompressionType = "DEFLATE"
+	if _, err := client.UpdateApplicationDelivery(ctx, site.SiteID, &invalid); err == nil {
+		t.Errorf("Expected an error for an invalid compression type")
+	}
+
+	// Error pages are replaced as a whole
+	errorPages := &CustomErrorPage{DefaultErrorPage: "<html>$TITLE$</html>"}
+	errorPages.CustomErrorPageTemplates.ErrorAccessDenied = "<html>denied</html>"
+	if _, err := client.UpdateErrorPages(ctx, site.SiteID, errorPages); err != nil {
+		t.Fatalf("Unexpected error updating error pages: %v", err)
+	}
+	if _, err := client.UpdateErrorPages(ctx, site.SiteID, &CustomErrorPage{DefaultErrorPage: "<html>$BODY$</html>"}); err != nil {
+		t.Fatalf("Unexpected error replacing error pages: %v", err)
+	}
+	errorPages, err = client.GetErrorPages(ctx, site.SiteID)
+	if err != nil || errorPages.DefaultErrorPage != "<html>$BODY$</html>" || errorPages.CustomErrorPageTemplates.ErrorAccessDenied != "" {
+		t.Errorf("Expected the error pages to be replaced, got %+v %v", errorPages, err)
+	}
+
+	// Deleting the settings restores the defaults
+	if _, err := client.DeleteApplicationDelivery(ctx, site.SiteID); err != nil {
+		t.Fatalf("Unexpected error deleting application delivery: %v", err)
+	}
+	if settings := mock.GetSiteSettings(site.SiteID); !reflect.DeepEqual(settings.Delivery, newMockApplicationDelivery()) {
+		t.Errorf("Expected the default application delivery after delete, got %+v", settings.Delivery)
+	}
+	if _, err := client.GetApplicationDelivery(ctx, 42); !IsNotFound(err) {
+		t.Errorf("Expected a not found error for an unknown site, got %v", err)
+	}
+}
+
+func TestMockCacheSettings(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	site := &MockSite{Domain: "cache.example.com"}
+	mock.AddSite(site)
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+	siteID := strconv.Itoa(site.SiteID)
+
+	res := resourceSiteCacheConfiguration()
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"site_id":                             site.SiteID,
+		"mode_level":                          "all_resources",
+		"mode_https":                          "include_all_resources",
+		"mode_time":                           3600,
+		"response_cache_404_enabled":          true,
+		"response_cache_404_time":             120,
+		"response_cache_response_header_mode": "custom",
+		"response_cache_response_headers":     []interface{}{"Access-Control-Allow-Origin"},
+	})
+	if diags := resourceApplicationPerformanceUpdate(ctx, d, client); diags.HasError() {
+		t.Fatalf("Unexpected error applying cache configuration: %v", diags)
+	}
+	if d.Id() != siteID || d.Get("mode_time") != 3600 || d.Get("response_cache_404_time") != 120 || d.Get("response_cache_response_headers").(*schema.Set).Len() != 1 {
+		t.Errorf("Unexpected cache configuration state: %v", d.State())
+	}
+
+	// Updates are partial, and caching everything over HTTPS must be forced
+	url := mock.URL() + "/sites/" + siteID + "/settings/cache"
+	if status, body := testMockSettingsRequest(t, client, http.MethodPut, url, `{"mode":{"level":"all_resources"}}`, nil); status != http.StatusBadRequest || !strings.Contains(body, "risky") {
+		t.Errorf("Expected the risky operation to be rejected without its header, got %d %s", status, body)
+	}
+	forced := map[string]string{FORCE_RISKY_OP_HEADER_NAME: "true"}
+	if status, body := testMockSettingsRequest(t, client, http.MethodPut, url, `{"ttl":{"use_shortest_caching":true}}`, forced); status != http.StatusOK {
+		t.Fatalf("Unexpected error updating the TTL settings: %d %s", status, body)
+	}
+	performance, err := client.GetPerformanceSettings(ctx, siteID)
+	if err != nil || !performance.TTL.UseShortestCaching || performance.Mode.Time != 3600 || !performance.Response.Cache404.Enabled {
+		t.Errorf("Expected the other settings to be kept, got %+v %v", performance, err)
+	}
+
+	performance.Response.Cache404.Time = 90
+	if _, err := client.UpdatePerformanceSettings(ctx, siteID, performance); err == nil {
+		t.Errorf("Expected an error for a 404 caching time that isn't a number of minutes")
+	}
+	performance.Response.Cache404.Time = 60
+	performance.Response.StaleContent.Mode = "forever"
+	if _, err := client.UpdatePerformanceSettings(ctx, siteID, performance); err == nil {
+		t.Errorf("Expected an error for an invalid stale content mode")
+	}
+}
+
+func TestMockSiteMonitoring(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	site := &MockSite{Domain: "monitoring.example.com"}
+	mock.AddSite(site)
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+
+	res := resourceSiteMonitoring()
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"site_id":                        site.SiteID,
+		"failed_requests_duration":       2,
+		"failed_requests_duration_units": "MINUTES",
+		"required_monitors":              "ALL",
+	})
+	if diags := resourceSiteMonitoringUpdate(ctx, d, client); diags.HasError() {
+		t.Fatalf("Unexpected error applying site monitoring: %v", diags)
+	}
+	if d.Get("failed_requests_duration") != 2 || d.Get("required_monitors") != "ALL" || d.Get("monitoring_url") != "/" {
+		t.Errorf("Unexpected site monitoring state: %v", d.State())
+	}
+
+	// Updates are partial, down to the fields of each group
+	url := mock.URL() + "/appdlv-site-settings/v2/site/" + strconv.Itoa(site.SiteID) + "/monitoring"
+	if status, body := testMockSettingsRequest(t, client, http.MethodPost, url, `{"notifications":{"alarmOnServerFailover":true}}`, nil); status != http.StatusOK {
+		t.Fatalf("Unexpected error updating notifications: %d %s", status, body)
+	}
+	monitoring, err := client.GetSiteMonitoring(ctx, site.SiteID)
+	if err != nil || !monitoring.Data[0].Notifications.AlarmOnServerFailover || monitoring.Data[0].Notifications.RequiredMonitors != "ALL" {
+		t.Errorf("Expected the other notification settings to be kept, got %+v %v", monitoring, err)
+	}
+
+	// Durations are bounded according to their units
+	invalid := monitoring.Data[0]
+	invalid.MonitoringParameters.FailedRequestsDuration = 3
+	if _, err := client.UpdateSiteMonitoring(ctx, site.SiteID, &invalid); err == nil || !strings.Contains(err.Error(), "failedRequestsDuration must be between 1 and 2 MINUTES") {
+		t.Errorf("Expected an error for a duration of 3 minutes, got %v", err)
+	}
+	invalid = monitoring.Data[0]
+	invalid.UpDownVerification.UpChecksInterval = 5
+	if _, err := client.UpdateSiteMonitoring(ctx, site.SiteID, &invalid); err == nil {
+		t.Errorf("Expected an error for an up checks interval of 5 seconds")
+	}
+
+	// Unknown sites are treated like sites without load balancing subscription
+	if _, err := client.GetSiteMonitoring(ctx, 42); err == nil || !strings.Contains(err.Error(), "Missing Load Balancing subscription") {
+		t.Errorf("Expected a missing subscription error for an unknown site, got %v", err)
+	}
+}
+
+func TestMockSiteSSLSettings(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	site := &MockSite{Domain: "ssl.example.com"}
+	mock.AddSite(site)
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+
+	res := resourceSiteSSLSettings()
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"site_id": site.SiteID,
+		"hsts": []interface{}{map[string]interface{}{
+			"is_enabled":           true,
+			"max_age":              31536000,
+			"sub_domains_included": true,
+			"pre_loaded":           true,
+		}},
+		"inbound_tls_settings": []interface{}{map[string]interface{}{
+			"configuration_profile": "CUSTOM",
+			"tls_configuration": []interface{}{
+				map[string]interface{}{"tls_version": "TLS_1_2", "ciphers_support": []interface{}{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}},
+				map[string]interface{}{"tls_version": "TLS_1_3", "ciphers_support": []interface{}{"TLS_AES_128_GCM_SHA256"}},
+			},
+		}},
+	})
+	if diags := resourceSiteSSLSettingsUpdate(ctx, d, client); diags.HasError() {
+		t.Fatalf("Unexpected error applying SSL settings: %v", diags)
+	}
+	ssl := mock.GetSiteSettings(site.SiteID).SSL
+	if !ssl.HstsConfiguration.PreLoaded || ssl.InboundTLSSettingsConfiguration.ConfigurationProfile != "CUSTOM" || len(ssl.InboundTLSSettingsConfiguration.TLSConfigurations) != 2 {
+		t.Errorf("Unexpected stored SSL settings: %+v %+v", ssl.HstsConfiguration, ssl.InboundTLSSettingsConfiguration)
+	}
+
+	// A PATCH only replaces the settings it sends
+	pqc := SSLSettingsResponse{Data: []SSLSettingsDTO{{DisablePQCSupport: true}}}
+	if _, err := client.UpdateSiteSSLSettings(ctx, site.SiteID, 0, pqc); err != nil {
+		t.Fatalf("Unexpected error disabling PQC support: %s", err)
+	}
+	if ssl := mock.GetSiteSettings(site.SiteID).SSL; !ssl.DisablePQCSupport || !ssl.HstsConfiguration.IsEnabled {
+		t.Errorf("Expected the HSTS settings to be kept, got %+v", ssl.HstsConfiguration)
+	}
+
+	// HSTS preloading and custom TLS configurations are validated
+	invalid := []SSLSettingsDTO{
+		{HstsConfiguration: &HSTSConfiguration{IsEnabled: true, MaxAge: 3600, PreLoaded: true, SubDomainsIncluded: true}},
+		{InboundTLSSettingsConfiguration: &InboundTLSSettingsConfiguration{ConfigurationProfile: "CUSTOM"}},
+		{InboundTLSSettingsConfiguration: &InboundTLSSettingsConfiguration{ConfigurationProfile: "CUSTOM", TLSConfigurations: []TLSConfiguration{{TLSVersion: "TLS_1_2", CiphersSupport: []string{"TLS_AES_256_GCM_SHA384"}}}}},
+		{InboundTLSSettingsConfiguration: &InboundTLSSettingsConfiguration{ConfigurationProfile: "STRICT"}},
+	}
+	for _, settings := range invalid {
+		if _, err := client.UpdateSiteSSLSettings(ctx, site.SiteID, 0, SSLSettingsResponse{Data: []SSLSettingsDTO{settings}}); err == nil {
+			t.Errorf("Expected an error for invalid SSL settings %+v %+v", settings.HstsConfiguration, settings.InboundTLSSettingsConfiguration)
+		}
+	}
+
+	// Deleting the resource disables HSTS and restores the default TLS profile
+	if diags := resourceSiteSSLSettingsDelete(ctx, d, client); diags.HasError() {
+		t.Fatalf("Unexpected error deleting SSL settings: %v", diags)
+	}
+	settings, _, err := client.ReadSiteSSLSettings(ctx, site.SiteID, 0)
+	if err != nil || settings.Data[0].HstsConfiguration.IsEnabled || settings.Data[0].InboundTLSSettingsConfiguration.ConfigurationProfile != "DEFAULT" {
+		t.Errorf("Expected HSTS disabled and the default TLS profile, got %+v %v", settings, err)
+	}
+	if _, _, err := client.ReadSiteSSLSettings(ctx, 42, 0); !IsNotFound(err) {
+		t.Errorf("Expected a not found error for an unknown site, got %v", err)
+	}
+}
+
+func TestMockTXTRecords(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	site := &MockSite{Domain: "txt.example.com"}
+	mock.AddSite(site)
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+
+	res := resourceTXTRecord()
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"site_id":                site.SiteID,
+		"txt_record_value_one":   "v=spf1 -all",
+		"txt_record_value_three": "verification=mock",
+	})
+	if diags := resourceTXTRecordCreate(ctx, d, client); diags.HasError() {
+		t.Fatalf("Unexpected error creating TXT records: %v", diags)
+	}
+	if d.Get("txt_record_value_one") != "v=spf1 -all" || d.Get("txt_record_value_three") != "verification=mock" {
+		t.Errorf("Unexpected TXT records state: %v", d.State())
+	}
+
+	// Clearing a record deletes it, the other records are kept
+	state := d.State()
+	diff, err := schema.InternalMap(res.Schema).Diff(ctx, state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"site_id":              site.SiteID,
+		"txt_record_value_one": "v=spf1 -all",
+	}), nil, client, true)
+	if err != nil {
+		t.Fatalf("Unexpected error computing diff: %s", err)
+	}
+	updated, err := schema.InternalMap(res.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("Unexpected error applying diff: %s", err)
+	}
+	if diags := resourceTXTRecordUpdate(ctx, updated, client); diags.HasError() {
+		t.Fatalf("Unexpected error updating TXT records: %v", diags)
+	}
+	if records := mock.GetSiteSettings(site.SiteID).TXTRecords; records != [5]string{"v=spf1 -all"} {
+		t.Errorf("Expected only the first record to be left, got %q", records)
+	}
+
+	// Values are limited to 255 characters
+	if _, err := client.UpdateTXTRecord(ctx, site.SiteID, "", strings.Repeat("x", 256), "", "", ""); err == nil {
+		t.Errorf("Expected an error for a record of 256 characters")
+	}
+
+	if diags := resourceTXTRecordDelete(ctx, updated, client); diags.HasError() {
+		t.Fatalf("Unexpected error deleting TXT records: %v", diags)
+	}
+	if _, err := client.ReadTXTRecords(ctx, site.SiteID); err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("Expected the TXT records to be gone, got %v", err)
+	}
+	if _, err := client.ReadTXTRecords(ctx, 42); !IsNotFound(err) {
+		t.Errorf("Expected a not found error for an unknown site, got %v", err)
+	}
+}
+
+func TestMockSiteSettingsState(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	site := &MockSite{Domain: "seed.example.com"}
+	mock.AddSite(site)
+
+	// Seeds only need the documents they set, the others have their defaults
+	var state MockState
+	seed := `{"sites":[{"site_id":` + strconv.Itoa(site.SiteID) + `,"domain":"seed.example.com"}],
+		"site_settings":{"` + strconv.Itoa(site.SiteID) + `":{"monitoring":{"notifications":{"requiredMonitors":"ONE"}},"txt_records":["seeded"]}}}`
+	if err := json.Unmarshal([]byte(seed), &state); err != nil {
+		t.Fatalf("Unexpected error parsing the seed: %s", err)
+	}
+	if err := mock.LoadState(&state); err != nil {
+		t.Fatalf("Unexpected error loading the seed: %s", err)
+	}
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	records, err := client.ReadTXTRecords(context.Background(), site.SiteID)
+	if err != nil || records.TxtRecordValueOne != "seeded" {
+		t.Errorf("Expected the seeded TXT record, got %+v %v", records, err)
+	}
+	delivery, err := client.GetApplicationDelivery(context.Background(), site.SiteID)
+	if err != nil || delivery.Compression.CompressionType != "GZIP" {
+		t.Errorf("Expected the default delivery settings, got %+v %v", delivery, err)
+	}
+
+	// Site settings are deleted with their site
+	if err := client.DeleteSite(context.Background(), site.Domain, site.SiteID); err != nil {
+		t.Fatalf("Unexpected error deleting site: %s", err)
+	}
+	if snapshot := mock.Snapshot(); len(snapshot.SiteSettings) != 0 {
+		t.Errorf("Expected no site settings left, got %+v", snapshot.SiteSettings)
+	}
+}
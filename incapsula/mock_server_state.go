@@ -0,0 +1,399 @@
+// Mock Imperva API Server - state snapshots
+//
+// The state of the mock server can be dumped to and loaded from JSON, so that the standalone server can start from
+// a seed file and keep its state across restarts. Snapshots are valid seed files; seed files written by hand only
+// need the parts of the state they preload.
+
+package incapsula
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+)
+
+// MockState is the state of the mock server: its data and its ID generators. Objects are keyed by site ID where
+// they belong to a site.
+type MockState struct {
+	Accounts               []*MockAccount                        `json:"accounts,omitempty"`
+	APIKeys                []*MockAPIKey                         `json:"api_keys,omitempty"`
+	Sites                  []*MockSite                           `json:"sites,omitempty"`
+	CSPDomains             map[int][]*MockCSPDomain              `json:"csp_domains,omitempty"`
+	IncapRules             map[int][]*IncapRuleWithID            `json:"incap_rules,omitempty"`
+	CacheRules             map[int][]*CacheRuleWithID            `json:"cache_rules,omitempty"`
+	DeliveryRules          map[int]map[string][]DeliveryRuleDto  `json:"delivery_rules,omitempty"`
+	Policies               []*Policy                             `json:"policies,omitempty"`
+	PolicyAssets           map[string][]int                      `json:"policy_assets,omitempty"`
+	AccountPolicies        []*AccountPolicyAssociationV3         `json:"account_policies,omitempty"`
+	DataCenters            map[int]*MockDataCentersConfiguration `json:"data_centers,omitempty"`
+	SiemConnections        []*MockSiemConnection                 `json:"siem_connections,omitempty"`
+	SiemLogConfigurations  []*MockSiemLogConfiguration           `json:"siem_log_configurations,omitempty"`
+	CustomCertificates     map[int][]*MockCustomCertificate      `json:"custom_certificates,omitempty"`
+	ManagedCertificates    map[int]*SiteCertificateDTO           `json:"managed_certificates,omitempty"`
+	MTLSOriginCertificates []*MockMTLSCertificate                `json:"mtls_origin_certificates,omitempty"`
+	MTLSOriginSites        map[int]int                           `json:"mtls_origin_sites,omitempty"`
+	ClientCaCertificates   []*MockClientCaCertificate            `json:"client_ca_certificates,omitempty"`
+	ClientCaSites          map[int][]int                         `json:"client_ca_sites,omitempty"`
+	SiteTlsSettings        map[int]*SiteTlsSettings              `json:"site_tls_settings,omitempty"`
+	Roles                  []*MockRole                           `json:"roles,omitempty"`
+	Users                  []*MockUser                           `json:"users,omitempty"`
+	APIClients             []*MockAPIClient                      `json:"api_clients,omitempty"`
+	SiteSettings           map[int]*MockSiteSettings             `json:"site_settings,omitempty"`
+	WaitingRooms           []*MockWaitingRoom                    `json:"waiting_rooms,omitempty"`
+	NextIDs                *MockNextIDs                          `json:"next_ids,omitempty"`
+}
+
+// MockNextIDs are the next IDs of the ID generators of the mock server. When loading a state, each generator starts
+// after the highest ID of the state if it is greater.
+type MockNextIDs struct {
+	Account      int `json:"account"`
+	Site         int `json:"site"`
+	Rule         int `json:"rule"`
+	Policy       int `json:"policy"`
+	DataCenter   int `json:"data_center"`
+	OriginServer int `json:"origin_server"`
+	Siem         int `json:"siem"`
+	Certificate  int `json:"certificate"`
+	Role         int `json:"role"`
+	User         int `json:"user"`
+	APIClient    int `json:"api_client"`
+	WaitingRoom  int `json:"waiting_room"`
+}
+
+// Snapshot returns a deep copy of the state of the mock server, objects being sorted by ID
+func (m *MockImpervaServer) Snapshot() *MockState {
+	m.mu.RLock()
+	state := &MockState{
+		CSPDomains:          make(map[int][]*MockCSPDomain),
+		IncapRules:          make(map[int][]*IncapRuleWithID),
+		CacheRules:          make(map[int][]*CacheRuleWithID),
+		DeliveryRules:       m.deliveryRules,
+		PolicyAssets:        make(map[string][]int),
+		DataCenters:         m.dataCenters,
+		CustomCertificates:  make(map[int][]*MockCustomCertificate),
+		ManagedCertificates: m.managedCertificates,
+		MTLSOriginSites:     m.mtlsOriginSites,
+		ClientCaSites:       make(map[int][]int),
+		SiteTlsSettings:     m.siteTlsSettings,
+		SiteSettings:        m.siteSettings,
+		NextIDs: &MockNextIDs{
+			Account:      m.nextAccountID,
+			Site:         m.nextSiteID,
+			Rule:         m.nextRuleID,
+			Policy:       m.nextPolicyID,
+			DataCenter:   m.nextDataCenterID,
+			OriginServer: m.nextOriginServerID,
+			Siem:         m.nextSiemID,
+			Certificate:  m.nextCertificateID,
+			Role:         m.nextRoleID,
+			User:         m.nextUserID,
+			APIClient:    m.nextAPIClientID,
+			WaitingRoom:  m.nextWaitingRoomID,
+		},
+	}
+	for _, account := range m.accounts {
+		state.Accounts = append(state.Accounts, account)
+	}
+	sort.Slice(state.Accounts, func(i, j int) bool { return state.Accounts[i].AccountID < state.Accounts[j].AccountID })
+	for _, key := range m.apiKeys {
+		state.APIKeys = append(state.APIKeys, key)
+	}
+	sort.Slice(state.APIKeys, func(i, j int) bool { return state.APIKeys[i].APIID < state.APIKeys[j].APIID })
+	for _, site := range m.sites {
+		state.Sites = append(state.Sites, site)
+	}
+	sort.Slice(state.Sites, func(i, j int) bool { return state.Sites[i].SiteID < state.Sites[j].SiteID })
+	for siteID, domains := range m.cspDomains {
+		for _, domain := range domains {
+			state.CSPDomains[siteID] = append(state.CSPDomains[siteID], domain)
+		}
+		sort.Slice(state.CSPDomains[siteID], func(i, j int) bool {
+			return state.CSPDomains[siteID][i].Domain < state.CSPDomains[siteID][j].Domain
+		})
+	}
+	for siteID, rules := range m.incapRules {
+		for _, rule := range rules {
+			state.IncapRules[siteID] = append(state.IncapRules[siteID], rule)
+		}
+		sort.Slice(state.IncapRules[siteID], func(i, j int) bool {
+			return state.IncapRules[siteID][i].RuleID < state.IncapRules[siteID][j].RuleID
+		})
+	}
+	for siteID, rules := range m.cacheRules {
+		for _, rule := range rules {
+			state.CacheRules[siteID] = append(state.CacheRules[siteID], rule)
+		}
+		sort.Slice(state.CacheRules[siteID], func(i, j int) bool {
+			return state.CacheRules[siteID][i].RuleID < state.CacheRules[siteID][j].RuleID
+		})
+	}
+	for _, policy := range m.policies {
+		state.Policies = append(state.Policies, policy)
+	}
+	sort.Slice(state.Policies, func(i, j int) bool { return state.Policies[i].ID < state.Policies[j].ID })
+	for asset, policyIDs := range m.policyAssets {
+		state.PolicyAssets[asset] = mockSortedKeys(policyIDs)
+	}
+	for _, association := range m.accountPolicies {
+		state.AccountPolicies = append(state.AccountPolicies, association)
+	}
+	sort.Slice(state.AccountPolicies, func(i, j int) bool {
+		return state.AccountPolicies[i].AccountID < state.AccountPolicies[j].AccountID
+	})
+	for _, connection := range m.siemConnections {
+		state.SiemConnections = append(state.SiemConnections, connection)
+	}
+	sort.Slice(state.SiemConnections, func(i, j int) bool { return state.SiemConnections[i].ID < state.SiemConnections[j].ID })
+	for _, logConfiguration := range m.siemLogConfigurations {
+		state.SiemLogConfigurations = append(state.SiemLogConfigurations, logConfiguration)
+	}
+	sort.Slice(state.SiemLogConfigurations, func(i, j int) bool {
+		return state.SiemLogConfigurations[i].ID < state.SiemLogConfigurations[j].ID
+	})
+	for siteID, certificates := range m.customCertificates {
+		for _, authType := range []string{"RSA", "ECC"} {
+			if certificate, exists := certificates[authType]; exists {
+				state.CustomCertificates[siteID] = append(state.CustomCertificates[siteID], certificate)
+			}
+		}
+	}
+	for _, certificate := range m.mtlsOriginCertificates {
+		state.MTLSOriginCertificates = append(state.MTLSOriginCertificates, certificate)
+	}
+	sort.Slice(state.MTLSOriginCertificates, func(i, j int) bool {
+		return state.MTLSOriginCertificates[i].ID < state.MTLSOriginCertificates[j].ID
+	})
+	for _, certificate := range m.clientCaCertificates {
+		state.ClientCaCertificates = append(state.ClientCaCertificates, certificate)
+	}
+	sort.Slice(state.ClientCaCertificates, func(i, j int) bool {
+		return state.ClientCaCertificates[i].ID < state.ClientCaCertificates[j].ID
+	})
+	for siteID, certificateIDs := range m.clientCaSites {
+		state.ClientCaSites[siteID] = mockSortedKeys(certificateIDs)
+	}
+	for _, role := range m.roles {
+		state.Roles = append(state.Roles, role)
+	}
+	sort.Slice(state.Roles, func(i, j int) bool { return state.Roles[i].RoleID < state.Roles[j].RoleID })
+	state.Users = m.sortedUsers()
+	state.APIClients = m.sortedAPIClients()
+	for _, waitingRoom := range m.waitingRooms {
+		state.WaitingRooms = append(state.WaitingRooms, waitingRoom)
+	}
+	sort.Slice(state.WaitingRooms, func(i, j int) bool { return state.WaitingRooms[i].Id < state.WaitingRooms[j].Id })
+
+	// The state shares the objects of the mock server until it is copied, under the lock
+	encoded, err := json.Marshal(state)
+	m.mu.RUnlock()
+	if err != nil {
+		panic(fmt.Sprintf("Error encoding the mock server state: %s", err))
+	}
+	var snapshot MockState
+	if err := json.Unmarshal(encoded, &snapshot); err != nil {
+		panic(fmt.Sprintf("Error decoding the mock server state: %s", err))
+	}
+	return &snapshot
+}
+
+// mockSortedKeys returns the keys of a set of IDs in increasing order
+func mockSortedKeys(set map[int]bool) []int {
+	keys := []int{}
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+// LoadState replaces the data of the mock server with the state. Faults and journaled requests are kept. The state
+// is loaded as is: for example, no default policy is applied to its sites.
+func (m *MockImpervaServer) LoadState(state *MockState) error {
+	// Work on a copy, the mock server must not share objects with the caller
+	encoded, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("Error encoding the state: %s", err)
+	}
+	state = &MockState{}
+	if err := json.Unmarshal(encoded, state); err != nil {
+		return fmt.Errorf("Error decoding the state: %s", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.resetState()
+	if state.NextIDs != nil {
+		mockBumpID(&m.nextAccountID, state.NextIDs.Account-1)
+		mockBumpID(&m.nextSiteID, state.NextIDs.Site-1)
+		mockBumpID(&m.nextRuleID, state.NextIDs.Rule-1)
+		mockBumpID(&m.nextPolicyID, state.NextIDs.Policy-1)
+		mockBumpID(&m.nextDataCenterID, state.NextIDs.DataCenter-1)
+		mockBumpID(&m.nextOriginServerID, state.NextIDs.OriginServer-1)
+		mockBumpID(&m.nextSiemID, state.NextIDs.Siem-1)
+		mockBumpID(&m.nextCertificateID, state.NextIDs.Certificate-1)
+		mockBumpID(&m.nextRoleID, state.NextIDs.Role-1)
+		mockBumpID(&m.nextUserID, state.NextIDs.User-1)
+		mockBumpID(&m.nextAPIClientID, state.NextIDs.APIClient-1)
+		mockBumpID(&m.nextWaitingRoomID, state.NextIDs.WaitingRoom-1)
+	}
+
+	for _, account := range state.Accounts {
+		m.accounts[account.AccountID] = account
+		mockBumpID(&m.nextAccountID, account.AccountID)
+	}
+	for _, key := range state.APIKeys {
+		m.apiKeys[key.APIID] = key
+	}
+	for _, site := range state.Sites {
+		m.sites[site.SiteID] = site
+		mockBumpID(&m.nextSiteID, site.SiteID)
+	}
+	for siteID, domains := range state.CSPDomains {
+		m.cspDomains[siteID] = make(map[string]*MockCSPDomain)
+		for _, domain := range domains {
+			m.cspDomains[siteID][domain.Domain] = domain
+		}
+	}
+	for siteID, rules := range state.IncapRules {
+		m.incapRules[siteID] = make(map[int]*IncapRuleWithID)
+		for _, rule := range rules {
+			m.incapRules[siteID][rule.RuleID] = rule
+			mockBumpID(&m.nextRuleID, rule.RuleID)
+		}
+	}
+	for siteID, rules := range state.CacheRules {
+		m.cacheRules[siteID] = make(map[int]*CacheRuleWithID)
+		for _, rule := range rules {
+			m.cacheRules[siteID][rule.RuleID] = rule
+			mockBumpID(&m.nextRuleID, rule.RuleID)
+		}
+	}
+	for siteID, categories := range state.DeliveryRules {
+		m.deliveryRules[siteID] = categories
+	}
+	for _, policy := range state.Policies {
+		m.policies[policy.ID] = policy
+		mockBumpID(&m.nextPolicyID, policy.ID)
+	}
+	for asset, policyIDs := range state.PolicyAssets {
+		m.policyAssets[asset] = make(map[int]bool)
+		for _, policyID := range policyIDs {
+			m.policyAssets[asset][policyID] = true
+		}
+	}
+	for _, association := range state.AccountPolicies {
+		m.accountPolicies[association.AccountID] = association
+	}
+	for siteID, configuration := range state.DataCenters {
+		m.dataCenters[siteID] = configuration
+		for _, dataCenter := range configuration.DataCenters {
+			if dataCenter.ID != nil {
+				mockBumpID(&m.nextDataCenterID, *dataCenter.ID)
+			}
+			for _, server := range dataCenter.Servers {
+				mockBumpID(&m.nextOriginServerID, server.ID)
+			}
+		}
+	}
+	for _, connection := range state.SiemConnections {
+		m.siemConnections[connection.ID] = connection
+		mockBumpSiemID(&m.nextSiemID, connection.ID)
+	}
+	for _, logConfiguration := range state.SiemLogConfigurations {
+		m.siemLogConfigurations[logConfiguration.ID] = logConfiguration
+		mockBumpSiemID(&m.nextSiemID, logConfiguration.ID)
+	}
+	for siteID, certificates := range state.CustomCertificates {
+		m.customCertificates[siteID] = make(map[string]*MockCustomCertificate)
+		for _, certificate := range certificates {
+			m.customCertificates[siteID][certificate.AuthType] = certificate
+		}
+	}
+	for siteID, certificate := range state.ManagedCertificates {
+		m.managedCertificates[siteID] = certificate
+		for _, details := range certificate.CertificatesDetails {
+			mockBumpID(&m.nextCertificateID, details.Id)
+		}
+	}
+	for _, certificate := range state.MTLSOriginCertificates {
+		m.mtlsOriginCertificates[certificate.ID] = certificate
+		mockBumpID(&m.nextCertificateID, certificate.ID)
+	}
+	for siteID, certificateID := range state.MTLSOriginSites {
+		m.mtlsOriginSites[siteID] = certificateID
+	}
+	for _, certificate := range state.ClientCaCertificates {
+		m.clientCaCertificates[certificate.ID] = certificate
+		mockBumpID(&m.nextCertificateID, certificate.ID)
+	}
+	for siteID, certificateIDs := range state.ClientCaSites {
+		m.clientCaSites[siteID] = make(map[int]bool)
+		for _, certificateID := range certificateIDs {
+			m.clientCaSites[siteID][certificateID] = true
+		}
+	}
+	for siteID, settings := range state.SiteTlsSettings {
+		m.siteTlsSettings[siteID] = settings
+	}
+	for _, role := range state.Roles {
+		m.roles[role.RoleID] = role
+		mockBumpID(&m.nextRoleID, role.RoleID)
+	}
+	for _, user := range state.Users {
+		m.storeUser(user)
+		mockBumpID(&m.nextUserID, user.UserID)
+	}
+	for _, client := range state.APIClients {
+		m.apiClients[client.ID] = client
+		mockBumpID(&m.nextAPIClientID, client.ID)
+	}
+	for siteID, settings := range state.SiteSettings {
+		m.siteSettings[siteID] = settings
+	}
+	for _, waitingRoom := range state.WaitingRooms {
+		m.waitingRooms[int(waitingRoom.Id)] = waitingRoom
+		mockBumpID(&m.nextWaitingRoomID, int(waitingRoom.Id))
+	}
+	return nil
+}
+
+// mockBumpID makes an ID generator start after an existing ID
+func mockBumpID(next *int, id int) {
+	if id >= *next {
+		*next = id + 1
+	}
+}
+
+// mockBumpSiemID makes the SIEM ID generator start after an existing hexadecimal ID
+func mockBumpSiemID(next *int, id string) {
+	if value, err := strconv.ParseInt(id, 16, 64); err == nil {
+		mockBumpID(next, int(value))
+	}
+}
+
+// LoadStateFile replaces the data of the mock server with the state of a JSON file, such as a snapshot
+func (m *MockImpervaServer) LoadStateFile(path string) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Error reading the state file: %s", err)
+	}
+	var state MockState
+	if err := json.Unmarshal(content, &state); err != nil {
+		return fmt.Errorf("Error parsing the state file %s: %s", path, err)
+	}
+	return m.LoadState(&state)
+}
+
+// SaveStateFile writes a snapshot of the state of the mock server to a JSON file
+func (m *MockImpervaServer) SaveStateFile(path string) error {
+	content, err := json.MarshalIndent(m.Snapshot(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("Error encoding the state: %s", err)
+	}
+	if err := ioutil.WriteFile(path, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("Error writing the state file: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package incapsula
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestMockStateSnapshot(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	site := &MockSite{Domain: "snapshot.example.com"}
+	mock.AddSite(site)
+	siteID := strconv.Itoa(site.SiteID)
+
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+
+	rule, err := client.AddIncapRule(ctx, siteID, &IncapRule{Name: "Block bots", Action: "RULE_ACTION_BLOCK", Filter: `ClientType == "Bad Bot"`, Enabled: true})
+	if err != nil {
+		t.Fatalf("Unexpected error adding incap rule: %s", err)
+	}
+	if _, err := client.AddDataCenter(ctx, siteID, "Backup", "3.3.3.3", "true", "true"); err != nil {
+		t.Fatalf("Unexpected error adding data center: %s", err)
+	}
+	if _, _, err := client.CreateSiemConnection(ctx, &SiemConnection{Data: []SiemConnectionData{{
+		AssetID:        strconv.Itoa(mockAPIKeyAccountID),
+		ConnectionName: "Splunk",
+		StorageType:    StorageTypeCustomerSplunk,
+		ConnectionInfo: SplunkConnectionInfo{Host: "splunk.example.com", Port: 8088, Token: "splunk-token"},
+	}}}); err != nil {
+		t.Fatalf("Unexpected error creating SIEM connection: %s", err)
+	}
+
+	snapshot := mock.Snapshot()
+	if len(snapshot.Sites) != 1 || len(snapshot.IncapRules[site.SiteID]) != 1 || len(snapshot.Policies) == 0 || len(snapshot.SiemConnections) != 1 {
+		t.Fatalf("Unexpected snapshot: %+v", snapshot)
+	}
+
+	// The snapshot is a copy, later changes of either side don't affect the other
+	snapshot.Sites[0].Domain = "changed.example.com"
+	if mock.GetSite(site.SiteID).Domain != "snapshot.example.com" {
+		t.Errorf("Expected the snapshot not to share the sites of the mock server")
+	}
+	snapshot.Sites[0].Domain = "snapshot.example.com"
+
+	// The state of a server loading the snapshot is the same
+	restored := NewMockImpervaServer()
+	defer restored.Close()
+	if err := restored.LoadState(snapshot); err != nil {
+		t.Fatalf("Unexpected error loading state: %s", err)
+	}
+	if restoredSnapshot := restored.Snapshot(); !reflect.DeepEqual(snapshot, restoredSnapshot) {
+		t.Errorf("Expected the restored state to match the snapshot\nexpected: %+v\ngot:      %+v", snapshot, restoredSnapshot)
+	}
+	if restored.GetIncapRule(site.SiteID, rule.RuleID) == nil {
+		t.Errorf("Expected the incap rule to be restored")
+	}
+
+	// The ID generators go on from the snapshot
+	restoredConfig := testConfigForURL(restored.URL())
+	next, err := NewClient(&restoredConfig).AddIncapRule(ctx, siteID, &IncapRule{Name: "Alert bots", Action: "RULE_ACTION_ALERT", Filter: `ClientType == "Bad Bot"`, Enabled: true})
+	if err != nil || next.RuleID <= rule.RuleID {
+		t.Errorf("Expected a new rule ID after %d, got %+v %v", rule.RuleID, next, err)
+	}
+}
+
+func TestMockStateSeedFile(t *testing.T) {
+	seed := filepath.Join(t.TempDir(), "seed.json")
+	content := `{
+		"accounts": [{"account_id": 2000, "email": "seed@example.com", "plan_id": "ent100", "account_name": "Seed"}],
+		"sites": [{"site_id": 30000, "domain": "www.seed.com", "account_id": 2000, "status": "active"}],
+		"incap_rules": {"30000": [{"rule_id": 700, "name": "Seeded", "action": "RULE_ACTION_ALERT", "filter": "ASN == 1", "enabled": true}]}
+	}`
+	if err := ioutil.WriteFile(seed, []byte(content), 0644); err != nil {
+		t.Fatalf("Unexpected error writing seed: %s", err)
+	}
+
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	if err := mock.LoadStateFile(seed); err != nil {
+		t.Fatalf("Unexpected error loading seed: %s", err)
+	}
+	if site := mock.GetSite(30000); site == nil || site.AccountID != 2000 {
+		t.Fatalf("Expected the seeded site, got %+v", site)
+	}
+	if rule := mock.GetIncapRule(30000, 700); rule == nil || rule.Name != "Seeded" {
+		t.Errorf("Expected the seeded incap rule, got %+v", rule)
+	}
+
+	// New objects get IDs after the seeded ones
+	added := &MockSite{Domain: "added.seed.com"}
+	mock.AddSite(added)
+	if added.SiteID != 30001 {
+		t.Errorf("Expected the next site ID to be 30001, got %d", added.SiteID)
+	}
+	account := &MockAccount{Email: "added@example.com"}
+	mock.AddAccount(account)
+	if account.AccountID != 2001 {
+		t.Errorf("Expected the next account ID to be 2001, got %d", account.AccountID)
+	}
+
+	// The saved state can seed another server
+	saved := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := mock.SaveStateFile(saved); err != nil {
+		t.Fatalf("Unexpected error saving state: %s", err)
+	}
+	restored := NewMockImpervaServer()
+	defer restored.Close()
+	if err := restored.LoadStateFile(saved); err != nil {
+		t.Fatalf("Unexpected error loading saved state: %s", err)
+	}
+	if !reflect.DeepEqual(mock.Snapshot(), restored.Snapshot()) {
+		t.Errorf("Expected the saved state to be restored")
+	}
+
+	if err := ioutil.WriteFile(seed, []byte(`{"sites": {}}`), 0644); err != nil {
+		t.Fatalf("Unexpected error writing seed: %s", err)
+	}
+	if err := mock.LoadStateFile(seed); err == nil || !strings.Contains(err.Error(), "Error parsing the state file") {
+		t.Errorf("Expected an error for an invalid seed, got %v", err)
+	}
+}
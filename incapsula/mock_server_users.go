@@ -0,0 +1,839 @@
+// Mock Imperva API Server - users, roles and API clients
+//
+// The endpoints mirror the requests of client_account_user.go, client_account_role.go and client_api_client.go.
+// Roles grant abilities drawn from a fixed catalogue, the keys of the role abilities data source. Every account has
+// the non-editable Administrator and Reader roles, added the first time the roles of the account are needed. Users of
+// sub-accounts are users of an ancestor account given access to the sub-account. API clients issue API keys that
+// authenticate requests like the keys registered with AddAPIKey, until they are disabled or expire.
+
+package incapsula
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/mail"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+var (
+	mockRolesPattern      = regexp.MustCompile(`^user-management/v1/roles/?(\d*)$`)
+	mockAbilitiesPattern  = regexp.MustCompile(`^user-management/v1/abilities/accounts/(\d+)$`)
+	mockUsersPattern      = regexp.MustCompile(`^identity-management/v3/idm-users/?([^/]*)$`)
+	mockAPIClientsPattern = regexp.MustCompile(`^authorization/v3/api-clients/?(\d*)$`)
+)
+
+// mockRoleErrorNotFound is the error code of the role API for unknown roles
+const mockRoleErrorNotFound = apiRoleErrNotFound
+
+// mockAPIClientLimit is the number of API clients an account may have, returned as maxApiKeyLimit
+const mockAPIClientLimit = 10
+
+// mockDateFormat is the format of the expiration dates of API clients
+const mockDateFormat = "2006-01-02"
+
+// mockAbilities is the catalogue of the abilities roles can grant. Sub-accounts only have the abilities relevant
+// for them.
+var mockAbilities = []RoleAbility{
+	{AbilityKey: "canAddSite", AbilityDisplayName: "Add sites", IsRelevantForSubAccount: true},
+	{AbilityKey: "canEditSite", AbilityDisplayName: "Modify site settings", IsRelevantForSubAccount: true},
+	{AbilityKey: "canEditAccount", AbilityDisplayName: "Edit account settings"},
+	{AbilityKey: "canAddUser", AbilityDisplayName: "Manage users"},
+	{AbilityKey: "canManageApiKey", AbilityDisplayName: "Manage API keys", IsRelevantForSubAccount: true},
+	{AbilityKey: "canManageAccountSubAccounts", AbilityDisplayName: "Manage account sub-accounts"},
+	{AbilityKey: "canEditDomain", AbilityDisplayName: "Modify DNS zone settings"},
+	{AbilityKey: "canAddDomain", AbilityDisplayName: "Add DNS zones"},
+	{AbilityKey: "canViewInfraProtectSetting", AbilityDisplayName: "View Infra Protect settings"},
+	{AbilityKey: "canRunConnectivityReports", AbilityDisplayName: "Allow user to run connectivity reports", IsRelevantForSubAccount: true},
+	{AbilityKey: "canPurgeCache", AbilityDisplayName: "Purge cache", IsRelevantForSubAccount: true},
+	{AbilityKey: "canEditSingleIp", AbilityDisplayName: "Edit single IP", IsRelevantForSubAccount: true},
+	{AbilityKey: "canEditRoles", AbilityDisplayName: "Manage users roles"},
+	{AbilityKey: "canViewAuditTrail", AbilityDisplayName: "View audit trail", IsRelevantForSubAccount: true},
+	{AbilityKey: "canViewClientCertificates", AbilityDisplayName: "View client CA certificates", IsRelevantForSubAccount: true},
+	{AbilityKey: "canViewPolicy", AbilityDisplayName: "View policy", IsRelevantForSubAccount: true},
+	{AbilityKey: "canAssignClientCertificates", AbilityDisplayName: "Manage client CA certificates for site", IsRelevantForSubAccount: true},
+	{AbilityKey: "canDeletePolicyException", AbilityDisplayName: "Delete exception from policy"},
+	{AbilityKey: "canDeletePolicy", AbilityDisplayName: "Delete policy"},
+	{AbilityKey: "canAddPolicy", AbilityDisplayName: "Add/Duplicate policy"},
+	{AbilityKey: "canEditClientCertificates", AbilityDisplayName: "Manage client CA certificates for account"},
+	{AbilityKey: "canEditPolicy", AbilityDisplayName: "Edit policy"},
+	{AbilityKey: "canEditPolicyException", AbilityDisplayName: "Edit exception in policy"},
+	{AbilityKey: "canApplyPolicyToAssets", AbilityDisplayName: "Apply policy to assets"},
+	{AbilityKey: "canAddPolicyException", AbilityDisplayName: "Add exception to Policy"},
+}
+
+// mockReaderAbilities are the abilities of the default Reader role, when the account has them
+var mockReaderAbilities = map[string]bool{
+	"canViewInfraProtectSetting": true,
+	"canViewAuditTrail":          true,
+	"canViewClientCertificates":  true,
+	"canViewPolicy":              true,
+}
+
+// MockRole represents a role of an account in the mock server. Default roles can't be updated or deleted.
+type MockRole struct {
+	RoleID      int      `json:"role_id"`
+	AccountID   int      `json:"account_id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Abilities   []string `json:"abilities"`
+	Default     bool     `json:"default,omitempty"`
+	UpdateDate  string   `json:"update_date"`
+}
+
+// MockUser represents a user of an account in the mock server
+type MockUser struct {
+	UserID      int      `json:"user_id"`
+	AccountID   int      `json:"account_id"`
+	Email       string   `json:"email"`
+	FirstName   string   `json:"first_name"`
+	LastName    string   `json:"last_name"`
+	ApprovedIps []string `json:"approved_ips"`
+	RoleIDs     []int    `json:"role_ids"`
+}
+
+// MockAPIClient represents an API client in the mock server. Its ID and key are the credentials of its requests.
+type MockAPIClient struct {
+	ID             int    `json:"id"`
+	AccountID      int    `json:"account_id"`
+	UserEmail      string `json:"user_email"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	APIKey         string `json:"api_key"`
+	Enabled        bool   `json:"enabled"`
+	ExpirationDate string `json:"expiration_date"`
+}
+
+// expired tells whether the expiration date of the API client has passed
+func (client *MockAPIClient) expired() bool {
+	return client.ExpirationDate < time.Now().UTC().Format(mockDateFormat)
+}
+
+// response returns the API client as the API does, the API key only being returned when it is issued
+func (client *MockAPIClient) response(withKey bool) APIClientResponse {
+	response := APIClientResponse{
+		APIClientID:    client.ID,
+		UserEmail:      client.UserEmail,
+		Name:           client.Name,
+		Description:    client.Description,
+		Enabled:        client.Enabled,
+		ExpirationDate: client.ExpirationDate,
+	}
+	if withKey {
+		response.APIKey = client.APIKey
+	}
+	return response
+}
+
+// newMockAPIKey returns a random API key
+func newMockAPIKey() string {
+	key := make([]byte, 16)
+	rand.Read(key)
+	return hex.EncodeToString(key)
+}
+
+// apiClientAccountID returns the account of the API client whose ID is the API ID of a request, and whether its key
+// authenticates the request: it must be enabled, unexpired and its account must exist. The last result is false if
+// the API ID isn't the ID of an API client. The caller must hold the lock.
+func (m *MockImpervaServer) apiClientAccountID(apiID, apiKey string) (int, bool, bool) {
+	clientID, err := strconv.Atoi(apiID)
+	if err != nil {
+		return 0, false, false
+	}
+	client, exists := m.apiClients[clientID]
+	if !exists {
+		return 0, false, false
+	}
+	authenticated := client.APIKey == apiKey && client.Enabled && !client.expired() && m.lookupAccount(client.AccountID) != nil
+	return client.AccountID, authenticated, true
+}
+
+// mockAccountAbilities returns the abilities of the catalogue an account can grant
+func mockAccountAbilities(account *MockAccount) []RoleAbility {
+	if mockAccountType(account) != MockAccountTypeSubAccount {
+		return mockAbilities
+	}
+	abilities := []RoleAbility{}
+	for _, ability := range mockAbilities {
+		if ability.IsRelevantForSubAccount {
+			abilities = append(abilities, ability)
+		}
+	}
+	return abilities
+}
+
+// writeRoleErrorResponse writes an error of the role API, described by an error code
+func (m *MockImpervaServer) writeRoleErrorResponse(w http.ResponseWriter, status int, errorCode int, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"errorCode":   errorCode,
+		"description": description,
+	})
+}
+
+// Abilities Handlers
+
+// handleAbilities handles GET /user-management/v1/abilities/accounts/{accountId}
+func (m *MockImpervaServer) handleAbilities(w http.ResponseWriter, r *http.Request, path string) {
+	accountID, _ := strconv.Atoi(mockAbilitiesPattern.FindStringSubmatch(path)[1])
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if r.Method != http.MethodGet {
+		m.writeRoleErrorResponse(w, http.StatusMethodNotAllowed, http.StatusMethodNotAllowed, fmt.Sprintf("Method not allowed: %s", r.Method))
+		return
+	}
+	account := m.lookupAccount(accountID)
+	if account == nil {
+		m.writeRoleErrorResponse(w, http.StatusNotFound, http.StatusNotFound, fmt.Sprintf("Account %d not found", accountID))
+		return
+	}
+	m.writeJSONResponse(w, mockAccountAbilities(account))
+}
+
+// Roles Handlers
+
+// accountRoles returns the roles of an account by ID, first adding its default roles if it has none yet. The caller
+// must hold the lock.
+func (m *MockImpervaServer) accountRoles(accountID int) []*MockRole {
+	roles := []*MockRole{}
+	hasDefaults := false
+	for _, role := range m.roles {
+		if role.AccountID == accountID {
+			roles = append(roles, role)
+			hasDefaults = hasDefaults || role.Default
+		}
+	}
+	if !hasDefaults {
+		administrator := &MockRole{AccountID: accountID, Name: "Administrator", Description: "Default administrator role", Default: true}
+		reader := &MockRole{AccountID: accountID, Name: "Reader", Description: "Default read only role", Default: true}
+		for _, ability := range mockAccountAbilities(m.lookupAccount(accountID)) {
+			administrator.Abilities = append(administrator.Abilities, ability.AbilityKey)
+			if mockReaderAbilities[ability.AbilityKey] {
+				reader.Abilities = append(reader.Abilities, ability.AbilityKey)
+			}
+		}
+		for _, role := range []*MockRole{administrator, reader} {
+			m.storeRole(role)
+			roles = append(roles, role)
+		}
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].RoleID < roles[j].RoleID })
+	return roles
+}
+
+// storeRole stores a role, giving it an ID if it has none. The caller must hold the lock.
+func (m *MockImpervaServer) storeRole(role *MockRole) {
+	if role.RoleID == 0 {
+		role.RoleID = m.nextRoleID
+		m.nextRoleID++
+	}
+	role.UpdateDate = time.Now().UTC().Format(time.RFC3339)
+	m.roles[role.RoleID] = role
+}
+
+// roleDetails returns a role as the API does, with the users it is assigned to. The caller must hold the lock.
+func (m *MockImpervaServer) roleDetails(role *MockRole) RoleDetailsDTO {
+	details := RoleDetailsDTO{
+		RoleId:          role.RoleID,
+		RoleName:        role.Name,
+		RoleDescription: role.Description,
+		AccountId:       role.AccountID,
+		RoleAbilities:   []RoleAbility{},
+		UserAssignment:  []UserAssignment{},
+		UpdateDate:      role.UpdateDate,
+		IsEditable:      !role.Default,
+	}
+	if account := m.lookupAccount(role.AccountID); account != nil {
+		details.AccountName = account.AccountName
+	}
+	for _, key := range role.Abilities {
+		for _, ability := range mockAbilities {
+			if ability.AbilityKey == key {
+				details.RoleAbilities = append(details.RoleAbilities, ability)
+			}
+		}
+	}
+	for _, user := range m.sortedUsers() {
+		for _, roleID := range user.RoleIDs {
+			if roleID == role.RoleID {
+				details.UserAssignment = append(details.UserAssignment, UserAssignment{UserEmail: user.Email, AccountId: user.AccountID})
+			}
+		}
+	}
+	return details
+}
+
+// handleRoles handles GET/POST /user-management/v1/roles and GET/POST/DELETE /user-management/v1/roles/{roleId}
+func (m *MockImpervaServer) handleRoles(w http.ResponseWriter, r *http.Request, path string) {
+	roleID, _ := strconv.Atoi(mockRolesPattern.FindStringSubmatch(path)[1])
+	callerID := mockCallerAccountID(r)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if roleID == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			accountID, _ := strconv.Atoi(r.URL.Query().Get("accountId"))
+			if accountID == 0 {
+				accountID = callerID
+			}
+			if m.lookupAccount(accountID) == nil || !m.accountInTree(callerID, accountID) {
+				m.writeRoleErrorResponse(w, http.StatusUnauthorized, http.StatusUnauthorized, fmt.Sprintf("Unknown/unauthorized account %d", accountID))
+				return
+			}
+			roles := []RoleDetailsDTO{}
+			for _, role := range m.accountRoles(accountID) {
+				roles = append(roles, m.roleDetails(role))
+			}
+			m.writeJSONResponse(w, roles)
+		case http.MethodPost:
+			m.writeRole(w, r, &MockRole{})
+		default:
+			m.writeRoleErrorResponse(w, http.StatusMethodNotAllowed, http.StatusMethodNotAllowed, fmt.Sprintf("Method not allowed: %s", r.Method))
+		}
+		return
+	}
+
+	role, exists := m.roles[roleID]
+	if !exists || !m.ownedByRequest(r, role.AccountID) {
+		m.writeRoleErrorResponse(w, http.StatusNotFound, mockRoleErrorNotFound, fmt.Sprintf("Role %d not found", roleID))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		m.writeJSONResponse(w, m.roleDetails(role))
+	case http.MethodPost, http.MethodDelete:
+		if role.Default {
+			m.writeRoleErrorResponse(w, http.StatusBadRequest, http.StatusBadRequest, fmt.Sprintf("The default role %s can't be changed", role.Name))
+			return
+		}
+		if r.Method == http.MethodPost {
+			m.writeRole(w, r, role)
+			return
+		}
+		// The role is taken away from its users
+		details := m.roleDetails(role)
+		delete(m.roles, roleID)
+		for _, user := range m.sortedUsers() {
+			roleIDs := []int{}
+			for _, userRoleID := range user.RoleIDs {
+				if userRoleID != roleID {
+					roleIDs = append(roleIDs, userRoleID)
+				}
+			}
+			user.RoleIDs = roleIDs
+		}
+		m.writeJSONResponse(w, details)
+	default:
+		m.writeRoleErrorResponse(w, http.StatusMethodNotAllowed, http.StatusMethodNotAllowed, fmt.Sprintf("Method not allowed: %s", r.Method))
+	}
+}
+
+// writeRole validates a create or update request, stores the role and writes it. Roles are created in the account
+// of the request body, the account of the API key by default. The caller must hold the lock.
+func (m *MockImpervaServer) writeRole(w http.ResponseWriter, r *http.Request, role *MockRole) {
+	var request RoleDetailsCreateDTO
+	body, _ := ioutil.ReadAll(r.Body)
+	if err := json.Unmarshal(body, &request); err != nil {
+		m.writeRoleErrorResponse(w, http.StatusBadRequest, http.StatusBadRequest, fmt.Sprintf("Invalid role: %s", err))
+		return
+	}
+
+	if role.RoleID == 0 {
+		role.AccountID = request.AccountId
+		if role.AccountID == 0 {
+			role.AccountID = mockCallerAccountID(r)
+		}
+	}
+	if m.lookupAccount(role.AccountID) == nil || !m.accountInTree(mockCallerAccountID(r), role.AccountID) {
+		m.writeRoleErrorResponse(w, http.StatusUnauthorized, http.StatusUnauthorized, fmt.Sprintf("Unknown/unauthorized account %d", role.AccountID))
+		return
+	}
+
+	if request.RoleName == "" {
+		m.writeRoleErrorResponse(w, http.StatusBadRequest, http.StatusBadRequest, "roleName is required")
+		return
+	}
+	for _, other := range m.accountRoles(role.AccountID) {
+		if other != role && other.Name == request.RoleName {
+			m.writeRoleErrorResponse(w, http.StatusBadRequest, http.StatusBadRequest, fmt.Sprintf("A role named %s already exists", request.RoleName))
+			return
+		}
+	}
+
+	// The abilities must be abilities of the catalogue the account can grant
+	available := map[string]bool{}
+	for _, ability := range mockAccountAbilities(m.lookupAccount(role.AccountID)) {
+		available[ability.AbilityKey] = true
+	}
+	abilities := []string{}
+	for _, key := range request.RoleAbilities {
+		if !available[key] {
+			m.writeRoleErrorResponse(w, http.StatusBadRequest, http.StatusBadRequest, fmt.Sprintf("Unknown ability for account %d: %s", role.AccountID, key))
+			return
+		}
+		abilities = append(abilities, key)
+	}
+
+	role.Name = request.RoleName
+	role.Description = request.RoleDescription
+	role.Abilities = abilities
+	m.storeRole(role)
+
+	m.writeJSONResponse(w, m.roleDetails(role))
+}
+
+// Users Handlers
+
+// sortedUsers returns the users of every account, by account and email. The caller must hold the lock.
+func (m *MockImpervaServer) sortedUsers() []*MockUser {
+	users := []*MockUser{}
+	for _, accountUsers := range m.users {
+		for _, user := range accountUsers {
+			users = append(users, user)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool {
+		if users[i].AccountID != users[j].AccountID {
+			return users[i].AccountID < users[j].AccountID
+		}
+		return users[i].Email < users[j].Email
+	})
+	return users
+}
+
+// storeUser stores a user, giving it an ID if it has none. The caller must hold the lock.
+func (m *MockImpervaServer) storeUser(user *MockUser) {
+	if user.UserID == 0 {
+		user.UserID = m.nextUserID
+		m.nextUserID++
+	}
+	if m.users[user.AccountID] == nil {
+		m.users[user.AccountID] = make(map[string]*MockUser)
+	}
+	m.users[user.AccountID][user.Email] = user
+}
+
+// userResponse returns a user as the API does. The caller must hold the lock.
+func (m *MockImpervaServer) userResponse(user *MockUser) map[string]interface{} {
+	roles := []map[string]interface{}{}
+	for _, roleID := range user.RoleIDs {
+		if role, exists := m.roles[roleID]; exists {
+			roles = append(roles, map[string]interface{}{"id": role.RoleID, "name": role.Name})
+		}
+	}
+	approvedIps := user.ApprovedIps
+	if approvedIps == nil {
+		approvedIps = []string{}
+	}
+	return map[string]interface{}{
+		"data": []map[string]interface{}{{
+			"id":          strconv.Itoa(user.UserID),
+			"accountId":   user.AccountID,
+			"firstName":   user.FirstName,
+			"lastName":    user.LastName,
+			"email":       user.Email,
+			"approvedIps": approvedIps,
+			"roles":       roles,
+		}},
+	}
+}
+
+// validateMockUserRoles checks that roles can be assigned to the users of an account: they must be roles of the
+// account or of one of its ancestors. It returns the error message. The caller must hold the lock.
+func (m *MockImpervaServer) validateMockUserRoles(accountID int, roleIDs []int) string {
+	for _, roleID := range roleIDs {
+		role, exists := m.roles[roleID]
+		if !exists || !m.accountInTree(role.AccountID, accountID) {
+			return fmt.Sprintf("Unknown role for account %d: %d", accountID, roleID)
+		}
+	}
+	return ""
+}
+
+// validateMockApprovedIps checks that the approved IPs of a user are IP addresses or CIDR ranges. It returns the
+// error message.
+func validateMockApprovedIps(approvedIps []string) string {
+	for _, approvedIp := range approvedIps {
+		if net.ParseIP(approvedIp) == nil {
+			if _, _, err := net.ParseCIDR(approvedIp); err != nil {
+				return fmt.Sprintf("Invalid approved IP: %s", approvedIp)
+			}
+		}
+	}
+	return ""
+}
+
+// handleUsers handles POST /identity-management/v3/idm-users and GET/POST/PATCH/DELETE
+// /identity-management/v3/idm-users/{email}. Users belong to the account of the caid query param, or of the API key.
+func (m *MockImpervaServer) handleUsers(w http.ResponseWriter, r *http.Request, path string) {
+	email := mockUsersPattern.FindStringSubmatch(path)[1]
+	accountID := m.requestAccountID(r)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	account := m.lookupAccount(accountID)
+	if account == nil {
+		m.writeV3ErrorResponse(w, http.StatusNotFound, "", fmt.Sprintf("Account %d not found", accountID))
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		// Users of sub-accounts are added with their email in the path, users of other accounts in the body
+		if (mockAccountType(account) == MockAccountTypeSubAccount) != (email != "") {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "", fmt.Sprintf("Users of account %d can't be added with this request", accountID))
+			return
+		}
+		m.writeNewUser(w, r, accountID, email)
+		return
+	}
+	if email == "" {
+		m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+		return
+	}
+
+	user, exists := m.users[accountID][email]
+	if !exists {
+		m.writeV3ErrorResponse(w, http.StatusNotFound, "", fmt.Sprintf("User %s not found", email))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		m.writeJSONResponse(w, m.userResponse(user))
+	case http.MethodPatch:
+		// Only the fields of the request are updated
+		var request UserUpdateReq
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &request); err != nil {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "", fmt.Sprintf("Invalid user: %s", err))
+			return
+		}
+		if request.RoleIds != nil {
+			if message := m.validateMockUserRoles(accountID, *request.RoleIds); message != "" {
+				m.writeV3ErrorResponse(w, http.StatusBadRequest, "/roleIds", message)
+				return
+			}
+		}
+		if request.ApprovedIps != nil {
+			if message := validateMockApprovedIps(*request.ApprovedIps); message != "" {
+				m.writeV3ErrorResponse(w, http.StatusBadRequest, "/approvedIps", message)
+				return
+			}
+		}
+		if request.RoleIds != nil {
+			user.RoleIDs = *request.RoleIds
+		}
+		if request.ApprovedIps != nil {
+			user.ApprovedIps = *request.ApprovedIps
+		}
+		m.writeJSONResponse(w, m.userResponse(user))
+	case http.MethodDelete:
+		delete(m.users[accountID], email)
+		if len(m.users[accountID]) == 0 {
+			delete(m.users, accountID)
+		}
+		m.writeJSONResponse(w, map[string]interface{}{
+			"code":    http.StatusOK,
+			"message": fmt.Sprintf("User %s deleted", email),
+		})
+	default:
+		m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+	}
+}
+
+// writeNewUser validates an add request, stores the user and writes it. The user of a sub-account, whose email is
+// given, must be a user of one of its ancestors, whose name it keeps. The caller must hold the lock.
+func (m *MockImpervaServer) writeNewUser(w http.ResponseWriter, r *http.Request, accountID int, email string) {
+	var request UserAddReq
+	body, _ := ioutil.ReadAll(r.Body)
+	if err := json.Unmarshal(body, &request); err != nil {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "", fmt.Sprintf("Invalid user: %s", err))
+		return
+	}
+
+	user := &MockUser{AccountID: accountID, Email: email, FirstName: request.FirstName, LastName: request.LastName}
+	if email == "" {
+		user.Email = request.UserEmail
+		if _, err := mail.ParseAddress(user.Email); err != nil {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "/email", fmt.Sprintf("Invalid email: %s", user.Email))
+			return
+		}
+	} else {
+		var ancestorUser *MockUser
+		for ancestorID, users := range m.users {
+			if existing, exists := users[email]; exists && ancestorID != accountID && m.accountInTree(ancestorID, accountID) {
+				ancestorUser = existing
+			}
+		}
+		if ancestorUser == nil {
+			m.writeV3ErrorResponse(w, http.StatusNotFound, "", fmt.Sprintf("User %s not found in the parent accounts of account %d", email, accountID))
+			return
+		}
+		user.FirstName, user.LastName = ancestorUser.FirstName, ancestorUser.LastName
+	}
+	if _, exists := m.users[accountID][user.Email]; exists {
+		m.writeV3ErrorResponse(w, http.StatusConflict, "/email", fmt.Sprintf("User %s already exists", user.Email))
+		return
+	}
+	if message := m.validateMockUserRoles(accountID, request.RoleIds); message != "" {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "/roleIds", message)
+		return
+	}
+	if message := validateMockApprovedIps(request.ApprovedIps); message != "" {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "/approvedIps", message)
+		return
+	}
+
+	user.RoleIDs = request.RoleIds
+	user.ApprovedIps = request.ApprovedIps
+	m.storeUser(user)
+
+	m.writeJSONResponse(w, m.userResponse(user))
+}
+
+// API Clients Handlers
+
+// handleAPIClients handles GET/POST /authorization/v3/api-clients and PATCH/DELETE
+// /authorization/v3/api-clients/{clientId}. API clients belong to the account of the caid query param, or of the API
+// key.
+func (m *MockImpervaServer) handleAPIClients(w http.ResponseWriter, r *http.Request, path string) {
+	clientID, _ := strconv.Atoi(mockAPIClientsPattern.FindStringSubmatch(path)[1])
+	accountID := m.requestAccountID(r)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if clientID == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			m.writeAPIClients(w, r, accountID)
+		case http.MethodPost:
+			m.writeNewAPIClient(w, r, accountID)
+		default:
+			m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+		}
+		return
+	}
+
+	client, exists := m.apiClients[clientID]
+	if !exists || client.AccountID != accountID {
+		m.writeV3ErrorResponse(w, http.StatusNotFound, "", fmt.Sprintf("API client %d not found", clientID))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPatch:
+		var request APIClientUpdateRequest
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &request); err != nil {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "", fmt.Sprintf("Invalid API client: %s", err))
+			return
+		}
+		if request.ExpirationDate != "" {
+			if message := validateMockExpirationDate(request.ExpirationDate); message != "" {
+				m.writeV3ErrorResponse(w, http.StatusBadRequest, "/expirationDate", message)
+				return
+			}
+			client.ExpirationDate = request.ExpirationDate
+		}
+		if request.Name != "" {
+			client.Name = request.Name
+		}
+		if request.Description != "" {
+			client.Description = request.Description
+		}
+		if request.Enabled != nil {
+			client.Enabled = *request.Enabled
+		}
+		// A regenerated key replaces the previous one at once
+		if request.Regenerate {
+			client.APIKey = newMockAPIKey()
+		}
+		m.writeJSONResponse(w, client.response(request.Regenerate))
+	case http.MethodDelete:
+		delete(m.apiClients, clientID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+	}
+}
+
+// writeAPIClients writes the API clients of an account, or the API client of the id query param. API keys are
+// never listed. The caller must hold the lock.
+func (m *MockImpervaServer) writeAPIClients(w http.ResponseWriter, r *http.Request, accountID int) {
+	clients := []APIClientResponse{}
+	for _, client := range m.sortedAPIClients() {
+		if client.AccountID == accountID {
+			clients = append(clients, client.response(false))
+		}
+	}
+	if id := r.URL.Query().Get("id"); id != "" {
+		clientID, _ := strconv.Atoi(id)
+		client, exists := m.apiClients[clientID]
+		if !exists || client.AccountID != accountID {
+			m.writeV3ErrorResponse(w, http.StatusNotFound, "", fmt.Sprintf("API client %s not found", id))
+			return
+		}
+		clients = []APIClientResponse{client.response(false)}
+	}
+	m.writeJSONResponse(w, map[string]interface{}{
+		"meta": MetaData{Total: len(clients), PageSize: len(clients), MaxApiKeyLimit: mockAPIClientLimit},
+		"data": clients,
+	})
+}
+
+// writeNewAPIClient validates a create request, stores the API client and writes it with its API key. The API client
+// belongs to the user of the userEmail query param, the account's user by default. The caller must hold the lock.
+func (m *MockImpervaServer) writeNewAPIClient(w http.ResponseWriter, r *http.Request, accountID int) {
+	var request APIClientUpdateRequest
+	body, _ := ioutil.ReadAll(r.Body)
+	if err := json.Unmarshal(body, &request); err != nil {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "", fmt.Sprintf("Invalid API client: %s", err))
+		return
+	}
+
+	account := m.lookupAccount(accountID)
+	if account == nil {
+		m.writeV3ErrorResponse(w, http.StatusNotFound, "", fmt.Sprintf("Account %d not found", accountID))
+		return
+	}
+	count := 0
+	for _, client := range m.apiClients {
+		if client.AccountID == accountID {
+			count++
+		}
+	}
+	if count >= mockAPIClientLimit {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "", fmt.Sprintf("Account %d already has %d API clients", accountID, count))
+		return
+	}
+	if request.Name == "" {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "/name", "name is required")
+		return
+	}
+
+	userEmail := r.URL.Query().Get("userEmail")
+	if userEmail == "" {
+		userEmail = account.Email
+	} else if _, exists := m.users[accountID][userEmail]; !exists {
+		m.writeV3ErrorResponse(w, http.StatusNotFound, "", fmt.Sprintf("User %s not found", userEmail))
+		return
+	}
+
+	// Keys expire after a year by default
+	expirationDate := request.ExpirationDate
+	if expirationDate == "" {
+		expirationDate = time.Now().UTC().AddDate(1, 0, 0).Format(mockDateFormat)
+	} else if message := validateMockExpirationDate(expirationDate); message != "" {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "/expirationDate", message)
+		return
+	}
+
+	client := &MockAPIClient{
+		AccountID:      accountID,
+		UserEmail:      userEmail,
+		Name:           request.Name,
+		Description:    request.Description,
+		APIKey:         newMockAPIKey(),
+		Enabled:        request.Enabled == nil || *request.Enabled,
+		ExpirationDate: expirationDate,
+	}
+	m.storeAPIClient(client)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(client.response(true))
+}
+
+// validateMockExpirationDate checks that the expiration date of an API client is a future date. It returns the error
+// message.
+func validateMockExpirationDate(expirationDate string) string {
+	date, err := time.Parse(mockDateFormat, expirationDate)
+	if err != nil {
+		return fmt.Sprintf("Invalid expirationDate, expected YYYY-MM-DD: %s", expirationDate)
+	}
+	if !date.After(time.Now().UTC()) {
+		return fmt.Sprintf("expirationDate must be a future date: %s", expirationDate)
+	}
+	return ""
+}
+
+// storeAPIClient stores an API client, giving it an ID if it has none. The caller must hold the lock.
+func (m *MockImpervaServer) storeAPIClient(client *MockAPIClient) {
+	if client.ID == 0 {
+		client.ID = m.nextAPIClientID
+		m.nextAPIClientID++
+	}
+	m.apiClients[client.ID] = client
+}
+
+// sortedAPIClients returns the API clients by ID. The caller must hold the lock.
+func (m *MockImpervaServer) sortedAPIClients() []*MockAPIClient {
+	clients := []*MockAPIClient{}
+	for _, client := range m.apiClients {
+		clients = append(clients, client)
+	}
+	sort.Slice(clients, func(i, j int) bool { return clients[i].ID < clients[j].ID })
+	return clients
+}
+
+// Helper methods for tests
+
+// GetRole returns a role by ID (for test assertions)
+func (m *MockImpervaServer) GetRole(roleID int) *MockRole {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.roles[roleID]
+}
+
+// AddRole adds a role directly (for test setup)
+func (m *MockImpervaServer) AddRole(role *MockRole) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.storeRole(role)
+}
+
+// GetUser returns a user of an account by email (for test assertions)
+func (m *MockImpervaServer) GetUser(accountID int, email string) *MockUser {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.users[accountID][email]
+}
+
+// AddUser adds a user directly (for test setup)
+func (m *MockImpervaServer) AddUser(user *MockUser) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.storeUser(user)
+}
+
+// GetAPIClient returns an API client by ID (for test assertions)
+func (m *MockImpervaServer) GetAPIClient(clientID int) *MockAPIClient {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.apiClients[clientID]
+}
+
+// AddAPIClient adds an API client directly, without validating its expiration date (for test setup)
+func (m *MockImpervaServer) AddAPIClient(client *MockAPIClient) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if client.APIKey == "" {
+		client.APIKey = newMockAPIKey()
+	}
+	m.storeAPIClient(client)
+}
@@ -0,0 +1,309 @@
+package incapsula
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testMockRoleIDs returns the IDs of the roles of an account by name
+func testMockRoleIDs(t *testing.T, client *Client, accountID int) map[string]int {
+	roles, err := client.GetAccountRoles(context.Background(), accountID)
+	if err != nil {
+		t.Fatalf("Unexpected error listing roles: %s", err)
+	}
+	roleIDs := map[string]int{}
+	for _, role := range *roles {
+		roleIDs[role.RoleName] = role.RoleId
+	}
+	return roleIDs
+}
+
+func TestMockAccountRoles(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	subAccount := &MockAccount{AccountType: MockAccountTypeSubAccount, ParentID: mockAPIKeyAccountID, AccountName: "Sub"}
+	mock.AddAccount(subAccount)
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+
+	// Sub-accounts only have the abilities relevant for them
+	abilities, err := client.GetAccountAbilities(ctx, mockAPIKeyAccountID)
+	if err != nil || len(*abilities) != len(mockAbilities) {
+		t.Fatalf("Expected the whole abilities catalogue, got %v %v", abilities, err)
+	}
+	subAbilities, err := client.GetAccountAbilities(ctx, subAccount.AccountID)
+	if err != nil || len(*subAbilities) == 0 || len(*subAbilities) >= len(mockAbilities) {
+		t.Fatalf("Expected part of the abilities catalogue for the sub-account, got %v %v", subAbilities, err)
+	}
+	for _, ability := range *subAbilities {
+		if !ability.IsRelevantForSubAccount {
+			t.Errorf("Unexpected ability for the sub-account: %+v", ability)
+		}
+	}
+
+	// Accounts have default roles
+	d := dataSourceAccountRoles().TestResourceData()
+	d.Set("account_id", mockAPIKeyAccountID)
+	if diags := dataSourceAccountRolesRead(ctx, d, client); diags.HasError() {
+		t.Fatalf("Unexpected error reading account roles: %v", diags)
+	}
+	adminID, readerID := d.Get("admin_role_id").(int), d.Get("reader_role_id").(int)
+	if adminID == 0 || readerID == 0 {
+		t.Fatalf("Expected the default roles, got %v", d.Get("map"))
+	}
+
+	role, err := client.AddAccountRole(ctx, RoleDetailsCreateDTO{AccountId: mockAPIKeyAccountID, RoleDetailsBasicDTO: RoleDetailsBasicDTO{
+		RoleName:      "Site editor",
+		RoleAbilities: []string{"canEditSite", "canPurgeCache"},
+	}})
+	if err != nil || len(role.RoleAbilities) != 2 || !role.IsEditable || role.RoleAbilities[1].AbilityDisplayName != "Purge cache" {
+		t.Fatalf("Unexpected role: %+v %v", role, err)
+	}
+	if _, err := client.AddAccountRole(ctx, RoleDetailsCreateDTO{AccountId: subAccount.AccountID, RoleDetailsBasicDTO: RoleDetailsBasicDTO{
+		RoleName:      "Account editor",
+		RoleAbilities: []string{"canEditAccount"},
+	}}); err == nil {
+		t.Errorf("Expected an error for an ability the sub-account doesn't have")
+	}
+	if _, err := client.AddAccountRole(ctx, RoleDetailsCreateDTO{AccountId: mockAPIKeyAccountID, RoleDetailsBasicDTO: RoleDetailsBasicDTO{
+		RoleName: "Administrator",
+	}}); err == nil {
+		t.Errorf("Expected an error for a duplicate role name")
+	}
+
+	updated, err := client.UpdateAccountRole(ctx, role.RoleId, mockAPIKeyAccountID, RoleDetailsBasicDTO{RoleName: "Site editor", RoleAbilities: []string{"canEditSite"}})
+	if err != nil || len(updated.RoleAbilities) != 1 {
+		t.Fatalf("Unexpected updated role: %+v %v", updated, err)
+	}
+
+	// Roles list the users they are assigned to
+	mock.AddUser(&MockUser{AccountID: mockAPIKeyAccountID, Email: "editor@example.com", RoleIDs: []int{role.RoleId}})
+	read, err := client.GetAccountRole(ctx, role.RoleId)
+	if err != nil || len(read.UserAssignment) != 1 || read.UserAssignment[0].UserEmail != "editor@example.com" {
+		t.Errorf("Expected the role to be assigned to the user, got %+v %v", read, err)
+	}
+
+	// Default roles can't be deleted, the other roles are taken away from their users
+	client.DeleteAccountRole(ctx, adminID, mockAPIKeyAccountID)
+	if mock.GetRole(adminID) == nil {
+		t.Errorf("Expected the default role not to be deleted")
+	}
+	if err := client.DeleteAccountRole(ctx, role.RoleId, mockAPIKeyAccountID); err != nil {
+		t.Fatalf("Unexpected error deleting role: %s", err)
+	}
+	if read, err := client.GetAccountRole(ctx, role.RoleId); read != nil || !IsNotFound(err) {
+		t.Errorf("Expected a role not found error, got %+v %v", read, err)
+	}
+	if user := mock.GetUser(mockAPIKeyAccountID, "editor@example.com"); len(user.RoleIDs) != 0 {
+		t.Errorf("Expected the deleted role to be taken away from the user, got %v", user.RoleIDs)
+	}
+}
+
+func TestMockAccountUsers(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+	roleIDs := testMockRoleIDs(t, client, mockAPIKeyAccountID)
+	email := "user@example.com"
+
+	added, err := client.AddAccountUser(ctx, mockAPIKeyAccountID, email, "John", "Snow", []interface{}{roleIDs["Administrator"]}, []interface{}{"1.2.3.4", "10.0.0.0/8"})
+	if err != nil {
+		t.Fatalf("Unexpected error adding user: %s", err)
+	}
+	user := added.Data[0]
+	if user.Email != email || len(user.Roles) != 1 || user.Roles[0].RoleName != "Administrator" || len(user.ApprovedIps) != 2 {
+		t.Fatalf("Unexpected user: %+v", user)
+	}
+	if _, err := client.AddAccountUser(ctx, mockAPIKeyAccountID, email, "John", "Snow", nil, nil); err == nil {
+		t.Errorf("Expected an error adding an existing user")
+	}
+	if _, err := client.AddAccountUser(ctx, mockAPIKeyAccountID, "other@example.com", "Jane", "Doe", []interface{}{1}, nil); err == nil {
+		t.Errorf("Expected an error for an unknown role")
+	}
+
+	// Updates only change the fields they send
+	updated, err := client.UpdateAccountUser(ctx, mockAPIKeyAccountID, email, []interface{}{roleIDs["Reader"]}, nil)
+	if err != nil || updated.Data[0].Roles[0].RoleName != "Reader" || len(updated.Data[0].ApprovedIps) != 2 {
+		t.Fatalf("Unexpected updated user: %+v %v", updated, err)
+	}
+	if _, err := client.UpdateAccountUser(ctx, mockAPIKeyAccountID, email, nil, []interface{}{"not-an-ip"}); err == nil {
+		t.Errorf("Expected an error for an invalid approved IP")
+	}
+
+	// Users of sub-accounts are users of the parent account
+	subAccount := &MockAccount{AccountType: MockAccountTypeSubAccount, ParentID: mockAPIKeyAccountID, AccountName: "Sub"}
+	mock.AddAccount(subAccount)
+	if _, err := client.AddAccountUser(ctx, subAccount.AccountID, email, "", "", []interface{}{roleIDs["Reader"]}, nil); err != nil {
+		t.Fatalf("Unexpected error adding sub-account user: %s", err)
+	}
+	read, err := client.GetAccountUser(ctx, subAccount.AccountID, email)
+	if err != nil || read.Data[0].AccountID != subAccount.AccountID || read.Data[0].FirstName != "John" {
+		t.Errorf("Unexpected sub-account user: %+v %v", read, err)
+	}
+	if _, err := client.AddAccountUser(ctx, subAccount.AccountID, "stranger@example.com", "", "", nil, nil); !IsNotFound(err) {
+		t.Errorf("Expected a not found error for a user outside the parent account, got %v", err)
+	}
+
+	if err := client.DeleteAccountUser(ctx, mockAPIKeyAccountID, email); err != nil {
+		t.Fatalf("Unexpected error deleting user: %s", err)
+	}
+	if _, err := client.GetAccountUser(ctx, mockAPIKeyAccountID, email); !IsNotFound(err) {
+		t.Errorf("Expected a not found error reading the deleted user, got %v", err)
+	}
+}
+
+func TestMockAccountUserRoleNames(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+	roleIDs := testMockRoleIDs(t, client, mockAPIKeyAccountID)
+	email := "names@example.com"
+	mock.AddUser(&MockUser{AccountID: mockAPIKeyAccountID, Email: email, FirstName: "John", LastName: "Snow", RoleIDs: []int{roleIDs["Administrator"]}})
+
+	res := resourceAccountUser()
+	userSchema := schema.InternalMap(res.Schema)
+	d := res.TestResourceData()
+	d.SetId(strconv.Itoa(mockAPIKeyAccountID) + "/" + email)
+	if diags := resourceUserRead(ctx, d, client); diags.HasError() {
+		t.Fatalf("Unexpected error reading user: %v", diags)
+	}
+	if names := d.Get("role_names").(*schema.Set).List(); !reflect.DeepEqual(names, []interface{}{"Administrator"}) {
+		t.Fatalf("Expected the role names of the user, got %v", names)
+	}
+	state := d.State()
+	diff := func(roleID int, lastName string) (*terraform.InstanceDiff, error) {
+		return userSchema.Diff(ctx, state, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"email":      email,
+			"account_id": mockAPIKeyAccountID,
+			"first_name": "John",
+			"last_name":  lastName,
+			"role_ids":   []interface{}{roleID},
+		}), res.CustomizeDiff, client, true)
+	}
+
+	if unchanged, err := diff(roleIDs["Administrator"], "Snow"); err != nil || !unchanged.Empty() {
+		t.Errorf("Expected no diff for the same roles, got %+v %v", unchanged, err)
+	}
+	if _, err := diff(roleIDs["Administrator"], "Stark"); err == nil || !strings.Contains(err.Error(), "Cannot update") {
+		t.Errorf("Expected an error changing the name of the user, got %v", err)
+	}
+
+	// The role names are unknown until the new role IDs are applied
+	changed, err := diff(roleIDs["Reader"], "Snow")
+	if err != nil {
+		t.Fatalf("Unexpected error computing diff: %s", err)
+	}
+	if attribute := changed.Attributes["role_names.#"]; attribute == nil || !attribute.NewComputed {
+		t.Fatalf("Expected the role names to be computed, got %+v", changed.Attributes)
+	}
+	updated, err := userSchema.Data(state, changed)
+	if err != nil {
+		t.Fatalf("Unexpected error applying diff: %s", err)
+	}
+	if diags := resourceUserUpdate(ctx, updated, client); diags.HasError() {
+		t.Fatalf("Unexpected error updating user: %v", diags)
+	}
+	if names := updated.Get("role_names").(*schema.Set).List(); !reflect.DeepEqual(names, []interface{}{"Reader"}) {
+		t.Errorf("Expected the new role names, got %v", names)
+	}
+	if user := mock.GetUser(mockAPIKeyAccountID, email); !reflect.DeepEqual(user.RoleIDs, []int{roleIDs["Reader"]}) {
+		t.Errorf("Expected the new role IDs to be stored, got %v", user.RoleIDs)
+	}
+}
+
+func TestMockAPIClients(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	customer := &MockAccount{Email: "customer@example.com"}
+	mock.AddAccount(customer)
+	mock.AddUser(&MockUser{AccountID: customer.AccountID, Email: "dev@example.com"})
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+	keyClient := func(clientID int, apiKey string) *Client {
+		config := testConfigForURL(mock.URL())
+		config.APIID, config.APIKey = strconv.Itoa(clientID), apiKey
+		return NewClient(&config)
+	}
+
+	created, err := client.CreateAPIClient(ctx, customer.AccountID, "dev@example.com", &APIClientUpdateRequest{Name: "CI"})
+	if err != nil {
+		t.Fatalf("Unexpected error creating API client: %s", err)
+	}
+	nextYear := time.Now().UTC().AddDate(1, 0, 0).Format(mockDateFormat)
+	if created.APIKey == "" || !created.Enabled || created.ExpirationDate != nextYear || created.UserEmail != "dev@example.com" {
+		t.Fatalf("Unexpected API client: %+v", created)
+	}
+	clientID := strconv.Itoa(created.APIClientID)
+
+	// The key of the API client authenticates requests of its account, and is only returned when issued
+	if verified, err := keyClient(created.APIClientID, created.APIKey).Verify(ctx); err != nil || verified.AccountID != customer.AccountID {
+		t.Errorf("Expected the API client to authenticate as the customer, got %+v %v", verified, err)
+	}
+	read, err := client.GetAPIClient(ctx, customer.AccountID, clientID)
+	if err != nil || read.Name != "CI" || read.APIKey != "" {
+		t.Errorf("Unexpected read API client: %+v %v", read, err)
+	}
+
+	// Regenerating the key revokes the previous one
+	expirationDate := time.Now().UTC().AddDate(0, 1, 0).Format(mockDateFormat)
+	regenerated, err := client.PatchAPIClient(ctx, customer.AccountID, clientID, &APIClientUpdateRequest{ExpirationDate: expirationDate, Regenerate: true})
+	if err != nil || regenerated.APIKey == "" || regenerated.APIKey == created.APIKey || regenerated.ExpirationDate != expirationDate {
+		t.Fatalf("Unexpected regenerated API client: %+v %v", regenerated, err)
+	}
+	if _, err := keyClient(created.APIClientID, created.APIKey).Verify(ctx); !IsPermissionDenied(err) {
+		t.Errorf("Expected an authentication error for the previous key, got %v", err)
+	}
+	if _, err := keyClient(created.APIClientID, regenerated.APIKey).Verify(ctx); err != nil {
+		t.Errorf("Unexpected error authenticating with the new key: %s", err)
+	}
+
+	// Disabled and expired API clients don't authenticate
+	if _, err := client.PatchAPIClient(ctx, customer.AccountID, clientID, &APIClientUpdateRequest{Enabled: Bool(false)}); err != nil {
+		t.Fatalf("Unexpected error disabling API client: %s", err)
+	}
+	if _, err := keyClient(created.APIClientID, regenerated.APIKey).Verify(ctx); !IsPermissionDenied(err) {
+		t.Errorf("Expected an authentication error for a disabled API client, got %v", err)
+	}
+	yesterday := time.Now().UTC().AddDate(0, 0, -1).Format(mockDateFormat)
+	expired := &MockAPIClient{AccountID: customer.AccountID, Name: "Old", Enabled: true, ExpirationDate: yesterday}
+	mock.AddAPIClient(expired)
+	if _, err := keyClient(expired.ID, expired.APIKey).Verify(ctx); !IsPermissionDenied(err) {
+		t.Errorf("Expected an authentication error for an expired API client, got %v", err)
+	}
+	if _, err := client.CreateAPIClient(ctx, customer.AccountID, "", &APIClientUpdateRequest{Name: "Past", ExpirationDate: yesterday}); err == nil {
+		t.Errorf("Expected an error for a past expiration date")
+	}
+	if _, err := client.CreateAPIClient(ctx, customer.AccountID, "nobody@example.com", &APIClientUpdateRequest{Name: "Nobody"}); !IsNotFound(err) {
+		t.Errorf("Expected a not found error for an unknown user, got %v", err)
+	}
+
+	// API clients are part of the state
+	restored := NewMockImpervaServer()
+	defer restored.Close()
+	if err := restored.LoadState(mock.Snapshot()); err != nil {
+		t.Fatalf("Unexpected error loading state: %s", err)
+	}
+	if !reflect.DeepEqual(restored.GetAPIClient(created.APIClientID), mock.GetAPIClient(created.APIClientID)) || restored.GetUser(customer.AccountID, "dev@example.com") == nil {
+		t.Errorf("Expected the API clients and users to be restored")
+	}
+
+	if err := client.DeleteAPIClient(ctx, customer.AccountID, clientID); err != nil {
+		t.Fatalf("Unexpected error deleting API client: %s", err)
+	}
+	if _, err := client.GetAPIClient(ctx, customer.AccountID, clientID); !IsNotFound(err) {
+		t.Errorf("Expected a not found error reading the deleted API client, got %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+// Mock Imperva API Server - waiting rooms
+//
+// The endpoints mirror the requests of client_waiting_room.go. Like the real API, a waiting room needs at least one
+// activation threshold, its name is unique among the waiting rooms of its site and its HTML template must keep the
+// mandatory placeholders. A PUT replaces the settings of a waiting room; its ID, account and creation time are kept.
+
+package incapsula
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var mockWaitingRoomsPattern = regexp.MustCompile(`^waiting-room-settings/v3/sites/(\d+)/waiting-rooms(?:/(\d+))?$`)
+
+// mockWaitingRoomPlaceholders are the placeholders the HTML template of a waiting room must contain
+var mockWaitingRoomPlaceholders = []string{"$WAITING_ROOM_CONFIG$", "$WAITING_ROOM_LOADER$", "$WAITING_ROOM_WRAPPER$"}
+
+// MockWaitingRoom represents a waiting room in the mock server
+type MockWaitingRoom struct {
+	WaitingRoomDTO
+	SiteID int `json:"site_id"`
+}
+
+// handleWaitingRooms handles GET/POST /waiting-room-settings/v3/sites/{siteId}/waiting-rooms and
+// GET/PUT/DELETE /waiting-room-settings/v3/sites/{siteId}/waiting-rooms/{waitingRoomId}
+func (m *MockImpervaServer) handleWaitingRooms(w http.ResponseWriter, r *http.Request, path string) {
+	matches := mockWaitingRoomsPattern.FindStringSubmatch(path)
+	siteID, _ := strconv.Atoi(matches[1])
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	site, exists := m.sites[siteID]
+	if !exists || !m.ownedByRequest(r, siteAccountID(site)) {
+		m.writeV3ErrorResponse(w, http.StatusNotFound, "", fmt.Sprintf("Site %d not found", siteID))
+		return
+	}
+
+	if matches[2] == "" {
+		switch r.Method {
+		case http.MethodGet:
+			waitingRooms := []WaitingRoomDTO{}
+			for _, waitingRoom := range m.siteWaitingRooms(siteID) {
+				waitingRooms = append(waitingRooms, waitingRoom.WaitingRoomDTO)
+			}
+			m.writeJSONResponse(w, WaitingRoomDTOResponse{Data: waitingRooms})
+		case http.MethodPost:
+			waitingRoom, ok := m.decodeWaitingRoom(w, r, siteID, 0)
+			if !ok {
+				return
+			}
+			waitingRoom.Id = int64(m.nextWaitingRoomID)
+			m.nextWaitingRoomID++
+			waitingRoom.AccountId = int64(siteAccountID(site))
+			waitingRoom.CreatedAt = waitingRoom.LastModifiedAt
+			waitingRoom.Mode = "NOT_QUEUING"
+			m.waitingRooms[int(waitingRoom.Id)] = &MockWaitingRoom{WaitingRoomDTO: *waitingRoom, SiteID: siteID}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(WaitingRoomDTOResponse{Data: []WaitingRoomDTO{*waitingRoom}})
+		default:
+			m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+		}
+		return
+	}
+
+	waitingRoomID, _ := strconv.Atoi(matches[2])
+	existing, exists := m.waitingRooms[waitingRoomID]
+	if !exists || existing.SiteID != siteID {
+		m.writeV3ErrorResponse(w, http.StatusNotFound, "", fmt.Sprintf("Waiting room %d not found", waitingRoomID))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		waitingRoom, ok := m.decodeWaitingRoom(w, r, siteID, waitingRoomID)
+		if !ok {
+			return
+		}
+		waitingRoom.Id = existing.Id
+		waitingRoom.AccountId = existing.AccountId
+		waitingRoom.CreatedAt = existing.CreatedAt
+		waitingRoom.Mode = existing.Mode
+		existing.WaitingRoomDTO = *waitingRoom
+	case http.MethodDelete:
+		delete(m.waitingRooms, waitingRoomID)
+	default:
+		m.writeV3ErrorResponse(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("Method not allowed: %s", r.Method))
+		return
+	}
+	m.writeJSONResponse(w, WaitingRoomDTOResponse{Data: []WaitingRoomDTO{existing.WaitingRoomDTO}})
+}
+
+// decodeWaitingRoom reads a waiting room from the request body and validates it like the API does, stamping it with
+// the time and author of the change. The caller must hold the lock.
+func (m *MockImpervaServer) decodeWaitingRoom(w http.ResponseWriter, r *http.Request, siteID, waitingRoomID int) (*WaitingRoomDTO, bool) {
+	var waitingRoom WaitingRoomDTO
+	body, _ := ioutil.ReadAll(r.Body)
+	if err := json.Unmarshal(body, &waitingRoom); err != nil {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, "", fmt.Sprintf("Invalid request body: %s", err))
+		return nil, false
+	}
+	if pointer, message := validateMockWaitingRoom(&waitingRoom); message != "" {
+		m.writeV3ErrorResponse(w, http.StatusBadRequest, pointer, message)
+		return nil, false
+	}
+	for _, other := range m.siteWaitingRooms(siteID) {
+		if other.Name == waitingRoom.Name && int(other.Id) != waitingRoomID {
+			m.writeV3ErrorResponse(w, http.StatusBadRequest, "/name", fmt.Sprintf("A waiting room named %s already exists for site %d", waitingRoom.Name, siteID))
+			return nil, false
+		}
+	}
+
+	waitingRoom.LastModifiedAt = time.Now().UnixNano() / int64(time.Millisecond)
+	waitingRoom.LastModifiedBy = "mock-user@example.com"
+	if account := m.lookupAccount(mockCallerAccountID(r)); account != nil && account.Email != "" {
+		waitingRoom.LastModifiedBy = account.Email
+	}
+	return &waitingRoom, true
+}
+
+// validateMockWaitingRoom validates the settings of a waiting room. It returns the JSON pointer of the field at fault
+// and the error message.
+func validateMockWaitingRoom(waitingRoom *WaitingRoomDTO) (string, string) {
+	if strings.TrimSpace(waitingRoom.Name) == "" {
+		return "/name", "name is required"
+	}
+	if waitingRoom.HtmlTemplateBase64 != "" {
+		template, err := base64.StdEncoding.DecodeString(waitingRoom.HtmlTemplateBase64)
+		if err != nil {
+			return "/htmlTemplateBase64", "htmlTemplateBase64 is not valid Base64"
+		}
+		for _, placeholder := range mockWaitingRoomPlaceholders {
+			if !strings.Contains(string(template), placeholder) {
+				return "/htmlTemplateBase64", fmt.Sprintf("The HTML template must contain the %s placeholder", placeholder)
+			}
+		}
+	}
+	if waitingRoom.BotsActionInQueuingMode == "" {
+		waitingRoom.BotsActionInQueuingMode = "WAIT_IN_LINE"
+	} else if !contains([]string{"WAIT_IN_LINE", "BYPASS", "BLOCK"}, waitingRoom.BotsActionInQueuingMode) {
+		return "/botsActionInQueuingMode", fmt.Sprintf("Invalid botsActionInQueuingMode: %s", waitingRoom.BotsActionInQueuingMode)
+	}
+	if waitingRoom.QueueInactivityTimeout == 0 {
+		waitingRoom.QueueInactivityTimeout = 1
+	} else if waitingRoom.QueueInactivityTimeout < 1 || waitingRoom.QueueInactivityTimeout > 10 {
+		return "/queueInactivityTimeout", "queueInactivityTimeout must be between 1 and 10 minutes"
+	}
+
+	thresholds := &waitingRoom.ThresholdSettings
+	if !thresholds.EntranceRateEnabled && !thresholds.ConcurrentSessionsEnabled {
+		return "/thresholdSettings", "At least one of the entrance rate and concurrent sessions thresholds must be enabled"
+	}
+	if thresholds.EntranceRateEnabled && thresholds.EntranceRateThreshold < 60 {
+		return "/thresholdSettings/entranceRateThreshold", "entranceRateThreshold must be at least 60 users per minute"
+	}
+	if thresholds.ConcurrentSessionsEnabled && thresholds.ConcurrentSessionsThreshold < 1 {
+		return "/thresholdSettings/concurrentSessionsThreshold", "concurrentSessionsThreshold must be at least 1"
+	}
+	if thresholds.InactivityTimeout == 0 {
+		thresholds.InactivityTimeout = 5
+	} else if thresholds.InactivityTimeout < 1 || thresholds.InactivityTimeout > 30 {
+		return "/thresholdSettings/inactivityTimeout", "inactivityTimeout must be between 1 and 30 minutes"
+	}
+	return "", ""
+}
+
+// siteWaitingRooms returns the waiting rooms of a site by ID. The caller must hold the lock.
+func (m *MockImpervaServer) siteWaitingRooms(siteID int) []*MockWaitingRoom {
+	waitingRooms := []*MockWaitingRoom{}
+	for _, waitingRoom := range m.waitingRooms {
+		if waitingRoom.SiteID == siteID {
+			waitingRooms = append(waitingRooms, waitingRoom)
+		}
+	}
+	sort.Slice(waitingRooms, func(i, j int) bool { return waitingRooms[i].Id < waitingRooms[j].Id })
+	return waitingRooms
+}
+
+// Helper methods for tests
+
+// GetWaitingRoom returns a waiting room by ID (for test assertions)
+func (m *MockImpervaServer) GetWaitingRoom(waitingRoomID int) *MockWaitingRoom {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.waitingRooms[waitingRoomID]
+}
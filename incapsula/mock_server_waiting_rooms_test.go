@@ -0,0 +1,87 @@
+package incapsula
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestMockWaitingRooms(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+	site := &MockSite{Domain: "queue.example.com"}
+	mock.AddSite(site)
+	config := testConfigForURL(mock.URL())
+	client := NewClient(&config)
+	ctx := context.Background()
+	siteID := strconv.Itoa(site.SiteID)
+
+	res := resourceWaitingRoom()
+	template := base64.StdEncoding.EncodeToString([]byte("<html>$WAITING_ROOM_CONFIG$ $WAITING_ROOM_LOADER$ $WAITING_ROOM_WRAPPER$</html>"))
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"site_id":                 siteID,
+		"name":                    "peak",
+		"description":             "Peak time queue",
+		"html_template_base64":    template,
+		"entrance_rate_threshold": 600,
+	})
+	if diags := resourceWaitingRoomCreate(ctx, d, client); diags.HasError() {
+		t.Fatalf("Unexpected error creating waiting room: %v", diags)
+	}
+	if d.Id() == "" || d.Get("mode") != "NOT_QUEUING" || d.Get("account_id") != strconv.Itoa(mockAPIKeyAccountID) || d.Get("created_at") == "0" || d.Get("last_modified_by") == "" {
+		t.Errorf("Unexpected waiting room state: %v", d.State())
+	}
+	if d.Get("entrance_rate_threshold") != 600 || d.Get("concurrent_sessions_threshold") != 0 || d.Get("inactivity_timeout") != 5 || d.Get("queue_inactivity_timeout") != 1 {
+		t.Errorf("Unexpected waiting room thresholds: %v", d.State())
+	}
+
+	// Updates replace the settings, keeping the creation time
+	createdAt := d.Get("created_at")
+	d.Set("description", "")
+	d.Set("entrance_rate_threshold", 0)
+	d.Set("concurrent_sessions_threshold", 1000)
+	if diags := resourceWaitingRoomUpdate(ctx, d, client); diags.HasError() {
+		t.Fatalf("Unexpected error updating waiting room: %v", diags)
+	}
+	waitingRoomID, _ := strconv.Atoi(d.Id())
+	waitingRoom := mock.GetWaitingRoom(waitingRoomID)
+	if waitingRoom.Description != "" || waitingRoom.ThresholdSettings.EntranceRateEnabled || waitingRoom.ThresholdSettings.ConcurrentSessionsThreshold != 1000 {
+		t.Errorf("Expected the waiting room settings to be replaced, got %+v", waitingRoom.WaitingRoomDTO)
+	}
+	if d.Get("created_at") != createdAt || d.Get("concurrent_sessions_threshold") != 1000 {
+		t.Errorf("Unexpected waiting room state after update: %v", d.State())
+	}
+
+	// The API validates names, thresholds and templates
+	invalid := []WaitingRoomDTO{
+		{Name: "peak", ThresholdSettings: ThresholdSettings{ConcurrentSessionsEnabled: true, ConcurrentSessionsThreshold: 10}},
+		{Name: "no thresholds"},
+		{Name: "slow", ThresholdSettings: ThresholdSettings{EntranceRateEnabled: true, EntranceRateThreshold: 30}},
+		{Name: "template", HtmlTemplateBase64: base64.StdEncoding.EncodeToString([]byte("<html>$WAITING_ROOM_CONFIG$</html>")), ThresholdSettings: ThresholdSettings{ConcurrentSessionsEnabled: true, ConcurrentSessionsThreshold: 10}},
+	}
+	for _, waitingRoom := range invalid {
+		if _, err := client.CreateWaitingRoom(ctx, "", siteID, &waitingRoom); err == nil {
+			t.Errorf("Expected an error creating waiting room %s", waitingRoom.Name)
+		}
+	}
+	if _, err := client.CreateWaitingRoom(ctx, "", "42", &WaitingRoomDTO{Name: "unknown"}); !IsNotFound(err) || !strings.Contains(err.Error(), "Site 42 not found") {
+		t.Errorf("Expected a not found error for an unknown site, got %v", err)
+	}
+	if snapshot := mock.Snapshot(); len(snapshot.WaitingRooms) != 1 || snapshot.NextIDs.WaitingRoom != waitingRoomID+1 {
+		t.Errorf("Expected one waiting room in the snapshot, got %+v %+v", snapshot.WaitingRooms, snapshot.NextIDs)
+	}
+
+	// Deleted waiting rooms are removed from the state
+	id := d.Id()
+	if diags := resourceWaitingRoomDelete(ctx, d, client); diags.HasError() {
+		t.Fatalf("Unexpected error deleting waiting room: %v", diags)
+	}
+	d.SetId(id)
+	if diags := resourceWaitingRoomRead(ctx, d, client); diags.HasError() || d.Id() != "" {
+		t.Errorf("Expected the deleted waiting room to be removed from the state, got %v %s", diags, d.Id())
+	}
+}
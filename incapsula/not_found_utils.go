@@ -0,0 +1,35 @@
+package incapsula
+
+import (
+	"errors"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// removeGoneResource removes the resource from the state when err reports that the object, or the
+// site or account it belongs to, no longer exists (e.g. it was deleted in the Imperva console).
+// Read functions call it before failing, so that the next plan recreates the resource instead of
+// failing the whole run. It returns true when the resource was removed from the state.
+func removeGoneResource(d *schema.ResourceData, err error, resourceType string) bool {
+	if err == nil || !IsNotFound(err) {
+		return false
+	}
+
+	log.Printf("[WARN] Incapsula %s %s no longer exists, removing it from the state: %s\n", resourceType, d.Id(), err)
+	d.SetId("")
+	return true
+}
+
+// removeGoneAccount does the same as removeGoneResource for the account and subaccount resources, for which
+// an unknown account_id (res 9403) also means the account was deleted. Other resources don't treat 9403 as
+// gone, since the API also returns it for an account the API key may not access (e.g. a wrong caid).
+func removeGoneAccount(d *schema.ResourceData, err error, resourceType string) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Code == apiV1ResUnknownAccountID {
+		log.Printf("[WARN] Incapsula %s %s no longer exists, removing it from the state: %s\n", resourceType, d.Id(), err)
+		d.SetId("")
+		return true
+	}
+	return removeGoneResource(d, err, resourceType)
+}
@@ -0,0 +1,90 @@
+package incapsula
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func testNotFoundResourceData(t *testing.T) *schema.ResourceData {
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+		"site_id": {Type: schema.TypeString, Optional: true},
+	}, map[string]interface{}{"site_id": "42"})
+	d.SetId("7")
+	return d
+}
+
+func TestRemoveGoneResourceNotFoundStatus(t *testing.T) {
+	d := testNotFoundResourceData(t)
+	err := newAPIError(&http.Response{StatusCode: http.StatusNotFound}, nil, nil, "not found")
+
+	if !removeGoneResource(d, err, "incap rule") {
+		t.Errorf("Expected the resource to be removed")
+	}
+	if d.Id() != "" {
+		t.Errorf("Expected an empty ID, got %s", d.Id())
+	}
+}
+
+func TestRemoveGoneResourceUnknownSite(t *testing.T) {
+	d := testNotFoundResourceData(t)
+	body := []byte(`{"res":9413,"res_message":"Unknown/unauthorized site_id"}`)
+	err := newAPIError(&http.Response{StatusCode: http.StatusOK}, body, nil, "Error from Incapsula service: %s", string(body))
+
+	if !removeGoneResource(d, err, "site") {
+		t.Errorf("Expected the resource to be removed")
+	}
+	if d.Id() != "" {
+		t.Errorf("Expected an empty ID, got %s", d.Id())
+	}
+}
+
+func TestRemoveGoneResourceOtherErrors(t *testing.T) {
+	d := testNotFoundResourceData(t)
+	errs := []error{
+		nil,
+		errors.New("404"),
+		newAPIError(&http.Response{StatusCode: http.StatusInternalServerError}, nil, nil, "server error"),
+		newAPIError(&http.Response{StatusCode: http.StatusForbidden}, nil, nil, "forbidden"),
+		newAPIError(&http.Response{StatusCode: http.StatusOK}, []byte(`{"res":9403,"res_message":"Unknown/unauthorized account_id"}`), nil, "unknown account"),
+	}
+
+	for _, err := range errs {
+		if removeGoneResource(d, err, "site") {
+			t.Errorf("Expected the resource not to be removed for error: %v", err)
+		}
+	}
+	if d.Id() != "7" {
+		t.Errorf("Expected the ID to be kept, got %s", d.Id())
+	}
+}
+
+func TestRemoveGoneResourceV3Errors(t *testing.T) {
+	d := testNotFoundResourceData(t)
+	body := []byte(`{"errors":[{"status":"404","title":"Not Found","detail":"Site 42 not found"}]}`)
+	err := newAPIError(&http.Response{StatusCode: http.StatusNotFound}, body, nil, "Error from Incapsula service: %s", string(body))
+
+	if !removeGoneResource(d, err, "waiting room") {
+		t.Errorf("Expected the resource to be removed")
+	}
+	if d.Id() != "" {
+		t.Errorf("Expected an empty ID, got %s", d.Id())
+	}
+}
+
+func TestRemoveGoneAccount(t *testing.T) {
+	d := testNotFoundResourceData(t)
+	err := newAPIError(&http.Response{StatusCode: http.StatusOK}, []byte(`{"res":9403,"res_message":"Unknown/unauthorized account_id"}`), nil, "unknown account")
+
+	if IsNotFound(err) || !IsPermissionDenied(err) {
+		t.Errorf("Expected an unknown account to be a permission denied error")
+	}
+	if !removeGoneAccount(d, err, "subaccount") {
+		t.Errorf("Expected the account to be removed")
+	}
+	if d.Id() != "" {
+		t.Errorf("Expected an empty ID, got %s", d.Id())
+	}
+}
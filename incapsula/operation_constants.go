@@ -116,13 +116,13 @@ const DeleteCspSiteDomain = "delete_csp_site_domain"
 
 const CreateATOSiteAllowlistOperation = "create_ato_site_allowlist"
 const ReadATOSiteAllowlistOperation = "read_ato_site_allowlist"
-const UpdateATOSiteAllowlistOperation = "read_ato_site_allowlist"
-const DeleteATOSiteAllowlistOperation = "read_ato_site_allowlist"
+const UpdateATOSiteAllowlistOperation = "update_ato_site_allowlist"
+const DeleteATOSiteAllowlistOperation = "delete_ato_site_allowlist"
 
 const CreateATOSiteMitigationConfigurationOperation = "create_ato_site_mitigation_configuration"
 const ReadATOSiteMitigationConfigurationOperation = "read_ato_site_mitigation_configuration"
-const UpdateATOSiteMitigationConfigurationOperation = "read_ato_site_mitigation_configuration"
-const DeleteATOSiteMitigationConfigurationOperation = "read_ato_site_mitigation_configuration"
+const UpdateATOSiteMitigationConfigurationOperation = "update_ato_site_mitigation_configuration"
+const DeleteATOSiteMitigationConfigurationOperation = "delete_ato_site_mitigation_configuration"
 
 const CreateNotificationCenterPolicy = "create_notification_center_policy"
 const ReadNotificationCenterPolicy = "read_notification_center_policy"
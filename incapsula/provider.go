@@ -1,7 +1,12 @@
 package incapsula
 
 import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 var baseURL string
@@ -25,17 +30,100 @@ func init() {
 			"from the Incapsula management console. Can be set via INCAPSULA_API_KEY " +
 			"environment variable.",
 
-		"base_url": "The base URL for API operations. Used for provider development.",
+		"base_url": "The base URL for API operations. Used for provider development. " +
+			"Can be set via INCAPSULA_BASE_URL environment variable.",
+
+		"base_url_rev_2": "The base URL (revision 2) for API operations. Used for provider development. " +
+			"Can be set via INCAPSULA_BASE_URL_REV_2 environment variable.",
+
+		"base_url_rev_3": "The base URL (revision 3) for API operations. Used for provider development. " +
+			"Can be set via INCAPSULA_BASE_URL_REV_3 environment variable.",
+
+		"base_url_api": "The base URL (same as v2 but with different subdomain) for API operations. Used for provider development. " +
+			"Can be set via INCAPSULA_BASE_URL_API environment variable.",
+
+		"environment": "The environment whose base URLs are used: production (default) or mock, the local mock server " +
+			"(cmd/mock-server) on its default port. The base URL arguments override the URLs of the environment. " +
+			"Can be set via INCAPSULA_ENVIRONMENT environment variable.",
+
+		"profile": "The profile of the shared credentials file to read the API credentials and base URLs from. " +
+			"Can be set via INCAPSULA_PROFILE environment variable. Defaults to default. " +
+			"Values set in the provider block take precedence over environment variables, which take precedence over the profile.",
+
+		"shared_credentials_file": "The path of the shared credentials file (INI or JSON) holding the profiles. " +
+			"Can be set via INCAPSULA_SHARED_CREDENTIALS_FILE environment variable. Defaults to ~/.incapsula/credentials.",
+
+		"max_requests_per_second": "The maximum number of API requests per second the provider sends to Imperva. " +
+			"The limit is shared by all resources using this provider. Set to 0 (default) for no limit.",
+
+		"max_concurrent_requests": "The maximum number of API requests the provider keeps in flight at the same time. " +
+			"The limit is shared by all resources using this provider. Set to 0 (default) for no limit.",
+
+		"retry": "Retry policy for transient API failures (429, 5xx and HTML error pages). " +
+			"Each provider block (including aliases) can use its own policy.",
+
+		"retry_max_attempts": "The total number of attempts per API request, including the first one.",
+
+		"retry_min_backoff_seconds": "The backoff in seconds before the first retry. It is doubled on every further retry. " +
+			"Must not be greater than max_backoff_seconds.",
+
+		"retry_max_backoff_seconds": "The maximum backoff in seconds between two attempts.",
+
+		"retry_retryable_status_codes": "Additional HTTP status codes to retry, on top of 429 and 5xx.",
+
+		"retry_non_idempotent": "Whether to also retry write requests (create/update/delete) on 5xx and on the additional " +
+			"retryable status codes. Writes may not be idempotent, so by default they are only retried when the error " +
+			"shows the request never reached the API.",
+
+		"proxy_url": "The URL of the proxy used for all API requests, e.g. http://proxy.example.com:3128. " +
+			"Can be set via INCAPSULA_PROXY_URL environment variable. When not set, the HTTPS_PROXY, HTTP_PROXY " +
+			"and NO_PROXY environment variables are used.",
+
+		"ca_bundle_file": "The path of a PEM file with the CA certificates trusted for the API endpoints and the proxy. " +
+			"When set, the system root certificates are not trusted. Can be set via INCAPSULA_CA_BUNDLE_FILE environment variable.",
+
+		"client_cert_file": "The path of a PEM file with the client certificate presented when the API endpoints or the " +
+			"proxy require mutual TLS. Must be set together with client_key_file. Can be set via " +
+			"INCAPSULA_CLIENT_CERT_FILE environment variable.",
+
+		"client_key_file": "The path of a PEM file with the private key of the client certificate. Can be set via " +
+			"INCAPSULA_CLIENT_KEY_FILE environment variable.",
 
-		"base_url_rev_2": "The base URL (revision 2) for API operations. Used for provider development.",
+		"insecure_skip_verify": "Whether to skip the verification of the API endpoints' TLS certificates. " +
+			"Only meant for the mock server used in provider development.",
 
-		"base_url_rev_3": "The base URL (revision 3) for API operations. Used for provider development.",
+		"request_timeout_seconds": "The timeout in seconds of a single API request, including reading the response. " +
+			"Set to 0 (default) for no timeout.",
 
-		"base_url_api": "The base URL (same as v2 but with different subdomain) for API operations. Used for provider development.",
+		"idle_conn_timeout_seconds": "The time in seconds an idle (keep-alive) connection to the API is kept open.",
+
+		"max_idle_conns": "The maximum number of idle (keep-alive) connections kept open to all API endpoints.",
+
+		"max_idle_conns_per_host": "The maximum number of idle (keep-alive) connections kept open per API endpoint.",
+
+		"log_body_file": "The path of a file the full API request and response bodies are appended to, for troubleshooting. " +
+			"Secret headers and fields (API key, passwords, private keys, tokens) are redacted. " +
+			"Can be set via INCAPSULA_LOG_BODY_FILE environment variable.",
+
+		"read_cache_ttl_seconds": "How long in seconds the responses of the heavy read operations (e.g. the site status) " +
+			"are cached and shared by all the resources, so that a refresh calls them once per site. " +
+			"A write to a site evicts the responses cached for it. Defaults to 0, which disables the cache.",
+
+		"skip_credentials_validation": "Don't check the API credentials when the provider is configured, but before its " +
+			"first API call, so that the provider can be configured without a reachable API (e.g. in validation jobs or " +
+			"with data sources only). Can be set via INCAPSULA_SKIP_CREDENTIALS_VALIDATION environment variable.",
+
+		"read_only": "Refuse every API call that would change the configuration (create, update and delete), so that " +
+			"plan and refresh can be run safely with credentials allowed to make changes. " +
+			"Can be set via INCAPSULA_READ_ONLY environment variable.",
+
+		"default_account_id": "The account (e.g. a sub-account of a reseller) the resources are managed in when their " +
+			"account_id is not set. Sent as caid by the API calls that take a caid and don't name their account. " +
+			"Can be set via INCAPSULA_DEFAULT_ACCOUNT_ID environment variable.",
 	}
 }
 
-func providerConfigure(d *schema.ResourceData, terraformVersion string) (interface{}, error) {
+func providerConfigure(ctx context.Context, d *schema.ResourceData, terraformVersion string) (interface{}, error) {
 	config := Config{
 		APIID:       d.Get("api_id").(string),
 		APIKey:      d.Get("api_key").(string),
@@ -43,9 +131,51 @@ func providerConfigure(d *schema.ResourceData, terraformVersion string) (interfa
 		BaseURLRev2: d.Get("base_url_rev_2").(string),
 		BaseURLRev3: d.Get("base_url_rev_3").(string),
 		BaseURLAPI:  d.Get("base_url_api").(string),
+
+		MaxRequestsPerSecond:  d.Get("max_requests_per_second").(float64),
+		MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+		Retry:                 expandRetryPolicy(d.Get("retry").([]interface{})),
+
+		ProxyURL:            d.Get("proxy_url").(string),
+		CABundleFile:        d.Get("ca_bundle_file").(string),
+		ClientCertFile:      d.Get("client_cert_file").(string),
+		ClientKeyFile:       d.Get("client_key_file").(string),
+		InsecureSkipVerify:  d.Get("insecure_skip_verify").(bool),
+		RequestTimeout:      time.Duration(d.Get("request_timeout_seconds").(int)) * time.Second,
+		IdleConnTimeout:     time.Duration(d.Get("idle_conn_timeout_seconds").(int)) * time.Second,
+		MaxIdleConns:        d.Get("max_idle_conns").(int),
+		MaxIdleConnsPerHost: d.Get("max_idle_conns_per_host").(int),
+		LogBodyFile:         d.Get("log_body_file").(string),
+		ReadCacheTTL:        time.Duration(d.Get("read_cache_ttl_seconds").(int)) * time.Second,
+		DefaultAccountID:    d.Get("default_account_id").(int),
+
+		SkipCredentialsValidation: d.Get("skip_credentials_validation").(bool),
+		ReadOnly:                  d.Get("read_only").(bool),
+
+		Environment:           d.Get("environment").(string),
+		Profile:               d.Get("profile").(string),
+		SharedCredentialsFile: d.Get("shared_credentials_file").(string),
 	}
 
-	return config.Client()
+	return config.Client(ctx)
+}
+
+func expandRetryPolicy(retry []interface{}) *RetryPolicy {
+	policy := defaultRetryPolicy
+	if len(retry) == 0 || retry[0] == nil {
+		return &policy
+	}
+
+	retryMap := retry[0].(map[string]interface{})
+	policy.MaxAttempts = retryMap["max_attempts"].(int)
+	policy.MinBackoff = time.Duration(retryMap["min_backoff_seconds"].(int)) * time.Second
+	policy.MaxBackoff = time.Duration(retryMap["max_backoff_seconds"].(int)) * time.Second
+	policy.RetryNonIdempotent = retryMap["retry_non_idempotent"].(bool)
+	for _, code := range retryMap["retryable_status_codes"].(*schema.Set).List() {
+		policy.RetryableStatusCodes = append(policy.RetryableStatusCodes, code.(int))
+	}
+
+	return &policy
 }
 
 // Provider returns a *schema.Provider.
@@ -55,39 +185,201 @@ func Provider() *schema.Provider {
 			"api_id": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_API_ID", ""),
 				Description: descriptions["api_id"],
 			},
 			"api_key": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_API_KEY", ""),
 				Description: descriptions["api_key"],
 			},
 			"base_url": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_BASE_URL", baseURL),
 				Description: descriptions["base_url"],
 			},
 			"base_url_rev_2": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_BASE_URL_REV_2", baseURLRev2),
 				Description: descriptions["base_url_rev_2"],
 			},
 			"base_url_rev_3": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_BASE_URL_REV_3", baseURLRev3),
 				Description: descriptions["base_url_rev_3"],
 			},
 			"base_url_api": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_BASE_URL_API", baseURLAPI),
 				Description: descriptions["base_url_api"],
 			},
+			"environment": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc(environmentEnv, environmentProduction),
+				ValidateFunc: validation.StringInSlice(environmentNames(), false),
+				Description:  descriptions["environment"],
+			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: descriptions["profile"],
+			},
+			"shared_credentials_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: descriptions["shared_credentials_file"],
+			},
+			"max_requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      0.0,
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  descriptions["max_requests_per_second"],
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  descriptions["max_concurrent_requests"],
+			},
+			"retry": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["retry"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_attempts": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      defaultRetryPolicy.MaxAttempts,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  descriptions["retry_max_attempts"],
+						},
+						"min_backoff_seconds": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      int(defaultRetryPolicy.MinBackoff / time.Second),
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  descriptions["retry_min_backoff_seconds"],
+						},
+						"max_backoff_seconds": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      int(defaultRetryPolicy.MaxBackoff / time.Second),
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  descriptions["retry_max_backoff_seconds"],
+						},
+						"retryable_status_codes": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: descriptions["retry_retryable_status_codes"],
+							Elem: &schema.Schema{
+								Type:         schema.TypeInt,
+								ValidateFunc: validation.IntBetween(400, 599),
+							},
+						},
+						"retry_non_idempotent": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: descriptions["retry_non_idempotent"],
+						},
+					},
+				},
+			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("INCAPSULA_PROXY_URL", ""),
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+				Description:  descriptions["proxy_url"],
+			},
+			"ca_bundle_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_CA_BUNDLE_FILE", ""),
+				Description: descriptions["ca_bundle_file"],
+			},
+			"client_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_CLIENT_CERT_FILE", ""),
+				Description: descriptions["client_cert_file"],
+			},
+			"client_key_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_CLIENT_KEY_FILE", ""),
+				Description: descriptions["client_key_file"],
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: descriptions["insecure_skip_verify"],
+			},
+			"request_timeout_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  descriptions["request_timeout_seconds"],
+			},
+			"idle_conn_timeout_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      90,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  descriptions["idle_conn_timeout_seconds"],
+			},
+			"max_idle_conns": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  descriptions["max_idle_conns"],
+			},
+			"max_idle_conns_per_host": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      2,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  descriptions["max_idle_conns_per_host"],
+			},
+			"log_body_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_LOG_BODY_FILE", ""),
+				Description: descriptions["log_body_file"],
+			},
+			"read_cache_ttl_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  descriptions["read_cache_ttl_seconds"],
+			},
+			"skip_credentials_validation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_SKIP_CREDENTIALS_VALIDATION", false),
+				Description: descriptions["skip_credentials_validation"],
+			},
+			"read_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("INCAPSULA_READ_ONLY", false),
+				Description: descriptions["read_only"],
+			},
+			"default_account_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("INCAPSULA_DEFAULT_ACCOUNT_ID", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  descriptions["default_account_id"],
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -160,15 +452,26 @@ func Provider() *schema.Provider {
 		},
 	}
 
-	provider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+	provider.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		terraformVersion := provider.TerraformVersion
 		if terraformVersion == "" {
 			// Terraform 0.12 introduced this field to the protocol
 			// We can therefore assume that if it's missing it's 0.10 or 0.11
 			terraformVersion = "0.11+compatible"
 		}
-		return providerConfigure(d, terraformVersion)
+		setupTracing(ctx)
+		client, err := providerConfigure(ctx, d, terraformVersion)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		return client, nil
 	}
 
+	// New resources without an account_id plan the default_account_id
+	defaultAccountIDResources(provider)
+
+	// One span per CRUD operation when OpenTelemetry tracing is enabled
+	traceResources(provider)
+
 	return provider
 }
@@ -2,9 +2,11 @@ package incapsula
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -45,9 +47,20 @@ func TestProvider_impl(t *testing.T) {
 }
 
 func testAccPreCheck(t *testing.T) {
+	// Each acceptance test records to (or replays from) its own cassette
+	SetCassetteName(t.Name())
+
 	testAccProviderConfigure.Do(func() {
 		if ShouldUseMockServer() {
 			setupMockServerForAcceptanceTests(t)
+		} else if IsReplayMode() {
+			// The API is not called when replaying, so any credentials will do
+			if _, ok := os.LookupEnv("INCAPSULA_API_ID"); !ok {
+				os.Setenv("INCAPSULA_API_ID", "replay-api-id")
+			}
+			if _, ok := os.LookupEnv("INCAPSULA_API_KEY"); !ok {
+				os.Setenv("INCAPSULA_API_KEY", "replay-api-key")
+			}
 		} else {
 			if v := os.Getenv("INCAPSULA_API_ID"); v == "" {
 				t.Fatal("INCAPSULA_API_ID must be set for acceptance tests")
@@ -91,3 +104,108 @@ func IsMockServerMode() bool {
 func GetMockServer() *MockImpervaServer {
 	return mockServer
 }
+
+func TestProviderRetryPolicy(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+
+	configureProvider := func(raw map[string]interface{}) *Client {
+		raw["api_id"] = "mock-api-id"
+		raw["api_key"] = "mock-api-key"
+		raw["base_url"] = mock.URL()
+		raw["base_url_rev_2"] = mock.URL()
+		raw["base_url_rev_3"] = mock.URL()
+		raw["base_url_api"] = mock.URL()
+
+		provider := Provider()
+		diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+		if diags.HasError() {
+			t.Fatalf("Unexpected error configuring provider: %v", diags)
+		}
+		return provider.Meta().(*Client)
+	}
+
+	defaultClient := configureProvider(map[string]interface{}{})
+	policy := defaultClient.retryPolicy()
+	if policy.MaxAttempts != 5 || policy.MinBackoff != time.Second || policy.MaxBackoff != 30*time.Second || policy.RetryNonIdempotent {
+		t.Errorf("Expected the default retry policy, got %+v", policy)
+	}
+
+	customClient := configureProvider(map[string]interface{}{
+		"retry": []interface{}{
+			map[string]interface{}{
+				"max_attempts":           2,
+				"min_backoff_seconds":    3,
+				"max_backoff_seconds":    10,
+				"retryable_status_codes": []interface{}{409},
+				"retry_non_idempotent":   true,
+			},
+		},
+	})
+	policy = customClient.retryPolicy()
+	if policy.MaxAttempts != 2 || policy.MinBackoff != 3*time.Second || policy.MaxBackoff != 10*time.Second {
+		t.Errorf("Expected the configured attempts and backoff, got %+v", policy)
+	}
+	if !policy.RetryNonIdempotent || len(policy.RetryableStatusCodes) != 1 || policy.RetryableStatusCodes[0] != 409 {
+		t.Errorf("Expected retry_non_idempotent and status code 409, got %+v", policy)
+	}
+
+	// Each provider instance keeps its own policy
+	if defaultClient.retryPolicy().MaxAttempts != 5 {
+		t.Errorf("Configuring a second provider changed the first provider's retry policy")
+	}
+
+	// An inverted backoff range is rejected
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"api_id":         "mock-api-id",
+		"api_key":        "mock-api-key",
+		"base_url":       mock.URL(),
+		"base_url_rev_2": mock.URL(),
+		"base_url_rev_3": mock.URL(),
+		"base_url_api":   mock.URL(),
+		"retry": []interface{}{
+			map[string]interface{}{
+				"min_backoff_seconds": 60,
+				"max_backoff_seconds": 10,
+			},
+		},
+	}))
+	if !diags.HasError() || diags[0].Summary != "Retry min_backoff_seconds (60) must not be greater than max_backoff_seconds (10)" {
+		t.Errorf("Expected an error for an inverted backoff range, got %v", diags)
+	}
+}
+
+func TestProviderHTTPClientSettings(t *testing.T) {
+	mock := NewMockImpervaServer()
+	defer mock.Close()
+
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"api_id":                    "mock-api-id",
+		"api_key":                   "mock-api-key",
+		"base_url":                  mock.URL(),
+		"base_url_rev_2":            mock.URL(),
+		"base_url_rev_3":            mock.URL(),
+		"base_url_api":              mock.URL(),
+		"insecure_skip_verify":      true,
+		"request_timeout_seconds":   45,
+		"idle_conn_timeout_seconds": 20,
+		"max_idle_conns_per_host":   8,
+	}))
+	if diags.HasError() {
+		t.Fatalf("Unexpected error configuring provider: %v", diags)
+	}
+
+	httpClient := provider.Meta().(*Client).httpClient
+	if httpClient.Timeout != 45*time.Second {
+		t.Errorf("Expected a request timeout of 45s, got %s", httpClient.Timeout)
+	}
+	transport := httpClient.Transport.(*loggingTransport).next.(*http.Transport)
+	if !transport.TLSClientConfig.InsecureSkipVerify {
+		t.Errorf("Expected TLS certificate verification to be disabled")
+	}
+	if transport.IdleConnTimeout != 20*time.Second || transport.MaxIdleConns != 100 || transport.MaxIdleConnsPerHost != 8 {
+		t.Errorf("Unexpected idle connection settings: %s %d %d", transport.IdleConnTimeout, transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+	}
+}
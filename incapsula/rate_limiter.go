@@ -0,0 +1,141 @@
+package incapsula
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// requestLimiter throttles calls to the Imperva API on the client side.
+// It combines a token bucket (max_requests_per_second) with a semaphore capping the
+// number of requests in flight (max_concurrent_requests). One limiter is owned by each
+// Client, so it is shared by every resource configured through the same provider block.
+// A nil *requestLimiter is valid and does not limit anything.
+type requestLimiter struct {
+	mu           sync.Mutex
+	rate         float64
+	burst        float64
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+
+	inFlight chan struct{}
+}
+
+// newRequestLimiter returns a limiter for the given settings, or nil if both are unlimited (0)
+func newRequestLimiter(requestsPerSecond float64, maxConcurrent int) *requestLimiter {
+	if requestsPerSecond <= 0 && maxConcurrent <= 0 {
+		return nil
+	}
+
+	limiter := &requestLimiter{rate: requestsPerSecond}
+	if requestsPerSecond > 0 {
+		limiter.burst = math.Max(1, math.Ceil(requestsPerSecond))
+		limiter.tokens = limiter.burst
+		limiter.last = time.Now()
+	}
+	if maxConcurrent > 0 {
+		limiter.inFlight = make(chan struct{}, maxConcurrent)
+	}
+
+	return limiter
+}
+
+// acquire blocks until a request may be sent. The returned release func must be called
+// once the request has completed to free its concurrency slot.
+func (l *requestLimiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	if l.inFlight != nil {
+		select {
+		case l.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if l.inFlight != nil {
+			<-l.inFlight
+		}
+	}
+
+	for {
+		wait := l.reserve()
+		if wait <= 0 {
+			return release, nil
+		}
+		if err := sleepWithContext(ctx, wait); err != nil {
+			release()
+			return nil, err
+		}
+	}
+}
+
+// reserve takes a token if one is available and returns 0, otherwise it returns how
+// long the caller should wait before trying again.
+func (l *requestLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(l.blockedUntil) {
+		return l.blockedUntil.Sub(now)
+	}
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// pause holds back every request sent through the limiter for the given duration,
+// e.g. when the API answered with a Retry-After header.
+func (l *requestLimiter) pause(d time.Duration) {
+	if l == nil || d <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	until := time.Now().Add(d)
+	if until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+}
+
+// parseRetryAfter returns the delay requested by a Retry-After response header, given
+// either in seconds or as an HTTP date. It returns 0 if the header is missing or invalid.
+func parseRetryAfter(resp *http.Response) time.Duration {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if d := time.Until(date); d > 0 {
+			return d
+		}
+	}
+
+	return 0
+}
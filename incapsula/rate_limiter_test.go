@@ -0,0 +1,142 @@
+package incapsula
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewRequestLimiterUnlimited(t *testing.T) {
+	limiter := newRequestLimiter(0, 0)
+	if limiter != nil {
+		t.Fatalf("Expected a nil limiter when both limits are 0")
+	}
+
+	release, err := limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Nil limiter should never fail, got: %s", err)
+	}
+	release()
+	limiter.pause(time.Second)
+}
+
+func TestRequestLimiterRate(t *testing.T) {
+	limiter := newRequestLimiter(20, 0)
+
+	start := time.Now()
+	for i := 0; i < 30; i++ {
+		release, err := limiter.acquire(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		release()
+	}
+
+	// The first 20 requests use the initial burst, the remaining 10 need ~500ms of refill
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("Expected the rate limiter to throttle requests, took only %s", elapsed)
+	}
+}
+
+func TestRequestLimiterAcquireCancelled(t *testing.T) {
+	limiter := newRequestLimiter(0, 1)
+
+	release, err := limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.acquire(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded while waiting for a free slot, got: %v", err)
+	}
+}
+
+func TestRequestLimiterPause(t *testing.T) {
+	limiter := newRequestLimiter(1000, 0)
+	limiter.pause(300 * time.Millisecond)
+
+	start := time.Now()
+	release, err := limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	release()
+
+	if elapsed := time.Since(start); elapsed < 250*time.Millisecond {
+		t.Errorf("Expected acquire to wait for the pause to end, took only %s", elapsed)
+	}
+}
+
+func TestClientMaxConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL, MaxConcurrentRequests: 2}
+	client := NewClient(config)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.GetWithHeaders(context.Background(), server.URL, nil, ReadSite)
+			if err != nil {
+				t.Errorf("Unexpected error: %s", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if max := atomic.LoadInt32(&maxInFlight); max > 2 {
+		t.Errorf("Expected at most 2 requests in flight, got %d", max)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	cases := []struct {
+		header   string
+		expected time.Duration
+	}{
+		{"", 0},
+		{"3", 3 * time.Second},
+		{"-1", 0},
+		{"soon", 0},
+	}
+
+	for _, c := range cases {
+		resp := &http.Response{Header: http.Header{}}
+		if c.header != "" {
+			resp.Header.Set("Retry-After", c.header)
+		}
+		if got := parseRetryAfter(resp); got != c.expected {
+			t.Errorf("parseRetryAfter(%q): expected %s, got %s", c.header, c.expected, got)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", time.Now().Add(10*time.Second).UTC().Format(http.TimeFormat))
+	if got := parseRetryAfter(resp); got <= 0 || got > 10*time.Second {
+		t.Errorf("Expected an HTTP-date Retry-After within 10s, got %s", got)
+	}
+}
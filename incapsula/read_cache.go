@@ -0,0 +1,198 @@
+package incapsula
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Read operations whose responses are cached. They are the heavy reads made again and again for the
+// same site during a refresh, e.g. SiteStatus is called by the site and by every WAF rule of the site.
+var cacheableReadOperations = map[string]bool{
+	ReadSite:              true,
+	ReadSitePerformance:   true,
+	ReadSiteMasking:       true,
+	ReadDataStorageRegion: true,
+	ReadDataCenter:        true,
+	ReadPoliciesAll:       true,
+}
+
+var sitePathID = regexp.MustCompile(`/sites?/(\d+)(/|$)`)
+
+// readCache caches the responses of the cacheable read operations for a short time. Concurrent
+// identical reads share one API call (single flight), and a write to a site evicts the entries of
+// that site (a write that is not made for a specific site evicts all the entries).
+type readCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*readCacheEntry
+	// generation is incremented on every eviction, so a read that was in flight during a write is not stored
+	generation uint64
+}
+
+type readCacheEntry struct {
+	siteID  string
+	expires time.Time
+	// done is closed when the API call of the entry has completed
+	done chan struct{}
+
+	statusCode int
+	status     string
+	header     http.Header
+	body       []byte
+	err        error
+}
+
+func newReadCache(ttl time.Duration) *readCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &readCache{ttl: ttl, entries: map[string]*readCacheEntry{}}
+}
+
+// isReadRequest reports whether the request only reads data: GET requests and the (POST) API v1 read operations
+func isReadRequest(req *http.Request) bool {
+	return req.Method == http.MethodGet ||
+		strings.HasPrefix(strings.ToLower(req.Header.Get("x-tf-operation")), "read")
+}
+
+func requestBody(req *http.Request) []byte {
+	if req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+	content, _ := io.ReadAll(body)
+	return content
+}
+
+// requestSiteID returns the site a request is made for, from the site_id parameter or the /sites/<id> path
+func requestSiteID(req *http.Request, body []byte) string {
+	if siteID := req.URL.Query().Get("site_id"); siteID != "" {
+		return siteID
+	}
+	if strings.HasPrefix(req.Header.Get("Content-Type"), contentTypeApplicationUrlEncoded) {
+		if values, err := url.ParseQuery(string(body)); err == nil && values.Get("site_id") != "" {
+			return values.Get("site_id")
+		}
+	}
+	if match := sitePathID.FindStringSubmatch(req.URL.Path); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// do serves the request from the cache when it is a cacheable read, and otherwise sends it with send.
+// Writes evict the cached entries of their site once they have completed.
+func (rc *readCache) do(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	operation := req.Header.Get("x-tf-operation")
+	body := requestBody(req)
+	siteID := requestSiteID(req, body)
+
+	if !isReadRequest(req) {
+		resp, err := send(req)
+		rc.evict(siteID)
+		return resp, err
+	}
+	if !cacheableReadOperations[operation] {
+		return send(req)
+	}
+
+	key := req.Method + " " + req.URL.String() + " " + operation + " " + string(body)
+
+	rc.mu.Lock()
+	if entry, ok := rc.entries[key]; ok && (entry.expires.IsZero() || time.Now().Before(entry.expires)) {
+		rc.mu.Unlock()
+		<-entry.done
+		if entry.err == nil {
+			log.Printf("[DEBUG] Serving %s %s (operation %s) from the read cache\n", req.Method, req.URL.Path, operation)
+			return entry.response(req), nil
+		}
+		// The shared call failed, so make our own
+		return send(req)
+	}
+	entry := &readCacheEntry{siteID: siteID, done: make(chan struct{})}
+	rc.entries[key] = entry
+	generation := rc.generation
+	rc.mu.Unlock()
+
+	resp, err := send(req)
+	if err == nil {
+		entry.statusCode, entry.status, entry.header = resp.StatusCode, resp.Status, resp.Header
+		entry.body, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(entry.body))
+	}
+	entry.err = err
+
+	rc.mu.Lock()
+	// Only successful responses are kept, and only when no write happened while the call was in flight
+	if err != nil || !isSuccessfulResponse(resp.StatusCode, entry.body) || generation != rc.generation {
+		if rc.entries[key] == entry {
+			delete(rc.entries, key)
+		}
+	} else {
+		entry.expires = time.Now().Add(rc.ttl)
+	}
+	rc.mu.Unlock()
+	close(entry.done)
+
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// isSuccessfulResponse reports whether a response can be cached: a 2xx status and, for the API v1
+// responses that report errors with a 200 status, a "res" code of 0 in the body
+func isSuccessfulResponse(statusCode int, body []byte) bool {
+	if statusCode < 200 || statusCode > 299 {
+		return false
+	}
+	var v1Response struct {
+		Res json.RawMessage `json:"res"`
+	}
+	if err := json.Unmarshal(body, &v1Response); err != nil || v1Response.Res == nil {
+		return true
+	}
+	return jsonInt(v1Response.Res) == 0
+}
+
+// evict removes the cached entries of a site, or all the entries when siteID is empty
+func (rc *readCache) evict(siteID string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.generation++
+	for key, entry := range rc.entries {
+		if siteID == "" || entry.siteID == "" || entry.siteID == siteID {
+			delete(rc.entries, key)
+		}
+	}
+}
+
+// response returns a copy of the cached response for req
+func (e *readCacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        e.status,
+		StatusCode:    e.statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,197 @@
+package incapsula
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newReadCacheTestClient(t *testing.T, ttl time.Duration, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLRev3: server.URL, BaseURLAPI: server.URL,
+		Retry: &RetryPolicy{MaxAttempts: 1}, ReadCacheTTL: ttl}
+	return NewClient(config)
+}
+
+func TestReadCacheSingleFlight(t *testing.T) {
+	var calls int32
+	client := newReadCacheTestClient(t, time.Minute, func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(50 * time.Millisecond)
+		req.ParseForm()
+		rw.Write([]byte(`{"site_id":` + req.Form.Get("site_id") + `,"domain":"example.com","res":0}`))
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			siteStatus, err := client.SiteStatus(context.Background(), "example.com", 42)
+			if err != nil || siteStatus.SiteID != 42 {
+				t.Errorf("Unexpected site status %v: %v", siteStatus, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("Expected concurrent identical reads to share one API call, got %d calls", calls)
+	}
+
+	if _, err := client.SiteStatus(context.Background(), "example.com", 43); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected a read of another site to call the API, got %d calls", calls)
+	}
+}
+
+func TestReadCacheEvictedByWrite(t *testing.T) {
+	var reads int32
+	client := newReadCacheTestClient(t, time.Minute, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("x-tf-operation") == ReadSite {
+			atomic.AddInt32(&reads, 1)
+		}
+		rw.Write([]byte(`{"res":0}`))
+	})
+
+	readSite := func(siteID string) {
+		resp, err := client.PostFormWithHeaders(context.Background(), client.config.BaseURL+"/sites/status", url.Values{"site_id": {siteID}}, ReadSite)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		io.ReadAll(resp.Body)
+		resp.Body.Close()
+	}
+
+	readSite("42")
+	readSite("42")
+	readSite("43")
+	if reads != 2 {
+		t.Fatalf("Expected the second read of site 42 to be served from the cache, got %d reads", reads)
+	}
+
+	// A write to site 42 evicts site 42 only
+	resp, err := client.PostFormWithHeaders(context.Background(), client.config.BaseURL+"/sites/configure", url.Values{"site_id": {"42"}, "param": {"active"}}, UpdateSite)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	resp.Body.Close()
+	readSite("42")
+	readSite("43")
+	if reads != 3 {
+		t.Errorf("Expected only site 42 to be read again after the write, got %d reads", reads)
+	}
+
+	// A write to a v3 site path evicts it too
+	resp, err = client.DoJsonRequestWithHeaders(context.Background(), http.MethodPut, client.config.BaseURLRev3+"/sites/43/settings", []byte(`{}`), UpdateSite)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	resp.Body.Close()
+	readSite("43")
+	if reads != 4 {
+		t.Errorf("Expected site 43 to be read again after the write, got %d reads", reads)
+	}
+}
+
+func TestReadCacheExpiryAndErrors(t *testing.T) {
+	var calls int32
+	var status int32 = http.StatusInternalServerError
+	client := newReadCacheTestClient(t, 50*time.Millisecond, func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		rw.WriteHeader(int(atomic.LoadInt32(&status)))
+		rw.Write([]byte(`[]`))
+	})
+
+	readPolicies := func() {
+		resp, err := client.DoJsonRequestWithHeaders(context.Background(), http.MethodGet, client.config.BaseURLAPI+"/policies/v2/policies", nil, ReadPoliciesAll)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		resp.Body.Close()
+	}
+
+	readPolicies()
+	readPolicies()
+	if calls != 2 {
+		t.Fatalf("Expected error responses not to be cached, got %d calls", calls)
+	}
+
+	atomic.StoreInt32(&status, http.StatusOK)
+	readPolicies()
+	readPolicies()
+	if calls != 3 {
+		t.Fatalf("Expected the successful response to be cached, got %d calls", calls)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	readPolicies()
+	if calls != 4 {
+		t.Errorf("Expected the cached response to expire, got %d calls", calls)
+	}
+}
+
+func TestReadCacheV1Errors(t *testing.T) {
+	var calls int32
+	var body atomic.Value
+	body.Store(`{"res":"9413","res_message":"Unknown/unauthorized site_id"}`)
+	client := newReadCacheTestClient(t, time.Minute, func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		rw.Write([]byte(body.Load().(string)))
+	})
+
+	readSite := func() {
+		resp, err := client.PostFormWithHeaders(context.Background(), client.config.BaseURL+"/sites/status", url.Values{"site_id": {"42"}}, ReadSite)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		io.ReadAll(resp.Body)
+		resp.Body.Close()
+	}
+
+	readSite()
+	readSite()
+	if calls != 2 {
+		t.Fatalf("Expected v1 responses with a non zero res code not to be cached, got %d calls", calls)
+	}
+
+	body.Store(`{"res":0,"site_id":42}`)
+	readSite()
+	readSite()
+	if calls != 3 {
+		t.Errorf("Expected the v1 response with a res code of 0 to be cached, got %d calls", calls)
+	}
+}
+
+func TestReadCacheOnlyCachesHeavyReads(t *testing.T) {
+	var calls int32
+	client := newReadCacheTestClient(t, time.Minute, func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		rw.Write([]byte(`{}`))
+	})
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.DoJsonRequestWithHeaders(context.Background(), http.MethodGet, client.config.BaseURLAPI+"/sites/42/rules/7", nil, ReadIncapRule)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		resp.Body.Close()
+	}
+	if calls != 2 {
+		t.Errorf("Expected reads that are not cacheable to call the API every time, got %d calls", calls)
+	}
+
+	if NewClient(&Config{}).readCache != nil {
+		t.Errorf("Expected no read cache without a TTL")
+	}
+}
@@ -56,12 +56,9 @@ func resourceAbpWebsites() *schema.Resource {
 				d.SetId(accountIdStr)
 
 				client := meta.(*Client)
-				abpWebsites, diags := client.ReadAbpWebsites(accountId)
-
-				for _, diagnostic := range diags {
-					if diagnostic.Severity == diag.Error {
-						return nil, fmt.Errorf("%s", diagnostic.Detail)
-					}
+				abpWebsites, err := client.ReadAbpWebsites(ctx, accountId)
+				if err != nil {
+					return nil, err
 				}
 
 				setUniqueNameIds(abpWebsites)
@@ -343,11 +340,11 @@ func resourceAbpWebsitesCreate(ctx context.Context, data *schema.ResourceData, m
 	}
 	var abpWebsites *AbpTerraformAccount
 
-	abpWebsites, diags = client.CreateAbpWebsites(accountId, account)
+	abpWebsites, err := client.CreateAbpWebsites(ctx, accountId, account)
 
-	if diags != nil && diags.HasError() {
+	if err != nil {
 		log.Printf("[ERROR] Failed to create ABP websites for Account ID %d", accountId)
-		return diags
+		return diag.Diagnostics{abpWebsitesErrorDiagnostic("Creating", err)}
 	}
 
 	serializeAccount(data, *abpWebsites)
@@ -364,11 +361,14 @@ func resourceAbpWebsitesRead(ctx context.Context, data *schema.ResourceData, m i
 	accountId := data.Get("account_id").(int)
 
 	var abpWebsites *AbpTerraformAccount
-	abpWebsites, diags = client.ReadAbpWebsites(accountId)
+	abpWebsites, err := client.ReadAbpWebsites(ctx, accountId)
 
-	if diags != nil && diags.HasError() {
+	if err != nil {
+		if removeGoneResource(data, err, "ABP websites") {
+			return nil
+		}
 		log.Printf("[ERROR] Failed to read ABP websites for Account ID %d", accountId)
-		return diags
+		return diag.Diagnostics{abpWebsitesErrorDiagnostic("Reading", err)}
 	}
 
 	serializeAccount(data, *abpWebsites)
@@ -387,11 +387,11 @@ func resourceAbpWebsitesUpdate(ctx context.Context, data *schema.ResourceData, m
 	}
 
 	var abpWebsites *AbpTerraformAccount
-	abpWebsites, diags = client.UpdateAbpWebsites(accountId, account)
+	abpWebsites, err := client.UpdateAbpWebsites(ctx, accountId, account)
 
-	if diags != nil && diags.HasError() {
+	if err != nil {
 		log.Printf("[ERROR] Failed to update ABP websites for Account ID %d", accountId)
-		return diags
+		return diag.Diagnostics{abpWebsitesErrorDiagnostic("Updating", err)}
 	}
 
 	serializeAccount(data, *abpWebsites)
@@ -406,14 +406,22 @@ func resourceAbpWebsitesDelete(ctx context.Context, data *schema.ResourceData, m
 	accountId := data.Get("account_id").(int)
 	autoPublish := data.Get("auto_publish").(bool)
 
-	_, diags = client.DeleteAbpWebsites(accountId, autoPublish)
+	_, err := client.DeleteAbpWebsites(ctx, accountId, autoPublish)
 
-	if diags != nil && diags.HasError() {
+	if err != nil {
 		log.Printf("[ERROR] Failed to delete ABP websites for Account ID %d", accountId)
-		return diags
+		return diag.Diagnostics{abpWebsitesErrorDiagnostic("Deleting", err)}
 	}
 
 	data.SetId("")
 
 	return diags
 }
+
+func abpWebsitesErrorDiagnostic(action string, err error) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Failure %s %s", action, resourceName),
+		Detail:   err.Error(),
+	}
+}
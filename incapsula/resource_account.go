@@ -1,22 +1,24 @@
 package incapsula
 
 import (
+	"context"
 	"log"
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceAccount() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAccountCreate,
-		Read:   resourceAccountRead,
-		Update: resourceAccountUpdate,
-		Delete: resourceAccountDelete,
+		CreateContext: resourceAccountCreate,
+		ReadContext:   resourceAccountRead,
+		UpdateContext: resourceAccountUpdate,
+		DeleteContext: resourceAccountDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -149,13 +151,13 @@ func resourceAccount() *schema.Resource {
 	}
 }
 
-func resourceAccountCreate(d *schema.ResourceData, m interface{}) error {
+func resourceAccountCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	email := d.Get("email").(string)
 
 	log.Printf("[INFO] Creating Incapsula account for email: %s\n", email)
 
-	accountAddResponse, err := client.AddAccount(
+	accountAddResponse, err := client.AddAccount(ctx,
 		email,
 		d.Get("ref_id").(string),
 		d.Get("user_name").(string),
@@ -168,7 +170,7 @@ func resourceAccountCreate(d *schema.ResourceData, m interface{}) error {
 
 	if err != nil {
 		log.Printf("[ERROR] Could not create Incapsula account for email: %s, %s\n", email, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the Account ID
@@ -177,41 +179,41 @@ func resourceAccountCreate(d *schema.ResourceData, m interface{}) error {
 
 	// There may be a timing/race condition here
 	// Set an arbitrary period to sleep
-	time.Sleep(3 * time.Second)
+	if err := sleepWithContext(ctx, 3*time.Second); err != nil {
+		return diag.FromErr(err)
+	}
 
-	err = updateAdditionalAccountProperties(client, d)
+	err = updateAdditionalAccountProperties(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = updateDefaultDataStorageRegion(client, d)
+	err = updateDefaultDataStorageRegion(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the rest of the state from the resource read
-	return resourceAccountRead(d, m)
+	return resourceAccountRead(ctx, d, m)
 }
 
-func resourceAccountRead(d *schema.ResourceData, m interface{}) error {
+func resourceAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	accountID, _ := strconv.Atoi(d.Id())
 
 	log.Printf("[INFO] Reading Incapsula account for Account ID: %d\n", accountID)
 
-	accountStatusResponse, err := client.AccountStatus(accountID, ReadAccount)
+	accountStatusResponse, err := client.AccountStatus(ctx, accountID, ReadAccount)
 
 	// Account object may have been deleted
-	if accountStatusResponse != nil && accountStatusResponse.Res.(float64) == 9403 {
-		log.Printf("[INFO] Incapsula Account ID %d has already been deleted: %s\n", accountID, err)
-		d.SetId("")
+	if removeGoneAccount(d, err, "account") {
 		return nil
 	}
 
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula account for Account ID: %d, %s\n", accountID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("parent_id", accountStatusResponse.Account.ParentID)
@@ -232,10 +234,10 @@ func resourceAccountRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("enable_http2_to_origin_for_new_sites", strconv.FormatBool(accountStatusResponse.Account.EnableHttp2ToOriginForNewSites))
 
 	// Get the performance settings for the site
-	defaultAccountDataStorageRegion, err := client.GetAccountDataStorageRegion(d.Id())
+	defaultAccountDataStorageRegion, err := client.GetAccountDataStorageRegion(ctx, d.Id())
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula default data storage region for account id: %d, %s\n", accountID, err)
-		return err
+		return diag.FromErr(err)
 	}
 	d.Set("data_storage_region", defaultAccountDataStorageRegion.Region)
 
@@ -244,7 +246,7 @@ func resourceAccountRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceAccountUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceAccountUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	updateParams := [7]string{"email", "plan_id", "ref_id", "error_page_template", "support_all_tls_versions", "naked_domain_san_for_new_www_sites", "wildcard_san_for_new_sites"}
@@ -252,44 +254,44 @@ func resourceAccountUpdate(d *schema.ResourceData, m interface{}) error {
 		param := updateParams[i]
 		if d.HasChange(param) && d.Get(param) != "" {
 			log.Printf("[INFO] Updating Incapsula account param (%s) with value (%s) for account_id: %s\n", param, d.Get(param).(string), d.Id())
-			_, err := client.UpdateAccount(d.Id(), param, d.Get(param).(string))
+			_, err := client.UpdateAccount(ctx, d.Id(), param, d.Get(param).(string))
 			if err != nil {
 				log.Printf("[ERROR] Could not update Incapsula account param (%s) with value (%s) for account_id: %s %s\n", param, d.Get(param).(string), d.Id(), err)
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	}
 
-	err := updateAdditionalAccountProperties(client, d)
+	err := updateAdditionalAccountProperties(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = updateAccountLogLevel(client, d)
+	err = updateAccountLogLevel(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = updateDefaultDataStorageRegion(client, d)
+	err = updateDefaultDataStorageRegion(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the rest of the state from the resource read
-	return resourceAccountRead(d, m)
+	return resourceAccountRead(ctx, d, m)
 }
 
-func resourceAccountDelete(d *schema.ResourceData, m interface{}) error {
+func resourceAccountDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	accountID, _ := strconv.Atoi(d.Id())
 
 	log.Printf("[INFO] Deleting Incapsula account id: %d\n", accountID)
 
-	err := client.DeleteAccount(accountID)
+	err := client.DeleteAccount(ctx, accountID)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not delete Incapsula account id: %d, %s\n", accountID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
@@ -301,7 +303,7 @@ func resourceAccountDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func updateAdditionalAccountProperties(client *Client, d *schema.ResourceData) error {
+func updateAdditionalAccountProperties(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	consentRequiredParam := "consent_required"
 	defaultInactivityTimeout := "15"
 	updateParams := [7]string{"account_name", "error_page_template", "support_all_tls_versions", "naked_domain_san_for_new_www_sites", "wildcard_san_for_new_sites", consentRequiredParam, "inactivity_timeout"}
@@ -325,7 +327,7 @@ func updateAdditionalAccountProperties(client *Client, d *schema.ResourceData) e
 			if paramValStr != "" {
 				param = replaceAccountNameParamName(param)
 				log.Printf("[INFO] Updating Incapsula account param (%s) with value (%s) for account_id: %s\n", param, paramValStr, d.Id())
-				_, err := client.UpdateAccount(d.Id(), param, paramValStr)
+				_, err := client.UpdateAccount(ctx, d.Id(), param, paramValStr)
 				if err != nil {
 					log.Printf("[ERROR] Could not update Incapsula account param (%s) with value (%s) for account_id: %s %s\n", param, paramValStr, d.Id(), err)
 					return err
@@ -334,7 +336,7 @@ func updateAdditionalAccountProperties(client *Client, d *schema.ResourceData) e
 		}
 	}
 
-	return updateHttp2Properties(client, d)
+	return updateHttp2Properties(ctx, client, d)
 }
 
 func replaceAccountNameParamName(param string) string {
@@ -344,12 +346,12 @@ func replaceAccountNameParamName(param string) string {
 	return param
 }
 
-func updateAccountLogLevel(client *Client, d *schema.ResourceData) error {
+func updateAccountLogLevel(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	if d.HasChange("log_level") ||
 		d.HasChange("logs_account_id") {
 		logLevel := d.Get("log_level").(string)
 		logsAccountId := d.Get("logs_account_id").(string)
-		err := client.UpdateLogLevel(d.Id(), logLevel, logsAccountId)
+		err := client.UpdateLogLevel(ctx, d.Id(), logLevel, logsAccountId)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula account log level: %s and log account id: %s for account_id: %s %s\n", logLevel, logsAccountId, d.Id(), err)
 			return err
@@ -358,10 +360,10 @@ func updateAccountLogLevel(client *Client, d *schema.ResourceData) error {
 	return nil
 }
 
-func updateDefaultDataStorageRegion(client *Client, d *schema.ResourceData) error {
+func updateDefaultDataStorageRegion(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	if d.HasChange("data_storage_region") {
 		region := d.Get("data_storage_region").(string)
-		_, err := client.UpdateAccountDataStorageRegion(d.Id(), region)
+		_, err := client.UpdateAccountDataStorageRegion(ctx, d.Id(), region)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula account default data storage region: %s for account_id: %s %s\n", region, d.Id(), err)
 			return err
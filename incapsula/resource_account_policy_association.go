@@ -1,7 +1,9 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
@@ -15,12 +17,12 @@ const noAvailablePoliciesConst = "NO_AVAILABLE_POLICIES"
 func resourceAccountPolicyAssociation() *schema.Resource {
 
 	return &schema.Resource{
-		Create: resourceAccountPolicyAssociationUpdate,
-		Read:   resourceAccountPolicyAssociationRead,
-		Update: resourceAccountPolicyAssociationUpdate,
-		Delete: resourceAccountPolicyAssociationDelete,
+		CreateContext: resourceAccountPolicyAssociationUpdate,
+		ReadContext:   resourceAccountPolicyAssociationRead,
+		UpdateContext: resourceAccountPolicyAssociationUpdate,
+		DeleteContext: resourceAccountPolicyAssociationDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -73,7 +75,7 @@ func resourceAccountPolicyAssociation() *schema.Resource {
 	}
 }
 
-func resourceAccountPolicyAssociationUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceAccountPolicyAssociationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	accountID := d.Get("account_id").(string)
@@ -91,7 +93,7 @@ func resourceAccountPolicyAssociationUpdate(d *schema.ResourceData, m interface{
 	if !IsSetNil(d.Get("default_non_mandatory_policy_ids").(*schema.Set)) {
 		defaultNonMandatoryPolicyIds, err = ListToIntSlice(defaultNonMandatoryPolicyIdsInter)
 		if err != nil {
-			return fmt.Errorf("Cannot convert default_non_mandatory_policy_ids to integer")
+			return diag.Errorf("Cannot convert default_non_mandatory_policy_ids to integer")
 		}
 	}
 
@@ -101,22 +103,26 @@ func resourceAccountPolicyAssociationUpdate(d *schema.ResourceData, m interface{
 	if availablePolicies == noAvailablePoliciesConst {
 		availablePolicyIds = make([]int, 0)
 	} else if availablePolicies != "" {
-		if client.accountStatus.isSubAccount() {
-			return fmt.Errorf("sub accounts cannot change thier available_policy_ids")
+		accountStatus, err := client.currentAccountStatus(ctx)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if accountStatus.isSubAccount() {
+			return diag.Errorf("sub accounts cannot change thier available_policy_ids")
 		}
 		splitPoliciesIds := strings.Split(availablePolicies, ",")
 		availablePolicyIds, err = ToIntSlice(splitPoliciesIds)
 		if err != nil {
-			return fmt.Errorf("Cannot convert available_policy_ids to integer")
+			return diag.Errorf("Cannot convert available_policy_ids to integer")
 		}
 	}
 
-	_, err = client.PatchAccountPolicyAssociation(accountID, availablePolicyIds, defaultNonMandatoryPolicyIds, wafPolicyIDStr)
+	_, err = client.PatchAccountPolicyAssociation(ctx, accountID, availablePolicyIds, defaultNonMandatoryPolicyIds, wafPolicyIDStr)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(accountID)
-	return resourceAccountPolicyAssociationRead(d, m)
+	return resourceAccountPolicyAssociationRead(ctx, d, m)
 }
 
 func IsSetNil(setToCheck *schema.Set) bool {
@@ -162,46 +168,53 @@ func ToStringSlice(intArray []int) []string {
 	return stringArray
 }
 
-func resourceAccountPolicyAssociationDelete(d *schema.ResourceData, m interface{}) error {
+func resourceAccountPolicyAssociationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	accountIDStr := d.Get("account_id").(string)
 	accountID, err := strconv.Atoi(accountIDStr)
 	if err != nil {
 		log.Printf("[ERROR] Could not convert Account ID. Error: is not numeric: %s", accountIDStr)
-		return err
+		return diag.FromErr(err)
 	}
 	wafPolicyIdStr := d.Get("default_waf_policy_id").(string)
 	defaultNonMandatoryPolicyIds := make([]int, 0)
 	var availablePolicyIds []int
-	if wafPolicyIdStr != "" && !client.accountStatus.isSubAccount() && client.accountStatus.AccountID != accountID {
+	accountStatus, err := client.currentAccountStatus(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if wafPolicyIdStr != "" && !accountStatus.isSubAccount() && accountStatus.AccountID != accountID {
 		wafPolicyID, err := strconv.Atoi(wafPolicyIdStr)
 		if err != nil {
 			log.Printf("[ERROR] Could not convert WAF Rule Policy ID. Error: is not numeric: %s", wafPolicyIdStr)
-			return err
+			return diag.FromErr(err)
 		}
 		availablePolicyIds = append(availablePolicyIds, wafPolicyID)
 	}
-	_, err = client.PatchAccountPolicyAssociation(accountIDStr, availablePolicyIds, defaultNonMandatoryPolicyIds, wafPolicyIdStr)
+	_, err = client.PatchAccountPolicyAssociation(ctx, accountIDStr, availablePolicyIds, defaultNonMandatoryPolicyIds, wafPolicyIdStr)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
 	return nil
 }
 
-func resourceAccountPolicyAssociationRead(d *schema.ResourceData, m interface{}) error {
+func resourceAccountPolicyAssociationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	err := d.Set("account_id", d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	accountID := d.Id()
-	getAccountPolicyAssociation, err := client.GetAccountPolicyAssociation(accountID)
+	getAccountPolicyAssociation, err := client.GetAccountPolicyAssociation(ctx, accountID)
 
+	if removeGoneResource(d, err, "account policy association") {
+		return nil
+	}
 	if err != nil {
 		log.Printf("[ERROR] Could not get Incapsula Policies Association for Account ID: %s - %s\n", accountID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	defaultWafPolicyId := getAccountPolicyAssociation.DefaultWafPolicyId
@@ -211,12 +224,12 @@ func resourceAccountPolicyAssociationRead(d *schema.ResourceData, m interface{})
 	if d.Get("default_waf_policy_id").(string) != "" && defaultWafPolicyId != 0 {
 		err = d.Set("default_waf_policy_id", strconv.Itoa(defaultWafPolicyId))
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 	err = d.Set("default_non_mandatory_policy_ids", ToStringSlice(nonMandatoryPolicies))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	sort.Slice(availablePolicyIds, func(i, j int) bool {
@@ -225,7 +238,7 @@ func resourceAccountPolicyAssociationRead(d *schema.ResourceData, m interface{})
 
 	err = d.Set("available_policy_ids", strings.Join(ToStringSlice(availablePolicyIds), ","))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
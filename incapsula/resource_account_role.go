@@ -1,6 +1,8 @@
 package incapsula
 
 import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"strconv"
@@ -8,12 +10,12 @@ import (
 
 func resourceAccountRole() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAccountRoleCreate,
-		Read:   resourceAccountRoleRead,
-		Update: resourceAccountRoleUpdate,
-		Delete: resourceAccountRoleDelete,
+		CreateContext: resourceAccountRoleCreate,
+		ReadContext:   resourceAccountRoleRead,
+		UpdateContext: resourceAccountRoleUpdate,
+		DeleteContext: resourceAccountRoleDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -77,7 +79,7 @@ func populateRoleDetailsDTO(d *schema.ResourceData) RoleDetailsBasicDTO {
 	return requestDTO
 }
 
-func resourceAccountRoleCreate(d *schema.ResourceData, m interface{}) error {
+func resourceAccountRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	accountId := d.Get("account_id").(int)
 
@@ -89,11 +91,11 @@ func resourceAccountRoleCreate(d *schema.ResourceData, m interface{}) error {
 	requestDTO.RoleDetailsBasicDTO = roleDetailsBasicDTO
 	requestDTO.AccountId = d.Get("account_id").(int)
 
-	responseDTO, err := client.AddAccountRole(requestDTO)
+	responseDTO, err := client.AddAccountRole(ctx, requestDTO)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not create Incapsula account role: %s\n", err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the Account Role ID
@@ -101,28 +103,26 @@ func resourceAccountRoleCreate(d *schema.ResourceData, m interface{}) error {
 	log.Printf("[INFO] Created Incapsula account role: %s, Id: %d\n", responseDTO.RoleName, responseDTO.RoleId)
 
 	// Set the rest of the state from the resource read
-	return resourceAccountRoleRead(d, m)
+	return resourceAccountRoleRead(ctx, d, m)
 }
 
-func resourceAccountRoleRead(d *schema.ResourceData, m interface{}) error {
+func resourceAccountRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	roleID, _ := strconv.Atoi(d.Id())
 
 	log.Printf("[INFO] Reading Incapsula account role ID: %d\n", roleID)
 
-	accountRoleResponse, err := client.GetAccountRole(roleID)
+	accountRoleResponse, err := client.GetAccountRole(ctx, roleID)
 
 	// Account object may have been deleted
-	if accountRoleResponse != nil && accountRoleResponse.ErrorCode == 1047 {
-		log.Printf("[INFO] Incapsula Account Role with ID %d does not exist: %s\n", roleID, err)
-		d.SetId("")
+	if removeGoneResource(d, err, "account role") {
 		return nil
 	}
 
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula account role ID: %d, %s\n", roleID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("account_id", accountRoleResponse.AccountId)
@@ -139,7 +139,7 @@ func resourceAccountRoleRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceAccountRoleUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceAccountRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	roleID, _ := strconv.Atoi(d.Id())
 	accountId := d.Get("account_id").(int)
@@ -147,30 +147,30 @@ func resourceAccountRoleUpdate(d *schema.ResourceData, m interface{}) error {
 	log.Printf("[INFO] Updating Incapsula account role with id: %d (account id: %d)\n", roleID, accountId)
 
 	requestDTO := populateRoleDetailsDTO(d)
-	responseDTO, err := client.UpdateAccountRole(roleID, accountId, requestDTO)
+	responseDTO, err := client.UpdateAccountRole(ctx, roleID, accountId, requestDTO)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not create Incapsula account role: %s\n", err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Updated Incapsula account role: %s, Id: %d\n", responseDTO.RoleName, responseDTO.RoleId)
 	// Set the rest of the state from the resource read
-	return resourceAccountRoleRead(d, m)
+	return resourceAccountRoleRead(ctx, d, m)
 }
 
-func resourceAccountRoleDelete(d *schema.ResourceData, m interface{}) error {
+func resourceAccountRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	roleID, _ := strconv.Atoi(d.Id())
 	accountID := d.Get("account_id").(int)
 
 	log.Printf("[INFO] Deleting Incapsula account role with id: %d (account id: %d)\n", roleID, accountID)
 
-	err := client.DeleteAccountRole(roleID, accountID)
+	err := client.DeleteAccountRole(ctx, roleID, accountID)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not delete Incapsula account role with id: %d, %s\n", roleID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
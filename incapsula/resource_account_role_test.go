@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"testing"
@@ -99,10 +100,10 @@ func testCheckIncapsulaAccountRoleDestroy(state *terraform.State) error {
 			return fmt.Errorf("Account Role ID conversion error for %s: %s", accountRoleIDStr, err)
 		}
 
-		accountRoleResponse, err := client.GetAccountRole(accountRoleID)
+		_, err = client.GetAccountRole(context.Background(), accountRoleID)
 
 		// Account object may have been deleted
-		if accountRoleResponse != nil && accountRoleResponse.ErrorCode != 1047 {
+		if !IsNotFound(err) {
 			return fmt.Errorf("Incapsula account role id: %d still exists", accountRoleID)
 		}
 	}
@@ -128,7 +129,7 @@ func testCheckIncapsulaAccountRoleExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		accountRoleStatusResponse, err := client.GetAccountRole(accountRoleID)
+		accountRoleStatusResponse, err := client.GetAccountRole(context.Background(), accountRoleID)
 		if accountRoleStatusResponse == nil {
 			return fmt.Errorf("Incapsula account role id: %d does not exist", accountRoleID)
 		}
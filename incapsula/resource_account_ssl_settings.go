@@ -156,10 +156,10 @@ func resourceAccountSSLSettingsUpdate(ctx context.Context, d *schema.ResourceDat
 		fieldVal := d.Get("enable_hsts_for_new_sites").(bool)
 		accountSSLSettingsDTO.EnableHSTSForNewSites = &fieldVal
 	}
-	accountSSLSettingsDTOResponse, diags := client.UpdateAccountSSLSettings(&accountSSLSettingsDTO, accountID)
-	if diags != nil && diags.HasError() {
-		log.Printf("[ERROR] Could not update Incapsula account SSL settings for Account ID: %s, %v\n", accountID, diags)
-		return diags
+	accountSSLSettingsDTOResponse, err := client.UpdateAccountSSLSettings(ctx, &accountSSLSettingsDTO, accountID)
+	if err != nil {
+		log.Printf("[ERROR] Could not update Incapsula account SSL settings for Account ID: %s, %v\n", accountID, err)
+		return diag.FromErr(err)
 	} else if accountSSLSettingsDTOResponse.Errors != nil {
 		log.Printf("[ERROR] Failed to update Incapsula account SSL settings for Account ID: %s, %v\n", accountID, accountSSLSettingsDTOResponse.Errors[0].Detail)
 		return []diag.Diagnostic{diag.Diagnostic{
@@ -168,7 +168,7 @@ func resourceAccountSSLSettingsUpdate(ctx context.Context, d *schema.ResourceDat
 			Detail:   fmt.Sprintf("Failed to update account SSL settings for account%s, %s", accountID, accountSSLSettingsDTOResponse.Errors[0].Detail),
 		}}
 	}
-	err := d.Set("account_id", accountID)
+	err = d.Set("account_id", accountID)
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula account SSL settings after update for Account ID: %s, %s\n", accountID, err)
 		return diag.FromErr(err)
@@ -209,11 +209,14 @@ func resourceAccountSSLSettingsRead(ctx context.Context, d *schema.ResourceData,
 
 	log.Printf("[INFO] Reading Incapsula account SSL settings for Account ID: %s\n", accountID)
 
-	accountSSLSettingsDTOResponse, diagFromClient := client.GetAccountSSLSettings(accountID)
+	accountSSLSettingsDTOResponse, err := client.GetAccountSSLSettings(ctx, accountID)
 
-	if diagFromClient != nil && diagFromClient.HasError() {
-		log.Printf("[ERROR] Could not read Incapsula account SSL settings for Account ID: %s, %v\n", accountID, diagFromClient)
-		return diagFromClient
+	if err != nil {
+		if removeGoneResource(d, err, "account SSL settings") {
+			return nil
+		}
+		log.Printf("[ERROR] Could not read Incapsula account SSL settings for Account ID: %s, %v\n", accountID, err)
+		return diag.FromErr(err)
 	}
 	accountSSLSettingsDTO := accountSSLSettingsDTOResponse.Data[0]
 	if err := d.Set("use_wild_card_san_instead_of_fqdn", accountSSLSettingsDTO.ImpervaCertificate.UseWildCardSanInsteadOfFQDN); err != nil {
@@ -265,7 +268,6 @@ func resourceAccountSSLSettingsRead(ctx context.Context, d *schema.ResourceData,
 
 func resourceAccountSSLSettingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
-	var diag diag.Diagnostics
 	accountID := d.Id()
 	if d.Get("account_id") != nil {
 		accountID, _ = d.Get("account_id").(string)
@@ -273,15 +275,15 @@ func resourceAccountSSLSettingsDelete(ctx context.Context, d *schema.ResourceDat
 
 	log.Printf("[INFO] Reseting Incapsula account SSL settings for Account ID: %s\n", accountID)
 
-	diag = client.DeleteAccountSSLSettings(accountID)
+	err := client.DeleteAccountSSLSettings(ctx, accountID)
 
-	if diag != nil && diag.HasError() {
-		log.Printf("[ERROR] Could not delete Incapsula account SSL settings for Account ID: %s, %v\n", accountID, diag[0].Detail)
-		return diag
+	if err != nil {
+		log.Printf("[ERROR] Could not delete Incapsula account SSL settings for Account ID: %s, %v\n", accountID, err)
+		return diag.FromErr(err)
 	}
 
 	d.SetId("")
-	return diag
+	return nil
 }
 
 func domainUniqueId(v interface{}) int {
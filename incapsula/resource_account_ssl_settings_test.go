@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -98,8 +99,8 @@ func testCheckAccAccountSSLSettingsAfterFullUpdate() resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		settings, diagnostics := client.GetAccountSSLSettings("")
-		if diagnostics != nil && diagnostics.HasError() {
+		settings, err := client.GetAccountSSLSettings(context.Background(), "")
+		if err != nil {
 			return fmt.Errorf("failed to get account ssl settings after full update resource %s", accountSSLSettingsResourceName)
 		}
 		if settings.Errors == nil && settings.Data != nil && !*settings.Data[0].ImpervaCertificate.UseWildCardSanInsteadOfFQDN && *settings.Data[0].ImpervaCertificate.AddNakedDomainSanForWWWSites &&
@@ -118,8 +119,8 @@ func testCheckAccAccountSSLSettingsAfterPartialUpdate1() resource.TestCheckFunc
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		settings, diagnostics := client.GetAccountSSLSettings("")
-		if diagnostics != nil && diagnostics.HasError() {
+		settings, err := client.GetAccountSSLSettings(context.Background(), "")
+		if err != nil {
 			return fmt.Errorf("failed to get account ssl settings after partial update1 resource %s", accountSSLSettingsResourceName)
 		}
 		if settings.Errors == nil && settings.Data != nil && *settings.Data[0].ImpervaCertificate.UseWildCardSanInsteadOfFQDN && !*settings.Data[0].ImpervaCertificate.AddNakedDomainSanForWWWSites &&
@@ -138,8 +139,8 @@ func testCheckAccAccountSSLSettingsAfterPartialUpdate2() resource.TestCheckFunc
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		settings, diagnostics := client.GetAccountSSLSettings("")
-		if diagnostics != nil && diagnostics.HasError() {
+		settings, err := client.GetAccountSSLSettings(context.Background(), "")
+		if err != nil {
 			return fmt.Errorf("failed to get account ssl settings after partia2 update1 resource %s", accountSSLSettingsResourceName)
 		}
 		if settings.Errors == nil && settings.Data != nil && *settings.Data[0].ImpervaCertificate.UseWildCardSanInsteadOfFQDN && *settings.Data[0].ImpervaCertificate.AddNakedDomainSanForWWWSites &&
@@ -158,8 +159,8 @@ func testCheckAccAccountSSLSettingsAfterPartialUpdate3() resource.TestCheckFunc
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		settings, diagnostics := client.GetAccountSSLSettings("")
-		if diagnostics != nil && diagnostics.HasError() {
+		settings, err := client.GetAccountSSLSettings(context.Background(), "")
+		if err != nil {
 			return fmt.Errorf("failed to get account ssl settings after partia3 update1 resource %s", accountSSLSettingsResourceName)
 		}
 		if settings.Errors == nil && settings.Data != nil && !*settings.Data[0].ImpervaCertificate.UseWildCardSanInsteadOfFQDN && *settings.Data[0].ImpervaCertificate.AddNakedDomainSanForWWWSites &&
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"os"
@@ -106,7 +107,7 @@ func testCheckIncapsulaAccountDestroy(state *terraform.State) error {
 			return fmt.Errorf("Account ID conversion error for %s: %s", accountIDStr, err)
 		}
 
-		_, err = client.AccountStatus(accountID, ReadAccount)
+		_, err = client.AccountStatus(context.Background(), accountID, ReadAccount)
 
 		if err == nil {
 			return fmt.Errorf("Incapsula account id: %d still exists", accountID)
@@ -134,7 +135,7 @@ func testCheckIncapsulaAccountExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		accountStatusResponse, err := client.AccountStatus(accountID, ReadAccount)
+		accountStatusResponse, err := client.AccountStatus(context.Background(), accountID, ReadAccount)
 		if accountStatusResponse == nil {
 			return fmt.Errorf("Incapsula account id: %d does not exist", accountID)
 		}
@@ -3,6 +3,7 @@ package incapsula
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"net/mail"
@@ -15,12 +16,12 @@ const sleepTimeSeconds = 2
 
 func resourceAccountUser() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceUserCreate,
-		Read:   resourceUserRead,
-		Update: resourceUserUpdate,
-		Delete: resourceUserDelete,
+		CreateContext: resourceUserCreate,
+		ReadContext:   resourceUserRead,
+		UpdateContext: resourceUserUpdate,
+		DeleteContext: resourceUserDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -122,7 +123,7 @@ func resourceAccountUser() *schema.Resource {
 	}
 }
 
-func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
+func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	email := d.Get("email").(string)
@@ -132,7 +133,7 @@ func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
 
 	roleIds := d.Get("role_ids").(*schema.Set)
 	approvedIps := d.Get("approved_ips").(*schema.Set)
-	UserAddResponse, err := client.AddAccountUser(
+	UserAddResponse, err := client.AddAccountUser(ctx,
 		accountId,
 		email,
 		d.Get("first_name").(string),
@@ -143,7 +144,7 @@ func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
 
 	if err != nil {
 		log.Printf("[ERROR] Could not create user for email: %s, %s\n", email, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the User ID
@@ -153,28 +154,33 @@ func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
 	// There may be a timing/race condition here
 	// Set an arbitrary period to sleep
 	log.Printf("[DEBUG] Avoid timing/race condition, sleeping %d seconds\n", sleepTimeSeconds)
-	time.Sleep(sleepTimeSeconds * time.Second)
+	if err := sleepWithContext(ctx, sleepTimeSeconds*time.Second); err != nil {
+		return diag.FromErr(err)
+	}
 
 	// Set the rest of the state from the resource read
-	return resourceUserRead(d, m)
+	return resourceUserRead(ctx, d, m)
 }
 
-func resourceUserRead(d *schema.ResourceData, m interface{}) error {
+func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	userID := d.Id()
 	stringSlice := strings.Split(userID, "/")
 	if len(stringSlice) != 2 {
-		return fmt.Errorf("Error parsing ID, actual value: %s, expected numeric id and string seperated by '/'\n", stringSlice)
+		return diag.Errorf("Error parsing ID, actual value: %s, expected numeric id and string seperated by '/'\n", stringSlice)
 	}
 	accountID, _ := strconv.Atoi(stringSlice[0])
 	email := stringSlice[1]
 	log.Printf("[INFO] Reading Incapsula user : %s\n", userID)
 
-	userStatusResponse, err := client.GetAccountUser(accountID, email)
+	userStatusResponse, err := client.GetAccountUser(ctx, accountID, email)
 
+	if removeGoneResource(d, err, "user") {
+		return nil
+	}
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula user: %s, %s\n", email, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO]listRoles : %v\n", userStatusResponse.Data[0].Roles)
@@ -210,7 +216,7 @@ func resourceUserRead(d *schema.ResourceData, m interface{}) error {
 	log.Printf("[DEBUG] Setting approved_ips in state: %v\n", approvedIps)
 	d.Set("approved_ips", approvedIps)
 
-	accountStatusResponse, err := client.AccountStatus(accountID, ReadAccount)
+	accountStatusResponse, err := client.AccountStatus(ctx, accountID, ReadAccount)
 	if accountStatusResponse != nil && accountStatusResponse.AccountType == "Sub Account" {
 		log.Printf("[DEBUG] User creation on Sub Account, setting null value to avoid forces replacement\n")
 		d.Set("first_name", nil)
@@ -227,7 +233,7 @@ func resourceUserRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	email := d.Get("email").(string)
 	accountId := d.Get("account_id").(int)
@@ -260,7 +266,7 @@ func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
 		log.Printf("[DEBUG] approved_ips will NOT be updated (nil)\n")
 	}
 
-	userUpdateResponse, err := client.UpdateAccountUser(
+	userUpdateResponse, err := client.UpdateAccountUser(ctx,
 		accountId,
 		email,
 		roleIds,
@@ -268,7 +274,7 @@ func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
 	)
 	if err != nil {
 		log.Printf("[ERROR] Could not update user for email: %s, %s\n", email, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[Info] New Roles for user %s : %+v\n", email, userUpdateResponse.Data[0].Roles)
@@ -276,24 +282,26 @@ func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
 	// There may be a timing/race condition here
 	// Set an arbitrary period to sleep
 	log.Printf("[DEBUG] Avoid timing/race condition, sleeping %d seconds\n", sleepTimeSeconds)
-	time.Sleep(sleepTimeSeconds * time.Second)
+	if err := sleepWithContext(ctx, sleepTimeSeconds*time.Second); err != nil {
+		return diag.FromErr(err)
+	}
 
 	// Set the rest of the state from the resource read
-	return resourceUserRead(d, m)
+	return resourceUserRead(ctx, d, m)
 }
 
-func resourceUserDelete(d *schema.ResourceData, m interface{}) error {
+func resourceUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	accountID := d.Get("account_id").(int)
 	email := d.Get("email").(string)
 
 	log.Printf("[INFO] Deleting Incapsula user: %s\n", email)
 
-	err := client.DeleteAccountUser(accountID, email)
+	err := client.DeleteAccountUser(ctx, accountID, email)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not delete Incapsula user: %s %s\n", email, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
@@ -303,7 +311,9 @@ func resourceUserDelete(d *schema.ResourceData, m interface{}) error {
 	// There may be a timing/race condition here
 	// Set an arbitrary period to sleep
 	log.Printf("[DEBUG] Avoid timing/race condition, sleeping %d seconds\n", sleepTimeSeconds)
-	time.Sleep(sleepTimeSeconds * time.Second)
+	if err := sleepWithContext(ctx, sleepTimeSeconds*time.Second); err != nil {
+		return diag.FromErr(err)
+	}
 
 	log.Printf("[INFO] Deleted Incapsula user: %s\n", email)
 
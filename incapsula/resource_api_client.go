@@ -85,7 +85,7 @@ func resourceApiClientCreate(ctx context.Context, d *schema.ResourceData, m inte
 		Enabled:        Bool(d.Get("enabled").(bool)),
 	}
 
-	resp, err := client.CreateAPIClient(
+	resp, err := client.CreateAPIClient(ctx,
 		accountID,
 		d.Get("user_email").(string),
 		&apiClient,
@@ -147,7 +147,7 @@ func resourceApiClientUpdate(ctx context.Context, d *schema.ResourceData, meta i
 		return resourceApiClientRead(ctx, d, meta)
 	}
 
-	resp, err := client.PatchAPIClient(accountID, id, request)
+	resp, err := client.PatchAPIClient(ctx, accountID, id, request)
 	if err != nil {
 		return diag.Errorf("error updating API client: %v", err)
 	}
@@ -172,11 +172,13 @@ func resourceApiClientRead(ctx context.Context, d *schema.ResourceData, meta int
 		return nil
 	}
 	accountID := d.Get("account_id").(int)
-	resp, err := client.GetAPIClient(accountID, id)
-	if err != nil {
-		d.SetId("")
+	resp, err := client.GetAPIClient(ctx, accountID, id)
+	if removeGoneResource(d, err, "API client") {
 		return nil
 	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	// Set the account_id in state
 	if accountID != 0 {
@@ -195,7 +197,7 @@ func resourceApiClientRead(ctx context.Context, d *schema.ResourceData, meta int
 func resourceApiClientDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client)
 	id := d.Id()
-	if err := client.DeleteAPIClient(d.Get("account_id").(int), id); err != nil {
+	if err := client.DeleteAPIClient(ctx, d.Get("account_id").(int), id); err != nil {
 		return diag.Errorf("error deleting API client: %v", err)
 	}
 	d.SetId("")
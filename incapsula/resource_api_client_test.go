@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -133,7 +134,7 @@ func testCheckIncapsulaApiClientExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		apiClientStatusResponse, _ := client.GetAPIClient(0, apiClientIDStr)
+		apiClientStatusResponse, _ := client.GetAPIClient(context.Background(), 0, apiClientIDStr)
 		if apiClientStatusResponse == nil {
 			return fmt.Errorf(
 				"Incapsula api client with id: %s does not exist", apiClientIDStr)
@@ -1,23 +1,25 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceApiSecurityApiConfig() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceApiSecurityAPIConfigCreate,
-		Read:   resourceApiSecurityAPIConfigRead,
-		Update: resourceApiSecurityAPIConfigUpdate,
-		Delete: resourceApiSecurityAPIConfigDelete,
+		CreateContext: resourceApiSecurityAPIConfigCreate,
+		ReadContext:   resourceApiSecurityAPIConfigRead,
+		UpdateContext: resourceApiSecurityAPIConfigUpdate,
+		DeleteContext: resourceApiSecurityAPIConfigDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/api_id", d.Id())
@@ -117,7 +119,7 @@ func resourceApiSecurityApiConfig() *schema.Resource {
 	}
 }
 
-func resourceApiSecurityAPIConfigCreate(d *schema.ResourceData, m interface{}) error {
+func resourceApiSecurityAPIConfigCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	payload := ApiSecurityApiConfigPostPayload{
@@ -134,23 +136,23 @@ func resourceApiSecurityAPIConfigCreate(d *schema.ResourceData, m interface{}) e
 		},
 	}
 
-	apiSecurityApiConfigPostResponse, err := client.CreateApiSecurityApiConfig(
+	apiSecurityApiConfigPostResponse, err := client.CreateApiSecurityApiConfig(ctx,
 		int64(d.Get("site_id").(int)),
 		&payload)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not create Incapsula API-security site configuration on site id: %d - %s\n", d.Get("site_id"), err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	apiID := strconv.FormatInt(apiSecurityApiConfigPostResponse.Value.ApiId, 10)
 	d.SetId(apiID)
 	log.Printf("[INFO] Updated Incapsula API-security api configuration with ID: %s\n", apiID)
 
-	return resourceApiSecurityAPIConfigRead(d, m)
+	return resourceApiSecurityAPIConfigRead(ctx, d, m)
 }
 
-func resourceApiSecurityAPIConfigUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceApiSecurityAPIConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	payload := ApiSecurityApiConfigPostPayload{
@@ -167,34 +169,37 @@ func resourceApiSecurityAPIConfigUpdate(d *schema.ResourceData, m interface{}) e
 		},
 	}
 
-	_, err := client.UpdateApiSecurityApiConfig(
+	_, err := client.UpdateApiSecurityApiConfig(ctx,
 		int64(d.Get("site_id").(int)),
 		d.Id(),
 		&payload)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not update Incapsula API-security API configuration on site id: %d - %s\n", d.Get("site_id"), err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Updated Incapsula API-security api configuration with ID: %s\n", d.Id())
-	return resourceApiSecurityAPIConfigRead(d, m)
+	return resourceApiSecurityAPIConfigRead(ctx, d, m)
 }
 
-func resourceApiSecurityAPIConfigRead(d *schema.ResourceData, m interface{}) error {
+func resourceApiSecurityAPIConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteID := int64(d.Get("site_id").(int))
 	apiID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
 		log.Printf("[ERROR] Could not read API Security API Config ID: %s - %s\n", d.Id(), err)
-		return err
+		return diag.FromErr(err)
 	}
 
-	apiSecurityApiConfigGetResponse, err := client.GetApiSecurityApiConfig(siteID, apiID)
+	apiSecurityApiConfigGetResponse, err := client.GetApiSecurityApiConfig(ctx, siteID, apiID)
 
+	if removeGoneResource(d, err, "API security API configuration") {
+		return nil
+	}
 	if err != nil {
 		log.Printf("[ERROR] Could not get Incapsula API Security API: %d - %s\n", apiID, err)
-		return err
+		return diag.FromErr(err)
 	}
 	// Set computed values
 	d.SetId(strconv.FormatInt(apiSecurityApiConfigGetResponse.Value.Id, 10))
@@ -209,28 +214,28 @@ func resourceApiSecurityAPIConfigRead(d *schema.ResourceData, m interface{}) err
 	d.Set("invalid_param_name_violation_action", apiSecurityApiConfigGetResponse.Value.ViolationActions.InvalidParamNameViolationAction)
 	d.Set("invalid_param_value_violation_action", apiSecurityApiConfigGetResponse.Value.ViolationActions.InvalidParamValueViolationAction)
 
-	apiSecurityApiConfigGetFileResponse, err := client.GetApiSecurityApiSwaggerConfig(siteID, apiID)
+	apiSecurityApiConfigGetFileResponse, err := client.GetApiSecurityApiSwaggerConfig(ctx, siteID, apiID)
 	if err != nil {
 		log.Printf("[ERROR] Could not get Incapsula API Security API swagger file: %d - %s\n", apiID, err)
-		return err
+		return diag.FromErr(err)
 	}
 	d.Set("api_specification", apiSecurityApiConfigGetFileResponse.Value)
 
 	return nil
 }
 
-func resourceApiSecurityAPIConfigDelete(d *schema.ResourceData, m interface{}) error {
+func resourceApiSecurityAPIConfigDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteID := int64(d.Get("site_id").(int))
 	apiID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return fmt.Errorf("Error converting Api Security API configuration ID for site (%d). Expected numeric value, got %s", siteID, d.Id())
+		return diag.Errorf("Error converting Api Security API configuration ID for site (%d). Expected numeric value, got %s", siteID, d.Id())
 	}
 
-	err = client.DeleteApiSecurityApiConfig(siteID, d.Id())
+	err = client.DeleteApiSecurityApiConfig(ctx, siteID, d.Id())
 
 	if err != nil {
-		return fmt.Errorf("Error deleting Api Security API configuration for site (%d), API Id (%d): %s", siteID, apiID, err)
+		return diag.Errorf("Error deleting Api Security API configuration for site (%d), API Id (%d): %s", siteID, apiID, err)
 	}
 
 	// Set the ID to empty
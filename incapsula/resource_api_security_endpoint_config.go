@@ -1,23 +1,25 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceApiSecurityEndpointConfig() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceApiSecurityEndpointConfigCreate,
-		Read:   resourceApiSecurityEndpointConfigRead,
-		Update: resourceApiSecurityEndpointConfigUpdate,
-		Delete: resourceApiSecurityEndpointConfigDelete,
+		CreateContext: resourceApiSecurityEndpointConfigCreate,
+		ReadContext:   resourceApiSecurityEndpointConfigRead,
+		UpdateContext: resourceApiSecurityEndpointConfigUpdate,
+		DeleteContext: resourceApiSecurityEndpointConfigDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
 					return nil, fmt.Errorf("unexpected format of API Security Endpoint ID (%q), expected api_id/endpoint_id", d.Id())
@@ -89,13 +91,16 @@ func resourceApiSecurityEndpointConfig() *schema.Resource {
 	}
 }
 
-func resourceApiSecurityEndpointConfigRead(d *schema.ResourceData, m interface{}) error {
+func resourceApiSecurityEndpointConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Read Incapsula API-security endpoint configuration for ID: %s", d.Id())
 	client := m.(*Client)
-	endpointGetResponse, err := client.GetApiSecurityEndpointConfig(int64(d.Get("api_id").(int)), d.Id())
+	endpointGetResponse, err := client.GetApiSecurityEndpointConfig(ctx, int64(d.Get("api_id").(int)), d.Id())
+	if removeGoneResource(d, err, "API security endpoint configuration") {
+		return nil
+	}
 	if err != nil {
 		log.Printf("[ERROR] Could not get Incapsula API-security endpoint: %s - %s\n", d.Get("id"), err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("missing_param_violation_action", endpointGetResponse.Value.ViolationActions.MissingParamViolationAction)
@@ -106,9 +111,9 @@ func resourceApiSecurityEndpointConfigRead(d *schema.ResourceData, m interface{}
 	return nil
 }
 
-func resourceApiSecurityEndpointConfigCreate(d *schema.ResourceData, m interface{}) error {
+func resourceApiSecurityEndpointConfigCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
-	endpointGetAllResponse, _ := client.GetApiSecurityAllEndpointsConfig(int64(d.Get("api_id").(int)))
+	endpointGetAllResponse, _ := client.GetApiSecurityAllEndpointsConfig(ctx, int64(d.Get("api_id").(int)))
 	var found bool
 	var endpointId string
 	for _, entry := range endpointGetAllResponse.Value {
@@ -120,15 +125,15 @@ func resourceApiSecurityEndpointConfigCreate(d *schema.ResourceData, m interface
 	}
 
 	if !found {
-		return fmt.Errorf("[ERROR] API-security endpoint [%s %s] doesn't exist and will not be updated.", d.Get("method").(string), d.Get("path").(string))
+		return diag.Errorf("[ERROR] API-security endpoint [%s %s] doesn't exist and will not be updated.", d.Get("method").(string), d.Get("path").(string))
 	}
 	log.Printf("[DEBUG] found endpoint id %s", endpointId)
 	d.SetId(endpointId)
 
-	return resourceApiSecurityEndpointConfigUpdate(d, m)
+	return resourceApiSecurityEndpointConfigUpdate(ctx, d, m)
 }
 
-func resourceApiSecurityEndpointConfigUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceApiSecurityEndpointConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	payload := ApiSecurityEndpointConfigPostPayload{
@@ -143,16 +148,16 @@ func resourceApiSecurityEndpointConfigUpdate(d *schema.ResourceData, m interface
 	if err != nil {
 		fmt.Errorf("Endpoint ID should be numeric. Actual value: %s", d.Id())
 	}
-	_, err = client.PostApiSecurityEndpointConfig(int64(d.Get("api_id").(int)), endpointId, &payload)
+	_, err = client.PostApiSecurityEndpointConfig(ctx, int64(d.Get("api_id").(int)), endpointId, &payload)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceApiSecurityEndpointConfigRead(d, m)
+	return resourceApiSecurityEndpointConfigRead(ctx, d, m)
 }
 
-func resourceApiSecurityEndpointConfigDelete(d *schema.ResourceData, m interface{}) error {
+func resourceApiSecurityEndpointConfigDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Deleting Incapsula API-security endpoint configuration isn't supported. Request made for endpoint ID: %s \n", d.Id())
 	return nil
 }
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -83,7 +84,7 @@ func testCheckIncapsulaApiSecurityEndpointExists(name string) resource.TestCheck
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		endpointListResponse, err := client.GetApiSecurityEndpointConfig(apiIdInt, endpointId)
+		endpointListResponse, err := client.GetApiSecurityEndpointConfig(context.Background(), apiIdInt, endpointId)
 		if err != nil {
 			return fmt.Errorf("Incapsula Api Security Endpoint doesn't exist")
 		}
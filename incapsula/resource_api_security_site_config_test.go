@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -54,7 +55,7 @@ func testCheckApiSecuritySiteConfigExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		_, err = client.ReadApiSecuritySiteConfig(siteId)
+		_, err = client.ReadApiSecuritySiteConfig(context.Background(), siteId)
 		if err != nil {
 			fmt.Errorf("Incapsula Api Security Site Config doesn't exist")
 		}
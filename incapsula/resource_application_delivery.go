@@ -22,7 +22,7 @@ func resourceApplicationDelivery() *schema.Resource {
 		UpdateContext: resourceApplicationDeliveryUpdate,
 		DeleteContext: resourceApplicationDeliveryDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				siteID, err := strconv.Atoi(d.Id())
 				if err != nil {
 					fmt.Errorf("failed to convert Site Id from import command for Application Delivery, actual value: %s, expected numeric id", d.Id())
@@ -312,16 +312,22 @@ func resourceApplicationDeliveryRead(ctx context.Context, d *schema.ResourceData
 	siteID := d.Get("site_id").(int)
 	siteIdStr := strconv.Itoa(siteID)
 
-	applicationDelivery, diag := client.GetApplicationDelivery(siteID)
-	if diag != nil {
+	applicationDelivery, err := client.GetApplicationDelivery(ctx, siteID)
+	if err != nil {
+		if removeGoneResource(d, err, "application delivery") {
+			return nil
+		}
 		log.Printf("[ERROR] Could not get Incapsula Application Delivery for Site Id: %d\n", siteID)
-		return diag
+		return diag.FromErr(err)
 	}
 
-	errorPages, diag := client.GetErrorPages(siteID)
-	if diag != nil {
+	errorPages, err := client.GetErrorPages(ctx, siteID)
+	if err != nil {
+		if removeGoneResource(d, err, "application delivery") {
+			return nil
+		}
 		log.Printf("[ERROR] Could not get Incapsula Error Pages for Site Id: %d\n", siteID)
-		return diag
+		return diag.FromErr(err)
 	}
 
 	d.SetId(siteIdStr)
@@ -432,11 +438,11 @@ func resourceApplicationDeliveryUpdate(ctx context.Context, d *schema.ResourceDa
 		Redirection:      redirection,
 	}
 
-	_, diags = client.UpdateApplicationDelivery(siteID, &payload)
+	_, err := client.UpdateApplicationDelivery(ctx, siteID, &payload)
 
-	if diags != nil {
-		log.Printf("[ERROR] Could not update Incapsula Application Delivery for Site Id: %d - %s\n", siteID, diags[0].Detail)
-		return diags
+	if err != nil {
+		log.Printf("[ERROR] Could not update Incapsula Application Delivery for Site Id: %d - %s\n", siteID, err)
+		return diag.FromErr(err)
 	}
 
 	if d.HasChange("default_error_page_template") ||
@@ -465,11 +471,11 @@ func resourceApplicationDeliveryUpdate(ctx context.Context, d *schema.ResourceDa
 			},
 		}
 
-		_, diags := client.UpdateErrorPages(siteID, &customErrorPage)
+		_, err := client.UpdateErrorPages(ctx, siteID, &customErrorPage)
 
-		if diags != nil {
+		if err != nil {
 			log.Printf("[ERROR] Could not get Incapsula Error Pages for Site Id: %d\n", siteID)
-			return diags
+			return diag.FromErr(err)
 		}
 	}
 
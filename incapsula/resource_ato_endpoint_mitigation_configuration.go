@@ -1,7 +1,9 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"net/http"
@@ -11,12 +13,12 @@ import (
 
 func ATOEndpointMitigationConfiguration() *schema.Resource {
 	return &schema.Resource{
-		Create: ATOEndpointMitigationConfigurationUpdate,
-		Read:   resourceATOEndpointMitigationConfigurationRead,
-		Update: ATOEndpointMitigationConfigurationUpdate,
-		Delete: ATOEndpointMitigationConfigurationDelete,
+		CreateContext: ATOEndpointMitigationConfigurationUpdate,
+		ReadContext:   resourceATOEndpointMitigationConfigurationRead,
+		UpdateContext: ATOEndpointMitigationConfigurationUpdate,
+		DeleteContext: ATOEndpointMitigationConfigurationDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				keyParts := strings.Split(d.Id(), "/")
 				print("id is %s", d.Id())
 				if len(keyParts) != 3 {
@@ -79,7 +81,7 @@ func ATOEndpointMitigationConfiguration() *schema.Resource {
 	}
 }
 
-func resourceATOEndpointMitigationConfigurationRead(d *schema.ResourceData, m interface{}) error {
+func resourceATOEndpointMitigationConfigurationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	// Fetch our http client
 	client := m.(*Client)
@@ -88,13 +90,13 @@ func resourceATOEndpointMitigationConfigurationRead(d *schema.ResourceData, m in
 	siteId, ok := d.Get("site_id").(int)
 
 	if !ok {
-		return fmt.Errorf("site_id should be of type int. Received : %s", d.Get("site_id"))
+		return diag.Errorf("site_id should be of type int. Received : %s", d.Get("site_id"))
 	}
 
 	if siteId == 0 {
 		siteIdFromResourceId, conversionError := strconv.Atoi(strings.Split(d.Id(), "/")[1])
 		if conversionError != nil {
-			return fmt.Errorf("either id : %s or (account_id, site_id and endpoint_id) should be set for incapsula_ato_endpoint_mitigation_configuration for reading mitigation configuration", d.Id())
+			return diag.Errorf("either id : %s or (account_id, site_id and endpoint_id) should be set for incapsula_ato_endpoint_mitigation_configuration for reading mitigation configuration", d.Id())
 		}
 		siteId = siteIdFromResourceId
 	}
@@ -103,21 +105,24 @@ func resourceATOEndpointMitigationConfigurationRead(d *schema.ResourceData, m in
 	accountId, ok := d.Get("account_id").(int)
 
 	if !ok {
-		return fmt.Errorf("account_id should be of type string. Received : %s", d.Get("account_id"))
+		return diag.Errorf("account_id should be of type string. Received : %s", d.Get("account_id"))
 	}
 
 	// Extract the EndpointId
 	endpointId, ok := d.Get("endpoint_id").(string)
 
 	if !ok {
-		return fmt.Errorf("endpoint_id should be of type string. Received : %s", d.Get("endpoint_id"))
+		return diag.Errorf("endpoint_id should be of type string. Received : %s", d.Get("endpoint_id"))
 	}
 
-	atoEndpointMitigationConfigurationDTO, status, err := client.GetAtoEndpointMitigationConfigurationWithRetries(accountId, siteId, endpointId)
+	atoEndpointMitigationConfigurationDTO, status, err := client.GetAtoEndpointMitigationConfigurationWithRetries(ctx, accountId, siteId, endpointId)
 
+	if removeGoneResource(d, err, "ATO endpoint mitigation configuration") {
+		return nil
+	}
 	// Handle fetch error
 	if err != nil {
-		return fmt.Errorf("[Error] getting ATO site mitigation configuration for site : %d Error : : %s", siteId, err)
+		return diag.Errorf("[Error] getting ATO site mitigation configuration for site : %d Error : : %s", siteId, err)
 	}
 
 	// Handle site does not exist in ATO. If this is a permissions issue, then let this be addressed in the update phase
@@ -144,18 +149,18 @@ func resourceATOEndpointMitigationConfigurationRead(d *schema.ResourceData, m in
 	return nil
 }
 
-func ATOEndpointMitigationConfigurationUpdate(d *schema.ResourceData, m interface{}) error {
+func ATOEndpointMitigationConfigurationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Extract the required identifiers siteId, accountId and endpointId
 	siteId, ok := d.Get("site_id").(int)
 
 	if !ok {
-		return fmt.Errorf("site_id should be of type int. Received : %s", d.Get("site_id"))
+		return diag.Errorf("site_id should be of type int. Received : %s", d.Get("site_id"))
 	}
 
 	if siteId == 0 {
 		siteIdFromResourceId, conversionError := strconv.Atoi(d.Id())
 		if conversionError != nil {
-			return fmt.Errorf("either id or (account_id, site_id and endpoint_id) should be set for incapsula_ato_endpoint_mitigation_configuration for updating mitigation configuration")
+			return diag.Errorf("either id or (account_id, site_id and endpoint_id) should be set for incapsula_ato_endpoint_mitigation_configuration for updating mitigation configuration")
 		}
 		siteId = siteIdFromResourceId
 	}
@@ -164,35 +169,35 @@ func ATOEndpointMitigationConfigurationUpdate(d *schema.ResourceData, m interfac
 	accountId, ok := d.Get("account_id").(int)
 
 	if !ok {
-		return fmt.Errorf("account_id should be of type string. Received : %s", d.Get("account_id"))
+		return diag.Errorf("account_id should be of type string. Received : %s", d.Get("account_id"))
 	}
 
 	// Extract the EndpointId
 	endpointId, ok := d.Get("endpoint_id").(string)
 
 	if !ok {
-		return fmt.Errorf("endpoint_id should be of type string. Received : %s", d.Get("endpoint_id"))
+		return diag.Errorf("endpoint_id should be of type string. Received : %s", d.Get("endpoint_id"))
 	}
 
 	// Extract low action
 	lowAction, ok := d.Get("mitigation_action_for_low_risk").(string)
 
 	if !ok {
-		return fmt.Errorf("mitigation_action_for_low_risk should be of type string. Received : %s", d.Get("mitigation_action_for_low_risk"))
+		return diag.Errorf("mitigation_action_for_low_risk should be of type string. Received : %s", d.Get("mitigation_action_for_low_risk"))
 	}
 
 	// Extract medium action
 	mediumAction, ok := d.Get("mitigation_action_for_medium_risk").(string)
 
 	if !ok {
-		return fmt.Errorf("mitigation_action_for_medium_risk should be of type string. Received : %s", d.Get("mitigation_action_for_medium_risk"))
+		return diag.Errorf("mitigation_action_for_medium_risk should be of type string. Received : %s", d.Get("mitigation_action_for_medium_risk"))
 	}
 
 	// Extract high action
 	highAction, ok := d.Get("mitigation_action_for_high_risk").(string)
 
 	if !ok {
-		return fmt.Errorf("mitigation_action_for_high_risk should be of type string. Received : %s", d.Get("mitigation_action_for_high_risk"))
+		return diag.Errorf("mitigation_action_for_high_risk should be of type string. Received : %s", d.Get("mitigation_action_for_high_risk"))
 	}
 
 	// convert terraform compatible map to ATOEndpointMitigationConfigurationDTO
@@ -208,17 +213,17 @@ func ATOEndpointMitigationConfigurationUpdate(d *schema.ResourceData, m interfac
 	// Fetch our http client
 	client := m.(*Client)
 
-	err := client.UpdateATOEndpointMitigationConfigurationWithRetries(&atoMitigationConfigurationDTO)
+	err := client.UpdateATOEndpointMitigationConfigurationWithRetries(ctx, &atoMitigationConfigurationDTO)
 	if err != nil {
 		// Return the error from the api call
-		e := fmt.Errorf("[ERROR] Could not update ATO site mitigation configuration for site ID : %d Error : %s \n", atoMitigationConfigurationDTO.SiteId, err)
-		return e
+		e := fmt.Errorf("[ERROR] Could not update ATO site mitigation configuration for site ID : %d Error : %w \n", atoMitigationConfigurationDTO.SiteId, err)
+		return diag.FromErr(e)
 	}
 
-	return resourceATOEndpointMitigationConfigurationRead(d, m)
+	return resourceATOEndpointMitigationConfigurationRead(ctx, d, m)
 }
 
-func ATOEndpointMitigationConfigurationDelete(d *schema.ResourceData, m interface{}) error {
+func ATOEndpointMitigationConfigurationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteId := d.Get("site_id").(int)
 	accountId := d.Get("account_id").(int)
@@ -226,10 +231,10 @@ func ATOEndpointMitigationConfigurationDelete(d *schema.ResourceData, m interfac
 
 	log.Printf("[DEBUG] Disabling ATO site mitigation for site ID %d \n", siteId)
 
-	err := client.DisableATOEndpointMitigationConfiguration(accountId, siteId, endpointId)
+	err := client.DisableATOEndpointMitigationConfiguration(ctx, accountId, siteId, endpointId)
 	if err != nil {
-		e := fmt.Errorf("[ERROR] Could not disable ATO site mitigation configuration for site ID : %d Error : %s \n", siteId, err)
-		return e
+		e := fmt.Errorf("[ERROR] Could not disable ATO site mitigation configuration for site ID : %d Error : %w \n", siteId, err)
+		return diag.FromErr(e)
 	}
 
 	return nil
@@ -1,7 +1,9 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"net/http"
@@ -11,12 +13,12 @@ import (
 
 func resourceATOSiteAllowlist() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceATOSiteAllowlistUpdate,
-		Read:   resourceATOSiteAllowlistRead,
-		Update: resourceATOSiteAllowlistUpdate,
-		Delete: resourceATOSiteAllowlistDelete,
+		CreateContext: resourceATOSiteAllowlistUpdate,
+		ReadContext:   resourceATOSiteAllowlistRead,
+		UpdateContext: resourceATOSiteAllowlistUpdate,
+		DeleteContext: resourceATOSiteAllowlistDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 
 				// If this id is of form <account_id>/<site_id> extract the sub account Id as well
 				if strings.Contains(d.Id(), "/") {
@@ -44,7 +46,7 @@ func resourceATOSiteAllowlist() *schema.Resource {
 				siteId, err := strconv.Atoi(d.Id())
 				err = d.Set("site_id", siteId)
 				if err != nil {
-					return nil, fmt.Errorf("[ERROR] failed to extract site ID from import command, actual value: %s, error : %s", d.Id(), err)
+					return nil, fmt.Errorf("[ERROR] failed to extract site ID from import command, actual value: %s, error : %w", d.Id(), err)
 				}
 				log.Printf("[DEBUG] Import ATO allowlist for site ID %d", siteId)
 				return []*schema.ResourceData{d}, nil
@@ -76,7 +78,7 @@ func resourceATOSiteAllowlist() *schema.Resource {
 	}
 }
 
-func resourceATOSiteAllowlistRead(d *schema.ResourceData, m interface{}) error {
+func resourceATOSiteAllowlistRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	// Fetch our http client
 	client := m.(*Client)
@@ -85,23 +87,26 @@ func resourceATOSiteAllowlistRead(d *schema.ResourceData, m interface{}) error {
 	siteId, ok := d.Get("site_id").(int)
 
 	if !ok {
-		return fmt.Errorf("site_id should be of type int. Received : %s", d.Get("site_id"))
+		return diag.Errorf("site_id should be of type int. Received : %s", d.Get("site_id"))
 	}
 
 	if siteId == 0 {
 		siteIdFromResourceId, conversionError := strconv.Atoi(d.Id())
 		if conversionError != nil {
-			return fmt.Errorf("atleast one of id or site_id should be set for incapsula_ato_site_allowlist")
+			return diag.Errorf("atleast one of id or site_id should be set for incapsula_ato_site_allowlist")
 		}
 		siteId = siteIdFromResourceId
 	}
 
 	accountId := d.Get("account_id").(int)
-	atoAllowlistDTO, status, err := client.GetAtoSiteAllowlistWithRetries(accountId, siteId)
+	atoAllowlistDTO, status, err := client.GetAtoSiteAllowlistWithRetries(ctx, accountId, siteId)
 
+	if removeGoneResource(d, err, "ATO site allowlist") {
+		return nil
+	}
 	// Handle fetch error
 	if err != nil {
-		return fmt.Errorf("[Error] getting ATO allowlist: %s", err)
+		return diag.Errorf("[Error] getting ATO allowlist: %s", err)
 	}
 
 	// Initialize the data map that terraform uses
@@ -143,14 +148,14 @@ func resourceATOSiteAllowlistRead(d *schema.ResourceData, m interface{}) error {
 
 	err = d.Set("allowlist", atoAllowlistEntry["allowlist"])
 	if err != nil {
-		e := fmt.Errorf("[Error] Error in reading allowlist values : %s", err)
-		return e
+		e := fmt.Errorf("[Error] Error in reading allowlist values : %w", err)
+		return diag.FromErr(e)
 	}
 
 	return nil
 }
 
-func resourceATOSiteAllowlistUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceATOSiteAllowlistUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteId := d.Get("site_id").(int)
 	accountId := d.Get("account_id").(int)
@@ -165,31 +170,31 @@ func resourceATOSiteAllowlistUpdate(d *schema.ResourceData, m interface{}) error
 	// convert terraform compatible map to ATOAllowlistDTO
 	atoAllowlistDTO, err := formAtoAllowlistDTOFromMap(atoAllowlistMap)
 	if err != nil {
-		e := fmt.Errorf("[Error] Error forming ATO allow list object for API call : %s", err)
+		e := fmt.Errorf("[Error] Error forming ATO allow list object for API call : %w", err)
 		log.Print(e.Error())
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = client.UpdateATOSiteAllowlistWithRetries(atoAllowlistDTO)
+	err = client.UpdateATOSiteAllowlistWithRetries(ctx, atoAllowlistDTO)
 	if err != nil {
-		e := fmt.Errorf("[ERROR] Could not update ATO site allowlist for site ID : %d Error : %s \n", atoAllowlistDTO.SiteId, err)
-		return e
+		e := fmt.Errorf("[ERROR] Could not update ATO site allowlist for site ID : %d Error : %w \n", atoAllowlistDTO.SiteId, err)
+		return diag.FromErr(e)
 	}
 
-	return resourceATOSiteAllowlistRead(d, m)
+	return resourceATOSiteAllowlistRead(ctx, d, m)
 }
 
-func resourceATOSiteAllowlistDelete(d *schema.ResourceData, m interface{}) error {
+func resourceATOSiteAllowlistDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteId := d.Get("site_id").(int)
 	accountId := d.Get("account_id").(int)
 
 	log.Printf("[DEBUG] Deleting ATO site allowlist for site ID %d \n", siteId)
 
-	err := client.DeleteATOSiteAllowlist(accountId, siteId)
+	err := client.DeleteATOSiteAllowlist(ctx, accountId, siteId)
 	if err != nil {
-		e := fmt.Errorf("[ERROR] Could not delete ATO site allowlist for site ID : %d Error : %s \n", siteId, err)
-		return e
+		e := fmt.Errorf("[ERROR] Could not delete ATO site allowlist for site ID : %d Error : %w \n", siteId, err)
+		return diag.FromErr(e)
 	}
 
 	return nil
@@ -1,8 +1,9 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"sort"
@@ -14,12 +15,12 @@ var botId = "id"
 
 func resourceBotsConfiguration() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBotsConfigurationCreate,
-		Read:   resourceBotsConfigurationRead,
-		Update: resourceBotsConfigurationCreate,
-		Delete: resourceBotsConfigurationDelete,
+		CreateContext: resourceBotsConfigurationCreate,
+		ReadContext:   resourceBotsConfigurationRead,
+		UpdateContext: resourceBotsConfigurationCreate,
+		DeleteContext: resourceBotsConfigurationDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				d.Set("site_id", d.Id())
 				return []*schema.ResourceData{d}, nil
 			},
@@ -84,35 +85,38 @@ func populateFromConfBotsConfigurationDTO(d *schema.ResourceData) BotsConfigurat
 	return requestDTO
 }
 
-func resourceBotsConfigurationCreate(d *schema.ResourceData, m interface{}) error {
+func resourceBotsConfigurationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	requestDTO := populateFromConfBotsConfigurationDTO(d)
 
-	responseDTO, err := client.UpdateBotAccessControlConfiguration(d.Get("site_id").(string), requestDTO)
+	responseDTO, err := client.UpdateBotAccessControlConfiguration(ctx, d.Get("site_id").(string), requestDTO)
 	if err != nil {
-		return fmt.Errorf("Error updating Bots configuration for site (%s): %s",
+		return diag.Errorf("Error updating Bots configuration for site (%s): %s",
 			d.Get("site_id"), err)
 	}
 
 	if responseDTO.Errors != nil && len(responseDTO.Errors) > 0 {
-		return fmt.Errorf("Error updating Bots configuration for site (%s): %s",
+		return diag.Errorf("Error updating Bots configuration for site (%s): %s",
 			d.Get("site_id"), responseDTO.Errors)
 	}
 
 	// Set the dc ID
 	d.SetId(d.Get("site_id").(string))
 
-	return resourceBotsConfigurationRead(d, m)
+	return resourceBotsConfigurationRead(ctx, d, m)
 }
 
-func resourceBotsConfigurationRead(d *schema.ResourceData, m interface{}) error {
+func resourceBotsConfigurationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by reading the ListBotsResponse for the bots
 	client := m.(*Client)
 
-	responseDTO, err := client.GetBotAccessControlConfiguration(d.Get("site_id").(string))
+	responseDTO, err := client.GetBotAccessControlConfiguration(ctx, d.Get("site_id").(string))
 	if err != nil {
-		return fmt.Errorf("Error getting Bots configuration for site (%s): %s", d.Get("site_id"), err)
+		if removeGoneResource(d, err, "bots configuration") {
+			return nil
+		}
+		return diag.Errorf("Error getting Bots configuration for site (%s): %s", d.Get("site_id"), err)
 	}
 
 	if responseDTO.Errors != nil && len(responseDTO.Errors) > 0 {
@@ -126,7 +130,7 @@ func resourceBotsConfigurationRead(d *schema.ResourceData, m interface{}) error
 		if err != nil {
 			panic(err)
 		}
-		return fmt.Errorf("Error getting Bots configuration for site (%s): %s", d.Get("site_id"), string(out))
+		return diag.Errorf("Error getting Bots configuration for site (%s): %s", d.Get("site_id"), string(out))
 	}
 
 	canceledGoodBotsList := make([]int, 0)
@@ -149,20 +153,20 @@ func resourceBotsConfigurationRead(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceBotsConfigurationDelete(d *schema.ResourceData, m interface{}) error {
+func resourceBotsConfigurationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
-	responseDTO, err := client.GetBotAccessControlConfiguration(d.Get("site_id").(string))
-	if err != nil {
-		return fmt.Errorf("Error deleting Bots configuration for site (%s): %s", d.Get("site_id"), err)
+	responseDTO, err := client.GetBotAccessControlConfiguration(ctx, d.Get("site_id").(string))
+	if err != nil && !IsNotFound(err) {
+		return diag.Errorf("Error deleting Bots configuration for site (%s): %s", d.Get("site_id"), err)
 	}
 
-	if responseDTO.Errors != nil && len(responseDTO.Errors) > 0 && responseDTO.Errors[0].Status != "404" {
+	if responseDTO != nil && responseDTO.Errors != nil && len(responseDTO.Errors) > 0 && responseDTO.Errors[0].Status != "404" {
 		out, err := json.Marshal(responseDTO.Errors)
 		if err != nil {
 			panic(err)
 		}
-		return fmt.Errorf("Error deleting Bots configuration for site (%s): %s", d.Get("site_id"), string(out))
+		return diag.Errorf("Error deleting Bots configuration for site (%s): %s", d.Get("site_id"), string(out))
 	}
 
 	d.SetId("")
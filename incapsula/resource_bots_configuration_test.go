@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
@@ -79,7 +80,7 @@ func testAccCheckIncapsulaBotsConfigurationDestroy(state *terraform.State) error
 			return nil
 		}
 
-		listBotResponse, _ := client.GetBotAccessControlConfiguration(siteID)
+		listBotResponse, _ := client.GetBotAccessControlConfiguration(context.Background(), siteID)
 
 		// See comment above - the bot may have already been deleted
 		// This workaround will be removed in the future
@@ -128,12 +129,12 @@ func testCheckIncapsulaBotsConfigurationExists(name string) resource.TestCheckFu
 
 		// If the site has already been deleted then return nil
 		// Otherwise check the bot list
-		_, err = client.SiteStatus(domain, siteID)
+		_, err = client.SiteStatus(context.Background(), domain, siteID)
 		if err != nil {
 			return nil
 		}
 
-		responseDTO, err := client.GetBotAccessControlConfiguration(siteIDString)
+		responseDTO, err := client.GetBotAccessControlConfiguration(context.Background(), siteIDString)
 		if responseDTO == nil || responseDTO.Data == nil || len(responseDTO.Data) == 0 {
 			return fmt.Errorf("Incapsula bots configuration: %s (Site ID: %d) does not exist\n%s", name, siteID, err)
 		}
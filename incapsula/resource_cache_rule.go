@@ -1,21 +1,23 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceCacheRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCacheRuleCreate,
-		Read:   resourceCacheRuleRead,
-		Update: resourceCacheRuleUpdate,
-		Delete: resourceCacheRuleDelete,
+		CreateContext: resourceCacheRuleCreate,
+		ReadContext:   resourceCacheRuleRead,
+		UpdateContext: resourceCacheRuleUpdate,
+		DeleteContext: resourceCacheRuleDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/rule_id", d.Id())
@@ -84,7 +86,7 @@ func resourceCacheRule() *schema.Resource {
 	}
 }
 
-func resourceCacheRuleCreate(d *schema.ResourceData, m interface{}) error {
+func resourceCacheRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	rule := CacheRule{
@@ -98,36 +100,35 @@ func resourceCacheRuleCreate(d *schema.ResourceData, m interface{}) error {
 		DifferentiateByValue: d.Get("differentiate_by_value").(string),
 	}
 
-	ruleWithID, err := client.AddCacheRule(d.Get("site_id").(string), &rule)
+	ruleWithID, err := client.AddCacheRule(ctx, d.Get("site_id").(string), &rule)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(strconv.Itoa(ruleWithID.RuleID))
 
-	return resourceCacheRuleRead(d, m)
+	return resourceCacheRuleRead(ctx, d, m)
 }
 
-func resourceCacheRuleRead(d *schema.ResourceData, m interface{}) error {
+func resourceCacheRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by reading the SiteResponse for the site
 	client := m.(*Client)
 
 	ruleID, err := strconv.Atoi(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	rule, statusCode, err := client.ReadCacheRule(d.Get("site_id").(string), ruleID)
+	rule, _, err := client.ReadCacheRule(ctx, d.Get("site_id").(string), ruleID)
 
-	// If the rule is deleted on the server, blow it out locally and run through the normal TF cycle
-	if statusCode == 404 {
-		d.SetId("")
+	// If the rule or its site is deleted on the server, blow it out locally and run through the normal TF cycle
+	if removeGoneResource(d, err, "cache rule") {
 		return nil
 	}
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Update all of the properties
@@ -143,7 +144,7 @@ func resourceCacheRuleRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceCacheRuleUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceCacheRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	rule := CacheRule{
@@ -159,29 +160,29 @@ func resourceCacheRuleUpdate(d *schema.ResourceData, m interface{}) error {
 
 	ruleID, err := strconv.Atoi(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = client.UpdateCacheRule(d.Get("site_id").(string), ruleID, &rule)
+	err = client.UpdateCacheRule(ctx, d.Get("site_id").(string), ruleID, &rule)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceCacheRuleRead(d, m)
+	return resourceCacheRuleRead(ctx, d, m)
 }
 
-func resourceCacheRuleDelete(d *schema.ResourceData, m interface{}) error {
+func resourceCacheRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	ruleID, err := strconv.Atoi(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = client.DeleteCacheRule(d.Get("site_id").(string), ruleID)
+	err = client.DeleteCacheRule(ctx, d.Get("site_id").(string), ruleID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
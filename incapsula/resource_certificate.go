@@ -1,20 +1,22 @@
 package incapsula
 
 import (
+	"context"
 	"crypto/sha1"
 	"encoding/hex"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 )
 
 func resourceCertificate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCertificateCreate,
-		Read:   resourceCertificateRead,
-		Update: resourceCertificateUpdate,
-		Delete: resourceCertificateDelete,
+		CreateContext: resourceCertificateCreate,
+		ReadContext:   resourceCertificateRead,
+		UpdateContext: resourceCertificateUpdate,
+		DeleteContext: resourceCertificateDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				d.SetId("12345")
 				d.Set("site_id", d.Get("site_id").(string))
 				return []*schema.ResourceData{d}, nil
@@ -69,10 +71,10 @@ func resourceCertificate() *schema.Resource {
 	}
 }
 
-func resourceCertificateCreate(d *schema.ResourceData, m interface{}) error {
+func resourceCertificateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	inputHash := createHash(d)
-	_, err := client.AddCertificate(
+	_, err := client.AddCertificate(ctx,
 		d.Get("site_id").(string),
 		d.Get("certificate").(string),
 		d.Get("private_key").(string),
@@ -82,33 +84,31 @@ func resourceCertificateCreate(d *schema.ResourceData, m interface{}) error {
 	)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// TODO: Setting this to arbitrary value as there is only one cert for each site.
 	d.SetId("12345")
 
-	return resourceCertificateRead(d, m)
+	return resourceCertificateRead(ctx, d, m)
 }
 
-func resourceCertificateRead(d *schema.ResourceData, m interface{}) error {
+func resourceCertificateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by reading the ListCertificatesResponse for the data center
 	client := m.(*Client)
 
 	siteID := d.Get("site_id").(string)
 	operation := getOperation(d)
-	listCertificatesResponse, err := client.ListCertificates(siteID, operation)
+	listCertificatesResponse, err := client.ListCertificates(ctx, siteID, operation)
 
-	// List data centers response object may indicate that the Site ID has been deleted (9413)
-	if listCertificatesResponse != nil && listCertificatesResponse.Res == 9413 {
-		log.Printf("[INFO] Incapsula Site with ID %s has already been deleted: %s\n", d.Get("site_id"), err)
-		d.SetId("")
+	// The site of the certificate may have been deleted
+	if removeGoneResource(d, err, "custom certificate") {
 		return nil
 	}
 
 	if err != nil {
 		log.Printf("[ERROR] Could not read custom certificate from Incapsula site for site_id: %s, %s\n", siteID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("input_hash", listCertificatesResponse.SSL.CustomCertificate.InputHash)
@@ -128,12 +128,12 @@ func getOperation(d *schema.ResourceData) string {
 	return operation
 }
 
-func resourceCertificateUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceCertificateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	inputHash := createHash(d)
 
-	_, err := client.EditCertificate(
+	_, err := client.EditCertificate(ctx,
 		d.Get("site_id").(string),
 		d.Get("certificate").(string),
 		d.Get("private_key").(string),
@@ -143,23 +143,23 @@ func resourceCertificateUpdate(d *schema.ResourceData, m interface{}) error {
 	)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId("12345")
-	return resourceCertificateRead(d, m)
+	return resourceCertificateRead(ctx, d, m)
 }
 
-func resourceCertificateDelete(d *schema.ResourceData, m interface{}) error {
+func resourceCertificateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
-	err := client.DeleteCertificate(
+	err := client.DeleteCertificate(ctx,
 		d.Get("site_id").(string),
 		d.Get("auth_type").(string),
 	)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
@@ -1,15 +1,19 @@
 package incapsula
 
 import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceCertificateSigningRequest() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCertificateSigningRequestCreate,
-		Read:   resourceCertificateSigningRequestRead,
-		Update: resourceCertificateSigningRequestUpdate,
-		Delete: resourceCertificateSigningRequestDelete,
+		CreateContext: resourceCertificateSigningRequestCreate,
+		ReadContext:   resourceCertificateSigningRequestRead,
+		UpdateContext: resourceCertificateSigningRequestUpdate,
+		DeleteContext: resourceCertificateSigningRequestDelete,
 
 		Schema: map[string]*schema.Schema{
 			// Required Arguments
@@ -65,9 +69,9 @@ func resourceCertificateSigningRequest() *schema.Resource {
 	}
 }
 
-func resourceCertificateSigningRequestCreate(d *schema.ResourceData, m interface{}) error {
+func resourceCertificateSigningRequestCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
-	certificateSigningRequestResponse, err := client.CreateCertificateSigningRequest(
+	certificateSigningRequestResponse, err := client.CreateCertificateSigningRequest(ctx,
 		d.Get("site_id").(string),
 		d.Get("domain").(string),
 		d.Get("email").(string),
@@ -79,7 +83,7 @@ func resourceCertificateSigningRequestCreate(d *schema.ResourceData, m interface
 	)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("csr_content", certificateSigningRequestResponse.CsrContent)
@@ -89,11 +93,21 @@ func resourceCertificateSigningRequestCreate(d *schema.ResourceData, m interface
 	return nil
 }
 
-func resourceCertificateSigningRequestRead(d *schema.ResourceData, m interface{}) error {
+func resourceCertificateSigningRequestRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// The CSR can't be read back, so only check that its site still exists
+	client := m.(*Client)
+	siteID, _ := strconv.Atoi(d.Get("site_id").(string))
+	_, err := client.SiteStatus(ctx, "certificate signing request", siteID)
+	if removeGoneResource(d, err, "certificate signing request") {
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
-func resourceCertificateSigningRequestUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceCertificateSigningRequestUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	if d.HasChange("domain") ||
 		d.HasChange("email") ||
 		d.HasChange("country") ||
@@ -101,11 +115,11 @@ func resourceCertificateSigningRequestUpdate(d *schema.ResourceData, m interface
 		d.HasChange("city") ||
 		d.HasChange("organization") ||
 		d.HasChange("organization_unit") {
-		return resourceCertificateSigningRequestCreate(d, m)
+		return resourceCertificateSigningRequestCreate(ctx, d, m)
 	}
 	return nil
 }
 
-func resourceCertificateSigningRequestDelete(d *schema.ResourceData, m interface{}) error {
+func resourceCertificateSigningRequestDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return nil
 }
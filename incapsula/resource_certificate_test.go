@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"log"
 	"os"
 	"testing"
@@ -59,7 +60,7 @@ func testCheckIncapsulaCertificateExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		listCertificatesResponse, _ := client.ListCertificates(siteID, ReadHSMCustomCertificate)
+		listCertificatesResponse, _ := client.ListCertificates(context.Background(), siteID, ReadHSMCustomCertificate)
 		if listCertificatesResponse == nil && listCertificatesResponse.Res == 9413 {
 			return fmt.Errorf("Incapsula Custom Certificate : %s (SiteId : %s) does not exist", certificateResource, siteID)
 		}
@@ -80,7 +81,7 @@ func testAccCheckIncapsulaCertificateDestroy(state *terraform.State) error {
 		}
 
 		// List certificates response object may indicate that the certificate has been deleted (9413)
-		listCertificatesResponse, _ := client.ListCertificates(siteID, ReadHSMCustomCertificate)
+		listCertificatesResponse, _ := client.ListCertificates(context.Background(), siteID, ReadHSMCustomCertificate)
 		if listCertificatesResponse != nil && listCertificatesResponse.Res != 9413 {
 			return fmt.Errorf("Resource %s for Incapsula Custom Certificate: site ID %s still exists", certificateResourceName, siteID)
 		}
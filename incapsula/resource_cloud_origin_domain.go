@@ -155,7 +155,7 @@ func resourceCloudOriginDomainCreate(ctx context.Context, d *schema.ResourceData
 
 	log.Printf("[INFO] Creating Incapsula cloud origin domain: %s for site: %d\n", domain, siteID)
 
-	response, err := client.CreateCloudOriginDomain(siteID, accountID, domain, region, port, sslProtocol)
+	response, err := client.CreateCloudOriginDomain(ctx, siteID, accountID, domain, region, port, sslProtocol)
 	if err != nil {
 		return diag.Errorf("[ERROR] Could not create Incapsula cloud origin domain: %s for site: %d: %s\n", domain, siteID, err)
 	}
@@ -182,7 +182,10 @@ func resourceCloudOriginDomainRead(ctx context.Context, d *schema.ResourceData,
 
 	log.Printf("[INFO] Reading Incapsula cloud origin domain: %d for site: %d\n", originID, siteID)
 
-	response, err := client.GetCloudOriginDomain(siteID, originID, accountID)
+	response, err := client.GetCloudOriginDomain(ctx, siteID, originID, accountID)
+	if removeGoneResource(d, err, "cloud origin domain") {
+		return nil
+	}
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula cloud origin domain: %d for site: %d: %s\n", originID, siteID, err)
 		return diag.Errorf("[ERROR] Could not read Incapsula cloud origin domain: %d for site: %d: %s", originID, siteID, err)
@@ -216,7 +219,7 @@ func resourceCloudOriginDomainDelete(ctx context.Context, d *schema.ResourceData
 
 	log.Printf("[INFO] Deleting Incapsula cloud origin domain: %d for site: %d\n", originID, siteID)
 
-	err = client.DeleteCloudOriginDomain(siteID, originID, accountID)
+	err = client.DeleteCloudOriginDomain(ctx, siteID, originID, accountID)
 	if err != nil {
 		return diag.Errorf("[ERROR] Could not delete Incapsula cloud origin domain: %d for site: %d: %s\n", originID, siteID, err)
 	}
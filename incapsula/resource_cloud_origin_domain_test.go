@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -122,7 +123,7 @@ func testAccCheckCloudOriginDomainExists(name string) resource.TestCheckFunc {
 
 		client := testAccProvider.Meta().(*Client)
 
-		response, err := client.GetCloudOriginDomain(siteID, originID, accountID)
+		response, err := client.GetCloudOriginDomain(context.Background(), siteID, originID, accountID)
 		if err != nil {
 			return fmt.Errorf("Error getting cloud origin domain: %s", err)
 		}
@@ -165,7 +166,7 @@ func testAccCheckCloudOriginDomainDestroy(s *terraform.State) error {
 			continue
 		}
 
-		response, err := client.GetCloudOriginDomain(siteID, originID, accountID)
+		response, err := client.GetCloudOriginDomain(context.Background(), siteID, originID, accountID)
 		if err == nil && response != nil {
 			return fmt.Errorf("Cloud origin domain still exists: %s", rs.Primary.ID)
 		}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -62,7 +63,7 @@ func testCheckCSPSiteConfigExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		cspSite, err := client.GetCSPSite(accountID, siteID)
+		cspSite, err := client.GetCSPSite(context.Background(), accountID, siteID)
 		if err != nil {
 			return fmt.Errorf("Incapsula CSP Site Config doesn't exist for site ID %d", siteID)
 		}
@@ -131,7 +132,7 @@ func testACCStateCSPSiteConfigDestroy(s *terraform.State) error {
 			return fmt.Errorf("failed to convert site ID from import command, actual value : %s, expected numeric id", siteID)
 		}
 
-		cspSite, err := client.GetCSPSite(accountIDInt, siteIDInt)
+		cspSite, err := client.GetCSPSite(context.Background(), accountIDInt, siteIDInt)
 		fmt.Sprintf("Got CSP site config for site ID %d: %v", siteIDInt, cspSite)
 		if err != nil && cspSite != nil && cspSite.Discovery != CSPDiscoveryOff {
 			return fmt.Errorf("Resource %s for CSP site configuration: Api Id %s, site ID %d still exists", cspSiteConfigResourceType, rs.Primary.ID, siteIDInt)
@@ -1,8 +1,10 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
@@ -17,12 +19,12 @@ const (
 
 func resourceCSPSiteDomain() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCSPSiteDomainUpdate,
-		Read:   resourceCSPSiteDomainRead,
-		Update: resourceCSPSiteDomainUpdate,
-		Delete: resourceCSPSiteDomainDelete,
+		CreateContext: resourceCSPSiteDomainUpdate,
+		ReadContext:   resourceCSPSiteDomainRead,
+		UpdateContext: resourceCSPSiteDomainUpdate,
+		DeleteContext: resourceCSPSiteDomainDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				keyParts := strings.Split(d.Id(), "/")
 				if len(keyParts) != 3 {
 					return nil, fmt.Errorf("Error parsing ID, actual value: %s, expected two numeric IDs and string seperated by '/'\n", d.Id())
@@ -115,7 +117,7 @@ func domainRefFromState(d *schema.ResourceData) string {
 	return base64.RawURLEncoding.EncodeToString([]byte(d.Get("domain").(string)))
 }
 
-func resourceCSPSiteDomainRead(d *schema.ResourceData, m interface{}) error {
+func resourceCSPSiteDomainRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	accountID := d.Get("account_id").(int)
 	siteID := d.Get("site_id").(int)
@@ -124,7 +126,7 @@ func resourceCSPSiteDomainRead(d *schema.ResourceData, m interface{}) error {
 
 	log.Printf("[DEBUG] Reading CSP domain for site ID: %d , domain reference: %s , domain: %s", siteID, domainRef, domain)
 
-	cspNotes, err := client.getCSPDomainNotes(accountID, siteID, domain)
+	cspNotes, err := client.getCSPDomainNotes(ctx, accountID, siteID, domain)
 	if err != nil {
 		log.Printf("[ERROR] Could not get CSP domain notes: %s - %s\n", d.Id(), err)
 	} else {
@@ -140,10 +142,11 @@ func resourceCSPSiteDomainRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	// First check if it's a pre-approved domain, and update resource according to that
-	preApprovedDomain, err := client.getCSPPreApprovedDomainByRef(accountID, siteID, domainRef)
-	if err != nil {
+	preApprovedDomain, err := client.getCSPPreApprovedDomainByRef(ctx, accountID, siteID, domainRef)
+	if err != nil && !IsNotFound(err) {
 		log.Printf("[ERROR] Could not get CSP pre-approved domain : %s - %s\n", d.Id(), err)
-	} else {
+		return diag.FromErr(err)
+	} else if err == nil {
 		log.Printf("[DEBUG] Reading CSP pre-approved domain %s for site ID: %d , response: %v.", domain, siteID, preApprovedDomain)
 
 		d.Set("include_subdomains", preApprovedDomain.Subdomains)
@@ -153,9 +156,13 @@ func resourceCSPSiteDomainRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	// If domain wasn't found as pre-approved domain, check if status set directly and update accordingly
-	status, err := client.getCSPDomainStatus(accountID, siteID, domain)
+	status, err := client.getCSPDomainStatus(ctx, accountID, siteID, domain)
 	if err != nil {
+		if removeGoneResource(d, err, "CSP site domain") {
+			return nil
+		}
 		log.Printf("[ERROR] Could not get CSP domain status: %s - %s\n", d.Id(), err)
+		return diag.FromErr(err)
 	} else if status.Blocked != nil {
 		log.Printf("[DEBUG] Reading CSP domain status for domain %s from site ID: %d , response: %v.", domain, siteID, status)
 		d.Set("include_subdomains", strings.HasPrefix(domain, "*."))
@@ -169,13 +176,12 @@ func resourceCSPSiteDomainRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	// In case we couldn't find data of pre-approved/status for the domain, remove it as a resource
+	log.Printf("[WARN] No CSP domain data found for domain %s from site ID %d, removing it from the state\n", domain, siteID)
 	d.SetId("")
-	fmt.Errorf("Error no CSP domain data found for domain %s from site ID %d\n",
-		domain, siteID)
 	return nil
 }
 
-func resourceCSPSiteDomainUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceCSPSiteDomainUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	accountID := d.Get("account_id").(int)
 	siteID := d.Get("site_id").(int)
@@ -193,10 +199,10 @@ func resourceCSPSiteDomainUpdate(d *schema.ResourceData, m interface{}) error {
 			ReferenceID: domRef,
 		}
 		log.Printf("[DEBUG] Updating CSP domain for site ID: %d , domain: %v\n", siteID, dom)
-		updatedDom, err := client.updateCSPPreApprovedDomain(accountID, siteID, &dom)
+		updatedDom, err := client.updateCSPPreApprovedDomain(ctx, accountID, siteID, &dom)
 		if err != nil {
 			log.Printf("[ERROR] Could not update CSP pre-approved domain: %v - %s\n", dom, err)
-			return err
+			return diag.FromErr(err)
 		}
 		log.Printf("[DEBUG] Updating CSP domain %v for site ID: %d , got response: %v.", dom, siteID, updatedDom)
 		// Use the referenceId Imperva assigned — it may differ from what we sent
@@ -212,26 +218,26 @@ func resourceCSPSiteDomainUpdate(d *schema.ResourceData, m interface{}) error {
 		*(st.Blocked) = true
 		*(st.Reviewed) = true
 
-		domainStatus, err := client.updateCSPDomainStatus(accountID, siteID, domain, &st)
+		domainStatus, err := client.updateCSPDomainStatus(ctx, accountID, siteID, domain, &st)
 		if err != nil || domainStatus.Blocked == nil || domainStatus.Reviewed == nil {
-			e := fmt.Errorf("[ERROR] Could not update CSP domain %s status: %v - %s\n", domain, status, err)
-			return e
+			e := fmt.Errorf("[ERROR] Could not update CSP domain %s status: %v - %w\n", domain, status, err)
+			return diag.FromErr(e)
 		}
 	}
 
-	client.deleteCSPDomainNotes(accountID, siteID, domain)
+	client.deleteCSPDomainNotes(ctx, accountID, siteID, domain)
 	for _, note := range notes.List() {
-		client.addCSPDomainNote(accountID, siteID, domain, note.(string))
+		client.addCSPDomainNote(ctx, accountID, siteID, domain, note.(string))
 	}
 
 	newID := fmt.Sprintf("%d/%d/%s", accountID, siteID, domRef)
 	log.Printf("[DEBUG] Update CSP Domain, setting key %s to: %s", d.Id(), newID)
 	d.SetId(newID)
 
-	return resourceCSPSiteDomainRead(d, m)
+	return resourceCSPSiteDomainRead(ctx, d, m)
 }
 
-func resourceCSPSiteDomainDelete(d *schema.ResourceData, m interface{}) error {
+func resourceCSPSiteDomainDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	accountID := d.Get("account_id").(int)
 	siteID := d.Get("site_id").(int)
@@ -240,10 +246,10 @@ func resourceCSPSiteDomainDelete(d *schema.ResourceData, m interface{}) error {
 	log.Printf("[DEBUG] Deleting CSP domain %s from site ID %d\n", domain, siteID)
 
 	if strings.Compare(status, cspDomainStatusAllowed) == 0 {
-		err := client.deleteCSPPreApprovedDomains(accountID, siteID, domainRefFromState(d))
+		err := client.deleteCSPPreApprovedDomains(ctx, accountID, siteID, domainRefFromState(d))
 		if err != nil {
 			log.Printf("[ERROR] Could not delete CSP pre-approved domain %s for site ID %d: %s\n", domain, siteID, err)
-			return err
+			return diag.FromErr(err)
 		}
 	} else if strings.Compare(status, cspDomainStatusBlocked) == 0 {
 		newStatus := CSPDomainStatus{
@@ -252,13 +258,13 @@ func resourceCSPSiteDomainDelete(d *schema.ResourceData, m interface{}) error {
 		}
 		*newStatus.Blocked = false
 		*newStatus.Reviewed = false
-		ret, err := client.updateCSPDomainStatus(accountID, siteID, domain, &newStatus)
+		ret, err := client.updateCSPDomainStatus(ctx, accountID, siteID, domain, &newStatus)
 		if err != nil {
 			log.Printf("[ERROR] Could not delete CSP domain status %s for site ID %d: %s\n", domain, siteID, err)
-			return err
+			return diag.FromErr(err)
 		}
 		if ret.Blocked == nil || ret.Reviewed == nil {
-			return fmt.Errorf("[ERROR] Could not update CSP domain %s status to: %v got: %v\n", domain, newStatus, ret)
+			return diag.Errorf("[ERROR] Could not update CSP domain %s status to: %v got: %v\n", domain, newStatus, ret)
 		}
 	}
 	log.Printf("[DEBUG] Deleted CSP domain %s for site ID: %d successfully", domain, siteID)
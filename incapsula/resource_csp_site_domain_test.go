@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -60,7 +61,7 @@ func testCheckCSPDomainExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		cspDomain, err := client.getCSPPreApprovedDomain(accountID, siteID, res.Primary.Attributes["domain"])
+		cspDomain, err := client.getCSPPreApprovedDomain(context.Background(), accountID, siteID, res.Primary.Attributes["domain"])
 		if err != nil || cspDomain == nil {
 			return fmt.Errorf("Incapsula CSP domain %s doesn't exist for site ID %d", res.Primary.Attributes["domain"], siteID)
 		}
@@ -132,7 +133,7 @@ func testACCStateCSPDomainDestroy(s *terraform.State) error {
 			return fmt.Errorf("Parameter domain was not found in resource %s", cspDomainResourceName)
 		}
 
-		cspDomain, err := client.getCSPPreApprovedDomain(accountIDInt, siteIDInt, domain)
+		cspDomain, err := client.getCSPPreApprovedDomain(context.Background(), accountIDInt, siteIDInt, domain)
 
 		fmt.Sprintf("Got CSP domain for site ID %d: %v", siteIDInt, cspDomain)
 		if err != nil && cspDomain != nil {
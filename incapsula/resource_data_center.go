@@ -1,11 +1,13 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -13,12 +15,12 @@ import (
 func resourceDataCenter() *schema.Resource {
 	return &schema.Resource{
 		DeprecationMessage: "This resource is deprecated. It will be removed in a future version. Please use resource incapsula_data_centers_configuration instead.",
-		Create:             resourceDataCenterCreate,
-		Read:               resourceDataCenterRead,
-		Update:             resourceDataCenterUpdate,
-		Delete:             resourceDataCenterDelete,
+		CreateContext:      resourceDataCenterCreate,
+		ReadContext:        resourceDataCenterRead,
+		UpdateContext:      resourceDataCenterUpdate,
+		DeleteContext:      resourceDataCenterDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/dc_id", d.Id())
@@ -76,14 +78,14 @@ func resourceDataCenter() *schema.Resource {
 	}
 }
 
-func resourceDataCenterCreate(d *schema.ResourceData, m interface{}) error {
+func resourceDataCenterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	var dataCenterAddResponse *DataCenterAddResponse
 	var err error
 
-	err = resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
-		dataCenterAddResponse, err = client.AddDataCenter(
+	err = resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		dataCenterAddResponse, err = client.AddDataCenter(ctx,
 			d.Get("site_id").(string),
 			d.Get("name").(string),
 			d.Get("server_address").(string),
@@ -92,48 +94,35 @@ func resourceDataCenterCreate(d *schema.ResourceData, m interface{}) error {
 		)
 
 		if err != nil {
-			return resource.RetryableError(fmt.Errorf("Error creating data center for site (%s): %s", d.Get("site_id"), err))
+			return resource.RetryableError(fmt.Errorf("Error creating data center for site (%s): %w", d.Get("site_id"), err))
 		}
 
 		return nil
 	})
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the dc ID
 	d.SetId(dataCenterAddResponse.DataCenterID)
 
-	return resourceDataCenterRead(d, m)
+	return resourceDataCenterRead(ctx, d, m)
 }
 
-func resourceDataCenterRead(d *schema.ResourceData, m interface{}) error {
+func resourceDataCenterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by reading the ListDataCentersResponse for the data center
 	client := m.(*Client)
 
-	listDataCentersResponse, err := client.ListDataCenters(d.Get("site_id").(string))
+	listDataCentersResponse, err := client.ListDataCenters(ctx, d.Get("site_id").(string))
 
 	// List data centers response object may indicate that the Site ID has been deleted (9413)
-	if listDataCentersResponse != nil {
-		// Res can oscillate between strings and ints
-		var resString string
-		if resNumber, ok := listDataCentersResponse.Res.(float64); ok {
-			resString = fmt.Sprintf("%d", int(resNumber))
-		} else {
-			resString = listDataCentersResponse.Res.(string)
-		}
-		// This should never happen during data center creation
-		// This is likely to happen if someone deletes the data center via the UI, SDK, or API
-		if resString == "9413" {
-			log.Printf("[INFO] Incapsula Site with ID %s has already been deleted: %s\n", d.Get("site_id"), err)
-			d.SetId("")
-			return nil
-		}
+	if removeGoneResource(d, err, "data center") {
+		return nil
 	}
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	found := false
@@ -158,11 +147,11 @@ func resourceDataCenterRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceDataCenterUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceDataCenterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
-	return resource.Retry(d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
-		_, err := client.EditDataCenter(
+	return diag.FromErr(resource.RetryContext(ctx, d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
+		_, err := client.EditDataCenter(ctx,
 			d.Id(),
 			d.Get("name").(string),
 			d.Get("is_content").(string),
@@ -170,21 +159,21 @@ func resourceDataCenterUpdate(d *schema.ResourceData, m interface{}) error {
 		)
 
 		if err != nil {
-			return resource.RetryableError(fmt.Errorf("Error updating data center %s for Site ID %s: %s", d.Id(), d.Get("site_id"), err))
+			return resource.RetryableError(fmt.Errorf("Error updating data center %s for Site ID %s: %w", d.Id(), d.Get("site_id"), err))
 		}
 
 		return nil
-	})
+	}))
 }
 
-func resourceDataCenterDelete(d *schema.ResourceData, m interface{}) error {
+func resourceDataCenterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
-	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
-		err := client.DeleteDataCenter(d.Id())
+	return diag.FromErr(resource.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		err := client.DeleteDataCenter(ctx, d.Id())
 
 		if err != nil {
-			return resource.RetryableError(fmt.Errorf("Error deleting data center %s for Site ID %s: %s", d.Id(), d.Get("site_id"), err))
+			return resource.RetryableError(fmt.Errorf("Error deleting data center %s for Site ID %s: %w", d.Id(), d.Get("site_id"), err))
 		}
 
 		// Set the ID to empty
@@ -192,5 +181,5 @@ func resourceDataCenterDelete(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 
 		return nil
-	})
+	}))
 }
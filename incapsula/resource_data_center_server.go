@@ -1,22 +1,24 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceDataCenterServer() *schema.Resource {
 	return &schema.Resource{
 		DeprecationMessage: "This resource is deprecated. It will be removed in a future version. Please use resource incapsula_data_centers_configuration instead.",
-		Create:             resourceDataCenterServerCreate,
-		Read:               resourceDataCenterServerRead,
-		Update:             resourceDataCenterServerUpdate,
-		Delete:             resourceDataCenterServerDelete,
+		CreateContext:      resourceDataCenterServerCreate,
+		ReadContext:        resourceDataCenterServerRead,
+		UpdateContext:      resourceDataCenterServerUpdate,
+		DeleteContext:      resourceDataCenterServerDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) != 3 || idSlice[0] == "" || idSlice[1] == "" || idSlice[2] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/dc_id/server_id", d.Id())
@@ -70,10 +72,10 @@ func resourceDataCenterServer() *schema.Resource {
 	}
 }
 
-func resourceDataCenterServerCreate(d *schema.ResourceData, m interface{}) error {
+func resourceDataCenterServerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
-	dataCenterServerAddResponse, err := client.AddDataCenterServer(
+	dataCenterServerAddResponse, err := client.AddDataCenterServer(ctx,
 		d.Get("dc_id").(string),
 		d.Get("server_address").(string),
 		d.Get("is_standby").(string),
@@ -81,39 +83,28 @@ func resourceDataCenterServerCreate(d *schema.ResourceData, m interface{}) error
 	)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the server ID
 	d.SetId(dataCenterServerAddResponse.ServerID)
 
-	return resourceDataCenterServerRead(d, m)
+	return resourceDataCenterServerRead(ctx, d, m)
 }
 
-func resourceDataCenterServerRead(d *schema.ResourceData, m interface{}) error {
+func resourceDataCenterServerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by reading the ListDataCentersResponse for the data centers
 	client := m.(*Client)
 
-	listDataCentersResponse, err := client.ListDataCenters(d.Get("site_id").(string))
+	listDataCentersResponse, err := client.ListDataCenters(ctx, d.Get("site_id").(string))
 
 	// List data centers response object may indicate that the Site ID has been deleted (9413)
-	if listDataCentersResponse != nil {
-		// Res can oscillate between strings and ints
-		var resString string
-		if resNumber, ok := listDataCentersResponse.Res.(float64); ok {
-			resString = fmt.Sprintf("%d", int(resNumber))
-		} else {
-			resString = listDataCentersResponse.Res.(string)
-		}
-		if resString == "9413" {
-			log.Printf("[INFO] Incapsula Site with ID %s has already been deleted: %s\n", d.Get("site_id"), err)
-			d.SetId("")
-			return nil
-		}
+	if removeGoneResource(d, err, "data center server") {
+		return nil
 	}
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	found := false
@@ -147,10 +138,10 @@ func resourceDataCenterServerRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceDataCenterServerUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceDataCenterServerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
-	_, err := client.EditDataCenterServer(
+	_, err := client.EditDataCenterServer(ctx,
 		d.Id(),
 		d.Get("server_address").(string),
 		d.Get("is_standby").(string),
@@ -158,19 +149,19 @@ func resourceDataCenterServerUpdate(d *schema.ResourceData, m interface{}) error
 	)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceDataCenterServerDelete(d *schema.ResourceData, m interface{}) error {
+func resourceDataCenterServerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	serverID := d.Id()
-	err := client.DeleteDataCenterServer(serverID)
+	err := client.DeleteDataCenterServer(ctx, serverID)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
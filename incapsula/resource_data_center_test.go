@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"testing"
@@ -75,7 +76,7 @@ func testAccCheckIncapsulaDataCenterDestroy(state *terraform.State) error {
 			return nil
 		}
 
-		listDataCenterResponse, _ := client.ListDataCenters(siteID)
+		listDataCenterResponse, _ := client.ListDataCenters(context.Background(), siteID)
 
 		// See comment above - the data center may have already been deleted
 		// This workaround will be removed in the future
@@ -126,12 +127,12 @@ func testCheckIncapsulaDataCenterExists(name string) resource.TestCheckFunc {
 
 		// If the site has already been deleted then return nil
 		// Otherwise check the data center list
-		_, err = client.SiteStatus(domain, siteID)
+		_, err = client.SiteStatus(context.Background(), domain, siteID)
 		if err != nil {
 			return nil
 		}
 
-		dataCenterListResponse, err := client.ListDataCenters(siteIDString)
+		dataCenterListResponse, err := client.ListDataCenters(context.Background(), siteIDString)
 		if dataCenterListResponse == nil {
 			return fmt.Errorf("Incapsula data center: %s (Site ID: %d) does not exist\n%s", name, siteID, err)
 		}
@@ -2,8 +2,10 @@ package incapsula
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"hash/crc32"
@@ -13,12 +15,12 @@ import (
 
 func resourceDataCentersConfiguration() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDataCentersConfigurationCreate,
-		Read:   resourceDataCentersConfigurationRead,
-		Update: resourceDataCentersConfigurationCreate,
-		Delete: resourceDataCentersConfigurationDelete,
+		CreateContext: resourceDataCentersConfigurationCreate,
+		ReadContext:   resourceDataCentersConfigurationRead,
+		UpdateContext: resourceDataCentersConfigurationCreate,
+		DeleteContext: resourceDataCentersConfigurationDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				d.Set("site_id", d.Id())
 				return []*schema.ResourceData{d}, nil
 			},
@@ -338,34 +340,37 @@ func populateFromConfDataCentersConfigurationDTO(d *schema.ResourceData) DataCen
 	return requestDTO
 }
 
-func resourceDataCentersConfigurationCreate(d *schema.ResourceData, m interface{}) error {
+func resourceDataCentersConfigurationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	requestDTO := populateFromConfDataCentersConfigurationDTO(d)
-	responseDTO, err := client.PutDataCentersConfiguration(d.Get("site_id").(string), requestDTO)
+	responseDTO, err := client.PutDataCentersConfiguration(ctx, d.Get("site_id").(string), requestDTO)
 	if err != nil {
-		return fmt.Errorf("Error updating Data Centers configuration for site (%s): %s",
+		return diag.Errorf("Error updating Data Centers configuration for site (%s): %s",
 			d.Get("site_id"), err)
 	}
 
 	if responseDTO.Errors != nil && len(responseDTO.Errors) > 0 {
-		return fmt.Errorf("Error updating Data Centers configuration for site (%s): %s",
+		return diag.Errorf("Error updating Data Centers configuration for site (%s): %s",
 			d.Get("site_id"), responseDTO.Errors)
 	}
 
 	// Set the dc ID
 	d.SetId(d.Get("site_id").(string))
 
-	return resourceDataCentersConfigurationRead(d, m)
+	return resourceDataCentersConfigurationRead(ctx, d, m)
 }
 
-func resourceDataCentersConfigurationRead(d *schema.ResourceData, m interface{}) error {
+func resourceDataCentersConfigurationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by reading the ListDataCentersResponse for the data center
 	client := m.(*Client)
 
-	responseDTO, err := client.GetDataCentersConfiguration(d.Get("site_id").(string))
+	responseDTO, err := client.GetDataCentersConfiguration(ctx, d.Get("site_id").(string))
 	if err != nil {
-		return fmt.Errorf("Error getting Data Centers configuration for site (%s): %s", d.Get("site_id"), err)
+		if removeGoneResource(d, err, "data centers configuration") {
+			return nil
+		}
+		return diag.Errorf("Error getting Data Centers configuration for site (%s): %s", d.Get("site_id"), err)
 	}
 
 	if responseDTO.Errors != nil && len(responseDTO.Errors) > 0 {
@@ -379,7 +384,7 @@ func resourceDataCentersConfigurationRead(d *schema.ResourceData, m interface{})
 		if err != nil {
 			panic(err)
 		}
-		return fmt.Errorf("Error getting Data Centers configuration for site (%s): %s", d.Get("site_id"), string(out))
+		return diag.Errorf("Error getting Data Centers configuration for site (%s): %s", d.Get("site_id"), string(out))
 	}
 
 	d.Set("site_lb_algorithm", responseDTO.Data[0].SiteLbAlgorithm)
@@ -441,20 +446,20 @@ func resourceDataCentersConfigurationRead(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceDataCentersConfigurationDelete(d *schema.ResourceData, m interface{}) error {
+func resourceDataCentersConfigurationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
-	responseDTO, err := client.GetDataCentersConfiguration(d.Get("site_id").(string))
-	if err != nil {
-		return fmt.Errorf("Error deleting Data Centers configuration for site (%s): %s", d.Get("site_id"), err)
+	responseDTO, err := client.GetDataCentersConfiguration(ctx, d.Get("site_id").(string))
+	if err != nil && !IsNotFound(err) {
+		return diag.Errorf("Error deleting Data Centers configuration for site (%s): %s", d.Get("site_id"), err)
 	}
 
-	if responseDTO.Errors != nil && len(responseDTO.Errors) > 0 && responseDTO.Errors[0].Status != "404" {
+	if responseDTO != nil && responseDTO.Errors != nil && len(responseDTO.Errors) > 0 && responseDTO.Errors[0].Status != "404" {
 		out, err := json.Marshal(responseDTO.Errors)
 		if err != nil {
 			panic(err)
 		}
-		return fmt.Errorf("Error deleting Data Centers configuration for site (%s): %s", d.Get("site_id"), string(out))
+		return diag.Errorf("Error deleting Data Centers configuration for site (%s): %s", d.Get("site_id"), string(out))
 	}
 
 	d.SetId("")
@@ -19,7 +19,7 @@ func resourceDeliveryRulesConfiguration() *schema.Resource {
 		UpdateContext: resourceDeliveryRulesConfigurationUpdate,
 		DeleteContext: resourceDeliveryRulesConfigurationDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(data.Id(), "/")
 				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/category", data.Id())
@@ -195,11 +195,11 @@ func resourceDeliveryRulesConfigurationUpdate(ctx context.Context, data *schema.
 		RulesList: createRulesListFromState(data),
 	}
 
-	deliveryRulesListDTO, diags := client.UpdateDeliveryRuleConfiguration(siteID, category, &rulesListDTO)
+	deliveryRulesListDTO, err := client.UpdateDeliveryRuleConfiguration(ctx, siteID, category, &rulesListDTO)
 
-	if diags != nil && diags.HasError() {
+	if err != nil {
 		log.Printf("[ERROR] Failed to update delivery rules of category %s for Site ID %s", category, siteID)
-		return diags
+		return diag.FromErr(err)
 	} else if deliveryRulesListDTO.Errors != nil {
 		errors, _ := json.Marshal(deliveryRulesListDTO.Errors)
 		log.Printf("[ERROR] Failed to update delivery rules of category %s for Site ID %s: %s", category, siteID, string(errors[:]))
@@ -220,18 +220,16 @@ func resourceDeliveryRulesConfigurationRead(ctx context.Context, data *schema.Re
 	siteID := data.Get("site_id").(string)
 	category := data.Get("category").(string)
 
-	deliveryRulesListDTO, diags := client.ReadDeliveryRuleConfiguration(siteID, category)
+	deliveryRulesListDTO, err := client.ReadDeliveryRuleConfiguration(ctx, siteID, category)
 
 	fmt.Println(deliveryRulesListDTO)
 
-	if deliveryRulesListDTO != nil && deliveryRulesListDTO.Errors != nil && deliveryRulesListDTO.Errors[0].Status == 404 {
-		log.Printf("[INFO] Incapsula Site with ID %s has already been deleted\n", data.Get("site_id"))
-		data.SetId("")
-		return nil
-	}
-	if diags != nil && diags.HasError() {
+	if err != nil {
+		if removeGoneResource(data, err, "delivery rules configuration") {
+			return nil
+		}
 		log.Printf("[ERROR] Failed to read delivery rules in category %s for Site ID %s", category, siteID)
-		return diags
+		return diag.FromErr(err)
 	}
 
 	data.Set("rule", serializeDeliveryRule(data, *deliveryRulesListDTO))
@@ -275,7 +273,6 @@ func serializeDeliveryRule(data *schema.ResourceData, DeliveryRule DeliveryRules
 
 func resourceDeliveryRulesConfigurationDelete(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
-	var diags diag.Diagnostics
 	siteID := data.Get("site_id").(string)
 	category := data.Get("category").(string)
 
@@ -283,14 +280,14 @@ func resourceDeliveryRulesConfigurationDelete(ctx context.Context, data *schema.
 		RulesList: []DeliveryRuleDto{},
 	}
 
-	_, diags = client.UpdateDeliveryRuleConfiguration(siteID, category, &emptyRulesList)
-	if diags != nil && diags.HasError() {
+	_, err := client.UpdateDeliveryRuleConfiguration(ctx, siteID, category, &emptyRulesList)
+	if err != nil {
 		log.Printf("[ERROR] Failed to delete delivery rules in category %s for Site ID %s", category, siteID)
-		return diags
+		return diag.FromErr(err)
 	}
 
 	data.SetId("")
-	return diags
+	return nil
 }
 
 func createRulesListFromState(data *schema.ResourceData) []DeliveryRuleDto {
@@ -1,8 +1,10 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"strconv"
@@ -12,12 +14,12 @@ import (
 func resourceSiteSingleDomainConfiguration() *schema.Resource {
 
 	return &schema.Resource{
-		Read:   resourceSingleDomainRead,
-		Create: resourceDomainCreate,
-		Delete: resourceSingleDomainDelete,
-		Update: resourceDomainUpdate,
+		ReadContext:   resourceSingleDomainRead,
+		CreateContext: resourceDomainCreate,
+		DeleteContext: resourceSingleDomainDelete,
+		UpdateContext: resourceDomainUpdate,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 
 				idSlice := strings.Split(d.Id(), "/")
 				log.Printf("[DEBUG] Starting to import domain. Parameters: %s\n", d.Id())
@@ -76,42 +78,45 @@ func resourceSiteSingleDomainConfiguration() *schema.Resource {
 	}
 }
 
-func resourceDomainUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceDomainUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
-	return fmt.Errorf("resource updates are not allowed")
+	return diag.Errorf("resource updates are not allowed")
 }
-func resourceDomainCreate(d *schema.ResourceData, m interface{}) error {
+func resourceDomainCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	client := m.(*Client)
 	siteID := d.Get("site_id").(string)
 
-	resp, err := client.AddDomainToSite(siteID, d.Get("domain").(string))
+	resp, err := client.AddDomainToSite(ctx, siteID, d.Get("domain").(string))
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(strconv.Itoa(resp.Id))
-	return resourceSingleDomainRead(d, m)
+	return resourceSingleDomainRead(ctx, d, m)
 }
 
-func resourceSingleDomainRead(d *schema.ResourceData, m interface{}) error {
+func resourceSingleDomainRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteID := d.Get("site_id").(string)
 	id := d.Id()
-	siteDomainDetailsDto, err := client.GetDomain(siteID, id)
+	siteDomainDetailsDto, err := client.GetDomain(ctx, siteID, id)
 
 	if err != nil {
-		return err
+		if removeGoneResource(d, err, "domain") {
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
 	if siteDomainDetailsDto.Errors != nil && len(siteDomainDetailsDto.Errors) > 0 {
 		out, err := json.Marshal(siteDomainDetailsDto.Errors)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
-		return fmt.Errorf("error getting domain (%s) for site (%s): %s",
+		return diag.Errorf("error getting domain (%s) for site (%s): %s",
 			id,
 			siteID,
 			out)
@@ -132,13 +137,13 @@ func resourceSingleDomainRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSingleDomainDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSingleDomainDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteID := d.Get("site_id").(string)
-	err := client.DeleteDomain(siteID, d.Id())
+	err := client.DeleteDomain(ctx, siteID, d.Id())
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
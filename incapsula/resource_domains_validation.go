@@ -62,7 +62,10 @@ func resourceSSLValidationAdd(ctx context.Context, d *schema.ResourceData, m int
 		index++
 	}
 	for i := 0; i < 50; i++ {
-		siteCertificateV3Response, _ := client.GetSiteCertificateRequestStatus(siteId, nil)
+		siteCertificateV3Response, err := client.GetSiteCertificateRequestStatus(ctx, siteId, nil)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 		b := siteCertificateV3Response != nil && siteCertificateV3Response.Data != nil && len(siteCertificateV3Response.Data) > 0
 		b = b && siteCertificateV3Response.Data[0].CertificatesDetails != nil && len(siteCertificateV3Response.Data[0].CertificatesDetails) > 0
 		b = b && siteCertificateV3Response.Data[0].CertificatesDetails[0].Sans != nil && len(siteCertificateV3Response.Data[0].CertificatesDetails[0].Sans) == len(domainIds)
@@ -70,18 +73,30 @@ func resourceSSLValidationAdd(ctx context.Context, d *schema.ResourceData, m int
 			b = b && value.Status == "VALIDATED"
 		}
 		if b {
-			diags = client.ValidateDomains(siteId, domainIds)
+			if err := client.ValidateDomains(ctx, siteId, domainIds); err != nil {
+				return diag.FromErr(err)
+			}
 			d.SetId(strconv.Itoa(siteId))
 			return diags
 		}
-		client.ValidateDomains(siteId, domainIds)
-		time.Sleep(10 * time.Second)
+		client.ValidateDomains(ctx, siteId, domainIds)
+		if err := sleepWithContext(ctx, 10*time.Second); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 	panic("ssl validation was not completed as expected")
 }
 
 func resourceSSLValidationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 	siteId, _ := d.Get("site_id").(int)
+	_, err := client.GetSiteCertificateRequestStatus(ctx, siteId, nil)
+	if removeGoneResource(d, err, "domains validation") {
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	d.SetId(strconv.Itoa(siteId))
 	return nil
 }
@@ -1,21 +1,23 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceIncapRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceIncapRuleCreate,
-		Read:   resourceIncapRuleRead,
-		Update: resourceIncapRuleUpdate,
-		Delete: resourceIncapRuleDelete,
+		CreateContext: resourceIncapRuleCreate,
+		ReadContext:   resourceIncapRuleRead,
+		UpdateContext: resourceIncapRuleUpdate,
+		DeleteContext: resourceIncapRuleDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/rule_id", d.Id())
@@ -192,7 +194,7 @@ func resourceIncapRule() *schema.Resource {
 	}
 }
 
-func resourceIncapRuleCreate(d *schema.ResourceData, m interface{}) error {
+func resourceIncapRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	rewriteExisting := new(bool)
 	action := d.Get("action").(string)
@@ -209,7 +211,7 @@ func resourceIncapRuleCreate(d *schema.ResourceData, m interface{}) error {
 		valStr := v.(string)
 		valBool, err := strconv.ParseBool(valStr)
 		if err != nil {
-			return fmt.Errorf("Error parsing send_notifications: %s", err)
+			return diag.Errorf("Error parsing send_notifications: %s", err)
 		}
 		sendNotifications = &valBool
 	}
@@ -251,36 +253,35 @@ func resourceIncapRuleCreate(d *schema.ResourceData, m interface{}) error {
 		BlockDurationDetails:  blockDurationDetails,
 	}
 
-	ruleWithID, err := client.AddIncapRule(d.Get("site_id").(string), &rule)
+	ruleWithID, err := client.AddIncapRule(ctx, d.Get("site_id").(string), &rule)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(strconv.Itoa(ruleWithID.RuleID))
 
-	return resourceIncapRuleRead(d, m)
+	return resourceIncapRuleRead(ctx, d, m)
 }
 
-func resourceIncapRuleRead(d *schema.ResourceData, m interface{}) error {
+func resourceIncapRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by reading the SiteResponse for the site
 	client := m.(*Client)
 
 	ruleID, err := strconv.Atoi(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	rule, statusCode, err := client.ReadIncapRule(d.Get("site_id").(string), ruleID)
+	rule, _, err := client.ReadIncapRule(ctx, d.Get("site_id").(string), ruleID)
 
-	// If the rule is deleted on the server, blow it out locally and run through the normal TF cycle
-	if statusCode == 404 {
-		d.SetId("")
+	// If the rule or its site is deleted on the server, blow it out locally and run through the normal TF cycle
+	if removeGoneResource(d, err, "incap rule") {
 		return nil
 	}
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Update all of the properties
@@ -328,7 +329,7 @@ func resourceIncapRuleRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceIncapRuleUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceIncapRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	rewriteExisting := new(bool)
 	action := d.Get("action").(string)
@@ -345,7 +346,7 @@ func resourceIncapRuleUpdate(d *schema.ResourceData, m interface{}) error {
 		valStr := v.(string)
 		valBool, err := strconv.ParseBool(valStr)
 		if err != nil {
-			return fmt.Errorf("Error parsing send_notifications: %s", err)
+			return diag.Errorf("Error parsing send_notifications: %s", err)
 		}
 		sendNotifications = &valBool
 	}
@@ -389,29 +390,29 @@ func resourceIncapRuleUpdate(d *schema.ResourceData, m interface{}) error {
 
 	ruleID, err := strconv.Atoi(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	_, err = client.UpdateIncapRule(d.Get("site_id").(string), ruleID, &rule)
+	_, err = client.UpdateIncapRule(ctx, d.Get("site_id").(string), ruleID, &rule)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceIncapRuleDelete(d *schema.ResourceData, m interface{}) error {
+func resourceIncapRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	ruleID, err := strconv.Atoi(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = client.DeleteIncapRule(d.Get("site_id").(string), ruleID)
+	err = client.DeleteIncapRule(ctx, d.Get("site_id").(string), ruleID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"testing"
@@ -133,7 +134,7 @@ func testAccCheckIncapsulaIncapRuleDestroy(state *terraform.State) error {
 			return fmt.Errorf("Incapsula Site ID does not exist for Rule ID %d", ruleID)
 		}
 
-		_, statusCode, err := client.ReadIncapRule(siteID, ruleID)
+		_, statusCode, err := client.ReadIncapRule(context.Background(), siteID, ruleID)
 		if statusCode != 404 {
 			return fmt.Errorf("Incapsula Incap Rule %d (site id: %s) should have received 404 status code", ruleID, siteID)
 		}
@@ -163,7 +164,7 @@ func testCheckIncapsulaIncapRuleExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		_, statusCode, err := client.ReadIncapRule(siteID, ruleID)
+		_, statusCode, err := client.ReadIncapRule(context.Background(), siteID, ruleID)
 		if statusCode != 200 {
 			return fmt.Errorf("Incapsula Incap Rule: %s (site id: %s) should have received 200 status code", name, siteID)
 		}
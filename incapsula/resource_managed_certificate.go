@@ -32,7 +32,11 @@ func resourceManagedCertificate() *schema.Resource {
 						return nil, fmt.Errorf("[ERROR] Failed to convert account_id parameter %s to number", parameters[0])
 					}
 				} else if len(parameters) == 1 {
-					accountId = client.accountStatus.AccountID
+					accountStatus, err := client.currentAccountStatus(ctx)
+					if err != nil {
+						return nil, err
+					}
+					accountId = accountStatus.AccountID
 					siteId = parameters[0]
 				} else {
 					return nil, fmt.Errorf("[ERROR] unexpected format of ID (%q), expected site_id or account_id/site_id", d.Id())
@@ -83,10 +87,10 @@ func resourceManagedCertificateAdd(ctx context.Context, d *schema.ResourceData,
 	validationMethod, _ := d.Get("default_validation_method").(string)
 	id, _ := strconv.Atoi(siteId)
 	log.Printf("[INFO] requesting site cert to site ID: %d to %v", id, d)
-	siteCertificateV3Response, diags := client.RequestSiteCertificate(id, validationMethod, accountId)
-	if diags != nil && diags.HasError() {
-		log.Printf("[ERROR] failed request site cert to site ID: %d, %v\n", id, diags)
-		return diags
+	siteCertificateV3Response, err := client.RequestSiteCertificate(ctx, id, validationMethod, accountId)
+	if err != nil {
+		log.Printf("[ERROR] failed request site cert to site ID: %d, %v\n", id, err)
+		return diag.FromErr(err)
 	} else if siteCertificateV3Response.Errors != nil {
 		log.Printf("[ERROR] Failed to request site cert to site ID: %d, %v\n", id, siteCertificateV3Response.Errors[0].Detail)
 		return []diag.Diagnostic{{
@@ -95,7 +99,7 @@ func resourceManagedCertificateAdd(ctx context.Context, d *schema.ResourceData,
 			Detail:   fmt.Sprintf("Failed to request site cert to site ID%d, %s", id, siteCertificateV3Response.Errors[0].Detail),
 		}}
 	}
-	err := d.Set("site_id", strconv.Itoa(siteCertificateV3Response.Data[0].SiteId))
+	err = d.Set("site_id", strconv.Itoa(siteCertificateV3Response.Data[0].SiteId))
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula site id after delete v3 site: %s\n", err)
 		return diag.FromErr(err)
@@ -115,10 +119,13 @@ func resourceManagedCertificateRead(ctx context.Context, d *schema.ResourceData,
 	}
 	id, _ := strconv.Atoi(siteId)
 	log.Printf("[INFO] get site cert status of site ID: %d to %v", id, d)
-	siteCertificateV3Response, diags := client.GetSiteCertificateRequestStatus(id, accountId)
-	if diags != nil && diags.HasError() {
-		log.Printf("[ERROR] failed get site cert status of site ID: %d, %v\n", id, diags)
-		return diags
+	siteCertificateV3Response, err := client.GetSiteCertificateRequestStatus(ctx, id, accountId)
+	if err != nil {
+		if removeGoneResource(d, err, "managed certificate") {
+			return nil
+		}
+		log.Printf("[ERROR] failed get site cert status of site ID: %d, %v\n", id, err)
+		return diag.FromErr(err)
 	} else if siteCertificateV3Response.Errors != nil {
 		log.Printf("[ERROR] Failed get site cert status of site ID: %d, %v\n", id, siteCertificateV3Response.Errors[0].Detail)
 		return []diag.Diagnostic{{
@@ -127,7 +134,7 @@ func resourceManagedCertificateRead(ctx context.Context, d *schema.ResourceData,
 			Detail:   fmt.Sprintf("Failed to get site cert status of site ID%d, %s", id, siteCertificateV3Response.Errors[0].Detail),
 		}}
 	}
-	err := d.Set("site_id", strconv.Itoa(siteCertificateV3Response.Data[0].SiteId))
+	err = d.Set("site_id", strconv.Itoa(siteCertificateV3Response.Data[0].SiteId))
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula site id after request site cert to site ID: %d, %s\n", id, err)
 		return diag.FromErr(err)
@@ -140,7 +147,11 @@ func resourceManagedCertificateRead(ctx context.Context, d *schema.ResourceData,
 
 	if d.Get("account_id") == nil || d.Get("account_id") == 0 {
 		log.Printf("[INFO] changing account_id after request site cert to site ID: %d\n", id)
-		err = d.Set("account_id", client.accountStatus.AccountID)
+		accountStatus, err := client.currentAccountStatus(ctx)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		err = d.Set("account_id", accountStatus.AccountID)
 		if err != nil {
 			log.Printf("[ERROR] Could not read account_id after request site cert to site ID: %d, %s\n", id, err)
 			return diag.FromErr(err)
@@ -161,10 +172,10 @@ func resourceManagedCertificateDelete(ctx context.Context, d *schema.ResourceDat
 		accountId = &accountIdValue
 	}
 	log.Printf("[INFO] deleting site cert request of site ID: %d to %v", id, d)
-	siteCertificateV3Response, diags := client.DeleteRequestSiteCertificate(id, accountId)
-	if diags != nil && diags.HasError() {
-		log.Printf("[ERROR] failed delete site cert request of site ID: %d, %v\n", id, diags)
-		return diags
+	siteCertificateV3Response, err := client.DeleteRequestSiteCertificate(ctx, id, accountId)
+	if err != nil {
+		log.Printf("[ERROR] failed delete site cert request of site ID: %d, %v\n", id, err)
+		return diag.FromErr(err)
 	} else if siteCertificateV3Response.Errors != nil {
 		log.Printf("[ERROR] Failed to delete site cert request of site ID: %d, %v\n", id, siteCertificateV3Response.Errors[0].Detail)
 		return []diag.Diagnostic{{
@@ -173,7 +184,7 @@ func resourceManagedCertificateDelete(ctx context.Context, d *schema.ResourceDat
 			Detail:   fmt.Sprintf("Failed to delete site cert request of site ID%d, %s", id, siteCertificateV3Response.Errors[0].Detail),
 		}}
 	}
-	err := d.Set("site_id", strconv.Itoa(siteCertificateV3Response.Data[0].SiteId))
+	err = d.Set("site_id", strconv.Itoa(siteCertificateV3Response.Data[0].SiteId))
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula site id after delete v3 site: %s\n", err)
 		return diag.FromErr(err)
@@ -3,8 +3,10 @@ package incapsula
 import (
 	//"crypto/sha1"
 	//"encoding/hex"
+	"context"
 	"encoding/base64"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"strconv"
@@ -13,12 +15,12 @@ import (
 
 func resourceMtlsClientToImpervaCertificate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceClientCaCertificateCreate,
-		Read:   resourceClientCaCertificateRead,
-		Update: resourceClientCaCertificateUpdate,
-		Delete: resourceClientCaCertificateDelete,
+		CreateContext: resourceClientCaCertificateCreate,
+		ReadContext:   resourceClientCaCertificateRead,
+		UpdateContext: resourceClientCaCertificateUpdate,
+		DeleteContext: resourceClientCaCertificateDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				d.MarkNewResource()
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
@@ -70,10 +72,10 @@ func resourceMtlsClientToImpervaCertificate() *schema.Resource {
 	}
 }
 
-func resourceClientCaCertificateUpdate(d *schema.ResourceData, m interface{}) error {
-	return fmt.Errorf("Update action is not supported for current resource. Please create a new Mutual TLS Client to Imperva CA Certificate resource and only then, delete the old one.")
+func resourceClientCaCertificateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return diag.Errorf("Update action is not supported for current resource. Please create a new Mutual TLS Client to Imperva CA Certificate resource and only then, delete the old one.")
 }
-func resourceClientCaCertificateCreate(d *schema.ResourceData, m interface{}) error {
+func resourceClientCaCertificateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	encodedCert := d.Get("certificate").(string)
 	// Standard Base64 Decoding
@@ -85,34 +87,37 @@ func resourceClientCaCertificateCreate(d *schema.ResourceData, m interface{}) er
 		fmt.Printf("Error decoding Base64 encoded data from certificate field of incapsula_site_tls_settings resource %v", err)
 	}
 
-	mTLSCertificate, err := client.AddClientCaCertificate(
+	mTLSCertificate, err := client.AddClientCaCertificate(ctx,
 		decodedCert,
 		d.Get("account_id").(string),
 		d.Get("certificate_name").(string),
 	)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(strconv.Itoa(mTLSCertificate.Id))
-	return resourceClientCaCertificateRead(d, m)
+	return resourceClientCaCertificateRead(ctx, d, m)
 }
 
-func resourceClientCaCertificateRead(d *schema.ResourceData, m interface{}) error {
+func resourceClientCaCertificateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	accountIDStr := d.Get("account_id").(string)
 	certificateIDStr := d.Id()
 
-	clientToImpervaCertificateData, certificateExits, err := client.GetClientCaCertificate(accountIDStr, certificateIDStr)
+	clientToImpervaCertificateData, certificateExits, err := client.GetClientCaCertificate(ctx, accountIDStr, certificateIDStr)
 	if !certificateExits && !d.IsNewResource() {
 		d.SetId("")
 		return nil
 	}
 
+	if removeGoneResource(d, err, "mutual TLS Client to Imperva certificate") {
+		return nil
+	}
 	if err != nil || !certificateExits {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(strconv.Itoa(clientToImpervaCertificateData.Id))
@@ -120,14 +125,14 @@ func resourceClientCaCertificateRead(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceClientCaCertificateDelete(d *schema.ResourceData, m interface{}) error {
+func resourceClientCaCertificateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	accountID := d.Get("account_id").(string)
 	certificateID := d.Id()
-	err := client.DeleteClientCaCertificate(accountID, certificateID)
+	err := client.DeleteClientCaCertificate(ctx, accountID, certificateID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
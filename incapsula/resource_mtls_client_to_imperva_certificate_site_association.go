@@ -1,7 +1,9 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"strconv"
@@ -10,12 +12,12 @@ import (
 
 func resourceMtlsClientToImpervaCertificateSiteAssociation() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSiteMtlsClientToImpervaCertificateAssociationCreate,
-		Read:   resourceSiteMtlsClientToImpervaCertificateAssociationRead,
-		Update: resourceSiteMtlsClientToImpervaCertificateAssociationCreate,
-		Delete: resourceSiteMtlsClientToImpervaCertificateAssociationDelete,
+		CreateContext: resourceSiteMtlsClientToImpervaCertificateAssociationCreate,
+		ReadContext:   resourceSiteMtlsClientToImpervaCertificateAssociationRead,
+		UpdateContext: resourceSiteMtlsClientToImpervaCertificateAssociationCreate,
+		DeleteContext: resourceSiteMtlsClientToImpervaCertificateAssociationDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				d.MarkNewResource()
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) < 2 || len(idSlice) > 3 || idSlice[0] == "" || idSlice[1] == "" {
@@ -71,17 +73,20 @@ func resourceMtlsClientToImpervaCertificateSiteAssociation() *schema.Resource {
 	}
 }
 
-func resourceSiteMtlsClientToImpervaCertificateAssociationRead(d *schema.ResourceData, m interface{}) error {
+func resourceSiteMtlsClientToImpervaCertificateAssociationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	siteID, certificateID, accountID, err := validateInput(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	mTLSCertificateData, associationExists, err := client.GetSiteMtlsClientToImpervaCertificateAssociation(siteID, certificateID, accountID)
+	mTLSCertificateData, associationExists, err := client.GetSiteMtlsClientToImpervaCertificateAssociation(ctx, siteID, certificateID, accountID)
+	if removeGoneResource(d, err, "site to mutual TLS Client to Imperva certificate association") {
+		return nil
+	}
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if !associationExists && !d.IsNewResource() {
 		log.Printf("Site to mutual TLS Imperva to Origin Certificate association with Site ID %d, Certificate ID %d doesn't exist any more. The resource will be deleted from terraform state.", siteID, certificateID)
@@ -89,7 +94,7 @@ func resourceSiteMtlsClientToImpervaCertificateAssociationRead(d *schema.Resourc
 		return nil
 	}
 	if mTLSCertificateData == nil {
-		return fmt.Errorf("Couldn't find the Incapsula Client to Imperva CA Certificate Site Association. Site Id %d, certificate ID %d", siteID, certificateID)
+		return diag.Errorf("Couldn't find the Incapsula Client to Imperva CA Certificate Site Association. Site Id %d, certificate ID %d", siteID, certificateID)
 	}
 
 	// Generate synthetic ID
@@ -99,37 +104,37 @@ func resourceSiteMtlsClientToImpervaCertificateAssociationRead(d *schema.Resourc
 	return nil
 }
 
-func resourceSiteMtlsClientToImpervaCertificateAssociationCreate(d *schema.ResourceData, m interface{}) error {
+func resourceSiteMtlsClientToImpervaCertificateAssociationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteID, certificateID, accountID, err := validateInput(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = client.CreateSiteMtlsClientToImpervaCertificateAssociation(
+	err = client.CreateSiteMtlsClientToImpervaCertificateAssociation(ctx,
 		certificateID,
 		siteID,
 		accountID,
 	)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 		//todo -add error message
 	}
-	return resourceSiteMtlsClientToImpervaCertificateAssociationRead(d, m)
+	return resourceSiteMtlsClientToImpervaCertificateAssociationRead(ctx, d, m)
 }
 
-func resourceSiteMtlsClientToImpervaCertificateAssociationDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSiteMtlsClientToImpervaCertificateAssociationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteID, certificateID, accountID, err := validateInput(d)
 
-	err = client.DeleteSiteMtlsClientToImpervaCertificateAssociation(
+	err = client.DeleteSiteMtlsClientToImpervaCertificateAssociation(ctx,
 		certificateID,
 		siteID,
 		accountID,
 	)
 	if err != nil {
 		//todo - check error
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId("")
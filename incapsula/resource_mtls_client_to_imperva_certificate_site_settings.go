@@ -1,7 +1,8 @@
 package incapsula
 
 import (
-	"fmt"
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
@@ -18,12 +19,12 @@ const isDisableSessionResumptionDefault = false
 
 func resourceMtlsClientToImpervaCertificateSetings() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceeMtlsClientToImpervaCertificateSetingsUpdate,
-		Read:   resourceeMtlsClientToImpervaCertificateSetingsRead,
-		Update: resourceeMtlsClientToImpervaCertificateSetingsUpdate,
-		Delete: resourceeMtlsClientToImpervaCertificateSetingsDelete,
+		CreateContext: resourceeMtlsClientToImpervaCertificateSetingsUpdate,
+		ReadContext:   resourceeMtlsClientToImpervaCertificateSetingsRead,
+		UpdateContext: resourceeMtlsClientToImpervaCertificateSetingsUpdate,
+		DeleteContext: resourceeMtlsClientToImpervaCertificateSetingsDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				d.Set("site_id", d.Id())
 				log.Printf("[DEBUG] Importing Incapsula MTLS Client to Imperva Certificate Site Settings for Site ID %s", d.Id())
 				return []*schema.ResourceData{d}, nil
@@ -107,13 +108,13 @@ func resourceMtlsClientToImpervaCertificateSetings() *schema.Resource {
 	}
 }
 
-func resourceeMtlsClientToImpervaCertificateSetingsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceeMtlsClientToImpervaCertificateSetingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	siteIDStr := d.Get("site_id").(string)
 	siteID, err := strconv.Atoi(siteIDStr)
 	if err != nil {
-		return fmt.Errorf("failed to convert Site Id for Incapsula MTLS Client to Imperva Certificate Site Settings resource, actual value: %s, expected numeric id", siteIDStr)
+		return diag.Errorf("failed to convert Site Id for Incapsula MTLS Client to Imperva Certificate Site Settings resource, actual value: %s, expected numeric id", siteIDStr)
 	}
 
 	ports := []int{}
@@ -147,26 +148,26 @@ func resourceeMtlsClientToImpervaCertificateSetingsUpdate(d *schema.ResourceData
 		payload.HeaderValue = d.Get("header_value").(string)
 	}
 
-	err = client.UpdateSiteTlsSetings(siteID, payload)
+	err = client.UpdateSiteTlsSetings(ctx, siteID, payload)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(siteIDStr)
-	return resourceeMtlsClientToImpervaCertificateSetingsRead(d, m)
+	return resourceeMtlsClientToImpervaCertificateSetingsRead(ctx, d, m)
 }
 
-func resourceeMtlsClientToImpervaCertificateSetingsRead(d *schema.ResourceData, m interface{}) error {
+func resourceeMtlsClientToImpervaCertificateSetingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	siteIDStr := d.Get("site_id").(string)
 	siteID, err := strconv.Atoi(siteIDStr)
 	if err != nil {
-		return fmt.Errorf("failed to convert Site Id for Incapsula MTLS Client to Imperva Certificate Site Settings resource, actual value: %s, expected numeric id", siteIDStr)
+		return diag.Errorf("failed to convert Site Id for Incapsula MTLS Client to Imperva Certificate Site Settings resource, actual value: %s, expected numeric id", siteIDStr)
 	}
 
-	siteTlsSettings, objectExists, err := client.GetSiteTlsSettings(
+	siteTlsSettings, objectExists, err := client.GetSiteTlsSettings(ctx,
 		siteID,
 	)
 	if !objectExists && !d.IsNewResource() {
@@ -174,8 +175,11 @@ func resourceeMtlsClientToImpervaCertificateSetingsRead(d *schema.ResourceData,
 		return nil
 	}
 
+	if removeGoneResource(d, err, "site mutual TLS Client to Imperva settings") {
+		return nil
+	}
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if len(siteTlsSettings.Ports) == 0 {
@@ -209,7 +213,7 @@ func resourceeMtlsClientToImpervaCertificateSetingsRead(d *schema.ResourceData,
 	}
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("require_client_certificate", siteTlsSettings.Mandatory)
@@ -224,13 +228,13 @@ func resourceeMtlsClientToImpervaCertificateSetingsRead(d *schema.ResourceData,
 	return nil
 }
 
-func resourceeMtlsClientToImpervaCertificateSetingsDelete(d *schema.ResourceData, m interface{}) error {
+func resourceeMtlsClientToImpervaCertificateSetingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	siteIDStr := d.Get("site_id").(string)
 	siteID, err := strconv.Atoi(siteIDStr)
 	if err != nil {
-		return fmt.Errorf("failed to convert Site Id for Incapsula MTLS Client to Imperva Certificate Site Settings resource, actual value: %s, expected numeric id", siteIDStr)
+		return diag.Errorf("failed to convert Site Id for Incapsula MTLS Client to Imperva Certificate Site Settings resource, actual value: %s, expected numeric id", siteIDStr)
 	}
 
 	payload := SiteTlsSettings{
@@ -246,9 +250,9 @@ func resourceeMtlsClientToImpervaCertificateSetingsDelete(d *schema.ResourceData
 		HeaderValue:                headerValueDefault,
 	}
 
-	err = client.UpdateSiteTlsSetings(siteID, payload)
+	err = client.UpdateSiteTlsSetings(ctx, siteID, payload)
 	if err != nil {
-		return fmt.Errorf("Failed to destroy Incapsula MTLS Client to Imperva Certificate Site Settings resource for Site ID %s, error:\n%s", siteIDStr, err)
+		return diag.Errorf("Failed to destroy Incapsula MTLS Client to Imperva Certificate Site Settings resource for Site ID %s, error:\n%s", siteIDStr, err)
 	}
 
 	d.SetId("")
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -74,7 +75,7 @@ func testCheckSiteTlsSettingsExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		_, _, err = client.GetSiteTlsSettings(siteId)
+		_, _, err = client.GetSiteTlsSettings(context.Background(), siteId)
 		if err != nil {
 			fmt.Errorf("Incapsula Site TLS settings doesn't exist")
 		}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -52,7 +53,7 @@ func testACCStateMtlsClientToImervaCertificateDestroy(state *terraform.State) er
 		return fmt.Errorf("Mandatory parameter account_id doesn't exist for Incapsula mTLS Client CA To Imperva Certificate with ID %s", certificateID)
 	}
 
-	_, exists, _ := client.GetClientCaCertificate(accountID, certificateID)
+	_, exists, _ := client.GetClientCaCertificate(context.Background(), accountID, certificateID)
 	if exists == true {
 		return fmt.Errorf("Resource %s with cerificate ID %s still exists", mtlsClientToImervaCertificateResourceName, certificateID)
 	}
@@ -72,7 +73,7 @@ func testCheckMtlsClientToImervaCertificateExists() resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		_, _, err := client.GetClientCaCertificate(accountID, certificateID)
+		_, _, err := client.GetClientCaCertificate(context.Background(), accountID, certificateID)
 		if err != nil {
 			return fmt.Errorf("Incapsula mTLS Client CA To Imperva Certificate with ID %s does not exist for Account ID %s", certificateID, accountID)
 		}
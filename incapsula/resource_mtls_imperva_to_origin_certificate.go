@@ -1,8 +1,10 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"strconv"
@@ -10,10 +12,10 @@ import (
 
 func resourceMtlsImpervaToOriginCertificate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceMTLSImpervaToOriginCertificateCreate,
-		Read:   resourceMTLSImpervaToOriginCertificateRead,
-		Update: resourceMTLSImpervaToOriginCertificateUpdate,
-		Delete: resourceMTLSImpervaToOriginCertificateDelete,
+		CreateContext: resourceMTLSImpervaToOriginCertificateCreate,
+		ReadContext:   resourceMTLSImpervaToOriginCertificateRead,
+		UpdateContext: resourceMTLSImpervaToOriginCertificateUpdate,
+		DeleteContext: resourceMTLSImpervaToOriginCertificateDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -82,7 +84,7 @@ func resourceMtlsImpervaToOriginCertificate() *schema.Resource {
 	}
 }
 
-func resourceMTLSImpervaToOriginCertificateCreate(d *schema.ResourceData, m interface{}) error {
+func resourceMTLSImpervaToOriginCertificateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	inputHash := createHash(d)
 	accountID := d.Get("account_id").(string)
@@ -107,7 +109,7 @@ func resourceMTLSImpervaToOriginCertificateCreate(d *schema.ResourceData, m inte
 			fmt.Printf("Error decoding Base64 encoded data %v", err)
 		}
 
-		savedCertificate, err := client.AddMTLSCertificate(
+		savedCertificate, err := client.AddMTLSCertificate(ctx,
 			decodedCert,
 			decodedPKey,
 			passphrase,
@@ -117,22 +119,25 @@ func resourceMTLSImpervaToOriginCertificateCreate(d *schema.ResourceData, m inte
 		)
 
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		d.SetId(strconv.Itoa(savedCertificate.Id))
 		log.Printf("[INFO] Created mutual TLS Imperva to Origin Certificate with ID: %s\n", d.Id())
 	}
 
-	return resourceMTLSImpervaToOriginCertificateRead(d, m)
+	return resourceMTLSImpervaToOriginCertificateRead(ctx, d, m)
 }
 
-func resourceMTLSImpervaToOriginCertificateRead(d *schema.ResourceData, m interface{}) error {
+func resourceMTLSImpervaToOriginCertificateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	accountID := d.Get("account_id").(string)
 
-	mTLSCertificateData, err := client.GetMTLSCertificate(d.Id(), accountID)
+	mTLSCertificateData, err := client.GetMTLSCertificate(ctx, d.Id(), accountID)
+	if removeGoneResource(d, err, "mutual TLS Imperva to Origin certificate") {
+		return nil
+	}
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(strconv.Itoa(mTLSCertificateData.Id))
@@ -143,7 +148,7 @@ func resourceMTLSImpervaToOriginCertificateRead(d *schema.ResourceData, m interf
 	return nil
 }
 
-func resourceMTLSImpervaToOriginCertificateUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceMTLSImpervaToOriginCertificateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	inputHash := createHash(d)
 	accountID := d.Get("account_id").(string)
@@ -165,7 +170,7 @@ func resourceMTLSImpervaToOriginCertificateUpdate(d *schema.ResourceData, m inte
 			fmt.Printf("Error decoding Base64 encoded data %v", err)
 		}
 
-		mTLSCertificateData, err := client.UpdateMTLSCertificate(
+		mTLSCertificateData, err := client.UpdateMTLSCertificate(ctx,
 			d.Id(),
 			decodedCert,
 			decodedPKey,
@@ -176,22 +181,22 @@ func resourceMTLSImpervaToOriginCertificateUpdate(d *schema.ResourceData, m inte
 		)
 
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		d.SetId(strconv.Itoa(mTLSCertificateData.Id))
 		log.Printf("[INFO] Updated mutual TLS Imperva to Origin Certificate with ID: %s\n", d.Id())
 	}
-	return resourceMTLSImpervaToOriginCertificateRead(d, m)
+	return resourceMTLSImpervaToOriginCertificateRead(ctx, d, m)
 }
 
-func resourceMTLSImpervaToOriginCertificateDelete(d *schema.ResourceData, m interface{}) error {
+func resourceMTLSImpervaToOriginCertificateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	accountID := d.Get("account_id").(string)
 
-	err := client.DeleteMTLSCertificate(d.Id(), accountID)
+	err := client.DeleteMTLSCertificate(ctx, d.Id(), accountID)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
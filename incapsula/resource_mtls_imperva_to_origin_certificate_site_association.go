@@ -1,7 +1,9 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"strings"
@@ -9,13 +11,13 @@ import (
 
 func resourceMtlsImpervaToOriginCertificateSiteAssociation() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSiteMtlsCertificateAssociationCreate,
-		Read:   resourceSiteMtlsCertificateAssociationRead,
-		Update: resourceSiteMtlsCertificateAssociationCreate,
-		Delete: resourceSiteMtlsCertificateAssociationDelete,
+		CreateContext: resourceSiteMtlsCertificateAssociationCreate,
+		ReadContext:   resourceSiteMtlsCertificateAssociationRead,
+		UpdateContext: resourceSiteMtlsCertificateAssociationCreate,
+		DeleteContext: resourceSiteMtlsCertificateAssociationDelete,
 		//todo
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
 					return nil, fmt.Errorf("unexpected format of Incapsula Site to Imperva to Origin mutual TLS Certificate Association resource ID, expected site_id/certificate_id, got %s", d.Id())
@@ -51,21 +53,30 @@ func resourceMtlsImpervaToOriginCertificateSiteAssociation() *schema.Resource {
 	}
 }
 
-func resourceSiteMtlsCertificateAssociationRead(d *schema.ResourceData, m interface{}) error {
+func resourceSiteMtlsCertificateAssociationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	siteID, certificateID, accountId, err := validateInput(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	associationExists, err := client.GetSiteMtlsCertificateAssociation(certificateID, siteID, accountId)
+	associationExists, err := client.GetSiteMtlsCertificateAssociation(ctx, certificateID, siteID, accountId)
+	if removeGoneResource(d, err, "site to mutual TLS Imperva to Origin certificate association") {
+		return nil
+	}
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+
+	if !associationExists && !d.IsNewResource() {
+		log.Printf("[INFO] Site to mutual TLS Imperva to Origin Certificate association with Site ID %d, Certificate ID %d doesn't exist any more. The resource will be deleted from terraform state.", siteID, certificateID)
+		d.SetId("")
+		return nil
 	}
 
 	if associationExists == false {
-		return fmt.Errorf("Couldn't find the Incapsula Site - Imperva to Origin mutual TLS Certificate Association")
+		return diag.Errorf("Couldn't find the Incapsula Site - Imperva to Origin mutual TLS Certificate Association")
 	}
 
 	// Generate synthetic ID
@@ -75,35 +86,35 @@ func resourceSiteMtlsCertificateAssociationRead(d *schema.ResourceData, m interf
 	return nil
 }
 
-func resourceSiteMtlsCertificateAssociationCreate(d *schema.ResourceData, m interface{}) error {
+func resourceSiteMtlsCertificateAssociationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteID, certificateID, accountId, err := validateInput(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = client.CreateSiteMtlsCertificateAssociation(
+	err = client.CreateSiteMtlsCertificateAssociation(ctx,
 		certificateID,
 		siteID,
 		accountId,
 	)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return resourceSiteMtlsCertificateAssociationRead(d, m)
+	return resourceSiteMtlsCertificateAssociationRead(ctx, d, m)
 }
 
-func resourceSiteMtlsCertificateAssociationDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSiteMtlsCertificateAssociationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteID, certificateID, accountId, err := validateInput(d)
 
-	err = client.DeleteSiteMtlsCertificateAssociation(
+	err = client.DeleteSiteMtlsCertificateAssociation(ctx,
 		certificateID,
 		siteID,
 		accountId,
 	)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId("")
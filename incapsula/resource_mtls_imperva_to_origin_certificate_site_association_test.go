@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -45,7 +46,7 @@ func testACCStateSiteMtlsCertificateAssociationDestroy(s *terraform.State) error
 			return fmt.Errorf("Incapsula mTLS certificate is not assigned to Site ID %s (cannot find mandatory parameter site_id)", siteID)
 		}
 
-		_, err := client.GetMTLSCertificate(siteID, "")
+		_, err := client.GetMTLSCertificate(context.Background(), siteID, "")
 		if err == nil {
 			return fmt.Errorf("Resource %s with siteID ID %s still exists", siteMtlsCrtificateAssociationResourceName, siteID)
 		}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	b64 "encoding/base64"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -61,7 +62,7 @@ func testACCStateMtlsImpervaToOriginCertificateDestroy(s *terraform.State) error
 			fmt.Errorf("Parameter id was not found in resource %s", mtlsCrtificateResourceName)
 		}
 
-		_, err := client.GetMTLSCertificate(certificateID, "")
+		_, err := client.GetMTLSCertificate(context.Background(), certificateID, "")
 		if err == nil {
 			return fmt.Errorf("Resource %s with cerificate ID %s still exists", mtlsCrtificateResourceName, certificateID)
 		}
@@ -77,7 +78,7 @@ func testCheckMtlsImpervaToOriginCertificateExists() resource.TestCheckFunc {
 		}
 		certificateID := res.Primary.ID
 		client := testAccProvider.Meta().(*Client)
-		_, err := client.GetMTLSCertificate(certificateID, "")
+		_, err := client.GetMTLSCertificate(context.Background(), certificateID, "")
 		if err != nil {
 			return fmt.Errorf("Incapsula mTLS Imperva to Origin Certificate with ID %s does not exist", certificateID)
 		}
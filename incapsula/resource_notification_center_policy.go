@@ -1,7 +1,9 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
@@ -27,12 +29,12 @@ var assetResource = schema.Resource{
 
 func resourceNotificationCenterPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNotificationCenterPolicyCreate,
-		Read:   resourceNotificationCenterPolicyRead,
-		Update: resourceNotificationCenterPolicyUpdate,
-		Delete: resourceNotificationCenterPolicyDelete,
+		CreateContext: resourceNotificationCenterPolicyCreate,
+		ReadContext:   resourceNotificationCenterPolicyRead,
+		UpdateContext: resourceNotificationCenterPolicyUpdate,
+		DeleteContext: resourceNotificationCenterPolicyDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(d.Id(), "/")
 				isOnlyPolicyIdFormat := len(idSlice) == 1
 				isAccountIdSlashPolicyIdFormat := !(len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "")
@@ -160,7 +162,7 @@ func resourceNotificationCenterPolicy() *schema.Resource {
 	}
 }
 
-func resourceNotificationCenterPolicyUpdate(data *schema.ResourceData, i interface{}) error {
+func resourceNotificationCenterPolicyUpdate(ctx context.Context, data *schema.ResourceData, i interface{}) diag.Diagnostics {
 	client := i.(*Client)
 	notificationCenterPolicyName := data.Get("policy_name").(string)
 	notificationCenterPolicyId, _ := getPolicyId(data)
@@ -168,29 +170,29 @@ func resourceNotificationCenterPolicyUpdate(data *schema.ResourceData, i interfa
 	log.Printf("[INFO] Updateding NotificationCenterPolicy with policyId:%d accountId:%d and name: %s\n",
 		notificationCenterPolicyId, accountId, notificationCenterPolicyName)
 	notificationPolicyFullDto := getNotificationCenterPolicyFromResource(data)
-	notificationCenterPolicyUpdateResponse, err := client.UpdateNotificationCenterPolicy(&notificationPolicyFullDto)
+	notificationCenterPolicyUpdateResponse, err := client.UpdateNotificationCenterPolicy(ctx, &notificationPolicyFullDto)
 	if err != nil {
 		log.Printf("[ERROR] Could not update NotificationCenterPolicy id:%d. \nThe policy: %+v  \nThe response:%+v \nThe error: %s\n",
 			notificationCenterPolicyId, notificationPolicyFullDto, notificationCenterPolicyUpdateResponse, err)
-		return err
+		return diag.FromErr(err)
 	} else {
 		log.Printf("[DEBUG] NotificationCenter update policy with json reponse: %+v ", notificationCenterPolicyUpdateResponse)
 	}
 
-	return resourceNotificationCenterPolicyRead(data, client)
+	return resourceNotificationCenterPolicyRead(ctx, data, client)
 }
 
-func resourceNotificationCenterPolicyCreate(data *schema.ResourceData, i interface{}) error {
+func resourceNotificationCenterPolicyCreate(ctx context.Context, data *schema.ResourceData, i interface{}) diag.Diagnostics {
 	client := i.(*Client)
 	notificationCenterPolicyName := data.Get("policy_name").(string)
 	log.Printf("[INFO] Creating NotificationCenterPolicy: %s\n", notificationCenterPolicyName)
 	notificationPolicyFullDto := getNotificationCenterPolicyFromResource(data)
-	notificationCenterPolicyAddResponse, err := client.AddNotificationCenterPolicy(&notificationPolicyFullDto)
+	notificationCenterPolicyAddResponse, err := client.AddNotificationCenterPolicy(ctx, &notificationPolicyFullDto)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not create NotificationCenterPolicy. \nThe policy: %+v  \nThe response:%+v \nThe error: %s\n",
 			notificationPolicyFullDto, notificationCenterPolicyAddResponse, err)
-		return err
+		return diag.FromErr(err)
 	} else {
 		log.Printf("[DEBUG] NotificationCenter create policy with json response: %+v ", notificationCenterPolicyAddResponse)
 	}
@@ -199,7 +201,7 @@ func resourceNotificationCenterPolicyCreate(data *schema.ResourceData, i interfa
 	data.SetId(policyID)
 	log.Printf("[DEBUG] NotificationCenter create policy with id %s ", policyID)
 
-	return resourceNotificationCenterPolicyRead(data, client)
+	return resourceNotificationCenterPolicyRead(ctx, data, client)
 }
 
 // This function get all the properties of NotificationCenterPolicy from the resource,
@@ -306,18 +308,22 @@ func getAssetsFromResource(d *schema.ResourceData) []AssetDto {
 	return assetList
 }
 
-func resourceNotificationCenterPolicyRead(data *schema.ResourceData, i interface{}) error {
+func resourceNotificationCenterPolicyRead(ctx context.Context, data *schema.ResourceData, i interface{}) diag.Diagnostics {
 	client := i.(*Client)
 	policyID, _ := getPolicyId(data)
 	accountId := data.Get("account_id").(int)
-	notificationCenterPolicy, err := client.GetNotificationCenterPolicy(policyID, accountId)
+	notificationCenterPolicy, err := client.GetNotificationCenterPolicy(ctx, policyID, accountId)
 	log.Printf("[INFO] Reading NotificationCenterPolicy with id %d \nThe policy: %+v", policyID, notificationCenterPolicy)
+	if removeGoneResource(data, err, "notification center policy") {
+		return nil
+	}
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if notificationCenterPolicy == nil {
 		log.Printf("[INFO] notificationCenterPolicy %s has already been deleted: %s\n", data.Id(), err)
+		data.SetId("")
 		return nil
 	}
 
@@ -388,16 +394,16 @@ func getPolicyId(data *schema.ResourceData) (int, error) {
 	return policyID, err
 }
 
-func resourceNotificationCenterPolicyDelete(data *schema.ResourceData, i interface{}) error {
+func resourceNotificationCenterPolicyDelete(ctx context.Context, data *schema.ResourceData, i interface{}) diag.Diagnostics {
 	client := i.(*Client)
 	policyID, _ := getPolicyId(data)
 	accountId := data.Get("account_id").(int)
 	log.Printf("[INFO] Deleting NotificationCenterPolicy policyId: %d and accountId: %d", policyID, accountId)
-	err := client.DeleteNotificationCenterPolicy(policyID, accountId)
+	err := client.DeleteNotificationCenterPolicy(ctx, policyID, accountId)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not delete NotificationCenterPolicy id: %d, %s", policyID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Deleted NotificationCenterPolicy id: %d ", policyID)
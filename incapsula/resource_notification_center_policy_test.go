@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -99,7 +100,7 @@ func testAccNotificationCenterPolicyDestroy(state *terraform.State) error {
 		policyId, _ := strconv.Atoi(policyIdStr)
 		accountIdStr := res.Primary.Attributes["account_id"]
 		accountId, _ := strconv.Atoi(accountIdStr)
-		notificationCenterPolicy, err := client.GetNotificationCenterPolicy(policyId, accountId)
+		notificationCenterPolicy, err := client.GetNotificationCenterPolicy(context.Background(), policyId, accountId)
 		policyDeleted := notificationCenterPolicy == nil && err != nil && strings.Contains(err.Error(), "Policy not found")
 		if !policyDeleted {
 			log.Printf("[INFO] ****Test**** testAccNotificationCenterPolicyDestroy, policy: %+v error: %s \n", notificationCenterPolicy, err)
@@ -128,7 +129,7 @@ func testCheckNotificationCenterPolicyExists(fullResourceName string) resource.T
 		accountIdStr := res.Primary.Attributes["account_id"]
 		accountId, _ := strconv.Atoi(accountIdStr)
 		log.Printf("[INFO] ****Test**** policyId: %d accountId:%d", policyId, accountId)
-		notificationCenterPolicy, err := client.GetNotificationCenterPolicy(policyId, accountId)
+		notificationCenterPolicy, err := client.GetNotificationCenterPolicy(context.Background(), policyId, accountId)
 		if err != nil {
 			return err
 		}
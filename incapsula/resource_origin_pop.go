@@ -1,7 +1,9 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"strconv"
@@ -11,10 +13,10 @@ import (
 func resourceOriginPOP() *schema.Resource {
 	return &schema.Resource{
 		DeprecationMessage: "This resource is deprecated. It will be removed in a future version. Please use resource incapsula_data_centers_configuration instead.",
-		Create:             resourceOriginPOPUpdate,
-		Read:               resourceOriginPOPRead,
-		Update:             resourceOriginPOPUpdate,
-		Delete:             resourceOriginPOPDelete,
+		CreateContext:      resourceOriginPOPUpdate,
+		ReadContext:        resourceOriginPOPRead,
+		UpdateContext:      resourceOriginPOPUpdate,
+		DeleteContext:      resourceOriginPOPDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -49,7 +51,7 @@ func resourceOriginPOP() *schema.Resource {
 	}
 }
 
-func resourceOriginPOPUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceOriginPOPUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	dcID := d.Get("dc_id").(int)
 	siteID := d.Get("site_id").(int)
@@ -57,11 +59,11 @@ func resourceOriginPOPUpdate(d *schema.ResourceData, m interface{}) error {
 
 	log.Printf("[INFO] Setting Incapsula origin POP: %s for data center: %d\n", originPOP, dcID)
 
-	err := client.SetOriginPOP(dcID, originPOP)
+	err := client.SetOriginPOP(ctx, dcID, originPOP)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not set Incapsula origin POP: %s for data center: %d: %s\n", originPOP, dcID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Set Incapsula origin POP: %s for data center: %d\n", originPOP, dcID)
@@ -69,10 +71,10 @@ func resourceOriginPOPUpdate(d *schema.ResourceData, m interface{}) error {
 	syntheticID := fmt.Sprintf("%d/%d", siteID, dcID)
 	d.SetId(syntheticID)
 
-	return resourceOriginPOPRead(d, m)
+	return resourceOriginPOPRead(ctx, d, m)
 }
 
-func resourceOriginPOPRead(d *schema.ResourceData, m interface{}) error {
+func resourceOriginPOPRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by reading the ListDataCentersResponse for the data centers
 	client := m.(*Client)
 	if !strings.Contains(d.Id(), "/") {
@@ -86,11 +88,14 @@ func resourceOriginPOPRead(d *schema.ResourceData, m interface{}) error {
 	siteID := strings.Split(d.Id(), "/")[0]
 	dcID := strings.Split(d.Id(), "/")[1]
 
-	listDataCentersResponse, err := client.ListDataCenters(siteID)
+	listDataCentersResponse, err := client.ListDataCenters(ctx, siteID)
 
+	if removeGoneResource(d, err, "origin POP") {
+		return nil
+	}
 	if err != nil {
 		log.Printf("[ERROR] Could not read origin POP for data center: %s, site: %s %s\n", dcID, siteID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	found := false
@@ -119,14 +124,14 @@ func resourceOriginPOPRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceOriginPOPDelete(d *schema.ResourceData, m interface{}) error {
+func resourceOriginPOPDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	dcID := d.Get("dc_id").(int)
-	err := client.SetOriginPOP(dcID, "")
+	err := client.SetOriginPOP(ctx, dcID, "")
 
 	if err != nil {
 		log.Printf("[ERROR] Could not delete Incapsula origin POP for data center: %d: %s\n", dcID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Deleted Incapsula origin POP for data center: %d\n", dcID)
@@ -1,8 +1,10 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"strconv"
@@ -11,12 +13,12 @@ import (
 
 func resourcePolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePolicyCreate,
-		Read:   resourcePolicyRead,
-		Update: resourcePolicyUpdate,
-		Delete: resourcePolicyDelete,
+		CreateContext: resourcePolicyCreate,
+		ReadContext:   resourcePolicyRead,
+		UpdateContext: resourcePolicyUpdate,
+		DeleteContext: resourcePolicyDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -88,16 +90,23 @@ func resourcePolicy() *schema.Resource {
 	}
 }
 
-func getCurrentAccountId(d *schema.ResourceData, accountStatus *AccountStatusResponse) *int {
+func getCurrentAccountId(ctx context.Context, d *schema.ResourceData, client *Client) (*int, error) {
 	caid := d.Get("account_id").(int)
-	if accountStatus.isSubAccount() || caid == 0 {
+	if caid == 0 {
+		return nil, nil
+	}
+	accountStatus, err := client.currentAccountStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if accountStatus.isSubAccount() {
 		//in case of sub account we do not want to send the caid since the policy owner is the sub account's parent
-		return nil
+		return nil, nil
 	}
-	return &caid
+	return &caid, nil
 }
 
-func resourcePolicyCreate(d *schema.ResourceData, m interface{}) error {
+func resourcePolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	policySettingsString := d.Get("policy_settings").(string)
@@ -113,32 +122,38 @@ func resourcePolicyCreate(d *schema.ResourceData, m interface{}) error {
 		PolicySettings: policySettings,
 	}
 
-	policyAddResponse, err := client.AddPolicy(&policySubmitted)
+	policyAddResponse, err := client.AddPolicy(ctx, &policySubmitted)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not create Incapsula policy: %s - %s\n", policySubmitted.Name, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	policyID := strconv.Itoa(policyAddResponse.Value.ID)
 
 	d.SetId(policyID)
 	log.Printf("[INFO] Created Incapsula policy with ID: %s\n", policyID)
-	return resourcePolicyRead(d, m)
+	return resourcePolicyRead(ctx, d, m)
 }
 
-func resourcePolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourcePolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	policyID := d.Id()
 
-	currentAccountId := getCurrentAccountId(d, client.accountStatus)
+	currentAccountId, err := getCurrentAccountId(ctx, d, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	policyGetResponse, err := client.GetPolicy(policyID, currentAccountId)
+	policyGetResponse, err := client.GetPolicy(ctx, policyID, currentAccountId)
 
+	if removeGoneResource(d, err, "policy") {
+		return nil
+	}
 	if err != nil {
 		log.Printf("[ERROR] Could not get Incapsula policy: %s - %s\n", policyID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set computed values
@@ -156,19 +171,19 @@ func resourcePolicyRead(d *schema.ResourceData, m interface{}) error {
 	policySettingsJSONBytes, err := json.MarshalIndent(policyGetResponse.Value.PolicySettings, "", "    ")
 	if err != nil {
 		log.Printf("[ERROR] Could not get marshal Incapsula policy settings: %s - %s - %s\n", policyID, err, policySettingsJSONBytes)
-		return err
+		return diag.FromErr(err)
 	}
 	d.Set("policy_settings", string(policySettingsJSONBytes))
 
 	return nil
 }
 
-func resourcePolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if d.Get("account_id") != nil {
 		log.Printf("[WARN] Incapsula policy account id attribute is deprecated - please remove it\n")
@@ -177,16 +192,19 @@ func resourcePolicyUpdate(d *schema.ResourceData, m interface{}) error {
 	var policySettings []PolicySetting
 	err = json.Unmarshal([]byte(policySettingsString), &policySettings)
 
-	currentAccountId := getCurrentAccountId(d, client.accountStatus)
-	policyGetResponse, err := client.GetPolicy(d.Id(), currentAccountId)
+	currentAccountId, err := getCurrentAccountId(ctx, d, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	policyGetResponse, err := client.GetPolicy(ctx, d.Id(), currentAccountId)
 	if err != nil {
 		log.Printf("[ERROR] Could not get Incapsula policy: %d - %s\n", id, err)
-		if strings.Contains(err.Error(), "404") {
+		if IsNotFound(err) {
 			log.Printf("[INFO] Incapsula policy ID %d has already been deleted: %s\n", id, err)
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	policySubmitted := PolicySubmitted{
@@ -198,23 +216,26 @@ func resourcePolicyUpdate(d *schema.ResourceData, m interface{}) error {
 		PolicySettings:      policySettings,
 	}
 
-	_, err = client.UpdatePolicy(id, &policySubmitted, currentAccountId)
+	_, err = client.UpdatePolicy(ctx, id, &policySubmitted, currentAccountId)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not update Incapsula policy: %s - %s\n", policySubmitted.Name, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourcePolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
-	currentAccountId := getCurrentAccountId(d, client.accountStatus)
-	err := client.DeletePolicy(d.Id(), currentAccountId)
+	currentAccountId, err := getCurrentAccountId(ctx, d, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = client.DeletePolicy(ctx, d.Id(), currentAccountId)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
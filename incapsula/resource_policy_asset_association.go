@@ -1,7 +1,9 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"strings"
@@ -9,10 +11,10 @@ import (
 
 func resourcePolicyAssetAssociation() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePolicyAssetAssociationCreate,
-		Read:   resourcePolicyAssetAssociationRead,
-		Update: nil,
-		Delete: resourcePolicyAssetAssociationDelete,
+		CreateContext: resourcePolicyAssetAssociationCreate,
+		ReadContext:   resourcePolicyAssetAssociationRead,
+		Update:        nil,
+		DeleteContext: resourcePolicyAssetAssociationDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -49,7 +51,7 @@ func resourcePolicyAssetAssociation() *schema.Resource {
 	}
 }
 
-func resourcePolicyAssetAssociationCreate(d *schema.ResourceData, m interface{}) error {
+func resourcePolicyAssetAssociationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	policyID := d.Get("policy_id").(string)
@@ -57,11 +59,11 @@ func resourcePolicyAssetAssociationCreate(d *schema.ResourceData, m interface{})
 	assetType := d.Get("asset_type").(string)
 	currentAccountId := d.Get("account_id").(int)
 
-	err := client.AddPolicyAssetAssociation(policyID, assetID, assetType, &currentAccountId)
+	err := client.AddPolicyAssetAssociation(ctx, policyID, assetID, assetType, &currentAccountId)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not create Incapsula policy asset association: policy ID (%s) - asset ID (%s) - asset type (%s) - %s\n", policyID, assetID, assetType, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Generate synthetic ID
@@ -69,26 +71,32 @@ func resourcePolicyAssetAssociationCreate(d *schema.ResourceData, m interface{})
 	d.SetId(syntheticID)
 	log.Printf("[INFO] Created Incapsula policy asset association with ID: %s - policy ID (%s) - asset ID (%s) - asset type (%s)\n", syntheticID, policyID, assetID, assetType)
 
-	return resourcePolicyAssetAssociationRead(d, m)
+	return resourcePolicyAssetAssociationRead(ctx, d, m)
 }
 
-func resourcePolicyAssetAssociationRead(d *schema.ResourceData, m interface{}) error {
+func resourcePolicyAssetAssociationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	policyID := strings.Split(d.Id(), "/")[0]
 	assetID := strings.Split(d.Id(), "/")[1]
 	assetType := strings.Split(d.Id(), "/")[2]
-	currentAccountId := getCurrentAccountId(d, client.accountStatus)
+	currentAccountId, err := getCurrentAccountId(ctx, d, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	if currentAccountId != nil {
 		log.Printf("[INFO] Trying to read Incapsula Policy Asset Association: %s-%s-%s for account %d\n", policyID, assetID, assetType, *currentAccountId)
 	} else {
 		log.Printf("[INFO] Trying to read Incapsula Policy Asset Association: %s-%s-%s\n", policyID, assetID, assetType)
 	}
-	var isAssociated, err = client.isPolicyAssetAssociated(policyID, assetID, assetType, currentAccountId)
+	isAssociated, err := client.isPolicyAssetAssociated(ctx, policyID, assetID, assetType, currentAccountId)
 
+	if removeGoneResource(d, err, "policy asset association") {
+		return nil
+	}
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula Policy Asset Association: %s-%s-%s, err: %s\n", policyID, assetID, assetType, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	if !isAssociated {
@@ -111,22 +119,25 @@ func resourcePolicyAssetAssociationRead(d *schema.ResourceData, m interface{}) e
 	return nil
 }
 
-func resourcePolicyAssetAssociationDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePolicyAssetAssociationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	policyID := d.Get("policy_id").(string)
 	assetID := d.Get("asset_id").(string)
 	assetType := d.Get("asset_type").(string)
-	currentAccountId := getCurrentAccountId(d, client.accountStatus)
+	currentAccountId, err := getCurrentAccountId(ctx, d, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	if currentAccountId != nil {
 		log.Printf("[INFO] Trying to delete Incapsula Policy Asset Association: %s-%s-%s for account %d\n", policyID, assetID, assetType, *currentAccountId)
 	} else {
 		log.Printf("[INFO] Trying to delete Incapsula Policy Asset Association: %s-%s-%s\n", policyID, assetID, assetType)
 	}
-	err := client.DeletePolicyAssetAssociation(policyID, assetID, assetType, currentAccountId)
+	err = client.DeletePolicyAssetAssociation(ctx, policyID, assetID, assetType, currentAccountId)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
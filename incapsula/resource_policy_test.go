@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -295,7 +296,7 @@ func testCheckIncapsulaPolicyExists(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Policy ID conversion error for %s: %s", policyIDStr, err)
 		}
 		client := testAccProvider.Meta().(*Client)
-		_, err = client.GetPolicy(policyIDStr, nil)
+		_, err = client.GetPolicy(context.Background(), policyIDStr, nil)
 		if err != nil {
 			return fmt.Errorf("Get Incapsula Policy return error %s", err)
 		}
@@ -340,7 +341,7 @@ func testAccCheckIncapsulaPolicyDestroy(state *terraform.State) error {
 			return nil
 		}
 
-		getPolicyResponse, _ := client.GetPolicy(policyId, nil)
+		getPolicyResponse, _ := client.GetPolicy(context.Background(), policyId, nil)
 
 		if getPolicyResponse != nil {
 			return fmt.Errorf("Incapsula Policy with name: %s (id: %d) still exists", getPolicyResponse.Value.Name, getPolicyResponse.Value.ID)
@@ -1,11 +1,13 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -38,12 +40,12 @@ type DeleteSecurityRuleExceptionResponse struct {
 
 func resourceSecurityRuleException() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSecurityRuleExceptionCreate,
-		Read:   resourceSecurityRuleExceptionRead,
-		Update: resourceSecurityRuleExceptionUpdate,
-		Delete: resourceSecurityRuleExceptionDelete,
+		CreateContext: resourceSecurityRuleExceptionCreate,
+		ReadContext:   resourceSecurityRuleExceptionRead,
+		UpdateContext: resourceSecurityRuleExceptionUpdate,
+		DeleteContext: resourceSecurityRuleExceptionDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) != 3 || idSlice[0] == "" || idSlice[1] == "" || idSlice[2] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/rule_id/rule_exception_id", d.Id())
@@ -145,13 +147,13 @@ func resourceSecurityRuleException() *schema.Resource {
 	}
 }
 
-func resourceSecurityRuleExceptionCreate(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityRuleExceptionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	ruleID := d.Get("rule_id").(string)
 
 	log.Printf("[INFO] Configuring Incapsula Security Rule Exception for rule_id (%s) on site_id (%d)\n", ruleID, d.Get("site_id").(int))
-	siteStatusResponse, err := client.AddSecurityRuleException(
+	siteStatusResponse, err := client.AddSecurityRuleException(ctx,
 		d.Get("site_id").(int),
 		ruleID,
 		d.Get("client_app_types").(string),
@@ -165,7 +167,7 @@ func resourceSecurityRuleExceptionCreate(d *schema.ResourceData, m interface{})
 	)
 	if err != nil {
 		log.Printf("[ERROR] Could not create Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the rule exception ID
@@ -173,10 +175,10 @@ func resourceSecurityRuleExceptionCreate(d *schema.ResourceData, m interface{})
 
 	log.Printf("[INFO] Created Incapsula security rule exception for rule_id (%s) on site_id (%d)\n", ruleID, d.Get("site_id").(int))
 
-	return resourceSecurityRuleExceptionRead(d, m)
+	return resourceSecurityRuleExceptionRead(ctx, d, m)
 }
 
-func resourceSecurityRuleExceptionRead(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityRuleExceptionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by reading the SiteResponse for the site
 	client := m.(*Client)
 
@@ -186,18 +188,16 @@ func resourceSecurityRuleExceptionRead(d *schema.ResourceData, m interface{}) er
 
 	log.Printf("[INFO] Reading Incapsula security rule exception whitelist_id (%d) on rule_id (%s) \n", whitelistID, ruleID)
 
-	siteStatusResponse, err := client.ListSecurityRuleExceptions(siteID, ruleID)
+	siteStatusResponse, err := client.ListSecurityRuleExceptions(ctx, siteID, ruleID)
 
 	// Site object may have been deleted
-	if siteStatusResponse != nil && siteStatusResponse.Res.(float64) == 9413 {
-		log.Printf("[INFO] Incapsula Site with ID %s has already been deleted: %s\n", d.Get("site_id"), err)
-		d.SetId("")
+	if removeGoneResource(d, err, "security rule exception") {
 		return nil
 	}
 
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula security rule exception whitelist_id (%d) on rule_id (%s) %s\n", whitelistID, ruleID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Now with the site status, iterate through the rules and find our ID
@@ -265,7 +265,7 @@ func resourceSecurityRuleExceptionRead(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityRuleExceptionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	ruleID := d.Get("rule_id").(string)
@@ -277,7 +277,7 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 	switch ruleID {
 	// ACL RuleIDs
 	case blacklistedCountriesExceptionRuleID:
-		_, err := client.EditSecurityRuleException(
+		_, err := client.EditSecurityRuleException(ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			d.Get("client_app_types").(string),
@@ -292,10 +292,10 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case blacklistedIPsExceptionRuleID:
-		_, err := client.EditSecurityRuleException(
+		_, err := client.EditSecurityRuleException(ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -310,10 +310,10 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case blacklistedURLsExceptionRuleID:
-		_, err := client.EditSecurityRuleException(
+		_, err := client.EditSecurityRuleException(ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -328,10 +328,10 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case backdoorExceptionRuleID:
-		_, err := client.EditSecurityRuleException(
+		_, err := client.EditSecurityRuleException(ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -346,10 +346,10 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case botAccessControlExceptionRuleID:
-		_, err := client.EditSecurityRuleException(
+		_, err := client.EditSecurityRuleException(ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			d.Get("client_app_types").(string),
@@ -364,10 +364,10 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case crossSiteScriptingExceptionRuleID:
-		_, err := client.EditSecurityRuleException(
+		_, err := client.EditSecurityRuleException(ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -382,10 +382,10 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case ddosExceptionRuleID:
-		_, err := client.EditSecurityRuleException(
+		_, err := client.EditSecurityRuleException(ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -400,10 +400,10 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case illegalResourceAccessExceptionRuleID:
-		_, err := client.EditSecurityRuleException(
+		_, err := client.EditSecurityRuleException(ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -418,10 +418,10 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case remoteFileInclusionExceptionRuleID:
-		_, err := client.EditSecurityRuleException(
+		_, err := client.EditSecurityRuleException(ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -436,10 +436,10 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case sqlInjectionExceptionRuleID:
-		_, err := client.EditSecurityRuleException(
+		_, err := client.EditSecurityRuleException(ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -454,7 +454,7 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -463,10 +463,10 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 
 	log.Printf("[INFO] Updated Incapsula security rule exception for rule_id (%s) on site_id (%d)\n", ruleID, d.Get("site_id").(int))
 
-	return resourceWAFSecurityRuleRead(d, m)
+	return resourceWAFSecurityRuleRead(ctx, d, m)
 }
 
-func resourceSecurityRuleExceptionDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityRuleExceptionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	ruleID := d.Get("rule_id").(string)
@@ -474,14 +474,14 @@ func resourceSecurityRuleExceptionDelete(d *schema.ResourceData, m interface{})
 
 	log.Printf("[INFO] Deleting Incapsula security rule exception whitelist_id (%s) for rule_id (%s) on site_id (%d)\n", whitelistID, ruleID, d.Get("site_id").(int))
 
-	err := client.DeleteSecurityRuleException(
+	err := client.DeleteSecurityRuleException(ctx,
 		d.Get("site_id").(int),
 		ruleID,
 		whitelistID,
 	)
 	if err != nil {
 		log.Printf("[ERROR] Could not delete Incapsula security rule exception whitelist_id (%s) for rule_id (%s) on site_id (%d), %s\n", whitelistID, ruleID, d.Get("site_id").(int), err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
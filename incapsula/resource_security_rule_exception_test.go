@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -178,7 +179,7 @@ func testCheckSecurityRuleExceptionExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		siteStatusResponse, err := client.ListSecurityRuleExceptions(siteID, ruleID)
+		siteStatusResponse, err := client.ListSecurityRuleExceptions(context.Background(), siteID, ruleID)
 		if err != nil {
 			return fmt.Errorf("ListSecurityRuleExceptions Error for site_id (%s) and rule_id (%s) %s", siteID, ruleID, err)
 		}
@@ -234,7 +235,7 @@ func testAccCheckSecurityRuleExceptionDestroyBots(state *terraform.State) error
 			return fmt.Errorf("Error parsing siteID %s to int", siteID)
 		}
 
-		siteStatusResponse, _ := client.ListSecurityRuleExceptions(siteID, ruleID)
+		siteStatusResponse, _ := client.ListSecurityRuleExceptions(context.Background(), siteID, ruleID)
 		if siteStatusResponse.SiteID == siteIDInt {
 			return fmt.Errorf("site id (%s) still exists", siteID)
 		}
@@ -261,7 +262,7 @@ func testCheckSecurityRuleExceptionExistsBots(name string) resource.TestCheckFun
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		siteStatusResponse, err := client.ListSecurityRuleExceptions(siteID, ruleID)
+		siteStatusResponse, err := client.ListSecurityRuleExceptions(context.Background(), siteID, ruleID)
 		if err != nil {
 			return fmt.Errorf("ListSecurityRuleExceptions Error for site_id (%s) and rule_id (%s) %s", siteID, ruleID, err)
 		}
@@ -1,8 +1,10 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"strconv"
@@ -11,12 +13,12 @@ import (
 
 func resourceShortRenewalCycle() *schema.Resource {
 	return &schema.Resource{
-		Read:   resourceShortRenewalCycleConfigurationRead,
-		Create: resourceShortRenewalCycleConfigurationCreate,
-		Delete: resourceShortRenewalCycleConfigurationDelete,
-		Update: resourceShortRenewalCycleConfigurationUpdate,
+		ReadContext:   resourceShortRenewalCycleConfigurationRead,
+		CreateContext: resourceShortRenewalCycleConfigurationCreate,
+		DeleteContext: resourceShortRenewalCycleConfigurationDelete,
+		UpdateContext: resourceShortRenewalCycleConfigurationUpdate,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(d.Id(), "/")
 				log.Printf("[DEBUG] Starting to import short renewal cycle. Parameters: %s\n", d.Id())
 				if len(idSlice) < 2 || len(idSlice) > 3 || idSlice[0] == "" || idSlice[1] == "" {
@@ -85,24 +87,27 @@ func resourceShortRenewalCycle() *schema.Resource {
 	}
 }
 
-func resourceShortRenewalCycleConfigurationRead(d *schema.ResourceData, m interface{}) error {
+func resourceShortRenewalCycleConfigurationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
-	shortRenewalCycleConfigurationDto, err := client.GetShortRenewalCycleConfiguration(d.Get("site_id").(string), d.Get("account_id").(string))
+	shortRenewalCycleConfigurationDto, err := client.GetShortRenewalCycleConfiguration(ctx, d.Get("site_id").(string), d.Get("account_id").(string))
 	if err != nil {
-		return err
+		if removeGoneResource(d, err, "short renewal cycle configuration") {
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
 	if shortRenewalCycleConfigurationDto.Errors != nil && len(shortRenewalCycleConfigurationDto.Errors) > 0 {
 		if shortRenewalCycleConfigurationDto.Errors[0].Status != 200 {
 			d.SetId("")
-			return fmt.Errorf("%s", shortRenewalCycleConfigurationDto.Errors[0].Detail)
+			return diag.Errorf("%s", shortRenewalCycleConfigurationDto.Errors[0].Detail)
 		}
 
 		out, err := json.Marshal(shortRenewalCycleConfigurationDto.Errors)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
-		return fmt.Errorf("error getting short renewal cycleconfiguration for site (%s): %s", d.Get("site_id"), string(out))
+		return diag.Errorf("error getting short renewal cycleconfiguration for site (%s): %s", d.Get("site_id"), string(out))
 	}
 
 	d.SetId(d.Get("site_id").(string))
@@ -110,7 +115,7 @@ func resourceShortRenewalCycleConfigurationRead(d *schema.ResourceData, m interf
 	return nil
 }
 
-func resourceShortRenewalCycleConfigurationCreate(d *schema.ResourceData, m interface{}) error {
+func resourceShortRenewalCycleConfigurationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	shortRenewalCycle := d.Get("short_renewal_cycle").(bool)
 	siteId := d.Get("site_id").(string)
 	accountId := d.Get("account_id").(string)
@@ -119,35 +124,35 @@ func resourceShortRenewalCycleConfigurationCreate(d *schema.ResourceData, m inte
 	var err error
 	if shortRenewalCycle {
 		log.Printf("[DEBUG] going to enable short renewal cycle for site %s\n", siteId)
-		shortRenewalCycleConfigurationDto, err = client.EnableShortRenewalCycleConfiguration(siteId, accountId)
+		shortRenewalCycleConfigurationDto, err = client.EnableShortRenewalCycleConfiguration(ctx, siteId, accountId)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if shortRenewalCycleConfigurationDto.Errors != nil && len(shortRenewalCycleConfigurationDto.Errors) > 0 {
 			if shortRenewalCycleConfigurationDto.Errors[0].Status != 200 {
 				d.SetId("")
-				return fmt.Errorf("%s", shortRenewalCycleConfigurationDto.Errors[0].Detail)
+				return diag.Errorf("%s", shortRenewalCycleConfigurationDto.Errors[0].Detail)
 			}
 		}
 	} else {
 		log.Printf("[DEBUG] going to disable short renewal cyclefor site %s\n", siteId)
-		shortRenewalCycleConfigurationDto, err = client.DeleteShortRenewalCycleConfiguration(siteId, accountId)
+		shortRenewalCycleConfigurationDto, err = client.DeleteShortRenewalCycleConfiguration(ctx, siteId, accountId)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if shortRenewalCycleConfigurationDto != nil && shortRenewalCycleConfigurationDto.Errors != nil && len(shortRenewalCycleConfigurationDto.Errors) > 0 {
 		if shortRenewalCycleConfigurationDto.Errors[0].Status != 200 {
 			d.SetId("")
-			return fmt.Errorf("%s", shortRenewalCycleConfigurationDto.Errors[0].Detail)
+			return diag.Errorf("%s", shortRenewalCycleConfigurationDto.Errors[0].Detail)
 		}
 
 		out, err := json.Marshal(shortRenewalCycleConfigurationDto.Errors)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
-		return fmt.Errorf("error getting create short renewal cycleconfiguration for site (%s): %s", d.Get("site_id"), string(out))
+		return diag.Errorf("error getting create short renewal cycleconfiguration for site (%s): %s", d.Get("site_id"), string(out))
 	}
 
 	d.SetId(siteId)
@@ -155,19 +160,19 @@ func resourceShortRenewalCycleConfigurationCreate(d *schema.ResourceData, m inte
 	return nil
 }
 
-func resourceShortRenewalCycleConfigurationDelete(d *schema.ResourceData, m interface{}) error {
+func resourceShortRenewalCycleConfigurationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteId := d.Get("site_id").(string)
 	accountId := d.Get("account_id").(string)
-	shortRenewalCycleConfigurationDto, err := client.DeleteShortRenewalCycleConfiguration(siteId, accountId)
+	shortRenewalCycleConfigurationDto, err := client.DeleteShortRenewalCycleConfiguration(ctx, siteId, accountId)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if shortRenewalCycleConfigurationDto != nil && shortRenewalCycleConfigurationDto.Errors != nil && len(shortRenewalCycleConfigurationDto.Errors) > 0 {
 		if shortRenewalCycleConfigurationDto.Errors[0].Status != 200 {
 			d.SetId("")
-			return fmt.Errorf("%s", shortRenewalCycleConfigurationDto.Errors[0].Detail)
+			return diag.Errorf("%s", shortRenewalCycleConfigurationDto.Errors[0].Detail)
 		}
 	}
 
@@ -176,6 +181,6 @@ func resourceShortRenewalCycleConfigurationDelete(d *schema.ResourceData, m inte
 	return nil
 }
 
-func resourceShortRenewalCycleConfigurationUpdate(d *schema.ResourceData, m interface{}) error {
-	return resourceShortRenewalCycleConfigurationCreate(d, m)
+func resourceShortRenewalCycleConfigurationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceShortRenewalCycleConfigurationCreate(ctx, d, m)
 }
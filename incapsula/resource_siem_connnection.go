@@ -1,9 +1,11 @@
 package incapsula
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"strings"
@@ -15,12 +17,12 @@ const sensitiveDataPlaceholder = "Sensitive data placeholder"
 
 func resourceSiemConnection() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSiemConnectionCreate,
-		Read:   resourceSiemConnectionRead,
-		Update: resourceSiemConnectionUpdate,
-		Delete: resourceSiemConnectionDelete,
+		CreateContext: resourceSiemConnectionCreate,
+		ReadContext:   resourceSiemConnectionRead,
+		UpdateContext: resourceSiemConnectionUpdate,
+		DeleteContext: resourceSiemConnectionDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected account_id/connection_id", d.Id())
@@ -130,14 +132,14 @@ func siemConnectionResourceValidation(d *schema.ResourceData) error {
 	}
 	return nil
 }
-func resourceSiemConnectionCreate(d *schema.ResourceData, m interface{}) error {
+func resourceSiemConnectionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	resErr := siemConnectionResourceValidation(d)
 	if resErr != nil {
-		return resErr
+		return diag.FromErr(resErr)
 	}
 
 	client := m.(*Client)
-	response, statusCode, err := client.CreateSiemConnection(&SiemConnection{Data: []SiemConnectionData{{
+	response, statusCode, err := client.CreateSiemConnection(ctx, &SiemConnection{Data: []SiemConnectionData{{
 		AssetID:        d.Get("account_id").(string),
 		ConnectionName: d.Get("connection_name").(string),
 		StorageType:    d.Get("storage_type").(string),
@@ -148,28 +150,28 @@ func resourceSiemConnectionCreate(d *schema.ResourceData, m interface{}) error {
 		},
 	}}})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if (*statusCode == 201) && (response != nil) && (len(response.Data) == 1) {
 		d.SetId(response.Data[0].ID)
-		return resourceSiemConnectionRead(d, m)
+		return resourceSiemConnectionRead(ctx, d, m)
 	} else {
-		return fmt.Errorf("[ERROR] Unsupported operation. Response status code: %d", *statusCode)
+		return diag.Errorf("[ERROR] Unsupported operation. Response status code: %d", *statusCode)
 	}
 }
 
-func resourceSiemConnectionRead(d *schema.ResourceData, m interface{}) error {
+func resourceSiemConnectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
-	response, statusCode, err := client.ReadSiemConnection(d.Id(), d.Get("account_id").(string))
-	if err != nil {
-		return err
-	}
+	response, statusCode, err := client.ReadSiemConnection(ctx, d.Id(), d.Get("account_id").(string))
 	// If the connection is deleted on the server, blow it out locally and run through the normal TF cycle
-	if *statusCode == 404 {
-		d.SetId("")
+	if removeGoneResource(d, err, "SIEM connection") {
 		return nil
-	} else if (*statusCode == 200) && (response != nil) && (len(response.Data) == 1) {
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if (*statusCode == 200) && (response != nil) && (len(response.Data) == 1) {
 		var connection = response.Data[0]
 		d.Set("account_id", connection.AssetID)
 		d.Set("connection_name", connection.ConnectionName)
@@ -182,18 +184,18 @@ func resourceSiemConnectionRead(d *schema.ResourceData, m interface{}) error {
 		d.Set("path", connection.ConnectionInfo.(S3ConnectionInfo).Path)
 		return nil
 	} else {
-		return fmt.Errorf("[ERROR] Unsupported operation. Response status code: %d", *statusCode)
+		return diag.Errorf("[ERROR] Unsupported operation. Response status code: %d", *statusCode)
 	}
 }
 
-func resourceSiemConnectionUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSiemConnectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	resErr := siemConnectionResourceValidation(d)
 	if resErr != nil {
-		return resErr
+		return diag.FromErr(resErr)
 	}
 
 	client := m.(*Client)
-	_, _, err := client.UpdateSiemConnection(&SiemConnection{Data: []SiemConnectionData{{
+	_, _, err := client.UpdateSiemConnection(ctx, &SiemConnection{Data: []SiemConnectionData{{
 		ID:             d.Id(),
 		AssetID:        d.Get("account_id").(string),
 		ConnectionName: d.Get("connection_name").(string),
@@ -206,20 +208,20 @@ func resourceSiemConnectionUpdate(d *schema.ResourceData, m interface{}) error {
 	}}})
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
 
-func resourceSiemConnectionDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSiemConnectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	ID := d.Id()
 	accountId := d.Get("account_id").(string)
 
-	_, err := client.DeleteSiemConnection(ID, accountId)
+	_, err := client.DeleteSiemConnection(ctx, ID, accountId)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
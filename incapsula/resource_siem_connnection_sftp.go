@@ -1,9 +1,11 @@
 package incapsula
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"strings"
 )
@@ -12,12 +14,12 @@ const StorageTypeCustomerSftp = "CUSTOMER_SFTP"
 
 func resourceSiemSftpConnection() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSiemSftpConnectionCreate,
-		Read:   resourceSiemSftpConnectionRead,
-		Update: resourceSiemSftpConnectionUpdate,
-		Delete: resourceSiemSftpConnectionDelete,
+		CreateContext: resourceSiemSftpConnectionCreate,
+		ReadContext:   resourceSiemSftpConnectionRead,
+		UpdateContext: resourceSiemSftpConnectionUpdate,
+		DeleteContext: resourceSiemSftpConnectionDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected account_id/connection_id", d.Id())
@@ -109,14 +111,14 @@ func siemSftpConnectionResourceValidation(d *schema.ResourceData) error {
 	}
 	return nil
 }
-func resourceSiemSftpConnectionCreate(d *schema.ResourceData, m interface{}) error {
+func resourceSiemSftpConnectionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	resErr := siemSftpConnectionResourceValidation(d)
 	if resErr != nil {
-		return resErr
+		return diag.FromErr(resErr)
 	}
 
 	client := m.(*Client)
-	response, statusCode, err := client.CreateSiemConnection(&SiemConnection{Data: []SiemConnectionData{{
+	response, statusCode, err := client.CreateSiemConnection(ctx, &SiemConnection{Data: []SiemConnectionData{{
 		AssetID:        d.Get("account_id").(string),
 		ConnectionName: d.Get("connection_name").(string),
 		StorageType:    StorageTypeCustomerSftp,
@@ -128,28 +130,28 @@ func resourceSiemSftpConnectionCreate(d *schema.ResourceData, m interface{}) err
 		},
 	}}})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if (*statusCode == 201) && (response != nil) && (len(response.Data) == 1) {
 		d.SetId(response.Data[0].ID)
-		return resourceSiemSftpConnectionRead(d, m)
+		return resourceSiemSftpConnectionRead(ctx, d, m)
 	} else {
-		return fmt.Errorf("[ERROR] Unsupported operation. Response status code: %d", *statusCode)
+		return diag.Errorf("[ERROR] Unsupported operation. Response status code: %d", *statusCode)
 	}
 }
 
-func resourceSiemSftpConnectionRead(d *schema.ResourceData, m interface{}) error {
+func resourceSiemSftpConnectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
-	response, statusCode, err := client.ReadSiemConnection(d.Id(), d.Get("account_id").(string))
-	if err != nil {
-		return err
-	}
+	response, statusCode, err := client.ReadSiemConnection(ctx, d.Id(), d.Get("account_id").(string))
 	// If the connection is deleted on the server, blow it out locally and run through the normal TF cycle
-	if *statusCode == 404 {
-		d.SetId("")
+	if removeGoneResource(d, err, "SIEM connection") {
 		return nil
-	} else if (*statusCode == 200) && (response != nil) && (len(response.Data) == 1) {
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if (*statusCode == 200) && (response != nil) && (len(response.Data) == 1) {
 		var connection = response.Data[0]
 		d.Set("account_id", connection.AssetID)
 		d.Set("connection_name", connection.ConnectionName)
@@ -160,18 +162,18 @@ func resourceSiemSftpConnectionRead(d *schema.ResourceData, m interface{}) error
 		d.Set("input_hash", connectionInfo.Password)
 		return nil
 	} else {
-		return fmt.Errorf("[ERROR] Unsupported operation. Response status code: %d", *statusCode)
+		return diag.Errorf("[ERROR] Unsupported operation. Response status code: %d", *statusCode)
 	}
 }
 
-func resourceSiemSftpConnectionUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSiemSftpConnectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	resErr := siemSftpConnectionResourceValidation(d)
 	if resErr != nil {
-		return resErr
+		return diag.FromErr(resErr)
 	}
 
 	client := m.(*Client)
-	_, _, err := client.UpdateSiemConnection(&SiemConnection{Data: []SiemConnectionData{{
+	_, _, err := client.UpdateSiemConnection(ctx, &SiemConnection{Data: []SiemConnectionData{{
 		ID:             d.Id(),
 		AssetID:        d.Get("account_id").(string),
 		ConnectionName: d.Get("connection_name").(string),
@@ -185,20 +187,20 @@ func resourceSiemSftpConnectionUpdate(d *schema.ResourceData, m interface{}) err
 	}}})
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
 
-func resourceSiemSftpConnectionDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSiemSftpConnectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	ID := d.Id()
 	accountId := d.Get("account_id").(string)
 
-	_, err := client.DeleteSiemConnection(ID, accountId)
+	_, err := client.DeleteSiemConnection(ctx, ID, accountId)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
@@ -1,9 +1,11 @@
 package incapsula
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"strings"
 )
@@ -12,12 +14,12 @@ const StorageTypeCustomerSplunk = "CUSTOMER_SPLUNK"
 
 func resourceSiemSplunkConnection() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSiemSplunkConnectionCreate,
-		Read:   resourceSiemSplunkConnectionRead,
-		Update: resourceSiemSplunkConnectionUpdate,
-		Delete: resourceSiemSplunkConnectionDelete,
+		CreateContext: resourceSiemSplunkConnectionCreate,
+		ReadContext:   resourceSiemSplunkConnectionRead,
+		UpdateContext: resourceSiemSplunkConnectionUpdate,
+		DeleteContext: resourceSiemSplunkConnectionDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected account_id/connection_id", d.Id())
@@ -108,14 +110,14 @@ func siemSplunkConnectionResourceValidation(d *schema.ResourceData) error {
 	}
 	return nil
 }
-func resourceSiemSplunkConnectionCreate(d *schema.ResourceData, m interface{}) error {
+func resourceSiemSplunkConnectionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	resErr := siemSplunkConnectionResourceValidation(d)
 	if resErr != nil {
-		return resErr
+		return diag.FromErr(resErr)
 	}
 
 	client := m.(*Client)
-	response, statusCode, err := client.CreateSiemConnection(&SiemConnection{Data: []SiemConnectionData{{
+	response, statusCode, err := client.CreateSiemConnection(ctx, &SiemConnection{Data: []SiemConnectionData{{
 		AssetID:        d.Get("account_id").(string),
 		ConnectionName: d.Get("connection_name").(string),
 		StorageType:    StorageTypeCustomerSplunk,
@@ -127,28 +129,28 @@ func resourceSiemSplunkConnectionCreate(d *schema.ResourceData, m interface{}) e
 		},
 	}}})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if (*statusCode == 201) && (response != nil) && (len(response.Data) == 1) {
 		d.SetId(response.Data[0].ID)
-		return resourceSiemSplunkConnectionRead(d, m)
+		return resourceSiemSplunkConnectionRead(ctx, d, m)
 	} else {
-		return fmt.Errorf("[ERROR] Unsupported operation. Response status code: %d", *statusCode)
+		return diag.Errorf("[ERROR] Unsupported operation. Response status code: %d", *statusCode)
 	}
 }
 
-func resourceSiemSplunkConnectionRead(d *schema.ResourceData, m interface{}) error {
+func resourceSiemSplunkConnectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
-	response, statusCode, err := client.ReadSiemConnection(d.Id(), d.Get("account_id").(string))
-	if err != nil {
-		return err
-	}
+	response, statusCode, err := client.ReadSiemConnection(ctx, d.Id(), d.Get("account_id").(string))
 	// If the connection is deleted on the server, blow it out locally and run through the normal TF cycle
-	if *statusCode == 404 {
-		d.SetId("")
+	if removeGoneResource(d, err, "SIEM connection") {
 		return nil
-	} else if (*statusCode == 200) && (response != nil) && (len(response.Data) == 1) {
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if (*statusCode == 200) && (response != nil) && (len(response.Data) == 1) {
 		var connection = response.Data[0]
 		d.Set("account_id", connection.AssetID)
 		d.Set("connection_name", connection.ConnectionName)
@@ -160,18 +162,18 @@ func resourceSiemSplunkConnectionRead(d *schema.ResourceData, m interface{}) err
 		d.Set("input_hash", connectionInfo.Token)
 		return nil
 	} else {
-		return fmt.Errorf("[ERROR] Unsupported operation. Response status code: %d", *statusCode)
+		return diag.Errorf("[ERROR] Unsupported operation. Response status code: %d", *statusCode)
 	}
 }
 
-func resourceSiemSplunkConnectionUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSiemSplunkConnectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	resErr := siemSplunkConnectionResourceValidation(d)
 	if resErr != nil {
-		return resErr
+		return diag.FromErr(resErr)
 	}
 
 	client := m.(*Client)
-	_, _, err := client.UpdateSiemConnection(&SiemConnection{Data: []SiemConnectionData{{
+	_, _, err := client.UpdateSiemConnection(ctx, &SiemConnection{Data: []SiemConnectionData{{
 		ID:             d.Id(),
 		AssetID:        d.Get("account_id").(string),
 		ConnectionName: d.Get("connection_name").(string),
@@ -185,20 +187,20 @@ func resourceSiemSplunkConnectionUpdate(d *schema.ResourceData, m interface{}) e
 	}}})
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
 
-func resourceSiemSplunkConnectionDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSiemSplunkConnectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	ID := d.Id()
 	accountId := d.Get("account_id").(string)
 
-	_, err := client.DeleteSiemConnection(ID, accountId)
+	_, err := client.DeleteSiemConnection(ctx, ID, accountId)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -172,7 +173,7 @@ func getAccIncapsulaS3SiemConnectionConfigBasic(s3SiemConnectionName string) str
 
 func testAccReadSiemConnection(client *Client, ID string, accountId string) error {
 	log.Printf("[INFO] SiemConnection ID: %s, accountId: %s", ID, accountId)
-	siemConnection, statusCode, err := client.ReadSiemConnection(ID, accountId)
+	siemConnection, statusCode, err := client.ReadSiemConnection(context.Background(), ID, accountId)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"testing"
@@ -229,7 +230,7 @@ func getAccIncapsulaSiemLogConfigurationConfigBasic(siemLogConfigurationName str
 
 func testAccReadSiemLogConfiguration(client *Client, ID string, accountId string) error {
 	log.Printf("[INFO] SiemLogConfiguration ID: %s", ID)
-	siemLogConfiguration, statusCode, err := client.ReadSiemLogConfiguration(ID, accountId)
+	siemLogConfiguration, statusCode, err := client.ReadSiemLogConfiguration(context.Background(), ID, accountId)
 	if err != nil {
 		return err
 	}
@@ -19,7 +19,7 @@ func resourceSimplifiedRedirectRulesConfiguration() *schema.Resource {
 		UpdateContext: resourceSimplifiedRedirectRulesConfigurationUpdate,
 		DeleteContext: resourceSimplifiedRedirectRulesConfigurationDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(data.Id(), "/")
 				if len(idSlice) != 1 || idSlice[0] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id", data.Id())
@@ -93,11 +93,11 @@ func resourceSimplifiedRedirectRulesConfigurationUpdate(ctx context.Context, dat
 		RulesList: createSimplifiedRedirectRulesListFromState(data),
 	}
 
-	simplifiedRediectRulesListDTO, diags := client.UpdateDeliveryRuleConfiguration(siteID, "SIMPLIFIED_REDIRECT", &rulesListDTO)
+	simplifiedRediectRulesListDTO, err := client.UpdateDeliveryRuleConfiguration(ctx, siteID, "SIMPLIFIED_REDIRECT", &rulesListDTO)
 
-	if diags != nil && diags.HasError() {
+	if err != nil {
 		log.Printf("[ERROR] Failed to update delivery rules of category SIMPLIFIED_REDIRECT for Site ID %s", siteID)
-		return diags
+		return diag.FromErr(err)
 	} else if simplifiedRediectRulesListDTO.Errors != nil {
 		errors, _ := json.Marshal(simplifiedRediectRulesListDTO.Errors)
 		log.Printf("[ERROR] Failed to update delivery rules of category SIMPLIFIED_REDIRECT for Site ID %s: %s", siteID, string(errors[:]))
@@ -108,8 +108,7 @@ func resourceSimplifiedRedirectRulesConfigurationUpdate(ctx context.Context, dat
 		}}
 	}
 
-	diags = append(diags, resourceSimplifiedRedirectRulesConfigurationRead(ctx, data, m)[:]...)
-	return diags
+	return resourceSimplifiedRedirectRulesConfigurationRead(ctx, data, m)
 }
 
 func resourceSimplifiedRedirectRulesConfigurationRead(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -117,18 +116,16 @@ func resourceSimplifiedRedirectRulesConfigurationRead(ctx context.Context, data
 	client := m.(*Client)
 	siteID := data.Get("site_id").(string)
 
-	simplifiedRediectRulesListDTO, diags := client.ReadDeliveryRuleConfiguration(siteID, "SIMPLIFIED_REDIRECT")
+	simplifiedRediectRulesListDTO, err := client.ReadDeliveryRuleConfiguration(ctx, siteID, "SIMPLIFIED_REDIRECT")
 
 	fmt.Println(simplifiedRediectRulesListDTO)
 
-	if simplifiedRediectRulesListDTO != nil && simplifiedRediectRulesListDTO.Errors != nil && simplifiedRediectRulesListDTO.Errors[0].Status == 404 {
-		log.Printf("[INFO] Incapsula Site with ID %s has already been deleted\n", data.Get("site_id"))
-		data.SetId("")
-		return nil
-	}
-	if diags != nil && diags.HasError() {
+	if err != nil {
+		if removeGoneResource(data, err, "simplified redirect rules configuration") {
+			return nil
+		}
 		log.Printf("[ERROR] Failed to read delivery rules in category SIMPLIFIED_REDIRECT for Site ID %s", siteID)
-		return diags
+		return diag.FromErr(err)
 	}
 
 	data.Set("rule", serializeSimplifiedRedirectRule(data, *simplifiedRediectRulesListDTO))
@@ -155,21 +152,20 @@ func serializeSimplifiedRedirectRule(data *schema.ResourceData, DeliveryRule Del
 
 func resourceSimplifiedRedirectRulesConfigurationDelete(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
-	var diags diag.Diagnostics
 	siteID := data.Get("site_id").(string)
 
 	emptyRulesList := DeliveryRulesListDTO{
 		RulesList: []DeliveryRuleDto{},
 	}
 
-	_, diags = client.UpdateDeliveryRuleConfiguration(siteID, "SIMPLIFIED_REDIRECT", &emptyRulesList)
-	if diags != nil && diags.HasError() {
+	_, err := client.UpdateDeliveryRuleConfiguration(ctx, siteID, "SIMPLIFIED_REDIRECT", &emptyRulesList)
+	if err != nil {
 		log.Printf("[ERROR] Failed to delete delivery rules in category SIMPLIFIED_REDIRECT for Site ID %s", siteID)
-		return diags
+		return diag.FromErr(err)
 	}
 
 	data.SetId("")
-	return diags
+	return nil
 }
 
 func createSimplifiedRedirectRulesListFromState(data *schema.ResourceData) []DeliveryRuleDto {
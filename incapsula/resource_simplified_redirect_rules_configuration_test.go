@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
@@ -113,7 +114,7 @@ func testAccCheckIncapsulaDeliverySimplifiedRedirectRuleDestroy(state *terraform
 			return fmt.Errorf("Incapsula Site ID does not exist")
 		}
 
-		deliveryRulesListDTO, diags := client.ReadDeliveryRuleConfiguration(siteID, category)
+		deliveryRulesListDTO, readErr := client.ReadDeliveryRuleConfiguration(context.Background(), siteID, category)
 
 		// If the site has already been deleted then return nil
 		// Otherwise check the delivery rules list
@@ -121,12 +122,12 @@ func testAccCheckIncapsulaDeliverySimplifiedRedirectRuleDestroy(state *terraform
 		if err != nil {
 			return fmt.Errorf("Couldn't parse site ID: %s ", siteID)
 		}
-		_, siteStatusErr := client.SiteStatus(domain, siteIdInt)
+		_, siteStatusErr := client.SiteStatus(context.Background(), domain, siteIdInt)
 		if siteStatusErr != nil {
 			return nil
 		}
 
-		if diags != nil {
+		if readErr != nil {
 			log.Printf("[ERROR] Failed to read simplified redirect rules in category %s for Site ID %s", category, siteID)
 			return fmt.Errorf("failed to read simplified redirect rules in category %s for Site ID %s\"", category, siteID)
 		}
@@ -235,12 +236,12 @@ func testCheckSimplifiedRedirectRuleExists(name string, numRules int) resource.T
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		deliveryRulesListDTO, diags := client.ReadDeliveryRuleConfiguration(siteID, "SIMPLIFIED_REDIRECT")
+		deliveryRulesListDTO, err := client.ReadDeliveryRuleConfiguration(context.Background(), siteID, "SIMPLIFIED_REDIRECT")
 
 		if !ok {
 			return fmt.Errorf("Rule category : %s ,does not exist for Site ID is : SIMPLIFIED_REDIRECT ", siteID)
 		}
-		if diags != nil {
+		if err != nil {
 			return fmt.Errorf("Incapsula Delivery Rule: SIMPLIFIED_REDIRECT (site id: %s) returned error", siteID)
 		}
 		if deliveryRulesListDTO.RulesList == nil || len(deliveryRulesListDTO.RulesList) != numRules {
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -20,10 +21,10 @@ const sleep_before_retry_seconds = 3
 
 func resourceSite() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSiteCreate,
-		Read:   resourceSiteRead,
-		Update: resourceSiteUpdate,
-		Delete: resourceSiteDelete,
+		CreateContext: resourceSiteCreate,
+		ReadContext:   resourceSiteRead,
+		UpdateContext: resourceSiteUpdate,
+		DeleteContext: resourceSiteDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -427,16 +428,16 @@ func suppressEquivalentStringDiffsPlusDeprecated() func(k string, old string, ne
 	}
 }
 
-func resourceSiteCreate(d *schema.ResourceData, m interface{}) error {
+func resourceSiteCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	if d.Get("deprecated").(bool) {
-		return fmt.Errorf("cannot create deprecated resource")
+		return diag.Errorf("cannot create deprecated resource")
 	}
 	client := m.(*Client)
 	domain := d.Get("domain").(string)
 
 	log.Printf("[INFO] Creating Incapsula site for domain: %s\n", domain)
 
-	siteAddResponse, err := client.AddSite(
+	siteAddResponse, err := client.AddSite(ctx,
 		domain,
 		d.Get("ref_id").(string),
 		d.Get("send_site_setup_emails").(string),
@@ -450,7 +451,7 @@ func resourceSiteCreate(d *schema.ResourceData, m interface{}) error {
 
 	if err != nil {
 		log.Printf("[ERROR] Could not create Incapsula site for domain: %s, %s\n", domain, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the Site ID
@@ -459,38 +460,40 @@ func resourceSiteCreate(d *schema.ResourceData, m interface{}) error {
 
 	// There may be a timing/race condition here
 	// Set an arbitrary period to sleep
-	time.Sleep(sleep_before_update_seconds * time.Second)
+	if err := sleepWithContext(ctx, sleep_before_update_seconds*time.Second); err != nil {
+		return diag.FromErr(err)
+	}
 
-	err = updateAdditionalSiteProperties(create_retries, client, d)
+	err = updateAdditionalSiteProperties(ctx, create_retries, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = updateDataStorageRegion(client, d)
+	err = updateDataStorageRegion(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = updateMaskingSettings(client, d)
+	err = updateMaskingSettings(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = updateLogLevel(client, d)
+	err = updateLogLevel(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = updatePerformanceSettings(client, d)
+	err = updatePerformanceSettings(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the rest of the state from the resource read
-	return resourceSiteRead(d, m)
+	return resourceSiteRead(ctx, d, m)
 }
 
-func resourceSiteRead(d *schema.ResourceData, m interface{}) error {
+func resourceSiteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	if d.Get("deprecated").(bool) {
 		fmt.Printf("[WARN] Resource incapsule_site for domain %s is deprecated. Any future changes will be ignored.\n", d.Get("domain").(string))
 		return nil
@@ -502,18 +505,16 @@ func resourceSiteRead(d *schema.ResourceData, m interface{}) error {
 
 	log.Printf("[INFO] Reading Incapsula site for domain: %s\n", domain)
 
-	siteStatusResponse, err := client.SiteStatus(domain, siteID)
+	siteStatusResponse, err := client.SiteStatus(ctx, domain, siteID)
 
 	// Site object may have been deleted
-	if siteStatusResponse != nil && siteStatusResponse.Res.(float64) == 9413 {
-		log.Printf("[INFO] Incapsula Site ID %d has already been deleted: %s\n", siteID, err)
-		d.SetId("")
+	if removeGoneResource(d, err, "site") {
 		return nil
 	}
 
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula site for domain: %s, %s\n", domain, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("site_creation_date", siteStatusResponse.SiteCreationDate)
@@ -570,27 +571,27 @@ func resourceSiteRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	// Get the data storage region for the site
-	dataStorageRegionResponse, err := client.GetDataStorageRegion(d.Id())
+	dataStorageRegionResponse, err := client.GetDataStorageRegion(ctx, d.Id())
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula site data storage region for domain: %s and site id: %d, %s\n", domain, siteID, err)
-		return err
+		return diag.FromErr(err)
 	}
 	d.Set("data_storage_region", dataStorageRegionResponse.Region)
 
 	// Get the masking settings for the site
-	maskingResponse, err := client.GetMaskingSettings(d.Id())
+	maskingResponse, err := client.GetMaskingSettings(ctx, d.Id())
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula site masking settings for domain: %s and site id: %d, %s\n", domain, siteID, err)
-		return err
+		return diag.FromErr(err)
 	}
 	d.Set("hashing_enabled", maskingResponse.HashingEnabled)
 	d.Set("hash_salt", maskingResponse.HashSalt)
 
 	// Get the performance settings for the site
-	performanceSettingsResponse, err := client.GetPerformanceSettings(d.Id())
+	performanceSettingsResponse, err := client.GetPerformanceSettings(ctx, d.Id())
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula site peformance settings for domain: %s and site id: %d, %s\n", domain, siteID, err)
-		return err
+		return diag.FromErr(err)
 	}
 	d.Set("perf_client_comply_no_cache", performanceSettingsResponse.ClientSide.ComplyNoCache)
 	d.Set("perf_client_enable_client_side_caching", performanceSettingsResponse.ClientSide.EnableClientSideCaching)
@@ -615,26 +616,26 @@ func resourceSiteRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("perf_ttl_use_shortest_caching", performanceSettingsResponse.TTL.UseShortestCaching)
 
 	// Get the original data center ID (the first in the list of associated data centers)
-	dcsConfDTO, err := client.GetDataCentersConfiguration(d.Id())
+	dcsConfDTO, err := client.GetDataCentersConfiguration(ctx, d.Id())
 	if err != nil || len(dcsConfDTO.Data) == 0 || len(dcsConfDTO.Data[0].DataCenters) == 0 {
 		log.Printf("[ERROR] Could not read Incapsula data centers for domain: %s and site id: %d, %s\n", domain, siteID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	if len(dcsConfDTO.Data[0].DataCenters[0].OriginServers) == 0 {
 		log.Printf("[ERROR] Could not read Incapsula data center servers for domain: %s and site id: %d, %s\n", domain, siteID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	dataCenterID := dcsConfDTO.Data[0].DataCenters[0].ID
 	if dataCenterID == nil {
-		return fmt.Errorf("[ERROR] Incapsula Data Center missing for Site ID %s", d.Get("site_id"))
+		return diag.Errorf("[ERROR] Incapsula Data Center missing for Site ID %s", d.Get("site_id"))
 	}
 	d.Set("original_data_center_id", *dataCenterID)
 
 	siteIP := dcsConfDTO.Data[0].DataCenters[0].OriginServers[0].Address
 	if siteIP == "" {
-		return fmt.Errorf("[ERROR] Incapsula Data Center missing server address for Site ID %s", d.Get("site_id"))
+		return diag.Errorf("[ERROR] Incapsula Data Center missing server address for Site ID %s", d.Get("site_id"))
 	}
 
 	if d.IsNewResource() || d.Get("site_ip") == "" {
@@ -646,43 +647,43 @@ func resourceSiteRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSiteUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSiteUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	if d.Get("deprecated").(bool) {
 		return nil
 	}
 
 	client := m.(*Client)
 
-	err := updateAdditionalSiteProperties(update_retries, client, d)
+	err := updateAdditionalSiteProperties(ctx, update_retries, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = updateDataStorageRegion(client, d)
+	err = updateDataStorageRegion(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = updateMaskingSettings(client, d)
+	err = updateMaskingSettings(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = updateLogLevel(client, d)
+	err = updateLogLevel(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = updatePerformanceSettings(client, d)
+	err = updatePerformanceSettings(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the rest of the state from the resource read
-	return resourceSiteRead(d, m)
+	return resourceSiteRead(ctx, d, m)
 }
 
-func resourceSiteDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSiteDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	if d.Get("deprecated").(bool) {
 		d.SetId("")
 		return nil
@@ -694,11 +695,11 @@ func resourceSiteDelete(d *schema.ResourceData, m interface{}) error {
 
 	log.Printf("[INFO] Deleting Incapsula site for domain: %s\n", domain)
 
-	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
-		err := client.DeleteSite(domain, siteID)
+	return diag.FromErr(resource.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		err := client.DeleteSite(ctx, domain, siteID)
 
 		if err != nil {
-			return resource.RetryableError(fmt.Errorf("Error deleting site (%s) for domain %s: %s", d.Id(), domain, err))
+			return resource.RetryableError(fmt.Errorf("Error deleting site (%s) for domain %s: %w", d.Id(), domain, err))
 		}
 
 		log.Printf("[INFO] Deleted site (%s) for domain %s\n", d.Id(), domain)
@@ -708,24 +709,26 @@ func resourceSiteDelete(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 
 		return nil
-	})
+	}))
 }
 
-func updateAdditionalSiteProperties(retries int, client *Client, d *schema.ResourceData) error {
+func updateAdditionalSiteProperties(ctx context.Context, retries int, client *Client, d *schema.ResourceData) error {
 	updateParams := [12]string{"acceleration_level", "active", "approver", "domain_redirect_to_full", "domain_validation", "ignore_ssl", "remove_ssl", "ref_id", "seal_location", "restricted_cname_reuse", "naked_domain_san", "wildcard_san"}
 	retryCounter := 1
-	return resource.Retry(d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
+	return resource.RetryContext(ctx, d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
 		for i := 0; i < len(updateParams); i++ {
 			param := updateParams[i]
 
 			if d.HasChange(param) && d.Get(param) != "" {
 				value := fmt.Sprintf("%v", d.Get(param))
 				log.Printf("[INFO] Updating Incapsula site param (%s) with value (%s) for site_id: %s\n", param, value, d.Id())
-				_, err := client.UpdateSite(d.Id(), param, value)
+				_, err := client.UpdateSite(ctx, d.Id(), param, value)
 				if err != nil {
 					if retryCounter <= retries && strings.Contains(err.Error(), "Add site operation") {
 						log.Printf("[INFO] retry number %d/%d to update Incapsula site param (%s) for site_id: %s\n", retryCounter, retries, param, d.Id())
-						time.Sleep(sleep_before_retry_seconds * time.Second)
+						if err := sleepWithContext(ctx, sleep_before_retry_seconds*time.Second); err != nil {
+							return resource.NonRetryableError(err)
+						}
 						retryCounter++
 						return resource.RetryableError(err)
 					}
@@ -738,10 +741,10 @@ func updateAdditionalSiteProperties(retries int, client *Client, d *schema.Resou
 	})
 }
 
-func updateDataStorageRegion(client *Client, d *schema.ResourceData) error {
+func updateDataStorageRegion(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	if d.HasChange("data_storage_region") {
 		dataStorageRegion := d.Get("data_storage_region").(string)
-		_, err := client.UpdateDataStorageRegion(d.Id(), dataStorageRegion)
+		_, err := client.UpdateDataStorageRegion(ctx, d.Id(), dataStorageRegion)
 		if err != nil {
 			log.Printf("[ERROR] Could not set Incapsula site data storage region with value (%s) for site_id: %s %s\n", dataStorageRegion, d.Id(), err)
 			return err
@@ -750,12 +753,12 @@ func updateDataStorageRegion(client *Client, d *schema.ResourceData) error {
 	return nil
 }
 
-func updateMaskingSettings(client *Client, d *schema.ResourceData) error {
+func updateMaskingSettings(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	if d.HasChange("hashing_enabled") || d.HasChange("hash_salt") {
 		hashingEnabled := d.Get("hashing_enabled").(bool)
 		hashSalt := d.Get("hash_salt").(string)
 		maskingSettings := MaskingSettings{HashingEnabled: hashingEnabled, HashSalt: hashSalt}
-		err := client.UpdateMaskingSettings(d.Id(), &maskingSettings)
+		err := client.UpdateMaskingSettings(ctx, d.Id(), &maskingSettings)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula site masking settings for site_id: %s %s\n", d.Id(), err)
 			return err
@@ -764,12 +767,12 @@ func updateMaskingSettings(client *Client, d *schema.ResourceData) error {
 	return nil
 }
 
-func updateLogLevel(client *Client, d *schema.ResourceData) error {
+func updateLogLevel(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	if d.HasChange("log_level") ||
 		d.HasChange("logs_account_id") {
 		logLevel := d.Get("log_level").(string)
 		logsAccountId := d.Get("logs_account_id").(string)
-		err := client.UpdateLogLevel(d.Id(), logLevel, logsAccountId)
+		err := client.UpdateLogLevel(ctx, d.Id(), logLevel, logsAccountId)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula site log level: %s and logs account id: %s for site_id: %s %s\n", logLevel, logsAccountId, d.Id(), err)
 			return err
@@ -778,7 +781,7 @@ func updateLogLevel(client *Client, d *schema.ResourceData) error {
 	return nil
 }
 
-func updatePerformanceSettings(client *Client, d *schema.ResourceData) error {
+func updatePerformanceSettings(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	if d.HasChange("perf_client_comply_no_cache") ||
 		d.HasChange("perf_client_enable_client_side_caching") ||
 		d.HasChange("perf_client_send_age_header") ||
@@ -823,7 +826,7 @@ func updatePerformanceSettings(client *Client, d *schema.ResourceData) error {
 		performanceSettings.TTL.PreferLastModified = d.Get("perf_ttl_prefer_last_modified").(bool)
 		performanceSettings.TTL.UseShortestCaching = d.Get("perf_ttl_use_shortest_caching").(bool)
 
-		_, err := client.UpdatePerformanceSettings(d.Id(), &performanceSettings)
+		_, err := client.UpdatePerformanceSettings(ctx, d.Id(), &performanceSettings)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula performance settings for site_id: %s %s\n", d.Id(), err)
 			return err
@@ -1,22 +1,24 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSiteCacheConfiguration() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceApplicationPerformanceUpdate,
-		Read:   resourceApplicationPerformanceRead,
-		Update: resourceApplicationPerformanceUpdate,
-		Delete: resourceApplicationPerformanceDelete,
+		CreateContext: resourceApplicationPerformanceUpdate,
+		ReadContext:   resourceApplicationPerformanceRead,
+		UpdateContext: resourceApplicationPerformanceUpdate,
+		DeleteContext: resourceApplicationPerformanceDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				siteID, err := strconv.Atoi(d.Id())
 				if err != nil {
 					return nil, fmt.Errorf("failed to convert Site Id from import command for Site Cache Configuration resource, actual value: %s, expected numeric id", d.Id())
@@ -172,7 +174,7 @@ func resourceSiteCacheConfiguration() *schema.Resource {
 	}
 }
 
-func resourceApplicationPerformanceUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceApplicationPerformanceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteID := d.Get("site_id").(int)
 	siteIdStr := strconv.Itoa(siteID)
@@ -200,23 +202,26 @@ func resourceApplicationPerformanceUpdate(d *schema.ResourceData, m interface{})
 	performanceSettings.TTL.PreferLastModified = d.Get("ttl_prefer_last_modified").(bool)
 	performanceSettings.TTL.UseShortestCaching = d.Get("ttl_use_shortest_caching").(bool)
 
-	_, err := client.UpdatePerformanceSettings(siteIdStr, &performanceSettings)
+	_, err := client.UpdatePerformanceSettings(ctx, siteIdStr, &performanceSettings)
 	if err != nil {
 		log.Printf("[ERROR] Could not update Incapsula performance settings for site_id: %s %s\n", d.Id(), err)
-		return err
+		return diag.FromErr(err)
 	}
-	return resourceApplicationPerformanceRead(d, m)
+	return resourceApplicationPerformanceRead(ctx, d, m)
 }
 
-func resourceApplicationPerformanceRead(d *schema.ResourceData, m interface{}) error {
+func resourceApplicationPerformanceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteID := d.Get("site_id").(int)
 	siteIdStr := strconv.Itoa(siteID)
 
-	performanceSettingsResponse, err := client.GetPerformanceSettings(siteIdStr)
+	performanceSettingsResponse, err := client.GetPerformanceSettings(ctx, siteIdStr)
+	if removeGoneResource(d, err, "site cache configuration") {
+		return nil
+	}
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula site peformance settings for site id: %d, %s\n", siteID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("client_comply_no_cache", performanceSettingsResponse.ClientSide.ComplyNoCache)
@@ -246,7 +251,7 @@ func resourceApplicationPerformanceRead(d *schema.ResourceData, m interface{}) e
 	return nil
 }
 
-func resourceApplicationPerformanceDelete(d *schema.ResourceData, m interface{}) error {
+func resourceApplicationPerformanceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 	return nil
 }
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
@@ -255,7 +256,7 @@ func testCheckApplicationPerformanceExists(name string) resource.TestCheckFunc {
 		siteId := res.Primary.ID
 
 		client := testAccProvider.Meta().(*Client)
-		_, err2 := client.GetPerformanceSettings(siteId)
+		_, err2 := client.GetPerformanceSettings(context.Background(), siteId)
 		if err2 != nil {
 			return fmt.Errorf("Incapsula Site Cache Configuration doesn't exist")
 		}
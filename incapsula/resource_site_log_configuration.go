@@ -1,7 +1,9 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"strconv"
@@ -9,12 +11,12 @@ import (
 
 func resourceSiteLogConfiguration() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSiteLogConfigurationCreate,
-		Read:   resourceSiteLogConfigurationRead,
-		Update: resourceSiteLogConfigurationUpdate,
-		Delete: resourceSiteLogConfigurationDelete,
+		CreateContext: resourceSiteLogConfigurationCreate,
+		ReadContext:   resourceSiteLogConfigurationRead,
+		UpdateContext: resourceSiteLogConfigurationUpdate,
+		DeleteContext: resourceSiteLogConfigurationDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				siteID := d.Id()
 				if err := d.Set("site_id", siteID); err != nil {
 					return nil, err
@@ -71,14 +73,14 @@ func resourceSiteLogConfiguration() *schema.Resource {
 	}
 }
 
-func resourceSiteLogConfigurationCreate(d *schema.ResourceData, m interface{}) error {
+func resourceSiteLogConfigurationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Log the creation process
 	log.Printf("[INFO] Creating Incapsula site log configuration")
 
 	// Call the update function to create the resource
-	err := resourceSiteLogConfigurationUpdate(d, m)
-	if err != nil {
-		return err
+	diags := resourceSiteLogConfigurationUpdate(ctx, d, m)
+	if diags.HasError() {
+		return diags
 	}
 
 	// Set the resource ID
@@ -88,10 +90,10 @@ func resourceSiteLogConfigurationCreate(d *schema.ResourceData, m interface{}) e
 	// Log the resource ID
 	log.Printf("[INFO] Incapsula site log configuration created with site_id: %s", siteID)
 
-	return resourceSiteLogConfigurationRead(d, m)
+	return resourceSiteLogConfigurationRead(ctx, d, m)
 }
 
-func resourceSiteLogConfigurationRead(d *schema.ResourceData, m interface{}) error {
+func resourceSiteLogConfigurationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	siteIdStr, _ := d.Get("site_id").(string)
@@ -99,28 +101,35 @@ func resourceSiteLogConfigurationRead(d *schema.ResourceData, m interface{}) err
 	siteId, err := strconv.Atoi(siteIdStr)
 	if err != nil {
 		log.Printf("[ERROR] Could not convert site_id to int: %s\n", err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Get the log level for the site
-	siteStatusResponse, err := client.SiteStatus("nil", siteId)
+	siteStatusResponse, err := client.SiteStatus(ctx, "nil", siteId)
+	if removeGoneResource(d, err, "site log configuration") {
+		return nil
+	}
+	if err != nil {
+		log.Printf("[ERROR] Could not read Incapsula site status for site id: %d, %s\n", siteId, err)
+		return diag.FromErr(err)
+	}
 	if siteStatusResponse.LogLevel != "" {
 		d.Set("log_level", siteStatusResponse.LogLevel)
 	}
 
 	// Get the data storage region for the site
-	dataStorageRegionResponse, err := client.GetDataStorageRegion(siteIdStr)
+	dataStorageRegionResponse, err := client.GetDataStorageRegion(ctx, siteIdStr)
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula site data storage region for site id: %s, %s\n", siteIdStr, err)
-		return err
+		return diag.FromErr(err)
 	}
 	d.Set("data_storage_region", dataStorageRegionResponse.Region)
 
 	// Get the masking settings for the site
-	maskingResponse, err := client.GetMaskingSettings(siteIdStr)
+	maskingResponse, err := client.GetMaskingSettings(ctx, siteIdStr)
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula site masking settings for site id: %s, %s\n", siteIdStr, err)
-		return err
+		return diag.FromErr(err)
 	}
 	d.Set("hashing_enabled", maskingResponse.HashingEnabled)
 	d.Set("hash_salt", maskingResponse.HashSalt)
@@ -128,43 +137,43 @@ func resourceSiteLogConfigurationRead(d *schema.ResourceData, m interface{}) err
 	return nil
 }
 
-func resourceSiteLogConfigurationUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSiteLogConfigurationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	// Update the log level and logs account id for the site
-	err := updateSiteLogLevel(client, d)
+	err := updateSiteLogLevel(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Update the data storage region for the site
-	err = updateSiteDataStorageRegion(client, d)
+	err = updateSiteDataStorageRegion(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Update the masking settings for the site
-	err = updateSiteMaskingSettings(client, d)
+	err = updateSiteMaskingSettings(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceSiteLogConfigurationRead(d, m)
+	return resourceSiteLogConfigurationRead(ctx, d, m)
 }
 
-func resourceSiteLogConfigurationDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSiteLogConfigurationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement the delete logic here
 	d.SetId("")
 	return nil
 }
 
-func updateSiteLogLevel(client *Client, d *schema.ResourceData) error {
+func updateSiteLogLevel(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	if d.HasChange("log_level") ||
 		d.HasChange("logs_account_id") {
 		logLevel := d.Get("log_level").(string)
 		logsAccountId := d.Get("logs_account_id").(string)
 		siteID := d.Get("site_id").(string)
-		err := client.UpdateLogLevel(siteID, logLevel, logsAccountId)
+		err := client.UpdateLogLevel(ctx, siteID, logLevel, logsAccountId)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula site log level: %s and logs account id: %s for site_id: %s %s\n", logLevel, logsAccountId, siteID, err)
 			return err
@@ -173,11 +182,11 @@ func updateSiteLogLevel(client *Client, d *schema.ResourceData) error {
 	return nil
 }
 
-func updateSiteDataStorageRegion(client *Client, d *schema.ResourceData) error {
+func updateSiteDataStorageRegion(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	if d.HasChange("data_storage_region") {
 		dataStorageRegion := d.Get("data_storage_region").(string)
 		siteID := d.Get("site_id").(string)
-		_, err := client.UpdateDataStorageRegion(siteID, dataStorageRegion)
+		_, err := client.UpdateDataStorageRegion(ctx, siteID, dataStorageRegion)
 		if err != nil {
 			log.Printf("[ERROR] Could not set Incapsula site data storage region with value (%s) for site_id: %s %s\n", dataStorageRegion, siteID, err)
 			return err
@@ -186,13 +195,13 @@ func updateSiteDataStorageRegion(client *Client, d *schema.ResourceData) error {
 	return nil
 }
 
-func updateSiteMaskingSettings(client *Client, d *schema.ResourceData) error {
+func updateSiteMaskingSettings(ctx context.Context, client *Client, d *schema.ResourceData) error {
 	if d.HasChange("hashing_enabled") || d.HasChange("hash_salt") {
 		siteID := d.Get("site_id").(string)
 		hashingEnabled := d.Get("hashing_enabled").(bool)
 		hashSalt := d.Get("hash_salt").(string)
 		maskingSettings := MaskingSettings{HashingEnabled: hashingEnabled, HashSalt: hashSalt}
-		err := client.UpdateMaskingSettings(siteID, &maskingSettings)
+		err := client.UpdateMaskingSettings(ctx, siteID, &maskingSettings)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula site masking settings for site_id: %s %s\n", d.Id(), err)
 			return err
@@ -1,12 +1,13 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
 	"strconv"
-	"strings"
 )
 
 const defaultFailedRequestsMinNumber = 3
@@ -31,12 +32,12 @@ const defaultRequiredMonitors = "MOST"
 
 func resourceSiteMonitoring() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSiteMonitoringUpdate,
-		Read:   resourceSiteMonitoringRead,
-		Update: resourceSiteMonitoringUpdate,
-		Delete: resourceSiteMonitoringDelete,
+		CreateContext: resourceSiteMonitoringUpdate,
+		ReadContext:   resourceSiteMonitoringRead,
+		UpdateContext: resourceSiteMonitoringUpdate,
+		DeleteContext: resourceSiteMonitoringDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				siteID, err := strconv.Atoi(d.Id())
 				if err != nil {
 					fmt.Errorf("failed to convert Site Id from import command, actual value: %s, expected numeric id", d.Id())
@@ -172,7 +173,7 @@ func resourceSiteMonitoring() *schema.Resource {
 	}
 }
 
-func resourceSiteMonitoringUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSiteMonitoringUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteID := d.Get("site_id").(int)
 	siteIDStr := strconv.Itoa(siteID)
@@ -205,16 +206,10 @@ func resourceSiteMonitoringUpdate(d *schema.ResourceData, m interface{}) error {
 		},
 	}
 
-	siteMonitoringResponse, err := client.UpdateSiteMonitoring(siteID, &siteMonitoring)
-	if strings.Contains(fmt.Sprint(err), "Missing Load Balancing subscription for Site ID") {
-		log.Printf("[ERROR] Could not get Incapsula Site Monitoring for Site Id: %d - %s\n. Missing Load Balancing subscription for Site ID. The resource will be removed.", siteID, err)
-		d.SetId("")
-		return err
-	}
-
+	siteMonitoringResponse, err := client.UpdateSiteMonitoring(ctx, siteID, &siteMonitoring)
 	if err != nil {
 		log.Printf("[ERROR] Could not get Incapsula Site Monitoring for Site Id: %d - %s\n", siteID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	siteMonitoringResult := siteMonitoringResponse.Data[0]
@@ -244,20 +239,19 @@ func resourceSiteMonitoringUpdate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSiteMonitoringRead(d *schema.ResourceData, m interface{}) error {
+func resourceSiteMonitoringRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteID := d.Get("site_id").(int)
 	siteIdStr := strconv.Itoa(siteID)
 
-	siteMonitoringResponse, err := client.GetSiteMonitoring(siteID)
-	if strings.Contains(fmt.Sprint(err), "Missing Load Balancing subscription for Site ID") {
-		log.Printf("[ERROR] Could not get Incapsula Site Monitoring for Site Id: %d - %s\n. Missing Load Balancing subscription for Site ID. The resource will be removed.", siteID, err)
-		d.SetId("")
-		return err
-	}
+	siteMonitoringResponse, err := client.GetSiteMonitoring(ctx, siteID)
 	if err != nil {
+		// A 404 is returned for a deleted site as well as for a site without a Load Balancing subscription
+		if removeGoneResource(d, err, "site monitoring") {
+			return nil
+		}
 		log.Printf("[ERROR] Could not get Incapsula Site Monitoring for Site Id: %d - %s\n", siteID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(siteIdStr)
@@ -288,7 +282,7 @@ func resourceSiteMonitoringRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSiteMonitoringDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSiteMonitoringDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 	return nil
 }
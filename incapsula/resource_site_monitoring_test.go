@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -68,7 +69,7 @@ func testCheckSiteMonitoringExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		_, err = client.GetSiteMonitoring(siteId)
+		_, err = client.GetSiteMonitoring(context.Background(), siteId)
 		if err != nil {
 			fmt.Errorf("Incapsula Site Monitoring doesn't exist")
 		}
@@ -1,11 +1,13 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -65,12 +67,12 @@ var inboundTLSSettingsResource = schema.Resource{
 
 func resourceSiteSSLSettings() *schema.Resource {
 	return &schema.Resource{
-		Read:   resourceSiteSSLSettingsRead,
-		Update: resourceSiteSSLSettingsUpdate,
-		Create: resourceSiteSSLSettingsUpdate,
-		Delete: resourceSiteSSLSettingsDelete,
+		ReadContext:   resourceSiteSSLSettingsRead,
+		UpdateContext: resourceSiteSSLSettingsUpdate,
+		CreateContext: resourceSiteSSLSettingsUpdate,
+		DeleteContext: resourceSiteSSLSettingsDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(d.Id(), "/")
 				log.Printf("[DEBUG] Starting to import site ssl settings. Parameters: %s\n", d.Id())
 
@@ -137,31 +139,30 @@ func resourceSiteSSLSettings() *schema.Resource {
 	}
 }
 
-func resourceSiteSSLSettingsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSiteSSLSettingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	setting := getSSLSettingsDTO(d)
 
-	_, err := client.UpdateSiteSSLSettings(d.Get("site_id").(int), d.Get("account_id").(int), setting)
+	_, err := client.UpdateSiteSSLSettings(ctx, d.Get("site_id").(int), d.Get("account_id").(int), setting)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceSiteSSLSettingsRead(d, m)
+	return resourceSiteSSLSettingsRead(ctx, d, m)
 }
 
-func resourceSiteSSLSettingsRead(d *schema.ResourceData, m interface{}) error {
+func resourceSiteSSLSettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
-	settingsData, statusCode, err := client.ReadSiteSSLSettings(d.Get("site_id").(int), d.Get("account_id").(int))
-	if statusCode == 404 {
-		d.SetId("")
+	settingsData, _, err := client.ReadSiteSSLSettings(ctx, d.Get("site_id").(int), d.Get("account_id").(int))
+	if removeGoneResource(d, err, "site SSL settings") {
 		return nil
 	}
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if len(settingsData.Data) == 0 {
@@ -178,17 +179,17 @@ func resourceSiteSSLSettingsRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSiteSSLSettingsDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSiteSSLSettingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	// currently only disables HSTS and set default InboundTLSSettings
 	// If more settings are implemented in the endpoint, add delete logic for them here.
 	setting := prepareDisableHSTSStructure()
 	prepareDefaultTLSStructure(&setting)
-	var _, err = client.UpdateSiteSSLSettings(d.Get("site_id").(int), d.Get("account_id").(int), setting)
+	var _, err = client.UpdateSiteSSLSettings(ctx, d.Get("site_id").(int), d.Get("account_id").(int), setting)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
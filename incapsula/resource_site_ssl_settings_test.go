@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"testing"
@@ -97,7 +98,7 @@ func testAccSiteCheckIncapsulaSiteSSLSettingsDestroy(state *terraform.State) err
 			return fmt.Errorf("failed to parse account_id %s: %v", accountID, err)
 		}
 
-		_, statusCode, err := client.ReadSiteSSLSettings(siteIDToInt, accountIDToInt)
+		_, statusCode, err := client.ReadSiteSSLSettings(context.Background(), siteIDToInt, accountIDToInt)
 		if statusCode != 404 {
 			return fmt.Errorf("incapsula Incap Site ssl settings (site id: %s) should have received 404 status code", siteID)
 		}
@@ -136,7 +137,7 @@ func testCheckIncapsulaIncapSiteSSLSettingsExists(resourceName string) resource.
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		_, statusCode, err := client.ReadSiteSSLSettings(siteIDToInt, accountIDToInt)
+		_, statusCode, err := client.ReadSiteSSLSettings(context.Background(), siteIDToInt, accountIDToInt)
 		if statusCode != 200 {
 			return fmt.Errorf("incapsula site ssl settings (site id: %s) should have received 200 status code", siteID)
 		}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -245,7 +246,7 @@ func testAccCheckIncapsulaSiteDestroy(state *terraform.State) error {
 			return fmt.Errorf("Site ID conversion error for %s: %s", siteIDStr, err)
 		}
 
-		_, err = client.SiteStatus(generatedDomain, siteID)
+		_, err = client.SiteStatus(context.Background(), generatedDomain, siteID)
 
 		if err == nil {
 			return fmt.Errorf("Incapsula site for domain: %s (site id: %d) still exists", GenerateTestDomain(nil), siteID)
@@ -283,7 +284,7 @@ func testCheckIncapsulaSiteExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		siteStatusResponse, err := client.SiteStatus(GenerateTestDomain(nil), siteID)
+		siteStatusResponse, err := client.SiteStatus(context.Background(), GenerateTestDomain(nil), siteID)
 		if siteStatusResponse == nil {
 			return fmt.Errorf("Incapsula site for domain: %s (site id: %d) does not exist", GenerateTestDomain(nil), siteID)
 		}
@@ -393,7 +394,7 @@ func testCheckIncapsulaSiteNotDestroy(state *terraform.State) error {
 		}
 
 		domain := res.Primary.Attributes["domain"]
-		siteStatusResponse, err := client.SiteStatus(domain, siteID)
+		siteStatusResponse, err := client.SiteStatus(context.Background(), domain, siteID)
 
 		if err != nil {
 			return fmt.Errorf("Site status error for %s: %s", siteIDStr, err)
@@ -403,7 +404,7 @@ func testCheckIncapsulaSiteNotDestroy(state *terraform.State) error {
 			response, _ := json.Marshal(siteStatusResponse)
 			return fmt.Errorf("Incapsula site status for domain: %s (site id: %d) was not retreived. response: %s", domain, siteID, response)
 		}
-		err = client.DeleteSite(domain, siteID) // clean the env after checking
+		err = client.DeleteSite(context.Background(), domain, siteID) // clean the env after checking
 
 		if err != nil {
 			return fmt.Errorf("Error deleting site (%d) for domain %s: %s", siteID, domain, err)
@@ -25,7 +25,7 @@ func resourceSiteV3() *schema.Resource {
 			return nil
 		},
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 
 				idSlice := strings.Split(d.Id(), "/")
 				log.Printf("[DEBUG] Starting to import site v3. Parameters: %s\n", d.Id())
@@ -115,10 +115,10 @@ func resourceSiteV3Add(ctx context.Context, d *schema.ResourceData, m interface{
 		siteV3Request.CloudType = v.(string)
 	}
 	siteV3Request.Active = d.Get("active").(bool)
-	siteV3Response, diags := client.AddV3Site(&siteV3Request, accountID)
-	if diags != nil && diags.HasError() {
-		log.Printf("[ERROR] failed to add v3 site to Account ID: %s, %v\n", accountID, diags)
-		return diags
+	siteV3Response, err := client.AddV3Site(ctx, &siteV3Request, accountID)
+	if err != nil {
+		log.Printf("[ERROR] failed to add v3 site to Account ID: %s, %v\n", accountID, err)
+		return diag.FromErr(err)
 	} else if siteV3Response.Errors != nil {
 		log.Printf("[ERROR] Failed to add v3 site to Account ID: %s, %v\n", accountID, siteV3Response.Errors[0].Detail)
 		return []diag.Diagnostic{{
@@ -127,7 +127,7 @@ func resourceSiteV3Add(ctx context.Context, d *schema.ResourceData, m interface{
 			Detail:   fmt.Sprintf("Failed to add v3 site to account%s, %s", accountID, siteV3Response.Errors[0].Detail),
 		}}
 	}
-	err := d.Set("account_id", strconv.Itoa(siteV3Response.Data[0].AccountId))
+	err = d.Set("account_id", strconv.Itoa(siteV3Response.Data[0].AccountId))
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula account after add v3 site to Account ID: %s, %s\n", accountID, err)
 		return diag.FromErr(err)
@@ -153,10 +153,10 @@ func resourceSiteV3Update(ctx context.Context, d *schema.ResourceData, m interfa
 		siteV3Request.RefId = d.Get("ref_id").(string)
 	}
 	siteV3Request.Active = d.Get("active").(bool)
-	siteV3Response, diags := client.UpdateV3Site(&siteV3Request, accountID)
-	if diags != nil && diags.HasError() {
-		log.Printf("[ERROR] failed to update v3 site to Account ID: %s, %v\n", accountID, diags)
-		return diags
+	siteV3Response, err := client.UpdateV3Site(ctx, &siteV3Request, accountID)
+	if err != nil {
+		log.Printf("[ERROR] failed to update v3 site to Account ID: %s, %v\n", accountID, err)
+		return diag.FromErr(err)
 	} else if siteV3Response.Errors != nil {
 		log.Printf("[ERROR] Failed to update v3 site to Account ID: %s, %v\n", accountID, siteV3Response.Errors[0].Detail)
 		return []diag.Diagnostic{{
@@ -165,7 +165,7 @@ func resourceSiteV3Update(ctx context.Context, d *schema.ResourceData, m interfa
 			Detail:   fmt.Sprintf("Failed to update v3 site to account%s, %s", accountID, siteV3Response.Errors[0].Detail),
 		}}
 	}
-	err := d.Set("account_id", accountID)
+	err = d.Set("account_id", accountID)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula account after update v3 site to Account ID: %s, %s\n", accountID, err)
@@ -189,10 +189,13 @@ func resourceSiteV3Read(ctx context.Context, d *schema.ResourceData, m interface
 	siteV3Request.Name = d.Get("name").(string)
 	siteV3Request.AccountId, _ = strconv.Atoi(accountID)
 	siteV3Request.Id, _ = strconv.Atoi(d.Id())
-	siteV3Response, diags := client.GetV3Site(&siteV3Request, accountID)
-	if diags != nil && diags.HasError() {
-		log.Printf("[ERROR] failed to get v3 site of Account ID: %s, %v\n", accountID, diags)
-		return diags
+	siteV3Response, err := client.GetV3Site(ctx, &siteV3Request, accountID)
+	if err != nil {
+		if removeGoneResource(d, err, "v3 site") {
+			return nil
+		}
+		log.Printf("[ERROR] failed to get v3 site of Account ID: %s, %v\n", accountID, err)
+		return diag.FromErr(err)
 	} else if siteV3Response.Errors != nil {
 		log.Printf("[ERROR] Failed to get v3 site of Account ID: %s, %v\n", accountID, siteV3Response.Errors[0].Detail)
 		return []diag.Diagnostic{{
@@ -201,7 +204,7 @@ func resourceSiteV3Read(ctx context.Context, d *schema.ResourceData, m interface
 			Detail:   fmt.Sprintf("Failed to get v3 site of account%s, %s", accountID, siteV3Response.Errors[0].Detail),
 		}}
 	}
-	err := d.Set("account_id", strconv.Itoa(siteV3Response.Data[0].AccountId))
+	err = d.Set("account_id", strconv.Itoa(siteV3Response.Data[0].AccountId))
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula account after get v3 site of Account ID: %s, %s\n", accountID, err)
 		return diag.FromErr(err)
@@ -258,7 +261,6 @@ func resourceSiteV3Read(ctx context.Context, d *schema.ResourceData, m interface
 
 func resourceSiteV3Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
-	var diags diag.Diagnostics
 	accountID, _ := d.Get("account_id").(string)
 
 	log.Printf("[INFO] deleting v3 site of Account ID:%s to %v", accountID, d)
@@ -267,10 +269,10 @@ func resourceSiteV3Delete(ctx context.Context, d *schema.ResourceData, m interfa
 	siteV3Request.Name = d.Get("name").(string)
 	siteV3Request.AccountId, _ = strconv.Atoi(accountID)
 	siteV3Request.Id, _ = strconv.Atoi(d.Id())
-	siteV3Response, diags := client.DeleteV3Site(&siteV3Request, accountID)
-	if diags != nil && diags.HasError() {
-		log.Printf("[ERROR] failed to delete v3 site of Account ID: %s, %v\n", accountID, diags)
-		return diags
+	siteV3Response, err := client.DeleteV3Site(ctx, &siteV3Request, accountID)
+	if err != nil {
+		log.Printf("[ERROR] failed to delete v3 site of Account ID: %s, %v\n", accountID, err)
+		return diag.FromErr(err)
 	} else if siteV3Response.Errors != nil {
 		log.Printf("[ERROR] Failed to delete v3 site of Account ID: %s, %v\n", accountID, siteV3Response.Errors[0].Detail)
 		return []diag.Diagnostic{{
@@ -279,7 +281,7 @@ func resourceSiteV3Delete(ctx context.Context, d *schema.ResourceData, m interfa
 			Detail:   fmt.Sprintf("Failed to delete v3 site of account%s, %s", accountID, siteV3Response.Errors[0].Detail),
 		}}
 	}
-	err := d.Set("account_id", accountID)
+	err = d.Set("account_id", accountID)
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula account after delete v3 site of Account ID: %s, %s\n", accountID, err)
 		return diag.FromErr(err)
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"os"
@@ -140,9 +141,9 @@ func testCheckIncapsulaSiteV3Destroy(state *terraform.State) error {
 		siteV3Request := SiteV3Request{}
 		siteV3Request.Name = siteName
 
-		_, diags := client.GetV3Site(&siteV3Request, "123")
+		_, err = client.GetV3Site(context.Background(), &siteV3Request, "123")
 
-		if diags == nil {
+		if err == nil {
 			return fmt.Errorf("incapsula site for domain: %s (site id: %d) still exists", siteName, siteID)
 		}
 	}
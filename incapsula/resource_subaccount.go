@@ -1,6 +1,8 @@
 package incapsula
 
 import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
@@ -10,12 +12,12 @@ import (
 
 func resourceSubAccount() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSubAccountCreate,
-		Read:   resourceSubAccountRead,
-		Delete: resourceSubAccountDelete,
-		Update: resourceSubAccountUpdate,
+		CreateContext: resourceSubAccountCreate,
+		ReadContext:   resourceSubAccountRead,
+		DeleteContext: resourceSubAccountDelete,
+		UpdateContext: resourceSubAccountUpdate,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -75,7 +77,7 @@ func resourceSubAccount() *schema.Resource {
 	}
 }
 
-func resourceSubAccountCreate(d *schema.ResourceData, m interface{}) error {
+func resourceSubAccountCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	subAccountName := d.Get("sub_account_name").(string)
 
@@ -91,11 +93,11 @@ func resourceSubAccountCreate(d *schema.ResourceData, m interface{}) error {
 		d.Get("parent_id").(int),
 		d.Get("logs_account_id").(int)}
 
-	SubAccountAddResponse, err := client.AddSubAccount(&subAccountPayload)
+	SubAccountAddResponse, err := client.AddSubAccount(ctx, &subAccountPayload)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not create Incapsula subaccount %s, %s\n", subAccountName, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the SubAccount ID
@@ -103,42 +105,42 @@ func resourceSubAccountCreate(d *schema.ResourceData, m interface{}) error {
 	log.Printf("[DEBUG] Account id for new sub account : %d", SubAccountAddResponse.SubAccount.SubAccountID)
 	log.Printf("[INFO] Created Incapsula subaccount %s\n", subAccountName)
 
-	err = updateDefaultDataStorageRegion(client, d)
+	err = updateDefaultDataStorageRegion(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = updateHttp2Properties(client, d)
+	err = updateHttp2Properties(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// There may be a timing/race condition here
 	// Set an arbitrary period to sleep
-	time.Sleep(3 * time.Second)
+	if err := sleepWithContext(ctx, 3*time.Second); err != nil {
+		return diag.FromErr(err)
+	}
 
-	return resourceSubAccountRead(d, m)
+	return resourceSubAccountRead(ctx, d, m)
 }
 
-func resourceSubAccountRead(d *schema.ResourceData, m interface{}) error {
+func resourceSubAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	accountID, _ := strconv.Atoi(d.Id())
 
 	log.Printf("[INFO] Reading Incapsula account for Account ID: %d\n", accountID)
 
-	accountStatusResponse, err := client.AccountStatus(accountID, ReadSubAccount)
+	accountStatusResponse, err := client.AccountStatus(ctx, accountID, ReadSubAccount)
 
 	// Account object may have been deleted
-	if accountStatusResponse != nil && accountStatusResponse.Res.(float64) == 9403 {
-		log.Printf("[INFO] Incapsula Account ID %d has already been deleted: %s\n", accountID, err)
-		d.SetId("")
+	if removeGoneAccount(d, err, "subaccount") {
 		return nil
 	}
 
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula subaccount for Account ID: %d, %s\n", accountID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("sub_account_name", accountStatusResponse.Account.AccountName)
@@ -149,10 +151,10 @@ func resourceSubAccountRead(d *schema.ResourceData, m interface{}) error {
 
 	log.Printf("[INFO] Finished reading Incapsula subaccount: %s\n", d.Id())
 	// Get the performance settings for the site
-	defaultAccountDataStorageRegion, err := client.GetAccountDataStorageRegion(d.Id())
+	defaultAccountDataStorageRegion, err := client.GetAccountDataStorageRegion(ctx, d.Id())
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula default data storage region for account id: %d, %s\n", accountID, err)
-		return err
+		return diag.FromErr(err)
 	}
 	d.Set("data_storage_region", defaultAccountDataStorageRegion.Region)
 	d.Set("enable_http2_for_new_sites", strconv.FormatBool(accountStatusResponse.Account.EnableHttp2ForNewSites))
@@ -163,17 +165,17 @@ func resourceSubAccountRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSubAccountDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSubAccountDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	subAccountID, _ := strconv.Atoi(d.Id())
 
 	log.Printf("[INFO] Deleting Incapsula subaccount id: %d\n", subAccountID)
 
-	err := client.DeleteSubAccount(subAccountID)
+	err := client.DeleteSubAccount(ctx, subAccountID)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not delete Incapsula subaccount id: %d, %s\n", subAccountID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
@@ -185,7 +187,7 @@ func resourceSubAccountDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSubAccountUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSubAccountUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	updateParams := [1]string{"ref_id"}
@@ -193,24 +195,24 @@ func resourceSubAccountUpdate(d *schema.ResourceData, m interface{}) error {
 		param := updateParams[i]
 		if d.HasChange(param) && d.Get(param) != "" {
 			log.Printf("[INFO] Updating Incapsula sub-account param (%s) with value (%s) for account_id: %s\n", param, d.Get(param).(string), d.Id())
-			_, err := client.UpdateAccount(d.Id(), param, d.Get(param).(string))
+			_, err := client.UpdateAccount(ctx, d.Id(), param, d.Get(param).(string))
 			if err != nil {
 				log.Printf("[ERROR] Could not update Incapsula sub-account param (%s) with value (%s) for account_id: %s %s\n", param, d.Get(param).(string), d.Id(), err)
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	}
 
-	err := updateDefaultDataStorageRegion(client, d)
+	err := updateDefaultDataStorageRegion(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = updateHttp2Properties(client, d)
+	err = updateHttp2Properties(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the rest of the state from the resource read
-	return resourceAccountRead(d, m)
+	return resourceAccountRead(ctx, d, m)
 }
@@ -1,19 +1,21 @@
 package incapsula
 
 import (
+	"context"
 	"log"
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceTXTRecord() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTXTRecordCreate,
-		Read:   resourceTXTRecordRead,
-		Update: resourceTXTRecordUpdate,
-		Delete: resourceTXTRecordDelete,
+		CreateContext: resourceTXTRecordCreate,
+		ReadContext:   resourceTXTRecordRead,
+		UpdateContext: resourceTXTRecordUpdate,
+		DeleteContext: resourceTXTRecordDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -56,7 +58,7 @@ func resourceTXTRecord() *schema.Resource {
 	}
 }
 
-func resourceTXTRecordCreate(d *schema.ResourceData, m interface{}) error {
+func resourceTXTRecordCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by create the TXT Records
 	client := m.(*Client)
 	siteID := d.Get("site_id").(int)
@@ -69,36 +71,36 @@ func resourceTXTRecordCreate(d *schema.ResourceData, m interface{}) error {
 
 	log.Printf("[INFO] Setting Incapsula TXT Records: %s, %s, %s, %s, %s, for siteID: %d\n", TXTRecordOne, TXTRecordTwo, TXTRecordThree, TXTRecordFour, TXTRecordFive, siteID)
 
-	_, err := client.CreateTXTRecord(siteID, TXTRecordOne, TXTRecordTwo, TXTRecordThree, TXTRecordFour, TXTRecordFive)
+	_, err := client.CreateTXTRecord(ctx, siteID, TXTRecordOne, TXTRecordTwo, TXTRecordThree, TXTRecordFour, TXTRecordFive)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not set Incapsula TXT Records: %s, %s, %s, %s, %s, for siteID: %d\n%s", TXTRecordOne, TXTRecordTwo, TXTRecordThree, TXTRecordFour, TXTRecordFive, siteID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID
 	d.SetId(strconv.Itoa(siteID))
 	log.Printf("[INFO] Create Incapsula TXT Records: %s, %s, %s, %s, %s, for siteID: %d\n", TXTRecordOne, TXTRecordTwo, TXTRecordThree, TXTRecordFour, TXTRecordFive, siteID)
 
-	return resourceTXTRecordRead(d, m)
+	return resourceTXTRecordRead(ctx, d, m)
 }
 
-func resourceTXTRecordUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceTXTRecordUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteID := d.Get("site_id").(int)
-	errDelete := deleteSpecificTXTRecordIfNeeded(d, siteID, client)
+	errDelete := deleteSpecificTXTRecordIfNeeded(ctx, d, siteID, client)
 	if errDelete != nil {
-		return errDelete
+		return diag.FromErr(errDelete)
 	}
-	errUpdate := updateSpecificTXTRecordIfNeeded(d, siteID, client)
+	errUpdate := updateSpecificTXTRecordIfNeeded(ctx, d, siteID, client)
 	if errUpdate != nil {
-		return errUpdate
+		return diag.FromErr(errUpdate)
 	}
 
-	return resourceTXTRecordRead(d, m)
+	return resourceTXTRecordRead(ctx, d, m)
 }
 
-func updateSpecificTXTRecordIfNeeded(d *schema.ResourceData, siteID int, client *Client) error {
+func updateSpecificTXTRecordIfNeeded(ctx context.Context, d *schema.ResourceData, siteID int, client *Client) error {
 	if (d.HasChange("txt_record_value_one") && d.Get("txt_record_value_one") != "") ||
 		(d.HasChange("txt_record_value_two") && d.Get("txt_record_value_two") != "") ||
 		(d.HasChange("txt_record_value_three") && d.Get("txt_record_value_three") != "") ||
@@ -113,7 +115,7 @@ func updateSpecificTXTRecordIfNeeded(d *schema.ResourceData, siteID int, client
 
 		log.Printf("[INFO] Setting Incapsula TXT Records: %s, %s, %s, %s, %s, for siteID: %d\n", TXTRecordOne, TXTRecordTwo, TXTRecordThree, TXTRecordFour, TXTRecordFive, siteID)
 
-		_, err := client.UpdateTXTRecord(siteID, TXTRecordOne, TXTRecordTwo, TXTRecordThree, TXTRecordFour, TXTRecordFive)
+		_, err := client.UpdateTXTRecord(ctx, siteID, TXTRecordOne, TXTRecordTwo, TXTRecordThree, TXTRecordFour, TXTRecordFive)
 
 		if err != nil {
 			log.Printf("[ERROR] Could not set Incapsula TXT Records: %s, %s, %s, %s, %s, for siteID: %d\n%s", TXTRecordOne, TXTRecordTwo, TXTRecordThree, TXTRecordFour, TXTRecordFive, siteID, err)
@@ -125,38 +127,38 @@ func updateSpecificTXTRecordIfNeeded(d *schema.ResourceData, siteID int, client
 	return nil
 }
 
-func deleteSpecificTXTRecordIfNeeded(d *schema.ResourceData, siteID int, client *Client) error {
+func deleteSpecificTXTRecordIfNeeded(ctx context.Context, d *schema.ResourceData, siteID int, client *Client) error {
 	if d.HasChange("txt_record_value_one") && d.Get("txt_record_value_one") == "" {
 		log.Printf("[INFO] Delete Incapsula TXT Record 1, for siteID: %d", siteID)
-		err := client.DeleteTXTRecord(siteID, "1")
+		err := client.DeleteTXTRecord(ctx, siteID, "1")
 		if err != nil {
 			log.Printf("[ERROR] Could not delete Incapsula TXT Records 1, for siteID: %d\n%s", siteID, err)
 			return err
 		}
 	} else if d.HasChange("txt_record_value_two") && d.Get("txt_record_value_two") == "" {
 		log.Printf("[INFO] Delete Incapsula TXT Record 2, for siteID: %d", siteID)
-		err := client.DeleteTXTRecord(siteID, "2")
+		err := client.DeleteTXTRecord(ctx, siteID, "2")
 		if err != nil {
 			log.Printf("[ERROR] Could not delete Incapsula TXT Records 2, for siteID: %d\n%s", siteID, err)
 			return err
 		}
 	} else if d.HasChange("txt_record_value_three") && d.Get("txt_record_value_three") == "" {
 		log.Printf("[INFO] Delete Incapsula TXT Record 3, for siteID: %d", siteID)
-		err := client.DeleteTXTRecord(siteID, "3")
+		err := client.DeleteTXTRecord(ctx, siteID, "3")
 		if err != nil {
 			log.Printf("[ERROR] Could not delete Incapsula TXT Records 3, for siteID: %d\n%s", siteID, err)
 			return err
 		}
 	} else if d.HasChange("txt_record_value_four") && d.Get("txt_record_value_four") == "" {
 		log.Printf("[INFO] Delete Incapsula TXT Record 4, for siteID: %d", siteID)
-		err := client.DeleteTXTRecord(siteID, "4")
+		err := client.DeleteTXTRecord(ctx, siteID, "4")
 		if err != nil {
 			log.Printf("[ERROR] Could not delete Incapsula TXT Records 4, for siteID: %d\n%s", siteID, err)
 			return err
 		}
 	} else if d.HasChange("txt_record_value_five") && d.Get("txt_record_value_five") == "" {
 		log.Printf("[INFO] Delete Incapsula TXT Record 5, for siteID: %d", siteID)
-		err := client.DeleteTXTRecord(siteID, "5")
+		err := client.DeleteTXTRecord(ctx, siteID, "5")
 		if err != nil {
 			log.Printf("[ERROR] Could not delete Incapsula TXT Records 5, for siteID: %d\n%s", siteID, err)
 			return err
@@ -165,16 +167,19 @@ func deleteSpecificTXTRecordIfNeeded(d *schema.ResourceData, siteID int, client
 	return nil
 }
 
-func resourceTXTRecordRead(d *schema.ResourceData, m interface{}) error {
+func resourceTXTRecordRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by reading the TXTRecordResponse for the TXT Records
 	client := m.(*Client)
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
 		log.Printf("[ERROR] The ID should be numeric. Currrent value: %s", d.Id())
-		return err
+		return diag.FromErr(err)
 	}
 
-	recordResponse, err := client.ReadTXTRecords(id)
+	recordResponse, err := client.ReadTXTRecords(ctx, id)
+	if removeGoneResource(d, err, "TXT record") {
+		return nil
+	}
 	d.Set("site_id", id)
 
 	// Gte TXT response object
@@ -190,19 +195,19 @@ func resourceTXTRecordRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
 
-func resourceTXTRecordDelete(d *schema.ResourceData, m interface{}) error {
+func resourceTXTRecordDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by deleting the a TXT Record
 	client := m.(*Client)
 	siteID := d.Get("site_id").(int)
-	err := client.DeleteTXTRecordAll(siteID)
+	err := client.DeleteTXTRecordAll(ctx, siteID)
 	if err != nil {
 		log.Printf("[ERROR] Could not delete all Incapsula TXT Records, for siteID: %d\n%s", siteID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Set the ID to empty
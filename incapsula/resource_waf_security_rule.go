@@ -1,7 +1,9 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
 	"strconv"
@@ -26,12 +28,12 @@ const botAccessControlChallengeSuspectedBotsDefaultAction = "false"
 
 func resourceWAFSecurityRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceWAFSecurityRuleCreate,
-		Read:   resourceWAFSecurityRuleRead,
-		Update: resourceWAFSecurityRuleUpdate,
-		Delete: resourceWAFSecurityRuleDelete,
+		CreateContext: resourceWAFSecurityRuleCreate,
+		ReadContext:   resourceWAFSecurityRuleRead,
+		UpdateContext: resourceWAFSecurityRuleUpdate,
+		DeleteContext: resourceWAFSecurityRuleDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/rule_id", d.Id())
@@ -112,7 +114,7 @@ func resourceWAFSecurityRule() *schema.Resource {
 	}
 }
 
-func resourceWAFSecurityRuleCreate(d *schema.ResourceData, m interface{}) error {
+func resourceWAFSecurityRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	ruleID := d.Get("rule_id").(string)
@@ -120,7 +122,7 @@ func resourceWAFSecurityRuleCreate(d *schema.ResourceData, m interface{}) error
 	log.Printf("[INFO] Creating Incapsula WAF Rule rule_id (%s) on site_id (%d)\n", ruleID, d.Get("site_id").(int))
 
 	if ruleID == backdoorRuleID || ruleID == crossSiteScriptingRuleID || ruleID == illegalResourceAccessRuleID || ruleID == remoteFileInclusionRuleID || ruleID == sqlInjectionRuleID {
-		_, err := client.ConfigureWAFSecurityRule(
+		_, err := client.ConfigureWAFSecurityRule(ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			d.Get("security_rule_action").(string),
@@ -133,10 +135,10 @@ func resourceWAFSecurityRuleCreate(d *schema.ResourceData, m interface{}) error
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not create Incapsula WAF Rule rule_id (%s) and security_rule_action (%s) on site_id (%d), %s\n", ruleID, d.Get("security_rule_action").(string), d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	} else if ruleID == ddosRuleID {
-		_, err := client.ConfigureWAFSecurityRule(
+		_, err := client.ConfigureWAFSecurityRule(ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -149,10 +151,10 @@ func resourceWAFSecurityRuleCreate(d *schema.ResourceData, m interface{}) error
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not create Incapsula WAF Rule rule_id (%s) with activation_mode (%s), ddos_traffic_threshold (%s), unknown_clients_challenge (%s) and block_non_essential_bots (%s) on site_id (%d), %s\n", ruleID, d.Get("activation_mode").(string), d.Get("ddos_traffic_threshold").(string), d.Get("unknown_clients_challenge").(string), d.Get("block_non_essential_bots").(string), d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	} else if ruleID == botAccessControlRuleID {
-		_, err := client.ConfigureWAFSecurityRule(
+		_, err := client.ConfigureWAFSecurityRule(ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -165,7 +167,7 @@ func resourceWAFSecurityRuleCreate(d *schema.ResourceData, m interface{}) error
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not create Incapsula WAF Rule rule_id (%s) with block_bad_bots (%s) and challenge_suspected_bots (%s) on site_id (%d), %s\n", ruleID, d.Get("block_bad_bots").(string), d.Get("challenge_suspected_bots").(string), d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -174,10 +176,10 @@ func resourceWAFSecurityRuleCreate(d *schema.ResourceData, m interface{}) error
 
 	log.Printf("[INFO] Created Incapsula WAF Rule rule_id (%s) on site_id (%d)\n", ruleID, d.Get("site_id").(int))
 
-	return resourceWAFSecurityRuleRead(d, m)
+	return resourceWAFSecurityRuleRead(ctx, d, m)
 }
 
-func resourceWAFSecurityRuleRead(d *schema.ResourceData, m interface{}) error {
+func resourceWAFSecurityRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by reading the SiteResponse for the site
 	client := m.(*Client)
 
@@ -185,18 +187,16 @@ func resourceWAFSecurityRuleRead(d *schema.ResourceData, m interface{}) error {
 
 	log.Printf("[INFO] Reading Incapsula WAF Rule for id: %s\n", ruleID)
 
-	siteStatusResponse, err := client.SiteStatus("waf-rule-read", d.Get("site_id").(int))
+	siteStatusResponse, err := client.SiteStatus(ctx, "waf-rule-read", d.Get("site_id").(int))
 
 	// Site object may have been deleted
-	if siteStatusResponse != nil && siteStatusResponse.Res.(float64) == 9413 {
-		log.Printf("[INFO] Incapsula Site with ID %s has already been deleted: %s\n", d.Get("site_id"), err)
-		d.SetId("")
+	if removeGoneResource(d, err, "WAF security rule") {
 		return nil
 	}
 
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula WAF Rule for id: %s, %s\n", ruleID, err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	found := false
@@ -242,9 +242,9 @@ func resourceWAFSecurityRuleRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceWAFSecurityRuleUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceWAFSecurityRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// This is the same as create
-	return resourceWAFSecurityRuleCreate(d, m)
+	return resourceWAFSecurityRuleCreate(ctx, d, m)
 }
 
 func testAccStateWAFSecurityRuleID(s *terraform.State) (string, error) {
@@ -267,7 +267,7 @@ func testAccStateWAFSecurityRuleID(s *terraform.State) (string, error) {
 	return "", fmt.Errorf("Error finding site_id")
 }
 
-func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error {
+func resourceWAFSecurityRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	ruleID := d.Get("rule_id").(string)
@@ -277,7 +277,7 @@ func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 	// Set WAF rule type defaults based on specific rule id
 	switch ruleID {
 	case backdoorRuleID:
-		_, err := client.ConfigureWAFSecurityRule(
+		_, err := client.ConfigureWAFSecurityRule(ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			backdoorRuleIDDefaultAction,
@@ -290,10 +290,10 @@ func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not reset Incapsula WAF Rule rule_id (%s) with security_rule_action (%s) on site_id (%d) %s\n", ruleID, backdoorRuleIDDefaultAction, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case crossSiteScriptingRuleID:
-		_, err := client.ConfigureWAFSecurityRule(
+		_, err := client.ConfigureWAFSecurityRule(ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			crossSiteScriptingRuleIDDefaultAction,
@@ -306,10 +306,10 @@ func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not reset Incapsula WAF Rule rule_id (%s) with security_rule_action (%s) on site_id (%d) %s\n", ruleID, crossSiteScriptingRuleIDDefaultAction, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case illegalResourceAccessRuleID:
-		_, err := client.ConfigureWAFSecurityRule(
+		_, err := client.ConfigureWAFSecurityRule(ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			illegalResourceAccessRuleIDDefaultAction,
@@ -322,10 +322,10 @@ func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not reset Incapsula WAF Rule rule_id (%s) with security_rule_action (%s) on site_id (%d) %s\n", ruleID, illegalResourceAccessRuleIDDefaultAction, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case remoteFileInclusionRuleID:
-		_, err := client.ConfigureWAFSecurityRule(
+		_, err := client.ConfigureWAFSecurityRule(ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			remoteFileInclusionRuleIDDefaultAction,
@@ -338,10 +338,10 @@ func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not reset Incapsula WAF Rule rule_id (%s) with security_rule_action (%s) on site_id (%d) %s\n", ruleID, remoteFileInclusionRuleIDDefaultAction, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case sqlInjectionRuleID:
-		_, err := client.ConfigureWAFSecurityRule(
+		_, err := client.ConfigureWAFSecurityRule(ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			sqlInjectionRuleIDDefaultAction,
@@ -354,10 +354,10 @@ func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not reset Incapsula WAF Rule rule_id (%s) with security_rule_action (%s) on site_id (%d) %s\n", ruleID, sqlInjectionRuleIDDefaultAction, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case ddosRuleID:
-		_, err := client.ConfigureWAFSecurityRule(
+		_, err := client.ConfigureWAFSecurityRule(ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -370,10 +370,10 @@ func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not reset Incapsula WAF Rule rule_id (%s) with default_activation_mode (%s), ddos_traffic_threshold (%s), unknown_clients_challenge (%s) and block_non_essential_bots (%s) on site_id (%d) %s\n", ruleID, ddosRuleIDDefaultActivationMode, ddosRuleIDDefaultDDOSTrafficThreshold, ddosRuleIDDefaultDDOSUnknownClientsChallenge, ddosRuleIDDefaultDDOSBlockNonEssentialBots, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	case botAccessControlRuleID:
-		_, err := client.ConfigureWAFSecurityRule(
+		_, err := client.ConfigureWAFSecurityRule(ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -386,7 +386,7 @@ func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not reset Incapsula WAF Rule rule_id (%s) with block_bad_bots (%s) and challenge_suspected_bots (%s) on site_id (%d) %s\n", ruleID, botAccessControlBlockBadBotsDefaultAction, botAccessControlChallengeSuspectedBotsDefaultAction, d.Get("site_id").(int), err)
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -19,7 +19,7 @@ func resourceWaitingRoom() *schema.Resource {
 		UpdateContext: resourceWaitingRoomUpdate,
 		DeleteContext: resourceWaitingRoomDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(data.Id(), "/")
 				if len(idSlice) != 3 || idSlice[0] == "" || idSlice[1] == "" || idSlice[2] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected account_id/site_id/waiting_room_id", data.Id())
@@ -171,10 +171,10 @@ func resourceWaitingRoomCreate(ctx context.Context, data *schema.ResourceData, m
 		waitingRoom.ThresholdSettings.ConcurrentSessionsEnabled = true
 	}
 
-	waitingRoomDTOResponse, diags := client.CreateWaitingRoom(accountId, siteID, &waitingRoom)
-	if diags != nil && diags.HasError() {
+	waitingRoomDTOResponse, err := client.CreateWaitingRoom(ctx, accountId, siteID, &waitingRoom)
+	if err != nil {
 		log.Printf("[ERROR] Failed to create Waiting Room for Site ID %s", siteID)
-		return diags
+		return diag.FromErr(err)
 	} else if waitingRoomDTOResponse.Errors != nil {
 		log.Printf("[ERROR] Failed to create Waiting Room for Site ID %s: %s", siteID, waitingRoomDTOResponse.Errors[0].Detail)
 		return []diag.Diagnostic{diag.Diagnostic{
@@ -215,14 +215,13 @@ func resourceWaitingRoomRead(ctx context.Context, data *schema.ResourceData, m i
 		}}
 	}
 
-	waitingRoomDTOResponse, diags := client.ReadWaitingRoom(accountId, siteID, waitingRoomID)
-	if waitingRoomDTOResponse != nil && waitingRoomDTOResponse.Errors != nil && waitingRoomDTOResponse.Errors[0].Status == 404 {
-		data.SetId("")
-		return nil
-	}
-	if diags != nil && diags.HasError() {
+	waitingRoomDTOResponse, err := client.ReadWaitingRoom(ctx, accountId, siteID, waitingRoomID)
+	if err != nil {
+		if removeGoneResource(data, err, "waiting room") {
+			return nil
+		}
 		log.Printf("[ERROR] Failed to read Waiting Room %d for Site ID %s", waitingRoomID, siteID)
-		return diags
+		return diag.FromErr(err)
 	}
 
 	if len(waitingRoomDTOResponse.Data) == 0 {
@@ -306,10 +305,10 @@ func resourceWaitingRoomUpdate(ctx context.Context, data *schema.ResourceData, m
 		waitingRoom.ThresholdSettings.ConcurrentSessionsEnabled = true
 	}
 
-	_, diags = client.UpdateWaitingRoom(accountId, siteID, waitingRoomID, &waitingRoom)
-	if diags != nil && diags.HasError() {
+	_, err = client.UpdateWaitingRoom(ctx, accountId, siteID, waitingRoomID, &waitingRoom)
+	if err != nil {
 		log.Printf("[ERROR] Failed to update Waiting Room %d for Site ID %s", waitingRoomID, siteID)
-		return diags
+		return diag.FromErr(err)
 	}
 
 	diags = append(diags, resourceWaitingRoomRead(ctx, data, m)[:]...)
@@ -332,14 +331,10 @@ func resourceWaitingRoomDelete(ctx context.Context, data *schema.ResourceData, m
 		}}
 	}
 
-	waitingRoomDTOResponse, diags := client.DeleteWaitingRoom(accountId, siteID, waitingRoomID)
-	if waitingRoomDTOResponse != nil && waitingRoomDTOResponse.Errors != nil && waitingRoomDTOResponse.Errors[0].Status == 404 {
-		data.SetId("")
-		return nil
-	}
-	if diags != nil && diags.HasError() {
+	_, err = client.DeleteWaitingRoom(ctx, accountId, siteID, waitingRoomID)
+	if err != nil && !IsNotFound(err) {
 		log.Printf("[ERROR] Failed to update Waiting Room %d for Site ID %s", waitingRoomID, siteID)
-		return diags
+		return diag.FromErr(err)
 	}
 
 	data.SetId("")
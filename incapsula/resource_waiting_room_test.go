@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"regexp"
@@ -115,7 +116,7 @@ func testAccCheckWaitingRoomExists(waitingRoomDTOresponse *WaitingRoomDTO) resou
 
 		client := testAccProvider.Meta().(*Client)
 
-		response, _ := client.ReadWaitingRoom(accountId, siteID, waitingRoomID)
+		response, _ := client.ReadWaitingRoom(context.Background(), accountId, siteID, waitingRoomID)
 		if response == nil {
 			return fmt.Errorf("Failed to retrieve Waiting Room (id=%d)", waitingRoomID)
 		}
@@ -231,13 +232,13 @@ func testAccCheckWaitingRoomDestroy(state *terraform.State) error {
 
 		accountId := res.Primary.Attributes["account_id"]
 
-		waitingRoomDTOResponse, _ := client.ReadWaitingRoom(accountId, siteID, waitingRoomIdInt)
-		if waitingRoomDTOResponse == nil {
-			return fmt.Errorf("Failed to check Waiting Room status (id=%s)", waitingRoomID)
-		}
-		if waitingRoomDTOResponse.Errors[0].Status != 404 {
+		_, err = client.ReadWaitingRoom(context.Background(), accountId, siteID, waitingRoomIdInt)
+		if err == nil {
 			return fmt.Errorf("Incapsula Waiting Room with id %s still exists", waitingRoomID)
 		}
+		if !IsNotFound(err) {
+			return fmt.Errorf("Failed to check Waiting Room status (id=%s): %s", waitingRoomID, err)
+		}
 	}
 
 	return nil
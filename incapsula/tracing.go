@@ -0,0 +1,242 @@
+package incapsula
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/terraform-providers/terraform-provider-incapsula"
+
+// tracesFileEnv is the file the spans are written to with OTEL_TRACES_EXPORTER=file
+const tracesFileEnv = "INCAPSULA_OTEL_TRACES_FILE"
+const defaultTracesFile = "incapsula-traces.json"
+
+// Span attributes set on the provider spans
+const (
+	attributeResourceType = attribute.Key("incapsula.resource_type")
+	attributeResourceID   = attribute.Key("incapsula.resource_id")
+	attributeCRUD         = attribute.Key("incapsula.crud")
+	attributeSiteID       = attribute.Key("incapsula.site_id")
+	attributeOperation    = attribute.Key("incapsula.operation")
+	attributeRetryCount   = attribute.Key("incapsula.retry_count")
+)
+
+var (
+	tracingSetup   sync.Once
+	tracerProvider *sdktrace.TracerProvider
+)
+
+func tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// setupTracing installs the global tracer provider from the standard OTEL_* environment variables.
+// Tracing is off unless OTEL_TRACES_EXPORTER is set to "otlp", "console" or "file", or an OTLP endpoint
+// (OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT) is set.
+func setupTracing(ctx context.Context) {
+	tracingSetup.Do(func() {
+		provider, err := newTracerProviderFromEnv(ctx)
+		if err != nil {
+			log.Printf("[WARN] OpenTelemetry tracing is disabled: %s\n", err)
+			return
+		}
+		if provider == nil {
+			return
+		}
+		tracerProvider = provider
+		otel.SetTracerProvider(provider)
+	})
+}
+
+func newTracerProviderFromEnv(ctx context.Context) (*sdktrace.TracerProvider, error) {
+	exporterName := strings.ToLower(strings.TrimSpace(os.Getenv("OTEL_TRACES_EXPORTER")))
+	if exporterName == "" && (os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != "") {
+		exporterName = "otlp"
+	}
+	if exporterName == "" || exporterName == "none" {
+		return nil, nil
+	}
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch exporterName {
+	case "otlp":
+		protocol := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL")
+		if protocol == "" {
+			protocol = os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL")
+		}
+		switch protocol {
+		case "", "http/protobuf":
+			exporter, err = otlptracehttp.New(ctx)
+		case "grpc":
+			exporter, err = otlptracegrpc.New(ctx)
+		default:
+			return nil, fmt.Errorf("unsupported OTLP protocol %q, use http/protobuf or grpc", protocol)
+		}
+	case "console":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stderr))
+	case "file":
+		path := os.Getenv(tracesFileEnv)
+		if path == "" {
+			path = defaultTracesFile
+		}
+		var file *os.File
+		file, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err == nil {
+			exporter, err = stdouttrace.New(stdouttrace.WithWriter(file))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported traces exporter %q, use otlp, console, file or none", exporterName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error creating the %s traces exporter: %w", exporterName, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attribute.String("service.name", "terraform-provider-incapsula")))
+	if err != nil {
+		res = resource.Default()
+	}
+	// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES take precedence over the default service name
+	if envRes, err := resource.New(ctx, resource.WithFromEnv()); err == nil {
+		if merged, err := resource.Merge(res, envRes); err == nil {
+			res = merged
+		}
+	}
+
+	log.Printf("[INFO] OpenTelemetry tracing enabled with the %s exporter\n", exporterName)
+	return sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res)), nil
+}
+
+// flushTraces exports the spans ended so far. Terraform stops the provider process without notice,
+// so the spans are flushed at the end of every CRUD operation instead of at exit.
+func flushTraces(ctx context.Context) {
+	if tracerProvider == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := tracerProvider.ForceFlush(ctx); err != nil {
+		log.Printf("[WARN] Error exporting OpenTelemetry spans: %s\n", err)
+	}
+}
+
+// startRequestSpan starts the span of an API call, a child of the span of the CRUD operation making it
+func startRequestSpan(req *http.Request) (context.Context, trace.Span) {
+	attributes := []attribute.KeyValue{
+		attribute.String("http.request.method", req.Method),
+		attribute.String("url.path", req.URL.Path),
+		attribute.String("server.address", req.URL.Hostname()),
+		attributeOperation.String(req.Header.Get("x-tf-operation")),
+	}
+	if siteID := traceSiteID(req.Context()); siteID != "" {
+		attributes = append(attributes, attributeSiteID.String(siteID))
+	}
+
+	return tracer().Start(req.Context(), fmt.Sprintf("%s %s", req.Method, req.Header.Get("x-tf-operation")),
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+}
+
+// endRequestSpan records the outcome of an API call on its span and ends it
+func endRequestSpan(span trace.Span, resp *http.Response, err error, retries int) {
+	span.SetAttributes(attributeRetryCount.Int(retries))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else if resp != nil {
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		if resp.StatusCode >= 400 {
+			span.SetStatus(codes.Error, resp.Status)
+		}
+	}
+	span.End()
+}
+
+type traceSiteIDKey struct{}
+
+// traceSiteID returns the site ID of the resource whose CRUD operation started the request, if any
+func traceSiteID(ctx context.Context) string {
+	siteID, _ := ctx.Value(traceSiteIDKey{}).(string)
+	return siteID
+}
+
+// resourceSiteID returns the site the resource belongs to, or "" for resources that don't belong to a site
+func resourceSiteID(resourceType string, d *schema.ResourceData) string {
+	if siteID, ok := d.GetOk("site_id"); ok {
+		return fmt.Sprint(siteID)
+	}
+	if resourceType == "incapsula_site" || resourceType == "incapsula_site_v3" {
+		return d.Id()
+	}
+	return ""
+}
+
+// crudContextFunc is the signature shared by the CreateContext, ReadContext, UpdateContext and DeleteContext functions
+type crudContextFunc = func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+
+// traceCRUD wraps a CRUD function so that every call is traced as one span (e.g. "incapsula_site.read"),
+// the parent of the spans of the API calls made by the function
+func traceCRUD(resourceType string, crud string, fn crudContextFunc) crudContextFunc {
+	if fn == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		attributes := []attribute.KeyValue{attributeResourceType.String(resourceType), attributeCRUD.String(crud)}
+		if d.Id() != "" {
+			attributes = append(attributes, attributeResourceID.String(d.Id()))
+		}
+		siteID := resourceSiteID(resourceType, d)
+		if siteID != "" {
+			attributes = append(attributes, attributeSiteID.String(siteID))
+			ctx = context.WithValue(ctx, traceSiteIDKey{}, siteID)
+		}
+
+		ctx, span := tracer().Start(ctx, resourceType+"."+crud, trace.WithAttributes(attributes...))
+		diags := fn(ctx, d, m)
+		if d.Id() != "" {
+			span.SetAttributes(attributeResourceID.String(d.Id()))
+		}
+		if diags.HasError() {
+			for _, diagnostic := range diags {
+				if diagnostic.Severity == diag.Error {
+					span.SetStatus(codes.Error, diagnostic.Summary)
+					break
+				}
+			}
+		}
+		span.End()
+		flushTraces(ctx)
+
+		return diags
+	}
+}
+
+// traceResources wraps the CRUD functions of all the resources and data sources of the provider
+func traceResources(provider *schema.Provider) {
+	for resourceType, res := range provider.ResourcesMap {
+		res.CreateContext = traceCRUD(resourceType, "create", res.CreateContext)
+		res.ReadContext = traceCRUD(resourceType, "read", res.ReadContext)
+		res.UpdateContext = traceCRUD(resourceType, "update", res.UpdateContext)
+		res.DeleteContext = traceCRUD(resourceType, "delete", res.DeleteContext)
+	}
+	for dataSourceType, res := range provider.DataSourcesMap {
+		res.ReadContext = traceCRUD(dataSourceType, "read", res.ReadContext)
+	}
+}
@@ -0,0 +1,133 @@
+package incapsula
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestTraceCRUDAndRequestSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		attempts++
+		if attempts == 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLRev3: server.URL, BaseURLAPI: server.URL, Retry: &RetryPolicy{MaxAttempts: 2}}
+	client := NewClient(config)
+
+	readRule := traceCRUD("incapsula_incap_rule", "read", func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		resp, err := m.(*Client).DoJsonRequestWithHeaders(ctx, http.MethodGet, server.URL+"/sites/42/rules/7", nil, ReadIncapRule)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		resp.Body.Close()
+		return nil
+	})
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+		"site_id": {Type: schema.TypeString, Required: true},
+	}, map[string]interface{}{"site_id": "42"})
+	d.SetId("7")
+
+	if diags := readRule(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("Expected a CRUD span and an API call span, got %d spans", len(spans))
+	}
+	requestSpan, crudSpan := spans[0], spans[1]
+
+	if crudSpan.Name() != "incapsula_incap_rule.read" {
+		t.Errorf("Unexpected CRUD span name: %s", crudSpan.Name())
+	}
+	if requestSpan.Parent().SpanID() != crudSpan.SpanContext().SpanID() {
+		t.Errorf("Expected the API call span to be a child of the CRUD span")
+	}
+	if value, _ := spanAttribute(crudSpan, attributeResourceID); value.AsString() != "7" {
+		t.Errorf("Unexpected resource ID on the CRUD span: %v", value)
+	}
+
+	expected := map[attribute.Key]string{
+		attributeOperation:          ReadIncapRule,
+		attributeSiteID:             "42",
+		"http.response.status_code": "200",
+		attributeRetryCount:         "1",
+	}
+	for key, want := range expected {
+		value, ok := spanAttribute(requestSpan, key)
+		if !ok || value.Emit() != want {
+			t.Errorf("Expected attribute %s=%s on the API call span, got %q", key, want, value.Emit())
+		}
+	}
+}
+
+func TestTracerProviderFromEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+	t.Setenv("OTEL_TRACES_EXPORTER", "file")
+	t.Setenv(tracesFileEnv, path)
+	t.Setenv("OTEL_SERVICE_NAME", "incapsula-ci")
+
+	provider, err := newTracerProviderFromEnv(context.Background())
+	if err != nil || provider == nil {
+		t.Fatalf("Expected a tracer provider, got %v", err)
+	}
+	_, span := provider.Tracer(tracerName).Start(context.Background(), "incapsula_site.create")
+	span.End()
+	if err := provider.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Unexpected error shutting down the tracer provider: %s", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Error reading the traces file: %s", err)
+	}
+	if !strings.Contains(string(content), "incapsula_site.create") || !strings.Contains(string(content), "incapsula-ci") {
+		t.Errorf("Expected the span and service name in the traces file, got:\n%s", content)
+	}
+}
+
+func TestTracerProviderFromEnvDisabled(t *testing.T) {
+	t.Setenv("OTEL_TRACES_EXPORTER", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	if provider, err := newTracerProviderFromEnv(context.Background()); provider != nil || err != nil {
+		t.Errorf("Expected tracing to be disabled, got %v %v", provider, err)
+	}
+
+	t.Setenv("OTEL_TRACES_EXPORTER", "zipkin")
+	if _, err := newTracerProviderFromEnv(context.Background()); err == nil {
+		t.Errorf("Expected an error for an unsupported exporter")
+	}
+}